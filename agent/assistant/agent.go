@@ -202,6 +202,34 @@ func (ast *Assistant) Stream(ctx *context.Context, inputMessages []context.Messa
 		}
 	}
 
+	// ================================================
+	// Execute Orchestration (if configured)
+	// ================================================
+	// Parallel branches or classifier routing replace the LLM call
+	if ast.Orchestration != nil {
+		ctx.Logger.Phase("Orchestration")
+		orchestrationResponse, err := ast.executeOrchestration(ctx, inputMessages)
+		if err != nil {
+			finalStatus = context.ResumeStatusFailed
+			finalError = err
+			ast.traceAgentFail(agentNode, err)
+			ast.sendStreamEndOnError(ctx, streamHandler, streamStartTime, err)
+			return nil, err
+		}
+		ctx.Logger.PhaseComplete("Orchestration")
+
+		// For root stack, send stream_end and close output (same as delegation)
+		if ctx.Stack != nil && ctx.Stack.IsRoot() {
+			ast.sendAgentStreamEnd(ctx, streamHandler, streamStartTime, "completed", nil, nil)
+			if err := ctx.CloseOutput(); err != nil {
+				if trace, _ := ctx.Trace(); trace != nil {
+					trace.Error(i18n.Tr(ast.ID, ctx.Locale, "assistant.agent.stream.close_error"), map[string]any{"error": err.Error()})
+				}
+			}
+		}
+		return orchestrationResponse, nil
+	}
+
 	// ================================================
 	// Execute LLM Call Stream
 	// ================================================
@@ -226,6 +254,9 @@ func (ast *Assistant) Stream(ctx *context.Context, inputMessages []context.Messa
 			return nil, err
 		}
 
+		// Cap the generated tokens with the call-level limit (e.g. the orchestration branch budget)
+		ast.applyTokenLimit(completionOptions, opts)
+
 		// ================================================
 		// Execute Auto Search (if enabled)
 		// ================================================
@@ -311,6 +342,9 @@ func (ast *Assistant) Stream(ctx *context.Context, inputMessages []context.Messa
 				}
 			}
 
+			// Notify the client of each tool result
+			ast.sendToolResults(ctx, toolResults)
+
 			// If all successful, complete step and break out
 			if !hasErrors {
 				ast.CompleteStep(ctx, map[string]interface{}{
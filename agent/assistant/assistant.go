@@ -207,8 +207,9 @@ func (ast *Assistant) Clone() *Assistant {
 			CreatedAt:            ast.CreatedAt,
 			UpdatedAt:            ast.UpdatedAt,
 		},
-		HookScript: ast.HookScript,
-		openai:     ast.openai,
+		HookScript:    ast.HookScript,
+		Orchestration: ast.Orchestration,
+		openai:        ast.openai,
 	}
 
 	// Deep copy tags
@@ -449,6 +449,26 @@ func (ast *Assistant) applyAssistantOptions(options *context.CompletionOptions)
 	return nil
 }
 
+// applyTokenLimit caps the generated tokens with the call-level limit (opts.MaxTokens)
+// The lower of the limit and the configured max_tokens/max_completion_tokens is used
+func (ast *Assistant) applyTokenLimit(options *context.CompletionOptions, opts *context.Options) {
+	if options == nil || opts == nil || opts.MaxTokens <= 0 {
+		return
+	}
+
+	limit := opts.MaxTokens
+	if options.MaxCompletionTokens != nil {
+		if *options.MaxCompletionTokens > limit {
+			options.MaxCompletionTokens = &limit
+		}
+		return
+	}
+
+	if options.MaxTokens == nil || *options.MaxTokens > limit {
+		options.MaxTokens = &limit
+	}
+}
+
 // applyContextOptions applies options from ctx to CompletionOptions
 // ctx provides Route and Metadata for CUI context
 func (ast *Assistant) applyContextOptions(options *context.CompletionOptions, ctx *context.Context) {
@@ -7,6 +7,7 @@ import (
 	"github.com/yaoapp/yao/agent/content/text"
 	contentTypes "github.com/yaoapp/yao/agent/content/types"
 	"github.com/yaoapp/yao/agent/context"
+	"github.com/yaoapp/yao/agent/search"
 )
 
 // BuildContent processes messages through Vision function to convert extended content types
@@ -38,6 +39,7 @@ func (ast *Assistant) BuildContent(ctx *context.Context, messages []context.Mess
 		CompletionOptions: options,
 		Connector:         connector,
 		StreamOptions:     options.StreamOptions,
+		Search:            ast.Search,
 	}
 
 	contentMessages, referenceContext, err := content.ParseUserInput(ctx, messages, parseOptions)
@@ -47,6 +49,15 @@ func (ast *Assistant) BuildContent(ctx *context.Context, messages []context.Mess
 
 	// Inject reference context into messages
 	if referenceContext != nil {
+		// References from user content (fetched links) carry no formatted context yet
+		if referenceContext.XML == "" && len(referenceContext.References) > 0 {
+			referenceContext.XML = search.FormatReferencesXML(referenceContext.References)
+			if ast.Search != nil {
+				referenceContext.Prompt = search.GetCitationPrompt(ast.Search.Citation)
+			} else {
+				referenceContext.Prompt = search.GetCitationPrompt(nil)
+			}
+		}
 		contentMessages = ast.injectSearchContext(contentMessages, referenceContext)
 	}
 
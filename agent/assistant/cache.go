@@ -5,6 +5,9 @@ import (
 	"sync"
 )
 
+// CacheName the name of the loaded assistants cache in the cluster events
+const CacheName = "assistant"
+
 // Cache represents a thread-safe LRU cache for Assistant objects
 type Cache struct {
 	capacity int
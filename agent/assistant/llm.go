@@ -25,6 +25,12 @@ func (ast *Assistant) executeLLMStream(
 		return nil, err
 	}
 
+	// Enforce usage quotas before the completion starts
+	if err := ast.checkQuota(ctx, conn.ID()); err != nil {
+		ast.traceAgentFail(agentNode, err)
+		return nil, err
+	}
+
 	// Set capabilities in options if not already set
 	if completionOptions.Capabilities == nil && capabilities != nil {
 		completionOptions.Capabilities = capabilities
@@ -71,6 +77,9 @@ func (ast *Assistant) executeLLMStream(
 	// Mark LLM Request Complete
 	ast.traceLLMComplete(ctx, completionResponse)
 
+	// Meter the token usage and cost
+	ast.recordUsage(ctx, conn.ID(), completionResponse)
+
 	return completionResponse, nil
 }
 
@@ -93,6 +102,12 @@ func (ast *Assistant) executeLLMForToolRetry(
 		return nil, err
 	}
 
+	// Enforce usage quotas before the retry starts
+	if err := ast.checkQuota(ctx, conn.ID()); err != nil {
+		ast.traceAgentFail(agentNode, err)
+		return nil, err
+	}
+
 	// Set capabilities in options if not already set
 	if completionOptions.Capabilities == nil && capabilities != nil {
 		completionOptions.Capabilities = capabilities
@@ -131,5 +146,8 @@ func (ast *Assistant) executeLLMForToolRetry(
 	// Mark LLM Request Complete
 	ast.traceLLMComplete(ctx, completionResponse)
 
+	// Meter the token usage and cost
+	ast.recordUsage(ctx, conn.ID(), completionResponse)
+
 	return completionResponse, nil
 }
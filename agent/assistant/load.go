@@ -219,6 +219,26 @@ func GetCache() *Cache {
 	return loaded
 }
 
+// Invalidate removes the assistants from the loaded cache, they are loaded from the store again on the next use.
+// Empty ids remove all the assistants except the system agents.
+func Invalidate(ids []string) error {
+	if loaded == nil {
+		return nil
+	}
+
+	if len(ids) == 0 {
+		loaded.ClearExcept(func(id string) bool {
+			return strings.HasPrefix(id, "__yao.")
+		})
+		return nil
+	}
+
+	for _, id := range ids {
+		loaded.Remove(id)
+	}
+	return nil
+}
+
 // LoadStore create a new assistant from store
 func LoadStore(id string) (*Assistant, error) {
 
@@ -716,6 +736,20 @@ func loadMap(data map[string]interface{}) (*Assistant, error) {
 		assistant.Workflow = wf
 	}
 
+	// orchestration (parallel branches or classifier routing)
+	if orchestration, has := data["orchestration"]; has {
+		o, err := ToOrchestration(orchestration)
+		if err != nil {
+			return nil, err
+		}
+		if o != nil {
+			if err := o.Validate(assistant.ID); err != nil {
+				return nil, err
+			}
+		}
+		assistant.Orchestration = o
+	}
+
 	// uses (wrapper configurations for vision, audio, etc.)
 	// Merge hierarchy: global uses < assistant uses
 	if uses, has := data["uses"]; has {
@@ -990,6 +1024,41 @@ func mergeSearchConfig(base, override *searchTypes.Config) *searchTypes.Config {
 		}
 	}
 
+	// Merge Link config
+	if override.Link != nil {
+		if result.Link == nil {
+			result.Link = override.Link
+		} else {
+			merged := *result.Link
+			merged.Enabled = override.Link.Enabled
+			if len(override.Link.Allow) > 0 {
+				merged.Allow = override.Link.Allow
+			}
+			if len(override.Link.Deny) > 0 {
+				merged.Deny = override.Link.Deny
+			}
+			if override.Link.AllowPrivate {
+				merged.AllowPrivate = override.Link.AllowPrivate
+			}
+			if override.Link.MaxLinks > 0 {
+				merged.MaxLinks = override.Link.MaxLinks
+			}
+			if override.Link.MaxSize > 0 {
+				merged.MaxSize = override.Link.MaxSize
+			}
+			if override.Link.MaxChars > 0 {
+				merged.MaxChars = override.Link.MaxChars
+			}
+			if override.Link.Timeout > 0 {
+				merged.Timeout = override.Link.Timeout
+			}
+			if override.Link.UserAgent != "" {
+				merged.UserAgent = override.Link.UserAgent
+			}
+			result.Link = &merged
+		}
+	}
+
 	// Merge Options config
 	if override.Options != nil {
 		if result.Options == nil {
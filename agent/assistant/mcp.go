@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	jsoniter "github.com/json-iterator/go"
 	gouJson "github.com/yaoapp/gou/json"
 	"github.com/yaoapp/gou/mcp"
 	mcpTypes "github.com/yaoapp/gou/mcp/types"
 	agentContext "github.com/yaoapp/yao/agent/context"
+	"github.com/yaoapp/yao/agent/output/message"
 	storeTypes "github.com/yaoapp/yao/agent/store/types"
 	"github.com/yaoapp/yao/trace/types"
 )
@@ -215,6 +217,36 @@ func (ast *Assistant) buildMCPTools(ctx *agentContext.Context, createResponse *a
 	return allTools, samplesPrompt, nil
 }
 
+// sendToolResults sends a tool_result event for each executed tool call
+// Clients use it to pair results with the streamed tool_call messages
+func (ast *Assistant) sendToolResults(ctx *agentContext.Context, results []ToolCallResult) {
+	for _, result := range results {
+		content, _ := result.ParsedContent()
+		data := message.EventToolResultData{
+			ToolCallID: result.ToolCallID,
+			Name:       result.Name,
+			Content:    content,
+			Timestamp:  time.Now().UnixMilli(),
+		}
+		if result.Error != nil {
+			data.Error = result.Error.Error()
+			data.Content = nil
+		}
+
+		msg := &message.Message{
+			Type: message.TypeEvent,
+			Props: map[string]interface{}{
+				"event":   message.EventToolResult,
+				"message": "Tool call completed",
+				"data":    data,
+			},
+		}
+		if err := ctx.Send(msg); err != nil {
+			ctx.Logger.Warn("Failed to send tool result: %v", err)
+		}
+	}
+}
+
 // ToolCallResult represents the result of a tool call execution
 // executeToolCalls executes tool calls with intelligent strategy and trace logging:
 // - Single tool: use CallTool, single trace node
@@ -0,0 +1,521 @@
+package assistant
+
+import (
+	stdContext "context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/yaoapp/yao/agent/context"
+	"github.com/yaoapp/yao/agent/output/message"
+	"github.com/yaoapp/yao/trace/types"
+)
+
+// Orchestration modes
+const (
+	OrchestrationParallel = "parallel" // Fan out to several assistants, aggregate with the merge assistant
+	OrchestrationRoute    = "route"    // Classify the request and route it to one assistant
+)
+
+// Branch statuses
+const (
+	BranchCompleted  = "completed"
+	BranchFailed     = "failed"
+	BranchTimeout    = "timeout"
+	BranchOverBudget = "over_budget"
+)
+
+// streamAssistant calls an assistant of the orchestration, replaced by the tests
+var streamAssistant = func(target *Assistant, ctx *context.Context, messages []context.Message, opts *context.Options) (*context.Response, error) {
+	return target.Stream(ctx, messages, opts)
+}
+
+// Orchestration the declarative multi-agent orchestration of an assistant (package.yao orchestration block)
+// When set, the orchestration replaces the LLM call of the assistant
+type Orchestration struct {
+	Mode       string                `json:"mode"`                  // parallel or route
+	Branches   []OrchestrationBranch `json:"branches,omitempty"`    // parallel: assistants called concurrently
+	Merge      string                `json:"merge,omitempty"`       // parallel: judge/merge assistant aggregating the answers, empty sends each answer
+	MinSuccess int                   `json:"min_success,omitempty"` // parallel: minimum number of successful branches, default is 1
+	Classifier *OrchestrationBranch  `json:"classifier,omitempty"`  // route: assistant classifying the request
+	Routes     map[string]string     `json:"routes,omitempty"`      // route: label returned by the classifier -> assistant ID
+	Default    string                `json:"default,omitempty"`     // route: assistant used when no label matches
+	Timeout    int                   `json:"timeout,omitempty"`     // Default branch timeout in seconds, 0 means no timeout
+	MaxTokens  int                   `json:"max_tokens,omitempty"`  // Default branch token budget, 0 means no limit
+}
+
+// OrchestrationBranch a call to another assistant
+type OrchestrationBranch struct {
+	Name      string                 `json:"name,omitempty"`       // Branch name, default is the assistant ID
+	Assistant string                 `json:"assistant"`            // Assistant ID
+	Timeout   int                    `json:"timeout,omitempty"`    // Timeout in seconds, overrides Orchestration.Timeout
+	MaxTokens int                    `json:"max_tokens,omitempty"` // Token budget, overrides Orchestration.MaxTokens
+	Options   map[string]interface{} `json:"options,omitempty"`    // Call options (connector, mode, metadata, etc.)
+}
+
+// BranchResult the outcome of a branch
+type BranchResult struct {
+	Name      string             `json:"name"`
+	Assistant string             `json:"assistant"`
+	Status    string             `json:"status"` // completed, failed, timeout, over_budget
+	Content   string             `json:"content,omitempty"`
+	Usage     *message.UsageInfo `json:"usage,omitempty"`
+	Error     string             `json:"error,omitempty"`
+	Duration  int64              `json:"duration_ms"`
+}
+
+// ToOrchestration converts the orchestration block of package.yao
+func ToOrchestration(v interface{}) (*Orchestration, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	switch o := v.(type) {
+	case *Orchestration:
+		return o, nil
+
+	case Orchestration:
+		return &o, nil
+
+	default:
+		raw, err := jsoniter.Marshal(o)
+		if err != nil {
+			return nil, fmt.Errorf("orchestration format error: %s", err.Error())
+		}
+
+		var orchestration Orchestration
+		err = jsoniter.Unmarshal(raw, &orchestration)
+		if err != nil {
+			return nil, fmt.Errorf("orchestration format error: %s", err.Error())
+		}
+		return &orchestration, nil
+	}
+}
+
+// Validate validates the orchestration of the given assistant
+func (o *Orchestration) Validate(assistantID string) error {
+	check := func(id string) error {
+		if id == "" {
+			return fmt.Errorf("orchestration: assistant is required")
+		}
+		if id == assistantID {
+			return fmt.Errorf("orchestration: %s cannot call itself", assistantID)
+		}
+		return nil
+	}
+
+	switch o.Mode {
+	case OrchestrationParallel:
+		if len(o.Branches) == 0 {
+			return fmt.Errorf("orchestration: parallel mode requires branches")
+		}
+		names := map[string]bool{}
+		for _, branch := range o.Branches {
+			if err := check(branch.Assistant); err != nil {
+				return err
+			}
+			if names[branch.name()] {
+				return fmt.Errorf("orchestration: duplicate branch %s", branch.name())
+			}
+			names[branch.name()] = true
+		}
+		if o.MinSuccess > len(o.Branches) {
+			return fmt.Errorf("orchestration: min_success %d exceeds the number of branches", o.MinSuccess)
+		}
+		if o.Merge != "" {
+			return check(o.Merge)
+		}
+		return nil
+
+	case OrchestrationRoute:
+		if o.Classifier == nil {
+			return fmt.Errorf("orchestration: route mode requires a classifier")
+		}
+		if err := check(o.Classifier.Assistant); err != nil {
+			return err
+		}
+		if len(o.Routes) == 0 && o.Default == "" {
+			return fmt.Errorf("orchestration: route mode requires routes")
+		}
+		for _, id := range o.Routes {
+			if err := check(id); err != nil {
+				return err
+			}
+		}
+		if o.Default != "" {
+			return check(o.Default)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("orchestration: unsupported mode %q", o.Mode)
+}
+
+// Route returns the route label matching the classifier output, empty if none matches
+// The output can be a JSON object with a route, label or category field, or plain text
+func (o *Orchestration) Route(output string) string {
+	text := strings.TrimSpace(output)
+	if text == "" {
+		return ""
+	}
+
+	var data map[string]interface{}
+	if err := jsoniter.UnmarshalFromString(text, &data); err == nil {
+		// Next hook responses wrap the result in a data field
+		if inner, ok := data["data"].(map[string]interface{}); ok {
+			data = inner
+		}
+		for _, key := range []string{"route", "label", "category"} {
+			if value, ok := data[key].(string); ok {
+				return o.Route(value)
+			}
+		}
+		return ""
+	}
+
+	// Exact match first, then the longest label contained in the text
+	labels := make([]string, 0, len(o.Routes))
+	for label := range o.Routes {
+		if strings.EqualFold(label, strings.Trim(text, "\"'`. ")) {
+			return label
+		}
+		labels = append(labels, label)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		if len(labels[i]) != len(labels[j]) {
+			return len(labels[i]) > len(labels[j])
+		}
+		return labels[i] < labels[j]
+	})
+
+	lower := strings.ToLower(text)
+	for _, label := range labels {
+		if strings.Contains(lower, strings.ToLower(label)) {
+			return label
+		}
+	}
+	return ""
+}
+
+// name returns the branch name
+func (b *OrchestrationBranch) name() string {
+	if b.Name != "" {
+		return b.Name
+	}
+	return b.Assistant
+}
+
+// timeout returns the branch timeout
+func (o *Orchestration) timeout(branch *OrchestrationBranch) time.Duration {
+	if branch.Timeout > 0 {
+		return time.Duration(branch.Timeout) * time.Second
+	}
+	return time.Duration(o.Timeout) * time.Second
+}
+
+// maxTokens returns the branch token budget
+func (o *Orchestration) maxTokens(branch *OrchestrationBranch) int {
+	if branch.MaxTokens > 0 {
+		return branch.MaxTokens
+	}
+	return o.MaxTokens
+}
+
+// executeOrchestration runs the orchestration configured for the assistant
+func (ast *Assistant) executeOrchestration(ctx *context.Context, messages []context.Message) (*context.Response, error) {
+	switch ast.Orchestration.Mode {
+	case OrchestrationParallel:
+		return ast.executeParallel(ctx, messages)
+
+	case OrchestrationRoute:
+		return ast.executeRoute(ctx, messages)
+	}
+	return nil, fmt.Errorf("orchestration: unsupported mode %q", ast.Orchestration.Mode)
+}
+
+// executeParallel calls the branches concurrently, then aggregates the answers with the merge assistant
+func (ast *Assistant) executeParallel(ctx *context.Context, messages []context.Message) (*context.Response, error) {
+	o := ast.Orchestration
+	ctx.Logger.Debug("Orchestration: calling %d branches in parallel", len(o.Branches))
+
+	// Each branch appears as a parallel node in the trace tree
+	var nodes []types.Node
+	if trace, _ := ctx.Trace(); trace != nil {
+		inputs := make([]types.TraceParallelInput, 0, len(o.Branches))
+		for i := range o.Branches {
+			branch := &o.Branches[i]
+			inputs = append(inputs, types.TraceParallelInput{
+				Input: map[string]any{
+					"branch":     branch.name(),
+					"assistant":  branch.Assistant,
+					"timeout":    o.timeout(branch).Seconds(),
+					"max_tokens": o.maxTokens(branch),
+				},
+				Option: types.TraceNodeOption{
+					Label:       fmt.Sprintf("Branch: %s", branch.name()),
+					Type:        "agent_branch",
+					Icon:        "assistant",
+					Description: fmt.Sprintf("Calling assistant '%s'", branch.Assistant),
+				},
+			})
+		}
+
+		var err error
+		nodes, err = trace.Parallel(inputs)
+		if err != nil {
+			ctx.Logger.Debug("trace.Parallel() failed: %v", err)
+		}
+	}
+
+	results := make([]*BranchResult, len(o.Branches))
+	var wg sync.WaitGroup
+	for i := range o.Branches {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = ast.runBranch(ctx, &o.Branches[i], messages)
+			if i < len(nodes) && nodes[i] != nil {
+				traceBranch(nodes[i], results[i])
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	succeeded := make([]*BranchResult, 0, len(results))
+	for _, result := range results {
+		if result.Status == BranchCompleted {
+			succeeded = append(succeeded, result)
+			continue
+		}
+		ctx.Logger.Warn("Orchestration: branch %s %s: %s", result.Name, result.Status, result.Error)
+	}
+
+	minSuccess := o.MinSuccess
+	if minSuccess <= 0 {
+		minSuccess = 1
+	}
+	if len(succeeded) < minSuccess {
+		return nil, fmt.Errorf("orchestration: %d of %d branches succeeded, %d required", len(succeeded), len(results), minSuccess)
+	}
+
+	// No merge assistant: send each answer to the client
+	if o.Merge == "" {
+		for _, result := range succeeded {
+			ctx.Send(&message.Message{
+				Type:  message.TypeText,
+				Props: map[string]interface{}{"content": result.Content},
+			})
+		}
+		return &context.Response{
+			ContextID:   ctx.ID,
+			RequestID:   ctx.RequestID(),
+			TraceID:     ctx.TraceID(),
+			ChatID:      ctx.ChatID,
+			AssistantID: ast.ID,
+			Next:        map[string]interface{}{"branches": results},
+		}, nil
+	}
+
+	merge, err := Get(o.Merge)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load merge assistant '%s': %w", o.Merge, err)
+	}
+	return streamAssistant(merge, ctx, mergeMessages(messages, succeeded), &context.Options{})
+}
+
+// executeRoute asks the classifier for a route label, then delegates the request to the matching assistant
+func (ast *Assistant) executeRoute(ctx *context.Context, messages []context.Message) (*context.Response, error) {
+	o := ast.Orchestration
+
+	var node types.Node
+	if trace, _ := ctx.Trace(); trace != nil {
+		node, _ = trace.Add(map[string]any{
+			"classifier": o.Classifier.Assistant,
+			"routes":     o.Routes,
+		}, types.TraceNodeOption{
+			Label:       fmt.Sprintf("Route: %s", o.Classifier.name()),
+			Type:        "agent_route",
+			Icon:        "alt_route",
+			Description: fmt.Sprintf("Classifying the request with assistant '%s'", o.Classifier.Assistant),
+		})
+	}
+
+	result := ast.runBranch(ctx, o.Classifier, messages)
+	label := ""
+	if result.Status == BranchCompleted {
+		label = o.Route(result.Content)
+	} else {
+		ctx.Logger.Warn("Orchestration: classifier %s %s: %s", result.Name, result.Status, result.Error)
+	}
+
+	target := o.Routes[label]
+	if label == "" {
+		target = o.Default
+	}
+	if target == "" {
+		err := fmt.Errorf("orchestration: no route for the classifier output %q", result.Content)
+		if result.Error != "" {
+			err = fmt.Errorf("orchestration: classifier %s: %s", result.Status, result.Error)
+		}
+		if node != nil {
+			node.Fail(err)
+		}
+		return nil, err
+	}
+
+	if node != nil {
+		node.Complete(map[string]any{
+			"label":      label,
+			"assistant":  target,
+			"classifier": result,
+		})
+	}
+	ctx.Logger.Debug("Orchestration: routing to %s (label: %s)", target, label)
+
+	targetAssistant, err := Get(target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load routed assistant '%s': %w", target, err)
+	}
+	return streamAssistant(targetAssistant, ctx, messages, &context.Options{})
+}
+
+// runBranch calls a branch assistant on a forked context, enforcing its timeout and token budget
+// The branch output is not sent to the client and not saved to the chat history
+func (ast *Assistant) runBranch(ctx *context.Context, branch *OrchestrationBranch, messages []context.Message) *BranchResult {
+	result := &BranchResult{Name: branch.name(), Assistant: branch.Assistant}
+	start := time.Now()
+	defer func() { result.Duration = time.Since(start).Milliseconds() }()
+
+	target, err := Get(branch.Assistant)
+	if err != nil {
+		result.Status = BranchFailed
+		result.Error = err.Error()
+		return result
+	}
+
+	var parent stdContext.Context
+	var cancel stdContext.CancelFunc
+	if timeout := ast.Orchestration.timeout(branch); timeout > 0 {
+		parent, cancel = stdContext.WithTimeout(ctx.Context, timeout)
+	} else {
+		parent, cancel = stdContext.WithCancel(ctx.Context)
+	}
+	defer cancel()
+
+	opts := context.OptionsFromMap(branch.Options)
+	if opts.Skip == nil {
+		opts.Skip = &context.Skip{}
+	}
+	opts.Skip.History = true
+	opts.Skip.Output = true
+
+	// The budget caps the generated tokens, the total usage is checked when the branch is done
+	limit := ast.Orchestration.maxTokens(branch)
+	if limit > 0 && (opts.MaxTokens <= 0 || opts.MaxTokens > limit) {
+		opts.MaxTokens = limit
+	}
+
+	type outcome struct {
+		response *context.Response
+		err      error
+	}
+
+	done := make(chan outcome, 1)
+	branchCtx := ctx.Fork(parent)
+	go func() {
+		defer branchCtx.CloseFork()
+		defer func() {
+			if r := recover(); r != nil {
+				done <- outcome{err: fmt.Errorf("panic: %v", r)}
+			}
+		}()
+		response, err := streamAssistant(target, branchCtx, messages, opts)
+		done <- outcome{response: response, err: err}
+	}()
+
+	// Do not wait for a branch that ignores the cancellation
+	var out outcome
+	select {
+	case out = <-done:
+	case <-parent.Done():
+		out.err = parent.Err()
+	}
+
+	if out.err != nil {
+		result.Status = BranchFailed
+		if errors.Is(out.err, stdContext.DeadlineExceeded) || errors.Is(parent.Err(), stdContext.DeadlineExceeded) {
+			result.Status = BranchTimeout
+		}
+		result.Error = out.err.Error()
+		return result
+	}
+
+	result.Content = responseContent(out.response)
+	if out.response != nil && out.response.Completion != nil {
+		result.Usage = out.response.Completion.Usage
+	}
+
+	if limit > 0 && result.Usage != nil && result.Usage.TotalTokens > limit {
+		result.Status = BranchOverBudget
+		result.Error = fmt.Sprintf("used %d tokens, the budget is %d", result.Usage.TotalTokens, limit)
+		return result
+	}
+
+	result.Status = BranchCompleted
+	return result
+}
+
+// traceBranch completes or fails the trace node of a branch
+func traceBranch(node types.Node, result *BranchResult) {
+	if result.Status == BranchCompleted {
+		node.Complete(map[string]any{"result": result})
+		return
+	}
+	node.SetMetadata("status", result.Status)
+	node.Fail(fmt.Errorf("%s: %s", result.Status, result.Error))
+}
+
+// responseContent returns the answer of an agent response
+// The Next hook data takes precedence over the completion content
+func responseContent(response *context.Response) string {
+	if response == nil {
+		return ""
+	}
+
+	var value interface{}
+	if response.Next != nil {
+		value = response.Next
+	} else if response.Completion != nil {
+		value = response.Completion.Content
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+
+	raw, err := jsoniter.MarshalToString(value)
+	if err != nil {
+		return ""
+	}
+	return raw
+}
+
+// mergeMessages appends the branch answers to the conversation for the merge assistant
+func mergeMessages(messages []context.Message, results []*BranchResult) []context.Message {
+	var sb strings.Builder
+	sb.WriteString("Answers from the assistants consulted for this request, compare them and write the final answer:\n")
+	for _, result := range results {
+		sb.WriteString(fmt.Sprintf("\n<answer branch=%q assistant=%q>\n%s\n</answer>\n", result.Name, result.Assistant, result.Content))
+	}
+
+	merged := make([]context.Message, len(messages), len(messages)+1)
+	copy(merged, messages)
+	return append(merged, context.Message{Role: context.RoleUser, Content: sb.String()})
+}
@@ -0,0 +1,257 @@
+package assistant
+
+import (
+	stdContext "context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/yao/agent/context"
+	"github.com/yaoapp/yao/agent/output/message"
+	store "github.com/yaoapp/yao/agent/store/types"
+)
+
+func TestToOrchestration(t *testing.T) {
+	o, err := ToOrchestration(map[string]interface{}{
+		"mode":       "parallel",
+		"merge":      "tests.judge",
+		"timeout":    30,
+		"max_tokens": 4000,
+		"branches": []interface{}{
+			map[string]interface{}{"assistant": "tests.a"},
+			map[string]interface{}{"name": "fast", "assistant": "tests.b", "timeout": 5, "max_tokens": 500},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, o.Validate("tests.orchestrator"))
+
+	assert.Equal(t, OrchestrationParallel, o.Mode)
+	require.Len(t, o.Branches, 2)
+	assert.Equal(t, "tests.a", o.Branches[0].name())
+	assert.Equal(t, "fast", o.Branches[1].name())
+	assert.Equal(t, 30.0, o.timeout(&o.Branches[0]).Seconds())
+	assert.Equal(t, 5.0, o.timeout(&o.Branches[1]).Seconds())
+	assert.Equal(t, 4000, o.maxTokens(&o.Branches[0]))
+	assert.Equal(t, 500, o.maxTokens(&o.Branches[1]))
+
+	nothing, err := ToOrchestration(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, nothing)
+}
+
+func TestOrchestrationValidate(t *testing.T) {
+	cases := map[string]*Orchestration{
+		"unsupported mode": {Mode: "serial"},
+		"no branches":      {Mode: OrchestrationParallel},
+		"self branch":      {Mode: OrchestrationParallel, Branches: []OrchestrationBranch{{Assistant: "tests.self"}}},
+		"duplicate branch": {Mode: OrchestrationParallel, Branches: []OrchestrationBranch{{Assistant: "tests.a"}, {Assistant: "tests.a"}}},
+		"min success":      {Mode: OrchestrationParallel, MinSuccess: 2, Branches: []OrchestrationBranch{{Assistant: "tests.a"}}},
+		"self merge":       {Mode: OrchestrationParallel, Merge: "tests.self", Branches: []OrchestrationBranch{{Assistant: "tests.a"}}},
+		"no classifier":    {Mode: OrchestrationRoute, Routes: map[string]string{"billing": "tests.billing"}},
+		"no routes":        {Mode: OrchestrationRoute, Classifier: &OrchestrationBranch{Assistant: "tests.classifier"}},
+		"empty route":      {Mode: OrchestrationRoute, Classifier: &OrchestrationBranch{Assistant: "tests.classifier"}, Routes: map[string]string{"billing": ""}},
+	}
+
+	for name, o := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, o.Validate("tests.self"))
+		})
+	}
+
+	route := &Orchestration{
+		Mode:       OrchestrationRoute,
+		Classifier: &OrchestrationBranch{Assistant: "tests.classifier"},
+		Default:    "tests.general",
+	}
+	assert.NoError(t, route.Validate("tests.self"))
+}
+
+func TestOrchestrationRoute(t *testing.T) {
+	o := &Orchestration{Routes: map[string]string{
+		"billing":         "tests.billing",
+		"billing dispute": "tests.dispute",
+		"tech":            "tests.tech",
+	}}
+
+	assert.Equal(t, "billing", o.Route("billing"))
+	assert.Equal(t, "tech", o.Route(" \"Tech\". "))
+	assert.Equal(t, "billing dispute", o.Route("This is a billing dispute about an invoice"))
+	assert.Equal(t, "billing", o.Route(`{"route": "Billing", "confidence": 0.9}`))
+	assert.Equal(t, "tech", o.Route(`{"data": {"label": "tech"}}`))
+	assert.Equal(t, "", o.Route(`{"answer": "tech"}`))
+	assert.Equal(t, "", o.Route("sales"))
+	assert.Equal(t, "", o.Route(""))
+}
+
+func TestResponseContent(t *testing.T) {
+	assert.Equal(t, "", responseContent(nil))
+	assert.Equal(t, "hello", responseContent(&context.Response{Completion: &context.CompletionResponse{Content: "hello"}}))
+	assert.Equal(t, `{"route":"tech"}`, responseContent(&context.Response{
+		Next:       map[string]interface{}{"route": "tech"},
+		Completion: &context.CompletionResponse{Content: "ignored"},
+	}))
+}
+
+func TestMergeMessages(t *testing.T) {
+	messages := []context.Message{{Role: context.RoleUser, Content: "Which plan should I pick?"}}
+	merged := mergeMessages(messages, []*BranchResult{
+		{Name: "pricing", Assistant: "tests.pricing", Content: "Pick Pro."},
+		{Name: "support", Assistant: "tests.support", Content: "Pick Team."},
+	})
+
+	require.Len(t, merged, 2)
+	assert.Len(t, messages, 1)
+	content, ok := merged[1].Content.(string)
+	require.True(t, ok)
+	assert.Equal(t, context.RoleUser, merged[1].Role)
+	assert.True(t, strings.Contains(content, `<answer branch="pricing" assistant="tests.pricing">`))
+	assert.True(t, strings.Contains(content, "Pick Team."))
+}
+
+func TestRunBranch(t *testing.T) {
+	var mu sync.Mutex
+	budgets := map[string]int{}
+	stubAssistants(t, func(target *Assistant, ctx *context.Context, messages []context.Message, opts *context.Options) (*context.Response, error) {
+		mu.Lock()
+		budgets[target.ID] = opts.MaxTokens
+		mu.Unlock()
+		return branchResponse(ctx, target.ID)
+	}, "tests.fast", "tests.slow", "tests.costly", "tests.broken")
+
+	ast := &Assistant{
+		AssistantModel: store.AssistantModel{ID: "tests.orchestrator"},
+		Orchestration:  &Orchestration{Mode: OrchestrationParallel, MaxTokens: 1000},
+	}
+	ctx := newOrchestrationContext(t)
+	messages := []context.Message{{Role: context.RoleUser, Content: "Which plan should I pick?"}}
+
+	fast := ast.runBranch(ctx, &OrchestrationBranch{Assistant: "tests.fast", MaxTokens: 500}, messages)
+	assert.Equal(t, BranchCompleted, fast.Status, fast.Error)
+	assert.Equal(t, "answer of tests.fast", fast.Content)
+	assert.Equal(t, 100, fast.Usage.TotalTokens)
+
+	start := time.Now()
+	slow := ast.runBranch(ctx, &OrchestrationBranch{Assistant: "tests.slow", Timeout: 1}, messages)
+	assert.Equal(t, BranchTimeout, slow.Status)
+	assert.Less(t, time.Since(start), 3*time.Second)
+
+	costly := ast.runBranch(ctx, &OrchestrationBranch{Assistant: "tests.costly"}, messages)
+	assert.Equal(t, BranchOverBudget, costly.Status)
+	assert.Contains(t, costly.Error, "the budget is 1000")
+
+	broken := ast.runBranch(ctx, &OrchestrationBranch{Assistant: "tests.broken"}, messages)
+	assert.Equal(t, BranchFailed, broken.Status)
+	assert.Equal(t, "the connector is not available", broken.Error)
+
+	missing := ast.runBranch(ctx, &OrchestrationBranch{Assistant: "tests.missing"}, messages)
+	assert.Equal(t, BranchFailed, missing.Status)
+
+	// The budget is passed to the branch as the completion limit
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 500, budgets["tests.fast"])
+	assert.Equal(t, 1000, budgets["tests.costly"])
+}
+
+func TestExecuteParallel(t *testing.T) {
+	var mu sync.Mutex
+	var merged []context.Message
+	stubAssistants(t, func(target *Assistant, ctx *context.Context, messages []context.Message, opts *context.Options) (*context.Response, error) {
+		if target.ID == "tests.judge" {
+			mu.Lock()
+			merged = messages
+			mu.Unlock()
+			return &context.Response{AssistantID: target.ID, Completion: &context.CompletionResponse{Content: "Pick Pro."}}, nil
+		}
+		return branchResponse(ctx, target.ID)
+	}, "tests.fast", "tests.steady", "tests.slow", "tests.costly", "tests.broken", "tests.judge")
+
+	branches := []OrchestrationBranch{
+		{Assistant: "tests.fast"},
+		{Assistant: "tests.steady"},
+		{Assistant: "tests.slow", Timeout: 1},
+		{Assistant: "tests.costly"},
+		{Assistant: "tests.broken"},
+	}
+	messages := []context.Message{{Role: context.RoleUser, Content: "Which plan should I pick?"}}
+
+	// Two of five branches succeed
+	ast := &Assistant{
+		AssistantModel: store.AssistantModel{ID: "tests.orchestrator"},
+		Orchestration:  &Orchestration{Mode: OrchestrationParallel, Branches: branches, Merge: "tests.judge", MinSuccess: 2, MaxTokens: 1000},
+	}
+	response, err := ast.executeParallel(newOrchestrationContext(t), messages)
+	require.NoError(t, err)
+	assert.Equal(t, "Pick Pro.", response.Completion.Content)
+
+	mu.Lock()
+	require.Len(t, merged, 2)
+	content, ok := merged[1].Content.(string)
+	mu.Unlock()
+	require.True(t, ok)
+	assert.Contains(t, content, "answer of tests.fast")
+	assert.Contains(t, content, "answer of tests.steady")
+	assert.NotContains(t, content, "answer of tests.costly")
+
+	// Three successful branches are required
+	ast.Orchestration.MinSuccess = 3
+	_, err = ast.executeParallel(newOrchestrationContext(t), messages)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2 of 5 branches succeeded, 3 required")
+}
+
+// stubAssistants registers in-memory assistants and replaces the assistant calls of the orchestration
+func stubAssistants(t *testing.T, stream func(target *Assistant, ctx *context.Context, messages []context.Message, opts *context.Options) (*context.Response, error), ids ...string) {
+	origin := streamAssistant
+	streamAssistant = stream
+	for _, id := range ids {
+		loaded.Put(&Assistant{AssistantModel: store.AssistantModel{ID: id}})
+	}
+
+	t.Cleanup(func() {
+		streamAssistant = origin
+		for _, id := range ids {
+			loaded.Remove(id)
+		}
+	})
+}
+
+// branchResponse the response of the stub branch assistants
+// tests.slow waits for the cancellation, tests.costly exceeds the budget, tests.broken fails
+func branchResponse(ctx *context.Context, id string) (*context.Response, error) {
+	usage := &message.UsageInfo{PromptTokens: 60, CompletionTokens: 40, TotalTokens: 100}
+	switch id {
+	case "tests.slow":
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(5 * time.Second):
+		}
+
+	case "tests.costly":
+		usage = &message.UsageInfo{PromptTokens: 1200, CompletionTokens: 300, TotalTokens: 1500}
+
+	case "tests.broken":
+		return nil, fmt.Errorf("the connector is not available")
+	}
+
+	return &context.Response{
+		AssistantID: id,
+		Completion:  &context.CompletionResponse{Content: fmt.Sprintf("answer of %s", id), Usage: usage},
+	}, nil
+}
+
+// newOrchestrationContext creates a context without trace for the orchestration tests
+func newOrchestrationContext(t *testing.T) *context.Context {
+	root := context.New(stdContext.Background(), nil, "test-orchestration")
+	ctx := root.Fork(nil)
+	t.Cleanup(func() {
+		ctx.CloseFork()
+		root.Release()
+	})
+	return ctx
+}
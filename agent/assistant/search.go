@@ -889,6 +889,12 @@ func (ast *Assistant) buildSearchRequests(ctx *context.Context, query string, co
 	return requests, extractedKeywords
 }
 
+// The tags of the references XML, see search.FormatReferencesXML
+const (
+	referencesOpen  = "<references>\n"
+	referencesClose = "</references>"
+)
+
 // injectSearchContext injects search results into messages
 // Adds search context as a system message after existing system messages
 func (ast *Assistant) injectSearchContext(messages []context.Message, refCtx *searchTypes.ReferenceContext) []context.Message {
@@ -896,6 +902,23 @@ func (ast *Assistant) injectSearchContext(messages []context.Message, refCtx *se
 		return messages
 	}
 
+	// One references message per turn, the later references are merged into the injected one
+	for i, msg := range messages {
+		if msg.Role != "system" {
+			break
+		}
+		content, ok := msg.Content.(string)
+		if !ok || !strings.Contains(content, referencesOpen) || !strings.HasSuffix(content, referencesClose) || refCtx.XML == "" {
+			continue
+		}
+
+		refs := strings.TrimSuffix(strings.TrimPrefix(refCtx.XML, referencesOpen), referencesClose)
+		result := make([]context.Message, len(messages))
+		copy(result, messages)
+		result[i].Content = strings.TrimSuffix(content, referencesClose) + refs + referencesClose
+		return result
+	}
+
 	// Build the search context message
 	var contentParts []string
 
@@ -0,0 +1,43 @@
+package assistant
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/yao/agent/context"
+	"github.com/yaoapp/yao/agent/output/message"
+	"github.com/yaoapp/yao/agent/search"
+	searchTypes "github.com/yaoapp/yao/agent/search/types"
+)
+
+func TestInjectSearchContextMerge(t *testing.T) {
+	ast := &Assistant{}
+	ids := message.NewIDGenerator()
+	refContext := func(source searchTypes.SourceType, titles ...string) *searchTypes.ReferenceContext {
+		refs := []*searchTypes.Reference{}
+		for _, title := range titles {
+			refs = append(refs, &searchTypes.Reference{ID: ids.GenerateCitationID(), Type: searchTypes.SearchTypeWeb, Source: source, Title: title})
+		}
+		return &searchTypes.ReferenceContext{References: refs, XML: search.FormatReferencesXML(refs), Prompt: search.GetCitationPrompt(nil)}
+	}
+
+	messages := []context.Message{
+		{Role: "system", Content: "You are a helpful assistant."},
+		{Role: "user", Content: "Compare https://example.com with the search results"},
+	}
+
+	// The auto search results, then the fetched links of the same turn
+	messages = ast.injectSearchContext(messages, refContext(searchTypes.SourceAuto, "Result A", "Result B"))
+	messages = ast.injectSearchContext(messages, refContext(searchTypes.SourceUser, "Example"))
+
+	assert.Len(t, messages, 3)
+	content, ok := messages[1].Content.(string)
+	assert.True(t, ok)
+	assert.Equal(t, 1, strings.Count(content, search.DefaultCitationPrompt))
+	assert.Equal(t, 1, strings.Count(content, "<references>"))
+	assert.Contains(t, content, `<ref id="1"`)
+	assert.Contains(t, content, `<ref id="2"`)
+	assert.Contains(t, content, `<ref id="3" type="web" weight="0.0" source="user">`)
+	assert.True(t, strings.HasSuffix(content, "</references>"))
+}
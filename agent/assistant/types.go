@@ -32,6 +32,9 @@ type Assistant struct {
 	HookScript *hook.Script       `json:"-" yaml:"-"` // Hook Script (index.ts)
 	Scripts    map[string]*Script `json:"-" yaml:"-"` // Other scripts
 
+	// Orchestration (from package.yao orchestration block), replaces the LLM call when set
+	Orchestration *Orchestration `json:"orchestration,omitempty" yaml:"orchestration,omitempty"`
+
 	// Internal
 	// ===============================
 	openai *api.OpenAI // OpenAI API
@@ -0,0 +1,46 @@
+package assistant
+
+import (
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/agent/context"
+	"github.com/yaoapp/yao/agent/usage"
+)
+
+// usageSubject returns who the completion is metered for
+func (ast *Assistant) usageSubject(ctx *context.Context, connector string) usage.Subject {
+	subject := usage.Subject{AssistantID: ast.ID, Connector: connector}
+	if ctx.Authorized != nil {
+		subject.UserID = ctx.Authorized.UserID
+		subject.TeamID = ctx.Authorized.TeamID
+		subject.TenantID = ctx.Authorized.TenantID
+	}
+	return subject
+}
+
+// checkQuota returns an error if a usage quota of the user, team or assistant is exhausted
+func (ast *Assistant) checkQuota(ctx *context.Context, connector string) error {
+	if !usage.Enabled() {
+		return nil
+	}
+	return usage.Check(ast.usageSubject(ctx, connector))
+}
+
+// recordUsage stores the token usage and cost of a completion
+func (ast *Assistant) recordUsage(ctx *context.Context, connector string, completion *context.CompletionResponse) {
+	if !usage.Enabled() || completion == nil || completion.Usage == nil {
+		return
+	}
+
+	err := usage.Save(&usage.Record{
+		Subject:          ast.usageSubject(ctx, connector),
+		RequestID:        ctx.RequestID(),
+		ChatID:           ctx.ChatID,
+		Model:            completion.Model,
+		PromptTokens:     completion.Usage.PromptTokens,
+		CompletionTokens: completion.Usage.CompletionTokens,
+		TotalTokens:      completion.Usage.TotalTokens,
+	})
+	if err != nil {
+		log.Warn("[usage] failed to record usage of %s: %v", ast.ID, err)
+	}
+}
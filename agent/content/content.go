@@ -5,12 +5,16 @@ import (
 	"strings"
 
 	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/agent/content/csv"
 	"github.com/yaoapp/yao/agent/content/docx"
+	"github.com/yaoapp/yao/agent/content/html"
 	"github.com/yaoapp/yao/agent/content/image"
+	"github.com/yaoapp/yao/agent/content/link"
 	"github.com/yaoapp/yao/agent/content/pdf"
 	"github.com/yaoapp/yao/agent/content/pptx"
 	"github.com/yaoapp/yao/agent/content/text"
 	"github.com/yaoapp/yao/agent/content/types"
+	"github.com/yaoapp/yao/agent/content/xlsx"
 	agentContext "github.com/yaoapp/yao/agent/context"
 	searchTypes "github.com/yaoapp/yao/agent/search/types"
 )
@@ -19,7 +23,19 @@ import (
 func ParseUserInput(ctx *agentContext.Context, messages []agentContext.Message, options *types.Options) ([]agentContext.Message, *searchTypes.ReferenceContext, error) {
 	var referenceContext *searchTypes.ReferenceContext = nil
 	var parsedMessages []agentContext.Message = make([]agentContext.Message, 0)
-	for _, message := range messages {
+
+	// Only the links of the latest user message are fetched, history was answered already
+	lastUser := -1
+	if link.Enabled(options) {
+		for i := len(messages) - 1; i >= 0; i-- {
+			if messages[i].Role == agentContext.RoleUser {
+				lastUser = i
+				break
+			}
+		}
+	}
+
+	for i, message := range messages {
 		// Only process user messages (current or from history)
 		if message.Role != agentContext.RoleUser {
 			parsedMessages = append(parsedMessages, message)
@@ -42,6 +58,21 @@ func ParseUserInput(ctx *agentContext.Context, messages []agentContext.Message,
 			}
 			referenceContext.References = append(referenceContext.References, refs...)
 		}
+
+		// Fetch links pasted in the message
+		if i == lastUser {
+			linkRefs, err := link.New(options).Parse(ctx, message)
+			if err != nil {
+				log.Error("Failed to fetch links: %v", err)
+				continue
+			}
+			if len(linkRefs) > 0 {
+				if referenceContext == nil {
+					referenceContext = &searchTypes.ReferenceContext{}
+				}
+				referenceContext.References = append(referenceContext.References, linkRefs...)
+			}
+		}
 	}
 
 	return parsedMessages, referenceContext, nil
@@ -134,6 +165,15 @@ func parseFileContent(ctx *agentContext.Context, content agentContext.ContentPar
 	case strings.HasSuffix(filename, ".pptx"):
 		return pptx.New(options).Parse(ctx, content)
 
+	case xlsx.IsSupportedExtension(filename):
+		return xlsx.New(options).Parse(ctx, content)
+
+	case csv.IsSupportedExtension(filename):
+		return csv.New(options).Parse(ctx, content)
+
+	case html.IsSupportedExtension(filename):
+		return html.New(options).Parse(ctx, content)
+
 	case text.IsSupportedExtension(filename):
 		return text.New(options).Parse(ctx, content)
 	}
@@ -0,0 +1,210 @@
+package csv
+
+import (
+	"bytes"
+	stdcsv "encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yaoapp/yao/agent/content/table"
+	"github.com/yaoapp/yao/agent/content/types"
+	agentContext "github.com/yaoapp/yao/agent/context"
+	searchTypes "github.com/yaoapp/yao/agent/search/types"
+	"github.com/yaoapp/yao/attachment"
+)
+
+// CSV handles CSV/TSV content
+type CSV struct {
+	options *types.Options
+}
+
+// New creates a new CSV handler
+func New(options *types.Options) *CSV {
+	return &CSV{options: options}
+}
+
+// IsSupportedExtension checks if a file is a CSV/TSV file
+func IsSupportedExtension(filename string) bool {
+	ext := strings.ToLower(filepath.Ext(filename))
+	return ext == ".csv" || ext == ".tsv"
+}
+
+// Parse parses CSV content and returns a markdown table
+func (h *CSV) Parse(ctx *agentContext.Context, content agentContext.ContentPart) (agentContext.ContentPart, []*searchTypes.Reference, error) {
+	if content.File == nil || content.File.URL == "" {
+		return content, nil, fmt.Errorf("file content missing URL")
+	}
+
+	url := content.File.URL
+
+	// Check cache first
+	cachedText, found, err := h.readFromCache(ctx, url)
+	if err == nil && found {
+		return agentContext.ContentPart{
+			Type: agentContext.ContentText,
+			Text: cachedText,
+		}, nil, nil
+	}
+
+	// Read CSV file
+	data, err := h.readFile(ctx, url)
+	if err != nil {
+		return content, nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+
+	text, err := ToMarkdown(data, content.File.Filename, table.DefaultLimits)
+	if err != nil {
+		return content, nil, err
+	}
+
+	// Cache the result
+	if err := h.saveToCache(ctx, url, text); err != nil {
+		// Log warning but don't fail
+		fmt.Printf("Warning: failed to cache CSV text: %v\n", err)
+	}
+
+	return agentContext.ContentPart{
+		Type: agentContext.ContentText,
+		Text: text,
+	}, nil, nil
+}
+
+// ToMarkdown converts CSV data to a markdown table
+// The delimiter is detected from the first line (comma, semicolon, tab or pipe)
+func ToMarkdown(data []byte, filename string, limits table.Limits) (string, error) {
+	limits = limits.WithDefaults()
+
+	// Strip UTF-8 BOM (Excel exports)
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if !utf8.Valid(data) {
+		return "", fmt.Errorf("CSV is not valid UTF-8")
+	}
+
+	reader := stdcsv.NewReader(bytes.NewReader(data))
+	reader.Comma = DetectDelimiter(data, filename)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	rows := [][]string{}
+	total := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to parse CSV at line %d: %w", total+1, err)
+		}
+
+		total++
+		// Keep the header and MaxRows data rows, count the rest
+		if len(rows) <= limits.MaxRows {
+			rows = append(rows, record)
+		}
+	}
+
+	if total == 0 {
+		return "", fmt.Errorf("no rows found in CSV")
+	}
+
+	sheet := table.Sheet{Rows: rows, TotalRows: total}
+	return table.Markdown(filename, []table.Sheet{sheet}, limits), nil
+}
+
+// DetectDelimiter detects the delimiter from the first line
+func DetectDelimiter(data []byte, filename string) rune {
+	if strings.ToLower(filepath.Ext(filename)) == ".tsv" {
+		return '\t'
+	}
+
+	line := data
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		line = data[:i]
+	}
+
+	best, bestCount := ',', 0
+	for _, d := range []rune{',', ';', '\t', '|'} {
+		count := bytes.Count(line, []byte(string(d)))
+		if count > bestCount {
+			best, bestCount = d, count
+		}
+	}
+	return best
+}
+
+// readFile reads CSV content from various sources
+func (h *CSV) readFile(ctx *agentContext.Context, url string) ([]byte, error) {
+	if strings.HasPrefix(url, "__") {
+		return h.readFromUploader(ctx, url)
+	}
+
+	if strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://") {
+		return nil, fmt.Errorf("HTTP URL fetch not implemented yet: %s", url)
+	}
+
+	// Try to read as local file path
+	if _, err := os.Stat(url); err == nil {
+		return os.ReadFile(url)
+	}
+
+	return nil, fmt.Errorf("unsupported CSV source: %s", url)
+}
+
+// readFromUploader reads CSV content from file uploader
+func (h *CSV) readFromUploader(ctx *agentContext.Context, wrapper string) ([]byte, error) {
+	uploaderName, fileID, ok := attachment.Parse(wrapper)
+	if !ok {
+		return nil, fmt.Errorf("invalid uploader wrapper format: %s", wrapper)
+	}
+
+	manager, exists := attachment.Managers[uploaderName]
+	if !exists {
+		return nil, fmt.Errorf("uploader '%s' not found", uploaderName)
+	}
+
+	data, err := manager.Read(ctx.Context, fileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	return data, nil
+}
+
+// readFromCache reads cached text content for a CSV
+func (h *CSV) readFromCache(ctx *agentContext.Context, url string) (string, bool, error) {
+	uploaderName, fileID, isWrapper := attachment.Parse(url)
+	if !isWrapper {
+		return "", false, nil
+	}
+
+	manager, exists := attachment.Managers[uploaderName]
+	if !exists {
+		return "", false, nil
+	}
+
+	text, err := manager.GetText(ctx.Context, fileID, false)
+	if err == nil && text != "" {
+		return text, true, nil
+	}
+
+	return "", false, nil
+}
+
+// saveToCache saves processed text to cache
+func (h *CSV) saveToCache(ctx *agentContext.Context, url string, text string) error {
+	uploaderName, fileID, isWrapper := attachment.Parse(url)
+	if !isWrapper {
+		return nil
+	}
+
+	manager, exists := attachment.Managers[uploaderName]
+	if !exists {
+		return nil
+	}
+
+	return manager.SaveText(ctx.Context, fileID, text)
+}
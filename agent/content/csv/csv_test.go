@@ -0,0 +1,76 @@
+package csv_test
+
+import (
+	stdContext "context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/yao/agent/content/csv"
+	"github.com/yaoapp/yao/agent/content/table"
+	agentContext "github.com/yaoapp/yao/agent/context"
+)
+
+func TestToMarkdown(t *testing.T) {
+	data := []byte("\xef\xbb\xbfname;city\nalice;Paris\nbob;\"Berlin; DE\"\n")
+	md, err := csv.ToMarkdown(data, "people.csv", table.Limits{})
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join([]string{
+		"File: people.csv",
+		"",
+		"| name | city |",
+		"| --- | --- |",
+		"| alice | Paris |",
+		"| bob | Berlin; DE |",
+	}, "\n"), md)
+
+	_, err = csv.ToMarkdown([]byte{}, "empty.csv", table.Limits{})
+	assert.ErrorContains(t, err, "no rows")
+
+	_, err = csv.ToMarkdown([]byte{0xff, 0xfe, 'a'}, "binary.csv", table.Limits{})
+	assert.ErrorContains(t, err, "UTF-8")
+}
+
+func TestToMarkdownLimits(t *testing.T) {
+	lines := []string{"id,value"}
+	for i := 0; i < 10; i++ {
+		lines = append(lines, "1,x")
+	}
+
+	md, err := csv.ToMarkdown([]byte(strings.Join(lines, "\n")), "", table.Limits{MaxRows: 3})
+	require.NoError(t, err)
+	assert.Equal(t, 3, strings.Count(md, "| 1 | x |"))
+	assert.Contains(t, md, "> showing 3 of 10 rows")
+}
+
+func TestDetectDelimiter(t *testing.T) {
+	assert.Equal(t, ',', csv.DetectDelimiter([]byte("a,b,c\n1;2"), "data.csv"))
+	assert.Equal(t, ';', csv.DetectDelimiter([]byte("a;b;c\n1,2,3,4"), "data.csv"))
+	assert.Equal(t, '|', csv.DetectDelimiter([]byte("a|b|c"), "data.csv"))
+	assert.Equal(t, '\t', csv.DetectDelimiter([]byte("a,b,c"), "data.tsv"))
+}
+
+func TestParse(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "data.tsv")
+	require.NoError(t, os.WriteFile(file, []byte("name\tage\nalice\t30\n"), 0644))
+
+	assert.True(t, csv.IsSupportedExtension("DATA.TSV"))
+	assert.False(t, csv.IsSupportedExtension("data.xlsx"))
+
+	ctx := agentContext.New(stdContext.Background(), nil, "test-chat")
+	handler := csv.New(nil)
+	part, refs, err := handler.Parse(ctx, agentContext.ContentPart{
+		Type: agentContext.ContentFile,
+		File: &agentContext.FileAttachment{URL: file, Filename: "data.tsv"},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, refs)
+	assert.Equal(t, agentContext.ContentText, part.Type)
+	assert.Contains(t, part.Text, "| alice | 30 |")
+
+	_, _, err = handler.Parse(ctx, agentContext.ContentPart{Type: agentContext.ContentFile})
+	assert.Error(t, err)
+}
@@ -0,0 +1,443 @@
+package html
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// Document is the markdown extracted from an HTML page
+type Document struct {
+	Title       string
+	Description string
+	Markdown    string
+}
+
+// skipTags elements that never contain readable content
+var skipTags = map[string]bool{
+	"script": true, "style": true, "noscript": true, "template": true,
+	"svg": true, "canvas": true, "iframe": true, "form": true, "button": true,
+	"input": true, "select": true, "textarea": true, "head": true,
+}
+
+// chromeTags page chrome removed when a main content element is not found
+var chromeTags = []string{"nav", "header", "footer", "aside"}
+
+var (
+	reBlankLines = regexp.MustCompile(`\n{3,}`)
+	reSpaces     = regexp.MustCompile(`[ \t\r\n\f]+`)
+)
+
+// ToMarkdown extracts the readable content of an HTML page as markdown
+// baseURL is used to resolve relative links, it can be empty
+func ToMarkdown(data []byte, baseURL string) (*Document, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	result := &Document{
+		Title: strings.TrimSpace(doc.Find("title").First().Text()),
+	}
+	if desc, ok := doc.Find(`meta[name="description"]`).Attr("content"); ok {
+		result.Description = strings.TrimSpace(desc)
+	}
+	if result.Title == "" {
+		if title, ok := doc.Find(`meta[property="og:title"]`).Attr("content"); ok {
+			result.Title = strings.TrimSpace(title)
+		}
+	}
+
+	// Prefer the main content element, otherwise the body without page chrome
+	root := doc.Find("main, article, [role=main]").First()
+	if root.Length() == 0 {
+		root = doc.Find("body").First()
+		if root.Length() == 0 {
+			root = doc.Selection
+		}
+		for _, tag := range chromeTags {
+			root.Find(tag).Remove()
+		}
+	}
+
+	var base *url.URL
+	if baseURL != "" {
+		base, _ = url.Parse(baseURL)
+	}
+
+	c := &converter{base: base}
+	for _, node := range root.Nodes {
+		c.children(node)
+	}
+
+	md := reBlankLines.ReplaceAllString(c.sb.String(), "\n\n")
+	result.Markdown = strings.TrimSpace(md)
+	return result, nil
+}
+
+// converter walks the HTML tree and writes markdown
+type converter struct {
+	sb    strings.Builder
+	base  *url.URL
+	lists []listState
+}
+
+type listState struct {
+	ordered bool
+	index   int
+}
+
+func (c *converter) children(n *html.Node) {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		c.node(child)
+	}
+}
+
+func (c *converter) node(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		c.text(n.Data)
+		return
+	case html.ElementNode:
+	default:
+		c.children(n)
+		return
+	}
+
+	tag := n.Data
+	if skipTags[tag] || attr(n, "hidden") != "" || attr(n, "aria-hidden") == "true" {
+		return
+	}
+
+	switch tag {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		level := int(tag[1] - '0')
+		c.block()
+		c.sb.WriteString(strings.Repeat("#", level) + " ")
+		c.inline(n)
+		c.block()
+
+	case "p", "div", "section", "figure", "figcaption", "dl", "dt", "dd", "address":
+		c.block()
+		c.children(n)
+		c.block()
+
+	case "br":
+		c.sb.WriteString("  \n")
+
+	case "hr":
+		c.block()
+		c.sb.WriteString("---")
+		c.block()
+
+	case "strong", "b":
+		c.wrap(n, "**")
+
+	case "em", "i":
+		c.wrap(n, "_")
+
+	case "del", "s":
+		c.wrap(n, "~~")
+
+	case "code":
+		text := strings.TrimSpace(nodeText(n))
+		if text != "" {
+			c.space()
+			c.sb.WriteString("`" + text + "`")
+		}
+
+	case "pre":
+		c.block()
+		lang := codeLanguage(n)
+		c.sb.WriteString("```" + lang + "\n")
+		c.sb.WriteString(strings.TrimRight(nodeText(n), "\n"))
+		c.sb.WriteString("\n```")
+		c.block()
+
+	case "blockquote":
+		c.block()
+		inner := &converter{base: c.base}
+		inner.children(n)
+		for _, line := range strings.Split(strings.TrimSpace(inner.sb.String()), "\n") {
+			c.sb.WriteString("> " + line + "\n")
+		}
+		c.block()
+
+	case "ul", "ol":
+		c.block()
+		c.lists = append(c.lists, listState{ordered: tag == "ol"})
+		c.children(n)
+		c.lists = c.lists[:len(c.lists)-1]
+		c.block()
+
+	case "li":
+		c.listItem(n)
+
+	case "a":
+		c.link(n)
+
+	case "img":
+		alt := strings.TrimSpace(attr(n, "alt"))
+		src := c.resolve(attr(n, "src"))
+		if alt != "" && src != "" && !strings.HasPrefix(src, "data:") {
+			c.space()
+			c.sb.WriteString(fmt.Sprintf("![%s](%s)", alt, src))
+		}
+
+	case "table":
+		c.block()
+		c.table(n)
+		c.block()
+
+	default:
+		c.children(n)
+	}
+}
+
+// text writes a text node, collapsing whitespace
+func (c *converter) text(data string) {
+	text := reSpaces.ReplaceAllString(data, " ")
+	if strings.TrimSpace(text) == "" {
+		if text != "" {
+			c.space()
+		}
+		return
+	}
+	if strings.HasPrefix(text, " ") {
+		c.space()
+		text = strings.TrimLeft(text, " ")
+	}
+	c.sb.WriteString(text)
+}
+
+// inline writes the children of n on a single line
+func (c *converter) inline(n *html.Node) {
+	inner := &converter{base: c.base}
+	inner.children(n)
+	c.sb.WriteString(strings.Join(strings.Fields(inner.sb.String()), " "))
+}
+
+// wrap writes the children of n between markers
+func (c *converter) wrap(n *html.Node, marker string) {
+	inner := &converter{base: c.base}
+	inner.children(n)
+	text := strings.TrimSpace(inner.sb.String())
+	if text == "" {
+		return
+	}
+	c.space()
+	c.sb.WriteString(marker + text + marker)
+}
+
+// link writes an anchor as a markdown link
+func (c *converter) link(n *html.Node) {
+	inner := &converter{base: c.base}
+	inner.children(n)
+	text := strings.Join(strings.Fields(inner.sb.String()), " ")
+	href := c.resolve(attr(n, "href"))
+
+	if text == "" {
+		return
+	}
+	c.space()
+	if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(strings.ToLower(href), "javascript:") {
+		c.sb.WriteString(text)
+		return
+	}
+	c.sb.WriteString(fmt.Sprintf("[%s](%s)", text, href))
+}
+
+// listItem writes a list item with its marker and indentation
+func (c *converter) listItem(n *html.Node) {
+	depth := len(c.lists)
+	marker := "-"
+	if depth > 0 {
+		state := &c.lists[depth-1]
+		state.index++
+		if state.ordered {
+			marker = fmt.Sprintf("%d.", state.index)
+		}
+	}
+
+	indent := ""
+	if depth > 1 {
+		indent = strings.Repeat("  ", depth-1)
+	}
+
+	c.newline()
+	inner := &converter{base: c.base, lists: c.lists}
+	inner.children(n)
+	lines := strings.Split(strings.TrimSpace(reBlankLines.ReplaceAllString(inner.sb.String(), "\n")), "\n")
+	c.sb.WriteString(indent + marker + " " + strings.TrimSpace(lines[0]) + "\n")
+	for _, line := range lines[1:] {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		c.sb.WriteString(line + "\n")
+	}
+}
+
+// table writes an HTML table as a markdown table
+func (c *converter) table(n *html.Node) {
+	rows := [][]string{}
+	headerRow := false
+	walk(n, func(tr *html.Node) bool {
+		if tr.Type != html.ElementNode || tr.Data != "tr" {
+			return true
+		}
+		row := []string{}
+		for cell := tr.FirstChild; cell != nil; cell = cell.NextSibling {
+			if cell.Type != html.ElementNode || (cell.Data != "td" && cell.Data != "th") {
+				continue
+			}
+			if len(rows) == 0 && cell.Data == "th" {
+				headerRow = true
+			}
+			inner := &converter{base: c.base}
+			inner.children(cell)
+			text := strings.Join(strings.Fields(inner.sb.String()), " ")
+			row = append(row, strings.ReplaceAll(text, "|", "\\|"))
+		}
+		if len(row) > 0 {
+			rows = append(rows, row)
+		}
+		return false
+	})
+
+	if len(rows) == 0 {
+		return
+	}
+
+	cols := 0
+	for _, row := range rows {
+		if len(row) > cols {
+			cols = len(row)
+		}
+	}
+
+	writeRow := func(row []string) {
+		c.sb.WriteString("|")
+		for i := 0; i < cols; i++ {
+			cell := ""
+			if i < len(row) {
+				cell = row[i]
+			}
+			c.sb.WriteString(" " + cell + " |")
+		}
+		c.sb.WriteString("\n")
+	}
+
+	// Markdown tables need a header, use an empty one if the table has none
+	data := rows
+	if headerRow {
+		writeRow(rows[0])
+		data = rows[1:]
+	} else {
+		writeRow(make([]string, cols))
+	}
+	c.sb.WriteString("|" + strings.Repeat(" --- |", cols) + "\n")
+	for _, row := range data {
+		writeRow(row)
+	}
+}
+
+// block ends the current block with a blank line
+func (c *converter) block() {
+	s := c.sb.String()
+	if s == "" || strings.HasSuffix(s, "\n\n") {
+		return
+	}
+	if strings.HasSuffix(s, "\n") {
+		c.sb.WriteString("\n")
+		return
+	}
+	c.sb.WriteString("\n\n")
+}
+
+// newline ends the current line
+func (c *converter) newline() {
+	s := c.sb.String()
+	if s == "" || strings.HasSuffix(s, "\n") {
+		return
+	}
+	c.sb.WriteString("\n")
+}
+
+// space writes a separating space if the previous character is not a space
+func (c *converter) space() {
+	s := c.sb.String()
+	if s == "" || strings.HasSuffix(s, " ") || strings.HasSuffix(s, "\n") {
+		return
+	}
+	c.sb.WriteString(" ")
+}
+
+// resolve resolves a link against the base URL
+func (c *converter) resolve(href string) string {
+	href = strings.TrimSpace(href)
+	if href == "" || c.base == nil || strings.HasPrefix(href, "#") {
+		return href
+	}
+	ref, err := url.Parse(href)
+	if err != nil {
+		return href
+	}
+	return c.base.ResolveReference(ref).String()
+}
+
+// attr returns the value of an attribute
+func attr(n *html.Node, name string) string {
+	for _, a := range n.Attr {
+		if a.Key == name {
+			if a.Val == "" {
+				return name
+			}
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// nodeText returns the raw text of a node
+func nodeText(n *html.Node) string {
+	var sb strings.Builder
+	walk(n, func(child *html.Node) bool {
+		if child.Type == html.TextNode {
+			sb.WriteString(child.Data)
+		}
+		return true
+	})
+	return sb.String()
+}
+
+// codeLanguage returns the language hint of a pre block (class="language-go")
+func codeLanguage(n *html.Node) string {
+	nodes := []*html.Node{n}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode {
+			nodes = append(nodes, child)
+		}
+	}
+	for _, node := range nodes {
+		for _, class := range strings.Fields(attr(node, "class")) {
+			if strings.HasPrefix(class, "language-") {
+				return strings.TrimPrefix(class, "language-")
+			}
+		}
+	}
+	return ""
+}
+
+// walk visits the descendants of n, fn returns false to skip the children of a node
+func walk(n *html.Node, fn func(*html.Node) bool) {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if fn(child) {
+			walk(child, fn)
+		}
+	}
+}
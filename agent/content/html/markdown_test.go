@@ -0,0 +1,59 @@
+package html
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToMarkdown(t *testing.T) {
+	page := `<!DOCTYPE html>
+<html>
+<head>
+	<title>Release Notes</title>
+	<meta name="description" content="What's new">
+	<style>body { color: red }</style>
+</head>
+<body>
+	<nav><a href="/">Home</a></nav>
+	<h1>Version <em>2.0</em></h1>
+	<p>This release adds <strong>tables</strong> and
+	   <a href="/docs/tables">documentation</a>.</p>
+	<ul>
+		<li>First</li>
+		<li>Second
+			<ol><li>Nested</li></ol>
+		</li>
+	</ul>
+	<pre><code class="language-go">fmt.Println("hi")</code></pre>
+	<table>
+		<tr><th>Name</th><th>Price</th></tr>
+		<tr><td>A|B</td><td>10</td></tr>
+	</table>
+	<script>alert(1)</script>
+	<footer>Copyright</footer>
+</body>
+</html>`
+
+	doc, err := ToMarkdown([]byte(page), "https://example.com/releases/")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Release Notes", doc.Title)
+	assert.Equal(t, "What's new", doc.Description)
+	assert.Contains(t, doc.Markdown, "# Version _2.0_")
+	assert.Contains(t, doc.Markdown, "This release adds **tables** and [documentation](https://example.com/docs/tables).")
+	assert.Contains(t, doc.Markdown, "- First\n- Second\n  1. Nested")
+	assert.Contains(t, doc.Markdown, "```go\nfmt.Println(\"hi\")\n```")
+	assert.Contains(t, doc.Markdown, "| Name | Price |\n| --- | --- |\n| A\\|B | 10 |")
+	assert.NotContains(t, doc.Markdown, "alert")
+	assert.NotContains(t, doc.Markdown, "Copyright")
+	assert.NotContains(t, doc.Markdown, "Home")
+}
+
+func TestToMarkdownMainContent(t *testing.T) {
+	page := `<html><body><div>Sidebar</div><main><h2>Title</h2><p>Body</p></main></body></html>`
+	doc, err := ToMarkdown([]byte(page), "")
+	require.NoError(t, err)
+	assert.Equal(t, "## Title\n\nBody", doc.Markdown)
+}
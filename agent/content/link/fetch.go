@@ -0,0 +1,265 @@
+package link
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/yaoapp/yao/agent/content/csv"
+	"github.com/yaoapp/yao/agent/content/html"
+	"github.com/yaoapp/yao/agent/content/table"
+	searchTypes "github.com/yaoapp/yao/agent/search/types"
+)
+
+// Default fetch limits
+const (
+	DefaultMaxLinks  = 3
+	DefaultMaxSize   = 2 * 1024 * 1024
+	DefaultMaxChars  = 20000
+	DefaultTimeout   = 10
+	DefaultUserAgent = "Yao-Agent/1.0"
+	maxRedirects     = 5
+)
+
+// Page is a fetched link converted to text
+type Page struct {
+	URL         string // Final URL after redirects
+	Title       string
+	Description string
+	ContentType string
+	Content     string // Markdown or plain text
+	Truncated   bool
+}
+
+// Fetcher fetches links under the link configuration
+type Fetcher struct {
+	config *searchTypes.LinkConfig
+	client *http.Client
+}
+
+// NewFetcher creates a new fetcher, nil config uses the defaults
+func NewFetcher(config *searchTypes.LinkConfig) *Fetcher {
+	cfg := withDefaults(config)
+	f := &Fetcher{config: cfg}
+
+	dialer := &net.Dialer{Timeout: time.Duration(cfg.Timeout) * time.Second}
+	if !cfg.AllowPrivate {
+		// Check the resolved address so DNS names pointing to internal hosts are rejected too
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip != nil && isPrivateIP(ip) {
+				return fmt.Errorf("address %s is not allowed", host)
+			}
+			return nil
+		}
+	}
+
+	transport := &http.Transport{
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: time.Duration(cfg.Timeout) * time.Second,
+	}
+
+	// A proxy dials the target itself, the address check above would only see the proxy,
+	// so the environment proxy is used only when private addresses are allowed
+	if cfg.AllowPrivate {
+		transport.Proxy = http.ProxyFromEnvironment
+	}
+
+	f.client = &http.Client{
+		Timeout:   time.Duration(cfg.Timeout) * time.Second,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("too many redirects")
+			}
+			return f.Allowed(req.URL.String())
+		},
+	}
+	return f
+}
+
+// withDefaults returns a copy of the config with defaults applied
+func withDefaults(config *searchTypes.LinkConfig) *searchTypes.LinkConfig {
+	cfg := searchTypes.LinkConfig{}
+	if config != nil {
+		cfg = *config
+	}
+	if cfg.MaxLinks <= 0 {
+		cfg.MaxLinks = DefaultMaxLinks
+	}
+	if cfg.MaxSize <= 0 {
+		cfg.MaxSize = DefaultMaxSize
+	}
+	if cfg.MaxChars <= 0 {
+		cfg.MaxChars = DefaultMaxChars
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = DefaultUserAgent
+	}
+	return &cfg
+}
+
+// Allowed checks a URL against the scheme, deny and allow lists
+func (f *Fetcher) Allowed(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %s", rawURL)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("scheme %s is not allowed", u.Scheme)
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host == "" {
+		return fmt.Errorf("invalid URL: %s", rawURL)
+	}
+
+	for _, pattern := range f.config.Deny {
+		if matchHost(pattern, host) {
+			return fmt.Errorf("host %s is denied", host)
+		}
+	}
+
+	if len(f.config.Allow) > 0 {
+		allowed := false
+		for _, pattern := range f.config.Allow {
+			if matchHost(pattern, host) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("host %s is not in the allow list", host)
+		}
+	}
+
+	if !f.config.AllowPrivate {
+		if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+			return fmt.Errorf("host %s is not allowed", host)
+		}
+		if ip := net.ParseIP(host); ip != nil && isPrivateIP(ip) {
+			return fmt.Errorf("address %s is not allowed", host)
+		}
+	}
+
+	return nil
+}
+
+// Fetch fetches a URL and converts the response to text
+func (f *Fetcher) Fetch(ctx context.Context, rawURL string) (*Page, error) {
+	if err := f.Allowed(rawURL); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", f.config.UserAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,text/plain,text/markdown,application/json,text/csv;q=0.9,*/*;q=0.5")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("failed to fetch %s: HTTP %d", rawURL, resp.StatusCode)
+	}
+
+	if resp.ContentLength > f.config.MaxSize {
+		return nil, fmt.Errorf("response of %s is too large (%d bytes)", rawURL, resp.ContentLength)
+	}
+
+	// Read one extra byte to detect oversized bodies without a content length
+	data, err := io.ReadAll(io.LimitReader(resp.Body, f.config.MaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", rawURL, err)
+	}
+	if int64(len(data)) > f.config.MaxSize {
+		return nil, fmt.Errorf("response of %s is too large (more than %d bytes)", rawURL, f.config.MaxSize)
+	}
+
+	finalURL := resp.Request.URL.String()
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType == "" {
+		mediaType = http.DetectContentType(data)
+		mediaType, _, _ = mime.ParseMediaType(mediaType)
+	}
+
+	page := &Page{URL: finalURL, ContentType: mediaType}
+	switch {
+	case mediaType == "text/html" || mediaType == "application/xhtml+xml":
+		doc, err := html.ToMarkdown(data, finalURL)
+		if err != nil {
+			return nil, err
+		}
+		page.Title = doc.Title
+		page.Description = doc.Description
+		page.Content = doc.Markdown
+
+	case mediaType == "text/csv":
+		text, err := csv.ToMarkdown(data, path.Base(resp.Request.URL.Path), table.DefaultLimits)
+		if err != nil {
+			return nil, err
+		}
+		page.Content = text
+
+	case strings.HasPrefix(mediaType, "text/") || mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		page.Content = string(data)
+
+	default:
+		return nil, fmt.Errorf("unsupported content type %s for %s", mediaType, rawURL)
+	}
+
+	if page.Title == "" {
+		page.Title = finalURL
+	}
+
+	// Cut to the character limit
+	runes := []rune(page.Content)
+	if len(runes) > f.config.MaxChars {
+		page.Content = string(runes[:f.config.MaxChars])
+		page.Truncated = true
+	}
+
+	return page, nil
+}
+
+// matchHost matches a host against a pattern: "*", "example.com" or "*.example.com"
+// "example.com" also matches its subdomains
+func matchHost(pattern, host string) bool {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	switch {
+	case pattern == "":
+		return false
+	case pattern == "*":
+		return true
+	case strings.HasPrefix(pattern, "*."):
+		return strings.HasSuffix(host, pattern[1:])
+	default:
+		return host == pattern || strings.HasSuffix(host, "."+pattern)
+	}
+}
+
+// isPrivateIP checks if an IP is loopback, private, link-local or unspecified
+func isPrivateIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsUnspecified() || ip.IsInterfaceLocalMulticast()
+}
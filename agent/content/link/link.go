@@ -1 +1,148 @@
 package link
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/agent/content/types"
+	agentContext "github.com/yaoapp/yao/agent/context"
+	searchTypes "github.com/yaoapp/yao/agent/search/types"
+)
+
+// reURL matches http(s) URLs in message text
+var reURL = regexp.MustCompile(`https?://[^\s<>"'\x60]+`)
+
+// Link fetches URLs pasted in user messages and converts them to references
+type Link struct {
+	options *types.Options
+	fetcher *Fetcher
+}
+
+// New creates a new link handler
+func New(options *types.Options) *Link {
+	var cfg *searchTypes.LinkConfig
+	if options != nil && options.Search != nil {
+		cfg = options.Search.Link
+	}
+	return &Link{options: options, fetcher: NewFetcher(cfg)}
+}
+
+// Enabled checks if link fetching is enabled in the options
+func Enabled(options *types.Options) bool {
+	return options != nil && options.Search != nil && options.Search.Link != nil && options.Search.Link.Enabled
+}
+
+// Parse fetches the URLs in a message and returns them as references
+// Failed fetches are logged and skipped
+func (h *Link) Parse(ctx *agentContext.Context, message agentContext.Message) ([]*searchTypes.Reference, error) {
+	urls := ExtractURLs(messageText(message), h.fetcher.config.MaxLinks)
+	if len(urls) == 0 {
+		return nil, nil
+	}
+
+	weight := 1.0
+	if h.options != nil && h.options.Search != nil {
+		weight = h.options.Search.GetWeight(searchTypes.SourceUser)
+	}
+
+	fetchCtx := context.Background()
+	if ctx != nil && ctx.Context != nil {
+		fetchCtx = ctx.Context
+	}
+
+	refs := []*searchTypes.Reference{}
+	for _, url := range urls {
+		if err := h.fetcher.Allowed(url); err != nil {
+			log.Warn("[Link] skip %s: %v", url, err)
+			continue
+		}
+
+		page, err := h.fetcher.Fetch(fetchCtx, url)
+		if err != nil {
+			log.Warn("[Link] %v", err)
+			continue
+		}
+
+		content := page.Content
+		if page.Description != "" {
+			content = page.Description + "\n\n" + content
+		}
+		if page.Truncated {
+			content += "\n\n(truncated)"
+		}
+
+		// The citation IDs are shared with the search results of the request
+		id := fmt.Sprintf("%d", len(refs)+1)
+		if ctx != nil && ctx.IDGenerator != nil {
+			id = ctx.IDGenerator.GenerateCitationID()
+		}
+
+		refs = append(refs, &searchTypes.Reference{
+			ID:      id,
+			Type:    searchTypes.SearchTypeWeb,
+			Source:  searchTypes.SourceUser,
+			Weight:  weight,
+			Score:   1,
+			Title:   page.Title,
+			Content: content,
+			URL:     page.URL,
+			Meta:    map[string]interface{}{"content_type": page.ContentType},
+		})
+	}
+
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	return refs, nil
+}
+
+// ExtractURLs returns the unique http(s) URLs in text, at most max (0 means no limit)
+func ExtractURLs(text string, max int) []string {
+	seen := map[string]bool{}
+	urls := []string{}
+	for _, match := range reURL.FindAllString(text, -1) {
+		// Trailing punctuation belongs to the sentence, not the URL
+		match = strings.TrimRight(match, ".,;:!?)]}")
+		if seen[match] {
+			continue
+		}
+		seen[match] = true
+		urls = append(urls, match)
+		if max > 0 && len(urls) >= max {
+			break
+		}
+	}
+	return urls
+}
+
+// messageText returns the text of a message, joining text parts
+func messageText(message agentContext.Message) string {
+	switch content := message.Content.(type) {
+	case string:
+		return content
+
+	case []agentContext.ContentPart:
+		texts := []string{}
+		for _, part := range content {
+			if part.Type == agentContext.ContentText {
+				texts = append(texts, part.Text)
+			}
+		}
+		return strings.Join(texts, "\n")
+
+	case []interface{}:
+		texts := []string{}
+		for _, item := range content {
+			if m, ok := item.(map[string]interface{}); ok && m["type"] == "text" {
+				if text, ok := m["text"].(string); ok {
+					texts = append(texts, text)
+				}
+			}
+		}
+		return strings.Join(texts, "\n")
+	}
+	return ""
+}
@@ -0,0 +1,152 @@
+package link
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/yao/agent/content/types"
+	agentContext "github.com/yaoapp/yao/agent/context"
+	"github.com/yaoapp/yao/agent/output/message"
+	searchTypes "github.com/yaoapp/yao/agent/search/types"
+)
+
+func TestExtractURLs(t *testing.T) {
+	text := "Read https://example.com/a, then (https://example.com/b). Again https://example.com/a and http://x.org/c?q=1"
+	assert.Equal(t, []string{"https://example.com/a", "https://example.com/b", "http://x.org/c?q=1"}, ExtractURLs(text, 0))
+	assert.Equal(t, []string{"https://example.com/a"}, ExtractURLs(text, 1))
+	assert.Empty(t, ExtractURLs("no links here", 3))
+}
+
+func TestAllowed(t *testing.T) {
+	f := NewFetcher(&searchTypes.LinkConfig{
+		Allow: []string{"*.example.com", "docs.org"},
+		Deny:  []string{"secret.example.com"},
+	})
+
+	assert.NoError(t, f.Allowed("https://www.example.com/page"))
+	assert.NoError(t, f.Allowed("https://docs.org/page"))
+	assert.NoError(t, f.Allowed("https://api.docs.org/page"))
+	assert.Error(t, f.Allowed("https://secret.example.com/"))
+	assert.Error(t, f.Allowed("https://other.com/"))
+	assert.Error(t, f.Allowed("ftp://www.example.com/file"))
+
+	open := NewFetcher(nil)
+	assert.NoError(t, open.Allowed("https://any.com"))
+	assert.Error(t, open.Allowed("http://127.0.0.1:8080/"))
+	assert.Error(t, open.Allowed("http://localhost/"))
+	assert.Error(t, open.Allowed("http://10.0.0.1/"))
+	assert.Error(t, open.Allowed("http://[::1]/"))
+}
+
+func TestProxy(t *testing.T) {
+	// The proxy would dial the private addresses on behalf of the fetcher
+	transport := NewFetcher(nil).client.Transport.(*http.Transport)
+	assert.Nil(t, transport.Proxy)
+
+	transport = NewFetcher(&searchTypes.LinkConfig{AllowPrivate: true}).client.Transport.(*http.Transport)
+	assert.NotNil(t, transport.Proxy)
+}
+
+func TestParseCitationIDs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("page " + r.URL.Path))
+	}))
+	defer server.Close()
+
+	options := &types.Options{Search: &searchTypes.Config{Link: &searchTypes.LinkConfig{Enabled: true, AllowPrivate: true}}}
+	ctx := &agentContext.Context{Context: context.Background(), IDGenerator: message.NewIDGenerator()}
+
+	// The search results of the request took the first citation ID
+	ctx.IDGenerator.GenerateCitationID()
+
+	refs, err := New(options).Parse(ctx, agentContext.Message{Role: "user", Content: "see " + server.URL + "/a and " + server.URL + "/b"})
+	require.NoError(t, err)
+	require.Len(t, refs, 2)
+	assert.Equal(t, "2", refs[0].ID)
+	assert.Equal(t, "3", refs[1].ID)
+}
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/page":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte(`<html><head><title>Hello</title></head><body><main><h1>Title</h1><p>Body <a href="/next">next</a></p></main></body></html>`))
+		case "/data.csv":
+			w.Header().Set("Content-Type", "text/csv")
+			w.Write([]byte("name,age\nalice,30\n"))
+		case "/plain":
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte(strings.Repeat("a", 100)))
+		case "/big":
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte(strings.Repeat("a", 2048)))
+		case "/binary":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Write([]byte{0, 1, 2})
+		case "/redirect":
+			http.Redirect(w, r, "/page", http.StatusFound)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	f := NewFetcher(&searchTypes.LinkConfig{AllowPrivate: true, MaxSize: 1024, MaxChars: 50})
+	ctx := context.Background()
+
+	t.Run("HTML", func(t *testing.T) {
+		page, err := NewFetcher(&searchTypes.LinkConfig{AllowPrivate: true}).Fetch(ctx, server.URL+"/page")
+		require.NoError(t, err)
+		assert.Equal(t, "Hello", page.Title)
+		assert.Equal(t, "text/html", page.ContentType)
+		assert.Contains(t, page.Content, "# Title")
+		assert.Contains(t, page.Content, "[next]("+server.URL+"/next)")
+	})
+
+	t.Run("Redirect", func(t *testing.T) {
+		page, err := NewFetcher(&searchTypes.LinkConfig{AllowPrivate: true}).Fetch(ctx, server.URL+"/redirect")
+		require.NoError(t, err)
+		assert.Equal(t, server.URL+"/page", page.URL)
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		page, err := NewFetcher(&searchTypes.LinkConfig{AllowPrivate: true}).Fetch(ctx, server.URL+"/data.csv")
+		require.NoError(t, err)
+		assert.Contains(t, page.Content, "| name | age |")
+		assert.Contains(t, page.Content, "| alice | 30 |")
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		page, err := f.Fetch(ctx, server.URL+"/plain")
+		require.NoError(t, err)
+		assert.True(t, page.Truncated)
+		assert.Len(t, page.Content, 50)
+	})
+
+	t.Run("TooLarge", func(t *testing.T) {
+		_, err := f.Fetch(ctx, server.URL+"/big")
+		assert.ErrorContains(t, err, "too large")
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		_, err := f.Fetch(ctx, server.URL+"/binary")
+		assert.ErrorContains(t, err, "unsupported content type")
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := f.Fetch(ctx, server.URL+"/missing")
+		assert.ErrorContains(t, err, "HTTP 404")
+	})
+
+	t.Run("PrivateBlocked", func(t *testing.T) {
+		_, err := NewFetcher(nil).Fetch(ctx, server.URL+"/page")
+		assert.Error(t, err)
+	})
+}
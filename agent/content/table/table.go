@@ -0,0 +1,200 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Limits controls how much of a table is rendered for the LLM
+type Limits struct {
+	MaxSheets int // Maximum number of sheets to render (default: 10)
+	MaxRows   int // Maximum number of data rows per sheet (default: 200)
+	MaxCols   int // Maximum number of columns per sheet (default: 50)
+	MaxCell   int // Maximum characters per cell (default: 200)
+	MaxChars  int // Maximum characters of the whole output (default: 100000)
+}
+
+// DefaultLimits the default table limits
+var DefaultLimits = Limits{
+	MaxSheets: 10,
+	MaxRows:   200,
+	MaxCols:   50,
+	MaxCell:   200,
+	MaxChars:  100000,
+}
+
+// Sheet is a named table, the first row is the header
+type Sheet struct {
+	Name string
+	Rows [][]string
+
+	// TotalRows and TotalCols are the real dimensions when Rows was read partially
+	// If zero, the length of Rows is used
+	TotalRows int
+	TotalCols int
+}
+
+// WithDefaults returns the limits with zero values replaced by defaults
+func (l Limits) WithDefaults() Limits {
+	if l.MaxSheets <= 0 {
+		l.MaxSheets = DefaultLimits.MaxSheets
+	}
+	if l.MaxRows <= 0 {
+		l.MaxRows = DefaultLimits.MaxRows
+	}
+	if l.MaxCols <= 0 {
+		l.MaxCols = DefaultLimits.MaxCols
+	}
+	if l.MaxCell <= 0 {
+		l.MaxCell = DefaultLimits.MaxCell
+	}
+	if l.MaxChars <= 0 {
+		l.MaxChars = DefaultLimits.MaxChars
+	}
+	return l
+}
+
+// Markdown renders sheets as markdown tables, one section per sheet
+// A note is added when rows, columns or sheets are truncated
+func Markdown(filename string, sheets []Sheet, limits Limits) string {
+	limits = limits.WithDefaults()
+
+	var sb strings.Builder
+	if filename != "" {
+		sb.WriteString(fmt.Sprintf("File: %s\n\n", filename))
+	}
+
+	for i, sheet := range sheets {
+		if i >= limits.MaxSheets {
+			sb.WriteString(fmt.Sprintf("> %d more sheet(s) omitted\n", len(sheets)-limits.MaxSheets))
+			break
+		}
+
+		if len(sheets) > 1 || sheet.Name != "" {
+			sb.WriteString(fmt.Sprintf("## %s\n\n", sheet.Name))
+		}
+
+		section := renderSheet(sheet, limits)
+		if sb.Len()+len(section) > limits.MaxChars {
+			sb.WriteString("> Output truncated: size limit reached\n")
+			break
+		}
+		sb.WriteString(section)
+		sb.WriteString("\n")
+	}
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// renderSheet renders a single sheet as a markdown table
+func renderSheet(sheet Sheet, limits Limits) string {
+	rows := trimEmptyRows(sheet.Rows)
+	if len(rows) == 0 {
+		return "(empty)\n"
+	}
+
+	// Column count is the widest row
+	cols := 0
+	for _, row := range rows {
+		if len(row) > cols {
+			cols = len(row)
+		}
+	}
+
+	totalCols := sheet.TotalCols
+	if totalCols < cols {
+		totalCols = cols
+	}
+	if cols > limits.MaxCols {
+		cols = limits.MaxCols
+	}
+
+	totalRows := sheet.TotalRows - 1 // header excluded
+	if totalRows < len(rows)-1 {
+		totalRows = len(rows) - 1
+	}
+
+	var sb strings.Builder
+	header := rows[0]
+	writeRow(&sb, header, cols, limits.MaxCell, true)
+
+	sb.WriteString("|")
+	for c := 0; c < cols; c++ {
+		sb.WriteString(" --- |")
+	}
+	sb.WriteString("\n")
+
+	data := rows[1:]
+	if len(data) > limits.MaxRows {
+		data = data[:limits.MaxRows]
+	}
+	for _, row := range data {
+		writeRow(&sb, row, cols, limits.MaxCell, false)
+	}
+
+	// Truncation notes
+	var notes []string
+	if totalRows > len(data) {
+		notes = append(notes, fmt.Sprintf("showing %d of %d rows", len(data), totalRows))
+	}
+	if totalCols > cols {
+		notes = append(notes, fmt.Sprintf("showing %d of %d columns", cols, totalCols))
+	}
+	if len(notes) > 0 {
+		sb.WriteString(fmt.Sprintf("\n> %s\n", strings.Join(notes, ", ")))
+	}
+
+	return sb.String()
+}
+
+// writeRow writes a table row, padding or cutting it to cols cells
+func writeRow(sb *strings.Builder, row []string, cols int, maxCell int, header bool) {
+	sb.WriteString("|")
+	for c := 0; c < cols; c++ {
+		cell := ""
+		if c < len(row) {
+			cell = Cell(row[c], maxCell)
+		}
+		if header && cell == "" {
+			cell = fmt.Sprintf("Column %d", c+1)
+		}
+		sb.WriteString(" ")
+		sb.WriteString(cell)
+		sb.WriteString(" |")
+	}
+	sb.WriteString("\n")
+}
+
+// Cell escapes a value for a markdown table cell and cuts it to max characters
+func Cell(value string, max int) string {
+	value = strings.TrimSpace(value)
+	value = strings.ReplaceAll(value, "\r\n", " ")
+	value = strings.ReplaceAll(value, "\n", " ")
+	value = strings.ReplaceAll(value, "|", "\\|")
+
+	if max > 0 {
+		runes := []rune(value)
+		if len(runes) > max {
+			value = string(runes[:max]) + "…"
+		}
+	}
+	return value
+}
+
+// trimEmptyRows removes trailing empty rows
+func trimEmptyRows(rows [][]string) [][]string {
+	end := len(rows)
+	for end > 0 && isEmptyRow(rows[end-1]) {
+		end--
+	}
+	return rows[:end]
+}
+
+func isEmptyRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
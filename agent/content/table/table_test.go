@@ -0,0 +1,45 @@
+package table
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdown(t *testing.T) {
+	sheets := []Sheet{
+		{Name: "Orders", Rows: [][]string{{"ID", "Customer", ""}, {"1", "Acme | Inc", "x"}, {"2", "Globex\nLtd"}, {}}},
+		{Name: "Empty"},
+	}
+
+	md := Markdown("orders.xlsx", sheets, Limits{})
+	assert.Equal(t, strings.Join([]string{
+		"File: orders.xlsx",
+		"",
+		"## Orders",
+		"",
+		"| ID | Customer | Column 3 |",
+		"| --- | --- | --- |",
+		"| 1 | Acme \\| Inc | x |",
+		"| 2 | Globex Ltd |  |",
+		"",
+		"## Empty",
+		"",
+		"(empty)",
+	}, "\n"), md)
+}
+
+func TestMarkdownLimits(t *testing.T) {
+	rows := [][]string{{"A", "B", "C"}}
+	for i := 0; i < 10; i++ {
+		rows = append(rows, []string{"1", "2", "abcdefgh"})
+	}
+
+	md := Markdown("", []Sheet{{Rows: rows, TotalRows: 101}, {Name: "Second"}}, Limits{MaxRows: 2, MaxCols: 2, MaxCell: 3, MaxSheets: 1})
+	assert.Contains(t, md, "| A | B |\n| --- | --- |\n| 1 | 2 |\n| 1 | 2 |\n")
+	assert.Contains(t, md, "> showing 2 of 100 rows, showing 2 of 3 columns")
+	assert.Contains(t, md, "> 1 more sheet(s) omitted")
+
+	assert.Equal(t, "abc…", Cell("abcdefgh", 3))
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/yaoapp/gou/connector"
 	"github.com/yaoapp/gou/connector/openai"
 	agentContext "github.com/yaoapp/yao/agent/context"
+	searchTypes "github.com/yaoapp/yao/agent/search/types"
 )
 
 // Options represents the options for the content
@@ -23,4 +24,7 @@ type Options struct {
 
 	// SilentLoading, if true, suppress loading messages (used when called from parent handler)
 	SilentLoading bool
+
+	// Search, Current search configuration (link fetching, citation, weights)
+	Search *searchTypes.Config
 }
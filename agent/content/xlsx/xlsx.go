@@ -0,0 +1,190 @@
+package xlsx
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/yaoapp/yao/agent/content/table"
+	"github.com/yaoapp/yao/agent/content/types"
+	agentContext "github.com/yaoapp/yao/agent/context"
+	searchTypes "github.com/yaoapp/yao/agent/search/types"
+	"github.com/yaoapp/yao/attachment"
+	"github.com/yaoapp/yao/excel"
+)
+
+// Xlsx handles XLSX spreadsheet content
+type Xlsx struct {
+	options *types.Options
+}
+
+// New creates a new XLSX handler
+func New(options *types.Options) *Xlsx {
+	return &Xlsx{options: options}
+}
+
+// IsSupportedExtension checks if a file is a spreadsheet handled by this handler
+func IsSupportedExtension(filename string) bool {
+	filename = strings.ToLower(filename)
+	return strings.HasSuffix(filename, ".xlsx") || strings.HasSuffix(filename, ".xlsm")
+}
+
+// Parse parses XLSX content and returns one markdown table per sheet
+func (h *Xlsx) Parse(ctx *agentContext.Context, content agentContext.ContentPart) (agentContext.ContentPart, []*searchTypes.Reference, error) {
+	if content.File == nil || content.File.URL == "" {
+		return content, nil, fmt.Errorf("file content missing URL")
+	}
+
+	url := content.File.URL
+
+	// Check cache first
+	cachedText, found, err := h.readFromCache(ctx, url)
+	if err == nil && found {
+		return agentContext.ContentPart{
+			Type: agentContext.ContentText,
+			Text: cachedText,
+		}, nil, nil
+	}
+
+	// Read XLSX file
+	data, err := h.readFile(ctx, url)
+	if err != nil {
+		return content, nil, fmt.Errorf("failed to read XLSX: %w", err)
+	}
+
+	text, err := ToMarkdown(data, content.File.Filename, table.DefaultLimits)
+	if err != nil {
+		return content, nil, err
+	}
+
+	// Cache the result
+	if err := h.saveToCache(ctx, url, text); err != nil {
+		// Log warning but don't fail
+		fmt.Printf("Warning: failed to cache XLSX text: %v\n", err)
+	}
+
+	return agentContext.ContentPart{
+		Type: agentContext.ContentText,
+		Text: text,
+	}, nil, nil
+}
+
+// ToMarkdown converts a workbook to markdown tables
+// Only the rows within the limits are read, so large sheets are not loaded entirely
+func ToMarkdown(data []byte, filename string, limits table.Limits) (string, error) {
+	limits = limits.WithDefaults()
+
+	file, err := excel.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse XLSX: %w", err)
+	}
+	defer file.Close()
+
+	names := file.ListSheets()
+	if len(names) == 0 {
+		return "", fmt.Errorf("no sheets found in XLSX")
+	}
+
+	sheets := make([]table.Sheet, 0, len(names))
+	for i, name := range names {
+		if i >= limits.MaxSheets {
+			// Keep the name so the omitted count is reported
+			sheets = append(sheets, table.Sheet{Name: name})
+			continue
+		}
+
+		// Header row plus MaxRows data rows
+		rows, err := file.ReadSheetRows(name, 0, limits.MaxRows+1)
+		if err != nil {
+			return "", fmt.Errorf("failed to read sheet %s: %w", name, err)
+		}
+
+		totalRows, totalCols, err := file.GetSheetDimension(name)
+		if err != nil {
+			return "", fmt.Errorf("failed to read sheet %s: %w", name, err)
+		}
+
+		sheets = append(sheets, table.Sheet{
+			Name:      name,
+			Rows:      rows,
+			TotalRows: totalRows,
+			TotalCols: totalCols,
+		})
+	}
+
+	return table.Markdown(filename, sheets, limits), nil
+}
+
+// readFile reads XLSX content from various sources
+func (h *Xlsx) readFile(ctx *agentContext.Context, url string) ([]byte, error) {
+	if strings.HasPrefix(url, "__") {
+		return h.readFromUploader(ctx, url)
+	}
+
+	if strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://") {
+		return nil, fmt.Errorf("HTTP URL fetch not implemented yet: %s", url)
+	}
+
+	// Try to read as local file path
+	if _, err := os.Stat(url); err == nil {
+		return os.ReadFile(url)
+	}
+
+	return nil, fmt.Errorf("unsupported XLSX source: %s", url)
+}
+
+// readFromUploader reads XLSX content from file uploader
+func (h *Xlsx) readFromUploader(ctx *agentContext.Context, wrapper string) ([]byte, error) {
+	uploaderName, fileID, ok := attachment.Parse(wrapper)
+	if !ok {
+		return nil, fmt.Errorf("invalid uploader wrapper format: %s", wrapper)
+	}
+
+	manager, exists := attachment.Managers[uploaderName]
+	if !exists {
+		return nil, fmt.Errorf("uploader '%s' not found", uploaderName)
+	}
+
+	data, err := manager.Read(ctx.Context, fileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	return data, nil
+}
+
+// readFromCache reads cached text content for a XLSX
+func (h *Xlsx) readFromCache(ctx *agentContext.Context, url string) (string, bool, error) {
+	uploaderName, fileID, isWrapper := attachment.Parse(url)
+	if !isWrapper {
+		return "", false, nil
+	}
+
+	manager, exists := attachment.Managers[uploaderName]
+	if !exists {
+		return "", false, nil
+	}
+
+	text, err := manager.GetText(ctx.Context, fileID, false)
+	if err == nil && text != "" {
+		return text, true, nil
+	}
+
+	return "", false, nil
+}
+
+// saveToCache saves processed text to cache
+func (h *Xlsx) saveToCache(ctx *agentContext.Context, url string, text string) error {
+	uploaderName, fileID, isWrapper := attachment.Parse(url)
+	if !isWrapper {
+		return nil
+	}
+
+	manager, exists := attachment.Managers[uploaderName]
+	if !exists {
+		return nil
+	}
+
+	return manager.SaveText(ctx.Context, fileID, text)
+}
@@ -0,0 +1,112 @@
+package xlsx_test
+
+import (
+	stdContext "context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+	"github.com/yaoapp/yao/agent/content/table"
+	"github.com/yaoapp/yao/agent/content/xlsx"
+	agentContext "github.com/yaoapp/yao/agent/context"
+)
+
+func TestToMarkdown(t *testing.T) {
+	data := newWorkbook(t, map[string][][]interface{}{
+		"Orders": {{"ID", "Customer"}, {1, "Acme | Inc"}, {2, "Globex"}},
+	})
+
+	md, err := xlsx.ToMarkdown(data, "orders.xlsx", table.Limits{})
+	require.NoError(t, err)
+	assert.Contains(t, md, "File: orders.xlsx")
+	assert.Contains(t, md, "## Orders")
+	assert.Contains(t, md, "| ID | Customer |\n| --- | --- |\n| 1 | Acme \\| Inc |\n| 2 | Globex |")
+
+	_, err = xlsx.ToMarkdown([]byte("not a workbook"), "broken.xlsx", table.Limits{})
+	assert.ErrorContains(t, err, "failed to parse XLSX")
+}
+
+func TestToMarkdownLimits(t *testing.T) {
+	rows := [][]interface{}{{"A", "B", "C"}}
+	for i := 1; i <= 10; i++ {
+		rows = append(rows, []interface{}{i, i * 2, i * 3})
+	}
+	data := newWorkbook(t, map[string][][]interface{}{
+		"Sheet1": rows,
+		"Second": {{"X"}},
+	})
+
+	md, err := xlsx.ToMarkdown(data, "", table.Limits{MaxRows: 2, MaxCols: 2, MaxSheets: 1})
+	require.NoError(t, err)
+	assert.Contains(t, md, "| A | B |\n| --- | --- |\n| 1 | 2 |\n| 2 | 4 |\n")
+	assert.Contains(t, md, "> showing 2 of 10 rows, showing 2 of 3 columns")
+	assert.Contains(t, md, "> 1 more sheet(s) omitted")
+	assert.NotContains(t, md, "## Second")
+}
+
+func TestParse(t *testing.T) {
+	file := newWorkbookFile(t, map[string][][]interface{}{
+		"Sheet1": {{"name", "age"}, {"alice", 30}},
+	})
+
+	assert.True(t, xlsx.IsSupportedExtension("Report.XLSM"))
+	assert.False(t, xlsx.IsSupportedExtension("report.csv"))
+
+	ctx := agentContext.New(stdContext.Background(), nil, "test-chat")
+	handler := xlsx.New(nil)
+	part, refs, err := handler.Parse(ctx, agentContext.ContentPart{
+		Type: agentContext.ContentFile,
+		File: &agentContext.FileAttachment{URL: file, Filename: "people.xlsx"},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, refs)
+	assert.Equal(t, agentContext.ContentText, part.Type)
+	assert.True(t, strings.HasPrefix(part.Text, "File: people.xlsx"))
+	assert.Contains(t, part.Text, "| alice | 30 |")
+
+	_, _, err = handler.Parse(ctx, agentContext.ContentPart{Type: agentContext.ContentFile})
+	assert.Error(t, err)
+}
+
+// newWorkbookFile writes a workbook to a temporary file and returns the path
+func newWorkbookFile(t *testing.T, sheets map[string][][]interface{}) string {
+	file := excelize.NewFile()
+	defer file.Close()
+	fillWorkbook(t, file, sheets)
+
+	path := filepath.Join(t.TempDir(), "workbook.xlsx")
+	require.NoError(t, file.SaveAs(path))
+	return path
+}
+
+// newWorkbook returns the content of a workbook with the sheets, Sheet1 is the first sheet
+func newWorkbook(t *testing.T, sheets map[string][][]interface{}) []byte {
+	file := excelize.NewFile()
+	defer file.Close()
+	fillWorkbook(t, file, sheets)
+
+	buf, err := file.WriteToBuffer()
+	require.NoError(t, err)
+	return buf.Bytes()
+}
+
+func fillWorkbook(t *testing.T, file *excelize.File, sheets map[string][][]interface{}) {
+	for name, rows := range sheets {
+		if name != "Sheet1" {
+			if _, err := file.NewSheet(name); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for i, row := range rows {
+			cell := fmt.Sprintf("A%d", i+1)
+			require.NoError(t, file.SetSheetRow(name, cell, &row))
+		}
+	}
+	if _, has := sheets["Sheet1"]; !has {
+		require.NoError(t, file.DeleteSheet("Sheet1"))
+	}
+}
@@ -146,6 +146,11 @@ func (ctx *Context) GetAuthorizedMap() map[string]interface{} {
 // Trace returns the trace manager for this context, lazily initialized on first call
 // Uses the TraceID from ctx.Stack if available, or generates a new one
 func (ctx *Context) Trace() (traceTypes.Manager, error) {
+	// Forked branch contexts are traced by the caller
+	if ctx.noTrace {
+		return nil, nil
+	}
+
 	// Return trace if already initialized
 	if ctx.trace != nil {
 		return ctx.trace, nil
@@ -0,0 +1,56 @@
+package context
+
+import (
+	"context"
+)
+
+// Fork creates a branch context to run an agent concurrently with its siblings
+// The branch shares the request identity (ID, authorization, chat, memory, writer, locale)
+// and starts from a copy of the current stack, so parallel Stream calls do not race on
+// Stack, Stacks, the chat buffer or the interrupt handler.
+// Branches do not write to the trace, the caller records each branch on its own node.
+// The branch has its own logger, call CloseFork when the branch is finished.
+func (ctx *Context) Fork(parent context.Context) *Context {
+	if parent == nil {
+		parent = ctx.Context
+	}
+
+	var stack *Stack
+	if ctx.Stack != nil {
+		copied := *ctx.Stack
+		stack = &copied
+	}
+
+	return &Context{
+		Context:         parent,
+		ID:              ctx.ID,
+		Memory:          ctx.Memory,
+		Cache:           ctx.Cache,
+		Stack:           stack,
+		Stacks:          make(map[string]*Stack),
+		Writer:          ctx.Writer,
+		IDGenerator:     ctx.IDGenerator,
+		Logger:          NewRequestLogger(ctx.AssistantID, ctx.ChatID, ctx.ID),
+		messageMetadata: newMessageMetadataStore(),
+		noTrace:         true,
+		Capabilities:    ctx.Capabilities,
+		Authorized:      ctx.Authorized,
+		ChatID:          ctx.ChatID,
+		AssistantID:     ctx.AssistantID,
+		Locale:          ctx.Locale,
+		Theme:           ctx.Theme,
+		Client:          ctx.Client,
+		Referer:         ctx.Referer,
+		Accept:          ctx.Accept,
+		Route:           ctx.Route,
+		Metadata:        ctx.Metadata,
+	}
+}
+
+// CloseFork releases the resources owned by a forked branch context
+// Shared resources (memory, trace, writer) belong to the parent and are not released
+func (ctx *Context) CloseFork() {
+	if ctx.Logger != nil {
+		ctx.Logger.Close()
+	}
+}
@@ -0,0 +1,50 @@
+package context_test
+
+import (
+	stdContext "context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/yao/agent/context"
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/test"
+)
+
+func TestFork(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+
+	ctx := context.New(stdContext.Background(), nil, "chat-fork")
+	defer ctx.Release()
+	ctx.Locale = "en"
+
+	_, _, done := context.EnterStack(ctx, "tests.orchestrator", &context.Options{})
+	defer done()
+
+	timeout, cancel := stdContext.WithTimeout(ctx.Context, time.Second)
+	defer cancel()
+
+	branch := ctx.Fork(timeout)
+	defer branch.CloseFork()
+
+	assert.Equal(t, ctx.ID, branch.ID)
+	assert.Equal(t, ctx.ChatID, branch.ChatID)
+	assert.Equal(t, "en", branch.Locale)
+	assert.Equal(t, timeout, branch.Context)
+
+	// The branch stack is a copy, entering a child stack does not change the parent
+	require.NotNil(t, branch.Stack)
+	assert.Equal(t, ctx.Stack.ID, branch.Stack.ID)
+	child, _, childDone := context.EnterStack(branch, "tests.branch", &context.Options{})
+	assert.Equal(t, ctx.Stack.ID, child.ParentID)
+	assert.NotEqual(t, child.ID, ctx.Stack.ID)
+	assert.NotContains(t, ctx.Stacks, child.ID)
+	childDone()
+
+	// Branches do not write to the trace
+	manager, err := branch.Trace()
+	assert.NoError(t, err)
+	assert.Nil(t, manager)
+}
@@ -28,6 +28,9 @@ func (opts *Options) ToMap() map[string]interface{} {
 	if opts.Metadata != nil {
 		result["metadata"] = opts.Metadata
 	}
+	if opts.MaxTokens > 0 {
+		result["max_tokens"] = opts.MaxTokens
+	}
 
 	// Note: Runtime fields (Context, Writer) are not serialized (json:"-")
 	// They should not be included in the map
@@ -73,6 +76,12 @@ func OptionsFromMap(m map[string]interface{}) *Options {
 	if metadata, ok := m["metadata"].(map[string]interface{}); ok {
 		opts.Metadata = metadata
 	}
+	switch v := m["max_tokens"].(type) {
+	case float64:
+		opts.MaxTokens = int(v)
+	case int:
+		opts.MaxTokens = v
+	}
 
 	// Note: Context and Writer are runtime fields, not restored from map
 	// They should be set by the caller if needed
@@ -39,6 +39,12 @@ const (
 
 	// AcceptDesktopCUI desktop CUI format with action request support
 	AcceptDesktopCUI = "cui-desktop"
+
+	// AcceptVercelAI Vercel AI SDK UI message stream protocol (useChat)
+	AcceptVercelAI = "vercel-ai"
+
+	// AcceptAGUI AG-UI event protocol
+	AcceptAGUI = "ag-ui"
 )
 
 // ValidAccepts is the map of valid accept types
@@ -47,6 +53,8 @@ var ValidAccepts = map[string]bool{
 	AcceptWebCUI:     true,
 	AccepNativeCUI:   true,
 	AcceptDesktopCUI: true,
+	AcceptVercelAI:   true,
+	AcceptAGUI:       true,
 }
 
 const (
@@ -241,6 +249,7 @@ type Context struct {
 	// Internal
 	trace           traceTypes.Manager    `json:"-"` // Trace manager, lazy initialized on first access
 	messageMetadata *messageMetadataStore `json:"-"` // Thread-safe message metadata store for delta operations
+	noTrace         bool                  `json:"-"` // Branch contexts created by Fork do not write to the trace
 
 	// Model capabilities (set by assistant, used by output adapters)
 	Capabilities *openai.Capabilities `json:"-"` // Model capabilities for the current connector
@@ -260,7 +269,7 @@ type Context struct {
 	// Request information
 	Client  Client `json:"client,omitempty"`  // Client information from HTTP request
 	Referer string `json:"referer,omitempty"` // Request source: api, process, mcp, jssdk, agent, tool, hook, schedule, script, internal
-	Accept  Accept `json:"accept,omitempty"`  // Response format: standard, cui-web, cui-native, cui-desktop
+	Accept  Accept `json:"accept,omitempty"`  // Response format: standard, cui-web, cui-native, cui-desktop, vercel-ai, ag-ui
 
 	// CUI Context information
 	Route    string                 `json:"route,omitempty"`    // The route of the request, it will be used to identify the route of the request
@@ -308,6 +317,9 @@ type Options struct {
 
 	// Metadata for passing custom data to hooks (e.g., scenario selection)
 	Metadata map[string]any `json:"metadata,omitempty"` // Custom metadata passed to Create/Next hooks
+
+	// Max tokens, caps the generated tokens of the call (e.g. the orchestration branch budget), 0 means no limit
+	MaxTokens int `json:"max_tokens,omitempty"` // Caps max_tokens/max_completion_tokens of the assistant and the hooks
 }
 
 // Stack represents the call stack node for tracing agent-to-agent calls
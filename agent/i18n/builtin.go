@@ -57,6 +57,21 @@ func init() {
 			"output.cui.writer.send_error":    "Failed to send data to client",
 			"output.cui.writer.marshal_error": "Failed to marshal chunk",
 
+			// Output: adapters/vercel/writer.go
+			"output.vercel.writer.adapt_error":   "Failed to adapt message",
+			"output.vercel.writer.chunk_error":   "Failed to send chunk",
+			"output.vercel.writer.group_error":   "Failed to write message in group",
+			"output.vercel.writer.send_error":    "Failed to send data to client",
+			"output.vercel.writer.marshal_error": "Failed to marshal chunk",
+			"output.vercel.writer.done_error":    "Failed to send [DONE] to client",
+
+			// Output: adapters/agui/writer.go
+			"output.agui.writer.adapt_error":   "Failed to adapt message",
+			"output.agui.writer.chunk_error":   "Failed to send chunk",
+			"output.agui.writer.group_error":   "Failed to write message in group",
+			"output.agui.writer.send_error":    "Failed to send data to client",
+			"output.agui.writer.marshal_error": "Failed to marshal chunk",
+
 			// Output: Stream event messages
 			"output.stream_start": "Assistant is processing",
 			"output.view_trace":   "View process",
@@ -184,6 +199,21 @@ func init() {
 			"output.cui.writer.send_error":    "发送数据到客户端失败",
 			"output.cui.writer.marshal_error": "序列化数据块失败",
 
+			// Output: adapters/vercel/writer.go
+			"output.vercel.writer.adapt_error":   "适配消息失败",
+			"output.vercel.writer.chunk_error":   "发送数据块失败",
+			"output.vercel.writer.group_error":   "写入消息组中的消息失败",
+			"output.vercel.writer.send_error":    "发送数据到客户端失败",
+			"output.vercel.writer.marshal_error": "序列化数据块失败",
+			"output.vercel.writer.done_error":    "发送 [DONE] 到客户端失败",
+
+			// Output: adapters/agui/writer.go
+			"output.agui.writer.adapt_error":   "适配消息失败",
+			"output.agui.writer.chunk_error":   "发送数据块失败",
+			"output.agui.writer.group_error":   "写入消息组中的消息失败",
+			"output.agui.writer.send_error":    "发送数据到客户端失败",
+			"output.agui.writer.marshal_error": "序列化数据块失败",
+
 			// Output: Stream event messages
 			"output.stream_start": "智能体正在处理",
 			"output.view_trace":   "查看处理详情",
@@ -283,6 +313,21 @@ func init() {
 			"output.cui.writer.send_error":    "发送数据到客户端失败",
 			"output.cui.writer.marshal_error": "序列化数据块失败",
 
+			// Output: adapters/vercel/writer.go
+			"output.vercel.writer.adapt_error":   "适配消息失败",
+			"output.vercel.writer.chunk_error":   "发送数据块失败",
+			"output.vercel.writer.group_error":   "写入消息组中的消息失败",
+			"output.vercel.writer.send_error":    "发送数据到客户端失败",
+			"output.vercel.writer.marshal_error": "序列化数据块失败",
+			"output.vercel.writer.done_error":    "发送 [DONE] 到客户端失败",
+
+			// Output: adapters/agui/writer.go
+			"output.agui.writer.adapt_error":   "适配消息失败",
+			"output.agui.writer.chunk_error":   "发送数据块失败",
+			"output.agui.writer.group_error":   "写入消息组中的消息失败",
+			"output.agui.writer.send_error":    "发送数据到客户端失败",
+			"output.agui.writer.marshal_error": "序列化数据块失败",
+
 			// Output: Stream event messages
 			"output.stream_start": "智能体正在处理",
 			"output.view_trace":   "查看处理详情",
@@ -17,6 +17,8 @@ import (
 	store "github.com/yaoapp/yao/agent/store/types"
 	"github.com/yaoapp/yao/agent/store/xun"
 	"github.com/yaoapp/yao/agent/types"
+	"github.com/yaoapp/yao/agent/usage"
+	"github.com/yaoapp/yao/cluster"
 	"github.com/yaoapp/yao/config"
 )
 
@@ -106,6 +108,12 @@ func Load(cfg config.Config) error {
 		return err
 	}
 
+	// Initialize Usage Configuration
+	err = initUsageConfig()
+	if err != nil {
+		return err
+	}
+
 	// Initialize Assistant
 	err = initAssistant()
 	if err != nil {
@@ -244,6 +252,9 @@ func initAssistant() error {
 		assistant.SetGlobalSearchConfig(agentDSL.Search)
 	}
 
+	// Set usage prices and quotas (nil disables metering)
+	usage.SetConfig(agentDSL.Usage)
+
 	// Set system agents configuration
 	if agentDSL.System != nil {
 		assistant.SetSystemConfig(&assistant.SystemConfig{
@@ -268,6 +279,9 @@ func initAssistant() error {
 		return err
 	}
 
+	// Remove the assistants changed on the other instances of the cluster
+	cluster.RegisterCache(assistant.CacheName, assistant.Invalidate)
+
 	// Default Assistant
 	defaultAssistant, err := defaultAssistant()
 	if err != nil {
@@ -328,6 +342,32 @@ func initSearchConfig() error {
 	return nil
 }
 
+// initUsageConfig initialize the usage prices and quotas from agent/usage.yml
+func initUsageConfig() error {
+	path := filepath.Join("agent", "usage.yml")
+	if exists, _ := application.App.Exists(path); !exists {
+		return nil // Usage config is optional, usage is not metered without it
+	}
+
+	bytes, err := application.App.Read(path)
+	if err != nil {
+		return err
+	}
+
+	var usageConfig usage.Config
+	err = application.Parse("usage.yml", bytes, &usageConfig)
+	if err != nil {
+		return err
+	}
+
+	if err := usageConfig.Validate(); err != nil {
+		return fmt.Errorf("agent/usage.yml: %s", err.Error())
+	}
+
+	agentDSL.Usage = &usageConfig
+	return nil
+}
+
 // mergeSearchConfig merges two search configs (base < override)
 func mergeSearchConfig(base, override *searchTypes.Config) *searchTypes.Config {
 	if base == nil {
@@ -0,0 +1,377 @@
+package agui
+
+import (
+	"encoding/json"
+
+	"github.com/yaoapp/yao/agent/output/message"
+)
+
+// Adapter converts messages to AG-UI events
+// AG-UI messages have explicit start/content/end events, so the adapter keeps
+// track of the messages that are open
+type Adapter struct {
+	threadID string
+	runID    string
+	started  bool
+	finished bool
+	open     map[string]messageKind  // Open message kind by message ID
+	order    []string                // Message IDs in the order they were opened
+	tools    map[string][]*toolState // Streaming tool calls by message ID, indexed by tool call index
+	steps    map[string]string       // Step names by block ID
+}
+
+// NewAdapter creates a new AG-UI adapter
+func NewAdapter() *Adapter {
+	return &Adapter{
+		open:  map[string]messageKind{},
+		tools: map[string][]*toolState{},
+		steps: map[string]string{},
+	}
+}
+
+// Adapt converts a universal Message to AG-UI events
+func (a *Adapter) Adapt(msg *message.Message) ([]interface{}, error) {
+	switch msg.Type {
+	case message.TypeEvent:
+		return a.adaptEvent(msg)
+
+	case message.TypeText:
+		return a.adaptText(msg, kindText), nil
+
+	case message.TypeThinking:
+		return a.adaptText(msg, kindThinking), nil
+
+	case message.TypeToolCall:
+		return a.adaptToolCall(msg), nil
+
+	case message.TypeError:
+		// RUN_ERROR terminates the run, a later stream_end is not sent again
+		events := a.closeAll()
+		a.finished = true
+		return append(events, &Event{
+			Type:    EventRunError,
+			Message: getStringProp(msg.Props, "message", "An error occurred"),
+			Code:    getStringProp(msg.Props, "code", ""),
+		}), nil
+
+	case message.TypeUserInput:
+		// User input is echoed for CUI display only
+		return []interface{}{}, nil
+
+	default:
+		// Loading, action, media and custom types become custom events named by type
+		value := map[string]interface{}{"id": messageID(msg), "props": msg.Props}
+		return []interface{}{&Event{Type: EventCustom, Name: msg.Type, Value: value}}, nil
+	}
+}
+
+// SupportsType checks if the adapter explicitly supports a given message type
+// Every type is supported, unknown types are sent as custom events
+func (a *Adapter) SupportsType(msgType string) bool {
+	return true
+}
+
+// Finish ends the open messages and the run if stream_end was not received
+func (a *Adapter) Finish() []interface{} {
+	if !a.started || a.finished {
+		return []interface{}{}
+	}
+	a.finished = true
+	events := a.closeAll()
+	return append(events, &Event{Type: EventRunFinished, ThreadID: a.threadID, RunID: a.runID})
+}
+
+// adaptEvent converts lifecycle events
+func (a *Adapter) adaptEvent(msg *message.Message) ([]interface{}, error) {
+	event, _ := msg.Props["event"].(string)
+	switch event {
+	case message.EventStreamStart:
+		var data message.EventStreamStartData
+		decodeData(msg.Props["data"], &data)
+		a.started = true
+		a.threadID = data.ChatID
+		if a.threadID == "" {
+			a.threadID = data.ContextID
+		}
+		a.runID = data.RequestID
+		return []interface{}{&Event{Type: EventRunStarted, ThreadID: a.threadID, RunID: a.runID}}, nil
+
+	case message.EventMessageEnd:
+		var data message.EventMessageEndData
+		decodeData(msg.Props["data"], &data)
+		return a.close(data.MessageID), nil
+
+	case message.EventBlockStart:
+		var data message.EventBlockStartData
+		decodeData(msg.Props["data"], &data)
+		name := data.Label
+		if name == "" {
+			name = data.BlockID
+		}
+		a.steps[data.BlockID] = name
+		return []interface{}{&Event{Type: EventStepStarted, StepName: name}}, nil
+
+	case message.EventBlockEnd:
+		var data message.EventBlockEndData
+		decodeData(msg.Props["data"], &data)
+		name, ok := a.steps[data.BlockID]
+		if !ok {
+			name = data.BlockID
+		}
+		delete(a.steps, data.BlockID)
+		return []interface{}{&Event{Type: EventStepFinished, StepName: name}}, nil
+
+	case message.EventToolResult:
+		var data message.EventToolResultData
+		decodeData(msg.Props["data"], &data)
+		content := data.Error
+		if content == "" {
+			content = stringify(data.Content)
+		}
+		return []interface{}{&Event{
+			Type:       EventToolCallResult,
+			MessageID:  "result-" + data.ToolCallID,
+			ToolCallID: data.ToolCallID,
+			Content:    content,
+			Role:       "tool",
+		}}, nil
+
+	case message.EventStreamEnd:
+		var data message.EventStreamEndData
+		decodeData(msg.Props["data"], &data)
+		if a.finished {
+			return []interface{}{}, nil
+		}
+		a.finished = true
+
+		events := a.closeAll()
+		if data.Status == "error" {
+			text := data.Error
+			if text == "" {
+				text = "An error occurred"
+			}
+			return append(events, &Event{Type: EventRunError, Message: text}), nil
+		}
+
+		finished := &Event{Type: EventRunFinished, ThreadID: a.threadID, RunID: a.runID}
+		if data.Usage != nil {
+			finished.Result = map[string]interface{}{"usage": data.Usage}
+		}
+		return append(events, finished), nil
+	}
+
+	// Other events (message_start, thread events, etc.) have no equivalent
+	return []interface{}{}, nil
+}
+
+// adaptText converts text and thinking messages, starting the message on the first delta
+func (a *Adapter) adaptText(msg *message.Message, kind messageKind) []interface{} {
+	content := getStringProp(msg.Props, "content", "")
+	if content == "" {
+		return []interface{}{}
+	}
+
+	id := messageID(msg)
+	events := []interface{}{}
+
+	current, isOpen := a.open[id]
+	if isOpen && current != kind {
+		// Type correction: end the previous message first
+		events = append(events, a.close(id)...)
+		isOpen = false
+	}
+
+	if kind == kindThinking {
+		if !isOpen {
+			a.markOpen(id, kind)
+			events = append(events, &Event{Type: EventThinkingStart}, &Event{Type: EventThinkingTextMessageStart})
+		}
+		return append(events, &Event{Type: EventThinkingTextMessageContent, Delta: content})
+	}
+
+	if !isOpen {
+		a.markOpen(id, kind)
+		events = append(events, &Event{Type: EventTextMessageStart, MessageID: id, Role: "assistant"})
+	}
+	return append(events, &Event{Type: EventTextMessageContent, MessageID: id, Delta: content})
+}
+
+// adaptToolCall converts tool call deltas
+// The first delta of a call carries its id and name, the following ones the argument fragments
+func (a *Adapter) adaptToolCall(msg *message.Message) []interface{} {
+	id := messageID(msg)
+	calls := []map[string]interface{}{}
+
+	if list := toCallList(msg.Props["calls"]); list != nil {
+		// Multiple tool calls in one delta (OpenAI format)
+		for _, call := range list {
+			flat := map[string]interface{}{"id": call["id"], "index": call["index"]}
+			if fn, ok := call["function"].(map[string]interface{}); ok {
+				flat["name"] = fn["name"]
+				flat["arguments"] = fn["arguments"]
+			}
+			calls = append(calls, flat)
+		}
+	} else {
+		calls = append(calls, msg.Props)
+	}
+
+	events := []interface{}{}
+	for _, call := range calls {
+		state := a.tool(id, getIntProp(call, "index"))
+		if v := getStringProp(call, "id", ""); v != "" {
+			state.id = v
+		}
+		if v := getStringProp(call, "name", ""); v != "" {
+			state.name = v
+		}
+		args := getStringProp(call, "arguments", "")
+		state.args = append(state.args, args...)
+
+		if !state.started {
+			if state.id == "" || state.name == "" {
+				continue
+			}
+			state.started = true
+			a.markOpen(id, kindTool)
+			events = append(events, &Event{Type: EventToolCallStart, ToolCallID: state.id, ToolCallName: state.name, ParentMessageID: id})
+			// Arguments received before the id and name are flushed at once
+			args = string(state.args)
+		}
+
+		if args != "" {
+			events = append(events, &Event{Type: EventToolCallArgs, ToolCallID: state.id, Delta: args})
+		}
+	}
+	return events
+}
+
+// tool returns the state of a streaming tool call
+func (a *Adapter) tool(id string, index int) *toolState {
+	if index < 0 {
+		index = 0
+	}
+	states := a.tools[id]
+	for len(states) <= index {
+		states = append(states, &toolState{})
+	}
+	a.tools[id] = states
+	return states[index]
+}
+
+// markOpen records an open message
+func (a *Adapter) markOpen(id string, kind messageKind) {
+	if _, exists := a.open[id]; !exists {
+		a.order = append(a.order, id)
+	}
+	a.open[id] = kind
+}
+
+// close ends an open message
+func (a *Adapter) close(id string) []interface{} {
+	kind, isOpen := a.open[id]
+	if !isOpen {
+		return []interface{}{}
+	}
+	delete(a.open, id)
+
+	switch kind {
+	case kindText:
+		return []interface{}{&Event{Type: EventTextMessageEnd, MessageID: id}}
+
+	case kindThinking:
+		return []interface{}{&Event{Type: EventThinkingTextMessageEnd}, &Event{Type: EventThinkingEnd}}
+	}
+
+	events := []interface{}{}
+	for _, state := range a.tools[id] {
+		if state.started {
+			events = append(events, &Event{Type: EventToolCallEnd, ToolCallID: state.id})
+		}
+	}
+	delete(a.tools, id)
+	return events
+}
+
+// closeAll ends every open message in the order they were opened
+func (a *Adapter) closeAll() []interface{} {
+	events := []interface{}{}
+	for _, id := range a.order {
+		events = append(events, a.close(id)...)
+	}
+	a.order = nil
+	return events
+}
+
+// toCallList converts the "calls" prop of a tool call delta, nil if not present
+func toCallList(value interface{}) []map[string]interface{} {
+	switch list := value.(type) {
+	case []map[string]interface{}:
+		return list
+	case []interface{}:
+		calls := make([]map[string]interface{}, 0, len(list))
+		for _, item := range list {
+			if call, ok := item.(map[string]interface{}); ok {
+				calls = append(calls, call)
+			}
+		}
+		return calls
+	}
+	return nil
+}
+
+// messageID returns the message ID, falling back to the chunk ID
+func messageID(msg *message.Message) string {
+	if msg.MessageID != "" {
+		return msg.MessageID
+	}
+	return msg.ChunkID
+}
+
+// stringify converts a tool result to the string content AG-UI expects
+func stringify(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(raw)
+}
+
+// decodeData decodes event data, which is a struct in-process or a map when loaded from JSON
+func decodeData(data interface{}, target interface{}) {
+	if data == nil {
+		return
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	json.Unmarshal(raw, target)
+}
+
+// getStringProp safely gets a string property from props
+func getStringProp(props map[string]interface{}, key string, defaultValue string) string {
+	if val, ok := props[key].(string); ok {
+		return val
+	}
+	return defaultValue
+}
+
+// getIntProp safely gets an integer property from props
+func getIntProp(props map[string]interface{}, key string) int {
+	switch v := props[key].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	case int64:
+		return int(v)
+	}
+	return 0
+}
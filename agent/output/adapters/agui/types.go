@@ -0,0 +1,80 @@
+package agui
+
+// Event types of the AG-UI protocol
+// See https://docs.ag-ui.com/concepts/events
+const (
+	EventRunStarted  = "RUN_STARTED"
+	EventRunFinished = "RUN_FINISHED"
+	EventRunError    = "RUN_ERROR"
+
+	EventStepStarted  = "STEP_STARTED"
+	EventStepFinished = "STEP_FINISHED"
+
+	EventTextMessageStart   = "TEXT_MESSAGE_START"
+	EventTextMessageContent = "TEXT_MESSAGE_CONTENT"
+	EventTextMessageEnd     = "TEXT_MESSAGE_END"
+
+	EventThinkingStart              = "THINKING_START"
+	EventThinkingEnd                = "THINKING_END"
+	EventThinkingTextMessageStart   = "THINKING_TEXT_MESSAGE_START"
+	EventThinkingTextMessageContent = "THINKING_TEXT_MESSAGE_CONTENT"
+	EventThinkingTextMessageEnd     = "THINKING_TEXT_MESSAGE_END"
+
+	EventToolCallStart  = "TOOL_CALL_START"
+	EventToolCallArgs   = "TOOL_CALL_ARGS"
+	EventToolCallEnd    = "TOOL_CALL_END"
+	EventToolCallResult = "TOOL_CALL_RESULT"
+
+	EventCustom = "CUSTOM"
+)
+
+// Event is a single AG-UI event
+type Event struct {
+	Type string `json:"type"`
+
+	// Run
+	ThreadID string `json:"threadId,omitempty"`
+	RunID    string `json:"runId,omitempty"`
+
+	// Steps
+	StepName string `json:"stepName,omitempty"`
+
+	// Messages
+	MessageID string `json:"messageId,omitempty"`
+	Role      string `json:"role,omitempty"`
+	Delta     string `json:"delta,omitempty"`
+
+	// Tool calls
+	ToolCallID      string `json:"toolCallId,omitempty"`
+	ToolCallName    string `json:"toolCallName,omitempty"`
+	ParentMessageID string `json:"parentMessageId,omitempty"`
+	Content         string `json:"content,omitempty"`
+
+	// Custom events
+	Name  string      `json:"name,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+
+	// Errors
+	Message string `json:"message,omitempty"`
+	Code    string `json:"code,omitempty"`
+
+	// Result of RUN_FINISHED
+	Result interface{} `json:"result,omitempty"`
+}
+
+// messageKind is the kind of an open streaming message
+type messageKind int
+
+const (
+	kindText messageKind = iota
+	kindThinking
+	kindTool
+)
+
+// toolState tracks a streaming tool call
+type toolState struct {
+	id      string
+	name    string
+	args    []byte
+	started bool
+}
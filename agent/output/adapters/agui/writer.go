@@ -0,0 +1,129 @@
+package agui
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/yaoapp/yao/agent/i18n"
+	"github.com/yaoapp/yao/agent/output/message"
+	traceTypes "github.com/yaoapp/yao/trace/types"
+)
+
+// Writer implements the message.Writer interface for AG-UI clients
+type Writer struct {
+	Writer  http.ResponseWriter
+	Trace   traceTypes.Manager
+	Locale  string
+	adapter *Adapter
+}
+
+// NewWriter creates a new AG-UI writer
+func NewWriter(options message.Options) (*Writer, error) {
+	return &Writer{
+		Writer:  options.Writer,
+		Trace:   options.Trace,
+		Locale:  options.Locale,
+		adapter: NewAdapter(),
+	}, nil
+}
+
+// Write writes a single message to the output stream
+func (w *Writer) Write(msg *message.Message) error {
+	events, err := w.adapter.Adapt(msg)
+	if err != nil {
+		if w.Trace != nil {
+			w.Trace.Error(i18n.T(w.Locale, "output.agui.writer.adapt_error"), map[string]any{ // "AG-UI Writer: Failed to adapt message"
+				"error":        err.Error(),
+				"message_type": msg.Type,
+			})
+		}
+		return err
+	}
+
+	return w.sendEvents(events)
+}
+
+// WriteGroup writes a message group to the output stream
+func (w *Writer) WriteGroup(group *message.Group) error {
+	// AG-UI has no group concept, send each message individually
+	for _, msg := range group.Messages {
+		if err := w.Write(msg); err != nil {
+			if w.Trace != nil {
+				w.Trace.Error(i18n.T(w.Locale, "output.agui.writer.group_error"), map[string]any{ // "AG-UI Writer: Failed to write message in group"
+					"error":        err.Error(),
+					"group_id":     group.ID,
+					"message_type": msg.Type,
+				})
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush flushes any buffered data to the output stream
+func (w *Writer) Flush() error {
+	// Each event is flushed when sent
+	return nil
+}
+
+// Close ends the run if it was not finished by a stream_end event
+// AG-UI streams end with RUN_FINISHED or RUN_ERROR, there is no [DONE] marker
+func (w *Writer) Close() error {
+	return w.sendEvents(w.adapter.Finish())
+}
+
+// sendEvents sends events in order
+func (w *Writer) sendEvents(events []interface{}) error {
+	for _, event := range events {
+		if err := w.sendEvent(event); err != nil {
+			if w.Trace != nil {
+				w.Trace.Error(i18n.T(w.Locale, "output.agui.writer.chunk_error"), map[string]any{"error": err.Error()}) // "AG-UI Writer: Failed to send chunk"
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// sendEvent sends an event in SSE format
+func (w *Writer) sendEvent(event interface{}) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		if w.Trace != nil {
+			w.Trace.Error(i18n.T(w.Locale, "output.agui.writer.marshal_error"), map[string]any{"error": err.Error()}) // "AG-UI Writer: Failed to marshal chunk"
+		}
+		return err
+	}
+
+	// Format as SSE: "data: {json}\n\n"
+	sseData := append([]byte("data: "), data...)
+	sseData = append(sseData, []byte("\n\n")...)
+
+	if err := w.sendData(sseData); err != nil {
+		if w.Trace != nil {
+			w.Trace.Error(i18n.T(w.Locale, "output.agui.writer.send_error"), map[string]any{"error": err.Error()}) // "AG-UI Writer: Failed to send data to client"
+		}
+		return err
+	}
+
+	w.flush()
+	return nil
+}
+
+func (w *Writer) sendData(data []byte) error {
+	if w.Writer == nil {
+		return nil // No writer, silently ignore
+	}
+	_, err := w.Writer.Write(data)
+	return err
+}
+
+func (w *Writer) flush() {
+	if w.Writer == nil {
+		return
+	}
+	if flusher, ok := w.Writer.(interface{ Flush() }); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,384 @@
+package vercel
+
+import (
+	"encoding/json"
+	"mime"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/yaoapp/yao/agent/output/message"
+)
+
+// Adapter converts messages to the Vercel AI SDK UI message stream protocol
+// The protocol streams parts with explicit start/delta/end boundaries, so the
+// adapter keeps track of the parts that are open for each message
+type Adapter struct {
+	started  bool
+	finished bool
+	open     map[string]partKind     // Open part kind by message ID
+	order    []string                // Message IDs in the order they were opened
+	tools    map[string][]*toolState // Streaming tool calls by message ID, indexed by tool call index
+}
+
+// NewAdapter creates a new Vercel AI SDK adapter
+func NewAdapter() *Adapter {
+	return &Adapter{
+		open:  map[string]partKind{},
+		tools: map[string][]*toolState{},
+	}
+}
+
+// Adapt converts a universal Message to UI message stream parts
+func (a *Adapter) Adapt(msg *message.Message) ([]interface{}, error) {
+	switch msg.Type {
+	case message.TypeEvent:
+		return a.adaptEvent(msg)
+
+	case message.TypeText:
+		return a.adaptText(msg, kindText), nil
+
+	case message.TypeThinking:
+		return a.adaptText(msg, kindReasoning), nil
+
+	case message.TypeToolCall:
+		return a.adaptToolCall(msg), nil
+
+	case message.TypeError:
+		text := getStringProp(msg.Props, "message", "An error occurred")
+		return []interface{}{&Part{Type: PartError, ErrorText: text}}, nil
+
+	case message.TypeImage, message.TypeAudio, message.TypeVideo:
+		return a.adaptFile(msg), nil
+
+	case message.TypeUserInput:
+		// User input is echoed for CUI display only
+		return []interface{}{}, nil
+
+	default:
+		// Loading, action and custom types become data parts, the client renders them by type
+		// Parts with the same ID replace each other, so loading updates are merged
+		return []interface{}{&Part{Type: PartDataPrefix + msg.Type, ID: messageID(msg), Data: msg.Props}}, nil
+	}
+}
+
+// SupportsType checks if the adapter explicitly supports a given message type
+// Every type is supported, unknown types are sent as data parts
+func (a *Adapter) SupportsType(msgType string) bool {
+	return true
+}
+
+// Finish closes the open parts and finishes the message if stream_end was not received
+func (a *Adapter) Finish() []interface{} {
+	if !a.started || a.finished {
+		return []interface{}{}
+	}
+	a.finished = true
+	parts := a.closeAll()
+	return append(parts, &Part{Type: PartFinish})
+}
+
+// adaptEvent converts lifecycle events
+func (a *Adapter) adaptEvent(msg *message.Message) ([]interface{}, error) {
+	event, _ := msg.Props["event"].(string)
+	switch event {
+	case message.EventStreamStart:
+		var data message.EventStreamStartData
+		decodeData(msg.Props["data"], &data)
+		a.started = true
+		metadata := map[string]interface{}{}
+		if data.ChatID != "" {
+			metadata["chat_id"] = data.ChatID
+		}
+		if data.TraceID != "" {
+			metadata["trace_id"] = data.TraceID
+		}
+		if data.Assistant != nil && data.Assistant.ID != "" {
+			metadata["assistant_id"] = data.Assistant.ID
+		}
+		return []interface{}{&Part{Type: PartStart, MessageID: data.RequestID, MessageMetadata: metadata}}, nil
+
+	case message.EventMessageEnd:
+		var data message.EventMessageEndData
+		decodeData(msg.Props["data"], &data)
+		return a.close(data.MessageID), nil
+
+	case message.EventBlockStart:
+		return []interface{}{&Part{Type: PartStartStep}}, nil
+
+	case message.EventBlockEnd:
+		return []interface{}{&Part{Type: PartFinishStep}}, nil
+
+	case message.EventToolResult:
+		var data message.EventToolResultData
+		decodeData(msg.Props["data"], &data)
+		if data.Error != "" {
+			return []interface{}{&Part{Type: PartToolOutputError, ToolCallID: data.ToolCallID, ErrorText: data.Error}}, nil
+		}
+		output := data.Content
+		if output == nil {
+			output = ""
+		}
+		return []interface{}{&Part{Type: PartToolOutput, ToolCallID: data.ToolCallID, Output: output}}, nil
+
+	case message.EventStreamEnd:
+		var data message.EventStreamEndData
+		decodeData(msg.Props["data"], &data)
+		a.finished = true
+
+		parts := a.closeAll()
+		if data.Status == "error" && data.Error != "" {
+			parts = append(parts, &Part{Type: PartError, ErrorText: data.Error})
+		}
+
+		finish := &Part{Type: PartFinish}
+		if data.Usage != nil {
+			finish.MessageMetadata = map[string]interface{}{"usage": data.Usage}
+		}
+		return append(parts, finish), nil
+	}
+
+	// Other events (message_start, thread events, etc.) have no equivalent
+	return []interface{}{}, nil
+}
+
+// adaptText converts text and thinking messages, opening the part on the first delta
+func (a *Adapter) adaptText(msg *message.Message, kind partKind) []interface{} {
+	content := getStringProp(msg.Props, "content", "")
+	if content == "" {
+		return []interface{}{}
+	}
+
+	id := messageID(msg)
+	parts := []interface{}{}
+
+	current, isOpen := a.open[id]
+	if isOpen && current != kind {
+		// Type correction: close the previous part first
+		parts = append(parts, a.close(id)...)
+		isOpen = false
+	}
+
+	startType, deltaType := PartTextStart, PartTextDelta
+	if kind == kindReasoning {
+		startType, deltaType = PartReasoningStart, PartReasoningDelta
+	}
+
+	if !isOpen {
+		a.markOpen(id, kind)
+		parts = append(parts, &Part{Type: startType, ID: id})
+	}
+	return append(parts, &Part{Type: deltaType, ID: id, Delta: content})
+}
+
+// adaptToolCall converts tool call deltas
+// The first delta of a call carries its id and name, the following ones the argument fragments
+func (a *Adapter) adaptToolCall(msg *message.Message) []interface{} {
+	id := messageID(msg)
+	calls := []map[string]interface{}{}
+
+	if list := toCallList(msg.Props["calls"]); list != nil {
+		// Multiple tool calls in one delta (OpenAI format)
+		for _, call := range list {
+			flat := map[string]interface{}{"id": call["id"], "index": call["index"]}
+			if fn, ok := call["function"].(map[string]interface{}); ok {
+				flat["name"] = fn["name"]
+				flat["arguments"] = fn["arguments"]
+			}
+			calls = append(calls, flat)
+		}
+	} else {
+		calls = append(calls, msg.Props)
+	}
+
+	parts := []interface{}{}
+	for _, call := range calls {
+		state := a.tool(id, getIntProp(call, "index"))
+		if v := getStringProp(call, "id", ""); v != "" {
+			state.id = v
+		}
+		if v := getStringProp(call, "name", ""); v != "" {
+			state.name = v
+		}
+		args := getStringProp(call, "arguments", "")
+		state.args = append(state.args, args...)
+
+		if !state.started {
+			if state.id == "" || state.name == "" {
+				continue
+			}
+			state.started = true
+			a.markOpen(id, kindTool)
+			parts = append(parts, &Part{Type: PartToolInputStart, ToolCallID: state.id, ToolName: state.name})
+			// Arguments received before the id and name are flushed at once
+			args = string(state.args)
+		}
+
+		if args != "" {
+			parts = append(parts, &Part{Type: PartToolInputDelta, ToolCallID: state.id, InputTextDelta: args})
+		}
+	}
+	return parts
+}
+
+// adaptFile converts media messages to file parts
+func (a *Adapter) adaptFile(msg *message.Message) []interface{} {
+	fileURL := getStringProp(msg.Props, "url", "")
+	if fileURL == "" {
+		return []interface{}{}
+	}
+	return []interface{}{&Part{Type: PartFile, URL: fileURL, MediaType: mediaType(msg.Type, fileURL, getStringProp(msg.Props, "format", ""))}}
+}
+
+// tool returns the state of a streaming tool call
+func (a *Adapter) tool(id string, index int) *toolState {
+	if index < 0 {
+		index = 0
+	}
+	states := a.tools[id]
+	for len(states) <= index {
+		states = append(states, &toolState{})
+	}
+	a.tools[id] = states
+	return states[index]
+}
+
+// markOpen records an open part for a message
+func (a *Adapter) markOpen(id string, kind partKind) {
+	if _, exists := a.open[id]; !exists {
+		a.order = append(a.order, id)
+	}
+	a.open[id] = kind
+}
+
+// close ends the open part of a message
+func (a *Adapter) close(id string) []interface{} {
+	kind, isOpen := a.open[id]
+	if !isOpen {
+		return []interface{}{}
+	}
+	delete(a.open, id)
+
+	switch kind {
+	case kindText:
+		return []interface{}{&Part{Type: PartTextEnd, ID: id}}
+
+	case kindReasoning:
+		return []interface{}{&Part{Type: PartReasoningEnd, ID: id}}
+	}
+
+	// Tool calls: the complete input is sent once all argument fragments are received
+	parts := []interface{}{}
+	for _, state := range a.tools[id] {
+		if !state.started {
+			continue
+		}
+		parts = append(parts, &Part{Type: PartToolInputAvailable, ToolCallID: state.id, ToolName: state.name, Input: parseArguments(state.args)})
+	}
+	delete(a.tools, id)
+	return parts
+}
+
+// closeAll ends every open part in the order they were opened
+func (a *Adapter) closeAll() []interface{} {
+	parts := []interface{}{}
+	for _, id := range a.order {
+		parts = append(parts, a.close(id)...)
+	}
+	a.order = nil
+	return parts
+}
+
+// toCallList converts the "calls" prop of a tool call delta, nil if not present
+func toCallList(value interface{}) []map[string]interface{} {
+	switch list := value.(type) {
+	case []map[string]interface{}:
+		return list
+	case []interface{}:
+		calls := make([]map[string]interface{}, 0, len(list))
+		for _, item := range list {
+			if call, ok := item.(map[string]interface{}); ok {
+				calls = append(calls, call)
+			}
+		}
+		return calls
+	}
+	return nil
+}
+
+// messageID returns the message ID, falling back to the chunk ID
+func messageID(msg *message.Message) string {
+	if msg.MessageID != "" {
+		return msg.MessageID
+	}
+	return msg.ChunkID
+}
+
+// parseArguments parses tool call arguments, invalid JSON is passed as a string
+func parseArguments(args []byte) interface{} {
+	if len(strings.TrimSpace(string(args))) == 0 {
+		return map[string]interface{}{}
+	}
+	var input interface{}
+	if err := json.Unmarshal(args, &input); err != nil {
+		return string(args)
+	}
+	return input
+}
+
+// mediaType guesses the media type of a file from a data URL, the format or the URL extension
+func mediaType(msgType string, fileURL string, format string) string {
+	if strings.HasPrefix(fileURL, "data:") {
+		if end := strings.IndexAny(fileURL, ";,"); end > 5 {
+			return fileURL[5:end]
+		}
+	}
+
+	ext := ""
+	if format != "" {
+		ext = "." + strings.TrimPrefix(format, ".")
+	} else if u, err := url.Parse(fileURL); err == nil {
+		ext = path.Ext(u.Path)
+	}
+
+	if ext != "" {
+		if typ := mime.TypeByExtension(strings.ToLower(ext)); typ != "" {
+			typ, _, _ = mime.ParseMediaType(typ)
+			return typ
+		}
+	}
+	return msgType + "/*"
+}
+
+// decodeData decodes event data, which is a struct in-process or a map when loaded from JSON
+func decodeData(data interface{}, target interface{}) {
+	if data == nil {
+		return
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	json.Unmarshal(raw, target)
+}
+
+// getStringProp safely gets a string property from props
+func getStringProp(props map[string]interface{}, key string, defaultValue string) string {
+	if val, ok := props[key].(string); ok {
+		return val
+	}
+	return defaultValue
+}
+
+// getIntProp safely gets an integer property from props
+func getIntProp(props map[string]interface{}, key string) int {
+	switch v := props[key].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	case int64:
+		return int(v)
+	}
+	return 0
+}
@@ -0,0 +1,86 @@
+package vercel
+
+// ProtocolHeader is the response header the AI SDK client checks for the UI message stream protocol
+const ProtocolHeader = "x-vercel-ai-ui-message-stream"
+
+// ProtocolVersion is the UI message stream protocol version
+const ProtocolVersion = "v1"
+
+// Part types of the AI SDK UI message stream protocol
+// See https://ai-sdk.dev/docs/ai-sdk-ui/stream-protocol
+const (
+	PartStart      = "start"       // Message started
+	PartFinish     = "finish"      // Message finished
+	PartStartStep  = "start-step"  // Step started (one block of work)
+	PartFinishStep = "finish-step" // Step finished
+	PartError      = "error"       // Error
+
+	PartTextStart = "text-start" // Text part started
+	PartTextDelta = "text-delta" // Text part delta
+	PartTextEnd   = "text-end"   // Text part ended
+
+	PartReasoningStart = "reasoning-start" // Reasoning part started
+	PartReasoningDelta = "reasoning-delta" // Reasoning part delta
+	PartReasoningEnd   = "reasoning-end"   // Reasoning part ended
+
+	PartToolInputStart     = "tool-input-start"      // Tool call started
+	PartToolInputDelta     = "tool-input-delta"      // Tool call arguments delta
+	PartToolInputAvailable = "tool-input-available"  // Tool call arguments complete
+	PartToolOutput         = "tool-output-available" // Tool call result
+	PartToolOutputError    = "tool-output-error"     // Tool call failed
+
+	PartFile = "file" // File (image, audio, video)
+
+	// PartDataPrefix prefixes custom data parts, e.g. "data-loading", "data-table"
+	PartDataPrefix = "data-"
+)
+
+// Part is a single chunk of the UI message stream
+type Part struct {
+	Type string `json:"type"`
+
+	// Message and part identifiers
+	MessageID string `json:"messageId,omitempty"`
+	ID        string `json:"id,omitempty"`
+
+	// Text and reasoning deltas
+	Delta string `json:"delta,omitempty"`
+
+	// Tool calls
+	ToolCallID     string      `json:"toolCallId,omitempty"`
+	ToolName       string      `json:"toolName,omitempty"`
+	InputTextDelta string      `json:"inputTextDelta,omitempty"`
+	Input          interface{} `json:"input,omitempty"`
+	Output         interface{} `json:"output,omitempty"`
+
+	// Files
+	URL       string `json:"url,omitempty"`
+	MediaType string `json:"mediaType,omitempty"`
+
+	// Custom data parts
+	Data      interface{} `json:"data,omitempty"`
+	Transient bool        `json:"transient,omitempty"`
+
+	// Errors
+	ErrorText string `json:"errorText,omitempty"`
+
+	// Message metadata (start and finish)
+	MessageMetadata map[string]interface{} `json:"messageMetadata,omitempty"`
+}
+
+// partKind is the kind of an open streaming part
+type partKind int
+
+const (
+	kindText partKind = iota
+	kindReasoning
+	kindTool
+)
+
+// toolState tracks a streaming tool call
+type toolState struct {
+	id      string
+	name    string
+	args    []byte
+	started bool
+}
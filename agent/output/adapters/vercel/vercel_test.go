@@ -0,0 +1,154 @@
+package vercel
+
+import (
+	"flag"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/yao/agent/output/message"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestWriterGolden(t *testing.T) {
+	cases := []struct {
+		name     string
+		messages []*message.Message
+	}{
+		{"text", textStream()},
+		{"tools", toolStream()},
+		{"custom", customStream()},
+		{"unfinished", unfinishedStream()},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			w, err := NewWriter(message.Options{Writer: rec})
+			require.NoError(t, err)
+
+			for _, msg := range tc.messages {
+				require.NoError(t, w.Write(msg))
+			}
+			require.NoError(t, w.Close())
+
+			assert.Equal(t, ProtocolVersion, rec.Header().Get(ProtocolHeader))
+			assertGolden(t, tc.name, rec.Body.Bytes())
+		})
+	}
+}
+
+func TestMediaType(t *testing.T) {
+	assert.Equal(t, "image/png", mediaType(message.TypeImage, "https://example.com/a.png?x=1", ""))
+	assert.Equal(t, "image/jpeg", mediaType(message.TypeImage, "data:image/jpeg;base64,AAAA", ""))
+	assert.Equal(t, "video/*", mediaType(message.TypeVideo, "https://example.com/stream", ""))
+}
+
+func assertGolden(t *testing.T, name string, actual []byte) {
+	t.Helper()
+	file := filepath.Join("testdata", name+".golden")
+	if *update {
+		require.NoError(t, os.MkdirAll("testdata", 0755))
+		require.NoError(t, os.WriteFile(file, actual, 0644))
+	}
+
+	expected, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(actual))
+}
+
+func event(name string, data interface{}) *message.Message {
+	return &message.Message{
+		Type:  message.TypeEvent,
+		Props: map[string]interface{}{"event": name, "data": data},
+	}
+}
+
+func delta(id string, msgType string, props map[string]interface{}) *message.Message {
+	return &message.Message{MessageID: id, Type: msgType, Delta: true, Props: props}
+}
+
+func streamStart() *message.Message {
+	return event(message.EventStreamStart, message.EventStreamStartData{
+		RequestID: "req-1",
+		ChatID:    "chat-1",
+		TraceID:   "trace-1",
+		Assistant: &message.AssistantInfo{ID: "tests.weather"},
+	})
+}
+
+func messageEnd(id string, msgType string) *message.Message {
+	return event(message.EventMessageEnd, message.EventMessageEndData{MessageID: id, Type: msgType, Status: "completed"})
+}
+
+func streamEnd(status string, err string) *message.Message {
+	data := message.EventStreamEndData{RequestID: "req-1", Status: status, Error: err}
+	if status == "completed" {
+		data.Usage = &message.UsageInfo{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15}
+	}
+	return event(message.EventStreamEnd, data)
+}
+
+func textStream() []*message.Message {
+	return []*message.Message{
+		streamStart(),
+		event(message.EventMessageStart, message.EventMessageStartData{MessageID: "M1", Type: message.TypeText}),
+		delta("M1", message.TypeText, map[string]interface{}{"content": "Hello"}),
+		delta("M1", message.TypeText, map[string]interface{}{"content": " world"}),
+		messageEnd("M1", message.TypeText),
+		streamEnd("completed", ""),
+	}
+}
+
+func toolStream() []*message.Message {
+	return []*message.Message{
+		streamStart(),
+		delta("M1", message.TypeThinking, map[string]interface{}{"content": "Need the "}),
+		delta("M1", message.TypeThinking, map[string]interface{}{"content": "weather."}),
+		messageEnd("M1", message.TypeThinking),
+		delta("M2", message.TypeToolCall, map[string]interface{}{"id": "call_1", "type": "function", "index": 0, "name": "weather__get"}),
+		delta("M2", message.TypeToolCall, map[string]interface{}{"index": 0, "arguments": `{"city":`}),
+		delta("M2", message.TypeToolCall, map[string]interface{}{"index": 0, "arguments": `"Paris"}`}),
+		messageEnd("M2", message.TypeToolCall),
+		event(message.EventToolResult, message.EventToolResultData{
+			ToolCallID: "call_1",
+			Name:       "weather__get",
+			Content:    map[string]interface{}{"temp": 21, "sky": "sunny"},
+		}),
+		delta("M3", message.TypeText, map[string]interface{}{"content": "Sunny, 21°C."}),
+		messageEnd("M3", message.TypeText),
+		streamEnd("completed", ""),
+	}
+}
+
+func customStream() []*message.Message {
+	return []*message.Message{
+		streamStart(),
+		{MessageID: "M1", Type: message.TypeLoading, Props: map[string]interface{}{"message": "Searching..."}},
+		{MessageID: "M1", Type: message.TypeLoading, Delta: true, DeltaAction: message.DeltaReplace, Props: map[string]interface{}{"message": "Found 3 results", "done": true}},
+		{MessageID: "M2", Type: message.TypeImage, Props: map[string]interface{}{"url": "https://example.com/chart.png", "alt": "Chart"}},
+		{MessageID: "M3", Type: message.TypeAction, Props: map[string]interface{}{"name": "open_panel", "payload": map[string]interface{}{"id": "orders"}}},
+		{MessageID: "M4", Type: "table", Props: map[string]interface{}{"columns": []string{"name"}, "rows": [][]string{{"alice"}}}},
+		{MessageID: "M5", Type: message.TypeUserInput, Props: map[string]interface{}{"content": "hi"}},
+		event(message.EventToolResult, message.EventToolResultData{ToolCallID: "call_2", Name: "db__query", Error: "timeout"}),
+		{MessageID: "M6", Type: message.TypeError, Props: map[string]interface{}{"message": "Tool failed", "code": "tool_error"}},
+		streamEnd("error", "boom"),
+	}
+}
+
+func unfinishedStream() []*message.Message {
+	return []*message.Message{
+		streamStart(),
+		delta("M1", message.TypeText, map[string]interface{}{"content": "Partial"}),
+		delta("M2", message.TypeToolCall, map[string]interface{}{
+			"calls": []map[string]interface{}{
+				{"index": 0, "id": "call_a", "function": map[string]interface{}{"name": "a", "arguments": "{}"}},
+				{"index": 1, "id": "call_b", "function": map[string]interface{}{"name": "b", "arguments": ""}},
+			},
+		}),
+	}
+}
@@ -0,0 +1,149 @@
+package vercel
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/yaoapp/yao/agent/i18n"
+	"github.com/yaoapp/yao/agent/output/message"
+	traceTypes "github.com/yaoapp/yao/trace/types"
+)
+
+// Writer implements the message.Writer interface for Vercel AI SDK clients (useChat)
+type Writer struct {
+	Writer  http.ResponseWriter
+	Trace   traceTypes.Manager
+	Locale  string
+	adapter *Adapter
+}
+
+// NewWriter creates a new Vercel AI SDK writer
+func NewWriter(options message.Options) (*Writer, error) {
+	// The AI SDK client checks this header to select the stream protocol
+	// Headers are still writable here, the writer is created before the first chunk
+	if options.Writer != nil {
+		options.Writer.Header().Set(ProtocolHeader, ProtocolVersion)
+	}
+
+	return &Writer{
+		Writer:  options.Writer,
+		Trace:   options.Trace,
+		Locale:  options.Locale,
+		adapter: NewAdapter(),
+	}, nil
+}
+
+// Write writes a single message to the output stream
+func (w *Writer) Write(msg *message.Message) error {
+	parts, err := w.adapter.Adapt(msg)
+	if err != nil {
+		if w.Trace != nil {
+			w.Trace.Error(i18n.T(w.Locale, "output.vercel.writer.adapt_error"), map[string]any{ // "Vercel Writer: Failed to adapt message"
+				"error":        err.Error(),
+				"message_type": msg.Type,
+			})
+		}
+		return err
+	}
+
+	return w.sendParts(parts)
+}
+
+// WriteGroup writes a message group to the output stream
+func (w *Writer) WriteGroup(group *message.Group) error {
+	// The UI message stream has no group concept, send each message individually
+	for _, msg := range group.Messages {
+		if err := w.Write(msg); err != nil {
+			if w.Trace != nil {
+				w.Trace.Error(i18n.T(w.Locale, "output.vercel.writer.group_error"), map[string]any{ // "Vercel Writer: Failed to write message in group"
+					"error":        err.Error(),
+					"group_id":     group.ID,
+					"message_type": msg.Type,
+				})
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush flushes any buffered data to the output stream
+func (w *Writer) Flush() error {
+	// Each part is flushed when sent
+	return nil
+}
+
+// Close finishes the message and sends the [DONE] marker
+func (w *Writer) Close() error {
+	if err := w.sendParts(w.adapter.Finish()); err != nil {
+		return err
+	}
+	return w.sendDone()
+}
+
+// sendParts sends parts in order
+func (w *Writer) sendParts(parts []interface{}) error {
+	for _, part := range parts {
+		if err := w.sendPart(part); err != nil {
+			if w.Trace != nil {
+				w.Trace.Error(i18n.T(w.Locale, "output.vercel.writer.chunk_error"), map[string]any{"error": err.Error()}) // "Vercel Writer: Failed to send chunk"
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// sendPart sends a part in SSE format
+func (w *Writer) sendPart(part interface{}) error {
+	data, err := json.Marshal(part)
+	if err != nil {
+		if w.Trace != nil {
+			w.Trace.Error(i18n.T(w.Locale, "output.vercel.writer.marshal_error"), map[string]any{"error": err.Error()}) // "Vercel Writer: Failed to marshal chunk"
+		}
+		return err
+	}
+
+	// Format as SSE: "data: {json}\n\n"
+	sseData := append([]byte("data: "), data...)
+	sseData = append(sseData, []byte("\n\n")...)
+
+	if err := w.sendData(sseData); err != nil {
+		if w.Trace != nil {
+			w.Trace.Error(i18n.T(w.Locale, "output.vercel.writer.send_error"), map[string]any{"error": err.Error()}) // "Vercel Writer: Failed to send data to client"
+		}
+		return err
+	}
+
+	w.flush()
+	return nil
+}
+
+// sendDone sends the final [DONE] marker
+func (w *Writer) sendDone() error {
+	if err := w.sendData([]byte("data: [DONE]\n\n")); err != nil {
+		if w.Trace != nil {
+			w.Trace.Error(i18n.T(w.Locale, "output.vercel.writer.done_error"), map[string]any{"error": err.Error()}) // "Vercel Writer: Failed to send [DONE] to client"
+		}
+		return err
+	}
+	w.flush()
+	return nil
+}
+
+func (w *Writer) sendData(data []byte) error {
+	if w.Writer == nil {
+		return nil // No writer, silently ignore
+	}
+	_, err := w.Writer.Write(data)
+	return err
+}
+
+func (w *Writer) flush() {
+	if w.Writer == nil {
+		return
+	}
+	if flusher, ok := w.Writer.(interface{ Flush() }); ok {
+		flusher.Flush()
+	}
+}
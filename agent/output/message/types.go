@@ -98,6 +98,9 @@ const (
 	// Message level events (LLM layer - individual logical messages)
 	EventMessageStart = "message_start" // Message started event
 	EventMessageEnd   = "message_end"   // Message ended event
+
+	// Tool level events (Agent layer - tool execution results)
+	EventToolResult = "tool_result" // Tool call completed event
 )
 
 // Standard Props structures for built-in types
@@ -347,6 +350,17 @@ type EventToolCallInfo struct {
 	Index     int    `json:"index"`               // Index in the tool calls array
 }
 
+// EventToolResultData represents the data for tool_result event
+// Sent after a tool call requested by the LLM has been executed
+// Agent layer: Pairs with the tool_call message of the same tool call ID
+type EventToolResultData struct {
+	ToolCallID string      `json:"tool_call_id"`      // Tool call ID (matches the ID in the tool_call message)
+	Name       string      `json:"name"`              // Tool name
+	Content    interface{} `json:"content,omitempty"` // Tool result (parsed JSON when possible)
+	Error      string      `json:"error,omitempty"`   // Error message if the call failed
+	Timestamp  int64       `json:"timestamp"`         // Unix timestamp when the call completed
+}
+
 // EventBlockStartData represents the data for block_start event
 // Sent when an output block begins (one LLM call, one MCP call, one Agent sub-task, etc.)
 // Agent layer: Groups multiple related messages into a logical section
@@ -14,6 +14,7 @@ type IDGenerator struct {
 	messageCounter uint64
 	blockCounter   uint64
 	threadCounter  uint64
+	refCounter     uint64
 }
 
 // NewIDGenerator creates a new ID generator for a context
@@ -49,12 +50,20 @@ func (g *IDGenerator) GenerateThreadID() string {
 	return fmt.Sprintf("T%d", id)
 }
 
+// GenerateCitationID generates a unique citation ID of the references
+// Format: 1, 2, 3... (the search results and the fetched links of a context share the numbering)
+func (g *IDGenerator) GenerateCitationID() string {
+	id := atomic.AddUint64(&g.refCounter, 1)
+	return fmt.Sprintf("%d", id)
+}
+
 // Reset resets all counters (useful for testing)
 func (g *IDGenerator) Reset() {
 	atomic.StoreUint64(&g.chunkCounter, 0)
 	atomic.StoreUint64(&g.messageCounter, 0)
 	atomic.StoreUint64(&g.blockCounter, 0)
 	atomic.StoreUint64(&g.threadCounter, 0)
+	atomic.StoreUint64(&g.refCounter, 0)
 }
 
 // GetCounters returns current counter values (for debugging/testing)
@@ -75,6 +75,19 @@ func TestIDGenerator(t *testing.T) {
 		}
 	})
 
+	t.Run("GenerateCitationID", func(t *testing.T) {
+		gen := NewIDGenerator()
+		id1 := gen.GenerateCitationID()
+		id2 := gen.GenerateCitationID()
+
+		if id1 != "1" {
+			t.Errorf("Expected 1, got %s", id1)
+		}
+		if id2 != "2" {
+			t.Errorf("Expected 2, got %s", id2)
+		}
+	})
+
 	t.Run("Reset", func(t *testing.T) {
 		gen := NewIDGenerator()
 		gen.GenerateChunkID()
@@ -3,8 +3,10 @@ package output
 import (
 	"fmt"
 
+	"github.com/yaoapp/yao/agent/output/adapters/agui"
 	"github.com/yaoapp/yao/agent/output/adapters/cui"
 	"github.com/yaoapp/yao/agent/output/adapters/openai"
+	"github.com/yaoapp/yao/agent/output/adapters/vercel"
 	"github.com/yaoapp/yao/agent/output/message"
 )
 
@@ -14,6 +16,8 @@ const (
 	AcceptWebCUI     = "cui-web"
 	AccepNativeCUI   = "cui-native"
 	AcceptDesktopCUI = "cui-desktop"
+	AcceptVercelAI   = "vercel-ai"
+	AcceptAGUI       = "ag-ui"
 )
 
 // Output are the options for the output
@@ -36,6 +40,14 @@ func NewOutput(options message.Options) (*Output, error) {
 		// CUI format
 		writer, err = cui.NewWriter(options)
 
+	case AcceptVercelAI:
+		// Vercel AI SDK UI message stream
+		writer, err = vercel.NewWriter(options)
+
+	case AcceptAGUI:
+		// AG-UI events
+		writer, err = agui.NewWriter(options)
+
 	default:
 		// Default to Standard (OpenAI)
 		writer, err = openai.NewWriter(options)
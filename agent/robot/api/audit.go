@@ -0,0 +1,40 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/yaoapp/yao/agent/robot/types"
+	"github.com/yaoapp/yao/audit"
+)
+
+// auditControl records the control action of the robot
+func auditControl(ctx *types.Context, operation string, resourceType string, id string, details map[string]interface{}, err error) {
+	entry := audit.New(operation, audit.CategorySystem).WithResource(resourceType, id).WithError(err)
+	if ctx != nil {
+		entry.WithAuth(ctx.Auth)
+		entry.RequestID = ctx.RequestID
+	}
+	entry.Source = audit.SourceRobot
+	if details != nil {
+		entry.WithDetails(details)
+	}
+	audit.Log(entry)
+}
+
+// auditTrigger records the trigger of the robot, the rejected triggers are recorded as failed
+func auditTrigger(ctx *types.Context, operation string, memberID string, req *TriggerRequest, result *TriggerResult, err error) {
+	details := map[string]interface{}{}
+	if req != nil {
+		details["type"] = string(req.Type)
+		if req.Action != "" {
+			details["action"] = string(req.Action)
+		}
+	}
+	if result != nil {
+		details["execution_id"] = result.JobID
+		if err == nil && !result.Accepted {
+			err = fmt.Errorf("%s", result.Message)
+		}
+	}
+	auditControl(ctx, operation, "robot", memberID, details, err)
+}
@@ -130,7 +130,9 @@ func PauseExecution(ctx *types.Context, execID string) error {
 		return err
 	}
 
-	return mgr.PauseExecution(ctx, execID)
+	err = mgr.PauseExecution(ctx, execID)
+	auditControl(ctx, "robot.execution.pause", "execution", execID, nil, err)
+	return err
 }
 
 // ResumeExecution resumes a paused execution
@@ -144,7 +146,9 @@ func ResumeExecution(ctx *types.Context, execID string) error {
 		return err
 	}
 
-	return mgr.ResumeExecution(ctx, execID)
+	err = mgr.ResumeExecution(ctx, execID)
+	auditControl(ctx, "robot.execution.resume", "execution", execID, nil, err)
+	return err
 }
 
 // StopExecution stops a running execution
@@ -158,7 +162,9 @@ func StopExecution(ctx *types.Context, execID string) error {
 		return err
 	}
 
-	return mgr.StopExecution(ctx, execID)
+	err = mgr.StopExecution(ctx, execID)
+	auditControl(ctx, "robot.execution.stop", "execution", execID, nil, err)
+	return err
 }
 
 // ==================== Execution Status API ====================
@@ -24,16 +24,20 @@ func Trigger(ctx *types.Context, memberID string, req *TriggerRequest) (*Trigger
 		return nil, err
 	}
 
+	var result *TriggerResult
 	switch req.Type {
 	case types.TriggerHuman:
-		return triggerHuman(ctx, mgr, memberID, req)
+		result, err = triggerHuman(ctx, mgr, memberID, req)
 	case types.TriggerEvent:
-		return triggerEvent(ctx, mgr, memberID, req)
+		result, err = triggerEvent(ctx, mgr, memberID, req)
 	case types.TriggerClock:
-		return triggerManual(ctx, mgr, memberID, req)
+		result, err = triggerManual(ctx, mgr, memberID, req)
 	default:
 		return nil, fmt.Errorf("invalid trigger type: %s", req.Type)
 	}
+
+	auditTrigger(ctx, "robot.trigger", memberID, req, result, err)
+	return result, err
 }
 
 // TriggerManual manually triggers a robot execution (for testing or debugging)
@@ -78,7 +82,9 @@ func Intervene(ctx *types.Context, memberID string, req *TriggerRequest) (*Trigg
 		return nil, err
 	}
 
-	return triggerHuman(ctx, mgr, memberID, req)
+	result, err := triggerHuman(ctx, mgr, memberID, req)
+	auditTrigger(ctx, "robot.intervene", memberID, req, result, err)
+	return result, err
 }
 
 // HandleEvent processes an event trigger request
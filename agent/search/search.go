@@ -82,8 +82,12 @@ func (s *Searcher) Search(ctx *context.Context, req *types.Request) (*types.Resu
 		result.Items, _ = s.reranker.Rerank(ctx, req.Query, result.Items, req.Rerank)
 	}
 
-	// Generate citation IDs
+	// Generate citation IDs, the context generator is shared with the other references of the request
 	for _, item := range result.Items {
+		if ctx != nil && ctx.IDGenerator != nil {
+			item.CitationID = ctx.IDGenerator.GenerateCitationID()
+			continue
+		}
 		item.CitationID = s.citation.Next()
 	}
 
@@ -11,6 +11,7 @@ type Config struct {
 	Citation *CitationConfig `json:"citation,omitempty" yaml:"citation,omitempty"`
 	Weights  *WeightsConfig  `json:"weights,omitempty" yaml:"weights,omitempty"`
 	Options  *OptionsConfig  `json:"options,omitempty" yaml:"options,omitempty"`
+	Link     *LinkConfig     `json:"link,omitempty" yaml:"link,omitempty"`
 }
 
 // WebConfig for web search settings
@@ -77,6 +78,20 @@ type OptionsConfig struct {
 	SkipThreshold int `json:"skip_threshold,omitempty" yaml:"skip_threshold,omitempty"` // Skip auto search if user provides >= N results
 }
 
+// LinkConfig for fetching URLs found in user messages
+// Allow and Deny are host patterns: "example.com", "*.example.com" or "*"
+type LinkConfig struct {
+	Enabled      bool     `json:"enabled,omitempty" yaml:"enabled,omitempty"`             // Fetch links in user messages (default: false)
+	Allow        []string `json:"allow,omitempty" yaml:"allow,omitempty"`                 // Allowed hosts, if empty all public hosts are allowed
+	Deny         []string `json:"deny,omitempty" yaml:"deny,omitempty"`                   // Denied hosts, checked before allow
+	AllowPrivate bool     `json:"allow_private,omitempty" yaml:"allow_private,omitempty"` // Allow loopback/private network addresses and the environment proxy (default: false)
+	MaxLinks     int      `json:"max_links,omitempty" yaml:"max_links,omitempty"`         // Max links fetched per message (default: 3)
+	MaxSize      int64    `json:"max_size,omitempty" yaml:"max_size,omitempty"`           // Max response size in bytes (default: 2MB)
+	MaxChars     int      `json:"max_chars,omitempty" yaml:"max_chars,omitempty"`         // Max characters per reference (default: 20000)
+	Timeout      int      `json:"timeout,omitempty" yaml:"timeout,omitempty"`             // Fetch timeout in seconds (default: 10)
+	UserAgent    string   `json:"user_agent,omitempty" yaml:"user_agent,omitempty"`       // User-Agent header (default: "Yao-Agent/1.0")
+}
+
 // GetWeight returns the weight for a source type
 func (c *Config) GetWeight(source SourceType) float64 {
 	if c == nil || c.Weights == nil {
@@ -12,12 +12,16 @@ import (
 	goutext "github.com/yaoapp/gou/text"
 	"github.com/yaoapp/yao/agent/assistant"
 	"github.com/yaoapp/yao/agent/context"
+	"github.com/yaoapp/yao/utils/jsonschema"
 )
 
 // Asserter handles test assertions
 type Asserter struct {
 	// response holds the current response for tool-related assertions
 	response *context.Response
+
+	// scores holds the scores reported by validator agents
+	scores []float64
 }
 
 // NewAsserter creates a new asserter
@@ -31,6 +35,19 @@ func (a *Asserter) WithResponse(response *context.Response) *Asserter {
 	return a
 }
 
+// Score returns the average score reported by validator agents
+// Returns false if no agent assertion reported a score
+func (a *Asserter) Score() (float64, bool) {
+	if len(a.scores) == 0 {
+		return 0, false
+	}
+	var total float64
+	for _, s := range a.scores {
+		total += s
+	}
+	return total / float64(len(a.scores)), true
+}
+
 // Validate validates the output against the test case's assertions
 // Returns (passed, error message)
 func (a *Asserter) Validate(tc *Case, output interface{}) (bool, string) {
@@ -210,6 +227,8 @@ func (a *Asserter) evaluateAssertion(assertion *Assertion, output, input interfa
 		result = a.assertRegex(assertion, output)
 	case "type":
 		result = a.assertType(assertion, output)
+	case "schema":
+		result = a.assertSchema(assertion, output)
 	case "script":
 		result = a.assertScript(assertion, output, input)
 	case "agent":
@@ -524,6 +543,57 @@ func (a *Asserter) assertType(assertion *Assertion, output interface{}) *Asserti
 	return result
 }
 
+// assertSchema validates the output (or the value at Path) against a JSON Schema
+// The schema is taken from Value and can be an object or a JSON string
+func (a *Asserter) assertSchema(assertion *Assertion, output interface{}) *AssertionResult {
+	result := &AssertionResult{
+		Assertion: assertion,
+		Expected:  assertion.Value,
+	}
+
+	if assertion.Value == nil {
+		result.Passed = false
+		result.Message = "schema assertion requires a schema in value"
+		return result
+	}
+
+	validator, err := jsonschema.New(assertion.Value)
+	if err != nil {
+		result.Passed = false
+		result.Message = err.Error()
+		return result
+	}
+
+	// Parse string output as JSON (handles markdown, auto-repair, etc.)
+	data := output
+	if s, ok := output.(string); ok {
+		extracted := goutext.ExtractJSON(s)
+		if extracted == nil {
+			result.Passed = false
+			result.Actual = output
+			result.Message = fmt.Sprintf("output is not valid JSON: %s", truncateOutput(s, 200))
+			return result
+		}
+		data = extracted
+	}
+
+	// Validate a nested value when path is specified
+	if assertion.Path != "" {
+		data = a.extractPath(data, strings.TrimPrefix(assertion.Path, "$."))
+	}
+	result.Actual = data
+
+	if err := validator.Validate(data); err != nil {
+		result.Passed = false
+		result.Message = fmt.Sprintf("output does not match schema: %s", err.Error())
+		return result
+	}
+
+	result.Passed = true
+	result.Message = "output matches schema"
+	return result
+}
+
 // getType returns the type name of a value
 func (a *Asserter) getType(v interface{}) string {
 	if v == nil {
@@ -648,6 +718,10 @@ func (a *Asserter) parseValidatorResponse(response *context.Response, result *As
 		if reason, ok := outputMap["reason"].(string); ok {
 			result.Message = reason
 		}
+		// Keep the score for baseline comparison
+		if score, ok := outputMap["score"].(float64); ok {
+			a.scores = append(a.scores, score)
+		}
 		// Store score and suggestions in expected field for reference
 		result.Expected = outputMap
 	} else {
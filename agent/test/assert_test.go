@@ -854,3 +854,82 @@ func TestMatchToolName(t *testing.T) {
 		})
 	}
 }
+
+func TestAsserter_Schema(t *testing.T) {
+	asserter := NewAsserter()
+
+	schema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"need_search": map[string]interface{}{"type": "boolean"},
+			"search_types": map[string]interface{}{
+				"type":  "array",
+				"items": map[string]interface{}{"type": "string"},
+			},
+		},
+		"required": []interface{}{"need_search"},
+	}
+
+	tests := []struct {
+		name     string
+		tc       *Case
+		output   interface{}
+		expected bool
+	}{
+		{
+			name:     "object matches schema",
+			tc:       &Case{Assert: map[string]interface{}{"type": "schema", "value": schema}},
+			output:   map[string]interface{}{"need_search": true, "search_types": []interface{}{"web"}},
+			expected: true,
+		},
+		{
+			name:     "missing required field",
+			tc:       &Case{Assert: map[string]interface{}{"type": "schema", "value": schema}},
+			output:   map[string]interface{}{"search_types": []interface{}{"web"}},
+			expected: false,
+		},
+		{
+			name:     "wrong field type",
+			tc:       &Case{Assert: map[string]interface{}{"type": "schema", "value": schema}},
+			output:   map[string]interface{}{"need_search": "yes"},
+			expected: false,
+		},
+		{
+			name:     "JSON string in markdown",
+			tc:       &Case{Assert: map[string]interface{}{"type": "schema", "value": schema}},
+			output:   "```json\n{\"need_search\": false}\n```",
+			expected: true,
+		},
+		{
+			name:     "schema as JSON string",
+			tc:       &Case{Assert: map[string]interface{}{"type": "schema", "value": `{"type": "array", "minItems": 2}`}},
+			output:   []interface{}{"a", "b"},
+			expected: true,
+		},
+		{
+			name: "nested value with path",
+			tc: &Case{Assert: map[string]interface{}{
+				"type":  "schema",
+				"path":  "$.data",
+				"value": map[string]interface{}{"type": "array", "maxItems": 1},
+			}},
+			output:   map[string]interface{}{"data": []interface{}{1, 2}},
+			expected: false,
+		},
+		{
+			name:     "missing schema",
+			tc:       &Case{Assert: map[string]interface{}{"type": "schema"}},
+			output:   map[string]interface{}{},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			passed, errMsg := asserter.Validate(tt.tc, tt.output)
+			if passed != tt.expected {
+				t.Errorf("Expected passed=%v, got passed=%v (%s)", tt.expected, passed, errMsg)
+			}
+		})
+	}
+}
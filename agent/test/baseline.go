@@ -0,0 +1,310 @@
+package test
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Baseline change kinds
+const (
+	// BaselineChangeStatus indicates a single-run case changed between passed and not passed
+	BaselineChangeStatus = "status"
+	// BaselineChangePassRate indicates a stability pass rate changed
+	BaselineChangePassRate = "pass_rate"
+	// BaselineChangeScore indicates an agent assertion score changed
+	BaselineChangeScore = "score"
+	// BaselineChangeOverall indicates the overall pass rate changed
+	BaselineChangeOverall = "overall_pass_rate"
+)
+
+// BaselineComparison is the result of comparing a report against a baseline report
+type BaselineComparison struct {
+	// File is the baseline report path
+	File string `json:"file"`
+
+	// Tolerance is the allowed drop before a change counts as a regression
+	Tolerance float64 `json:"tolerance"`
+
+	// PassRate is the overall pass rate of the current run
+	PassRate float64 `json:"pass_rate"`
+
+	// BaselinePassRate is the overall pass rate of the baseline run
+	BaselinePassRate float64 `json:"baseline_pass_rate"`
+
+	// Compared is the number of cases present in both reports
+	Compared int `json:"compared"`
+
+	// Regressions contains cases that got worse than the baseline
+	Regressions []*BaselineChange `json:"regressions,omitempty"`
+
+	// Improvements contains cases that got better than the baseline
+	Improvements []*BaselineChange `json:"improvements,omitempty"`
+
+	// Missing contains baseline case IDs not present in the current run
+	Missing []string `json:"missing,omitempty"`
+}
+
+// BaselineChange describes a single difference from the baseline
+type BaselineChange struct {
+	// ID is the test case identifier (empty for overall changes)
+	ID string `json:"id,omitempty"`
+
+	// Kind is the change kind (status, pass_rate, score, overall_pass_rate)
+	Kind string `json:"kind"`
+
+	// Baseline is the value in the baseline report
+	Baseline float64 `json:"baseline"`
+
+	// Current is the value in the current report
+	Current float64 `json:"current"`
+}
+
+// HasRegressions returns true if any regression was found
+func (c *BaselineComparison) HasRegressions() bool {
+	return len(c.Regressions) > 0
+}
+
+// String returns a human-readable description of the change
+func (ch *BaselineChange) String() string {
+	switch ch.Kind {
+	case BaselineChangeStatus:
+		return fmt.Sprintf("[%s] %s -> %s", ch.ID, statusLabel(ch.Baseline), statusLabel(ch.Current))
+	case BaselineChangeScore:
+		return fmt.Sprintf("[%s] score %.2f -> %.2f", ch.ID, ch.Baseline, ch.Current)
+	case BaselineChangeOverall:
+		return fmt.Sprintf("overall pass rate %.1f%% -> %.1f%%", ch.Baseline, ch.Current)
+	default:
+		return fmt.Sprintf("[%s] pass rate %.1f%% -> %.1f%%", ch.ID, ch.Baseline, ch.Current)
+	}
+}
+
+func statusLabel(passRate float64) string {
+	if passRate == 100 {
+		return "passed"
+	}
+	return "failed"
+}
+
+// caseSnapshot is the comparable state of a single test case
+type caseSnapshot struct {
+	passRate  float64
+	score     *float64
+	stability bool
+}
+
+// jsonlEvent is a line of a JSONL report
+type jsonlEvent struct {
+	Type            string   `json:"type"`
+	ID              string   `json:"id"`
+	Status          Status   `json:"status"`
+	DurationMs      int64    `json:"duration_ms"`
+	Score           *float64 `json:"score"`
+	AvgScore        *float64 `json:"avg_score"`
+	Runs            int      `json:"runs"`
+	PassRate        float64  `json:"pass_rate"`
+	Total           int      `json:"total"`
+	Passed          int      `json:"passed"`
+	Failed          int      `json:"failed"`
+	Skipped         int      `json:"skipped"`
+	Errors          int      `json:"errors"`
+	Timeouts        int      `json:"timeouts"`
+	OverallPassRate float64  `json:"overall_pass_rate"`
+}
+
+// LoadBaseline loads a baseline report from a JSON report file
+// JSONL reports (one event per line) are also accepted
+func LoadBaseline(path string) (*Report, error) {
+	data, err := os.ReadFile(ResolvePathWithYaoRoot(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+
+	var report Report
+	if err := jsoniter.Unmarshal(data, &report); err == nil && report.Summary != nil {
+		return &report, nil
+	}
+
+	return parseJSONLReport(data)
+}
+
+// parseJSONLReport rebuilds a report from the events written by JSONLReporter
+func parseJSONLReport(data []byte) (*Report, error) {
+	report := &Report{Summary: &Summary{}}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var event jsonlEvent
+		if err := jsoniter.Unmarshal(line, &event); err != nil {
+			return nil, fmt.Errorf("invalid baseline at line %d: %w", lineNum, err)
+		}
+
+		switch event.Type {
+		case "result":
+			report.Results = append(report.Results, &Result{
+				ID:         event.ID,
+				Status:     event.Status,
+				DurationMs: event.DurationMs,
+				Score:      event.Score,
+			})
+		case "stability":
+			report.StabilityResults = append(report.StabilityResults, &StabilityResult{
+				ID:       event.ID,
+				Runs:     event.Runs,
+				Passed:   event.Passed,
+				Failed:   event.Failed,
+				PassRate: event.PassRate,
+				AvgScore: event.AvgScore,
+			})
+		case "summary":
+			report.Summary = &Summary{
+				Total:           event.Total,
+				Passed:          event.Passed,
+				Failed:          event.Failed,
+				Skipped:         event.Skipped,
+				Errors:          event.Errors,
+				Timeouts:        event.Timeouts,
+				OverallPassRate: event.OverallPassRate,
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+
+	if len(report.Results) == 0 && len(report.StabilityResults) == 0 {
+		return nil, fmt.Errorf("baseline contains no results")
+	}
+
+	return report, nil
+}
+
+// CompareBaseline compares the current report with a baseline report
+// A drop larger than tolerance (percentage points) in a case pass rate, a case
+// score or the overall pass rate is reported as a regression
+func CompareBaseline(current, baseline *Report, tolerance float64) *BaselineComparison {
+	cmp := &BaselineComparison{
+		Tolerance:        tolerance,
+		PassRate:         overallPassRate(current),
+		BaselinePassRate: overallPassRate(baseline),
+	}
+
+	currentCases := snapshotCases(current)
+	baselineCases := snapshotCases(baseline)
+
+	ids := make([]string, 0, len(baselineCases))
+	for id := range baselineCases {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		base := baselineCases[id]
+		cur, ok := currentCases[id]
+		if !ok {
+			cmp.Missing = append(cmp.Missing, id)
+			continue
+		}
+		cmp.Compared++
+
+		kind := BaselineChangePassRate
+		if !base.stability && !cur.stability {
+			kind = BaselineChangeStatus
+		}
+		cmp.add(&BaselineChange{ID: id, Kind: kind, Baseline: base.passRate, Current: cur.passRate})
+
+		if base.score != nil && cur.score != nil {
+			cmp.add(&BaselineChange{ID: id, Kind: BaselineChangeScore, Baseline: *base.score, Current: *cur.score})
+		}
+	}
+
+	if cmp.Compared > 0 {
+		cmp.add(&BaselineChange{Kind: BaselineChangeOverall, Baseline: cmp.BaselinePassRate, Current: cmp.PassRate})
+	}
+
+	return cmp
+}
+
+// add records a change as a regression or an improvement
+// Tolerance is in percentage points; scores are 0-1 fractions so it is scaled down
+func (c *BaselineComparison) add(change *BaselineChange) {
+	tolerance := c.Tolerance
+	if change.Kind == BaselineChangeScore {
+		tolerance = c.Tolerance / 100
+	}
+
+	switch {
+	case change.Current < change.Baseline-tolerance:
+		c.Regressions = append(c.Regressions, change)
+	case change.Current > change.Baseline+tolerance:
+		c.Improvements = append(c.Improvements, change)
+	}
+}
+
+// snapshotCases extracts the comparable state of each case in a report
+func snapshotCases(report *Report) map[string]*caseSnapshot {
+	cases := map[string]*caseSnapshot{}
+	if report == nil {
+		return cases
+	}
+
+	for _, r := range report.Results {
+		if r.Status == StatusSkipped {
+			continue
+		}
+		snap := &caseSnapshot{score: r.Score}
+		if r.Status == StatusPassed {
+			snap.passRate = 100
+		}
+		cases[r.ID] = snap
+	}
+
+	for _, sr := range report.StabilityResults {
+		cases[sr.ID] = &caseSnapshot{passRate: sr.PassRate, score: sr.AvgScore, stability: true}
+	}
+
+	return cases
+}
+
+// overallPassRate returns the overall pass rate of a report
+func overallPassRate(report *Report) float64 {
+	if report == nil || report.Summary == nil {
+		return 0
+	}
+	if len(report.StabilityResults) > 0 {
+		return report.Summary.OverallPassRate
+	}
+	executed := report.Summary.Total - report.Summary.Skipped
+	if executed <= 0 {
+		return 0
+	}
+	return float64(report.Summary.Passed) / float64(executed) * 100
+}
+
+// compareBaseline compares the report with the baseline from options and prints the result
+func (r *Executor) compareBaseline(report *Report) error {
+	baseline, err := LoadBaseline(r.opts.Baseline)
+	if err != nil {
+		return err
+	}
+
+	cmp := CompareBaseline(report, baseline, r.opts.BaselineTolerance)
+	cmp.File = r.opts.Baseline
+	report.Baseline = cmp
+
+	r.output.BaselineResult(cmp)
+	return nil
+}
@@ -0,0 +1,114 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scorePtr(v float64) *float64 {
+	return &v
+}
+
+func TestCompareBaseline_SingleRun(t *testing.T) {
+	baseline := &Report{
+		Summary: &Summary{Total: 3, Passed: 2, Failed: 1},
+		Results: []*Result{
+			{ID: "T001", Status: StatusPassed, Score: scorePtr(0.9)},
+			{ID: "T002", Status: StatusPassed},
+			{ID: "T003", Status: StatusFailed},
+		},
+	}
+	current := &Report{
+		Summary: &Summary{Total: 3, Passed: 2, Failed: 1},
+		Results: []*Result{
+			{ID: "T001", Status: StatusPassed, Score: scorePtr(0.7)},
+			{ID: "T002", Status: StatusFailed},
+			{ID: "T003", Status: StatusPassed},
+		},
+	}
+
+	cmp := CompareBaseline(current, baseline, 0)
+	assert.True(t, cmp.HasRegressions())
+	assert.Equal(t, 3, cmp.Compared)
+
+	kinds := map[string]string{}
+	for _, r := range cmp.Regressions {
+		kinds[r.ID] = r.Kind
+	}
+	assert.Equal(t, BaselineChangeScore, kinds["T001"])
+	assert.Equal(t, BaselineChangeStatus, kinds["T002"])
+
+	require.Len(t, cmp.Improvements, 1)
+	assert.Equal(t, "T003", cmp.Improvements[0].ID)
+
+	// Tolerance absorbs the score drop but not the status change
+	cmp = CompareBaseline(current, baseline, 50)
+	require.Len(t, cmp.Regressions, 1)
+	assert.Equal(t, "T002", cmp.Regressions[0].ID)
+
+	report := &Report{Summary: current.Summary, Baseline: cmp}
+	report.Summary.Failed = 0
+	assert.True(t, report.HasFailures())
+}
+
+func TestCompareBaseline_Stability(t *testing.T) {
+	baseline := &Report{
+		Summary:          &Summary{Total: 2, RunsPerCase: 5, OverallPassRate: 90},
+		StabilityResults: []*StabilityResult{{ID: "T001", PassRate: 100}, {ID: "T002", PassRate: 80}},
+	}
+	current := &Report{
+		Summary:          &Summary{Total: 1, RunsPerCase: 5, OverallPassRate: 80},
+		StabilityResults: []*StabilityResult{{ID: "T001", PassRate: 80}},
+	}
+
+	cmp := CompareBaseline(current, baseline, 20)
+	assert.False(t, cmp.HasRegressions())
+	assert.Equal(t, []string{"T002"}, cmp.Missing)
+
+	cmp = CompareBaseline(current, baseline, 0)
+	require.Len(t, cmp.Regressions, 2)
+	assert.Equal(t, BaselineChangePassRate, cmp.Regressions[0].Kind)
+	assert.Equal(t, BaselineChangeOverall, cmp.Regressions[1].Kind)
+}
+
+func TestLoadBaseline(t *testing.T) {
+	dir := t.TempDir()
+	report := &Report{
+		Summary:  &Summary{AgentID: "tests.baseline", Total: 2, Passed: 1, Failed: 1},
+		Results:  []*Result{{ID: "T001", Status: StatusPassed, Score: scorePtr(0.8)}, {ID: "T002", Status: StatusFailed}},
+		Metadata: &ReportMetadata{},
+	}
+
+	// JSON report
+	jsonFile := filepath.Join(dir, "baseline.json")
+	f, err := os.Create(jsonFile)
+	require.NoError(t, err)
+	require.NoError(t, NewJSONReporter().Write(report, f))
+	f.Close()
+
+	loaded, err := LoadBaseline(jsonFile)
+	require.NoError(t, err)
+	require.Len(t, loaded.Results, 2)
+	assert.Equal(t, 0.8, *loaded.Results[0].Score)
+
+	// JSONL report
+	jsonlFile := filepath.Join(dir, "baseline.jsonl")
+	f, err = os.Create(jsonlFile)
+	require.NoError(t, err)
+	require.NoError(t, NewJSONLReporter().Write(report, f))
+	f.Close()
+
+	loaded, err = LoadBaseline(jsonlFile)
+	require.NoError(t, err)
+	require.Len(t, loaded.Results, 2)
+	assert.Equal(t, StatusFailed, loaded.Results[1].Status)
+	assert.Equal(t, 0.8, *loaded.Results[0].Score)
+	assert.Equal(t, 1, loaded.Summary.Passed)
+
+	_, err = LoadBaseline(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}
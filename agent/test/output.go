@@ -373,6 +373,37 @@ func (w *OutputWriter) StabilityResult(sr *StabilityResult) {
 	color.New(color.FgHiBlack).Printf(" avg:%.0fms\n", sr.AvgDurationMs)
 }
 
+// BaselineResult prints the comparison with the baseline report
+func (w *OutputWriter) BaselineResult(cmp *BaselineComparison) {
+	w.SubHeader("Baseline")
+
+	color.New(color.FgWhite).Printf("  Baseline:  ")
+	color.New(color.FgCyan).Printf("%s\n", cmp.File)
+
+	color.New(color.FgWhite).Printf("  Pass Rate: ")
+	fmt.Printf("%.1f%% -> %.1f%%\n", cmp.BaselinePassRate, cmp.PassRate)
+
+	color.New(color.FgWhite).Printf("  Compared:  ")
+	fmt.Printf("%d\n", cmp.Compared)
+
+	if len(cmp.Missing) > 0 {
+		color.New(color.FgWhite).Printf("  Missing:   ")
+		color.New(color.FgYellow).Printf("%d\n", len(cmp.Missing))
+	}
+
+	for _, change := range cmp.Improvements {
+		color.New(color.FgGreen).Printf("  ↑ %s\n", change.String())
+	}
+
+	for _, change := range cmp.Regressions {
+		color.New(color.FgRed).Printf("  ↓ %s\n", change.String())
+	}
+
+	if !cmp.HasRegressions() {
+		color.New(color.FgGreen).Println("  No regressions")
+	}
+}
+
 // Helper functions
 
 func truncateString(s string, maxLen int) string {
@@ -2,9 +2,11 @@ package test
 
 import (
 	"bufio"
+	"encoding/xml"
 	"fmt"
 	"html/template"
 	"io"
+	"sort"
 	"strings"
 	"time"
 
@@ -57,6 +59,9 @@ func (r *JSONLReporter) Write(report *Report, w io.Writer) error {
 			if result.Error != "" {
 				resultEvent["error"] = result.Error
 			}
+			if result.Score != nil {
+				resultEvent["score"] = *result.Score
+			}
 			if err := writeJSONLineToWriter(writer, resultEvent); err != nil {
 				return err
 			}
@@ -77,6 +82,9 @@ func (r *JSONLReporter) Write(report *Report, w io.Writer) error {
 				"stability_class": sr.StabilityClass,
 				"avg_duration_ms": sr.AvgDurationMs,
 			}
+			if sr.AvgScore != nil {
+				stabilityEvent["avg_score"] = *sr.AvgScore
+			}
 			if err := writeJSONLineToWriter(writer, stabilityEvent); err != nil {
 				return err
 			}
@@ -514,6 +522,302 @@ const htmlTemplate = `<!DOCTYPE html>
 </body>
 </html>`
 
+// JUnitReporter generates JUnit XML format reports
+type JUnitReporter struct{}
+
+// NewJUnitReporter creates a new JUnit reporter
+func NewJUnitReporter() *JUnitReporter {
+	return &JUnitReporter{}
+}
+
+// Generate generates a JUnit report
+func (r *JUnitReporter) Generate(report *Report) error {
+	return nil
+}
+
+// junitTestSuites is the root element of a JUnit XML report
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite is a JUnit test suite
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       string          `xml:"time,attr"`
+	Timestamp  string          `xml:"timestamp,attr,omitempty"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Cases      []junitTestCase `xml:"testcase"`
+}
+
+// junitProperty is a JUnit suite property
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// junitTestCase is a JUnit test case
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+// junitMessage is a JUnit failure, error or skipped element
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Body    string `xml:",chardata"`
+}
+
+// Write writes the report in JUnit XML format
+func (r *JUnitReporter) Write(report *Report, w io.Writer) error {
+	suite := junitTestSuite{
+		Name:     report.Summary.AgentID,
+		Tests:    report.Summary.Total,
+		Failures: report.Summary.Failed,
+		Errors:   report.Summary.Errors + report.Summary.Timeouts,
+		Skipped:  report.Summary.Skipped,
+		Time:     junitSeconds(report.Summary.DurationMs),
+	}
+	if report.Metadata != nil && !report.Metadata.StartedAt.IsZero() {
+		suite.Timestamp = report.Metadata.StartedAt.Format(time.RFC3339)
+	}
+	if report.Summary.Connector != "" {
+		suite.Properties = append(suite.Properties, junitProperty{Name: "connector", Value: report.Summary.Connector})
+	}
+	if report.Summary.RunsPerCase > 1 {
+		suite.Properties = append(suite.Properties, junitProperty{Name: "runs_per_case", Value: fmt.Sprintf("%d", report.Summary.RunsPerCase)})
+	}
+
+	for _, result := range report.Results {
+		tc := junitTestCase{
+			Name:      result.ID,
+			Classname: report.Summary.AgentID,
+			Time:      junitSeconds(result.DurationMs),
+		}
+		switch result.Status {
+		case StatusFailed:
+			tc.Failure = &junitMessage{Message: result.Error, Type: "AssertionError", Body: junitOutput(result.Output)}
+		case StatusError:
+			tc.Error = &junitMessage{Message: result.Error, Type: "Error"}
+		case StatusTimeout:
+			tc.Error = &junitMessage{Message: result.Error, Type: "Timeout"}
+		case StatusSkipped:
+			tc.Skipped = &junitMessage{Message: result.Error}
+		}
+		if result.Status == StatusPassed && result.Output != nil {
+			tc.SystemOut = junitOutput(result.Output)
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	for _, sr := range report.StabilityResults {
+		tc := junitTestCase{
+			Name:      sr.ID,
+			Classname: report.Summary.AgentID,
+			Time:      junitSeconds(int64(sr.AvgDurationMs)),
+			SystemOut: fmt.Sprintf("%d/%d runs passed (%.1f%%), %s", sr.Passed, sr.Runs, sr.PassRate, sr.StabilityClass),
+		}
+		if !sr.Stable {
+			tc.Failure = &junitMessage{
+				Message: fmt.Sprintf("pass rate %.1f%% (%s)", sr.PassRate, sr.StabilityClass),
+				Type:    "Unstable",
+			}
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	// Baseline regressions are reported as a separate suite so CI shows them as failures
+	suites := []junitTestSuite{suite}
+	if report.Baseline != nil {
+		baseline := junitTestSuite{
+			Name:  report.Summary.AgentID + ".baseline",
+			Tests: 1,
+			Time:  "0",
+		}
+		tc := junitTestCase{Name: "regressions", Classname: report.Summary.AgentID + ".baseline", Time: "0"}
+		if report.Baseline.HasRegressions() {
+			lines := make([]string, 0, len(report.Baseline.Regressions))
+			for _, change := range report.Baseline.Regressions {
+				lines = append(lines, change.String())
+			}
+			tc.Failure = &junitMessage{
+				Message: fmt.Sprintf("%d regression(s) against %s", len(report.Baseline.Regressions), report.Baseline.File),
+				Type:    "Regression",
+				Body:    strings.Join(lines, "\n"),
+			}
+			baseline.Failures = 1
+		}
+		baseline.Cases = []junitTestCase{tc}
+		suites = append(suites, baseline)
+	}
+
+	root := junitTestSuites{
+		Name:   report.Summary.AgentID,
+		Time:   junitSeconds(report.Summary.DurationMs),
+		Suites: suites,
+	}
+	for _, s := range suites {
+		root.Tests += s.Tests
+		root.Failures += s.Failures
+		root.Errors += s.Errors
+		root.Skipped += s.Skipped
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(root); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// junitSeconds formats milliseconds as JUnit seconds
+func junitSeconds(ms int64) string {
+	return fmt.Sprintf("%.3f", float64(ms)/1000)
+}
+
+// junitOutput converts a test output to text
+func junitOutput(output interface{}) string {
+	if output == nil {
+		return ""
+	}
+	if s, ok := output.(string); ok {
+		return s
+	}
+	data, err := jsoniter.Marshal(output)
+	if err != nil {
+		return fmt.Sprintf("%v", output)
+	}
+	return string(data)
+}
+
+// TAPReporter generates TAP (Test Anything Protocol) version 13 reports
+type TAPReporter struct{}
+
+// NewTAPReporter creates a new TAP reporter
+func NewTAPReporter() *TAPReporter {
+	return &TAPReporter{}
+}
+
+// Generate generates a TAP report
+func (r *TAPReporter) Generate(report *Report) error {
+	return nil
+}
+
+// Write writes the report in TAP format
+func (r *TAPReporter) Write(report *Report, w io.Writer) error {
+	var sb strings.Builder
+
+	total := len(report.Results) + len(report.StabilityResults)
+	if report.Baseline != nil {
+		total++
+	}
+
+	sb.WriteString("TAP version 13\n")
+	sb.WriteString(fmt.Sprintf("1..%d\n", total))
+
+	n := 0
+	for _, result := range report.Results {
+		n++
+		switch result.Status {
+		case StatusPassed:
+			sb.WriteString(fmt.Sprintf("ok %d - %s\n", n, result.ID))
+		case StatusSkipped:
+			sb.WriteString(fmt.Sprintf("ok %d - %s # SKIP\n", n, result.ID))
+		default:
+			sb.WriteString(fmt.Sprintf("not ok %d - %s\n", n, result.ID))
+			writeTAPDiagnostics(&sb, map[string]interface{}{
+				"status":      string(result.Status),
+				"message":     result.Error,
+				"duration_ms": result.DurationMs,
+			})
+		}
+	}
+
+	for _, sr := range report.StabilityResults {
+		n++
+		if sr.Stable {
+			sb.WriteString(fmt.Sprintf("ok %d - %s\n", n, sr.ID))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("not ok %d - %s\n", n, sr.ID))
+		writeTAPDiagnostics(&sb, map[string]interface{}{
+			"stability_class": string(sr.StabilityClass),
+			"pass_rate":       fmt.Sprintf("%.1f", sr.PassRate),
+			"runs":            sr.Runs,
+			"passed":          sr.Passed,
+		})
+	}
+
+	if report.Baseline != nil {
+		n++
+		if !report.Baseline.HasRegressions() {
+			sb.WriteString(fmt.Sprintf("ok %d - baseline\n", n))
+		} else {
+			sb.WriteString(fmt.Sprintf("not ok %d - baseline\n", n))
+			regressions := make([]string, 0, len(report.Baseline.Regressions))
+			for _, change := range report.Baseline.Regressions {
+				regressions = append(regressions, change.String())
+			}
+			writeTAPDiagnostics(&sb, map[string]interface{}{
+				"baseline":    report.Baseline.File,
+				"regressions": regressions,
+			})
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// writeTAPDiagnostics writes a YAML diagnostic block for a failed TAP test
+func writeTAPDiagnostics(sb *strings.Builder, diag map[string]interface{}) {
+	keys := make([]string, 0, len(diag))
+	for k := range diag {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	sb.WriteString("  ---\n")
+	for _, k := range keys {
+		switch v := diag[k].(type) {
+		case []string:
+			sb.WriteString(fmt.Sprintf("  %s:\n", k))
+			for _, item := range v {
+				sb.WriteString(fmt.Sprintf("    - %q\n", item))
+			}
+		case string:
+			if v == "" {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("  %s: %q\n", k, v))
+		default:
+			sb.WriteString(fmt.Sprintf("  %s: %v\n", k, v))
+		}
+	}
+	sb.WriteString("  ...\n")
+}
+
 // AgentReporter uses a custom agent to generate reports
 type AgentReporter struct {
 	agentID string
@@ -661,6 +965,10 @@ func GetReporter(format OutputFormat) Reporter {
 		return NewHTMLReporter()
 	case FormatMarkdown:
 		return NewMarkdownReporter()
+	case FormatJUnit:
+		return NewJUnitReporter()
+	case FormatTAP:
+		return NewTAPReporter()
 	default:
 		return NewJSONLReporter()
 	}
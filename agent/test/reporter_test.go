@@ -0,0 +1,75 @@
+package test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testReport() *Report {
+	return &Report{
+		Summary: &Summary{AgentID: "tests.reporter", Connector: "gpt-4o", Total: 4, Passed: 1, Failed: 1, Errors: 1, Skipped: 1, DurationMs: 1500},
+		Results: []*Result{
+			{ID: "T001", Status: StatusPassed, DurationMs: 200, Output: "hello"},
+			{ID: "T002", Status: StatusFailed, DurationMs: 300, Error: "output does not contain 'world'", Output: map[string]interface{}{"a": 1}},
+			{ID: "T003", Status: StatusError, DurationMs: 1000, Error: "connector error"},
+			{ID: "T004", Status: StatusSkipped},
+		},
+		Metadata: &ReportMetadata{StartedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)},
+	}
+}
+
+func TestJUnitReporter_Write(t *testing.T) {
+	report := testReport()
+	report.Baseline = &BaselineComparison{
+		File:        "baseline.json",
+		Regressions: []*BaselineChange{{ID: "T002", Kind: BaselineChangeStatus, Baseline: 100, Current: 0}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, NewJUnitReporter().Write(report, &buf))
+
+	var suites junitTestSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &suites))
+	require.Len(t, suites.Suites, 2)
+
+	suite := suites.Suites[0]
+	assert.Equal(t, "tests.reporter", suite.Name)
+	assert.Equal(t, "1.500", suite.Time)
+	require.Len(t, suite.Cases, 4)
+	assert.Nil(t, suite.Cases[0].Failure)
+	assert.Equal(t, "hello", suite.Cases[0].SystemOut)
+	require.NotNil(t, suite.Cases[1].Failure)
+	assert.Equal(t, "output does not contain 'world'", suite.Cases[1].Failure.Message)
+	require.NotNil(t, suite.Cases[2].Error)
+	require.NotNil(t, suite.Cases[3].Skipped)
+
+	baseline := suites.Suites[1]
+	require.Len(t, baseline.Cases, 1)
+	require.NotNil(t, baseline.Cases[0].Failure)
+	assert.Contains(t, baseline.Cases[0].Failure.Body, "[T002] passed -> failed")
+	assert.Equal(t, 2, suites.Failures)
+}
+
+func TestTAPReporter_Write(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, NewTAPReporter().Write(testReport(), &buf))
+
+	out := buf.String()
+	assert.Contains(t, out, "TAP version 13\n1..4\n")
+	assert.Contains(t, out, "ok 1 - T001\n")
+	assert.Contains(t, out, "not ok 2 - T002\n  ---\n")
+	assert.Contains(t, out, "  message: \"connector error\"\n")
+	assert.Contains(t, out, "ok 4 - T004 # SKIP\n")
+}
+
+func TestGetOutputFormat_CI(t *testing.T) {
+	assert.Equal(t, FormatJUnit, GetOutputFormat("report.xml"))
+	assert.Equal(t, FormatTAP, GetOutputFormat("report.tap"))
+	assert.IsType(t, &JUnitReporter{}, GetReporter(FormatJUnit))
+	assert.IsType(t, &TAPReporter{}, GetReporter(FormatTAP))
+}
@@ -199,6 +199,10 @@ func GetOutputFormat(outputPath string) OutputFormat {
 		return FormatHTML
 	case ".md", ".markdown":
 		return FormatMarkdown
+	case ".xml":
+		return FormatJUnit
+	case ".tap":
+		return FormatTAP
 	default:
 		return FormatJSON // Default to JSON
 	}
@@ -322,6 +326,12 @@ func MergeOptions(opts *Options, defaults *Options) *Options {
 	if opts.FailFast {
 		result.FailFast = opts.FailFast
 	}
+	if opts.Baseline != "" {
+		result.Baseline = opts.Baseline
+	}
+	if opts.BaselineTolerance > 0 {
+		result.BaselineTolerance = opts.BaselineTolerance
+	}
 
 	return &result
 }
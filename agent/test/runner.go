@@ -63,6 +63,13 @@ func (r *Executor) RunScriptTests() (*Report, error) {
 	// Convert to standard report for unified output handling
 	report := scriptReport.ToReport()
 
+	// Compare with baseline
+	if r.opts.Baseline != "" {
+		if err := r.compareBaseline(report); err != nil {
+			return nil, err
+		}
+	}
+
 	// Write output if specified
 	if r.opts.OutputFile != "" {
 		err = r.writeOutput(report)
@@ -341,6 +348,13 @@ func (r *Executor) RunTests() (*Report, error) {
 	// Print summary
 	r.output.Summary(report.Summary, time.Since(startTime))
 
+	// Compare with baseline
+	if r.opts.Baseline != "" {
+		if err := r.compareBaseline(report); err != nil {
+			return nil, err
+		}
+	}
+
 	// Write output
 	if r.opts.OutputFile != "" {
 		err = r.writeOutput(report)
@@ -505,6 +519,9 @@ func (r *Executor) runSingleTest(ast *assistant.Assistant, tc *Case, agentID str
 	// Validate result using asserter (with response for tool_called assertions)
 	asserter := NewAsserter().WithResponse(response)
 	passed, errMsg := asserter.Validate(tc, result.Output)
+	if score, ok := asserter.Score(); ok {
+		result.Score = &score
+	}
 	if passed {
 		result.Status = StatusPassed
 	} else {
@@ -606,6 +623,7 @@ func (r *Executor) runStabilityTests(ast *assistant.Assistant, testCases []*Case
 				DurationMs: result.DurationMs,
 				Output:     result.Output,
 				Error:      result.Error,
+				Score:      result.Score,
 			}
 			sr.RunDetails = append(sr.RunDetails, rd)
 		}
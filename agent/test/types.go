@@ -36,6 +36,10 @@ const (
 	FormatHTML OutputFormat = "html"
 	// FormatMarkdown outputs Markdown format (for documentation)
 	FormatMarkdown OutputFormat = "markdown"
+	// FormatJUnit outputs JUnit XML format (for CI test result views)
+	FormatJUnit OutputFormat = "junit"
+	// FormatTAP outputs TAP (Test Anything Protocol) format
+	FormatTAP OutputFormat = "tap"
 )
 
 // StabilityClass represents the stability classification of a test case
@@ -131,6 +135,17 @@ type Options struct {
 	// If not set, default JSONL format is used
 	ReporterID string `json:"reporter_id,omitempty"`
 
+	// Baseline
+	// ===============================
+
+	// Baseline is the path to a previous JSON report used as the regression baseline
+	// When set, the run fails if scores or pass rates drop below the baseline
+	Baseline string `json:"baseline,omitempty"`
+
+	// BaselineTolerance is the allowed drop before a change counts as a regression
+	// In percentage points for pass rates, scores (0-1) use tolerance/100, default is 0
+	BaselineTolerance float64 `json:"baseline_tolerance,omitempty"`
+
 	// Behavior
 	// ===============================
 
@@ -501,7 +516,7 @@ type Assertion struct {
 	// - "regex": match output against regex pattern
 	// - "script": run a custom assertion script
 	// - "type": check output type (string, object, array, number, boolean)
-	// - "schema": validate against JSON schema
+	// - "schema": validate against JSON schema (schema in Value, optional Path)
 	// - "agent": use an agent to validate the response
 	Type string `json:"type"`
 
@@ -641,6 +656,9 @@ type Result struct {
 	// Error contains the error message if status is failed/error/timeout
 	Error string `json:"error,omitempty"`
 
+	// Score is the average score reported by agent assertions (if any)
+	Score *float64 `json:"score,omitempty"`
+
 	// Options contains the context options used for this test case
 	Options *CaseOptions `json:"options,omitempty"`
 
@@ -664,6 +682,9 @@ type RunDetail struct {
 
 	// Error contains the error message if this run failed
 	Error string `json:"error,omitempty"`
+
+	// Score is the average score reported by agent assertions (if any)
+	Score *float64 `json:"score,omitempty"`
 }
 
 // StabilityResult represents the stability analysis result for a test case
@@ -689,6 +710,9 @@ type StabilityResult struct {
 	// PassRate is the pass rate percentage (0-100)
 	PassRate float64 `json:"pass_rate"`
 
+	// AvgScore is the average score across runs that reported one
+	AvgScore *float64 `json:"avg_score,omitempty"`
+
 	// Consistency is a measure of output consistency (0-1)
 	// 1.0 means all outputs are identical, lower values indicate variation
 	Consistency float64 `json:"consistency"`
@@ -720,10 +744,17 @@ func (sr *StabilityResult) CalculateStability() {
 	sr.Failed = 0
 
 	var totalDuration int64
+	var totalScore float64
+	var scored int
 	sr.MinDurationMs = math.MaxInt64
 	sr.MaxDurationMs = 0
 
 	for _, rd := range sr.RunDetails {
+		if rd.Score != nil {
+			totalScore += *rd.Score
+			scored++
+		}
+
 		if rd.Status == StatusPassed {
 			sr.Passed++
 		} else {
@@ -742,6 +773,12 @@ func (sr *StabilityResult) CalculateStability() {
 	// Calculate pass rate
 	sr.PassRate = float64(sr.Passed) / float64(sr.Runs) * 100
 
+	// Calculate average score
+	if scored > 0 {
+		avg := totalScore / float64(scored)
+		sr.AvgScore = &avg
+	}
+
 	// Calculate average duration
 	sr.AvgDurationMs = float64(totalDuration) / float64(sr.Runs)
 
@@ -840,6 +877,9 @@ type Report struct {
 	// StabilityResults contains stability analysis results (for multiple runs)
 	StabilityResults []*StabilityResult `json:"stability_results,omitempty"`
 
+	// Baseline contains the comparison with the baseline report (if specified)
+	Baseline *BaselineComparison `json:"baseline,omitempty"`
+
 	// Metadata contains additional report metadata
 	Metadata *ReportMetadata `json:"metadata"`
 }
@@ -865,8 +905,12 @@ type ReportMetadata struct {
 	Options *Options `json:"options,omitempty"`
 }
 
-// HasFailures returns true if there are any failed, error, or timeout tests
+// HasFailures returns true if there are any failed, error, or timeout tests,
+// or if the run regressed against the baseline
 func (r *Report) HasFailures() bool {
+	if r.Baseline != nil && r.Baseline.HasRegressions() {
+		return true
+	}
 	return r.Summary.Failed > 0 || r.Summary.Errors > 0 || r.Summary.Timeouts > 0
 }
 
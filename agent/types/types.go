@@ -5,6 +5,7 @@ import (
 	"github.com/yaoapp/yao/agent/assistant"
 	searchTypes "github.com/yaoapp/yao/agent/search/types"
 	store "github.com/yaoapp/yao/agent/store/types"
+	"github.com/yaoapp/yao/agent/usage"
 )
 
 // DSL AI assistant
@@ -28,6 +29,7 @@ type DSL struct {
 	Models map[string]openai.Capabilities `json:"models,omitempty" yaml:"models,omitempty"` // The model capabilities configuration
 	KB     *store.KBSetting               `json:"kb,omitempty" yaml:"kb,omitempty"`         // The knowledge base configuration loaded from agent/kb.yml
 	Search *searchTypes.Config            `json:"search,omitempty" yaml:"search,omitempty"` // The search configuration loaded from agent/search.yao
+	Usage  *usage.Config                  `json:"usage,omitempty" yaml:"usage,omitempty"`   // The usage prices and quotas loaded from agent/usage.yml
 
 	// Internal
 	// ===============================
@@ -0,0 +1,112 @@
+package usage
+
+import (
+	"fmt"
+	"time"
+)
+
+// Scope of a quota
+const (
+	ScopeUser      = "user"
+	ScopeTeam      = "team"
+	ScopeAssistant = "assistant"
+)
+
+// Period of a quota
+const (
+	PeriodDaily   = "daily"
+	PeriodMonthly = "monthly"
+)
+
+// Config the usage accounting configuration loaded from agent/usage.yml
+type Config struct {
+	Disabled bool             `json:"disabled,omitempty" yaml:"disabled,omitempty"` // Disable usage recording and quota enforcement
+	Currency string           `json:"currency,omitempty" yaml:"currency,omitempty"` // The currency of the price table, default is USD
+	Prices   map[string]Price `json:"prices,omitempty" yaml:"prices,omitempty"`     // The price table, keyed by connector ID, "*" is the fallback
+	Quotas   []Quota          `json:"quotas,omitempty" yaml:"quotas,omitempty"`     // The quotas enforced before each completion
+}
+
+// Price the price of a connector, per 1M tokens
+type Price struct {
+	Input  float64 `json:"input" yaml:"input"`   // Price per 1M prompt tokens
+	Output float64 `json:"output" yaml:"output"` // Price per 1M completion tokens
+}
+
+// Quota a token or cost limit for a scope in a period
+type Quota struct {
+	Scope  string  `json:"scope" yaml:"scope"`                       // user, team or assistant
+	ID     string  `json:"id,omitempty" yaml:"id,omitempty"`         // The user, team or assistant ID, empty applies to every subject of the scope
+	Period string  `json:"period" yaml:"period"`                     // daily or monthly
+	Tokens int64   `json:"tokens,omitempty" yaml:"tokens,omitempty"` // Max total tokens in the period, 0 means unlimited
+	Cost   float64 `json:"cost,omitempty" yaml:"cost,omitempty"`     // Max cost in the period, 0 means unlimited
+}
+
+// Subject who a completion is metered for
+type Subject struct {
+	UserID      string `json:"user_id,omitempty"`
+	TeamID      string `json:"team_id,omitempty"`
+	TenantID    string `json:"tenant_id,omitempty"`
+	AssistantID string `json:"assistant_id"`
+	Connector   string `json:"connector"`
+}
+
+// Record a metered completion
+type Record struct {
+	Subject
+	RequestID        string  `json:"request_id,omitempty"`
+	ChatID           string  `json:"chat_id,omitempty"`
+	Model            string  `json:"model,omitempty"`
+	PromptTokens     int     `json:"prompt_tokens"`
+	CompletionTokens int     `json:"completion_tokens"`
+	TotalTokens      int     `json:"total_tokens"`
+	Cost             float64 `json:"cost"`
+	Currency         string  `json:"currency,omitempty"`
+}
+
+// Filter the filter of the usage history and statistics
+type Filter struct {
+	UserID      string
+	TeamID      string
+	AssistantID string
+	Connector   string
+	From        *time.Time
+	To          *time.Time
+}
+
+// Total the token and cost total of a group
+type Total struct {
+	Requests         int     `json:"requests"`
+	PromptTokens     int64   `json:"prompt_tokens"`
+	CompletionTokens int64   `json:"completion_tokens"`
+	TotalTokens      int64   `json:"total_tokens"`
+	Cost             float64 `json:"cost"`
+}
+
+// Statistics the usage statistics
+type Statistics struct {
+	Currency    string            `json:"currency"`
+	Total       Total             `json:"total"`
+	ByAssistant map[string]*Total `json:"by_assistant"`
+	ByConnector map[string]*Total `json:"by_connector"`
+	ByDay       map[string]*Total `json:"by_day"`
+}
+
+// QuotaError is returned when a quota is exceeded
+type QuotaError struct {
+	Quota Quota
+	Used  float64 // Tokens or cost used in the period
+	Limit float64
+	Unit  string // tokens or the currency
+}
+
+// Error implements the error interface
+func (e *QuotaError) Error() string {
+	subject := e.Quota.Scope
+	if e.Quota.ID != "" {
+		subject = fmt.Sprintf("%s %s", e.Quota.Scope, e.Quota.ID)
+	}
+	if e.Unit == "tokens" {
+		return fmt.Sprintf("%s %s token quota exceeded: %d of %d tokens used", subject, e.Quota.Period, int64(e.Used), int64(e.Limit))
+	}
+	return fmt.Sprintf("%s %s cost quota exceeded: %.4f of %.4f %s used", subject, e.Quota.Period, e.Used, e.Limit, e.Unit)
+}
@@ -0,0 +1,370 @@
+package usage
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/kun/maps"
+	"github.com/yaoapp/xun"
+	"github.com/yaoapp/xun/capsule"
+	"github.com/yaoapp/xun/dbal"
+)
+
+// ModelID the usage model
+const ModelID = "__yao.agent.usage"
+
+var (
+	config *Config
+	mutex  sync.RWMutex
+)
+
+// Fields defines the fields to select for usage history queries
+var Fields = []interface{}{
+	"id", "request_id", "chat_id", "user_id", "team_id", "assistant_id",
+	"connector", "model", "prompt_tokens", "completion_tokens", "total_tokens",
+	"cost", "currency", "created_at",
+}
+
+// SetConfig sets the global usage configuration
+func SetConfig(cfg *Config) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	config = cfg
+}
+
+// GetConfig returns the global usage configuration, nil if not configured
+func GetConfig() *Config {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return config
+}
+
+// Enabled returns true if usage accounting is configured and not disabled
+func Enabled() bool {
+	cfg := GetConfig()
+	return cfg != nil && !cfg.Disabled
+}
+
+// Validate validates the configuration and fills the defaults
+func (cfg *Config) Validate() error {
+	if cfg.Currency == "" {
+		cfg.Currency = "USD"
+	}
+	for i, q := range cfg.Quotas {
+		switch q.Scope {
+		case ScopeUser, ScopeTeam, ScopeAssistant:
+		default:
+			return fmt.Errorf("quotas[%d]: scope %q is not supported, use user, team or assistant", i, q.Scope)
+		}
+		switch q.Period {
+		case PeriodDaily, PeriodMonthly:
+		default:
+			return fmt.Errorf("quotas[%d]: period %q is not supported, use daily or monthly", i, q.Period)
+		}
+		if q.Tokens <= 0 && q.Cost <= 0 {
+			return fmt.Errorf("quotas[%d]: tokens or cost is required", i)
+		}
+	}
+	return nil
+}
+
+// Cost computes the cost of a completion from the price table
+func (cfg *Config) Cost(connector string, promptTokens, completionTokens int) float64 {
+	price, ok := cfg.Prices[connector]
+	if !ok {
+		price, ok = cfg.Prices["*"]
+		if !ok {
+			return 0
+		}
+	}
+	return (float64(promptTokens)*price.Input + float64(completionTokens)*price.Output) / 1000000
+}
+
+// PeriodStart returns the start of the period that contains now
+func PeriodStart(period string, now time.Time) time.Time {
+	if period == PeriodMonthly {
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	}
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+}
+
+// Applies returns the column and the value the quota is checked against, false if the quota does not apply to the subject
+func (q Quota) Applies(subject Subject) (string, string, bool) {
+	var column, value string
+	switch q.Scope {
+	case ScopeUser:
+		column, value = "user_id", subject.UserID
+	case ScopeTeam:
+		column, value = "team_id", subject.TeamID
+	case ScopeAssistant:
+		column, value = "assistant_id", subject.AssistantID
+	}
+
+	if value == "" || (q.ID != "" && q.ID != value) {
+		return "", "", false
+	}
+	return column, value, true
+}
+
+// Check returns a *QuotaError if any quota of the subject is exhausted
+func Check(subject Subject) error {
+	cfg := GetConfig()
+	if cfg == nil || cfg.Disabled || len(cfg.Quotas) == 0 {
+		return nil
+	}
+
+	mod := model.Select(ModelID)
+	if mod == nil {
+		return fmt.Errorf("usage model not found")
+	}
+
+	now := time.Now()
+	for _, q := range cfg.Quotas {
+		column, value, ok := q.Applies(subject)
+		if !ok {
+			continue
+		}
+
+		rows, err := mod.Get(model.QueryParam{
+			Select: []interface{}{dbal.Raw("SUM(total_tokens) as tokens"), dbal.Raw("SUM(cost) as cost")},
+			Wheres: []model.QueryWhere{
+				{Column: column, Value: value},
+				{Column: "created_at", OP: ">=", Value: PeriodStart(q.Period, now)},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to check usage quota: %w", err)
+		}
+
+		var tokens, cost float64
+		if len(rows) > 0 {
+			tokens = toFloat(rows[0]["tokens"])
+			cost = toFloat(rows[0]["cost"])
+		}
+
+		if q.Tokens > 0 && tokens >= float64(q.Tokens) {
+			return &QuotaError{Quota: q, Used: tokens, Limit: float64(q.Tokens), Unit: "tokens"}
+		}
+		if q.Cost > 0 && cost >= q.Cost {
+			return &QuotaError{Quota: q, Used: cost, Limit: q.Cost, Unit: cfg.Currency}
+		}
+	}
+	return nil
+}
+
+// Save prices and stores a metered completion
+func Save(record *Record) error {
+	cfg := GetConfig()
+	if cfg == nil || cfg.Disabled {
+		return nil
+	}
+
+	mod := model.Select(ModelID)
+	if mod == nil {
+		return fmt.Errorf("usage model not found")
+	}
+
+	record.Cost = cfg.Cost(record.Connector, record.PromptTokens, record.CompletionTokens)
+	record.Currency = cfg.Currency
+	if record.TotalTokens == 0 {
+		record.TotalTokens = record.PromptTokens + record.CompletionTokens
+	}
+
+	_, err := mod.Create(map[string]interface{}{
+		"request_id":        record.RequestID,
+		"chat_id":           record.ChatID,
+		"user_id":           record.UserID,
+		"team_id":           record.TeamID,
+		"tenant_id":         record.TenantID,
+		"assistant_id":      record.AssistantID,
+		"connector":         record.Connector,
+		"model":             record.Model,
+		"prompt_tokens":     record.PromptTokens,
+		"completion_tokens": record.CompletionTokens,
+		"total_tokens":      record.TotalTokens,
+		"cost":              record.Cost,
+		"currency":          record.Currency,
+	})
+	if err != nil {
+		log.Error("[usage] failed to save usage of %s: %v", record.AssistantID, err)
+		return err
+	}
+	return nil
+}
+
+// History returns the usage records with pagination, newest first
+func History(filter Filter, page int, pagesize int) (maps.MapStrAny, error) {
+	mod := model.Select(ModelID)
+	if mod == nil {
+		return nil, fmt.Errorf("usage model not found")
+	}
+
+	return mod.Paginate(model.QueryParam{
+		Select: Fields,
+		Wheres: filter.wheres(),
+		Orders: []model.QueryOrder{{Column: "created_at", Option: "desc"}},
+	}, page, pagesize)
+}
+
+// statisticsColumns the aggregates of the usage statistics
+var statisticsColumns = []interface{}{
+	dbal.Raw("COUNT(*) as requests"),
+	dbal.Raw("SUM(prompt_tokens) as prompt_tokens"),
+	dbal.Raw("SUM(completion_tokens) as completion_tokens"),
+	dbal.Raw("SUM(total_tokens) as total_tokens"),
+	dbal.Raw("SUM(cost) as cost"),
+}
+
+// GetStatistics returns the usage totals grouped by assistant, connector and day, aggregated by the database
+func GetStatistics(filter Filter) (*Statistics, error) {
+	mod := model.Select(ModelID)
+	if mod == nil {
+		return nil, fmt.Errorf("usage model not found")
+	}
+
+	stats := &Statistics{
+		Currency:    "USD",
+		ByAssistant: map[string]*Total{},
+		ByConnector: map[string]*Total{},
+		ByDay:       map[string]*Total{},
+	}
+	if cfg := GetConfig(); cfg != nil && cfg.Currency != "" {
+		stats.Currency = cfg.Currency
+	}
+
+	table := mod.MetaData.Table.Name
+	rows, err := aggregate(table, filter, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) > 0 {
+		stats.Total = *toTotal(rows[0])
+	}
+
+	groups := []struct {
+		selected interface{}
+		groupBy  interface{}
+		key      string
+		totals   map[string]*Total
+	}{
+		{"assistant_id", "assistant_id", "assistant_id", stats.ByAssistant},
+		{"connector", "connector", "connector", stats.ByConnector},
+		{dbal.Raw("DATE(created_at) as day"), dbal.Raw("DATE(created_at)"), "day", stats.ByDay},
+	}
+
+	for _, group := range groups {
+		rows, err := aggregate(table, filter, group.selected, group.groupBy)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			var key string
+			if group.key == "day" {
+				key = toDay(row[group.key])
+			} else if value, ok := row[group.key].(string); ok {
+				key = value
+			}
+			if key != "" {
+				group.totals[key] = toTotal(row)
+			}
+		}
+	}
+	return stats, nil
+}
+
+// aggregate sums the usage matching the filter, grouped by the column if not nil
+func aggregate(table string, filter Filter, selected interface{}, groupBy interface{}) ([]xun.R, error) {
+	qb := capsule.Query().Table(table)
+	for _, where := range filter.wheres() {
+		if where.OP == "" {
+			qb.Where(where.Column, where.Value)
+			continue
+		}
+		qb.Where(where.Column, where.OP, where.Value)
+	}
+
+	columns := append([]interface{}{}, statisticsColumns...)
+	if groupBy != nil {
+		columns = append(columns, selected)
+		qb.GroupBy(groupBy)
+	}
+	return qb.Select(columns...).Get()
+}
+
+// toTotal converts an aggregate row to the total
+func toTotal(row xun.R) *Total {
+	return &Total{
+		Requests:         int(toFloat(row["requests"])),
+		PromptTokens:     int64(toFloat(row["prompt_tokens"])),
+		CompletionTokens: int64(toFloat(row["completion_tokens"])),
+		TotalTokens:      int64(toFloat(row["total_tokens"])),
+		Cost:             toFloat(row["cost"]),
+	}
+}
+
+// wheres converts the filter to query conditions
+func (filter Filter) wheres() []model.QueryWhere {
+	wheres := []model.QueryWhere{}
+	if filter.UserID != "" {
+		wheres = append(wheres, model.QueryWhere{Column: "user_id", Value: filter.UserID})
+	}
+	if filter.TeamID != "" {
+		wheres = append(wheres, model.QueryWhere{Column: "team_id", Value: filter.TeamID})
+	}
+	if filter.AssistantID != "" {
+		wheres = append(wheres, model.QueryWhere{Column: "assistant_id", Value: filter.AssistantID})
+	}
+	if filter.Connector != "" {
+		wheres = append(wheres, model.QueryWhere{Column: "connector", Value: filter.Connector})
+	}
+	if filter.From != nil {
+		wheres = append(wheres, model.QueryWhere{Column: "created_at", OP: ">=", Value: *filter.From})
+	}
+	if filter.To != nil {
+		wheres = append(wheres, model.QueryWhere{Column: "created_at", OP: "<", Value: *filter.To})
+	}
+	return wheres
+}
+
+// toFloat converts a SUM or decimal column value to float64
+func toFloat(v interface{}) float64 {
+	switch value := v.(type) {
+	case float64:
+		return value
+	case float32:
+		return float64(value)
+	case int:
+		return float64(value)
+	case int64:
+		return float64(value)
+	case uint64:
+		return float64(value)
+	case []byte:
+		f, _ := strconv.ParseFloat(string(value), 64)
+		return f
+	case string:
+		f, _ := strconv.ParseFloat(value, 64)
+		return f
+	}
+	return 0
+}
+
+// toDay formats a created_at value as YYYY-MM-DD
+func toDay(v interface{}) string {
+	switch value := v.(type) {
+	case time.Time:
+		return value.Format("2006-01-02")
+	case []byte:
+		return toDay(string(value))
+	case string:
+		if len(value) >= 10 {
+			return value[:10]
+		}
+	}
+	return ""
+}
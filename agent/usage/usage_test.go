@@ -0,0 +1,84 @@
+package usage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun"
+)
+
+func TestConfigValidate(t *testing.T) {
+	cfg := &Config{Quotas: []Quota{{Scope: ScopeUser, Period: PeriodDaily, Tokens: 1000}}}
+	assert.NoError(t, cfg.Validate())
+	assert.Equal(t, "USD", cfg.Currency)
+
+	invalid := map[string]Quota{
+		"scope":  {Scope: "tenant", Period: PeriodDaily, Tokens: 1000},
+		"period": {Scope: ScopeTeam, Period: "weekly", Tokens: 1000},
+		"limit":  {Scope: ScopeAssistant, Period: PeriodMonthly},
+	}
+	for name, q := range invalid {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, (&Config{Quotas: []Quota{q}}).Validate())
+		})
+	}
+}
+
+func TestCost(t *testing.T) {
+	cfg := &Config{Prices: map[string]Price{
+		"gpt-4o": {Input: 2.5, Output: 10},
+		"*":      {Input: 1, Output: 2},
+	}}
+
+	assert.InDelta(t, 0.0125, cfg.Cost("gpt-4o", 1000, 1000), 1e-9)
+	assert.InDelta(t, 0.003, cfg.Cost("deepseek", 1000, 1000), 1e-9)
+	assert.Equal(t, 0.0, (&Config{}).Cost("gpt-4o", 1000, 1000))
+}
+
+func TestPeriodStart(t *testing.T) {
+	now := time.Date(2025, 3, 18, 15, 4, 5, 0, time.UTC)
+	assert.Equal(t, time.Date(2025, 3, 18, 0, 0, 0, 0, time.UTC), PeriodStart(PeriodDaily, now))
+	assert.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), PeriodStart(PeriodMonthly, now))
+}
+
+func TestQuotaApplies(t *testing.T) {
+	subject := Subject{UserID: "u1", TeamID: "t1", AssistantID: "tests.chat"}
+
+	column, value, ok := Quota{Scope: ScopeUser}.Applies(subject)
+	assert.True(t, ok)
+	assert.Equal(t, "user_id", column)
+	assert.Equal(t, "u1", value)
+
+	_, _, ok = Quota{Scope: ScopeTeam, ID: "t2"}.Applies(subject)
+	assert.False(t, ok)
+
+	_, _, ok = Quota{Scope: ScopeTeam}.Applies(Subject{UserID: "u1"})
+	assert.False(t, ok)
+
+	column, _, ok = Quota{Scope: ScopeAssistant, ID: "tests.chat"}.Applies(subject)
+	assert.True(t, ok)
+	assert.Equal(t, "assistant_id", column)
+}
+
+func TestQuotaError(t *testing.T) {
+	err := &QuotaError{Quota: Quota{Scope: ScopeUser, Period: PeriodDaily}, Used: 1200, Limit: 1000, Unit: "tokens"}
+	assert.Equal(t, "user daily token quota exceeded: 1200 of 1000 tokens used", err.Error())
+
+	err = &QuotaError{Quota: Quota{Scope: ScopeTeam, ID: "t1", Period: PeriodMonthly}, Used: 10.5, Limit: 10, Unit: "USD"}
+	assert.Equal(t, "team t1 monthly cost quota exceeded: 10.5000 of 10.0000 USD used", err.Error())
+}
+
+func TestToTotal(t *testing.T) {
+	total := toTotal(xun.R{"requests": int64(2), "prompt_tokens": "30", "completion_tokens": []byte("15"), "total_tokens": 45.0, "cost": "1.5"})
+	assert.Equal(t, 2, total.Requests)
+	assert.Equal(t, int64(30), total.PromptTokens)
+	assert.Equal(t, int64(15), total.CompletionTokens)
+	assert.Equal(t, int64(45), total.TotalTokens)
+	assert.InDelta(t, 1.5, total.Cost, 1e-9)
+
+	// The DATE() of the drivers
+	assert.Equal(t, "2025-03-19", toDay("2025-03-19"))
+	assert.Equal(t, "2025-03-19", toDay([]byte("2025-03-19")))
+	assert.Equal(t, "2025-03-19", toDay(time.Date(2025, 3, 19, 0, 0, 0, 0, time.UTC)))
+}
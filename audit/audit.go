@@ -0,0 +1,404 @@
+package audit
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/gou/session"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/openapi/oauth/authorized"
+	oauthTypes "github.com/yaoapp/yao/openapi/oauth/types"
+	"github.com/yaoapp/yao/share"
+)
+
+// Masked the value of the sensitive fields in the before and after data
+const Masked = "******"
+
+// SensitiveFields the fields masked in the before and after data
+var SensitiveFields = []string{"password", "password_hash", "secret", "client_secret", "token", "access_token", "refresh_token", "mfa_secret", "api_key"}
+
+var (
+	setting  = config.Audit{Retention: 365}
+	hostname string
+	chain    sync.Mutex // Serializes the writes of this instance, the database serializes the instances (unique prev_hash)
+	cleaner  *Cleaner
+)
+
+func init() {
+	hostname, _ = os.Hostname()
+}
+
+// Load the audit log config, start the writer and the retention cleaner
+func Load(cfg config.Config) error {
+	Stop()
+	setting = cfg.Audit
+	if setting.Disable {
+		return nil
+	}
+
+	if setting.Secret == "" && cfg.JWTSecret == "" {
+		log.Warn("[Audit] neither YAO_AUDIT_SECRET nor YAO_JWT_SECRET is set, the record hashes are not keyed")
+	}
+	startWriter()
+
+	if setting.Retention <= 0 {
+		return nil
+	}
+
+	cleaner = NewCleaner(setting.Retention)
+	go cleaner.Start()
+	return nil
+}
+
+// Stop the retention cleaner and the writer, the queued entries are written before it returns
+func Stop() {
+	if cleaner != nil {
+		cleaner.Stop()
+		cleaner = nil
+	}
+	stopWriter()
+}
+
+// Enabled checks if the audit log is enabled
+func Enabled() bool {
+	return !setting.Disable
+}
+
+// New create a new audit entry
+func New(operation string, category string) *Entry {
+	return &Entry{
+		Operation: operation,
+		Category:  category,
+		Severity:  SeverityMedium,
+		Success:   true,
+	}
+}
+
+// WithAuth set the actor of the entry by the authorized information
+func (entry *Entry) WithAuth(info *oauthTypes.AuthorizedInfo) *Entry {
+	if info == nil {
+		return entry
+	}
+	entry.UserID = info.UserID
+	entry.TeamID = info.TeamID
+	entry.ClientID = info.ClientID
+	entry.SessionID = info.SessionID
+	return entry
+}
+
+// WithGin set the actor and the request of the entry by the gin context
+func (entry *Entry) WithGin(c *gin.Context) *Entry {
+	entry.WithAuth(authorized.GetInfo(c))
+	entry.Source = SourceOpenAPI
+	entry.ClientIP = c.ClientIP()
+	entry.UserAgent = c.GetHeader("User-Agent")
+	entry.RequestID = c.GetHeader("X-Request-ID")
+	entry.TraceID = c.GetHeader("X-Trace-ID")
+	return entry
+}
+
+// WithProcess set the actor of the entry by the process
+func (entry *Entry) WithProcess(p *process.Process) *Entry {
+	entry.WithAuth(authorized.ProcessAuthInfo(p))
+	entry.Source = SourceProcess
+	if entry.SessionID == "" {
+		entry.SessionID = p.Sid
+	}
+	if entry.UserID == "" && p.Sid != "" {
+		// The sessions of the SSH consoles and the legacy admin keep the user in the session
+		for _, key := range []string{"__user_id", "user_id"} {
+			if id, err := session.Global().ID(p.Sid).Get(key); err == nil && id != nil {
+				entry.UserID = fmt.Sprintf("%v", id)
+				break
+			}
+		}
+	}
+	return entry
+}
+
+// WithResource set the target resource of the entry
+func (entry *Entry) WithResource(typ string, id interface{}) *Entry {
+	entry.ResourceType = typ
+	if id != nil {
+		entry.Resource = fmt.Sprintf("%v", id)
+	}
+	return entry
+}
+
+// WithChange set the data before and after the operation, only the changed fields are kept.
+// before is nil for the created resources and after is nil for the deleted resources.
+func (entry *Entry) WithChange(before interface{}, after interface{}) *Entry {
+	entry.Before, entry.After = Changes(toMap(before), toMap(after))
+	return entry
+}
+
+// WithDetails set the details of the entry
+func (entry *Entry) WithDetails(details map[string]interface{}) *Entry {
+	if entry.Details == nil {
+		entry.Details = map[string]interface{}{}
+	}
+	for key, value := range details {
+		entry.Details[key] = value
+	}
+	return entry
+}
+
+// WithSeverity set the severity of the entry
+func (entry *Entry) WithSeverity(severity string) *Entry {
+	entry.Severity = severity
+	return entry
+}
+
+// WithError mark the entry failed
+func (entry *Entry) WithError(err error) *Entry {
+	if err != nil {
+		entry.Success = false
+		entry.Error = err.Error()
+	}
+	return entry
+}
+
+// Since set the response time of the entry
+func (entry *Entry) Since(start time.Time) *Entry {
+	entry.ResponseTime = time.Since(start).Milliseconds()
+	return entry
+}
+
+// Log queue the entry for the writer, the errors are logged and never returned. Use it to audit the operations
+// which should not wait for or fail because of the audit log. The entry is written at once when the writer
+// is not started or its queue is full.
+func Log(entry *Entry) {
+	if !Enabled() {
+		return
+	}
+
+	if entry.Operation == "" {
+		log.Error("[Audit] the operation of the audit entry is required")
+		return
+	}
+
+	// The data is copied now, the caller may change it after the call
+	entry.prepare()
+	if enqueue(entry) {
+		return
+	}
+
+	if err := write(entry); err != nil {
+		log.Error("[Audit] %s %s: %s", entry.Operation, entry.Resource, err.Error())
+	}
+}
+
+// Record write the entry at once, it is chained to the last record
+func Record(entry *Entry) error {
+	if !Enabled() {
+		return nil
+	}
+
+	if entry.Operation == "" {
+		return fmt.Errorf("the operation of the audit entry is required")
+	}
+
+	entry.prepare()
+	return write(entry)
+}
+
+// prepare fill the defaults, and normalize the data to the JSON representation stored in the database,
+// so the hash can be computed again from the stored record
+func (entry *Entry) prepare() {
+	if entry.EventID == "" {
+		entry.EventID = uuid.NewString()
+	}
+	if entry.UserID == "" {
+		entry.UserID = Anonymous
+	}
+	if entry.Severity == "" {
+		entry.Severity = SeverityMedium
+	}
+	if entry.Source == "" {
+		entry.Source = SourceSystem
+	}
+	if entry.Hostname == "" {
+		entry.Hostname = hostname
+	}
+	if entry.Application == "" && share.App.Name != "" {
+		entry.Application = share.App.Name
+	}
+	if entry.Timestamp == 0 {
+		entry.Timestamp = time.Now().UnixMilli()
+	}
+
+	entry.Before = normalize(redact(entry.Before))
+	entry.After = normalize(redact(entry.After))
+	entry.Details = normalize(redact(entry.Details))
+}
+
+// hashFields the fields of the record covered by the hash
+type hashFields struct {
+	EventID      string                 `json:"event_id"`
+	Operation    string                 `json:"operation"`
+	Category     string                 `json:"category"`
+	Severity     string                 `json:"severity"`
+	UserID       string                 `json:"user_id"`
+	TeamID       string                 `json:"team_id"`
+	ClientID     string                 `json:"client_id"`
+	SessionID    string                 `json:"session_id"`
+	ClientIP     string                 `json:"client_ip"`
+	Resource     string                 `json:"target_resource"`
+	ResourceType string                 `json:"resource_type"`
+	Source       string                 `json:"source"`
+	Success      bool                   `json:"success"`
+	Before       map[string]interface{} `json:"data_before"`
+	After        map[string]interface{} `json:"data_after"`
+	Details      map[string]interface{} `json:"details"`
+	Error        string                 `json:"error_message"`
+	Timestamp    int64                  `json:"timestamp"`
+	PrevHash     string                 `json:"prev_hash"`
+}
+
+// hash the HMAC-SHA256 of the record fields and the previous hash, keyed with the server secret
+// so the chain can not be rebuilt by someone who can write the database only
+func (entry *Entry) hash() string {
+	data, _ := jsoniter.ConfigCompatibleWithStandardLibrary.Marshal(hashFields{
+		EventID:      entry.EventID,
+		Operation:    entry.Operation,
+		Category:     entry.Category,
+		Severity:     entry.Severity,
+		UserID:       entry.UserID,
+		TeamID:       entry.TeamID,
+		ClientID:     entry.ClientID,
+		SessionID:    entry.SessionID,
+		ClientIP:     entry.ClientIP,
+		Resource:     entry.Resource,
+		ResourceType: entry.ResourceType,
+		Source:       entry.Source,
+		Success:      entry.Success,
+		Before:       entry.Before,
+		After:        entry.After,
+		Details:      entry.Details,
+		Error:        entry.Error,
+		Timestamp:    entry.Timestamp,
+		PrevHash:     entry.PrevHash,
+	})
+	mac := hmac.New(sha256.New, hashKey())
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// hashKey the key of the record hashes, YAO_AUDIT_SECRET or the JWT secret
+func hashKey() []byte {
+	if setting.Secret != "" {
+		return []byte(setting.Secret)
+	}
+	return []byte(config.Conf.JWTSecret)
+}
+
+// Changes returns the fields changed between before and after
+func Changes(before map[string]interface{}, after map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	if before == nil || after == nil {
+		return before, after
+	}
+
+	b := map[string]interface{}{}
+	a := map[string]interface{}{}
+	for key, value := range after {
+		old, has := before[key]
+		if has && reflect.DeepEqual(normalizeValue(old), normalizeValue(value)) {
+			continue
+		}
+		if has {
+			b[key] = old
+		}
+		a[key] = value
+	}
+
+	for key, value := range before {
+		if _, has := after[key]; !has {
+			b[key] = value
+		}
+	}
+	return b, a
+}
+
+// redact masks the sensitive fields
+func redact(data map[string]interface{}) map[string]interface{} {
+	if data == nil {
+		return nil
+	}
+	res := make(map[string]interface{}, len(data))
+	for key, value := range data {
+		if sensitive(key) {
+			res[key] = Masked
+			continue
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			res[key] = redact(nested)
+			continue
+		}
+		res[key] = value
+	}
+	return res
+}
+
+func sensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, field := range SensitiveFields {
+		if key == field {
+			return true
+		}
+	}
+	return false
+}
+
+// toMap converts the data to a map, nil if the data is not an object
+func toMap(data interface{}) map[string]interface{} {
+	if data == nil {
+		return nil
+	}
+	if m, ok := data.(map[string]interface{}); ok {
+		return m
+	}
+	raw, err := jsoniter.Marshal(data)
+	if err != nil {
+		return nil
+	}
+	var m map[string]interface{}
+	if err := jsoniter.Unmarshal(raw, &m); err != nil {
+		return nil
+	}
+	return m
+}
+
+// normalize the data to its JSON representation
+func normalize(data map[string]interface{}) map[string]interface{} {
+	if len(data) == 0 {
+		return nil
+	}
+	if m, ok := normalizeValue(data).(map[string]interface{}); ok {
+		return m
+	}
+	return nil
+}
+
+func normalizeValue(value interface{}) interface{} {
+	raw, err := jsoniter.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	var res interface{}
+	if err := jsoniter.Unmarshal(raw, &res); err != nil {
+		return string(raw)
+	}
+	return res
+}
@@ -0,0 +1,187 @@
+package audit
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/test"
+)
+
+func TestChanges(t *testing.T) {
+	before := map[string]interface{}{"name": "Alpha", "status": "active", "logo": "a.png"}
+	after := map[string]interface{}{"name": "Beta", "status": "active", "settings": map[string]interface{}{"theme": "dark"}}
+
+	b, a := Changes(before, after)
+	assert.Equal(t, map[string]interface{}{"name": "Alpha", "logo": "a.png"}, b)
+	assert.Equal(t, map[string]interface{}{"name": "Beta", "settings": map[string]interface{}{"theme": "dark"}}, a)
+
+	// Created and deleted resources keep all the fields
+	b, a = Changes(nil, after)
+	assert.Nil(t, b)
+	assert.Equal(t, after, a)
+
+	// Numbers of different types are equal when the JSON is equal
+	b, a = Changes(map[string]interface{}{"count": int64(1)}, map[string]interface{}{"count": 1.0})
+	assert.Empty(t, b)
+	assert.Empty(t, a)
+}
+
+func TestWithChangeRedact(t *testing.T) {
+	entry := New("member.update", CategoryAuthorization).WithChange(
+		map[string]interface{}{"password": "old", "name": "Alpha"},
+		map[string]interface{}{"password": "new", "name": "Alpha", "profile": map[string]interface{}{"api_key": "key"}},
+	)
+	entry.prepare()
+
+	assert.Equal(t, Masked, entry.Before["password"])
+	assert.Equal(t, Masked, entry.After["password"])
+	assert.NotContains(t, entry.After, "name")
+	assert.Equal(t, Masked, entry.After["profile"].(map[string]interface{})["api_key"])
+	assert.Equal(t, Anonymous, entry.UserID)
+	assert.Equal(t, SourceSystem, entry.Source)
+	assert.NotEmpty(t, entry.EventID)
+}
+
+func TestHash(t *testing.T) {
+	entry := New("dsl.update", CategorySystem).WithResource("model", "user").WithDetails(map[string]interface{}{"count": 1})
+	entry.prepare()
+	entry.PrevHash = "prev"
+	hash := entry.hash()
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, entry.hash())
+
+	// The stored record has the same hash
+	row := map[string]interface{}{
+		"id": 1, "event_id": entry.EventID, "operation": entry.Operation, "category": entry.Category,
+		"severity": entry.Severity, "user_id": entry.UserID, "target_resource": "user", "resource_type": "model",
+		"source": entry.Source, "success": 1, "details": `{"count":1}`, "timestamp": entry.Timestamp, "prev_hash": "prev",
+	}
+	assert.Equal(t, hash, fromRow(row).hash())
+
+	// Any change breaks the hash
+	entry.Success = false
+	assert.NotEqual(t, hash, entry.hash())
+
+	// The hash is keyed with the secret
+	entry.Success = true
+	defer func() { setting.Secret = "" }()
+	setting.Secret = "another-secret"
+	assert.NotEqual(t, hash, entry.hash())
+}
+
+func TestRecordAndVerify(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+	setting = config.Audit{Retention: 365}
+	clean(t)
+	defer clean(t)
+
+	for i := 0; i < 3; i++ {
+		entry := New("team.update", CategoryAuthorization).
+			WithResource("team", fmt.Sprintf("team-%d", i)).
+			WithChange(map[string]interface{}{"name": "Alpha"}, map[string]interface{}{"name": fmt.Sprintf("Beta %d", i)})
+		entry.UserID = "user-1"
+		entry.TeamID = "team-1"
+		require.NoError(t, Record(entry))
+		assert.NotZero(t, entry.ID)
+	}
+
+	res, err := Verify()
+	require.NoError(t, err)
+	assert.True(t, res.Valid)
+	assert.Equal(t, 3, res.Checked)
+
+	// Search by the prefix of the operation
+	result, err := Search(Filter{Operation: "team.*", TeamID: "team-1"}, 1, 10)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, result.Get("total"))
+
+	// Tamper a record
+	mod := model.Select(ModelID)
+	_, err = mod.UpdateWhere(model.QueryParam{Wheres: []model.QueryWhere{{Column: "target_resource", Value: "team-1"}}}, map[string]interface{}{"user_id": "user-2"})
+	require.NoError(t, err)
+
+	res, err = Verify()
+	require.NoError(t, err)
+	assert.False(t, res.Valid)
+	require.Len(t, res.Broken, 1)
+}
+
+func TestPurge(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+	setting = config.Audit{Retention: 365}
+	clean(t)
+	defer clean(t)
+
+	old := time.Now().AddDate(0, 0, -30).UnixMilli()
+	for i := 0; i < 3; i++ {
+		entry := New("user.login", CategoryAuthentication)
+		if i < 2 {
+			entry.Timestamp = old
+		}
+		require.NoError(t, Record(entry))
+	}
+
+	removed, err := Purge(time.Now().AddDate(0, 0, -7))
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+
+	res, err := Verify()
+	require.NoError(t, err)
+	assert.True(t, res.Valid, "%v", res.Broken)
+	assert.Equal(t, 2, res.Checked)
+}
+
+func TestLogWriter(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+	setting = config.Audit{Retention: 365}
+	clean(t)
+	defer clean(t)
+
+	startWriter()
+	for i := 0; i < 20; i++ {
+		Log(New("table.update", CategoryData).WithResource("table", fmt.Sprintf("pet:%d", i)))
+	}
+
+	// The queued entries are written when the writer stops
+	stopWriter()
+	res, err := Verify()
+	require.NoError(t, err)
+	assert.True(t, res.Valid, "%v", res.Broken)
+	assert.Equal(t, 20, res.Checked)
+}
+
+func TestChainUnique(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+	setting = config.Audit{Retention: 365}
+	clean(t)
+	defer clean(t)
+
+	first := New("user.login", CategoryAuthentication)
+	require.NoError(t, Record(first))
+
+	// Another instance linking to the same record can not insert
+	fork := New("user.login", CategoryAuthentication)
+	fork.prepare()
+	fork.PrevHash = first.PrevHash
+	fork.Hash = fork.hash()
+	assert.Error(t, save(fork))
+
+	// The writer links to the last record
+	next := New("user.logout", CategoryAuthentication)
+	require.NoError(t, Record(next))
+	assert.Equal(t, first.Hash, next.PrevHash)
+}
+
+func clean(t *testing.T) {
+	_, err := model.Select(ModelID).DeleteWhere(model.QueryParam{Wheres: []model.QueryWhere{{Column: "id", OP: ">", Value: 0}}})
+	require.NoError(t, err)
+}
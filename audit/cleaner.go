@@ -0,0 +1,58 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/yaoapp/kun/log"
+)
+
+// Cleaner removes the audit records older than the retention
+type Cleaner struct {
+	ctx           context.Context
+	cancel        context.CancelFunc
+	retentionDays int
+}
+
+// NewCleaner creates a new cleaner
+func NewCleaner(retentionDays int) *Cleaner {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Cleaner{ctx: ctx, cancel: cancel, retentionDays: retentionDays}
+}
+
+// Start starts the daily cleanup routine
+func (c *Cleaner) Start() {
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	log.Info("[Audit] cleaner started with %d days retention", c.retentionDays)
+	for {
+		select {
+		case <-ticker.C:
+			c.Run()
+		case <-c.ctx.Done():
+			log.Info("[Audit] cleaner stopped")
+			return
+		}
+	}
+}
+
+// Run removes the expired records
+func (c *Cleaner) Run() (int, error) {
+	removed, err := Purge(time.Now().AddDate(0, 0, -c.retentionDays))
+	if err != nil {
+		log.Error("[Audit] cleanup failed: %s", err.Error())
+		return removed, err
+	}
+	if removed > 0 {
+		log.Info("[Audit] cleanup completed: %d records removed", removed)
+	}
+	return removed, nil
+}
+
+// Stop stops the cleaner
+func (c *Cleaner) Stop() {
+	if c.cancel != nil {
+		c.cancel()
+	}
+}
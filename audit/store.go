@@ -0,0 +1,405 @@
+package audit
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/spf13/cast"
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/kun/maps"
+)
+
+// ModelID the model of the audit records
+const ModelID = "__yao.audit"
+
+// OperationPurge the operation recorded when the expired records are removed
+const OperationPurge = "audit.purge"
+
+// Fields defines the fields to select for audit queries
+var Fields = []interface{}{
+	"id", "event_id", "operation", "category", "severity", "user_id", "user_name", "team_id", "client_id",
+	"session_id", "client_ip", "user_agent", "target_resource", "resource_type", "source", "application",
+	"hostname", "success", "response_time", "request_id", "trace_id", "data_before", "data_after",
+	"details", "error_message", "tags", "timestamp", "prev_hash", "hash", "created_at",
+}
+
+// chunkSize the number of records read at once by export and verify
+const chunkSize = 500
+
+func getModel() (*model.Model, error) {
+	mod, has := model.Models[ModelID]
+	if !has {
+		return nil, fmt.Errorf("audit model not found")
+	}
+	return mod, nil
+}
+
+// lastHash returns the hash of the last record
+func lastHash() (string, error) {
+	mod, err := getModel()
+	if err != nil {
+		return "", err
+	}
+
+	rows, err := mod.Get(model.QueryParam{
+		Select: []interface{}{"id", "hash"},
+		Orders: []model.QueryOrder{{Column: "id", Option: "desc"}},
+		Limit:  1,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get the last audit record: %w", err)
+	}
+
+	if len(rows) == 0 {
+		return "", nil
+	}
+	return cast.ToString(rows[0]["hash"]), nil
+}
+
+// save create the record, the records are never updated
+func save(entry *Entry) error {
+	mod, err := getModel()
+	if err != nil {
+		return err
+	}
+
+	now := time.UnixMilli(entry.Timestamp)
+	data := map[string]interface{}{
+		"event_id":        entry.EventID,
+		"operation":       entry.Operation,
+		"category":        nullable(entry.Category),
+		"severity":        entry.Severity,
+		"user_id":         entry.UserID,
+		"user_name":       nullable(entry.UserName),
+		"team_id":         nullable(entry.TeamID),
+		"client_id":       nullable(entry.ClientID),
+		"session_id":      nullable(entry.SessionID),
+		"client_ip":       nullable(entry.ClientIP),
+		"user_agent":      nullable(entry.UserAgent),
+		"target_resource": nullable(entry.Resource),
+		"resource_type":   nullable(entry.ResourceType),
+		"source":          nullable(entry.Source),
+		"application":     nullable(entry.Application),
+		"hostname":        nullable(entry.Hostname),
+		"success":         entry.Success,
+		"response_time":   entry.ResponseTime,
+		"request_id":      nullable(entry.RequestID),
+		"trace_id":        nullable(entry.TraceID),
+		"error_message":   nullable(entry.Error),
+		"timestamp":       entry.Timestamp,
+		"prev_hash":       entry.PrevHash,
+		"hash":            entry.Hash,
+		"created_at":      now,
+		"updated_at":      now,
+	}
+
+	if entry.Before != nil {
+		data["data_before"] = entry.Before
+	}
+	if entry.After != nil {
+		data["data_after"] = entry.After
+	}
+	if entry.Details != nil {
+		data["details"] = entry.Details
+	}
+	if len(entry.Tags) > 0 {
+		data["tags"] = entry.Tags
+	}
+
+	id, err := mod.Create(data)
+	if err != nil {
+		return fmt.Errorf("failed to create audit record: %w", err)
+	}
+	entry.ID = uint(id)
+	entry.CreatedAt = &now
+	return nil
+}
+
+// Search the audit records with pagination, the latest first
+func Search(filter Filter, page int, pagesize int) (maps.MapStrAny, error) {
+	mod, err := getModel()
+	if err != nil {
+		return nil, err
+	}
+
+	param := model.QueryParam{
+		Select: Fields,
+		Wheres: filter.wheres(),
+		Orders: []model.QueryOrder{{Column: "id", Option: "desc"}},
+	}
+	return mod.Paginate(param, page, pagesize)
+}
+
+// Get the audit record by id
+func Get(id uint) (*Entry, error) {
+	mod, err := getModel()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := mod.Get(model.QueryParam{
+		Select: Fields,
+		Wheres: []model.QueryWhere{{Column: "id", Value: id}},
+		Limit:  1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("audit record %d not found", id)
+	}
+	return fromRow(rows[0]), nil
+}
+
+// Each iterate the audit records matching the filter in chunks, the oldest first.
+// Stops when the handler returns an error.
+func Each(filter Filter, handler func(entry *Entry) error) error {
+	mod, err := getModel()
+	if err != nil {
+		return err
+	}
+
+	var last uint
+	for {
+		wheres := filter.wheres()
+		if last > 0 {
+			wheres = append(wheres, model.QueryWhere{Column: "id", OP: ">", Value: last})
+		}
+
+		rows, err := mod.Get(model.QueryParam{
+			Select: Fields,
+			Wheres: wheres,
+			Orders: []model.QueryOrder{{Column: "id", Option: "asc"}},
+			Limit:  chunkSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			entry := fromRow(row)
+			last = entry.ID
+			if err := handler(entry); err != nil {
+				return err
+			}
+		}
+
+		if len(rows) < chunkSize {
+			return nil
+		}
+	}
+}
+
+// Verify the hash chain of the records, each record must match its hash and link to the previous one.
+// The first record may link to a record removed by the retention policy, the anchor is kept in the purge record.
+func Verify() (*VerifyResult, error) {
+	anchors, err := purgeAnchors()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &VerifyResult{Valid: true}
+	prev := ""
+	err = Each(Filter{}, func(entry *Entry) error {
+		if res.Checked == 0 {
+			res.FirstID = entry.ID
+			if entry.PrevHash != "" && !anchors[entry.PrevHash] {
+				res.Broken = append(res.Broken, BrokenEntry{ID: entry.ID, Reason: "the previous records are missing"})
+			}
+		} else if entry.PrevHash != prev {
+			res.Broken = append(res.Broken, BrokenEntry{ID: entry.ID, Reason: "the previous hash does not match, records were removed or inserted"})
+		}
+
+		if entry.hash() != entry.Hash {
+			res.Broken = append(res.Broken, BrokenEntry{ID: entry.ID, Reason: "the hash does not match, the record was modified"})
+		}
+
+		prev = entry.Hash
+		res.LastID = entry.ID
+		res.Checked++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res.Valid = len(res.Broken) == 0
+	return res, nil
+}
+
+// Purge remove the records created before the time, a purge record is appended with the hash of the last
+// removed record, so the chain can still be verified.
+func Purge(before time.Time) (int, error) {
+	mod, err := getModel()
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := mod.Get(model.QueryParam{
+		Select: []interface{}{"id", "hash"},
+		Wheres: []model.QueryWhere{{Column: "timestamp", OP: "<", Value: before.UnixMilli()}},
+		Orders: []model.QueryOrder{{Column: "id", Option: "desc"}},
+		Limit:  1,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	lastID := cast.ToUint(rows[0]["id"])
+	anchor := cast.ToString(rows[0]["hash"])
+	removed, err := mod.DeleteWhere(model.QueryParam{
+		Wheres: []model.QueryWhere{{Column: "id", OP: "<=", Value: lastID}},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge audit records: %w", err)
+	}
+
+	entry := New(OperationPurge, CategorySystem).WithSeverity(SeverityHigh).WithDetails(map[string]interface{}{
+		"before":  before.UnixMilli(),
+		"removed": removed,
+		"last_id": lastID,
+		"anchor":  anchor,
+	})
+	entry.prepare()
+	return removed, write(entry)
+}
+
+// purgeAnchors returns the hashes of the last records removed by the purges
+func purgeAnchors() (map[string]bool, error) {
+	anchors := map[string]bool{}
+	err := Each(Filter{Operation: OperationPurge}, func(entry *Entry) error {
+		if anchor := cast.ToString(entry.Details["anchor"]); anchor != "" {
+			anchors[anchor] = true
+		}
+		return nil
+	})
+	return anchors, err
+}
+
+// wheres converts the filter to the query conditions
+func (filter Filter) wheres() []model.QueryWhere {
+	wheres := []model.QueryWhere{}
+	equals := map[string]string{
+		"user_id":         filter.UserID,
+		"team_id":         filter.TeamID,
+		"client_id":       filter.ClientID,
+		"client_ip":       filter.ClientIP,
+		"category":        filter.Category,
+		"severity":        filter.Severity,
+		"target_resource": filter.Resource,
+		"resource_type":   filter.ResourceType,
+		"source":          filter.Source,
+	}
+	for _, column := range []string{"user_id", "team_id", "client_id", "client_ip", "category", "severity", "target_resource", "resource_type", "source"} {
+		if value := equals[column]; value != "" {
+			wheres = append(wheres, model.QueryWhere{Column: column, Value: value})
+		}
+	}
+
+	if filter.Operation != "" {
+		if strings.HasSuffix(filter.Operation, "*") {
+			wheres = append(wheres, model.QueryWhere{Column: "operation", OP: "like", Value: strings.TrimSuffix(filter.Operation, "*") + "%"})
+		} else {
+			wheres = append(wheres, model.QueryWhere{Column: "operation", Value: filter.Operation})
+		}
+	}
+
+	if filter.Success != nil {
+		wheres = append(wheres, model.QueryWhere{Column: "success", Value: *filter.Success})
+	}
+
+	if filter.From != nil {
+		wheres = append(wheres, model.QueryWhere{Column: "timestamp", OP: ">=", Value: filter.From.UnixMilli()})
+	}
+
+	if filter.To != nil {
+		wheres = append(wheres, model.QueryWhere{Column: "timestamp", OP: "<", Value: filter.To.UnixMilli()})
+	}
+	return wheres
+}
+
+// fromRow converts the database row to the entry
+func fromRow(row maps.MapStr) *Entry {
+	entry := &Entry{
+		ID:           cast.ToUint(row["id"]),
+		EventID:      cast.ToString(row["event_id"]),
+		Operation:    cast.ToString(row["operation"]),
+		Category:     cast.ToString(row["category"]),
+		Severity:     cast.ToString(row["severity"]),
+		UserID:       cast.ToString(row["user_id"]),
+		UserName:     cast.ToString(row["user_name"]),
+		TeamID:       cast.ToString(row["team_id"]),
+		ClientID:     cast.ToString(row["client_id"]),
+		SessionID:    cast.ToString(row["session_id"]),
+		ClientIP:     cast.ToString(row["client_ip"]),
+		UserAgent:    cast.ToString(row["user_agent"]),
+		Resource:     cast.ToString(row["target_resource"]),
+		ResourceType: cast.ToString(row["resource_type"]),
+		Source:       cast.ToString(row["source"]),
+		Application:  cast.ToString(row["application"]),
+		Hostname:     cast.ToString(row["hostname"]),
+		Success:      cast.ToBool(row["success"]),
+		ResponseTime: cast.ToInt64(row["response_time"]),
+		RequestID:    cast.ToString(row["request_id"]),
+		TraceID:      cast.ToString(row["trace_id"]),
+		Before:       jsonMap(row["data_before"]),
+		After:        jsonMap(row["data_after"]),
+		Details:      jsonMap(row["details"]),
+		Error:        cast.ToString(row["error_message"]),
+		Timestamp:    cast.ToInt64(row["timestamp"]),
+		PrevHash:     cast.ToString(row["prev_hash"]),
+		Hash:         cast.ToString(row["hash"]),
+	}
+
+	switch tags := row["tags"].(type) {
+	case []interface{}:
+		entry.Tags = cast.ToStringSlice(tags)
+	case string:
+		jsoniter.UnmarshalFromString(tags, &entry.Tags)
+	}
+
+	if created, err := cast.ToTimeE(row["created_at"]); err == nil && !created.IsZero() {
+		entry.CreatedAt = &created
+	}
+	return entry
+}
+
+// jsonMap reads the value of a JSON column, some drivers return the raw JSON string
+func jsonMap(value interface{}) map[string]interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+		return normalize(v)
+	case maps.MapStrAny:
+		return normalize(v)
+	case maps.MapStr:
+		return normalize(v)
+	case string:
+		if v == "" || v == "null" {
+			return nil
+		}
+		var m map[string]interface{}
+		if err := jsoniter.UnmarshalFromString(v, &m); err != nil {
+			return nil
+		}
+		return normalize(m)
+	case []byte:
+		return jsonMap(string(v))
+	}
+	return nil
+}
+
+func nullable(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
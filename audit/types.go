@@ -0,0 +1,96 @@
+package audit
+
+import "time"
+
+// Categories of the audit records
+const (
+	CategoryAuthentication = "authentication"
+	CategoryAuthorization  = "authorization"
+	CategoryData           = "data"
+	CategorySystem         = "system"
+)
+
+// Severity levels of the audit records
+const (
+	SeverityLow      = "low"
+	SeverityMedium   = "medium"
+	SeverityHigh     = "high"
+	SeverityCritical = "critical"
+)
+
+// Sources of the audit records
+const (
+	SourceOpenAPI = "openapi"
+	SourceProcess = "process"
+	SourceRobot   = "robot"
+	SourceSystem  = "system"
+)
+
+// Anonymous the actor of the operations performed without a user (e.g. failed logins)
+const Anonymous = "anonymous"
+
+// Entry an audit record, the records are append-only and chained by hash
+type Entry struct {
+	ID           uint                   `json:"id,omitempty"`
+	EventID      string                 `json:"event_id"`
+	Operation    string                 `json:"operation"`
+	Category     string                 `json:"category,omitempty"`
+	Severity     string                 `json:"severity,omitempty"`
+	UserID       string                 `json:"user_id"`
+	UserName     string                 `json:"user_name,omitempty"`
+	TeamID       string                 `json:"team_id,omitempty"`
+	ClientID     string                 `json:"client_id,omitempty"`
+	SessionID    string                 `json:"session_id,omitempty"`
+	ClientIP     string                 `json:"client_ip,omitempty"`
+	UserAgent    string                 `json:"user_agent,omitempty"`
+	Resource     string                 `json:"target_resource,omitempty"`
+	ResourceType string                 `json:"resource_type,omitempty"`
+	Source       string                 `json:"source,omitempty"`
+	Application  string                 `json:"application,omitempty"`
+	Hostname     string                 `json:"hostname,omitempty"`
+	Success      bool                   `json:"success"`
+	ResponseTime int64                  `json:"response_time,omitempty"` // Milliseconds
+	RequestID    string                 `json:"request_id,omitempty"`
+	TraceID      string                 `json:"trace_id,omitempty"`
+	Before       map[string]interface{} `json:"data_before,omitempty"`
+	After        map[string]interface{} `json:"data_after,omitempty"`
+	Details      map[string]interface{} `json:"details,omitempty"`
+	Error        string                 `json:"error_message,omitempty"`
+	Tags         []string               `json:"tags,omitempty"`
+	Timestamp    int64                  `json:"timestamp"` // Unix milliseconds
+	PrevHash     string                 `json:"prev_hash"`
+	Hash         string                 `json:"hash"`
+	CreatedAt    *time.Time             `json:"created_at,omitempty"`
+}
+
+// Filter the search filter of the audit records
+type Filter struct {
+	UserID       string     `json:"user_id,omitempty"`
+	TeamID       string     `json:"team_id,omitempty"`
+	ClientID     string     `json:"client_id,omitempty"`
+	ClientIP     string     `json:"client_ip,omitempty"`
+	Operation    string     `json:"operation,omitempty"` // Exact name, or a prefix ending with "*" (e.g. "team.*")
+	Category     string     `json:"category,omitempty"`
+	Severity     string     `json:"severity,omitempty"`
+	Resource     string     `json:"target_resource,omitempty"`
+	ResourceType string     `json:"resource_type,omitempty"`
+	Source       string     `json:"source,omitempty"`
+	Success      *bool      `json:"success,omitempty"`
+	From         *time.Time `json:"from,omitempty"`
+	To           *time.Time `json:"to,omitempty"`
+}
+
+// VerifyResult the result of the hash chain verification
+type VerifyResult struct {
+	Valid   bool          `json:"valid"`
+	Checked int           `json:"checked"`
+	FirstID uint          `json:"first_id,omitempty"`
+	LastID  uint          `json:"last_id,omitempty"`
+	Broken  []BrokenEntry `json:"broken,omitempty"`
+}
+
+// BrokenEntry a record failed the verification
+type BrokenEntry struct {
+	ID     uint   `json:"id"`
+	Reason string `json:"reason"`
+}
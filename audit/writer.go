@@ -0,0 +1,109 @@
+package audit
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/yaoapp/kun/log"
+)
+
+// queueSize the entries buffered for the writer
+const queueSize = 1024
+
+// writeAttempts the attempts to append a record when other instances append at the same time
+const writeAttempts = 5
+
+// writer the single writer of the records, the entries are written in order off the request path
+type writer struct {
+	queue chan *Entry
+	done  chan struct{}
+}
+
+var (
+	current  *writer
+	writerMu sync.RWMutex
+)
+
+// startWriter starts the writer, the previous writer is stopped
+func startWriter() {
+	stopWriter()
+
+	w := &writer{queue: make(chan *Entry, queueSize), done: make(chan struct{})}
+	go w.run()
+
+	writerMu.Lock()
+	current = w
+	writerMu.Unlock()
+}
+
+// stopWriter stops the writer, the queued entries are written before it returns
+func stopWriter() {
+	writerMu.Lock()
+	w := current
+	current = nil
+	if w != nil {
+		close(w.queue)
+	}
+	writerMu.Unlock()
+
+	if w != nil {
+		<-w.done
+	}
+}
+
+// enqueue queues the entry for the writer, false if the writer is not started or its queue is full
+func enqueue(entry *Entry) bool {
+	writerMu.RLock()
+	defer writerMu.RUnlock()
+	if current == nil {
+		return false
+	}
+
+	select {
+	case current.queue <- entry:
+		return true
+	default:
+		log.Warn("[Audit] the queue is full, %s is written at once", entry.Operation)
+		return false
+	}
+}
+
+func (w *writer) run() {
+	defer close(w.done)
+	for entry := range w.queue {
+		if err := write(entry); err != nil {
+			log.Error("[Audit] %s %s: %s", entry.Operation, entry.Resource, err.Error())
+		}
+	}
+}
+
+// write chains the prepared entry to the last record and saves it.
+// The prev_hash is unique, when another instance appended a record after the last hash was read,
+// the insert fails and the entry is chained to the new last record.
+func write(entry *Entry) error {
+	chain.Lock()
+	defer chain.Unlock()
+
+	var err error
+	for i := 0; i < writeAttempts; i++ {
+		var prev string
+		prev, err = lastHash()
+		if err != nil {
+			return err
+		}
+
+		entry.PrevHash = prev
+		entry.Hash = entry.hash()
+		err = save(entry)
+		if err == nil {
+			return nil
+		}
+
+		// Not a conflict, the last record is the same
+		latest, lastErr := lastHash()
+		if lastErr != nil || latest == prev {
+			return err
+		}
+	}
+	return fmt.Errorf("failed to append the audit record after %d attempts: %w", writeAttempts, err)
+}
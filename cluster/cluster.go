@@ -0,0 +1,270 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/config"
+)
+
+var (
+	setting  = config.Cluster{}
+	bus      Bus
+	instance = instanceID()
+	handlers = map[string]Handler{}
+	caches   = map[string]Invalidator{}
+	stats    = Status{}
+	cancel   context.CancelFunc
+	mutex    sync.RWMutex
+)
+
+func init() {
+	Handle(KindDSL, handleDSL)
+	Handle(KindCache, handleCache)
+}
+
+func instanceID() string {
+	hostname, _ := os.Hostname()
+	return fmt.Sprintf("%s:%d:%s", hostname, os.Getpid(), uuid.NewString()[:8])
+}
+
+// Load the cluster config and create the event bus, the events are not published without a driver
+func Load(cfg config.Config) error {
+	mutex.RLock()
+	same := bus != nil && setting == cfg.Cluster
+	mutex.RUnlock()
+	if same {
+		return nil
+	}
+
+	Stop()
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if bus != nil {
+		bus.Close()
+		bus = nil
+	}
+	setting = cfg.Cluster
+
+	switch strings.ToLower(setting.Driver) {
+	case "", "none":
+		return nil
+
+	case "redis":
+		dsn := setting.Redis
+		if dsn == "" {
+			dsn = sessionRedis(cfg.Session)
+		}
+		redis, err := NewRedis(dsn, setting.Channel)
+		if err != nil {
+			return err
+		}
+		bus = redis
+
+	case "db":
+		bus = NewDB(time.Duration(setting.Interval)*time.Second, time.Duration(setting.Keep)*time.Second)
+
+	default:
+		return fmt.Errorf("cluster driver %s is not supported (redis|db)", setting.Driver)
+	}
+	return nil
+}
+
+// sessionRedis the redis URL of the session server, used when the redis URL is not set
+func sessionRedis(session config.Session) string {
+	dsn := url.URL{Scheme: "redis", Host: fmt.Sprintf("%s:%s", session.Host, session.Port), Path: "/" + session.DB}
+	if session.Password != "" {
+		dsn.User = url.UserPassword(session.Username, session.Password)
+	}
+	return dsn.String()
+}
+
+// Start receiving the events of the other instances, the subscription is restored when it fails
+func Start() {
+	mutex.Lock()
+	if bus == nil || cancel != nil {
+		mutex.Unlock()
+		return
+	}
+	ctx, stop := context.WithCancel(context.Background())
+	cancel = stop
+	current := bus
+	stats.Running = true
+	mutex.Unlock()
+
+	go func() {
+		wait := time.Second
+		for {
+			err := current.Subscribe(ctx, dispatch)
+			if ctx.Err() != nil {
+				return
+			}
+
+			if err != nil {
+				log.Error("[Cluster] subscribe: %s, retry in %s", err.Error(), wait)
+				failed(err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(wait):
+			}
+
+			if wait < 30*time.Second {
+				wait = wait * 2
+			}
+		}
+	}()
+	log.Info("[Cluster] %s started, instance %s", setting.Driver, instance)
+}
+
+// Stop receiving the events
+func Stop() {
+	mutex.Lock()
+	defer mutex.Unlock()
+	if cancel != nil {
+		cancel()
+		cancel = nil
+		stats.Running = false
+		log.Info("[Cluster] stopped")
+	}
+}
+
+// Enabled checks if the event bus is configured
+func Enabled() bool {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return bus != nil
+}
+
+// Instance returns the identifier of the running instance
+func Instance() string {
+	return instance
+}
+
+// Handle register the handler of the events of the kind
+func Handle(kind string, handler Handler) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	handlers[kind] = handler
+}
+
+// RegisterCache register the invalidator of the named cache, called when another instance invalidates the cache
+func RegisterCache(name string, invalidator Invalidator) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	caches[name] = invalidator
+}
+
+// Publish the event to the other instances, returns nil if the event bus is not configured
+func Publish(event *Event) error {
+	if event.ID == "" {
+		event.ID = uuid.NewString()
+	}
+	event.Origin = instance
+	event.Time = time.Now().UnixMilli()
+
+	mutex.RLock()
+	current := bus
+	mutex.RUnlock()
+	if current == nil {
+		return nil
+	}
+
+	ctx, done := context.WithTimeout(context.Background(), 5*time.Second)
+	defer done()
+	err := current.Publish(ctx, event)
+	if err != nil {
+		failed(err)
+		return err
+	}
+
+	mutex.Lock()
+	stats.Published++
+	mutex.Unlock()
+	return nil
+}
+
+// ReloadDSL broadcasts the action applied to the DSLs, the errors are logged
+func ReloadDSL(action string, typ string, store string, ids ...string) {
+	err := Publish(&Event{Kind: KindDSL, Action: action, Type: typ, Store: store, IDs: ids})
+	if err != nil {
+		log.Error("[Cluster] publish %s %s %v: %s", action, typ, ids, err.Error())
+	}
+}
+
+// Invalidate broadcasts the invalidation of the cache keys, empty keys invalidate the whole cache. The errors are logged
+func Invalidate(name string, keys ...string) {
+	err := Publish(&Event{Kind: KindCache, Type: name, IDs: keys})
+	if err != nil {
+		log.Error("[Cluster] publish invalidate %s %v: %s", name, keys, err.Error())
+	}
+}
+
+// ReloadApp broadcasts the reload of the application with the changed files, the errors are logged
+func ReloadApp(files ...string) {
+	err := Publish(&Event{Kind: KindApp, Action: ActionReload, IDs: files})
+	if err != nil {
+		log.Error("[Cluster] publish reload: %s", err.Error())
+	}
+}
+
+// GetStatus returns the state of the event bus of the instance
+func GetStatus() Status {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	status := stats
+	status.Instance = instance
+	status.Driver = setting.Driver
+	status.Caches = []string{}
+	for name := range caches {
+		status.Caches = append(status.Caches, name)
+	}
+	sort.Strings(status.Caches)
+	return status
+}
+
+// dispatch the event to the handler of its kind, the events of the instance itself are ignored.
+// The events are dispatched one by one in the order they are received, so every instance applies the same changes in the same order.
+func dispatch(event *Event) {
+	if event == nil || event.Origin == instance {
+		return
+	}
+
+	mutex.Lock()
+	stats.Received++
+	stats.LastEvent = event
+	handler, has := handlers[event.Kind]
+	mutex.Unlock()
+
+	if !has {
+		log.Warn("[Cluster] no handler of the %s event %s", event.Kind, event.ID)
+		return
+	}
+
+	err := handler(event)
+	if err != nil {
+		log.Error("[Cluster] %s event %s from %s: %s", event.Kind, event.ID, event.Origin, err.Error())
+		failed(err)
+		return
+	}
+	log.Trace("[Cluster] %s event %s from %s applied", event.Kind, event.ID, event.Origin)
+}
+
+func failed(err error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	stats.Failed++
+	stats.LastError = err.Error()
+}
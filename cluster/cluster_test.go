@@ -0,0 +1,132 @@
+package cluster
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/yao/config"
+)
+
+// memory the in-process bus shared by the instances of the tests
+type memory struct {
+	events chan *Event
+}
+
+func (m *memory) Publish(ctx context.Context, event *Event) error {
+	copied := *event
+	m.events <- &copied
+	return nil
+}
+
+func (m *memory) Subscribe(ctx context.Context, handler func(event *Event)) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-m.events:
+			handler(event)
+		}
+	}
+}
+
+func (m *memory) Close() error {
+	return nil
+}
+
+func TestInvalidate(t *testing.T) {
+	shared := &memory{events: make(chan *Event, 10)}
+	useBus(t, shared)
+
+	var lock sync.Mutex
+	received := [][]string{}
+	RegisterCache("unit-test", func(keys []string) error {
+		lock.Lock()
+		defer lock.Unlock()
+		received = append(received, keys)
+		return nil
+	})
+
+	// The events of the instance itself are ignored
+	Invalidate("unit-test", "ignored")
+
+	// The events of the other instances are applied in order
+	shared.Publish(context.Background(), &Event{ID: "1", Origin: "other", Kind: KindCache, Type: "unit-test", IDs: []string{"a", "b"}})
+	shared.Publish(context.Background(), &Event{ID: "2", Origin: "other", Kind: KindCache, Type: "unit-test"})
+	shared.Publish(context.Background(), &Event{ID: "3", Origin: "other", Kind: KindCache, Type: "not-registered"})
+
+	assert.Eventually(t, func() bool { return GetStatus().Received == 3 }, time.Second, 10*time.Millisecond)
+
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, [][]string{{"a", "b"}, nil}, received)
+
+	status := GetStatus()
+	assert.Equal(t, int64(1), status.Published)
+	assert.Equal(t, "3", status.LastEvent.ID)
+	assert.Contains(t, status.Caches, "unit-test")
+	assert.True(t, status.Running)
+}
+
+func TestHandle(t *testing.T) {
+	shared := &memory{events: make(chan *Event, 10)}
+	useBus(t, shared)
+
+	done := make(chan *Event, 1)
+	Handle(KindApp, func(event *Event) error {
+		done <- event
+		return nil
+	})
+
+	shared.Publish(context.Background(), &Event{ID: "app", Origin: "other", Kind: KindApp, Action: ActionReload, IDs: []string{"/apis/user.http.yao"}})
+	select {
+	case event := <-done:
+		assert.Equal(t, []string{"/apis/user.http.yao"}, event.IDs)
+	case <-time.After(time.Second):
+		t.Fatal("the app event is not handled")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	defer Load(config.Config{})
+
+	require.NoError(t, Load(config.Config{}))
+	assert.False(t, Enabled())
+	require.NoError(t, Publish(&Event{Kind: KindCache, Type: "unit-test"}))
+
+	err := Load(config.Config{Cluster: config.Cluster{Driver: "kafka"}})
+	assert.Error(t, err)
+
+	require.NoError(t, Load(config.Config{Cluster: config.Cluster{Driver: "db", Interval: 1, Keep: 60}}))
+	assert.True(t, Enabled())
+	assert.Equal(t, "db", GetStatus().Driver)
+
+	require.NoError(t, Load(config.Config{Cluster: config.Cluster{Driver: "redis", Redis: "redis://127.0.0.1:6379/1"}}))
+	assert.True(t, Enabled())
+}
+
+func TestSessionRedis(t *testing.T) {
+	dsn := sessionRedis(config.Session{Host: "127.0.0.1", Port: "6379", DB: "1"})
+	assert.Equal(t, "redis://127.0.0.1:6379/1", dsn)
+
+	dsn = sessionRedis(config.Session{Host: "redis", Port: "6380", DB: "2", Password: "secret"})
+	assert.Equal(t, "redis://:secret@redis:6380/2", dsn)
+}
+
+func useBus(t *testing.T, b Bus) {
+	mutex.Lock()
+	bus = b
+	stats = Status{}
+	mutex.Unlock()
+	Start()
+
+	t.Cleanup(func() {
+		Stop()
+		mutex.Lock()
+		bus = nil
+		mutex.Unlock()
+	})
+}
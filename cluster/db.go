@@ -0,0 +1,174 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/spf13/cast"
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/kun/log"
+)
+
+// EventModel the model of the events shared by the instances through the database
+const EventModel = "__yao.cluster"
+
+// pollSize the max number of the events read at once
+const pollSize = 200
+
+// DB the event bus polling the events table of the database shared by the instances
+type DB struct {
+	interval time.Duration
+	keep     time.Duration
+
+	// cursor the ID of the last delivered event, kept across the resubscriptions
+	mutex   sync.Mutex
+	cursor  int64
+	started bool
+}
+
+// NewDB create the database event bus
+func NewDB(interval time.Duration, keep time.Duration) *DB {
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+	if keep <= 0 {
+		keep = time.Hour
+	}
+	return &DB{interval: interval, keep: keep}
+}
+
+func eventModel() (*model.Model, error) {
+	mod, has := model.Models[EventModel]
+	if !has {
+		return nil, fmt.Errorf("model %s not found", EventModel)
+	}
+	return mod, nil
+}
+
+// Publish insert the event
+func (db *DB) Publish(ctx context.Context, event *Event) error {
+	mod, err := eventModel()
+	if err != nil {
+		return err
+	}
+
+	payload, err := jsoniter.MarshalToString(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = mod.Create(map[string]interface{}{
+		"event_id": event.ID,
+		"origin":   event.Origin,
+		"kind":     event.Kind,
+		"payload":  payload,
+	})
+	return err
+}
+
+// Subscribe polls the events inserted after the first subscription, in the order of their IDs
+// The resubscriptions resume from the last delivered event, the events published during the outage are not skipped
+func (db *DB) Subscribe(ctx context.Context, handler func(event *Event)) error {
+	mod, err := eventModel()
+	if err != nil {
+		return err
+	}
+
+	last, err := db.resume(mod)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(db.interval)
+	defer ticker.Stop()
+	purged := time.Now()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		rows, err := mod.Get(model.QueryParam{
+			Select: []interface{}{"id", "payload"},
+			Wheres: []model.QueryWhere{{Column: "id", OP: ">", Value: last}},
+			Orders: []model.QueryOrder{{Column: "id", Option: "asc"}},
+			Limit:  pollSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			last = cast.ToInt64(row["id"])
+			db.deliver(last)
+			var event Event
+			err := jsoniter.UnmarshalFromString(cast.ToString(row["payload"]), &event)
+			if err != nil {
+				log.Warn("[Cluster] invalid event %d: %s", last, err.Error())
+				continue
+			}
+			handler(&event)
+		}
+
+		// Every instance removes the expired events, removing them twice is harmless
+		if time.Since(purged) > db.keep/10 {
+			purged = time.Now()
+			_, err := mod.DeleteWhere(model.QueryParam{
+				Wheres: []model.QueryWhere{{Column: "created_at", OP: "<", Value: purged.Add(-db.keep)}},
+			})
+			if err != nil {
+				log.Warn("[Cluster] purge the expired events: %s", err.Error())
+			}
+		}
+	}
+}
+
+// resume returns the ID the subscription starts after, the latest event at the first subscription
+func (db *DB) resume(mod *model.Model) (int64, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	if db.started {
+		return db.cursor, nil
+	}
+
+	last, err := db.last(mod)
+	if err != nil {
+		return 0, err
+	}
+	db.cursor = last
+	db.started = true
+	return last, nil
+}
+
+// deliver records the ID of the delivered event
+func (db *DB) deliver(id int64) {
+	db.mutex.Lock()
+	db.cursor = id
+	db.mutex.Unlock()
+}
+
+// last returns the ID of the latest event, the events published before the subscription are skipped
+func (db *DB) last(mod *model.Model) (int64, error) {
+	rows, err := mod.Get(model.QueryParam{
+		Select: []interface{}{"id"},
+		Orders: []model.QueryOrder{{Column: "id", Option: "desc"}},
+		Limit:  1,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	return cast.ToInt64(rows[0]["id"]), nil
+}
+
+// Close the bus, the database connections are shared
+func (db *DB) Close() error {
+	return nil
+}
@@ -0,0 +1,57 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/yaoapp/yao/dsl"
+	"github.com/yaoapp/yao/dsl/types"
+)
+
+// handleDSL applies the action to the DSLs. The DSLs are read from their store again,
+// the load options (migration, dropTable ...) are applied by the publishing instance only.
+func handleDSL(event *Event) error {
+	typ := types.Type(event.Type)
+	manager, err := dsl.New(typ)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	store := types.StoreType(event.Store)
+	messages := []string{}
+	for _, id := range event.IDs {
+		path := types.ToPath(typ, id)
+		switch event.Action {
+		case ActionLoad:
+			err = manager.Load(ctx, &types.LoadOptions{ID: id, Path: path, Store: store})
+		case ActionUnload:
+			err = manager.Unload(ctx, &types.UnloadOptions{ID: id, Path: path, Store: store})
+		case ActionReload:
+			err = manager.Reload(ctx, &types.ReloadOptions{ID: id, Path: path, Store: store})
+		default:
+			return fmt.Errorf("dsl action %s is not supported", event.Action)
+		}
+
+		if err != nil {
+			messages = append(messages, fmt.Sprintf("%s %s: %s", typ, id, err.Error()))
+		}
+	}
+
+	if len(messages) > 0 {
+		return fmt.Errorf("%s", strings.Join(messages, ";\n"))
+	}
+	return nil
+}
+
+// handleCache invalidates the keys of the named cache, the caches not registered by the instance are ignored
+func handleCache(event *Event) error {
+	mutex.RLock()
+	invalidator, has := caches[event.Type]
+	mutex.RUnlock()
+	if !has {
+		return nil
+	}
+	return invalidator(event.IDs)
+}
@@ -0,0 +1,54 @@
+package cluster
+
+import (
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/kun/exception"
+)
+
+func init() {
+	process.RegisterGroup("cluster", map[string]process.Handler{
+		"status":     processStatus,
+		"invalidate": processInvalidate,
+		"reload":     processReload,
+	})
+}
+
+// processStatus cluster.Status returns the state of the event bus of the instance
+func processStatus(process *process.Process) interface{} {
+	return GetStatus()
+}
+
+// processInvalidate cluster.Invalidate (:name, ...:keys) invalidate the cache keys on every instance, no keys invalidate the whole cache
+func processInvalidate(process *process.Process) interface{} {
+	process.ValidateArgNums(1)
+	name := process.ArgsString(0)
+	keys := stringArgs(process, 1)
+
+	err := handleCache(&Event{Kind: KindCache, Type: name, IDs: keys})
+	if err != nil {
+		exception.New(err.Error(), 500).Throw()
+	}
+	Invalidate(name, keys...)
+	return nil
+}
+
+// processReload cluster.Reload (:type, ...:ids) reload the DSLs on every instance
+func processReload(process *process.Process) interface{} {
+	process.ValidateArgNums(2)
+	event := &Event{Kind: KindDSL, Action: ActionReload, Type: process.ArgsString(0), IDs: stringArgs(process, 1)}
+
+	err := handleDSL(event)
+	if err != nil {
+		exception.New(err.Error(), 500).Throw()
+	}
+	ReloadDSL(event.Action, event.Type, event.Store, event.IDs...)
+	return nil
+}
+
+func stringArgs(process *process.Process, from int) []string {
+	values := []string{}
+	for i := from; i < process.NumOfArgs(); i++ {
+		values = append(values, process.ArgsString(i))
+	}
+	return values
+}
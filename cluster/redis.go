@@ -0,0 +1,76 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/redis/go-redis/v9"
+	"github.com/yaoapp/kun/log"
+)
+
+// Redis the event bus on the redis pub/sub, the events are delivered at most once
+type Redis struct {
+	client  *redis.Client
+	channel string
+}
+
+// NewRedis create the redis event bus by the redis URL, e.g. redis://:password@127.0.0.1:6379/1
+func NewRedis(dsn string, channel string) (*Redis, error) {
+	options, err := redis.ParseURL(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("cluster redis %s: %w", dsn, err)
+	}
+
+	if channel == "" {
+		channel = "yao:cluster"
+	}
+	return &Redis{client: redis.NewClient(options), channel: channel}, nil
+}
+
+// Publish the event to the channel
+func (r *Redis) Publish(ctx context.Context, event *Event) error {
+	payload, err := jsoniter.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return r.client.Publish(ctx, r.channel, payload).Err()
+}
+
+// Subscribe the channel, the messages are handled one by one
+func (r *Redis) Subscribe(ctx context.Context, handler func(event *Event)) error {
+	sub := r.client.Subscribe(ctx, r.channel)
+	defer sub.Close()
+
+	// Wait for the confirmation of the subscription
+	_, err := sub.Receive(ctx)
+	if err != nil {
+		return err
+	}
+
+	messages := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case message, ok := <-messages:
+			if !ok {
+				return fmt.Errorf("redis channel %s is closed", r.channel)
+			}
+
+			var event Event
+			err := jsoniter.Unmarshal([]byte(message.Payload), &event)
+			if err != nil {
+				log.Warn("[Cluster] invalid event on %s: %s", r.channel, err.Error())
+				continue
+			}
+			handler(&event)
+		}
+	}
+}
+
+// Close the redis client
+func (r *Redis) Close() error {
+	return r.client.Close()
+}
@@ -0,0 +1,71 @@
+package cluster
+
+import (
+	"context"
+)
+
+const (
+	// KindDSL a DSL is loaded, unloaded or reloaded, the receivers apply the same action to the same DSL IDs
+	KindDSL = "dsl"
+
+	// KindCache the entries of a named cache are invalidated, empty keys invalidate the whole cache
+	KindCache = "cache"
+
+	// KindApp the application is reloaded, e.g. the application files are changed
+	KindApp = "app"
+)
+
+const (
+	// ActionLoad load the DSL
+	ActionLoad = "load"
+
+	// ActionUnload unload the DSL
+	ActionUnload = "unload"
+
+	// ActionReload reload the DSL
+	ActionReload = "reload"
+)
+
+// Event the event broadcasted to the instances of the cluster
+type Event struct {
+	ID     string   `json:"id"`               // Unique ID of the event
+	Origin string   `json:"origin"`           // The instance publishing the event
+	Kind   string   `json:"kind"`             // dsl | cache | app
+	Action string   `json:"action,omitempty"` // load | unload | reload, the DSL events only
+	Type   string   `json:"type,omitempty"`   // The DSL type of the DSL events or the cache name of the cache events
+	IDs    []string `json:"ids,omitempty"`    // The DSL IDs, the cache keys or the changed files
+	Store  string   `json:"store,omitempty"`  // The store of the DSL, file | db
+	Time   int64    `json:"time"`             // The publishing time in milliseconds
+}
+
+// Handler handles the events received from the other instances
+type Handler func(event *Event) error
+
+// Invalidator invalidates the keys of a cache, empty keys invalidate all
+type Invalidator func(keys []string) error
+
+// Bus the event bus shared by the instances
+type Bus interface {
+
+	// Publish the event to the other instances
+	Publish(ctx context.Context, event *Event) error
+
+	// Subscribe calls the handler for each event, blocks until the context is done or the subscription fails
+	Subscribe(ctx context.Context, handler func(event *Event)) error
+
+	// Close the bus
+	Close() error
+}
+
+// Status the state of the event bus of the instance
+type Status struct {
+	Instance  string   `json:"instance"`
+	Driver    string   `json:"driver"`
+	Running   bool     `json:"running"`
+	Published int64    `json:"published"`
+	Received  int64    `json:"received"`
+	Failed    int64    `json:"failed"`
+	LastEvent *Event   `json:"last_event,omitempty"`
+	LastError string   `json:"last_error,omitempty"`
+	Caches    []string `json:"caches"`
+}
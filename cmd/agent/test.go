@@ -33,10 +33,12 @@ var (
 	testParallel  int
 	testVerbose   bool
 	testFailFast  bool
-	testBefore    string // --before flag for global BeforeAll hook
-	testAfter     string // --after flag for global AfterAll hook
-	testDryRun    bool   // --dry-run flag for generating tests without running
-	testSimulator string // --simulator flag for default simulator agent in dynamic mode
+	testBefore    string  // --before flag for global BeforeAll hook
+	testAfter     string  // --after flag for global AfterAll hook
+	testDryRun    bool    // --dry-run flag for generating tests without running
+	testSimulator string  // --simulator flag for default simulator agent in dynamic mode
+	testBaseline  string  // --baseline flag for regression comparison with a previous report
+	testTolerance float64 // --baseline-tolerance flag for allowed drop before a regression
 )
 
 // TestCmd is the agent test command
@@ -165,6 +167,9 @@ var TestCmd = &cobra.Command{
 			AfterAll:    testAfter,
 			DryRun:      testDryRun,
 			Simulator:   testSimulator,
+
+			Baseline:          testBaseline,
+			BaselineTolerance: testTolerance,
 		}
 
 		// Merge with defaults
@@ -257,6 +262,9 @@ func init() {
 	TestCmd.Flags().BoolVar(&testDryRun, "dry-run", false, L("Generate test cases without running them"))
 	TestCmd.Flags().StringVar(&testSimulator, "simulator", "", L("Default simulator agent for dynamic mode (e.g., tests.simulator-agent)"))
 
+	TestCmd.Flags().StringVar(&testBaseline, "baseline", "", L("Baseline report to compare with, fails on regressions"))
+	TestCmd.Flags().Float64Var(&testTolerance, "baseline-tolerance", 0, L("Allowed drop in pass rate (%) or score before a regression"))
+
 	// Mark input as required
 	TestCmd.MarkFlagRequired("input")
 }
@@ -1,24 +1,28 @@
 package cmd
 
 import (
-	"archive/zip"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/fatih/color"
+	jsoniter "github.com/json-iterator/go"
 	"github.com/spf13/cobra"
 	"github.com/yaoapp/gou/model"
 	"github.com/yaoapp/kun/exception"
 	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/dump"
 	"github.com/yaoapp/yao/engine"
 )
 
-var dumpModel string
+var dumpModels []string
+var dumpQueries []string
+var dumpSince string
+var dumpPassphrase string
+var dumpNoData bool
 var dumpCmd = &cobra.Command{
 	Use:   "dump",
 	Short: L("Dump the application data"),
@@ -69,143 +73,114 @@ var dumpCmd = &cobra.Command{
 			}
 		}
 
-		if dumpModel != "" {
-			fmt.Println(color.YellowString(L("Not supported yet")))
+		option, err := dumpOption()
+		if err != nil {
+			fmt.Println(color.RedString(L("Fatal: %s"), err.Error()))
 			os.Exit(1)
-			return
 		}
 
-		// Export models
-		files := []string{}
-		for _, mod := range model.Models {
-
-			fmt.Printf("\r%s", color.GreenString(L("Export the models: %s (%s)"), mod.Name, mod.MetaData.Table.Name))
-			jsonfiles, err := mod.Export(5000, func(curr, total int) {
-				fmt.Printf("\r%s", strings.Repeat(" ", 80))
-				fmt.Printf("\r%s", color.GreenString(L("Export the models: %s (%s) %d/%d"), mod.Name, mod.MetaData.Table.Name, curr, total))
-			})
-
-			if err != nil {
-				fmt.Println(color.RedString(L("Fatal: %s"), err.Error()))
-				os.Exit(1)
-			}
-			files = append(files, jsonfiles...)
-		}
-		fmt.Printf("\r%s", strings.Repeat(" ", 80))
-		fmt.Printf("\r%s\n", color.GreenString(L("Export the models: ✨DONE✨")))
-
-		// Compress files
-		err = zipfiles(files, output, func(file string) {
-			fmt.Printf("\r%s", strings.Repeat(" ", 80))
-			fmt.Printf("\r%s", color.GreenString(L("Compress the files: %s"), file))
-		})
-		fmt.Printf("\r%s", strings.Repeat(" ", 80))
-		fmt.Printf("\r%s\n", color.GreenString(L("Compress the files: ✨DONE✨")))
-
+		manifest, err := dump.Dump(output, option)
+		fmt.Printf("\r%s\r", strings.Repeat(" ", 80))
 		if err != nil {
 			fmt.Println(color.RedString(L("Fatal: %s"), err.Error()))
 			os.Exit(1)
 		}
+		fmt.Println(color.GreenString(L("Export the models: ✨DONE✨")))
 
+		for _, entry := range manifest.Models {
+			watermark := "-"
+			if entry.Watermark != nil {
+				watermark = entry.Watermark.Format("2006-01-02 15:04:05")
+			}
+			mode := L("full")
+			if entry.Incremental {
+				mode = L("incremental")
+			}
+			fmt.Printf("  %-32s %8d  %-12s %s\n", entry.Model, entry.Rows, mode, color.WhiteString(watermark))
+		}
+
+		if option.Key != "" {
+			fmt.Println(color.YellowString(L("The dump is encrypted, keep the passphrase to restore it")))
+		}
 		fmt.Println(color.GreenString("File: %s", output))
 	},
 }
 
-// func init() {
-// 	// dumpCmd.PersistentFlags().StringVarP(&dumpModel, "name", "n", "", L("Model name"))
-// }
-
-// gzipfiles
-func zipfiles(files []string, output string, process func(file string)) error {
-	outpath := filepath.Dir(output)
-	os.MkdirAll(outpath, 0755)
+func init() {
+	dumpCmd.Flags().StringSliceVarP(&dumpModels, "models", "m", []string{}, L("The models to dump, all the models if not set"))
+	dumpCmd.Flags().StringArrayVar(&dumpQueries, "query", []string{}, L("Filter the rows of a model: <model>=<query param JSON>"))
+	dumpCmd.Flags().StringVar(&dumpSince, "since", "", L("Dump the rows updated after the previous dump file or the time"))
+	dumpCmd.Flags().StringVar(&dumpPassphrase, "passphrase", "", L("Encrypt the dump with the passphrase (default $YAO_DUMP_KEY)"))
+	dumpCmd.Flags().BoolVar(&dumpNoData, "no-data", false, L("Do not dump the data directory"))
+}
 
-	outfile, err := os.Create(output)
-	if err != nil {
-		fmt.Println(color.RedString(L("Fatal: %s"), err.Error()))
-		os.Exit(1)
+// dumpOption reads the option from the flags.
+// The data directory is dumped only if all the rows of all the models are dumped.
+func dumpOption() (dump.Option, error) {
+	option := dump.Option{
+		Models:  dumpModels,
+		Queries: map[string]model.QueryParam{},
+		Key:     passphrase(dumpPassphrase),
+		Progress: func(name string, rows int) {
+			fmt.Printf("\r%s", strings.Repeat(" ", 80))
+			fmt.Printf("\r%s", color.GreenString(L("Export the models: %s %d"), name, rows))
+		},
 	}
-	defer outfile.Close()
 
-	w := zip.NewWriter(outfile)
-	defer func() {
-		w.Close()
-		if err != nil {
-			fmt.Println(color.RedString(L("Fatal: %s"), err.Error()))
-			os.Exit(1)
+	for _, query := range dumpQueries {
+		name, value, ok := strings.Cut(query, "=")
+		if !ok {
+			return option, fmt.Errorf("the query %s should be <model>=<query param JSON>", query)
 		}
-	}()
+		param := model.QueryParam{}
+		if err := jsoniter.UnmarshalFromString(value, &param); err != nil {
+			return option, fmt.Errorf("the query of %s is invalid: %s", name, err.Error())
+		}
+		option.Queries[name] = param
 
-	for _, file := range files {
-		addFile(w, file, "model", process)
+		// Only the queried models are dumped if the models are not set
+		if len(dumpModels) == 0 {
+			option.Models = append(option.Models, name)
+		}
 	}
 
-	// Add data path
-	dataPath := filepath.Join(config.Conf.Root, "data")
-	_, err = os.Stat(dataPath)
-	if err == nil {
-		addFolder(w, dataPath, "data", process)
+	if dumpSince != "" {
+		if _, err := os.Stat(dumpSince); err == nil {
+			archive, err := dump.Open(dumpSince, option.Key)
+			if err != nil {
+				return option, err
+			}
+			option.Since = archive.Manifest
+			archive.Close()
+			if option.Since == nil {
+				return option, fmt.Errorf("%s has no watermarks, it is written by an earlier version", dumpSince)
+			}
+		} else {
+			since, err := parseSince(dumpSince)
+			if err != nil {
+				return option, err
+			}
+			option.SinceTime = &since
+		}
 	}
 
-	return nil
+	option.Data = !dumpNoData && len(dumpModels) == 0 && len(dumpQueries) == 0 && dumpSince == ""
+	return option, nil
 }
 
-func addFile(w *zip.Writer, file, baseInZip string, process func(file string)) {
-	process(filepath.Join(baseInZip, filepath.Base(file)))
-	dat, err := ioutil.ReadFile(file)
-	if err != nil {
-		fmt.Println(color.RedString(L("Fatal: %s"), err.Error()))
-		os.Exit(1)
-	}
-
-	// Add some files to the archive.
-	f, err := w.Create(filepath.Join(baseInZip, filepath.Base(file)))
-	if err != nil {
-		fmt.Println(color.RedString(L("Fatal: %s"), err.Error()))
-		os.Exit(1)
-	}
-	_, err = f.Write(dat)
-	if err != nil {
-		fmt.Println(color.RedString(L("Fatal: %s"), err.Error()))
-		os.Exit(1)
+func parseSince(value string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
 	}
-
-	os.Remove(file)
+	return time.Time{}, fmt.Errorf("--since %s is neither a dump file nor a time", value)
 }
 
-func addFolder(w *zip.Writer, basePath, baseInZip string, process func(file string)) {
-
-	// Open the Directory
-	files, err := ioutil.ReadDir(basePath)
-	if err != nil {
-		fmt.Println(color.RedString(L("Fatal: %s"), err.Error()))
-		os.Exit(1)
-	}
-
-	for _, file := range files {
-		process(filepath.Join(baseInZip, file.Name()))
-		if !file.IsDir() {
-			dat, err := ioutil.ReadFile(filepath.Join(basePath, file.Name()))
-			if err != nil {
-				fmt.Println(color.RedString(L("Fatal: %s"), err.Error()))
-				os.Exit(1)
-			}
-
-			// Add some files to the archive.
-			f, err := w.Create(filepath.Join(baseInZip, file.Name()))
-			if err != nil {
-				fmt.Println(color.RedString(L("Fatal: %s"), err.Error()))
-				os.Exit(1)
-			}
-			_, err = f.Write(dat)
-			if err != nil {
-				fmt.Println(color.RedString(L("Fatal: %s"), err.Error()))
-				os.Exit(1)
-			}
-		} else if file.IsDir() {
-			// Recurse
-			newBase := filepath.Join(basePath, file.Name())
-			addFolder(w, newBase, filepath.Join(baseInZip, file.Name()), process)
-		}
+// passphrase returns the passphrase of the flag or the YAO_DUMP_KEY environment variable
+func passphrase(value string) string {
+	if value != "" {
+		return value
 	}
+	return os.Getenv("YAO_DUMP_KEY")
 }
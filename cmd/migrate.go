@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -11,12 +13,15 @@ import (
 	"github.com/yaoapp/kun/exception"
 	"github.com/yaoapp/yao/config"
 	"github.com/yaoapp/yao/engine"
+	"github.com/yaoapp/yao/migration"
 	"github.com/yaoapp/yao/share"
 )
 
 var name string
 var force bool = false
 var resetModel bool = false
+var migrateYes bool = false
+var migrateSteps int = 1
 var migrateCmd = &cobra.Command{
 	Use:   "migrate",
 	Short: L("Update database schema"),
@@ -114,8 +119,264 @@ var migrateCmd = &cobra.Command{
 	},
 }
 
+var migratePlanCmd = &cobra.Command{
+	Use:   "plan",
+	Short: L("Show the schema changes of the models"),
+	Long:  L("Compare the models with the database and print the DDL statements, the database is not changed"),
+	Run: func(cmd *cobra.Command, args []string) {
+		defer catchMigrate()
+		loadMigrate()
+
+		plan := diffModels()
+		if len(plan.Changes) == 0 {
+			fmt.Println(color.GreenString(L("The schema is up to date")))
+			return
+		}
+
+		for _, change := range plan.Changes {
+			printChange(change)
+		}
+
+		fmt.Println(color.WhiteString(L("%d changes, %d destructive, %d not supported by %s"), len(plan.Changes), len(plan.Destructive()), len(plan.Unsupported()), plan.Driver))
+		fmt.Println(color.WhiteString(L("TRY:")), color.GreenString("%s migrate create <name>", share.BUILDNAME))
+	},
+}
+
+var migrateCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: L("Create a migration file from the schema changes"),
+	Long:  L("Create a migration file in the migrations directory from the schema changes of the models"),
+	Run: func(cmd *cobra.Command, args []string) {
+		defer catchMigrate()
+		if len(args) < 1 {
+			fmt.Println(color.RedString(L("Not enough arguments")))
+			fmt.Println(color.WhiteString(share.BUILDNAME + " migrate create <name>"))
+			return
+		}
+		loadMigrate()
+
+		plan := diffModels()
+		for _, change := range plan.Unsupported() {
+			fmt.Println(color.YellowString(L("Skipped %s %s.%s: %s"), change.Kind, change.Table, change.Name, change.Reason))
+		}
+
+		mig, err := migration.Create(args[0], plan)
+		if err != nil {
+			exception.New(err.Error(), 400).Throw()
+		}
+
+		fmt.Println(color.GreenString(L("Migration created: %s"), mig.File))
+		for _, note := range mig.Notes {
+			fmt.Println(color.YellowString("  %s", note))
+		}
+		if mig.Destructive {
+			fmt.Println(color.RedString(L("The migration is destructive, review it before applying")))
+		}
+	},
+}
+
+var migrateUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: L("Apply the pending migrations"),
+	Long:  L("Apply the pending migrations of the migrations directory in order"),
+	Run: func(cmd *cobra.Command, args []string) {
+		defer catchMigrate()
+		loadMigrate()
+		checkMigrateMode(cmd.Name())
+
+		pending, err := migration.Pending()
+		if err != nil {
+			exception.New(err.Error(), 400).Throw()
+		}
+
+		if len(pending) == 0 {
+			fmt.Println(color.GreenString(L("No pending migrations")))
+			return
+		}
+
+		for _, mig := range pending {
+			fmt.Print(color.WhiteString(fmt.Sprintf(L("Apply migration: %s %s "), mig.Version, mig.Name)) + "\t")
+			if mig.Destructive {
+				fmt.Println(color.RedString(L("DESTRUCTIVE")))
+				for _, note := range mig.Notes {
+					fmt.Println(color.YellowString("  %s", note))
+				}
+				if !confirmMigrate(L("The migration may lose data, type yes to apply it: ")) {
+					fmt.Println(color.YellowString(L("Canceled, the migrations after %s are not applied"), mig.Version))
+					return
+				}
+			}
+
+			err := migration.Apply(mig)
+			if err != nil {
+				fmt.Print(color.RedString(fmt.Sprintf(L("FAILURE\n%s"), err.Error())) + "\n")
+				return
+			}
+			fmt.Print(color.GreenString(L("SUCCESS")) + "\n")
+		}
+	},
+}
+
+var migrateDownCmd = &cobra.Command{
+	Use:   "down",
+	Short: L("Roll back the applied migrations"),
+	Long:  L("Roll back the last applied migrations with their down statements"),
+	Run: func(cmd *cobra.Command, args []string) {
+		defer catchMigrate()
+		loadMigrate()
+		checkMigrateMode(cmd.Name())
+
+		records, err := migration.Applied()
+		if err != nil {
+			exception.New(err.Error(), 500).Throw()
+		}
+
+		if len(records) == 0 {
+			fmt.Println(color.GreenString(L("No applied migrations")))
+			return
+		}
+
+		for i := len(records) - 1; i >= 0 && i >= len(records)-migrateSteps; i-- {
+			record := records[i]
+			fmt.Println(color.WhiteString(L("Roll back migration: %s %s"), record.Version, record.Name))
+			for _, stmt := range record.Down {
+				fmt.Println(color.CyanString("  %s;", stmt))
+			}
+
+			if len(record.Down) > 0 && !confirmMigrate(L("Rolling back may lose data, type yes to continue: ")) {
+				fmt.Println(color.YellowString(L("Canceled")))
+				return
+			}
+
+			err := migration.Rollback(record)
+			if err != nil {
+				fmt.Println(color.RedString(L("FAILURE\n%s"), err.Error()))
+				return
+			}
+			fmt.Println(color.GreenString(L("SUCCESS")))
+		}
+	},
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: L("Show the status of the migrations"),
+	Long:  L("Show the migration files and the applied migrations"),
+	Run: func(cmd *cobra.Command, args []string) {
+		defer catchMigrate()
+		loadMigrate()
+
+		statuses, err := migration.Statuses()
+		if err != nil {
+			exception.New(err.Error(), 500).Throw()
+		}
+
+		if len(statuses) == 0 {
+			fmt.Println(color.WhiteString(L("No migrations")))
+			return
+		}
+
+		for _, status := range statuses {
+			state := color.YellowString(L("pending"))
+			switch {
+			case status.Missing:
+				state = color.RedString(L("applied, the file is removed"))
+			case status.Changed:
+				state = color.RedString(L("applied, the file is changed"))
+			case status.Applied:
+				state = color.GreenString(L("applied at %s"), status.AppliedAt.Format("2006-01-02 15:04:05"))
+			}
+			fmt.Printf("%s  %-40s %s\n", color.WhiteString(status.Version), status.Name, state)
+		}
+	},
+}
+
 func init() {
 	migrateCmd.PersistentFlags().StringVarP(&name, "name", "n", "", L("Model name"))
 	migrateCmd.PersistentFlags().BoolVarP(&force, "force", "", false, L("Force migrate"))
 	migrateCmd.PersistentFlags().BoolVarP(&resetModel, "reset", "", false, L("Drop the table if exist"))
+
+	migrateUpCmd.Flags().BoolVarP(&migrateYes, "yes", "y", false, L("Apply the destructive migrations without confirmation"))
+	migrateDownCmd.Flags().BoolVarP(&migrateYes, "yes", "y", false, L("Roll back without confirmation"))
+	migrateDownCmd.Flags().IntVarP(&migrateSteps, "steps", "s", 1, L("Number of migrations to roll back"))
+	migrateCmd.AddCommand(migratePlanCmd, migrateCreateCmd, migrateUpCmd, migrateDownCmd, migrateStatusCmd)
+}
+
+func catchMigrate() {
+	err := exception.Catch(recover())
+	if err != nil {
+		fmt.Println(color.RedString(L("Fatal: %s"), err.Error()))
+		os.Exit(1)
+	}
+}
+
+// loadMigrate boots and loads the models for the migrate sub commands
+func loadMigrate() {
+	Boot()
+	loadWarnings, err := engine.Load(config.Conf, engine.LoadOption{Action: "migrate"})
+	if err != nil {
+		fmt.Println(color.RedString(L("Fatal: %s"), err.Error()))
+		os.Exit(1)
+	}
+
+	for _, warning := range loadWarnings {
+		fmt.Println(color.YellowString("[%s] %s", warning.Widget, warning.Error))
+	}
+}
+
+func checkMigrateMode(command string) {
+	if !force && config.Conf.Mode == "production" {
+		fmt.Println(color.WhiteString(L("TRY:")), color.GreenString("%s migrate %s --force", share.BUILDNAME, command))
+		exception.New(L("Migrate is not allowed on production mode."), 403).Throw()
+	}
+}
+
+// diffModels compares the model of --name or all the models with the database
+func diffModels() *migration.Plan {
+	ids := []string{}
+	if name != "" {
+		if _, has := model.Models[name]; !has {
+			exception.New(L("Model: %s does not exits"), 404, name).Throw()
+		}
+		ids = append(ids, name)
+	}
+
+	plan, err := migration.Diff(ids...)
+	if err != nil {
+		exception.New(err.Error(), 500).Throw()
+	}
+	return plan
+}
+
+func printChange(change *migration.Change) {
+	title := fmt.Sprintf("-- %s %s.%s (%s)", change.Kind, change.Table, change.Name, change.Model)
+	switch {
+	case len(change.Up) == 0:
+		fmt.Println(color.YellowString("%s NOT SUPPORTED", title))
+	case change.Destructive:
+		fmt.Println(color.RedString("%s DESTRUCTIVE", title))
+	default:
+		fmt.Println(color.WhiteString(title))
+	}
+
+	if change.Reason != "" {
+		fmt.Println(color.YellowString("-- %s", change.Reason))
+	}
+	for _, stmt := range change.Up {
+		fmt.Println(color.CyanString("%s;", stmt))
+	}
+	fmt.Println()
+}
+
+// confirmMigrate asks the confirmation, it's confirmed by --yes
+func confirmMigrate(message string) bool {
+	if migrateYes {
+		return true
+	}
+	fmt.Print(color.WhiteString(message))
+	input, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(input) == "yes"
 }
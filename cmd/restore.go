@@ -16,12 +16,17 @@ import (
 	"github.com/yaoapp/gou/model"
 	"github.com/yaoapp/kun/exception"
 	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/dump"
 	"github.com/yaoapp/yao/engine"
 	"github.com/yaoapp/yao/share"
 )
 
 var restoreForce bool = false
 var migrateNoInsert bool = false
+var restoreMode string
+var restoreDryRun bool
+var restoreModelsFlag []string
+var restorePassphrase string
 var restoreCmd = &cobra.Command{
 	Use:   "restore",
 	Short: L("Restore the application data"),
@@ -48,16 +53,24 @@ var restoreCmd = &cobra.Command{
 
 		Boot()
 
-		if !restoreForce && config.Conf.Mode == "production" {
+		if !restoreForce && !restoreDryRun && config.Conf.Mode == "production" {
 			fmt.Println(color.WhiteString(L("TRY:")), color.GreenString("%s restore --force", share.BUILDNAME))
 			exception.New(L("Retore is not allowed on production mode."), 403).Throw()
 		}
 
-		// Unzip files
-		dst := unzipFile(zipfile, func(file string) {
-			fmt.Printf("\r%s", strings.Repeat(" ", 80))
-			fmt.Printf("\r%s", color.GreenString(L("Unzip the file: %s"), file))
-		})
+		archive, err := dump.Open(zipfile, passphrase(restorePassphrase))
+		if err != nil {
+			fmt.Println(color.RedString(L("Fatal: %s"), err.Error()))
+			os.Exit(1)
+		}
+
+		// The dumps written by the earlier versions
+		if archive.Manifest == nil {
+			archive.Close()
+			restoreLegacy(zipfile)
+			return
+		}
+		defer archive.Close()
 
 		// 加载数据模型
 		loadWarnings, err := engine.Load(config.Conf, engine.LoadOption{Action: "restore"})
@@ -72,24 +85,100 @@ var restoreCmd = &cobra.Command{
 			}
 		}
 
-		// Restore models
-		restoreModels(filepath.Join(dst, "model"), []model.MigrateOption{
-			model.WithDonotInsertValues(migrateNoInsert),
+		report, err := archive.Restore(dump.RestoreOption{
+			Models: restoreModelsFlag,
+			Mode:   restoreMode,
+			DryRun: restoreDryRun,
+			Progress: func(name string, rows int) {
+				fmt.Printf("\r%s", strings.Repeat(" ", 80))
+				fmt.Printf("\r%s", color.GreenString(L("Restore model: %s %d"), name, rows))
+			},
 		})
+		fmt.Printf("\r%s\r", strings.Repeat(" ", 80))
+		if report != nil {
+			printReport(report)
+		}
+		if err != nil {
+			fmt.Println(color.RedString(L("Fatal: %s"), err.Error()))
+			os.Exit(1)
+		}
 
-		// Restore Data
-		restoreData(filepath.Join(dst, "data"))
+		if restoreDryRun {
+			fmt.Println(color.YellowString(L("Dry run, nothing is changed")))
+			return
+		}
 
-		// Clean
-		os.RemoveAll(dst)
+		// Restore Data
+		if archive.Manifest.Data && len(restoreModelsFlag) == 0 {
+			err = archive.RestoreData(filepath.Join(config.Conf.Root, "data"))
+			if err != nil {
+				fmt.Println(color.RedString(L("Fatal: %s"), err.Error()))
+				os.Exit(1)
+			}
+		}
 
 		fmt.Println(color.GreenString(L("✨DONE✨")))
 	},
 }
 
+// restoreLegacy restores the dump written by the earlier versions, the tables are recreated
+func restoreLegacy(zipfile string) {
+	// Unzip files
+	dst := unzipFile(zipfile, func(file string) {
+		fmt.Printf("\r%s", strings.Repeat(" ", 80))
+		fmt.Printf("\r%s", color.GreenString(L("Unzip the file: %s"), file))
+	})
+
+	// 加载数据模型
+	loadWarnings, err := engine.Load(config.Conf, engine.LoadOption{Action: "restore"})
+	if err != nil {
+		fmt.Println(color.RedString(L("Fatal: %s"), err.Error()))
+		os.Exit(1)
+	}
+
+	if len(loadWarnings) > 0 {
+		for _, warning := range loadWarnings {
+			fmt.Println(color.YellowString("[%s] %s", warning.Widget, warning.Error))
+		}
+	}
+
+	// Restore models
+	restoreModels(filepath.Join(dst, "model"), []model.MigrateOption{
+		model.WithDonotInsertValues(migrateNoInsert),
+	})
+
+	// Restore Data
+	restoreData(filepath.Join(dst, "data"))
+
+	// Clean
+	os.RemoveAll(dst)
+
+	fmt.Println(color.GreenString(L("✨DONE✨")))
+}
+
+// printReport prints the rows restored of the models
+func printReport(report *dump.Report) {
+	fmt.Println(color.WhiteString("  %-32s %8s %8s %8s %8s %8s", L("Model"), L("Rows"), L("Insert"), L("Update"), L("Delete"), L("Failure")))
+	for _, m := range report.Models {
+		line := fmt.Sprintf("  %-32s %8d %8d %8d %8d %8d", m.Model, m.Rows, m.Insert, m.Update, m.Delete, m.Failure)
+		if m.Failure > 0 {
+			fmt.Println(color.RedString(line))
+			for _, message := range m.Errors {
+				fmt.Println(color.RedString("    %s", message))
+			}
+			continue
+		}
+		fmt.Println(color.GreenString(line))
+	}
+}
+
 func init() {
 	restoreCmd.PersistentFlags().BoolVarP(&restoreForce, "force", "", false, L("Force restore"))
 	restoreCmd.PersistentFlags().BoolVarP(&migrateNoInsert, "migrate-no-insert", "", false, L("Do not insert values when migrating"))
+	restoreCmd.PersistentFlags().StringVar(&restoreMode, "mode", dump.Upsert, L("upsert: update the existing rows, replace: delete the rows of the models first"))
+	restoreCmd.PersistentFlags().BoolVar(&restoreDryRun, "dry-run", false, L("Report the changes without writing"))
+	restoreCmd.PersistentFlags().StringSliceVarP(&restoreModelsFlag, "models", "m", []string{}, L("The models to restore, all the models in the dump if not set"))
+	restoreCmd.PersistentFlags().StringVar(&restorePassphrase, "passphrase", "", L("The passphrase of the encrypted dump (default $YAO_DUMP_KEY)"))
 }
 
 func restoreData(basePath string) {
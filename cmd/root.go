@@ -71,6 +71,44 @@ var langs = map[string]string{
 	"Run a terminal user interface defined in .tui.yao files": "运行在 .tui.yao 文件中定义的终端用户界面",
 	"Enable debug mode":                                       "启用调试模式",
 	"Enable verbose output":                                   "启用详细输出",
+	"Open a websocket connection":                             "打开 WebSocket 连接",
+	"The access token of the connection":                      "连接使用的访问令牌",
+	"Show the schema changes of the models":                   "显示数据模型的表结构变更",
+	"Create a migration file from the schema changes":         "根据表结构变更生成迁移文件",
+	"Apply the pending migrations":                            "执行未运行的迁移",
+	"Roll back the applied migrations":                        "回滚已执行的迁移",
+	"Show the status of the migrations":                       "显示迁移状态",
+	"The schema is up to date":                                "表结构已是最新",
+	"No pending migrations":                                   "没有需要执行的迁移",
+
+	// dump and restore
+	"full":        "全量",
+	"incremental": "增量",
+	"The dump is encrypted, keep the passphrase to restore it":                       "备份文件已加密, 请妥善保存密码用于恢复",
+	"The models to dump, all the models if not set":                                  "指定导出的数据模型, 默认导出全部",
+	"Filter the rows of a model: <model>=<query param JSON>":                         "筛选数据模型的记录: <模型>=<查询参数 JSON>",
+	"Dump the rows updated after the previous dump file or the time":                 "导出上次备份文件或指定时间之后更新的记录",
+	"Encrypt the dump with the passphrase (default $YAO_DUMP_KEY)":                   "使用密码加密备份文件 (默认 $YAO_DUMP_KEY)",
+	"Do not dump the data directory":                                                 "不导出 data 目录",
+	"Export the models: %s %d":                                                       "导出数据模型: %s %d",
+	"Restore model: %s %d":                                                           "恢复数据模型: %s %d",
+	"Dry run, nothing is changed":                                                    "试运行, 未做任何修改",
+	"upsert: update the existing rows, replace: delete the rows of the models first": "upsert: 更新已有记录, replace: 先删除数据模型的记录",
+	"Report the changes without writing":                                             "仅报告变更, 不写入数据",
+	"The models to restore, all the models in the dump if not set":                   "指定恢复的数据模型, 默认恢复备份中的全部模型",
+	"The passphrase of the encrypted dump (default $YAO_DUMP_KEY)":                   "加密备份文件的密码 (默认 $YAO_DUMP_KEY)",
+
+	// seed
+	"Seed the data of an environment":                       "导入环境的初始数据",
+	"Show the status of the seed set":                       "显示初始数据集状态",
+	"Apply the seed set":                                    "导入初始数据集",
+	"Reset the data of the seed set":                        "重置初始数据集的数据",
+	"No seed items":                                         "没有初始数据项",
+	"Force seed on production mode":                         "在生产模式下强制导入",
+	"Apply the items unchanged since they were applied too": "同时导入未变更的数据项",
+	"Reset without confirmation":                            "重置前不需要确认",
+	"Seed is not allowed on production mode.":               "生产模式下不允许导入初始数据",
+	"unchanged": "未变更",
 }
 
 // L Language switch
@@ -169,10 +207,11 @@ func init() {
 		runCmd,
 		tui.Cmd,
 		// getCmd,
-		// dumpCmd,
-		// restoreCmd,
+		dumpCmd,
+		restoreCmd,
+		seedCmd,
 		// socketCmd,
-		// websocketCmd,
+		websocketCmd,
 		// packCmd,
 		// studioCmd,
 		suiCmd,
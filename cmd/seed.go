@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/yaoapp/kun/exception"
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/engine"
+	"github.com/yaoapp/yao/seed"
+	"github.com/yaoapp/yao/share"
+)
+
+var seedForce bool = false
+var seedAll bool = false
+var seedYes bool = false
+var seedCmd = &cobra.Command{
+	Use:   "seed",
+	Short: L("Seed the data of an environment"),
+	Long:  L("Apply the seed sets of the seeds directory, seeds/<env>.seed.yao"),
+	CompletionOptions: cobra.CompletionOptions{
+		DisableDefaultCmd: true,
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var seedStatusCmd = &cobra.Command{
+	Use:   "status [env]",
+	Short: L("Show the status of the seed set"),
+	Long:  L("Show the items of the seed set in the order to apply them, dev by default"),
+	Run: func(cmd *cobra.Command, args []string) {
+		defer catchMigrate()
+		loadSeed()
+
+		name := seedSet(args)
+		statuses, err := seed.Statuses(name)
+		if err != nil {
+			exception.New(err.Error(), 400).Throw()
+		}
+
+		if len(statuses) == 0 {
+			fmt.Println(color.WhiteString(L("No seed items")))
+			return
+		}
+
+		for _, status := range statuses {
+			state := color.YellowString(L("pending"))
+			switch {
+			case status.Changed:
+				state = color.RedString(L("applied, changed after it was applied"))
+			case status.Applied:
+				state = color.GreenString(L("applied at %s, %d rows"), status.AppliedAt.Format("2006-01-02 15:04:05"), status.Rows)
+			}
+			fmt.Printf("%s  %-40s %-24s %s\n", color.WhiteString("%-8s", status.Set), status.Item, status.Model, state)
+		}
+	},
+}
+
+var seedApplyCmd = &cobra.Command{
+	Use:   "apply [env]",
+	Short: L("Apply the seed set"),
+	Long:  L("Upsert the rows of the pending and changed items of the seed set, dev by default"),
+	Run: func(cmd *cobra.Command, args []string) {
+		defer catchMigrate()
+		loadSeed()
+		checkSeedMode(cmd.Name())
+
+		results, err := seed.Apply(seedSet(args), seed.ApplyOption{All: seedAll, Progress: seedProgress})
+		fmt.Printf("\r%s\r", strings.Repeat(" ", 80))
+		printSeedResults(results)
+		if err != nil {
+			exception.New(err.Error(), 500).Throw()
+		}
+	},
+}
+
+var seedResetCmd = &cobra.Command{
+	Use:   "reset [env]",
+	Short: L("Reset the data of the seed set"),
+	Long:  L("Delete all the rows of the models of the seed set and apply it again, dev by default"),
+	Run: func(cmd *cobra.Command, args []string) {
+		defer catchMigrate()
+		loadSeed()
+		checkSeedMode(cmd.Name())
+
+		name := seedSet(args)
+		if !seedYes && !confirmMigrate(fmt.Sprintf(L("All the rows of the models of %s will be deleted, type yes to continue: "), name)) {
+			fmt.Println(color.YellowString(L("Canceled")))
+			return
+		}
+
+		results, err := seed.Reset(name, seed.ApplyOption{Progress: seedProgress})
+		fmt.Printf("\r%s\r", strings.Repeat(" ", 80))
+		printSeedResults(results)
+		if err != nil {
+			exception.New(err.Error(), 500).Throw()
+		}
+	},
+}
+
+func init() {
+	seedCmd.PersistentFlags().BoolVarP(&seedForce, "force", "", false, L("Force seed on production mode"))
+	seedApplyCmd.Flags().BoolVarP(&seedAll, "all", "", false, L("Apply the items unchanged since they were applied too"))
+	seedResetCmd.Flags().BoolVarP(&seedYes, "yes", "y", false, L("Reset without confirmation"))
+	seedCmd.AddCommand(seedStatusCmd, seedApplyCmd, seedResetCmd)
+}
+
+// loadSeed boots and loads the models for the seed sub commands
+func loadSeed() {
+	Boot()
+	loadWarnings, err := engine.Load(config.Conf, engine.LoadOption{Action: "seed"})
+	if err != nil {
+		fmt.Println(color.RedString(L("Fatal: %s"), err.Error()))
+		os.Exit(1)
+	}
+
+	for _, warning := range loadWarnings {
+		fmt.Println(color.YellowString("[%s] %s", warning.Widget, warning.Error))
+	}
+}
+
+func checkSeedMode(command string) {
+	if !seedForce && config.Conf.Mode == "production" {
+		fmt.Println(color.WhiteString(L("TRY:")), color.GreenString("%s seed %s --force", share.BUILDNAME, command))
+		exception.New(L("Seed is not allowed on production mode."), 403).Throw()
+	}
+}
+
+func seedSet(args []string) string {
+	if len(args) > 0 {
+		return args[0]
+	}
+	return seed.DefaultSet
+}
+
+func seedProgress(item string, rows int) {
+	fmt.Printf("\r%s", strings.Repeat(" ", 80))
+	fmt.Printf("\r%s", color.GreenString(L("Seed: %s %d"), item, rows))
+}
+
+func printSeedResults(results []seed.ItemResult) {
+	for _, result := range results {
+		title := fmt.Sprintf("%s  %-40s %-24s", color.WhiteString("%-8s", result.Set), result.Item, result.Model)
+		if result.Skipped {
+			fmt.Println(title, color.WhiteString(L("unchanged")))
+			continue
+		}
+
+		res := result.Result
+		if res.Failure > 0 {
+			fmt.Println(title, color.RedString(L("%d rows, %d failed"), res.Total, res.Failure))
+			for i, e := range res.Errors {
+				if i == 10 {
+					fmt.Println(color.RedString(L("  ... %d more"), len(res.Errors)-i))
+					break
+				}
+				fmt.Println(color.RedString("  #%d %s", e.Row, e.Message))
+			}
+			continue
+		}
+		fmt.Println(title, color.GreenString(L("%d rows"), res.Success))
+	}
+}
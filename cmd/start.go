@@ -17,20 +17,21 @@ import (
 	"github.com/yaoapp/gou/fs"
 	"github.com/yaoapp/gou/mcp"
 	"github.com/yaoapp/gou/plugin"
-	"github.com/yaoapp/gou/schedule"
 	"github.com/yaoapp/gou/server/http"
 	"github.com/yaoapp/gou/store"
 	"github.com/yaoapp/gou/task"
-	"github.com/yaoapp/gou/websocket"
 	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/cluster"
 	"github.com/yaoapp/yao/config"
 	"github.com/yaoapp/yao/engine"
+	"github.com/yaoapp/yao/job"
 	"github.com/yaoapp/yao/openapi"
 	ischedule "github.com/yaoapp/yao/schedule"
 	"github.com/yaoapp/yao/service"
 	"github.com/yaoapp/yao/setup"
 	"github.com/yaoapp/yao/share"
 	itask "github.com/yaoapp/yao/task"
+	"github.com/yaoapp/yao/websocket"
 )
 
 var startDebug = false
@@ -225,6 +226,9 @@ var startCmd = &cobra.Command{
 		ischedule.Start()
 		defer ischedule.Stop()
 
+		// Resume the job retries and the executions waiting for their dependencies before the restart
+		job.Resume()
+
 		// Start HTTP Server
 		srv, err := service.Start(config.Conf)
 		defer func() {
@@ -237,6 +241,11 @@ var startCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		// Apply the changes of the other instances of the cluster
+		service.Coordinate(srv)
+		cluster.Start()
+		defer cluster.Stop()
+
 		// Start watching
 		watchDone := make(chan uint8, 1)
 		if mode == "development" && !startDisableWatching {
@@ -420,12 +429,12 @@ func printStudio(silent bool, host string) {
 
 func printSchedules(silent bool) {
 
-	if len(schedule.Schedules) == 0 {
+	if len(ischedule.Schedules) == 0 {
 		return
 	}
 
 	if silent {
-		for name, sch := range schedule.Schedules {
+		for name, sch := range ischedule.Schedules {
 			process := fmt.Sprintf("Process: %s", sch.Process)
 			if sch.TaskName != "" {
 				process = fmt.Sprintf("Task: %s", sch.TaskName)
@@ -436,9 +445,9 @@ func printSchedules(silent bool) {
 	}
 
 	fmt.Println(color.WhiteString("\n---------------------------------"))
-	fmt.Println(color.WhiteString(L("Schedules List (%d)"), len(schedule.Schedules)))
+	fmt.Println(color.WhiteString(L("Schedules List (%d)"), len(ischedule.Schedules)))
 	fmt.Println(color.WhiteString("---------------------------------"))
-	for name, sch := range schedule.Schedules {
+	for name, sch := range ischedule.Schedules {
 		process := fmt.Sprintf("Process: %s", sch.Process)
 		if sch.TaskName != "" {
 			process = fmt.Sprintf("Task: %s", sch.TaskName)
@@ -487,8 +496,8 @@ func printApis(silent bool) {
 				log.Info("%s %s %s", p.Method, path.Join(apiRoot, api.HTTP.Group, p.Path), p.Process)
 			}
 		}
-		for name, upgrader := range websocket.Upgraders { // WebSocket
-			log.Info("[WebSocket] GET  /websocket/%s process:%s", name, upgrader.Process)
+		for name, server := range websocket.Servers { // WebSocket
+			log.Info("[WebSocket] GET  /websocket/%s process:%s", name, server.Event.Message)
 		}
 		return
 	}
@@ -523,13 +532,13 @@ func printApis(silent bool) {
 		}
 	}
 
-	if len(websocket.Upgraders) > 0 {
-		fmt.Print(color.CyanString(fmt.Sprintf("\n%s(%d)\n", "WebSocket", len(websocket.Upgraders))))
-		for name, upgrader := range websocket.Upgraders { // WebSocket
+	if len(websocket.Servers) > 0 {
+		fmt.Print(color.CyanString(fmt.Sprintf("\n%s(%d)\n", "WebSocket", len(websocket.Servers))))
+		for name, server := range websocket.Servers { // WebSocket
 			fmt.Println(
 				colorMehtod("GET"),
 				color.WhiteString(path.Join("/websocket", name)),
-				"\tprocess:", upgrader.Process)
+				"\tprocess:", server.Event.Message)
 		}
 	}
 }
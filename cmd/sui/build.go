@@ -14,6 +14,7 @@ import (
 	"github.com/yaoapp/gou/session"
 	"github.com/yaoapp/yao/config"
 	"github.com/yaoapp/yao/engine"
+	"github.com/yaoapp/yao/sui/api"
 	"github.com/yaoapp/yao/sui/core"
 	"github.com/yaoapp/yao/utils"
 )
@@ -25,7 +26,7 @@ var BuildCmd = &cobra.Command{
 	Long:  L("Build the template"),
 	Run: func(cmd *cobra.Command, args []string) {
 		if len(args) < 1 {
-			fmt.Fprintln(os.Stderr, color.RedString(L("yao sui build <sui> [template] [data]")))
+			fmt.Fprintln(os.Stderr, color.RedString(L("yao sui build <sui> [template] [data] [--static]")))
 			return
 		}
 
@@ -103,6 +104,29 @@ var BuildCmd = &cobra.Command{
 			fmt.Fprintln(os.Stderr, color.RedString(err.Error()))
 			return
 		}
+
+		// Pre-render the pages to static HTML files
+		if static {
+			dist := output
+			if dist == "" {
+				dist = filepath.Join(cfg.Root, "dist", id, template)
+			}
+
+			option := &api.StaticOption{Output: dist, BaseURL: baseURL, Full: full}
+			if locales != "" {
+				option.Locales = strings.Split(locales, ",")
+			}
+
+			res, err := api.BuildStatic(sui, tmpl, option)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, color.RedString(err.Error()))
+				return
+			}
+			warnings = append(warnings, res.Warnings...)
+			fmt.Println(color.WhiteString("     Static: %s", dist))
+			fmt.Println(color.WhiteString("             %d rendered, %d unchanged, %d removed, %d assets", res.Rendered, res.Skipped, res.Removed, res.Assets))
+		}
+
 		end := time.Now()
 		timecost := end.Sub(start).Truncate(time.Millisecond)
 		if debug {
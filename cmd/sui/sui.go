@@ -3,12 +3,25 @@ package sui
 var data string
 var locales string
 var debug bool
+var static bool
+var output string
+var baseURL string
+var full bool
+var machine bool
+var connector string
 
 func init() {
 	WatchCmd.PersistentFlags().StringVarP(&data, "data", "d", "::{}", L("Session Data"))
 	BuildCmd.PersistentFlags().StringVarP(&data, "data", "d", "::{}", L("Session Data"))
 	BuildCmd.PersistentFlags().BoolVarP(&debug, "debug", "D", false, L("Debug mode"))
+	BuildCmd.PersistentFlags().BoolVarP(&static, "static", "s", false, L("Pre-render the pages to static HTML files"))
+	BuildCmd.PersistentFlags().StringVarP(&output, "output", "o", "", L("The output directory of the static files"))
+	BuildCmd.PersistentFlags().StringVarP(&baseURL, "base-url", "b", "", L("The base url of the sitemap"))
+	BuildCmd.PersistentFlags().StringVarP(&locales, "locales", "l", "", L("Locales, separated by commas"))
+	BuildCmd.PersistentFlags().BoolVarP(&full, "full", "f", false, L("Render all pages, ignore the last build"))
 	TransCmd.PersistentFlags().StringVarP(&data, "data", "d", "::{}", L("Session Data"))
 	TransCmd.PersistentFlags().BoolVarP(&debug, "debug", "D", false, L("Debug mode"))
 	TransCmd.PersistentFlags().StringVarP(&locales, "locales", "l", "", L("Locales, separated by commas"))
+	TransCmd.PersistentFlags().BoolVarP(&machine, "machine", "m", false, L("Fill the missing translations with the translation connector"))
+	TransCmd.PersistentFlags().StringVarP(&connector, "connector", "c", "", L("The translation connector, overrides the template setting"))
 }
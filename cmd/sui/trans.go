@@ -26,7 +26,7 @@ var TransCmd = &cobra.Command{
 	Long:  L("Translate the template"),
 	Run: func(cmd *cobra.Command, args []string) {
 		if len(args) < 2 {
-			fmt.Fprintln(os.Stderr, color.RedString(L("yao sui trans <sui> <template> [data]")))
+			fmt.Fprintln(os.Stderr, color.RedString(L("yao sui trans <sui> <template> [data] [--machine]")))
 			return
 		}
 
@@ -106,7 +106,7 @@ var TransCmd = &cobra.Command{
 			mode = "development"
 		}
 
-		option := core.BuildOption{SSR: true, AssetRoot: assetRoot, ExecScripts: true, ScriptMinify: minify, StyleMinify: minify}
+		option := core.BuildOption{SSR: true, AssetRoot: assetRoot, ExecScripts: true, ScriptMinify: minify, StyleMinify: minify, Machine: machine, Connector: connector}
 
 		// locales filter
 		if locales != "" {
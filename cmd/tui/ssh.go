@@ -0,0 +1,91 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/engine"
+	"github.com/yaoapp/yao/tui/server"
+	"github.com/yaoapp/yao/tui/tui"
+)
+
+var sshOption server.Option
+
+// SSHCmd serves the TUIs over SSH
+var SSHCmd = &cobra.Command{
+	Use:   "ssh [tui-name]",
+	Short: L("Serve TUIs over SSH"),
+	Long: L("Serve the loaded TUIs over SSH, each session runs its own TUI") +
+		L("\n\n") +
+		L("Connect to the default TUI or a named one:\n") +
+		L("  ssh -t -p 2222 alice@console.internal\n") +
+		L("  ssh -t -p 2222 alice@console.internal myapp '::{\"key\":\"value\"}'\n"),
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		Boot()
+
+		if len(args) > 0 {
+			sshOption.TUI = args[0]
+		}
+
+		// Load application engine
+		_, err := engine.Load(config.Conf, engine.LoadOption{Action: "tui"}, nil)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Failed to load engine: %v\n", color.RedString("Error:"), err)
+			os.Exit(1)
+		}
+
+		// Load TUI configurations
+		err = tui.Setup(config.Conf)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Failed to load TUI configurations: %v\n", color.RedString("Error:"), err)
+			os.Exit(1)
+		}
+
+		for _, id := range append([]string{sshOption.TUI}, sshOption.TUIs...) {
+			if id != "" && tui.Get(id) == nil {
+				fmt.Fprintf(os.Stderr, "%s TUI not found: %s\n", color.RedString("Error:"), id)
+				os.Exit(1)
+			}
+		}
+
+		srv, err := server.New(sshOption, nil)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Failed to create the SSH server: %v\n", color.RedString("Error:"), err)
+			os.Exit(1)
+		}
+
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+		go func() {
+			<-sigChan
+			if Verbose {
+				log.Info("Stopping the SSH server...")
+			}
+			srv.Close()
+		}()
+
+		fmt.Printf("%s TUI SSH server listening on %s\n", color.GreenString("✓"), sshOption.Addr)
+		err = srv.Start()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", color.RedString("Error:"), err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	SSHCmd.Flags().StringVarP(&sshOption.Addr, "addr", "a", server.DefaultAddr, L("Listen address"))
+	SSHCmd.Flags().StringSliceVar(&sshOption.TUIs, "tuis", nil, L("The TUIs allowed to be served, default all"))
+	SSHCmd.Flags().StringVar(&sshOption.HostKey, "host-key", "", L("Host key file, generated if not exists"))
+	SSHCmd.Flags().StringVar(&sshOption.AuthorizedKeys, "authorized-keys", "", L("Authorized keys file, each key with a user=\"<user>\" option"))
+	SSHCmd.Flags().BoolVar(&sshOption.Password, "password", false, L("Allow password authentication"))
+	SSHCmd.Flags().IntVar(&sshOption.MaxSessions, "max-sessions", 0, L("Maximum number of concurrent sessions"))
+	SSHCmd.Flags().StringVar(&sshOption.Banner, "banner", "", L("Shown before authentication"))
+}
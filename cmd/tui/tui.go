@@ -271,4 +271,5 @@ func init() {
 	Cmd.AddCommand(CheckCmd)
 	Cmd.AddCommand(DumpCmd)
 	Cmd.AddCommand(HelpCmd)
+	Cmd.AddCommand(SSHCmd)
 }
@@ -23,6 +23,14 @@ var langs = map[string]string{
 	"Dump TUI configuration as JSON":                                    "将 TUI 配置导出为 JSON",
 	"Dump the raw TUI configuration JSON for debugging purposes":        "将原始 TUI 配置 JSON 导出用于调试",
 	"Show help for TUI command":                                         "显示 TUI 命令的帮助信息",
+	"Serve TUIs over SSH":                                               "通过 SSH 提供 TUI 服务",
+	"Serve the loaded TUIs over SSH, each session runs its own TUI":     "通过 SSH 提供已加载的 TUI，每个会话运行独立的 TUI",
+	"Listen address":                                                    "监听地址",
+	"The TUIs allowed to be served, default all":                        "允许提供服务的 TUI，默认全部",
+	"Host key file, generated if not exists":                            "主机密钥文件，不存在时自动生成",
+	"Allow password authentication":                                     "允许密码认证",
+	"Maximum number of concurrent sessions":                             "最大并发会话数",
+	"Shown before authentication":                                       "认证前显示的信息",
 }
 
 // L 多语言切换
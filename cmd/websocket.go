@@ -1,58 +1,129 @@
 package cmd
 
-// var websocketCmd = &cobra.Command{
-// 	Use:   "websocket",
-// 	Short: L("Open a websocket connection"),
-// 	Long:  L("Open a websocket connection"),
-// 	Run: func(cmd *cobra.Command, args []string) {
-// 		defer share.SessionStop()
-// 		defer plugin.KillAll()
-// 		defer func() {
-// 			err := exception.Catch(recover())
-// 			if err != nil {
-// 				fmt.Println(color.RedString(L("Fatal: %s"), err.Error()))
-// 			}
-// 		}()
-
-// 		Boot()
-// 		cfg := config.Conf
-// 		cfg.Session.IsCLI = true
-// 		engine.Load(cfg)
-// 		if len(args) < 1 {
-// 			fmt.Println(color.RedString(L("Not enough arguments")))
-// 			fmt.Println(color.WhiteString(share.BUILDNAME + " help"))
-// 			return
-// 		}
-
-// 		name := args[0]
-// 		websocket, has := websocket.WebSockets[name]
-// 		if !has {
-// 			fmt.Println(color.RedString(L("%s not exists!"), name))
-// 			return
-// 		}
-
-// 		url := websocket.URL
-// 		protocols := websocket.Protocols
-// 		argsLen := len(args)
-// 		if argsLen > 1 {
-// 			url = args[1]
-// 		}
-
-// 		if argsLen > 2 {
-// 			protocols = args[2:]
-// 		}
-
-// 		fmt.Println(color.WhiteString("\n---------------------------------"))
-// 		fmt.Println(color.WhiteString(websocket.Name))
-// 		fmt.Println(color.WhiteString("---------------------------------"))
-// 		fmt.Println(color.GreenString("      URL: %s", url))
-// 		fmt.Println(color.GreenString("Protocols: %s", strings.Join(protocols, ",")))
-// 		fmt.Println(color.WhiteString("--------------------------------------"))
-// 		pargs := append([]string{url}, protocols...)
-// 		err := websocket.Open(pargs...)
-// 		if err != nil {
-// 			fmt.Println(color.RedString(L("%s"), err.Error()))
-// 			return
-// 		}
-// 	},
-// }
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/gorilla/websocket"
+	"github.com/spf13/cobra"
+	"github.com/yaoapp/gou/plugin"
+	"github.com/yaoapp/kun/exception"
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/engine"
+	"github.com/yaoapp/yao/share"
+	yaows "github.com/yaoapp/yao/websocket"
+)
+
+var websocketToken string
+
+var websocketCmd = &cobra.Command{
+	Use:   "websocket",
+	Short: L("Open a websocket connection"),
+	Long:  L("Open a websocket connection to the server defined in websockets, the lines of stdin are sent as messages"),
+	Run: func(cmd *cobra.Command, args []string) {
+		defer share.SessionStop()
+		defer plugin.KillAll()
+		defer func() {
+			err := exception.Catch(recover())
+			if err != nil {
+				fmt.Println(color.RedString(L("Fatal: %s"), err.Error()))
+			}
+		}()
+
+		Boot()
+		cfg := config.Conf
+		cfg.Session.IsCLI = true
+		engine.Load(cfg, engine.LoadOption{Action: "websocket"})
+		if len(args) < 1 {
+			fmt.Println(color.RedString(L("Not enough arguments")))
+			fmt.Println(color.WhiteString(share.BUILDNAME + " help"))
+			return
+		}
+
+		name := args[0]
+		server, err := yaows.Select(name)
+		if err != nil {
+			fmt.Println(color.RedString(L("%s not exists!"), name))
+			return
+		}
+
+		url := fmt.Sprintf("ws://127.0.0.1:%d/websocket/%s", cfg.Port, name)
+		if len(args) > 1 {
+			url = args[1]
+		}
+
+		header := http.Header{}
+		if websocketToken != "" {
+			header.Set("Authorization", "Bearer "+websocketToken)
+		}
+
+		dialer := websocket.Dialer{Subprotocols: server.Protocols}
+		conn, _, err := dialer.Dial(url, header)
+		if err != nil {
+			fmt.Println(color.RedString(L("%s"), err.Error()))
+			return
+		}
+		defer conn.Close()
+
+		fmt.Println(color.WhiteString("\n---------------------------------"))
+		fmt.Println(color.WhiteString(server.Name))
+		fmt.Println(color.WhiteString("---------------------------------"))
+		fmt.Println(color.GreenString("      URL: %s", url))
+		fmt.Println(color.GreenString("Protocols: %s", strings.Join(server.Protocols, ",")))
+		fmt.Println(color.WhiteString("--------------------------------------"))
+
+		// Print the messages from the server
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for {
+				_, message, err := conn.ReadMessage()
+				if err != nil {
+					if !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+						fmt.Println(color.RedString(L("%s"), err.Error()))
+					}
+					return
+				}
+				fmt.Println(color.CyanString("< %s", string(message)))
+			}
+		}()
+
+		// Send the lines of stdin
+		lines := make(chan string)
+		go func() {
+			scanner := bufio.NewScanner(os.Stdin)
+			for scanner.Scan() {
+				lines <- scanner.Text()
+			}
+			close(lines)
+		}()
+
+		for {
+			select {
+			case <-done:
+				return
+			case line, ok := <-lines:
+				if !ok {
+					conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+					<-done
+					return
+				}
+				if line == "" {
+					continue
+				}
+				if err := conn.WriteMessage(websocket.TextMessage, []byte(line)); err != nil {
+					fmt.Println(color.RedString(L("%s"), err.Error()))
+					return
+				}
+			}
+		}
+	},
+}
+
+func init() {
+	websocketCmd.PersistentFlags().StringVarP(&websocketToken, "token", "t", "", L("The access token of the connection"))
+}
@@ -28,6 +28,8 @@ type Config struct {
 	Studio        Studio   `json:"studio,omitempty"`                                          // Studio config
 	Runtime       Runtime  `json:"runtime,omitempty"`                                         // Runtime config
 	Trace         Trace    `json:"trace,omitempty"`                                           // Trace config
+	Audit         Audit    `json:"audit,omitempty"`                                           // Audit log config
+	Cluster       Cluster  `json:"cluster,omitempty"`                                         // Cluster event bus config
 }
 
 // Studio the studio config
@@ -71,6 +73,22 @@ type Runtime struct {
 	Import            bool   `json:"import,omitempty"  env:"YAO_RUNTIME_IMPORT" envDefault:"true"`                        // If false the import statement will be disabled, the default value is true.
 }
 
+// Audit the audit log config
+type Audit struct {
+	Disable   bool   `json:"disable,omitempty" env:"YAO_AUDIT_DISABLE" envDefault:"false"`   // Disable the audit log
+	Retention int    `json:"retention,omitempty" env:"YAO_AUDIT_RETENTION" envDefault:"365"` // The retention in days, 0 keeps the records forever
+	Secret    string `json:"secret,omitempty" env:"YAO_AUDIT_SECRET"`                        // The key of the record hashes (HMAC-SHA256), default is the JWT secret
+}
+
+// Cluster the cluster event bus config, broadcasts the DSL changes and the cache invalidations to the other instances
+type Cluster struct {
+	Driver   string `json:"driver,omitempty" env:"YAO_CLUSTER_DRIVER"`                            // The event bus driver. redis | db, empty runs a single instance
+	Redis    string `json:"redis,omitempty" env:"YAO_CLUSTER_REDIS"`                              // The redis URL when driver is "redis", e.g. redis://:password@127.0.0.1:6379/1
+	Channel  string `json:"channel,omitempty" env:"YAO_CLUSTER_CHANNEL" envDefault:"yao:cluster"` // The redis channel of the events
+	Interval int    `json:"interval,omitempty" env:"YAO_CLUSTER_INTERVAL" envDefault:"2"`         // The polling interval in seconds when driver is "db"
+	Keep     int    `json:"keep,omitempty" env:"YAO_CLUSTER_KEEP" envDefault:"3600"`              // The seconds the events are kept when driver is "db"
+}
+
 // Trace config
 type Trace struct {
 	Driver string `json:"driver,omitempty" env:"YAO_TRACE_DRIVER"` // The trace driver. local (development) | store (production)
@@ -76,19 +76,24 @@
 // .tmp/data/yao/models/agent/message.mod.yao
 // .tmp/data/yao/models/agent/resume.mod.yao
 // .tmp/data/yao/models/agent/search.mod.yao
+// .tmp/data/yao/models/agent/usage.mod.yao
 // .tmp/data/yao/models/attachment.mod.yao
 // .tmp/data/yao/models/audit.mod.yao
+// .tmp/data/yao/models/cluster.mod.yao
 // .tmp/data/yao/models/config.mod.yao
 // .tmp/data/yao/models/dsl.mod.yao
 // .tmp/data/yao/models/invitation.mod.yao
 // .tmp/data/yao/models/job/category.mod.yao
 // .tmp/data/yao/models/job/execution.mod.yao
 // .tmp/data/yao/models/job/job.mod.yao
+// .tmp/data/yao/models/job/lock.mod.yao
 // .tmp/data/yao/models/job/log.mod.yao
 // .tmp/data/yao/models/kb/collection.mod.yao
 // .tmp/data/yao/models/kb/document.mod.yao
 // .tmp/data/yao/models/member.mod.yao
+// .tmp/data/yao/models/migration.mod.yao
 // .tmp/data/yao/models/role.mod.yao
+// .tmp/data/yao/models/seed.mod.yao
 // .tmp/data/yao/models/team.mod.yao
 // .tmp/data/yao/models/user/oauth_account.mod.yao
 // .tmp/data/yao/models/user/type.mod.yao
@@ -114,9 +119,9 @@ import (
 	"bytes"
 	"compress/gzip"
 	"fmt"
-	"net/http"
 	"io"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -185,7 +190,6 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-
 type assetFile struct {
 	*bytes.Reader
 	name            string
@@ -1792,6 +1796,26 @@ func yaoModelsAgentSearchModYao() (*asset, error) {
 	return a, nil
 }
 
+var _yaoModelsAgentUsageModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbd\x57\x4b\x6b\x1b\x31\x10\xbe\xe7\x57\x88\x3d\xbb\x90\x94\x52\xd2\xde\x42\x7a\x29\x24\xa5\x14\xe7\x54\x8a\x91\xb5\x63\x5b\x44\x8f\x8d\x34\x6a\xb3\x84\xfc\xf7\x8e\xb4\x4f\xbf\x36\x5e\x63\x67\x0f\x0b\x3b\xef\x6f\xa4\x91\xbe\x7d\xb9\x60\x2c\x33\x5c\x43\xf6\x95\x65\x0f\x9e\x2f\x21\x9b\x44\x91\xe2\x73\x50\x1b\xb2\x1c\xbc\x70\xb2\x40\x69\x4d\xd4\xdc\xdd\xdd\x33\xb4\x8f\x60\x58\x88\x36\x8c\x9b\x9c\x09\xeb\x91\xd9\x05\x03\x2e\x56\xf4\xa1\x0b\x05\xd1\x7c\x42\x26\x90\xb3\x85\x75\xec\x29\x58\xe4\x3e\x19\xcf\xa5\x52\xd2\x2c\xab\xe0\xc8\x97\x9e\xa2\xfe\xce\x28\x94\xc1\x6c\xc2\x32\x5f\x7a\x04\x9d\xfd\x49\xea\x79\x90\x0a\x65\xcc\x8b\x2e\x40\x12\x39\xe0\xb9\x35\xaa\xec\xcb\xbc\x75\x48\xdf\x5f\xe8\xa9\xa3\xce\x55\x84\xf6\xd2\x81\x4c\xf1\x67\xa1\x82\xc5\x32\x2a\x52\xc7\x84\xa4\xb9\x89\x1a\x16\x61\x55\x80\x2a\x67\xf6\x9a\x22\x09\xab\x82\x36\xa9\x44\xfa\x64\xec\x25\xbd\x7b\xcd\x93\x79\x02\x92\x64\x58\x16\x49\xf6\xfd\x5b\x27\x6b\x3b\xda\x17\xf6\xb3\x07\xb4\x1f\xa4\x11\x0e\xa2\x84\x15\x4e\x6a\xee\x4a\xf6\x08\x65\x96\xac\x5f\x27\xbb\xf3\x3a\x78\x0a\xe0\x71\xb6\x2b\xbf\x47\xd7\x34\x78\xbd\x86\x5f\x95\x13\xdb\x57\x8b\xf7\x56\x48\x8e\xb4\x66\x6e\x87\xa5\x02\xb3\xc4\x15\x19\x7e\xfe\xd4\xca\x4c\x50\xaa\x6e\x76\xb3\x1a\x49\x2e\x4d\x0e\xcf\xb5\x70\x10\x87\x58\xf1\x91\x20\x6e\xc9\xe3\x00\x04\x62\xd3\xec\x2c\xe5\xd3\x06\x77\xe3\xca\x7f\x20\x8f\x7d\xe5\x27\xdd\xbf\x95\x65\x9a\xe7\xb4\x11\x57\xd0\xac\xc3\x0e\x14\x1f\x2f\x2f\x4f\x07\x03\x81\xeb\x71\x30\xa6\xe4\xb1\x0f\x46\xd2\xd1\x71\x10\x01\xc4\x06\x9d\xbf\x7a\xc3\xcd\xc8\x5d\x34\x4d\x3e\xfb\x11\x24\xed\x3b\x62\xe0\xde\x4b\x8f\xa3\x61\xdc\x34\x6e\x03\x13\x51\x1b\x60\x1c\x88\x76\x63\x75\xa7\xf4\xe1\xc8\x16\x5c\xf9\x63\x46\xdc\x1a\x03\x02\xad\x1b\x33\xe4\xdb\x3e\x3d\x50\xad\x96\x50\x77\x77\xcc\x3b\xc3\xd2\x36\xa7\x5a\x0f\x87\x74\xbf\x6e\xdf\x83\x93\x34\x2c\xc6\xa5\x79\xc7\xe0\x0c\x01\x9a\x97\x09\x4f\xe1\xec\x5f\x99\x8f\xdd\x7e\x83\x75\x53\x48\x5d\xe0\x2c\xdd\xe1\x7e\xbb\x7e\x69\x10\x96\x6b\x09\x1b\x00\x3f\x93\x23\x9b\x6e\x38\xf6\x80\xfc\x08\x7a\x4e\x27\x18\x8d\x4d\x95\x84\x6d\x26\xc9\x61\xc1\x83\x8a\xb6\xfb\x57\xe2\x8d\xbd\xd4\xac\xef\x11\x00\x6e\x5b\xe7\xc3\x40\x74\xc9\x4e\x0f\x04\x89\x0f\xa9\x23\x30\x4c\xa3\xdf\x50\xf9\x95\x81\x69\x41\x9c\x61\x09\xfa\xf7\x51\x53\x71\x0e\x82\x98\x8b\xda\xd9\xf5\xbe\xfd\xda\x14\x13\xc1\x88\x3d\x0e\xf1\xbe\x5e\xd0\x8e\xa9\x47\xb8\x19\x6e\x22\x43\xa2\xe1\x63\x6d\x80\xc2\x51\x26\x5f\x91\xd1\xab\xeb\x56\xec\x05\x4f\xf5\x5f\x9f\x10\x68\x70\x0e\x8c\x28\xc7\x1c\x5b\x5b\x2e\x7d\xbc\xb5\xb2\xb9\x56\xd6\x1b\xd9\xce\xf5\xd5\x1b\x63\x4d\xef\x8a\x1c\xa7\x93\x0a\x06\xb9\xe9\x73\xc5\x78\x67\x89\xa6\x10\xc7\x8c\xcc\xa8\x5f\x5b\x4b\x6e\x3b\x22\x43\xe2\xca\x6e\xc6\xb1\x62\xe1\x1b\x3b\x33\x1e\x8f\x83\x7d\xeb\xd2\x26\x5a\x31\x98\xb6\x25\x1e\xa7\x4c\xdb\xdd\xa5\x83\xb9\xd7\xaf\xdc\x11\x05\xb4\x2b\x60\x9b\xbf\x22\xfa\xd1\x40\xa9\x89\xa8\x71\x5d\xf8\x7a\xb5\xc8\xee\xf5\xe2\x3f\x5b\x3e\xac\x86\x69\x0d\x00\x00")
+
+func yaoModelsAgentUsageModYaoBytes() ([]byte, error) {
+	return bindataRead(
+		_yaoModelsAgentUsageModYao,
+		"yao/models/agent/usage.mod.yao",
+	)
+}
+
+func yaoModelsAgentUsageModYao() (*asset, error) {
+	bytes, err := yaoModelsAgentUsageModYaoBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "yao/models/agent/usage.mod.yao", size: 3433, mode: os.FileMode(438), modTime: time.Unix(1768928215, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _yaoModelsAttachmentModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x58\xdf\x6f\xe4\x34\x10\x7e\xef\x5f\x31\xf2\x13\x48\x45\x57\x90\x8a\xd4\xbe\x95\x3b\x40\x27\x21\xa8\xe0\x0e\x1e\x4e\xa7\x95\x93\x4c\x12\x23\xc7\x0e\x9e\x49\xdb\xbd\xaa\xff\x3b\xb2\xf3\x63\x93\xac\xb3\xbb\xd9\x83\x7b\xaa\x3a\x9e\x19\x7f\xdf\x8c\xf3\x79\xbc\xcf\x17\x00\xc2\xc8\x0a\xc5\x2d\x08\xc9\x2c\xd3\xb2\x42\xc3\xe2\xd2\xdb\xb5\x4c\x50\xfb\x85\xbb\xd9\x42\x86\x94\x3a\x55\xb3\xb2\x66\xba\x0c\x2c\x13\x8d\x90\x5b\x07\xc4\xd6\x29\x53\x40\xae\x34\xc2\x2e\x33\xc1\xa3\xe2\x12\x2a\x64\x99\x49\x96\x20\x4d\x06\x32\x4d\x91\x08\x52\x6b\xd8\x59\xdd\x6e\xc1\xb2\x20\x71\x0b\x1f\x04\x6d\x89\xb1\x12\x1f\x83\x35\x69\x94\x66\xe5\x37\x65\xd7\x60\x30\x39\x94\x99\x35\x7a\x3b\xb6\x91\x75\x2c\x6e\xe1\xe6\xe6\xe6\xa6\x4b\x96\x68\xcf\xd0\xb3\x5d\xe6\x0b\x20\x52\x5b\x85\x7f\x23\xa4\xc4\x05\xc0\x4b\xc8\x96\x5a\xdd\x54\x26\xa0\x0b\x51\x6d\xd6\x51\x5e\x95\x75\xf9\xfc\xd6\xdb\x3a\xd8\xde\xbe\xd9\xd9\x22\x75\x85\xf1\xfa\x08\xc5\x7b\xa3\xfe\x69\xc6\xf5\x03\x95\xa1\x61\x95\x2b\x74\x22\xf8\xbf\x5c\xc6\x41\xf8\xba\x6f\x62\x48\x88\x7d\x5f\x22\x68\x7e\xf2\x9d\x5a\xc0\x11\xd6\x46\x5b\xef\xa2\xd1\x14\x5c\x8a\x5b\xf8\xee\xfa\x7a\x30\x9a\x46\xeb\xae\xe4\xb9\xd4\x84\xc3\x42\x13\xe8\x8c\x5a\x15\xac\xca\x64\xf8\xd4\x19\x0f\x72\x6a\x6a\x6d\x65\x36\xde\xfe\x28\xa9\xf7\x7b\x21\x73\x56\x7d\x52\x08\xb9\x22\xc4\xae\xae\x8e\x13\x3b\x99\x82\x3f\xe4\x68\x78\x33\xdd\xec\x28\x8d\xd7\x6d\x18\xbc\x9b\x84\xcd\xa9\x74\xc9\xbf\x28\x93\x7d\x12\xda\x9a\xe2\x1d\x3e\xf1\x32\x8d\x38\x83\x46\x6b\xa8\xa5\x23\xcc\x80\xf1\x89\x07\x36\xb9\xb3\x15\xa8\x4a\x16\x78\x09\x75\x96\x5f\xc2\xa3\x75\x59\x10\x0e\xcb\x25\xba\x56\x61\xfc\xe6\x24\x62\xf4\x4e\x6e\x49\xed\xf0\x41\xe1\xe3\x3e\x21\x3e\x48\x06\xee\xe7\x71\x23\x52\xdd\x1a\xd8\x3c\x4a\xed\xab\x5c\x39\x62\xdf\x98\x2b\x48\x4b\xe9\x64\xca\xe8\xe8\xeb\xf3\x78\x84\xbf\xa7\x1f\xa9\x5f\x27\xee\xf3\xa3\x34\x4d\x36\x1c\xa1\xeb\xff\xf4\x08\x35\x4e\xaf\xf9\x94\x7f\xff\x65\x19\xef\x64\x71\x80\xfb\xed\x55\x1c\x6f\x54\x7e\x02\x89\x83\x78\xc7\xf7\xde\xe9\xb8\xdf\xc4\xa2\xe6\xf8\xa3\xa9\xff\x2f\x1e\x2b\xc5\xe7\xb0\xe8\xac\x13\x9b\x33\x85\x9f\xd0\x6d\x6a\xc9\xe5\x9a\xe3\x42\xe8\xe0\x7e\x12\x33\xbe\x58\x09\xdd\x37\x54\x63\xea\xef\xb3\x0c\x52\x5b\xd5\x1a\x19\x5b\x31\x99\xee\x74\x5e\x17\x8e\x72\x5a\x49\xe7\x0f\xb6\x4e\x16\xb8\xcc\xe8\x2e\xe5\x46\xea\x30\x77\x79\x3f\x9f\x3e\x0c\x62\x5c\xb6\xac\x56\x10\x3a\xf3\x73\x2e\x9c\x6d\x6a\xda\xe7\xf4\x37\x4d\x0e\x75\xcf\xe8\xe7\x99\xfb\xfc\x60\xcd\xd3\xad\xd1\xc2\xe2\x93\xaa\xf7\x81\x24\xd6\x6a\x94\x51\x2c\x13\xff\x11\x92\xbf\x4a\xdc\x5d\x32\x8a\xc0\x27\xae\x71\x34\x56\x65\x98\xcb\x46\xf3\xf9\x55\x4b\xb6\x8c\x91\xa2\x25\xaa\x78\x6b\x18\x8b\xc9\xb0\xd5\xc3\xfd\x61\x1a\x33\xaf\x1c\xa9\x4f\x08\xca\xc0\x2c\xf5\xe7\x77\x98\x58\x72\x13\x01\x8b\xa6\xa9\xa2\x67\x76\xea\x3e\xc7\x59\x3b\xeb\x27\x7f\xff\x4c\x98\x67\xb6\xfd\xeb\xe2\x43\x67\x81\x7e\xfc\x1b\x7f\x1e\x83\x71\xd4\x91\x9e\xcf\xcc\x2f\xd8\xa6\x6e\x6d\xe8\x26\x97\x4a\x47\xe2\x7b\x7b\x67\xfe\x18\xe9\x78\x04\xd1\x8a\xef\xdf\xd9\xc2\x21\x45\xaa\xb9\xa8\x01\xf7\x7b\x21\x93\x51\x63\x28\x66\x9f\x1a\x94\xc9\xad\xab\xe4\xc2\xad\x72\x40\xa2\x0f\x22\x47\xe7\xec\x9a\x19\xfc\xc7\xa9\xff\x08\x73\x58\x39\x82\xf2\xfb\xe3\x28\x97\x0a\x8c\x84\x91\xf9\x74\x59\x05\xee\x43\x04\xdc\xcd\xdf\x85\x71\x49\xe0\x52\x91\x97\x04\x09\xed\x4e\xb0\xf7\x9e\x3c\x20\x0f\xf3\x6f\xf1\x08\x95\x26\xd1\x2a\x5d\x45\x25\x44\xac\xa2\x32\x7e\x64\x12\x50\x29\x1d\xfa\xb7\xb9\xb3\x44\x20\xb5\x06\x46\x59\xf9\x23\x15\x6e\x94\x5a\x4b\xf6\x5d\xfb\x4c\xa6\xaf\x5e\xc1\xeb\x86\xd8\x56\x50\xa3\xab\x14\x91\xb2\x86\x16\xb4\xc7\x03\x3a\x5d\x7a\xa6\xde\xf1\x87\xbd\x4f\x19\xc4\x27\xb5\xe3\x19\x26\xa6\x3d\xb5\x53\x0f\x92\x51\x5c\x7a\xc8\xbf\x19\xbd\x85\x07\x45\x2a\xf1\xf3\x8f\x0d\x05\xb1\x8f\x06\xdd\xce\xdf\x17\x4b\x78\xdf\x3f\x77\x6e\x7d\x11\xa1\xc2\x2a\x41\x47\x87\x94\x65\xd8\xef\x0c\xf1\xbe\xe8\x72\x0a\x87\x3a\x7c\x55\x24\x6e\xe1\xb9\xfd\xf1\xa2\x95\xc1\xf0\xe3\x45\xeb\x33\x90\x7d\x06\xc1\xaa\x42\x62\x59\xd5\xd4\x4f\x35\x20\xc8\xe6\xbc\xc9\xd0\x0f\x47\x34\xec\x0d\x62\xd7\xae\xce\x15\x5e\x2e\x5e\x2e\xfe\x0d\x00\x00\xff\xff\xd7\xa2\x63\x02\x4f\x12\x00\x00")
 
 func yaoModelsAttachmentModYaoBytes() ([]byte, error) {
@@ -1812,7 +1836,7 @@ func yaoModelsAttachmentModYao() (*asset, error) {
 	return a, nil
 }
 
-var _yaoModelsAuditModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x58\xc1\x6e\xe3\x36\x13\xbe\xe7\x29\x06\x3a\xe5\x07\x84\x1f\xdb\xa2\x39\x24\xb7\x34\x29\xb0\x01\xb6\x6d\x90\x38\xe8\x61\xb1\x10\x68\x72\x24\xb3\xa0\x48\x2d\x49\x65\xe3\x2e\xf2\xee\xc5\x50\x92\x4d\xd9\x94\x13\x79\x8d\x9e\x04\x91\xfc\x66\xe6\xe3\x0c\x67\xc8\xf9\x7e\x06\x90\x69\x56\x63\x76\x05\x19\x6b\x85\xf4\x59\x4e\x43\x8a\x2d\x51\xd1\xd8\x35\x8d\xc1\x27\x53\x75\xe3\x02\x1d\xb7\xb2\xf1\xd2\xe8\xed\xac\x32\x15\x78\xb6\x54\x08\xa5\xb1\xe0\xbc\xb1\x52\x57\xe0\xd6\xce\x63\x0d\xa6\x41\xcb\x68\x3d\x04\xf1\x60\x91\x1b\x2b\x5c\x27\xce\xb3\xca\x65\x57\xf0\x39\xeb\x16\x67\x5f\xc2\xe8\xb2\x95\xca\x4b\x52\xe0\x6d\x8b\x61\xc8\x22\x13\x46\xab\x75\x3c\xe6\x8c\xf5\xd9\x15\x5c\x5e\x5e\x5e\xf6\xc2\x96\x8a\x78\x10\xa7\x5d\x56\x85\xea\x19\x00\x64\xdc\xd4\x35\x6a\x9f\xb0\x3f\x3b\x03\x78\x0d\xb2\xb8\x51\x6d\xad\x83\x6d\x01\xd4\xc9\x8c\xa4\x4a\xd1\x8b\x23\xc5\xeb\x26\x8c\xdd\xdd\x6e\xc7\x76\x36\x30\x9e\x8a\xf4\x3f\x69\xf9\xb5\xc5\xd1\xce\x80\x14\xa8\xbd\x2c\x25\xda\x2c\x20\x5e\xf3\xb4\x05\xf8\x8c\xda\x17\x29\x3b\x9c\x27\x07\x24\x6c\xf9\x8d\x20\x6f\xd8\x12\xc4\x46\x46\x04\x9f\x72\x63\x2d\xaa\xe0\xc6\x48\x2c\xea\xca\xaf\xb2\x2b\xf8\xe9\xc3\x87\xcd\xa0\x6e\x95\xea\xfd\x30\x38\x2a\x8c\x4b\x2d\xf0\xa5\x1f\x3c\x48\x6b\x13\x2f\x33\x78\xfd\xb9\x8f\x89\x88\x6d\x66\x81\x74\xc0\xb9\x32\x95\xd4\x39\x08\x54\xe8\x31\x87\xda\x08\x59\xae\x73\x40\xcf\xff\xff\xbf\xf7\xb3\x2b\x99\x72\x47\xd0\xe3\xcc\x63\x65\xec\x7a\x06\xbb\x9b\x3d\xc8\x5e\x04\x0f\x52\xe1\x9c\xb5\x7e\x45\xae\xe3\x81\x71\x0e\xf4\x6f\xac\xfc\xa7\xff\x15\xcc\xb3\xbc\x3f\x9a\x29\xb2\x17\x27\xf4\xa4\xc3\x67\xb4\xd2\x27\xa8\xa2\x6e\xeb\x04\xd1\xc7\x3d\x40\x44\xb4\x8b\xdd\x41\x26\x28\x7c\x46\xb5\x5d\x68\x86\x94\xf4\x39\x53\xe6\x5b\x96\x43\x56\xa3\x90\xa4\x05\xb2\x95\xac\x56\xf4\xe5\x56\xd2\xbe\xa8\x2e\xcb\x04\x98\xc0\x92\xb5\x2a\xc8\x1f\xd6\xcf\xa6\xd9\x3a\xb4\xf3\x8e\xe1\x93\x43\x3b\x79\x0a\x69\x8e\xb2\x28\xda\x67\xc9\x11\x18\xe7\xa6\x1d\x1d\xc8\x84\xd7\x7e\xbe\xb8\x38\x61\x88\x06\x42\xe1\x67\x26\xa5\x3f\x46\x98\x5d\x52\x42\xba\x46\xb1\x35\x8c\x25\x6f\x39\x4c\x27\x91\x37\xa2\xcc\x39\x69\xf4\x3c\x0f\x3c\x76\xa0\x29\x27\x0c\xd3\xc7\x6c\xfa\x71\x67\x85\x2b\x19\xb2\x79\x33\x27\x2f\x04\x0c\xdc\xdd\x27\x39\x6c\x66\x81\x09\x61\xd1\xb9\x04\x85\x5f\x4e\xc8\x20\x44\x0d\xab\x48\xfb\xcc\xb0\xb9\x1e\x83\xf6\x39\x90\x68\x08\xa2\x41\xea\xd2\xd8\x7a\xaa\x10\x5d\x1c\x19\x43\x9e\xd9\x0a\x7d\x61\xd1\x99\xd6\xf2\x39\x71\xbf\x08\x48\x78\xd8\x43\x46\x2c\xfa\x35\x83\x74\x58\x22\x5d\x8f\xba\x3a\x87\x02\xe6\x32\x99\xef\x9a\x41\x73\x11\xe8\xbc\x9f\xdc\xc0\x0a\x16\x23\x5c\x4c\x6d\xdd\x20\x98\x72\xcb\xed\xbc\x94\x0a\xf3\xee\x36\x95\x03\x37\xba\x94\xd5\xec\xd2\x7a\x64\xb9\x99\xeb\xbb\xc7\x69\x97\x6d\xaf\x0c\x03\xaf\xa7\xbb\x1c\xae\xef\xef\x72\xb8\xf9\x74\x77\xa8\x80\x9e\x94\x12\x6b\x1a\xd5\x57\xf2\x19\xbc\xae\x53\xa8\xf8\xca\xb0\x9d\x8f\x2b\xcd\x44\x5e\x3e\x29\xa1\x95\x71\x7e\x66\x65\xf9\xb8\x07\x89\xa8\x0c\x93\x14\x83\x7e\x85\xd1\x63\x63\x37\x1a\xde\x93\xb6\x0f\x87\x57\xcb\xf9\x28\x8b\x0e\x96\x2f\x8d\x51\xc8\x74\x2a\xc0\x76\x21\x91\xe5\x7f\xad\xd0\xaf\xd0\xee\x58\xfd\x8d\x39\xe8\x15\x95\x6d\x74\xbd\xf9\xf1\xa2\x8e\x2f\xd2\x17\xdc\x88\xc4\xd6\x4b\xed\xb1\x1a\x95\xb8\xcd\x7b\xe1\x45\x7a\xb8\x19\x81\xe2\x2a\xd9\x3d\xf0\x38\x53\x0a\x2c\xfa\xd6\x6a\x18\x2b\x98\xb3\xbd\x16\x5d\x63\xb4\xc3\xc2\xcb\x54\x78\x4c\xdb\xf8\xd0\x03\x61\x21\x27\x82\x64\x7b\x98\x07\x25\x40\x4a\x40\x6a\xa8\xa5\x52\xd2\x21\x37\x5a\xb8\x63\xed\xfe\xda\xa2\x9b\xf9\x0e\x7b\xe8\x40\x53\xd7\x8f\x8f\x8b\xc5\x3d\xf4\x82\x0f\xdf\x41\x4e\x7a\x38\xbd\x65\x1c\xe7\x11\x59\x10\x64\x8a\xc6\xad\x24\xdc\xb2\xa5\x22\x47\xb2\xa9\xea\xfd\x77\x6c\xe8\xa5\x53\x2c\xb1\x34\x36\x11\x4e\x7f\x3b\x93\x3a\xb0\xb7\xcc\x33\xf8\x75\x07\x13\x33\xa2\x79\xe7\x99\xa7\x22\x4e\xab\x20\xf1\x5a\x9d\x13\x3d\xc1\x48\x56\xfa\x78\x3b\xde\x63\xe3\xf5\x18\x92\x36\x31\xc8\xfd\x61\x0b\xd1\x33\xa9\x12\x69\x6f\xca\xbc\xdd\xf5\x71\xe1\x11\x42\x92\x25\x4c\x45\x29\xaf\x57\x00\x4c\x0b\x68\x98\x65\x35\x7a\xb4\x47\x1e\x46\xb4\xd6\xd8\xa2\x46\xe7\x58\x95\xf0\xba\xc7\x17\x9f\xca\x72\x84\x82\xdf\x77\x51\xf1\xdb\x33\xac\xe8\xe5\x82\x2c\x23\xf3\x4b\x26\x15\x8a\xe3\xcc\x0d\xdd\xaf\xf7\x6e\xec\x62\xb4\x38\xbd\xab\x24\xb0\xeb\xd7\x74\xfd\x80\xfe\xd9\xff\x86\x75\x67\x00\x5d\xdf\x6d\x68\xf1\xb8\xec\x0a\xbe\x77\x7d\xb0\x70\xdc\xf0\x60\x1f\xec\xa5\x08\x57\xff\x44\x9c\x45\x4d\xb4\xed\x2b\x39\xee\xf0\x7c\x49\xe4\x79\x3a\xde\x07\x77\x8d\x34\x6e\x6e\xb4\x6f\x68\xdd\xbb\xd8\x9f\x44\x3b\x55\x8f\x40\x3a\xad\x94\x5b\xa4\x8b\x7d\xc1\x3c\xe9\x1b\x88\x1f\xd6\xb6\xf1\xc1\xa6\x91\xd1\x77\x31\x49\x97\xf3\xac\x6e\xdc\x28\x13\x66\xce\x94\xbe\xe8\x5a\x58\x6e\xb8\x15\x9c\x91\xa0\xd7\xb3\x7f\x03\x00\x00\xff\xff\xcb\x12\xf5\xa3\xd4\x15\x00\x00")
+var _yaoModelsAuditModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xbd\x59\xdd\x6f\xdb\x36\x10\x7f\xcf\x5f\x71\xd0\x53\x06\x68\x45\x57\x34\x05\xb6\x37\xcf\x19\x90\x00\xed\x1a\x24\x0e\xf6\x50\x04\x06\x2d\x51\x32\x37\x4a\xd4\x48\x2a\x89\x17\xe4\x7f\xdf\x91\x12\x6d\xca\xa6\x1c\xd3\x35\xda\x87\x34\x22\x79\x1f\xbf\xfb\xe2\xf1\xf2\x72\x06\x90\xd4\xa4\xa2\xc9\x6f\x90\x90\x36\x67\x3a\x49\xcd\x12\x27\x0b\xca\xcd\xda\xc4\xac\xc1\x67\x51\x76\xeb\x39\x55\x99\x64\x8d\x66\xa2\xde\xec\x72\x51\x82\x26\x0b\x4e\xa1\x10\x12\x94\x16\x92\xd5\x25\xa8\x95\xd2\xb4\x02\xd1\x50\x49\xcc\x79\xb0\xec\x41\xd2\x4c\xc8\x5c\x75\xec\x34\x29\x15\xf2\xf9\x96\x74\x87\x93\x07\xbb\xba\x68\x19\xd7\xcc\x08\xd0\xb2\xa5\x76\x49\x52\x92\x8b\x9a\xaf\xfc\x35\x25\xa4\xc6\xef\x5f\xf1\x5f\xcf\x0c\x55\xc0\x85\x17\xfc\xd8\x46\x35\xe7\x3d\x02\xdc\xc8\x44\x55\xd1\x5a\x07\xf4\x4f\xf0\xc0\xab\xe5\x95\x09\xde\x56\xb5\xd5\xcd\x12\x75\x3c\x3d\xae\x2c\xef\xd9\x19\xc1\xab\xc6\xae\x5d\x5f\x6e\xd6\xb6\x0c\xe8\x6f\x79\xf2\xef\x6b\xf6\x6f\x4b\x07\x96\x01\x96\xe3\x26\x2b\x18\x95\x89\xa5\x78\x4d\xc3\x1a\xd0\x47\x3c\x37\x0f\xe9\xa1\xb4\x71\x40\x40\x97\x3f\x0c\xc9\x1b\xba\x58\xb6\x9e\x12\xd6\xa7\xa8\x98\xa4\xdc\xba\xd1\x63\x4b\xeb\x52\x2f\x91\xf4\x97\xf7\xef\xd7\x8b\x75\xcb\x79\xef\x07\xe7\x28\xbb\xce\xea\x9c\x3e\xf7\x8b\x7b\x61\xad\xe3\x25\x02\xd7\xd7\x5d\x1a\x0f\xd8\x7a\x17\x8c\x0c\x38\x47\x77\xb3\x3a\x85\x9c\x72\xaa\x69\x0a\x95\xc8\x59\xb1\x4a\x81\xea\xec\xdd\x4f\x87\xa3\x2b\x08\x57\x47\xc0\xcb\x88\xa6\xa5\x90\xab\x08\x74\xd3\x1d\x92\x9d\x08\x76\x5c\xe1\x9c\xb4\x7a\x69\x5c\x97\x59\xc4\x29\x98\x6f\xcc\xc7\xff\xfa\xcf\x9c\x68\x92\xf6\xa9\x19\x02\x7b\x71\x42\x4f\x2a\x0c\x25\xc9\x74\x00\x2a\xad\xdb\x2a\x00\xf4\x6e\x87\xc0\x03\xda\xc5\xae\xe3\x09\x1c\x7f\xe1\x9b\x83\xc2\x95\xa4\x6f\x09\x17\x4f\x49\x0a\x49\x45\x73\x66\xa4\x40\xb2\x64\xe5\xd2\xfc\x8f\x85\xcb\xd8\x85\x77\x55\xc6\x92\xe5\xb4\x20\x2d\xb7\xfc\xdd\xf9\x68\x98\xad\xa2\x32\x2e\x0d\xef\x91\x62\x34\x0b\xcd\x9e\xa9\xa2\x54\x3e\xb2\x0c\x4b\x43\x96\x89\x76\x90\x90\x01\xaf\x7d\xb8\xb8\x38\x61\x88\x5a\x40\xf6\x23\x12\xd2\x9f\x03\x9a\x6d\x50\x39\x53\x0d\x27\x2b\x18\x72\xde\x60\x18\x2f\x22\x7b\xb5\xd5\x94\x54\x71\xe6\x9f\x21\xc5\x98\xf9\xed\x9e\x28\x00\x93\x08\x8c\x1d\xe0\x09\xb3\xc9\x7e\x6d\xee\xb1\x27\xa2\x00\x3f\xb0\x2e\x62\xcc\xc4\x21\x39\xa2\x5e\x70\x16\x5d\xe6\xa7\x96\x66\x0c\xe2\xd7\x09\x96\x04\xe8\xf8\x1e\x15\x56\xc7\x56\x03\xa5\xd0\x7a\x71\x48\xee\x3a\xa2\x31\x28\x6e\xfb\xc7\xa1\x70\xee\x68\x8e\x70\xc7\x4d\x10\xc3\x7a\x17\x48\x9e\x4b\x04\x14\x80\xf0\xf1\x84\x08\x6c\x76\x93\xd2\x48\x8f\x4c\xef\xc9\x90\x68\x17\x83\x4d\x18\xcb\x1a\x58\x6d\xd2\x63\xac\x61\xb8\x38\x36\xd7\x89\x2c\xa9\x9e\xa3\x95\x44\x2b\xb3\x98\xfa\x34\xb3\x94\x70\xbb\x43\xe9\xe7\x7e\x77\xc6\x71\x87\x05\x35\x6d\x6c\x97\xf7\x34\x87\x58\x24\xf1\xae\x71\x92\xe7\x16\xce\xe1\xe0\x1c\x2a\x98\x0d\xe8\x7c\x68\xb8\x61\xca\xda\x1a\xdb\x79\xc1\x38\xf6\x3e\xb6\xeb\x4d\xb1\xbd\xab\x0b\x56\x46\xb7\x40\x47\x16\x82\x58\xdf\xdd\x8d\xbb\x6c\xd3\xda\x39\x5c\xf7\xd7\x29\x4c\x6e\xf0\xc7\xf4\xf3\xf5\xbe\x46\xe7\xa4\x90\x48\xd3\xf0\xbe\xe3\x8a\xc0\x35\x09\x51\xf9\xad\xdd\x66\xdf\xef\x08\x46\xee\xcf\x93\x02\x5a\x0a\xa5\x23\x3b\x80\xab\x1d\x12\x0f\x8a\xdb\x74\x57\xab\xd8\xf2\x5b\x5c\xd9\xde\x1f\x5e\x6d\x96\x0d\xaa\xa8\xd3\x7c\x21\x04\xa7\xa4\x0e\x05\xd8\x36\x89\xa7\xf9\x5f\x4b\x8a\x0a\xcb\x40\x0b\xd0\x0b\x2a\x5a\xaf\x0d\xfd\xfe\xe6\x8b\x3e\xe3\x63\x35\x13\x79\xc0\xf4\xac\xc6\x26\x7f\x70\xc5\xad\xdf\x75\x48\x04\xd3\x01\x91\x7f\x4b\x76\x0f\x71\xec\x7c\x39\x16\x00\xdd\xca\x1a\x86\x02\x62\xcc\x8b\x15\xa4\x11\xb5\xc2\x1a\xc5\xaa\x28\x1d\x6f\x7b\x42\x98\xb1\xea\xad\x64\x76\x42\xc0\x08\xc1\xbb\x04\x2a\xc6\x39\x53\xf8\x3e\xae\x73\x75\xac\xde\xf8\xb8\x55\x91\x8d\xd4\x6d\x47\x34\xd6\x7e\x5c\xcd\x66\x37\xd0\x33\xde\xdf\x83\x9c\x34\x39\xb5\x24\x78\x47\xc4\xb5\xbc\x86\x64\x0c\xc6\x25\x33\x74\x8b\xd6\x5c\x72\x86\xb7\xb9\xf5\x7e\x1c\x1a\xf3\x22\x9d\x2f\x28\xb6\x0b\x81\x70\xfa\x5b\x89\x50\xc2\x5e\x22\x0d\xfc\xbe\x45\xe3\x23\x32\xfb\x4a\xe3\xb5\x0d\x1d\x67\x08\x4c\x15\x62\xa2\xc7\x2a\x49\x0a\xed\x9b\xe3\x10\x1d\x27\x43\x92\xb0\x8a\x96\xef\x77\x6b\x48\x35\x61\x5c\x1d\xae\xde\xf6\x79\xff\xe2\xc9\x73\x66\x34\x21\xdc\x2b\x79\xbd\x00\x20\x75\x0e\x0d\x91\x28\x16\xb5\x3e\x32\x19\xa9\x94\x42\xce\x2b\xac\x9e\xd8\x2d\xee\xaa\xac\xe9\xb3\x0e\x55\x39\x43\x05\x5f\xb6\xa9\xfc\x19\x81\x3d\xd1\xf3\x05\x56\x78\xea\x17\xa8\xbc\xff\x5a\x8b\x6b\x3b\xcb\xc3\x0d\x3b\x1b\x1c\x0e\x5b\xd5\x30\xec\xe6\x6a\xdd\xdc\xa6\x1f\xcf\x1c\xa9\x1d\xd6\x48\x0c\xa4\x2a\xf0\x24\x59\xb0\xf2\x7a\xb4\x20\xcf\x76\xe9\x76\xc6\x2d\xae\xfe\xb6\x35\x7b\x1e\x14\xe1\xd4\xc4\x80\x76\xd7\xf9\x92\xa8\xe5\x29\x2f\xc1\x46\xd2\xc7\xf9\x90\xe9\x9b\x25\xee\x06\x69\x98\x68\x15\x5c\x0d\xe8\xfc\x72\x8d\x1b\x4e\xe3\xc6\x9d\xf6\x47\xaf\xd8\x00\x57\x8d\x5e\x59\xcf\x98\x43\x05\x93\xca\xed\xbd\x83\x6e\x3e\x8a\x3d\xa5\xb0\x9b\xd9\x92\xa0\x61\x6a\x33\x8f\x32\x04\xff\x84\xde\x6d\x9f\x3e\xbe\x59\x27\x5b\xcb\xf5\xa0\x9e\x2c\xce\x1e\xe3\x66\xf8\x32\x99\xfe\x7c\x77\x35\xf9\x70\xf1\xc9\x59\xa3\x9f\x3c\x9b\xd4\x1e\x18\xc7\x88\x4c\x3b\xa4\xca\x3b\x88\xbf\x0b\x53\x0f\x68\x86\x11\x82\x01\x44\xb7\x54\xd8\x0f\x7f\x18\x13\x01\xfc\xf8\xf3\xa1\x1f\xfa\x77\x23\x67\x33\x86\x7f\xe9\xe6\xf2\x36\x84\xe8\xde\xb9\xfc\xf3\xdc\x3e\x71\x45\x68\x26\xbc\x1e\xea\x6f\xa6\x76\xfe\xc4\xf9\x21\xd0\xcf\x98\x90\xdd\xeb\x17\x23\x71\xfd\x72\x7b\x43\xea\xce\x03\xf6\x24\xd2\xed\x08\x4c\x6f\xb5\x55\x9e\x50\x37\x21\xf3\x6b\xc5\xf1\xc2\x90\x85\xb5\x70\x58\x58\x26\xa9\x79\x2d\xcf\x89\x36\xf2\x9c\x95\xf7\x4b\x5b\x3b\x7c\x3d\xc5\xed\xff\x84\xb3\x56\x57\x0d\xd2\x06\x9f\x90\x85\x9e\x77\xf3\x7b\xe5\x22\xca\xfc\xe9\xe6\xec\xf5\xec\x7f\xe4\xed\xc1\x80\xd1\x1a\x00\x00")
 
 func yaoModelsAuditModYaoBytes() ([]byte, error) {
 	return bindataRead(
@@ -1827,7 +1851,27 @@ func yaoModelsAuditModYao() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "yao/models/audit.mod.yao", size: 5588, mode: os.FileMode(438), modTime: time.Unix(1768928216, 0)}
+	info := bindataFileInfo{name: "yao/models/audit.mod.yao", size: 6865, mode: os.FileMode(438), modTime: time.Unix(1768928215, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _yaoModelsClusterModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x95\x54\x4d\x6f\xd4\x30\x10\xbd\xef\xaf\x18\xe5\x04\x52\x38\xb4\x20\x04\x7b\x43\x94\x43\x01\x51\xa4\xc2\x09\xa1\xca\xb1\xa7\xc9\xa8\xfe\xc2\x76\x56\x8d\xaa\xfd\xef\x8c\xbd\x69\x36\xdd\xa6\x5f\x39\x58\xf2\x9b\x99\xe7\xf7\x66\x1c\xdf\xac\x00\x2a\x2b\x0c\x56\x6b\xa8\xa4\xee\x63\xc2\x50\xd5\x19\xd4\xa2\x41\x9d\xd1\xcf\x3b\x14\xbe\x6c\xd0\xa6\x5d\x4c\x61\x94\x81\x7c\x22\x67\xe7\x19\x98\x33\x20\x89\x46\x63\x0d\xa9\x43\x38\x39\xff\x0e\xb2\x13\xb6\xc5\x08\xc2\xaa\x82\x49\x21\x79\x25\xbb\x11\x9a\x94\xc8\x14\x11\x9a\xe0\x84\x92\x82\x49\x38\xc7\x95\x34\xb2\x31\x09\x2b\xb9\xb0\x19\x0a\xa0\x1a\x50\x81\x36\xb7\xf2\x92\x68\x23\x9f\xfd\xa7\x8a\x03\x97\x99\xea\x6f\x41\x9b\x9e\x74\xa2\x2c\x2a\x85\x1e\x0b\x14\x50\x28\x67\xf5\x30\xc7\xa2\x0b\x89\xf7\x1f\xf9\x1b\xc9\x58\x32\x03\x37\xbc\xb9\xdf\x8f\x0b\x9c\x9c\x73\x50\x3a\x63\xf2\x76\xd9\x77\xc5\x49\xdb\xc2\x29\x9d\xee\x8d\x2d\x1a\x4b\xe1\x8e\x7b\xc6\x4e\x6a\xa4\xcc\x02\x06\x5f\xb0\xd3\x93\x3d\x36\x0d\x60\x0e\xce\x4e\xff\xd4\x27\xf7\x86\xac\x0c\x98\x11\xf0\x81\x8c\x08\x03\x5c\xe1\x50\x1f\x74\x30\xb7\xa0\x40\x45\x68\xe4\x08\xef\x28\x82\x0b\x8a\xdb\x59\xa8\xb7\xf5\xb2\xc8\x52\x71\xb1\x24\x35\xa6\x40\xb6\x5d\x90\x5b\xee\x09\x3c\x20\xfa\xb7\xa5\x7f\x3d\x72\x14\xdc\xe5\x5e\xd2\x8c\x05\x6d\x9b\x3a\xce\x7c\xff\x6e\xc2\x6c\xaf\xf5\x38\xa0\x4b\xa1\x23\x4e\x81\xbe\x90\x8d\x83\x7d\xd4\x86\x0b\xd4\xf2\xb5\x78\xbe\x89\xb3\x83\x82\x99\x85\xd3\xb1\xaf\xe0\xfb\x46\x53\xec\x98\x60\xef\x04\x5e\x75\x2e\xa6\x7c\xec\xda\x93\x5a\x07\xbe\xf5\xce\xbc\x5e\xf0\x77\x74\xfc\xe1\x69\x83\x64\x15\x5e\x3f\xc7\xdf\x15\x67\xbe\xc0\xdd\xb7\x3b\xe9\x33\x6f\x39\x70\x67\x32\x6b\x50\x51\xd7\xe3\x4f\xeb\x02\x08\xef\x17\xcc\xbc\x3d\x7e\xd0\xcb\xa3\xb2\xbd\x18\x34\xff\xf9\xf7\x95\x27\xbc\x4e\x0b\xba\x7f\x1e\xe6\xcf\xa4\xff\x9a\x46\x80\x56\x3a\xc5\x2f\x89\x88\xf0\xf5\xfc\xec\x47\xf5\x84\x36\x5e\x77\x8f\x87\xbb\x7d\xd0\xc6\x67\x20\x91\x41\x1e\xb5\xf1\x71\x3f\x82\xed\x6a\xbb\xfa\x0f\xa3\x01\x3f\xa6\x34\x05\x00\x00")
+
+func yaoModelsClusterModYaoBytes() ([]byte, error) {
+	return bindataRead(
+		_yaoModelsClusterModYao,
+		"yao/models/cluster.mod.yao",
+	)
+}
+
+func yaoModelsClusterModYao() (*asset, error) {
+	bytes, err := yaoModelsClusterModYaoBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "yao/models/cluster.mod.yao", size: 1332, mode: os.FileMode(438), modTime: time.Unix(1768928215, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -1912,7 +1956,7 @@ func yaoModelsJobCategoryModYao() (*asset, error) {
 	return a, nil
 }
 
-var _yaoModelsJobExecutionModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x59\x5b\x4f\x1c\xb9\x12\x7e\xe7\x57\x94\xfa\x89\x48\x70\x42\x8e\x8e\x8e\x0e\x48\xe7\x01\x91\x48\x9b\x95\x56\x8b\x02\x51\x1e\x22\x34\xf2\xb8\xab\xbb\x0b\xdc\x76\xc7\x17\x60\x76\xc5\x7f\x5f\xd9\xee\x7b\xf7\x0c\x33\x1d\xf2\x06\xb6\xbf\xaa\xfa\x5c\xd5\x75\xf1\xfc\x7d\x04\x90\x48\x56\x62\x72\x01\x09\x3e\x23\x77\x96\x94\x4c\x4e\xfc\xb2\x60\x6b\x14\x7e\xfd\x77\xb5\x86\x4f\xc3\xbd\x14\x0d\xd7\x54\x85\x85\xfa\x44\x8b\x06\xcb\xd6\x02\x21\x53\x1a\xac\x66\xfc\x81\x64\x0e\x24\x53\x7a\xa4\xd4\x31\xd1\x3b\x47\xd2\x58\x26\x39\x9a\x28\xd3\xb2\xdc\x24\x17\xf0\x3d\x31\x1b\x63\xb1\x4c\xee\xc2\xea\xda\x91\xb0\xe4\xb5\x58\xed\x30\x2c\x69\x64\xa9\x92\x62\x93\x5c\x40\xc6\x84\x89\x8b\x46\x69\x9b\x5c\xc0\xf9\xf9\xf9\x79\x2d\x6d\x2d\x3c\x2b\xcf\xb0\xc7\xf1\x5e\xad\x57\x43\x9e\x00\x09\x57\x65\x89\xd2\x4e\xb8\x46\x26\xc9\x11\xc0\x4b\x90\xc9\x95\x70\xa5\x0c\x46\x06\x60\x94\xdd\x93\x4e\x69\x2d\xd2\x1b\xb0\xa9\xc2\xda\xe7\x8f\xdd\x5a\x7b\xa5\xfd\xc5\x9e\xf6\x4b\x67\xd5\x29\x49\xae\xd1\xaf\x40\xa5\xa9\x64\x7a\x03\x0f\xb8\x49\xc2\xe9\x97\x93\x79\xbd\x2d\xa3\xd5\x9c\x05\xc6\x6a\x92\xf9\x8c\x15\x1d\xd1\x2d\xf6\x7c\x95\xf4\xc3\x21\x44\x01\x40\x29\x4a\x4b\x19\xa1\x8e\xce\x2d\x70\xc6\x99\x3d\x35\x28\x73\x5b\x24\x17\xf0\xdf\xff\xb4\x6b\xd2\x09\x51\x3b\xa6\x75\x5d\xd8\x70\x41\x53\xed\xe5\x9d\x5c\xbd\x07\x0f\x62\xe9\x5d\xba\x85\xdf\x17\xcc\x50\xa3\xe4\x08\x56\x05\x3e\xf7\x6a\x0d\xb6\x20\xd3\x23\xb6\x46\xa1\x64\x6e\xc0\xaa\x85\xd4\x48\xa6\xf8\xbc\x0f\x33\x63\x99\x75\x66\xca\x0c\xa5\x2b\x67\x78\xdd\x8c\x8e\xf7\x78\x7d\x9a\xb8\x05\xc6\xc2\x55\xf3\xf1\x7e\xaf\x57\x00\x92\x1f\x0e\x1d\xa6\xc9\x09\xbc\x7f\xdf\xfb\x08\xc8\x40\xdc\x00\x26\x53\x78\x62\x64\x7d\x30\x58\xe5\x45\x6a\xdb\xa1\x49\x92\x25\x26\xe8\xaf\xe0\x86\x89\x8c\x35\xc6\x44\x50\x1f\xc2\xb4\x43\x6a\x27\xe5\x3c\x88\x3b\xad\x51\x5a\xb1\x01\x92\x50\x69\x95\x6b\x34\xa6\x03\x72\x55\x56\x02\xed\xd4\xe6\x8c\x24\x99\x02\x53\x30\x8e\x73\x34\x26\x73\x42\x6c\x3a\x5c\xc6\x48\xcc\x80\xc2\x2a\x3c\x91\x2d\x00\xb5\x56\xba\xaf\xc9\x5f\xa2\x98\x01\x3d\x31\x03\xed\x26\xac\x37\xe0\x0c\xea\xf7\x31\x85\x75\x70\x4b\x25\x2a\x67\xc7\x60\xbf\x9c\x82\x72\xbd\x5b\x7c\xa0\xa0\x65\xaa\x24\x53\x9a\x63\xe0\x01\x16\x75\x49\x92\xd9\xf6\x0e\xef\x5a\xb7\xa6\x98\x31\x27\x42\x10\x34\xde\x7c\xbb\x00\xb5\x9a\xf2\x1c\xf5\x8a\x33\x8b\xb9\xd2\x9b\xbd\x43\xf5\x36\x02\xe1\x6a\x02\xec\x05\xed\x6f\x94\x17\xa7\x02\x1f\x51\x40\xad\x08\xa6\x8a\xe6\xc2\xb6\x64\xd2\x31\x11\xef\xf6\xab\x41\x0d\x71\xc1\x5f\x54\x94\xd3\x8f\x35\xc3\x0b\x4c\x5d\xeb\xc8\x5b\x2a\xf1\x74\xcd\x8c\x0f\x95\xb8\xe3\xa3\xf4\x98\x6b\x25\x4f\x80\xa4\x45\xfd\xc8\xc4\xbb\x0e\x8e\x8f\xde\xda\xe8\x46\xff\xe7\x69\xaa\xe9\x11\x65\xa3\xa9\x17\x31\xac\xa2\xc6\xdd\x16\xb5\x64\x02\x2e\xaf\x3f\x03\x67\x42\xf4\x0e\xd5\xa5\x2e\x9c\xbb\x09\x7f\x9f\xc6\x2f\xc4\x62\x3a\x23\x33\xc5\x0a\x65\x8a\x92\x6f\x42\x80\x7c\x6c\xff\xad\x29\x8c\x10\x77\xbf\xc0\xf7\x46\x39\xdd\xcf\xf2\xaf\xa6\xdf\xc6\xf7\x37\x23\x60\xcf\xf3\x37\x15\x72\xca\x88\xb7\x7e\x8f\x4a\xfa\x05\xe7\x18\x9f\x2d\x4a\x43\x6b\x81\xef\x66\xf2\xf0\x87\x7f\xff\x6f\x8e\x6b\xd3\x32\x2c\x0c\x73\x25\x2d\x3e\xdb\x29\xd7\x7b\xd3\xf6\x0e\xb3\x51\x3e\xc6\xf5\x2b\x7c\x9a\x92\x8f\x60\xd6\x0b\xf2\x78\x3c\x64\xd7\x12\x2d\x4b\x99\x65\xb3\x5f\xed\xeb\x05\xa4\x89\xed\x15\x9b\xb1\xda\x27\x1b\x63\x59\x59\xcd\xd5\x92\x06\x09\x97\xf3\x76\x7f\x2b\x7c\x90\x0f\x2b\xa3\xcf\x4a\xad\x4a\x5f\x10\xb4\x93\x70\xec\x5b\x83\x6e\xf5\x5e\xad\xcd\xbb\xad\x6c\x0e\xf7\xcd\x93\xd2\x0f\xa8\x0f\x6b\x00\xbe\x05\xcc\xb6\x1e\xa0\xde\x6d\x0b\x65\xc1\x64\x1a\x92\xc0\x90\xed\xaf\x0e\xba\x4a\x2b\x5f\xa8\x0e\x63\x76\x1d\x41\xdb\xa8\x75\xdb\xa1\x61\x2b\x90\x3d\x6e\x9e\x90\xf2\xc2\x76\xbc\xcc\xde\x5d\xcd\x32\x5e\x1a\xad\xde\xac\x98\xb5\x58\x56\x33\x41\xe9\x53\x6c\x8e\x7a\x86\xdb\x17\x0f\x84\xcb\x31\x70\x10\x93\xc4\x0b\x08\x0a\xa0\x56\x10\x9d\x46\x06\x8e\xcf\x02\xe5\x8c\xb4\xb1\xcd\x66\x2f\x0e\xbb\x32\x79\xb6\x35\x47\xee\x76\x17\xf3\x7d\xc9\x6a\x61\xe3\x7d\x1d\xd0\xf0\x6a\xff\x3d\xe8\x4f\xa3\xca\x7e\xcb\x9d\xb5\x74\x59\xbc\x86\x5f\xec\xcb\xd0\xf0\x2d\xc9\x2e\x11\xb7\x2d\xb7\xdc\x36\x50\x78\xf2\x59\xa6\x23\x58\xeb\x7b\xc3\xf4\xe1\xcb\xe5\xe1\xf6\x7f\xf2\xa8\x83\xad\x0f\xba\xde\xd0\xf6\xba\x87\x5c\x19\xe4\x4a\xa6\x33\x73\xc2\xf6\x6f\xe9\x36\x42\xe1\x66\x0c\xed\x57\x26\x6e\xfd\x78\x5e\x6b\xf1\x6d\x6c\x5a\x8f\x79\x83\xa4\x7f\x4c\xb2\x40\x4d\xde\x9d\x99\x56\x65\x98\x98\x94\x06\xf5\x88\x5a\x53\x9a\xa2\xdc\x9e\xed\x77\xb2\x4b\x9d\x66\xc3\x3c\xfb\x3a\xad\x8f\x13\xcc\xec\x0c\xd4\x88\xf6\x33\x44\x49\x42\xd0\xe4\x06\x0f\x31\xb4\xd2\xa4\x34\xd9\x99\xe6\x77\xbb\xa1\xd7\x13\xcc\xac\xa1\x8d\x68\x38\x2e\x28\x2f\x50\x83\x74\xe5\x1a\x35\xfc\x1f\xea\xff\x9b\x03\x07\x66\xb2\x45\xd5\x28\x8c\x5a\x87\x91\x1c\x63\xb6\x90\x8c\xc7\xa0\x42\xcd\x51\x5a\x96\x23\x1c\x9f\x9d\x7e\x38\x3b\x7b\xcb\xfc\xdc\x25\xe6\x38\x31\xcc\x30\xd9\xd2\xc5\x75\x96\xfe\x39\x46\xce\xf2\xa9\xe5\x03\x49\x2e\x5c\xea\x1b\x87\xd6\x8d\xbe\xa3\x33\x05\xd3\x98\xc2\xf2\xa6\x8e\x2b\x99\x51\xbe\x32\x92\x55\xa6\x50\xfb\x77\xa3\x57\x01\x07\x37\x13\x5c\xbf\xf1\xae\x37\x41\x65\xe1\x43\x8e\xba\x9a\xcf\x85\xf5\x8b\x8d\xcf\x0b\xcb\x08\x68\x34\xde\x95\xfb\xda\xfd\x65\x74\x7c\xf6\xd2\xa3\xcc\x9f\xb8\xd5\x30\xdf\xaf\x48\x66\x6a\xff\xc0\xf0\x10\xf8\x3c\x80\xf4\xef\xd2\x6a\xc7\xad\xf3\xce\x0e\xc2\xc1\x0b\xd7\x25\x6b\x4a\x35\x8e\xde\x19\x16\xb6\xf8\x96\xf1\x87\x95\xd5\x6c\x6e\x06\x1b\x4e\x1d\xfd\xf2\xcb\x1f\xe0\x76\x88\x19\x58\xee\xf7\x83\xcc\x81\xd1\xa3\x7a\xe6\xcd\x5e\x98\x33\x4b\xb4\x9a\xf8\xfe\xdf\xe0\x1f\xe3\xf3\xb3\x41\x50\x4b\x05\x41\x0f\x08\x25\x96\x4a\x6f\xc0\x19\x96\xe3\x09\x5c\x5d\x7f\x0d\x11\x7b\x02\x68\xf9\xbf\x16\x7f\x78\x07\x8d\x7f\x7b\x8e\x7d\xdd\x85\x2e\x18\xfc\x8e\xea\xa1\x3e\x66\x72\xdc\xf9\x14\xfd\xdc\xeb\x4e\xef\xd5\x7a\x35\xe9\xa6\x7a\xcf\xd9\xed\xab\xea\xa0\xc9\xbb\x9b\xa3\x72\xa5\xca\x4a\x19\xb2\x3e\x56\x52\x7c\x0e\x3d\xc2\xfd\xe0\xfd\xbf\x20\x63\xbd\x2f\x7e\x38\xd4\x84\x66\xf7\xd3\xf5\xd0\xce\xf8\x4a\xb9\xdb\xd4\xe6\x25\x73\x99\xa9\x11\x5d\xbf\x97\xd4\x16\xc6\x27\x3f\x1f\x2f\xa0\x74\x8a\xa1\x69\x3f\xc0\xe8\x7a\x26\xdd\x69\x74\x6f\x6e\x5d\x66\x77\x14\x30\xbd\xe5\x43\x0c\x6d\xdf\x70\x76\x59\x3a\x7d\xe4\x5b\x66\xf0\xf8\x0d\x0f\x98\x64\x62\x63\xe8\xa0\x78\xd8\xf6\xea\x34\x67\x70\x73\xe6\xe7\xcc\xad\x9f\x9e\x96\x18\x5b\xcf\x84\xa3\x49\xac\x6f\xea\xec\xd4\x38\x9e\x91\xf7\x36\x39\x4e\xbe\xbc\x60\x24\xdb\x5f\xda\x92\x51\x9e\x68\xdf\x4b\xeb\xdf\xc1\x2a\xd4\x25\x19\x13\xd7\xba\x39\xa4\x1b\x7f\x4c\x97\x6f\x5e\x8e\x5e\x8e\xfe\x09\x00\x00\xff\xff\x77\x3a\x68\x76\x21\x1c\x00\x00")
+var _yaoModelsJobExecutionModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb5\x59\xdd\x6f\xdb\x36\x10\x7f\xcf\x5f\x41\xf8\x29\x01\x92\x35\x1d\x86\x61\x1b\xb0\x87\x22\x2d\xb0\x0e\x18\x16\x34\x09\xfa\x50\x04\x06\x2d\x9d\x2c\xd6\x12\xa9\x92\x54\x12\xad\xc8\xff\xbe\xe3\x87\x24\x4a\xa2\x5d\x4b\x4d\x8b\x02\x8e\x8f\xbc\xe3\x1d\x7f\xc7\xfb\xf2\xd7\x13\x42\x56\x9c\x96\xb0\xfa\x83\xac\xe0\x09\x92\x5a\x33\xc1\x57\xe7\x86\x5c\xd0\x0d\x14\x86\xfe\xb7\xd8\x90\x77\xc3\xb5\x14\x54\x22\x59\x65\x09\x7e\x47\xc7\x4d\x34\xdd\x14\x40\x32\x21\x89\x96\x34\xd9\x31\xbe\x25\x8c\xa7\xec\x81\xa5\x35\x2d\x82\x7d\x8c\x2b\x4d\x79\x02\xca\xc9\xd4\x74\xab\x50\xd8\xa7\x95\x6a\x94\x86\x72\x75\x6f\xa9\x9b\x9a\x15\x9a\x99\x53\xb4\xac\xc1\x92\x24\xd0\x54\xf0\xa2\x41\x5a\x46\x0b\xe5\x88\x4a\x48\x8d\x84\xdf\xf1\x9f\x97\x86\x4a\x20\xe1\x2b\x7e\x09\x6c\xfc\x2c\x36\xeb\xa1\x9d\xb8\x98\x88\xb2\x04\xae\x27\xb6\x3a\x4b\x56\xb8\xe9\xd9\xca\x4c\x44\x51\x97\xdc\x2a\x69\x19\x9d\xec\x40\x3a\x4b\xbd\x48\xa3\x40\x53\x59\xda\xfb\xb7\x3d\xad\xbb\xd2\x90\x18\x9c\xfe\xa6\xd6\xe2\x82\xf1\x44\x82\xa1\x90\x4a\xb2\x92\xca\x86\xec\xa0\x59\xd9\xdd\xcf\xe7\xf1\x73\x3b\x8b\xd6\x31\x0d\x94\x96\x88\x41\x44\x8b\xde\xd0\x3d\xfa\xdc\x71\xf6\xa5\x06\xe2\x04\x10\x96\x22\x99\x65\x0c\xa4\x03\x37\x87\x08\x98\xc1\x31\xc0\xb7\x3a\x47\x31\xbf\xfe\xd2\xd1\x78\x5d\x14\x1e\x98\x0e\x3a\xbb\x50\xdb\x93\x3c\xca\x07\x6d\x35\x08\xce\xb2\xd2\x40\xba\xc7\xbe\x0f\x90\x81\x04\xd4\x9a\x68\x61\xed\x41\xe1\xf8\xc9\x54\x60\x18\x4a\x11\x7c\xab\x70\xc7\x42\xd3\xd0\xf7\xe1\xe9\x18\xcb\xf0\x02\x75\xad\xa6\x96\x01\xaf\xcb\x88\x5d\x37\xa3\xed\x81\x5d\xef\x26\xb0\x90\xb1\x70\xd1\x3e\xde\x4f\x9e\x82\x34\x44\xa0\x06\xbc\x5a\xf2\xea\x55\xf0\x08\xf0\x36\xdc\x02\xa1\x3c\x25\x8f\x94\x69\xe3\x0c\x78\x61\x28\x52\xea\x9e\xdb\xaf\x44\xd8\x5b\x1e\xe3\x35\x4c\x2b\x92\x42\x05\x78\x29\x3c\x61\x60\xee\x95\xa0\xe6\x55\x01\x1a\x7a\x59\x8c\x23\x07\x2d\xd8\x7f\x71\x81\x1b\x70\x41\xc5\x6f\x82\xb4\xe7\x94\x35\xe7\x71\xa6\xa4\x96\x88\xb5\x2e\x1a\x64\xc4\xa7\x25\xb6\x12\x94\xea\x19\x5b\x25\x26\xf6\x67\x78\x8e\xca\xd1\x7c\x55\x27\x18\xac\x54\x86\x40\x37\x3d\x5f\x46\x59\x11\x61\xb2\x54\xf2\xc8\x74\x4e\x40\x4a\x21\xc3\x93\x0c\x20\x45\x84\xe9\x91\xa2\x96\xed\x22\xd9\x34\xa4\x56\x20\x5f\xb9\x70\xd8\xb3\x6b\x56\x82\xa8\xf5\x98\xd9\x90\x53\x82\x0b\xfd\xce\x1d\xdb\x77\x0a\x22\x91\x80\x35\x84\x68\x90\x25\xe3\x54\x87\x97\x98\x62\x84\x5d\x0d\xb9\x50\x95\x94\xd4\x95\xc5\x4f\x02\xbe\x35\x84\x0e\xe1\x14\x1c\x88\xc8\xa6\xa8\xa6\x2c\x25\x5c\xe8\x31\xb4\xf7\x9d\xff\xa5\x90\xd1\xba\xb0\xde\xda\xba\xdd\xcb\xbd\x24\x54\x6f\xbb\x05\xb9\x4e\xd0\xac\xad\x90\xcd\xd1\x6f\xea\xd6\x31\x92\xab\x09\x63\xf0\xba\xfe\x62\xdb\xfc\xa2\x80\x07\x28\x88\x3f\x88\x4c\x0f\x8a\xbd\xaf\x92\x72\xcc\x80\x0e\x8f\x3b\x84\x96\x38\x82\x01\xc1\xc9\x09\x31\x50\x09\xfa\x5c\xdd\xe1\x77\x8b\xf0\x5e\x6c\xa8\x41\xc1\xaf\x98\x27\x70\x9a\x48\xc1\xcf\xd1\xa1\x11\xc5\x07\x5a\x9c\xf5\xec\xa8\x1e\x6f\x7d\xc4\xfc\x79\x91\x4a\x86\x9f\xed\x49\x81\x3b\xd2\x8a\xb5\x2e\x82\x52\x38\xa6\xe8\x37\xd7\xef\xd1\xa2\xa2\x08\x36\xf9\x9c\x6c\xf7\xdd\xd8\xbf\x2f\xdc\xf3\x43\xbf\x89\xc8\xec\x7c\xa1\xb1\x6e\xf4\xb6\xfb\xea\x4d\x18\x71\xdc\xff\x00\xec\x95\xa8\x65\x98\x8e\xbe\x99\x27\x5a\xec\x6f\x46\x8c\x01\xf2\x37\x15\x24\x98\x00\x93\x0e\x77\x77\x48\x98\x19\x4f\x01\x6f\x91\x2b\x86\x36\x9c\x45\x12\xc6\xeb\x9f\x7f\x8b\xd9\xda\xd6\x36\x0b\xdd\x5c\x20\xfe\x4f\x7a\x6a\xeb\x67\xd5\x15\x39\x51\x2f\x1f\xf3\x85\xa5\x48\x9a\x32\xe3\xc1\x34\x70\x72\xb7\xdd\xa6\x81\x12\x34\x4d\xa9\xa6\xab\x7d\xd6\x1c\xce\x74\xad\x6f\xaf\x69\x44\x6b\x13\xc9\x30\xb7\x94\x55\x2c\xe9\xb5\x9c\xe4\x4d\x5c\xef\x8f\xb9\x71\xf2\x61\x0a\x37\x11\xaf\x3b\xd2\x24\x1c\x4c\x12\xe4\xd4\x64\xa3\x9e\x8a\x99\x5f\x9d\xad\x5e\x0e\x9b\x47\x21\x77\x08\xcd\xac\x4a\xe5\xa3\xe5\xd9\x57\xac\xf8\xd5\x2e\xa3\xe7\x88\x83\x0d\x02\x43\x6b\x7f\xb4\xd3\x61\xe6\x34\x59\x70\x9e\x65\xd7\x8e\x69\x9f\x69\xfd\xb2\xad\x11\x72\xa0\x0f\xcd\x23\x60\x98\xd5\xbd\x5d\xea\xe8\xf2\x6b\x99\x5d\x26\xa9\x35\xe8\x8f\x18\xda\xaa\x88\x53\x9a\x10\x8b\x8f\x20\x62\xdb\x07\xc3\x88\xee\x38\x62\x1c\xf8\x24\x4b\x72\x9b\x35\x1b\xe2\x0f\x70\xa0\xe1\xff\xd3\x4b\x6b\x72\xc6\xa4\xd2\xed\x62\xe0\x87\x7d\x9a\xbc\xdc\x1b\x23\x0f\xc3\x45\x4d\xd1\xb3\x5e\xd8\x21\x5c\x5b\x6e\xf2\xcd\x46\x61\x52\x48\x3b\x7b\x82\x9e\x70\xf8\x22\x5d\x7e\xc0\x0a\x82\xff\x60\x54\x6d\x8d\xba\x24\xce\x38\xbe\x7d\x51\xe6\xb6\x65\x25\x8f\x26\xde\xf4\x86\xf9\xf3\x5e\x30\x90\x98\xc4\x39\x5f\xff\x77\x86\x6b\xb6\xf6\xf6\xac\x17\xd4\xdd\x97\xaa\x6b\x05\x98\x3b\x52\x35\xe7\x55\xdd\x3a\x56\x72\x33\x66\x0d\x73\x54\xa2\xcd\x44\xc1\x9f\xe2\x4a\x54\xd7\x99\x0e\x9c\xed\x94\xf1\x1c\x24\x33\x70\x66\x52\x94\xb6\xc9\x33\xa5\xeb\x03\x16\xe6\x2c\xc5\xd4\x7d\xb6\x2c\x8b\xa5\xb5\xa4\xc3\x88\xfb\x6d\xb3\xde\x4e\x78\xa2\x6d\x5b\x2b\xda\xb4\x2a\x25\x56\xf1\x6c\x72\x83\x73\x14\xad\x24\x13\x68\x7f\x33\x47\xd1\xeb\x09\x4f\x54\xd1\x56\x34\x39\xcd\x31\x5c\x63\x7a\xc2\xba\x7a\x83\x1f\x7f\x12\xff\xbd\xdd\x30\x33\xa6\x2d\xca\x4b\xb6\xa3\x9b\x67\xe4\x98\x67\x8f\x91\x6e\x1b\xa9\x00\xab\x3d\xae\xe9\x16\x30\x6e\x5f\xbc\xbe\xbc\x7c\xc9\x48\xdd\x87\x68\xd7\x3b\xa8\xa3\xeb\xb9\x5e\xd3\x7f\xc7\x9c\x51\x7b\xbc\x7c\x74\xae\xa4\xa8\x53\x53\x42\x74\x30\x9a\xda\x4e\xe5\x18\xf5\x53\xb2\xbc\xbc\x43\x57\xcd\xd8\x76\xad\x38\xad\x54\x2e\x8e\xaf\x4b\xaf\x2c\x1f\xb9\x99\xf0\x85\x25\xb8\x5f\x34\x5d\xa7\x79\xc8\xee\xac\xf6\xb9\xd0\x41\xce\xc1\xb8\xb0\xcc\x00\x04\xdb\x40\x79\xac\xde\x1f\x46\xdb\xa3\x97\xee\x64\x7e\xc7\xad\xda\x31\xc2\x9a\xf1\x4c\x1c\xef\x18\x86\x85\xbc\x1f\xb0\x84\x77\x89\x67\x62\x08\x35\x60\x5b\xe1\xc4\x08\x97\xa5\x8f\x3c\x59\x70\x95\xed\x90\x63\x51\xb1\xaf\x69\xb2\x5b\x9b\x51\x70\xa4\x1b\x1b\xf6\x1f\x61\xfa\x4d\x76\xe4\x76\xc8\x33\xd0\xdc\xac\x5b\x99\x03\xa5\x47\xf9\xcc\xa8\xbd\x30\x66\x96\x66\xc6\x91\x1c\xff\x06\xff\x19\xef\x8f\x3a\x81\x97\x4a\x0a\xb6\x03\xfc\x52\x0a\x69\x86\x3c\x18\x4f\xce\xc9\xd5\xf5\x9d\xf5\xd8\x73\x02\x3a\xf9\x69\xf1\xc3\x9b\xd5\x08\x1e\xd9\x00\xf6\x17\xba\xa0\x05\x3c\xf1\xed\xbd\x8b\xe4\x70\x70\x7a\xfe\x14\xd4\xa9\x66\xce\x3b\xa9\xa6\x82\x09\x7c\x37\x08\x1e\x14\x79\xf7\x31\x53\xae\x44\x59\x09\x85\x05\x00\xb1\x3a\xd8\x1a\xe1\xf3\xe0\x27\x0b\x2c\x18\xb4\xc1\xe2\x4b\x0d\x66\xb2\x75\x78\xda\x3e\xd4\xd3\x0d\x56\x0f\xab\xda\x0e\x5f\x97\xa9\xea\xb8\xfd\xe4\xc4\x6b\xe8\x26\x8b\xc6\x5f\xb0\x94\x49\xc1\x96\xef\x33\x94\xf6\xdd\xe9\x41\xa5\x83\x0e\x76\x99\xde\x4e\xc0\xf4\x96\xe7\x28\xda\x4d\x73\x0e\x69\x3a\x1d\xf7\x2d\x53\x78\x3c\xcd\x43\x37\xa7\x45\xa3\x98\x5a\xa4\xf1\x64\x8c\x34\x55\xb8\xdd\xf3\x7d\xea\xfa\x21\xd4\x12\x65\x7d\x77\x68\x5b\xd3\xb8\xaa\xd1\xfe\x71\xdc\x2d\x1f\xad\xb2\xeb\x81\x93\x9c\x32\xde\xfd\x38\xb8\x1a\xc5\x89\x6e\x72\xea\x7f\xba\xab\xcc\x80\x5a\x29\x47\xeb\xfb\x90\xbe\xfd\x51\x7d\xbc\x79\x3e\x79\x3e\xf9\x1f\xf5\x0e\xf5\x1f\xd4\x1c\x00\x00")
 
 func yaoModelsJobExecutionModYaoBytes() ([]byte, error) {
 	return bindataRead(
@@ -1927,7 +1971,7 @@ func yaoModelsJobExecutionModYao() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "yao/models/job/execution.mod.yao", size: 7201, mode: os.FileMode(438), modTime: time.Unix(1768928216, 0)}
+	info := bindataFileInfo{name: "yao/models/job/execution.mod.yao", size: 7380, mode: os.FileMode(438), modTime: time.Unix(1768928216, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -1952,6 +1996,26 @@ func yaoModelsJobJobModYao() (*asset, error) {
 	return a, nil
 }
 
+var _yaoModelsJobLockModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9d\x54\xc1\x6a\x1b\x31\x10\xbd\xfb\x2b\x06\x9d\x5a\x70\x0d\x0d\x04\x5a\x53\x02\x81\xf4\x90\x12\xda\x4b\x7b\x2a\x25\x68\x57\x63\xaf\x12\xed\x68\x2b\x8d\x70\x96\xe0\x7f\xaf\x24\xaf\x15\x99\x6c\x43\xda\x3d\xac\xd0\x93\xde\x9b\x79\x9a\x91\x1e\x17\x00\x82\x64\x8f\x62\x0d\xc2\xd8\xf6\x5e\x2c\x13\x62\x64\x83\x26\x41\x5f\x6c\x03\x37\x05\x56\xe8\x5b\xa7\x07\xd6\x96\x8e\x8b\x89\x03\x2c\x1b\x83\xb0\xb1\x0e\xb8\x43\x30\x28\x3d\x7a\xf0\x9d\x74\xa8\xa0\x19\x33\xa8\xc9\xb3\xa4\x36\xe2\x76\x03\x12\x5a\x13\x3c\xa3\x3b\xc8\xb2\xdc\xfa\xa8\xf7\x53\xf8\x31\x82\xbd\xf8\x95\xd1\x26\x68\xc3\x3a\x05\x62\x17\x30\x43\x0e\xa5\xb2\x64\xc6\x1a\xf3\xd6\x71\x9c\x7f\x8c\xdf\x24\x16\x73\x89\xc0\x63\x9c\x54\xde\xee\x6c\x73\x5b\xfc\x45\xbc\xb5\x7d\x8f\xc4\xcf\x6d\x88\xb8\xbe\xcf\x4a\xad\x35\xa1\xa7\x9c\x59\xe6\x1c\x14\x2b\x4d\xad\x26\xb5\x14\x76\x1c\x32\x76\x7d\xf5\x84\x95\x53\xac\xc1\x2a\xf0\x65\x60\xfb\x4e\x53\xeb\x30\x21\x30\x38\xdd\x4b\x37\xc2\x3d\x8e\x22\xef\xde\x2f\xe7\xe3\xe6\xf1\x59\x64\xcf\x4e\xd3\x76\x26\x7a\xaa\x1f\x7c\x3d\xe1\x54\x49\xfc\x20\xfd\x3b\x20\x24\xcd\x54\x9a\x5c\xc0\x44\x78\x83\xab\xed\x0a\x7c\xdb\xa1\x0a\x06\x57\x9f\xb4\xba\x78\x5b\x89\x23\x6d\xb9\x8b\xfc\xb3\xf3\xf3\x02\x52\x30\x66\x3a\xfd\x8d\x34\x1e\xcb\x42\xc8\x31\xa6\xaa\xbd\x68\xcd\xee\x68\xea\x8a\xd7\x79\xfb\x76\xba\xbf\xf2\x75\x3d\x35\x1c\x74\xd6\xa8\x48\xae\x9c\x75\xd6\x73\x0a\xb8\x1e\xb4\x5a\x3b\x49\xca\xf6\x73\xd6\xde\x9f\x7d\x98\xb3\x76\xec\xbc\x8c\x6b\x52\xf8\xf0\x1a\x63\xac\x4b\xef\x55\xbe\x58\xf7\x18\xd3\xec\x87\x19\x6b\xdf\x4f\x18\x95\xb3\x1b\xc9\x91\x54\x4a\xa3\x60\xa3\x1d\x42\x92\x8a\xd7\x4a\xc6\x21\x5f\x3a\x49\xe5\xce\x89\xbf\xd9\x78\x31\x63\x7c\x18\xa2\xae\xbf\x95\xfc\x6f\x79\x7f\x3e\xf0\xe0\x92\xe7\xb3\x4f\xaf\x03\x20\xa9\x63\xb7\xb9\x40\x94\xea\x93\x4b\xbf\x04\x87\x84\xbb\x68\x61\xd7\x69\x53\x16\xc5\x7f\x14\x22\xfe\x0f\x0f\x89\x3d\x3e\x58\xd3\x93\x50\x92\xf7\x4f\xfb\xf7\x8b\xfd\xe2\x0f\x15\xb6\xd3\x4a\x0c\x05\x00\x00")
+
+func yaoModelsJobLockModYaoBytes() ([]byte, error) {
+	return bindataRead(
+		_yaoModelsJobLockModYao,
+		"yao/models/job/lock.mod.yao",
+	)
+}
+
+func yaoModelsJobLockModYao() (*asset, error) {
+	bytes, err := yaoModelsJobLockModYaoBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "yao/models/job/lock.mod.yao", size: 1292, mode: os.FileMode(438), modTime: time.Unix(1768928216, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _yaoModelsJobLogModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x58\x41\x6f\xe3\x36\x13\xbd\xe7\x57\x0c\x74\xca\x02\x1b\xac\xf7\xc3\xd7\xa2\xc9\xad\x48\xb6\x45\x8b\x2d\xb0\xd8\x6c\xb1\x87\x45\x10\x50\xd2\x48\x66\x42\x91\x0a\x67\x14\xc7\x28\xf2\xdf\x8b\x21\x65\x89\xb6\x65\xc7\x0e\xd2\x9b\xcc\xe1\x0c\xdf\x9b\x79\x1c\x92\xfe\xe7\x04\x20\xb3\xaa\xc1\xec\x02\x32\xe3\xea\xec\xbd\x0c\x18\x95\xa3\x91\x91\x3f\x5d\x0e\x9f\x57\xa3\x25\x52\xe1\x75\xcb\xda\xd9\x95\xcd\xb8\x1a\x58\xe5\x06\xa1\x72\x1e\xd8\xab\xe2\x5e\xdb\x1a\xee\x5c\x0e\xf8\x84\x45\x27\x73\x65\x12\x81\xb2\x25\xe0\x23\x5a\xa6\x18\x8c\x55\x4d\xd9\x05\xfc\xc8\x68\x49\x8c\x4d\x76\x13\x46\xf3\x4e\x1b\xd6\x12\x9e\x7d\x87\x61\xc8\xa3\x2a\x9d\x35\xcb\xec\x02\x2a\x65\x28\x0e\x92\xf3\x9c\x5d\xc0\xf9\xf9\xf9\x79\x1f\x2d\x37\x42\x41\xe8\x24\x84\xee\x5c\x7e\xbb\x22\x05\x90\x15\xae\x69\xd0\x72\x42\x2c\x82\xcf\x4e\x00\x9e\x43\x9c\xc2\x99\xae\xb1\x01\x58\x70\x89\xf1\x92\x88\xba\xec\x83\xc9\xa2\xcb\x36\x8c\xfd\x71\x35\x8e\x0d\x99\x4b\x07\x93\x75\x7f\xed\xd8\x9d\x69\x5b\x78\x94\x11\x68\xbd\x6e\x94\x5f\xc2\x3d\x2e\xb3\x30\xfb\xf9\xfd\xf4\xba\xc2\x64\x6a\x6d\x62\xaf\x6d\x3d\xb1\xbe\x10\xdc\x81\xe1\x2b\x56\xe8\xd1\x16\x08\xec\x80\xe7\x18\xca\xc5\x73\x4d\xa1\x9c\x39\x1a\x67\x6b\x02\x76\x49\x54\xb4\x35\xcf\xb3\x0b\xf8\xf9\xff\xc3\x98\xed\x8c\xe9\xb3\x3e\xd4\x25\x18\xb4\x2d\xf1\xa9\xaf\xe0\x5e\x4e\x06\x1f\xd1\x6c\x53\x42\xdb\x35\x13\x84\xa4\x5a\x9f\xd7\x3d\x12\x4e\x62\x0d\xf1\x40\xdb\x52\x17\x8a\x45\x86\x84\x8f\xe8\x35\x2f\x47\x0f\xb7\x52\xef\x8f\x7e\x24\xc8\x3a\xef\xea\xec\x3d\x7c\xf8\x00\x57\xf2\x09\xda\x56\xce\x37\x2a\x68\x57\x64\x5d\x4a\x5c\xd7\xca\x52\xa3\x97\x4c\x8a\x4e\xbf\xa3\x45\xaf\xcc\x9a\x9b\xca\x5d\xc7\xe3\x16\x18\xdd\x16\xca\xdb\x50\x30\xf1\xfc\x1e\x7f\x40\x83\x44\xaa\x46\x0a\xab\xb5\x8e\xd1\xb2\x96\x80\x44\x1d\xd2\xe8\x8b\xde\x3b\x1f\x3d\x3f\xc9\xe7\xba\x5f\xa5\xb4\xe9\x7c\x3a\xbf\x52\xac\x4c\x9c\xff\x9b\x7c\x42\x08\x40\xc0\x73\xc5\x40\xec\xda\x29\x80\x1e\xd9\x2f\x33\xf1\xf9\x2a\x5f\xa0\x98\xb1\x69\x39\x65\xd7\x4f\xbe\x19\xb2\x5a\x62\xa5\x3a\x13\xea\x10\xd3\xf2\x76\x1a\xe9\x29\x6e\xab\x84\xf1\x89\x27\x54\xf2\xd7\xe6\xfc\x0d\x8d\xf4\xf1\xa0\x70\x56\xd2\xbc\x1b\xea\x5e\x54\xc1\x3b\x5d\x7f\x85\xea\x8e\x9c\x9d\x40\x75\xb9\x39\x3f\xed\x08\x65\xa9\x25\xab\xca\x40\x1f\x16\x4a\xc5\x2a\x36\xd4\x39\x86\x3d\x89\x56\x8a\x32\x85\xf5\xc5\x04\x92\xeb\x7c\x31\x91\xbf\x9d\x8d\xe3\x7a\xc3\x21\x81\x1a\x4d\x50\xb8\xa6\x75\x56\xda\x97\x68\xd0\x95\x9d\xc1\xa8\xa9\x3a\xec\x04\xc6\x72\x05\x7c\xa2\x83\x7c\xfc\xdf\x2f\xbb\x78\x1c\xaf\x8e\x41\xbf\xc7\xf5\xc6\x4f\xc3\xd1\xb4\xa3\x43\xfe\x6d\xf5\x43\x87\xa0\x4b\xd9\x89\x95\x46\xdf\x57\x43\x53\x72\xac\xf9\xce\xc2\x69\x47\x58\x75\x26\x98\xc3\xce\x01\x2a\xd0\x2a\xaf\x1d\xbd\x3b\xb8\x7d\xbe\x8e\x3b\x31\xb6\xc7\x94\x75\x6d\x7a\xc2\x75\x4c\x86\x44\x94\x92\xb6\x73\x45\x08\x8b\x39\xda\xf1\x54\x58\x28\x1a\xcb\x3b\x55\xd6\xd9\xec\xed\xa8\xb5\xde\xd5\x1e\x89\xb6\xe9\x69\xcb\x58\xa3\x9f\xe0\xf7\x65\xcb\x27\xe1\xb8\x32\x42\x8b\xbe\x40\xcb\xd2\x01\x14\x07\x95\xb2\x6e\x10\x5c\x35\x32\x3d\x9d\x9d\x7d\x9c\xcd\xde\xbd\x6e\xb7\x95\x9d\x0f\x2d\xf2\x18\xe4\x57\x5b\x3e\x09\xf2\x95\x11\xb4\x85\x46\x1b\xa3\x09\x0b\x67\x4b\x1a\xda\x83\x6b\xb1\x9f\x71\xaa\x2b\x50\x6d\x6b\x74\x21\x68\x5f\x49\x20\x1c\x11\xb7\x85\x2b\x8f\x69\x19\xf1\x34\xba\x5c\x73\x4a\x15\x16\xcc\x12\x33\xa0\x0e\x4b\x9c\xc5\xc3\x5a\xae\x86\x13\x6a\xfa\xe9\x0d\xc5\x44\xac\x8a\xfb\x5b\xb9\x9a\x1e\x7e\x8a\x5c\x8b\x0f\x7c\x5b\xf7\x49\x1b\x61\xb0\x87\x98\x23\xa5\x0d\x32\xc7\x64\x7d\xe1\xfc\x3d\xfa\xe3\x9a\xd8\xf7\xe0\xb3\xab\x83\xf5\x56\x6d\x89\x55\xb8\xe9\x6d\xf6\xe7\xa8\xf6\xff\xba\x41\xb7\xde\x15\x48\x74\x1c\xb3\x2f\xd1\x69\x17\xb5\xd1\x1c\x72\x3f\x47\xf5\xb8\x5c\xa0\xae\xe7\x2c\x17\xd9\x29\x39\xbd\x65\xdb\x95\x7e\x41\xac\x9a\x89\xde\x3b\x61\x1a\x38\x7d\xdb\xb6\x25\x94\x06\xeb\xaa\xeb\xe2\xbe\xa6\x7b\xd0\xad\x6a\xea\x72\x66\xdd\xe2\xf4\xdd\xfe\x77\x06\xe1\x43\x27\x4f\x83\x63\x1a\xd8\xf5\x96\x4f\xba\x55\x7a\x23\xd8\xae\xc9\xd1\xc3\x42\xf3\x5c\xdb\xe1\xd9\x31\x1e\xa7\x52\x4b\xe7\x4b\x5c\x17\xc5\x4e\xae\x23\xad\x59\x24\x74\xd2\xdf\x48\x63\x16\x70\xef\x1b\xee\x49\x5e\x85\xb7\xf2\xa6\x9a\xa8\x59\xf2\x06\x1c\x9e\x5d\x69\x71\x6f\xa6\x68\x5e\xca\x9d\x88\x34\x23\x84\xe5\x03\x9b\xbb\xfe\x81\xfc\xd0\xa1\xd7\x48\x91\x1d\x96\x90\x2f\xc3\xa9\xb3\xbf\x10\x29\x48\xb3\xf9\xec\x99\x02\x18\x27\x1d\x0c\xae\xd2\x86\x43\xae\xe3\xf3\x3c\x5f\x06\xb8\xf2\x4a\x8f\x81\x0e\x02\x37\xde\xbf\xb6\x75\x93\xa2\x5c\xbf\xa7\x25\x2a\x3b\x18\xee\x4a\x19\x11\x6d\x2f\x22\x65\x47\xfd\x1c\x06\x38\x70\x7b\xa9\xe8\x7d\xba\x5f\x55\xf3\xed\xb4\xc6\x83\x4e\x12\x7b\x78\xd1\xfb\xf3\xe0\x05\x9c\xc9\xa9\xf1\x2a\xac\xd1\xff\x8c\x5a\x2c\x74\xa5\x8b\x54\xab\xd9\xc6\x9e\x1a\xde\xcd\xfd\x1f\x2c\x2d\xfa\x46\x13\xc5\xb1\xb1\xdd\x8c\x28\x68\xec\xa5\xcf\x27\xcf\x27\xff\x06\x00\x00\xff\xff\x64\x42\xce\xa8\x67\x12\x00\x00")
 
 func yaoModelsJobLogModYaoBytes() ([]byte, error) {
@@ -2032,6 +2096,26 @@ func yaoModelsMemberModYao() (*asset, error) {
 	return a, nil
 }
 
+var _yaoModelsMigrationModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9d\x55\x4d\x8f\xd3\x30\x10\xbd\xf7\x57\x8c\x72\x5a\xa4\xee\xa5\x4b\x57\x82\x5b\xc5\x22\xc1\x01\x0e\x20\xc1\x01\x21\xe4\xd8\xd3\xc6\xd4\x1f\xc1\x76\xb6\x8d\x56\xfd\xef\xd8\x6e\x92\xba\x89\x5b\xd8\xcd\x21\x51\xde\x7c\xbd\xe7\x19\xdb\x4f\x33\x80\x42\x11\x89\xc5\x5b\x28\x24\xdf\x18\xe2\xb8\x56\xc5\x3c\xc0\x82\x94\x28\x02\xfe\xe9\x1c\x67\x68\xa9\xe1\x75\x04\x52\x2b\x54\xdc\x3a\x6d\x5a\x70\xa4\x14\x38\x07\x57\x21\x90\xba\x16\x1c\x19\x0c\xa9\x61\xcd\x05\x5a\xd0\xeb\x68\x1e\x60\x0b\x8c\x1b\xa4\x21\xfc\x58\xc4\x91\x8d\xf5\xd9\x7f\x14\xb6\xb5\x0e\x65\xf1\x33\xa2\x65\xc3\x85\xe3\xa1\xac\x33\x0d\x46\xc8\x20\x61\x5a\x89\x36\xc5\xac\x36\xce\xff\xbf\xf1\x4f\x97\xcc\x13\xf2\xc0\x93\xff\xb9\xa8\xd7\x1b\xa8\x96\x12\x95\xbb\xa2\xaa\xf0\x8e\x87\x98\x93\x6a\xd1\x48\x15\x39\xc6\xe0\x63\xee\x24\x3b\x67\x5d\xda\x40\xa0\xad\x23\xf6\xf1\xe1\x84\x0d\xcb\x9b\x82\x09\x83\x55\xe3\xf4\x2d\x57\xd4\x60\x40\xa0\x36\x5c\x12\x4f\x63\x8b\x6d\x11\xbd\x0f\xf3\x7c\xdd\x47\x34\xf6\xa4\x29\x29\x6e\x9d\xe1\x6a\x93\x21\xf0\x6d\x1c\x91\xb0\xe8\x6c\x93\x86\xc1\x4d\xeb\x1f\x29\x19\xab\x2a\x29\xad\x3d\xb6\xbb\x36\xb8\xe6\xfb\xde\x39\xb4\x1a\x02\xad\x57\x49\x51\x54\x1b\x57\xf9\xcc\x77\x8b\x01\x53\x8d\x10\x5d\x87\xd6\x44\x58\x1c\x0c\x8d\xe2\x7f\x1a\xec\x3a\x7b\x55\x74\xfc\xfe\xbf\xe2\xcf\x67\xee\x89\xdc\x60\x98\x68\xcd\xb0\x5f\x2c\x97\x17\xe9\x5f\x25\x4a\x2b\xa4\x5b\xdb\xc8\x67\x90\x7d\x37\x09\x49\x08\x7f\xfd\xb0\xba\x5d\x2c\xef\xa1\xcf\x3b\x6d\x54\xec\xc2\xae\x42\x05\xdc\xc1\x8e\xd8\x7e\x4f\x66\x54\xdd\xbf\x7e\x99\x28\xa6\x77\x99\x79\xfb\x6d\xcf\x96\xae\x97\xf3\x70\xe6\x9c\x48\xf9\xa2\x85\x28\x09\xdd\x82\x75\xc4\xc5\xa1\xf7\x53\x15\x78\x00\x1f\x6b\xa2\x44\x81\xd2\x0e\x4a\x04\xe3\xa3\xfc\x01\x13\x02\x8b\x1c\xfb\x7f\x8e\x8e\x3f\xce\xbc\x0f\x75\xfc\x31\x33\x41\xa5\xd6\x02\x49\x56\x46\x2e\x2c\x51\xf3\xbd\x42\xcf\xd9\x8c\x88\x33\xa3\x6b\x0b\xdd\xd9\x01\xda\xf8\xed\x61\x8c\xde\x59\x08\x05\xed\x29\x11\xc3\x35\x69\x84\x9b\xec\x88\xe7\xb5\xa5\x19\xcf\x6f\x2f\x8b\x2b\x87\x1b\x34\x39\x59\x93\x98\x44\xd3\xfb\x3d\xd2\x26\xea\x70\xdc\xef\x13\xae\xbc\x32\x21\xb8\x45\xaa\x15\xb3\x2f\x5b\xfe\x6e\x1a\x7f\x11\x37\xa5\x19\xaa\xf8\x61\x90\x75\x86\xe8\xaa\xbb\x59\x56\xee\xd2\xf2\xab\xd1\xda\x67\x67\x7f\x44\x75\xc0\xb9\x62\xb8\x3f\xe3\xef\xdf\xc7\x4b\x48\xf7\x57\x5f\x77\x9d\x0c\x2c\xed\xc9\xff\x30\x3b\xcc\xfe\x02\xd6\x66\x43\xcd\x5c\x07\x00\x00")
+
+func yaoModelsMigrationModYaoBytes() ([]byte, error) {
+	return bindataRead(
+		_yaoModelsMigrationModYao,
+		"yao/models/migration.mod.yao",
+	)
+}
+
+func yaoModelsMigrationModYao() (*asset, error) {
+	bytes, err := yaoModelsMigrationModYaoBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "yao/models/migration.mod.yao", size: 1884, mode: os.FileMode(438), modTime: time.Unix(1768928215, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _yaoModelsRoleModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x59\xcd\x6e\x1b\x37\x10\xbe\xfb\x29\x06\x7b\x72\x01\x23\x71\x0f\x45\x01\x03\x39\xb8\x71\x8b\xba\xa8\x13\xc3\x41\xd0\x43\x11\x2c\x46\xe4\x48\xcb\x84\x3f\x0a\xc9\x55\xb4\x08\xfc\xee\x05\x49\xad\xc4\x5d\x51\x96\xa5\xd4\xca\xc5\x08\xb9\x43\x7e\xdf\xfc\x0f\xf5\xfd\x0c\xa0\xd2\xa8\xa8\xba\x82\xea\xc1\x48\xaa\x2e\xc2\x8a\xc4\x09\xc9\xe1\x12\x27\xc7\xac\x98\x7b\x61\x74\xbf\x01\xa8\x39\xcc\xc9\x2a\xe1\x9c\x30\x1a\x14\x6a\x9c\x91\x22\xed\x93\x88\xc7\x99\xab\xae\xe0\xdf\xca\xc6\x53\xa0\xda\x7c\x1b\xfe\x87\xad\x6f\xc2\x5f\x3b\x41\x56\x7d\x5a\x49\x4c\x64\x80\x12\x60\x65\xc0\x6c\x8f\x02\xa0\x62\x46\xc5\x1b\xf6\x63\x38\x03\x78\x8c\xa7\x32\x23\x5b\xa5\x23\x94\x78\xc6\xeb\xd7\xf0\xe6\x7f\xfc\xd7\x9f\xf9\x1b\x3a\xc1\xe0\x0f\x41\x92\xbb\x17\xbb\x27\x69\x26\xd3\x8d\xe0\x2b\xcd\x04\xf5\x75\xf3\xb8\x76\x7b\xb3\x59\x5b\x9b\x32\x5f\xcc\x94\x78\x6f\x85\x42\xdb\xc1\x17\xea\x40\x70\xd2\x5e\x4c\x05\xd9\xcd\xa7\xf3\xb4\x5f\x5d\x81\xb7\x2d\xc5\xd5\xc7\x8b\x32\x94\x60\xa6\xba\x84\xc7\x79\x2b\xf4\xac\x80\x29\x5a\x70\x07\xb0\x8f\x5a\x7c\x6d\x09\xc2\xa1\x19\x30\x38\x47\xae\x84\xbe\x80\xd6\x91\xbd\x00\x65\x38\x59\xf4\xc6\x5e\x00\x79\xf6\xea\xa7\xec\x0e\xd2\x33\xdf\x54\x57\xf0\xcb\xe5\x7a\xad\x8d\x47\xae\xa8\xac\x57\x85\xe6\xb4\x1c\x2f\xea\x56\xca\x95\x33\x4e\x51\xba\xa7\x89\xc7\xbf\xcf\x67\xfd\x6e\xf0\x79\x46\xf9\x46\xb8\xb9\xc4\x0e\xc2\x79\x60\xa6\xe0\x9b\xc4\xbf\xc0\xea\xe7\xcb\xcb\xe3\xb0\xe6\x71\xbc\x05\xd9\xd3\xd2\x17\x00\xdf\x94\x64\x72\xdc\xe4\x51\x48\xe2\x90\x1d\x9e\xe3\x8f\x41\x2a\xbc\x83\x79\x6b\xe7\xc6\x65\x7c\x32\xe8\xb9\x7b\xbd\x64\x98\xde\x6f\x92\xc5\xa9\x63\x75\x93\xa7\xdc\xb6\xee\x3f\xbb\x5c\xbb\x6b\xdd\xdf\x97\x64\x32\xdd\xff\xf5\xe1\xfd\x3b\x30\x93\xcf\xc4\x3c\x30\xa3\x3d\x0a\x2d\xf4\x0c\x50\xca\x2c\x2d\xba\x68\x01\x64\x8c\x9c\x03\x2b\x66\x8d\x77\x7b\x6d\x50\x0e\x71\x0a\x5e\xcd\x3c\xf1\xfa\x18\x32\x0f\x6b\x71\x78\x16\x2f\xb4\x16\xbb\xe0\x49\xb4\x9c\x4b\xc1\x84\x97\x1d\x70\xd2\x82\xf2\x9c\xbf\x9f\xca\x4b\xba\xd3\x9f\x82\x2c\x5a\xd6\x74\xa7\xf7\x26\xb4\xa4\x7d\x7d\x78\xd6\xbd\x8f\x82\xf0\x54\xf2\x5d\x7d\x12\x83\x77\x6a\x2c\x08\xdd\x90\x15\x1e\x35\x23\x38\x37\x31\xc0\x51\xee\xc9\xb6\x23\x63\x14\xf3\xed\x93\xce\x26\x69\x41\x72\x9b\x97\xd0\x9e\x66\x79\x89\x5a\x13\xfb\x7b\x28\x30\x6e\x15\x9a\xb5\xa9\xe2\xc9\x70\xde\x88\x59\x43\x16\xde\x80\x32\x96\x72\x8f\xca\x98\x71\x9a\x62\x2b\xc3\x21\xc7\x31\x7b\x49\xdf\xbb\x5b\x37\x3b\xa7\x6f\x3b\x5c\x8d\xcc\x8b\x45\xa1\xee\x4d\x8c\x91\x84\xa5\xf0\xbf\x75\x70\x3d\x12\xca\x6c\xf4\x4f\x43\x3e\x98\xc3\x37\xc2\xad\xca\xbe\x03\xd6\xda\xe0\x89\xb2\x83\xf1\x75\x1b\xc3\x1c\xe7\x5d\xc2\xd5\xfd\x11\x87\x51\xb8\x19\x4b\xed\xe2\x20\x5c\xac\x80\xab\x5b\x36\xc1\xa4\xe9\x5b\x6c\x5f\x5c\x89\x4c\xac\xe0\x47\xb1\x71\x9d\xf3\xa4\x0e\x24\xf3\x61\x24\xf4\x04\x17\x84\x74\x43\x22\x72\xce\x50\x6b\xe3\x61\x12\x08\x4a\xf2\xc4\x8b\x41\x73\x24\x1d\x67\xac\xaf\x8d\xe5\x79\x98\xef\x8f\xff\x0f\xc6\x7a\x78\x3f\x94\x2a\xb4\x57\xf1\xdc\x68\x89\x70\xcd\x20\x47\x3e\x23\xda\x4f\x11\xd8\x3d\xd2\x53\x47\x35\x33\xd2\x14\x34\xbe\xb3\x92\xbc\x1d\x7e\x9f\xe9\x3a\xee\x00\x33\x3c\xb9\xfc\xc7\x5b\xe0\x2b\x4e\xe7\x0d\x2d\xc3\x9a\x42\x5f\x2a\x20\xbf\x1e\xd5\x97\x08\x56\x6a\x67\x77\xe2\xbe\x65\x3b\x3a\xd9\xb0\x91\x4f\x1b\x43\xec\x07\xd5\xbb\x53\xf8\xc9\x75\xea\xe8\xde\x1a\xed\xad\x91\x27\x77\x17\x85\xcb\x7a\x94\xc8\xf6\x07\xe9\x1d\x2e\xe1\xe3\x50\x28\x33\xc0\x1d\x2e\x85\x6a\x15\xe8\x56\x4d\xc8\x86\xd6\x2f\x5e\x00\xbe\x41\x0f\x0c\x35\x34\xb8\xa0\xac\x44\x9c\x5f\xc2\x1b\x68\xb5\x14\x4a\xec\x4a\x41\x7b\x2d\x54\xe4\x66\xe9\x6b\x2b\x2c\xb9\x1a\xe7\x73\x6b\x16\x58\x68\x44\x76\x27\xd6\x87\x95\x30\x5c\x6f\x09\x17\x12\x2c\x3a\x27\x66\xb1\x6b\xdf\xf0\xea\xaf\x87\x38\xee\xc2\x36\x88\x51\x8a\x7d\x92\x0b\xb6\xde\xd4\x96\x16\xe6\x0b\xd5\x1c\xbb\x83\xcc\x75\xdd\x7a\x03\x0f\x51\x16\x6e\x06\xb2\x79\x66\xc5\xce\x01\x4e\x3d\x59\xf8\xd6\x08\xd6\x0c\x6b\x78\xb8\x5e\xa1\x17\x0c\xa5\xec\x20\xe1\xe0\xc9\x72\x9a\x16\x64\x8f\xb3\xda\x4b\xc6\xd5\xef\x4b\x4f\x9a\x13\x0f\x91\x35\x15\xb3\xd6\x62\xe8\x78\x4f\x18\x58\xe4\x91\xa3\xc7\x67\x0f\x56\x77\x5b\x02\x99\x75\xae\x39\x17\xa9\x63\x4f\x26\x61\x39\xa9\x38\x19\x3a\xf2\xa1\x0e\x1e\x39\x14\x32\xa3\xd3\x05\xcf\x1f\x04\xdf\x16\x44\x06\xd5\xa3\xdf\x4e\x81\xaf\x5a\x17\x3b\x0d\x45\x1e\xbc\x59\x05\xcc\x6b\x85\x22\xce\xbb\x1b\x6f\xdb\x83\xff\x0c\x20\xbd\x33\xc6\x3e\x84\x36\x2f\x82\x85\x57\xb5\x65\x1a\xac\xc6\x5d\x67\xf6\x96\x98\xf7\xc0\x83\x8e\xe5\x53\x21\xbc\x42\xdf\x53\x2c\x38\x61\x27\x96\x99\x74\x54\xa4\xe1\xe0\x9b\xf0\x4d\xea\x53\x42\x01\x7b\xba\xf4\xf5\x50\xd7\xe3\x4d\x19\xed\x78\x5c\xec\xe7\xab\xe3\xd0\xda\xe1\x40\xf5\xb5\x25\x2b\xc8\x3d\x13\xe9\xb8\x53\x1d\x29\xb5\xdf\xce\xa7\x8c\xe3\x50\xe6\x0d\xeb\x61\x10\xb7\x46\x83\x11\xc6\xf5\xfe\x8f\x83\x9c\x0a\xcd\x43\xf2\xef\xe7\x84\xcc\x11\xaa\x91\xdf\x5a\x92\x98\xa2\x66\xfd\x46\xae\x28\x14\xca\xcd\x42\x76\x79\x83\xee\x0e\x75\xe6\x0e\xca\xf0\x14\x7e\x75\xdd\xa1\x79\x95\x44\x37\xdb\x5f\xa8\x2b\x3e\xe3\x4e\x8d\x25\x31\xd3\xf9\x5e\xae\xc4\x2a\xb5\x00\x47\x00\x08\x82\x3f\x70\xfd\xfa\x81\x7f\x81\xb2\x4d\xd1\x9c\xd4\x64\xfa\x9f\x29\xbe\x43\xe5\x85\x22\xe7\x51\xcd\x5d\x3f\x29\x86\x58\x9d\xfa\x3a\x4d\x2d\xd9\x6a\xf6\xeb\x44\x5a\x83\xc7\xb3\xc7\xb3\xff\x02\x00\x00\xff\xff\x48\xf4\x60\x22\x22\x19\x00\x00")
 
 func yaoModelsRoleModYaoBytes() ([]byte, error) {
@@ -2052,6 +2136,26 @@ func yaoModelsRoleModYao() (*asset, error) {
 	return a, nil
 }
 
+var _yaoModelsSeedModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xad\x55\x4d\x6b\x1b\x31\x10\xbd\xfb\x57\x88\x3d\xb5\xe0\xa4\x10\xea\x40\x43\x08\x98\xf6\xd0\x1c\xda\x43\x53\xe8\xa1\x94\x32\xbb\x3b\xde\x15\xd1\xc7\x22\xcd\xe2\x2e\xc1\xff\xbd\x23\x79\x77\x23\xc7\x8a\x8b\xa1\x3e\x2c\xe8\x69\xf4\xe6\xbd\x19\x69\xfc\xb4\x10\xa2\x30\xa0\xb1\xb8\x11\x85\x47\xac\x8b\x65\x40\x14\x94\xa8\x02\xf4\x30\x43\x35\xfa\xca\xc9\x8e\xa4\x35\xd3\x86\x68\xa5\x27\xeb\x06\x41\x50\x2a\x5c\x0a\x6a\x51\x40\xd7\x29\xc9\x5b\x92\x50\x7b\x61\x37\x11\x0c\xc4\xfc\xa1\x03\xc0\x8b\x5a\x3a\xac\x02\xc1\x3e\x03\x41\xe3\x99\xfa\x67\xe1\x07\xcf\xa7\x8b\x5f\x11\x2d\x7b\xa9\x48\x86\x9c\xe4\x7a\x8c\x90\x43\xa8\xad\x51\x43\x8a\x79\xeb\x88\xd7\x1f\xf8\x37\x92\xb1\x24\x06\x9e\x78\x91\xb3\xc8\x58\x65\xb5\x46\x43\x79\x37\x05\xc7\xec\x22\x53\x65\x55\xaf\x4d\x54\x16\xcf\xed\x19\x13\x4e\x39\x31\x86\xb4\x43\x17\xb1\xfb\x4f\xcf\xd8\x5c\xcc\x14\x4c\x92\xaf\x7b\xb2\x17\xd2\x54\x0e\x03\x22\x3a\x27\x35\xb0\x8c\x47\x1c\x8a\x18\xbd\x5b\xe6\xf3\x26\x5e\x92\xcc\x9e\x9c\x34\x4d\x26\x7b\xf4\xf8\x80\x94\xd5\xf0\x95\x39\x5f\x76\x4b\xd4\x58\x29\x08\x6c\x11\x0e\x1d\x15\x6f\x62\xe7\xde\xdd\x06\x0d\x77\x97\x61\x71\x39\x80\x7d\x9b\xa4\x43\xd3\x50\xcb\x94\xd7\xef\x67\xcc\xf4\x4a\x8d\xed\xd8\x80\xf2\x38\x6f\x48\x53\xe3\x9f\xb1\x8b\x27\xad\x86\xdc\x67\x58\xbd\x3f\x08\x7f\xc5\x66\xe0\xdc\xdf\xd9\x8d\x54\x28\x4c\xdc\x72\xa2\x41\x83\x0e\x08\x6f\x6e\xb5\xad\x51\xdd\x89\x72\xe0\x42\x6c\xa0\x57\x94\x71\x79\xb5\x5a\xbd\x6a\xf3\xa4\xa3\x48\x7e\x86\xa5\x2f\x87\xf1\x89\xa7\xef\xec\x20\xb2\xc5\xc6\x71\xeb\x58\xf0\xe4\xef\x7f\x2a\xae\x5a\xac\x1e\x7d\x7f\x4e\x1f\x3e\x1e\x1d\x49\xdf\xdc\xe7\xf5\xc5\xd5\xea\x5a\x4c\xbc\x69\x5f\x04\x98\x30\x42\x78\x44\x00\xc1\xbe\x3f\xdb\x16\x0d\x43\x62\x0b\x7e\x1a\x32\xe7\xdd\xba\x93\xe6\x9c\xdd\xfa\x63\x63\xd2\x10\x36\xe8\x32\xce\xbe\x1d\xc4\xa7\x37\xac\xd7\x25\xba\xc9\x4b\xa0\x3d\x56\x9b\x28\xfb\xe7\xcd\x1f\x0f\xff\x06\x3a\x96\x47\x52\xa3\x27\xd0\x5d\x46\xe0\x7a\x9c\xc3\xeb\xfc\x7b\xff\x11\xaa\x39\x57\x3b\x5b\xd3\x17\x2a\x4f\x3c\x5a\xfe\xee\x67\xb5\x9d\xfe\x1e\xc6\xa9\x3b\x0b\xf4\xcf\xf1\xbb\xc5\x6e\xf1\x17\x2d\x5d\xfe\xe8\x76\x06\x00\x00")
+
+func yaoModelsSeedModYaoBytes() ([]byte, error) {
+	return bindataRead(
+		_yaoModelsSeedModYao,
+		"yao/models/seed.mod.yao",
+	)
+}
+
+func yaoModelsSeedModYao() (*asset, error) {
+	bytes, err := yaoModelsSeedModYaoBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "yao/models/seed.mod.yao", size: 1654, mode: os.FileMode(438), modTime: time.Unix(1768928215, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _yaoModelsTeamModYao = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x5b\x5b\x6f\x1b\x37\x16\x7e\xcf\xaf\x20\xf4\x10\x38\x80\x13\x37\xdd\x4d\x81\x0d\xb0\x0f\xb2\x2c\x27\x8a\x1d\x49\xd0\xc5\x45\x53\x14\x02\x35\x73\x34\xe2\x96\x43\x4e\x48\x8e\x6d\xa5\xc8\x7f\x5f\xf0\x36\xc3\xb9\x48\x91\xd4\x58\x69\x1f\x52\x6b\x86\xe7\xf0\x3b\x3c\x57\x92\x67\xfe\x7a\x86\x50\x87\xe1\x14\x3a\x6f\x51\x67\x06\x38\xed\x9c\xeb\x27\x14\x2f\x81\x56\x1f\xc5\x20\x23\x41\x32\x45\x38\xf3\x2f\x90\x54\x22\x8f\x54\x2e\x00\x61\x16\x23\xc2\x56\x5c\xa4\x58\x8f\x40\x52\x71\x81\x13\xb0\xa4\x0a\x27\xb2\xf3\x16\xfd\xde\x51\x86\x1b\xea\x14\x74\xfa\xc7\x32\x97\x84\x81\x94\xfa\xef\x7b\x10\x64\x45\x22\xc3\xa3\xf3\x87\x23\x5e\x52\x8d\x4e\x23\x0d\xb0\x2a\x0f\x0c\xa1\x4e\xc4\xd3\x14\x98\x3a\x00\xd6\x33\x84\xbe\x1a\xee\x11\xa7\x79\xca\x0c\x3a\xc3\xeb\xe2\x02\xfd\xf7\x3b\xfe\xe7\x79\x5e\x62\x49\x22\x74\x4d\x80\xc6\xf2\xc9\xe6\xb1\x2b\x14\xac\x11\x89\xdd\x0a\xe9\x65\xdc\x64\xe6\xd9\xe0\xaa\x7c\x56\x68\x39\x7c\x18\x2c\xe6\x58\x90\x14\x8b\x0d\xfa\x13\x36\x88\xc4\xc0\x14\x59\x11\x10\xe5\xd0\xcc\xbe\xef\xbc\x45\x4a\xe4\x60\x9e\x7e\x3d\x6f\x87\xa2\xd5\xb5\x68\xc3\x23\x95\x20\x2c\x69\xc1\x64\x34\xb9\x05\xd8\x3b\xca\x97\x98\xa2\x9c\x91\xcf\x39\x20\xcd\xbb\x15\x1f\x05\x96\xa8\x75\xe7\x2d\xfa\xf9\xcd\x9b\xe2\xa1\x25\x72\x98\x8b\xa7\x84\xc5\xf0\x58\x13\xe4\x29\x0d\xc2\x48\x67\xad\x62\x50\xda\xe7\xe9\x4c\xc3\xfc\xff\x40\x65\x0c\x2b\x34\x81\x3a\x46\xab\x15\x89\x08\xa6\x56\x13\x55\xd6\xa5\x0e\x7e\xfa\xa9\x78\xc8\x72\x4a\x9d\x5b\xaf\x30\x95\xbb\x4d\x27\x26\x32\xa3\x78\xb3\x38\x10\xf2\x95\x25\xdb\x8e\xda\xc8\xe4\x98\x1b\xd0\x88\x0b\xb4\x14\x3a\x64\x1c\x26\xc2\x37\x8d\x3f\x0c\x9d\x0d\x01\x14\x3c\xaa\x36\xf8\x6d\x34\x0d\xf4\x6d\x83\x0e\x41\xf6\x00\x4b\x49\xd4\x21\xcb\xfa\x6b\x9d\xa2\x8e\xc9\xb1\x44\xf3\xc9\x6d\xcb\x12\xbe\x39\x72\x09\x29\x4f\xf8\x01\x28\x6f\x2b\xc3\xeb\x10\x35\xb3\xe3\xf1\x3d\x79\x58\x18\x3d\x30\x10\x72\x4d\xb2\xd3\x85\x03\xae\xa7\x3c\x2c\x3e\x1b\x94\xdb\x02\xb4\x91\xc3\x30\x45\xb9\x04\x11\x44\x67\x74\x26\x60\x05\x02\x58\x04\xd2\xbc\x7b\xa5\xff\x59\x90\xf8\xc5\x37\xe2\x76\x3d\x66\xfc\xb0\xd0\xdd\xe3\x4c\xe1\x48\x85\x71\x1b\x9d\x8d\x8c\x13\x62\xfa\xe2\x74\x3a\x8b\x2c\x8e\x05\xa4\x98\xd0\x03\x14\xe7\xf1\xf7\xab\x74\x75\xed\x39\xf6\xc8\xb0\x47\x38\x8e\x85\xa9\xd1\xf6\xd5\xd1\x37\xb3\xeb\x4e\x99\xb2\x35\x67\x87\x44\x25\x2f\xd3\xb8\x4a\xb7\x4d\x26\xc3\x1e\xb1\x3c\x5d\xb6\x96\x0b\x6f\xb6\xc6\x80\x1f\x5b\x2f\xdc\x05\xd5\xf1\x09\xab\x48\xb9\xb0\x65\x39\xb4\x84\x87\x25\xe7\x14\x30\x6b\xab\x29\xa5\xc3\x1b\x92\x05\x0a\xf9\x75\x0d\x6a\x0d\xc2\x55\x6f\x12\x35\xe7\x88\x61\x85\x73\xaa\xf6\x72\xf8\x56\xe4\x9e\xe5\x02\xab\x96\xbc\x4b\x52\x90\x0a\xa7\x59\x0b\x76\x0f\x1c\x75\xd5\x36\xec\xcc\x02\x7f\xc0\x6d\xc8\xff\xb6\x1f\x14\xc8\x97\x9b\x03\xbc\xa0\x80\x7d\xb9\xd9\x02\x9b\x17\x60\x91\x5a\x13\x89\x82\x5d\xd4\x9e\x3e\x7d\x32\x63\x9f\xdb\xe2\xbe\xc7\x63\x40\xcf\xd1\x6c\x93\xc1\xe9\x4c\xde\xec\x56\x22\x1e\x1f\x5c\x22\xf7\x2a\x34\xc1\xd2\xcf\x5b\xb6\x2a\xd6\x91\x51\x75\x9e\x42\x07\xaf\xb7\x57\x22\x47\xec\x65\x76\x8b\xb9\x30\xd2\x35\x64\x05\x96\xa7\xbb\x24\x35\x5a\x69\x8f\xb6\x9b\x0c\x10\x5f\xed\x21\x2f\xf7\x27\x0a\xbf\xbb\x27\x4f\xa3\x62\xc7\xd7\x6d\x1c\xe7\x8c\xdc\x83\x90\x98\xa2\xa9\xc2\x2c\xc6\x22\x96\xe8\xac\x9b\x65\x54\x9b\xda\x80\x29\x10\x0c\xdb\x7c\x8e\x7a\x3c\xcd\xb0\x22\x4b\x0a\x2f\x9e\x1c\x60\xe7\x6a\x3e\x9c\x76\xce\x35\xff\xab\x9c\xa1\xe7\xe8\x52\xe0\x58\x2a\x01\xa0\x02\xc8\x43\x93\xb6\x08\x4b\xd0\x74\x23\x15\xa4\x1e\xf9\x04\x3e\xe7\x44\x40\x5c\xe2\xec\xdc\xf6\x07\x96\xdd\x2d\x24\x98\xa2\x3e\x53\x44\x6d\xd0\x20\x28\xc6\xdc\x82\x5c\x13\x86\x99\xd9\xc2\xf9\x05\x09\xb8\xbc\x9b\xbe\xb6\x5c\xdc\xe0\x72\xcd\x46\x0c\xec\x02\xb1\x0d\x1a\x0b\x58\x91\xc7\x67\xa7\xd0\xe2\x90\x0b\xb5\x46\xdd\x14\x04\x89\xf0\xd3\x6b\xa5\x3f\x18\x5a\xf9\xe7\x53\xd4\x4f\x33\xca\x37\xba\xf0\xad\x1a\xb5\x55\x4a\x49\x33\x9d\x96\x34\x53\x6e\x57\x16\xa2\x5c\xe8\xf5\xb7\x63\xd1\xd9\x8a\x0b\x24\x39\x05\x94\x09\x9e\x09\x02\x8a\x0b\x19\x2c\xfb\x60\x16\xcc\x3b\x60\x31\xb9\x27\x71\x8e\x29\x9a\xe1\xc7\x0c\xef\x01\xe1\xd2\x51\xf7\x30\xc3\x31\x46\x97\xee\x98\xad\x31\x6e\xd8\x1d\xf4\x9c\xd5\x55\x16\x96\xe9\x49\x73\xa9\xc4\x06\xf5\x28\x96\xb2\x9c\xc9\xda\x5d\x20\xec\xa0\x67\xe9\xbd\x65\x78\x4a\x2d\x76\x95\xf6\x24\xf6\xd1\xcf\x05\xcf\x00\x33\xed\x34\x5c\xfb\x91\x7d\xf0\xf4\x96\x72\xd7\x9d\xd9\x85\xb8\xc3\x34\x07\xd4\x8d\x63\x88\xb5\xbe\x0a\x95\xf7\xe7\x2f\x1f\x48\x1c\x84\x92\x4e\x7f\x34\x71\x3e\xda\x8f\x38\xe3\x29\x89\xd0\x28\x03\x81\xb5\x31\xa0\x09\x24\x44\x2a\x61\x57\x1d\xb3\xb8\xae\xf2\xb3\xfe\xfc\x45\xa8\xc8\x5e\xdf\xb1\xf2\x0b\x30\x55\x58\x11\xa9\x48\xd4\x50\x84\x8e\xce\xc5\x8c\xdd\x48\x91\x7b\xa2\x08\xc8\x92\x5b\x6f\xe2\xed\xc7\x39\x78\x05\x8c\x17\x68\x7e\x13\x00\x98\xcf\x26\xce\x60\x6d\xb6\x2b\x2c\x75\xe2\xb7\x7c\x35\x82\xe9\x60\xd2\x77\xb3\x5c\xeb\xf7\xeb\xd2\x4a\xeb\x92\xfe\x07\xc5\x24\x21\x4a\xd6\xa8\x67\x15\xea\xbe\x54\x78\x49\x89\x5c\xeb\x3c\xd4\x60\xf1\xfa\xdf\x4d\x1e\x37\x77\x37\xce\xf4\x4d\x35\x4a\x31\x8b\x25\xea\xad\xb1\x91\x8e\xaf\xb4\xf0\x29\x88\x08\x1a\x5e\xf3\x7e\x72\xe9\x82\x22\x88\x14\x33\x3f\x50\x5b\xbc\x5d\x28\x10\x0d\x9a\xb9\xde\x2d\x6b\x9a\xae\x75\x0e\x76\x31\x7d\x20\x52\xa2\xbb\xee\xec\x5b\xce\x3c\x9a\xbc\x5b\x0c\x27\x85\x97\x3e\x40\x42\x30\x43\x23\x91\x60\x46\xbe\xb4\x93\xf4\xee\xdc\xf8\x2b\xcc\x88\x5c\xa3\x1e\x30\x25\x30\x2d\x97\xd8\xe3\x2c\x49\x7e\x5b\xcc\xe6\xc3\xe1\xdc\x45\x83\x6b\xc2\x0c\x61\xa9\x93\xab\x3a\xa0\xc5\xd4\x59\xdc\xf4\x01\x62\x3d\x76\x37\xa2\xc1\xb5\x1b\x9d\x61\xc2\x8c\x63\xd4\xc4\xd6\x55\x45\x60\xd0\x7e\xfc\x98\x0b\x95\x27\x98\x5e\x6c\x25\xac\x4f\x35\xe9\xbf\x1b\x0d\x3d\xb1\xd6\x2a\x1a\xfa\x8c\x5e\x93\x5f\x6c\xc2\x09\xc7\x15\x9a\x3d\x26\x1a\x0c\xdd\x34\x93\x5c\x4a\x82\xf7\x21\x19\xbd\x9b\x54\x69\xfc\x39\xbb\xf6\x56\x68\xf3\xb3\xc0\x5e\xc7\xe3\xc6\x74\x15\x82\x09\x60\xe9\x17\xf2\x14\xb1\xb6\x6b\x04\xc0\x91\x16\xf7\xe9\x03\x6c\xd7\x27\x35\xe3\x41\x98\x6a\x27\xd8\x9a\xd8\xba\xbd\xe6\x60\x1f\xcc\x1a\x49\xf0\x93\xe7\x3c\x84\x07\xf4\x09\xb0\xd1\xff\x56\xd6\x97\x5e\x83\x53\xc2\x12\x9c\x71\x01\x0d\xb3\x6a\xd7\xdf\xbc\xdf\x20\x74\xc1\xd2\x15\x67\x4d\x21\x26\xdd\x3a\x45\x37\x8a\x78\xce\x94\x2e\x01\x35\xca\x1e\x17\x19\x17\xce\x74\x72\xaa\x53\xc7\x06\x75\x73\xb5\xe6\xba\xdc\x08\xeb\xb8\xd9\xc2\x97\x14\xba\x9e\xc0\xe8\x1d\xe7\xb1\x34\x3c\xa6\x20\xee\x49\x04\x32\xc8\x56\x25\xe1\xb8\x5b\xa1\x1a\x9b\x88\xa7\x23\xac\x03\xd2\xe2\xe8\x15\x82\x12\xa0\xf5\x8c\x16\x31\x67\xd5\x29\x34\x8a\x2b\x88\xf3\xc8\x2c\xe2\xb6\x69\xde\xdf\x5c\xba\x10\xf7\x9e\xb3\x04\xdd\xe8\x7f\xf6\x53\x43\x6f\xb2\x18\x8e\xea\xa4\x3b\x12\x5d\xa0\xf9\xf9\x74\x30\xec\x4f\xa7\x8b\xde\xe8\xaa\xbf\xf8\xe0\xbc\xf1\x03\xce\x8c\x71\x79\x39\x1b\x48\x47\x1f\x06\xc3\x62\x4a\x3b\xda\xd6\xe4\x63\x10\xb2\x6d\x9a\xc9\x70\x71\xe3\x84\x9b\xf2\x5c\xad\xd1\x0d\x17\x80\xf7\x16\xaf\x9d\xba\x62\x29\x0d\xf2\x53\x84\x8b\x5b\xac\x08\x3b\x5d\xe9\xde\x1b\x8e\x3f\xd8\x65\xb8\x14\xf8\x0b\xa1\x65\x16\xf0\xc1\x5f\x27\xf9\x60\x77\x54\x2d\x81\xc6\xd7\x15\xe2\xb6\x22\xbc\x99\x44\x26\xf3\xde\x62\xec\xd2\xe2\x18\x44\x1e\xc6\xe8\xda\xb0\x7e\xcf\x97\x7e\x39\x8e\xb9\xd8\x3a\x72\xb6\xe8\xdd\xba\x62\x6c\x4d\x28\xec\x18\x37\xff\xcd\x55\x60\x22\x4f\x72\xbc\xd9\x32\xb2\x37\x1f\xb8\xc2\xa9\x2b\x12\xed\x91\xcc\x25\xae\x20\xc1\x4f\x7c\xf2\xbd\x03\x06\x5f\x72\xa0\x78\x0b\xb3\xe1\x60\xb6\xe8\x8d\x7c\xad\x48\x79\xba\x24\x2d\xdc\xae\x9d\xa8\x1f\xe1\x91\x44\xbc\xca\xea\x14\x86\xf7\x91\xc4\x31\x05\xd4\xc7\x52\xa1\xe7\xa8\xbb\x3a\x8d\xfd\xcd\x7c\x9e\x98\x77\xfb\xcd\x5c\xdd\x12\x95\xa6\x3e\xd8\xe3\x3c\x26\xa8\x2b\xf0\xd2\xc4\xcf\x5a\x6d\x29\xca\x23\x60\xe4\xf6\x1d\xed\x94\xba\xb2\x6c\x84\xda\xc1\x70\xf1\xa9\x1b\x06\x07\xbb\x1a\x0e\x9f\xaf\xd4\x1b\xe0\x6e\xda\xa9\x76\x45\xcd\x93\x1c\xe9\x00\xd3\xc1\x04\x3d\x47\x23\x5d\xc1\xcb\x13\x28\xd5\x67\xb7\xad\xb5\x1e\x3a\x73\xa8\x2a\xa7\x28\xce\xe5\x76\x26\xdd\x36\xca\x22\xe5\xdc\x0e\x7a\xfd\xa1\xaf\xb8\x8b\x4c\x70\x4b\x22\x60\x12\x76\x30\x98\x4d\xba\x57\xfd\x2a\xf5\x4c\xe0\x18\xf6\x20\x1d\xcd\xde\xf7\x27\x1d\x4d\x61\x56\xf7\xa2\x97\x4b\xc5\x53\x73\x86\x87\xd4\xa6\xd8\x53\xff\xf1\xcf\xbc\x30\xd1\x05\x75\x2e\xd1\x73\xf4\x11\x33\x9c\x80\xde\x15\x9e\xee\x10\x59\x9a\xc9\xf7\x3e\x55\x9d\xd6\x86\x37\x9b\x9a\xaa\xef\x5b\x0e\x4e\x3b\x19\xb0\xd8\x9c\x4e\xfb\x42\xd6\x9c\xbf\xe2\x07\x4c\x4c\xb1\x78\x5f\xbf\x3f\x32\x44\x58\x6f\xfe\xc1\xa5\x03\xf3\xb7\xbb\xda\x20\x6a\x8d\x56\x39\xa5\x08\x47\x11\xc8\x20\x2f\x12\x16\xd2\xcc\x20\xd5\x45\x85\x20\x74\x83\xfc\x9b\x72\xac\xcc\xa5\x46\x05\xb1\x0b\x1d\xfe\x27\x8a\x73\x40\x8a\xa3\x8c\x53\x12\x6d\xd0\x3d\xe1\xd4\xe0\x0a\xa6\xc1\x22\x5a\x93\x7b\x88\x3b\x36\x51\xd9\x1f\x06\x5b\xc3\xea\xca\xab\xa2\x72\x0d\xda\x2c\xef\xb8\x0e\x14\xc1\x29\x1c\x76\x39\x3e\xe1\x14\xf6\xb8\x1b\xd7\x8c\xb7\xdd\x8d\xeb\x77\xaf\xdc\xcc\x6d\x77\xe3\x47\x5e\x52\xb6\x0a\xa8\xe5\x39\xb0\x3b\x6b\x93\xed\x16\x50\x73\x08\x45\x5b\x71\x81\x28\x49\x89\xb2\xa1\x2f\x03\x91\x12\x29\xb5\xc2\x9b\x1d\x01\x9a\xf6\x95\xc3\x74\x0a\xd1\x0f\xbb\xf9\xd8\xeb\xd2\x63\xb7\x9f\x46\xae\x0e\x0f\xfb\x76\xf4\x3c\x34\x0a\x7f\x66\x58\x28\xd7\x09\x12\x3e\x96\xda\x26\x82\x23\xe3\xda\x6b\xc6\x59\x26\xf8\x8a\xa8\xf0\x61\xc2\xef\x41\x30\x03\x34\x78\xaa\x37\x58\xae\x1e\x0e\x1f\x73\x1d\xe9\x3b\xff\xd8\xc8\xde\xb5\xad\x08\x3f\xa6\x6f\xae\xd1\x07\xe1\x2d\xe6\x7f\x92\xb7\xdd\x82\x77\xeb\xe3\xeb\x7e\x92\xad\x37\xd2\x1c\xcf\x3a\xce\xe8\xac\xe8\x5e\x8d\xd1\x87\xe9\x68\x18\xc4\xe1\x18\x14\x26\x54\xbe\x38\xae\xd7\xcb\x5e\x24\x2d\xb6\x4a\xb0\xd5\xd7\xa7\xf6\x06\x6a\x97\x28\x6e\x88\x97\x81\x12\x06\xf6\x7e\xe3\x73\x4e\xa2\x3f\x5d\x02\x71\xdd\xb8\x31\x3c\x12\x96\xb4\xfb\xf5\x71\xcd\x61\x11\x51\x87\x5c\x93\xf7\x2a\xc3\x1b\x3d\x22\x95\xb7\x07\x5d\xc7\xee\x1d\x74\x74\x2e\x37\x3e\x7c\x4f\x58\x74\xc8\x25\xb3\x39\x21\xbc\x18\x37\x08\x2b\xaa\xc0\x0a\xce\x91\x67\x7e\x8e\xb8\x40\x38\x4e\x09\x73\xd5\xf9\x3d\x20\x01\x49\xa5\x63\xf0\x69\x84\xcc\xb8\x54\x98\x1e\x7a\x8d\x3e\x36\x54\xdb\x2f\xd2\x3f\x0d\xc6\xa6\xfa\x3c\x47\x96\xbf\xfb\xc1\x05\x82\xcf\x39\xb9\xc7\x34\x8c\x71\x61\xeb\xe6\xf7\x6c\x52\xca\x99\x12\x07\x59\x5c\x9d\xa2\xd9\x98\x64\x06\xa0\xb3\xc1\x74\x84\xfe\xf5\xfa\x97\x5f\x5e\xbe\x46\x98\x66\x6b\xfc\xf2\xe7\xd6\xbe\xb8\xef\x2e\xcc\xa1\xdd\xb5\x4e\xa2\xed\xdd\xb5\xd7\x3a\x60\x79\xb1\x4c\x77\xad\x0e\x07\xae\xdd\xf6\x30\xd3\xdb\x5d\x9b\xd5\x6c\xf9\xdb\xa5\x59\x8d\x20\x6c\x2b\x07\x9e\x08\x9c\xad\x49\xe4\x5c\x04\x9d\xc1\xab\xe4\xd5\x79\xf5\x5e\xf4\xdc\x5d\xaf\x9d\x9b\xb3\xef\x97\xee\xec\xfb\xa9\xeb\x94\x2f\x9c\x01\x61\xab\x43\x9a\x60\x3f\x71\x06\x26\x4b\xee\xc8\x3e\xee\xe6\x41\x91\x14\xf4\x0c\xe8\x6c\xd0\x1d\x76\xd1\x8c\xa4\x80\x0c\xf9\x15\x56\x78\x89\xa5\xd1\x5e\x8a\xd5\x77\x94\xf2\x29\x4b\x04\xbb\xf9\x03\xa5\x77\x3d\x27\xfc\xde\x42\xba\x19\xf7\xae\x0e\xa6\x0d\x82\x96\x8e\xc5\x15\x49\x72\x77\xac\xd2\x9c\xe0\x10\x57\x49\x41\xe1\x18\x2b\xbc\x37\xbc\x8f\x0d\x82\x00\x5e\xff\x51\xd9\x8d\x9c\xd9\x2c\x7a\xde\x26\xc7\x47\xf6\x90\x60\x65\x3e\x77\xf9\x06\xd6\x67\xae\xc4\xb4\x36\x02\xe5\x97\x38\x2d\x5f\xb3\x3c\x2e\x4c\xc7\x92\x6d\x56\xae\xef\x87\x83\x2f\x79\x82\x76\xe6\x62\x1b\xfe\x47\x43\x68\x6b\x94\x6d\xa2\x0d\xf4\x1b\xc4\x99\xdb\xa5\x69\x91\x2c\x17\x13\xc3\x56\x84\x2a\xd3\x7f\xd3\xd9\xb9\xda\x05\xdc\xca\x37\x4d\xad\x70\x2b\x1d\x96\xd5\xb6\xc5\xa3\x60\x87\x33\x7a\xe4\x5a\x08\xed\xe5\x7b\x82\x6e\xe9\x0a\x0b\x11\xd7\xc7\x84\x2d\x73\x47\x41\x56\xd6\xd8\xdd\xa9\x92\xb5\x22\xcd\x6c\x3f\xb4\x94\xef\x5a\xde\x22\x5f\x37\x0b\x2f\x57\x3b\x1e\x05\xd9\xb1\x3d\x37\x2b\x0c\x17\x9e\xa9\xc5\x4e\xd4\xc6\x18\x4b\x52\xa6\x94\xcf\x39\x08\x02\x72\x4f\x91\x6c\x02\xda\xa1\x02\x9f\xf8\x1c\xe6\xa3\x44\x48\x1a\x09\xcf\x98\x89\xdf\xbf\xef\x09\xd5\x15\x7a\xcd\x0c\x15\xc2\xad\x54\x83\x41\x3a\x3b\x0a\x77\x50\xfa\x19\xc4\x9e\x9b\x3d\x65\xe0\xde\xf2\xdd\xf1\xea\x9e\x62\x98\x03\x87\x5d\x51\xa5\x38\x25\xf9\x7b\x41\xa5\x58\xde\x7a\x60\x69\x3f\x1e\xd9\xd7\x5e\x38\xdd\xe5\xb0\xc5\x11\x56\x79\xd8\x73\x3c\xf8\xe0\xf0\xca\xd8\x8b\x96\x45\x0b\x50\xa2\xae\x19\x7b\x11\xe4\x05\xb8\x53\xbe\xf2\x83\x4e\xc3\xac\xf8\x19\xa0\x59\x63\x39\x0a\x9b\xf9\x53\x1e\xdb\xb4\xb4\x58\x6c\x30\x37\x9f\x8d\x94\x2f\xff\x84\x4d\xfb\x77\x2c\x2b\x2e\x80\x24\xe6\x93\x55\xf7\xa1\x49\x65\x41\xcd\xc2\x1c\x31\xbb\x21\xab\xcf\xde\x38\x27\x0c\x26\xf7\xef\x2a\x93\x1b\x44\x6e\xc6\x63\xe4\x7f\x55\x55\xb8\x83\xd1\x38\xcd\x0b\x60\xf8\x77\x15\x18\x29\xa4\x4b\x10\x72\x0b\x88\x8f\x98\x6d\xb6\xa2\xb0\xa4\x4d\x08\xf5\xcf\x3d\x43\x08\xee\x5d\x63\x25\x76\x01\x98\xad\x05\xcf\x93\xf5\x9e\xd6\xa0\xdc\xe8\xef\x01\xb3\xce\xf4\xc6\x92\x7a\x63\xaa\xbf\xbe\xde\x66\x6f\xc5\x27\xc7\xf7\x98\xe6\xb6\xce\xb1\x3e\x51\x1c\x10\xfe\x15\x7c\x94\x20\x7d\x09\x8d\x3a\x92\xaf\xd4\x22\x06\x0a\x0a\x82\xa7\xa5\xaf\xb9\x67\xe8\xeb\xb3\xaf\xcf\xfe\x1f\x00\x00\xff\xff\x72\x72\xfa\x34\xcf\x3d\x00\x00")
 
 func yaoModelsTeamModYaoBytes() ([]byte, error) {
@@ -2560,19 +2664,24 @@ var _bindata = map[string]func() (*asset, error){
 	"yao/models/agent/message.mod.yao":                     yaoModelsAgentMessageModYao,
 	"yao/models/agent/resume.mod.yao":                      yaoModelsAgentResumeModYao,
 	"yao/models/agent/search.mod.yao":                      yaoModelsAgentSearchModYao,
+	"yao/models/agent/usage.mod.yao":                       yaoModelsAgentUsageModYao,
 	"yao/models/attachment.mod.yao":                        yaoModelsAttachmentModYao,
 	"yao/models/audit.mod.yao":                             yaoModelsAuditModYao,
+	"yao/models/cluster.mod.yao":                           yaoModelsClusterModYao,
 	"yao/models/config.mod.yao":                            yaoModelsConfigModYao,
 	"yao/models/dsl.mod.yao":                               yaoModelsDslModYao,
 	"yao/models/invitation.mod.yao":                        yaoModelsInvitationModYao,
 	"yao/models/job/category.mod.yao":                      yaoModelsJobCategoryModYao,
 	"yao/models/job/execution.mod.yao":                     yaoModelsJobExecutionModYao,
 	"yao/models/job/job.mod.yao":                           yaoModelsJobJobModYao,
+	"yao/models/job/lock.mod.yao":                          yaoModelsJobLockModYao,
 	"yao/models/job/log.mod.yao":                           yaoModelsJobLogModYao,
 	"yao/models/kb/collection.mod.yao":                     yaoModelsKbCollectionModYao,
 	"yao/models/kb/document.mod.yao":                       yaoModelsKbDocumentModYao,
 	"yao/models/member.mod.yao":                            yaoModelsMemberModYao,
+	"yao/models/migration.mod.yao":                         yaoModelsMigrationModYao,
 	"yao/models/role.mod.yao":                              yaoModelsRoleModYao,
+	"yao/models/seed.mod.yao":                              yaoModelsSeedModYao,
 	"yao/models/team.mod.yao":                              yaoModelsTeamModYao,
 	"yao/models/user/oauth_account.mod.yao":                yaoModelsUserOauth_accountModYao,
 	"yao/models/user/type.mod.yao":                         yaoModelsUserTypeModYao,
@@ -2598,11 +2707,13 @@ var _bindata = map[string]func() (*asset, error){
 // directory embedded in the file by go-bindata.
 // For example if you run go-bindata on data/... and data contains the
 // following hierarchy:
-//     data/
-//       foo.txt
-//       img/
-//         a.png
-//         b.png
+//
+//	data/
+//	  foo.txt
+//	  img/
+//	    a.png
+//	    b.png
+//
 // then AssetDir("data") would return []string{"foo.txt", "img"}
 // AssetDir("data/img") would return []string{"a.png", "b.png"}
 // AssetDir("foo.txt") and AssetDir("notexist") would return an error
@@ -2804,9 +2915,11 @@ var _bintree = &bintree{nil, map[string]*bintree{
 				"message.mod.yao":   &bintree{yaoModelsAgentMessageModYao, map[string]*bintree{}},
 				"resume.mod.yao":    &bintree{yaoModelsAgentResumeModYao, map[string]*bintree{}},
 				"search.mod.yao":    &bintree{yaoModelsAgentSearchModYao, map[string]*bintree{}},
+				"usage.mod.yao":     &bintree{yaoModelsAgentUsageModYao, map[string]*bintree{}},
 			}},
 			"attachment.mod.yao": &bintree{yaoModelsAttachmentModYao, map[string]*bintree{}},
 			"audit.mod.yao":      &bintree{yaoModelsAuditModYao, map[string]*bintree{}},
+			"cluster.mod.yao":    &bintree{yaoModelsClusterModYao, map[string]*bintree{}},
 			"config.mod.yao":     &bintree{yaoModelsConfigModYao, map[string]*bintree{}},
 			"dsl.mod.yao":        &bintree{yaoModelsDslModYao, map[string]*bintree{}},
 			"invitation.mod.yao": &bintree{yaoModelsInvitationModYao, map[string]*bintree{}},
@@ -2814,15 +2927,18 @@ var _bintree = &bintree{nil, map[string]*bintree{
 				"category.mod.yao":  &bintree{yaoModelsJobCategoryModYao, map[string]*bintree{}},
 				"execution.mod.yao": &bintree{yaoModelsJobExecutionModYao, map[string]*bintree{}},
 				"job.mod.yao":       &bintree{yaoModelsJobJobModYao, map[string]*bintree{}},
+				"lock.mod.yao":      &bintree{yaoModelsJobLockModYao, map[string]*bintree{}},
 				"log.mod.yao":       &bintree{yaoModelsJobLogModYao, map[string]*bintree{}},
 			}},
 			"kb": &bintree{nil, map[string]*bintree{
 				"collection.mod.yao": &bintree{yaoModelsKbCollectionModYao, map[string]*bintree{}},
 				"document.mod.yao":   &bintree{yaoModelsKbDocumentModYao, map[string]*bintree{}},
 			}},
-			"member.mod.yao": &bintree{yaoModelsMemberModYao, map[string]*bintree{}},
-			"role.mod.yao":   &bintree{yaoModelsRoleModYao, map[string]*bintree{}},
-			"team.mod.yao":   &bintree{yaoModelsTeamModYao, map[string]*bintree{}},
+			"member.mod.yao":    &bintree{yaoModelsMemberModYao, map[string]*bintree{}},
+			"migration.mod.yao": &bintree{yaoModelsMigrationModYao, map[string]*bintree{}},
+			"role.mod.yao":      &bintree{yaoModelsRoleModYao, map[string]*bintree{}},
+			"seed.mod.yao":      &bintree{yaoModelsSeedModYao, map[string]*bintree{}},
+			"team.mod.yao":      &bintree{yaoModelsTeamModYao, map[string]*bintree{}},
 			"user": &bintree{nil, map[string]*bintree{
 				"oauth_account.mod.yao": &bintree{yaoModelsUserOauth_accountModYao, map[string]*bintree{}},
 				"type.mod.yao":          &bintree{yaoModelsUserTypeModYao, map[string]*bintree{}},
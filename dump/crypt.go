@@ -0,0 +1,185 @@
+package dump
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// magic the header of the encrypted dumps
+var magic = []byte("YAODUMP\x02")
+
+// chunkSize the plaintext size of the encrypted chunks
+const chunkSize = 64 << 10
+
+// saltSize the size of the random salt of the key derivation
+const saltSize = 16
+
+// The scrypt cost parameters of the key derivation
+const (
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// The encrypted dump is the magic, a 16 bytes random salt, an 8 bytes random nonce prefix and the chunks.
+// The AES-256 key is derived from the passphrase and the salt with scrypt.
+// A chunk is the 4 bytes length and the AES-256-GCM sealed data, the nonce is the prefix
+// and the chunk counter, the last chunk is sealed with the additional data "final" so
+// a truncated dump can not be decrypted.
+
+type encryptWriter struct {
+	w      io.Writer
+	aead   cipher.AEAD
+	prefix []byte
+	count  uint32
+	buf    []byte
+}
+
+// encrypter returns the writer encrypting the stream with the key
+func encrypter(w io.Writer, key string) (io.WriteCloser, error) {
+	random := make([]byte, saltSize+8)
+	if _, err := rand.Read(random); err != nil {
+		return nil, err
+	}
+	salt, prefix := random[:saltSize], random[saltSize:]
+
+	aead, err := newAEAD(key, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := w.Write(append(append([]byte{}, magic...), random...)); err != nil {
+		return nil, err
+	}
+	return &encryptWriter{w: w, aead: aead, prefix: prefix, buf: make([]byte, 0, chunkSize)}, nil
+}
+
+func (e *encryptWriter) Write(p []byte) (int, error) {
+	n := 0
+	for len(p) > 0 {
+		size := min(chunkSize-len(e.buf), len(p))
+		e.buf = append(e.buf, p[:size]...)
+		p, n = p[size:], n+size
+		if len(e.buf) == chunkSize {
+			if err := e.seal(false); err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// Close writes the final chunk, the underlying writer is not closed
+func (e *encryptWriter) Close() error {
+	return e.seal(true)
+}
+
+func (e *encryptWriter) seal(final bool) error {
+	var additional []byte
+	if final {
+		additional = []byte("final")
+	}
+
+	sealed := e.aead.Seal(nil, e.nonce(), e.buf, additional)
+	header := make([]byte, 4)
+	binary.BigEndian.PutUint32(header, uint32(len(sealed)))
+	if _, err := e.w.Write(append(header, sealed...)); err != nil {
+		return err
+	}
+
+	e.count++
+	e.buf = e.buf[:0]
+	return nil
+}
+
+func (e *encryptWriter) nonce() []byte {
+	nonce := make([]byte, 12)
+	copy(nonce, e.prefix)
+	binary.BigEndian.PutUint32(nonce[8:], e.count)
+	return nonce
+}
+
+// encrypted returns true if the reader starts with the magic
+func encrypted(r *bufio.Reader) bool {
+	head, err := r.Peek(len(magic))
+	return err == nil && bytes.Equal(head, magic)
+}
+
+// decrypt decrypts the stream to the writer
+func decrypt(r io.Reader, w io.Writer, key string) error {
+	head := make([]byte, len(magic)+saltSize+8)
+	if _, err := io.ReadFull(r, head); err != nil || !bytes.Equal(head[:len(magic)], magic) {
+		return fmt.Errorf("the dump is not encrypted")
+	}
+
+	salt := head[len(magic) : len(magic)+saltSize]
+	aead, err := newAEAD(key, salt)
+	if err != nil {
+		return err
+	}
+
+	e := &encryptWriter{prefix: head[len(magic)+saltSize:]}
+	header := make([]byte, 4)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			return fmt.Errorf("the dump is truncated")
+		}
+
+		// The length is read from the file, a chunk is never larger than a sealed full chunk
+		size := binary.BigEndian.Uint32(header)
+		if size > uint32(chunkSize+aead.Overhead()) {
+			return fmt.Errorf("the dump is corrupted, chunk %d is %d bytes", e.count, size)
+		}
+
+		sealed := make([]byte, size)
+		if _, err := io.ReadFull(r, sealed); err != nil {
+			return fmt.Errorf("the dump is truncated")
+		}
+
+		nonce := e.nonce()
+		e.count++
+
+		data, err := aead.Open(nil, nonce, sealed, nil)
+		final := false
+		if err != nil {
+			data, err = aead.Open(nil, nonce, sealed, []byte("final"))
+			if err != nil {
+				return fmt.Errorf("the key is wrong or the dump is corrupted")
+			}
+			final = true
+		}
+
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+		if final {
+			return nil
+		}
+	}
+}
+
+// newAEAD derives the AES-256 key from the passphrase and the salt
+func newAEAD(key string, salt []byte) (cipher.AEAD, error) {
+	if key == "" {
+		return nil, fmt.Errorf("the key is required")
+	}
+
+	derived, err := scrypt.Key([]byte(key), salt, scryptN, scryptR, scryptP, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(derived)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
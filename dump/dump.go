@@ -0,0 +1,319 @@
+package dump
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/share"
+)
+
+// layout the layout of the time values written to the dump and the queries, accepted by the databases
+const layout = "2006-01-02 15:04:05"
+
+// Dump writes the rows of the models to the output, one JSONL file per model in a zip archive.
+// The archive is encrypted if the key is set.
+func Dump(output string, option Option) (*Manifest, error) {
+	ids, err := selectModels(option.Models)
+	if err != nil {
+		return nil, err
+	}
+
+	for name := range option.Queries {
+		if !slices.Contains(ids, name) {
+			return nil, fmt.Errorf("the model %s of the query is not dumped", name)
+		}
+	}
+
+	if option.BatchSize <= 0 {
+		option.BatchSize = 1000
+	}
+
+	file, err := os.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := write(file, ids, option)
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(output)
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func write(file io.Writer, ids []string, option Option) (*Manifest, error) {
+	var w io.Writer = file
+	var enc io.WriteCloser
+	if option.Key != "" {
+		var err error
+		enc, err = encrypter(file, option.Key)
+		if err != nil {
+			return nil, err
+		}
+		w = enc
+	}
+
+	zw := zip.NewWriter(w)
+	manifest := &Manifest{
+		Version:     Version,
+		Name:        share.App.Name,
+		CreatedAt:   time.Now(),
+		Incremental: option.Since != nil || option.SinceTime != nil,
+		Data:        option.Data,
+		Models:      []Entry{},
+	}
+
+	deps := dependencies()
+	for _, id := range ids {
+		entry, err := dumpModel(zw, id, option)
+		if err != nil {
+			return nil, fmt.Errorf("[Dump] %s %s", id, err.Error())
+		}
+		entry.Depends = deps[id]
+		manifest.Models = append(manifest.Models, *entry)
+	}
+
+	if option.Data {
+		dataPath := filepath.Join(config.Conf.Root, "data")
+		if _, err := os.Stat(dataPath); err == nil {
+			if err := addDir(zw, dataPath, "data"); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	f, err := zw.Create("manifest.json")
+	if err != nil {
+		return nil, err
+	}
+	data, err := jsoniter.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Write(data); err != nil {
+		return nil, err
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	if enc != nil {
+		if err := enc.Close(); err != nil {
+			return nil, err
+		}
+	}
+	return manifest, nil
+}
+
+// dumpModel streams the rows of the model ordered by the primary key
+func dumpModel(zw *zip.Writer, id string, option Option) (*Entry, error) {
+	mod := model.Models[id]
+	entry := &Entry{Model: id, Table: mod.MetaData.Table.Name, File: fmt.Sprintf("model/%s.jsonl", id)}
+
+	param, hasQuery := option.Queries[id]
+	entry.Query = hasQuery
+	wheres := append([]model.QueryWhere{}, param.Wheres...)
+
+	since := option.since(id)
+	if since != nil && hasWatermark(mod) {
+		wheres = append(wheres, model.QueryWhere{Column: WatermarkColumn, OP: "ge", Value: since.Format(layout)})
+		entry.Incremental = true
+		entry.Watermark = since
+	}
+
+	f, err := zw.Create(entry.File)
+	if err != nil {
+		return nil, err
+	}
+
+	var last interface{}
+	for {
+		query := model.QueryParam{Select: param.Select, Wheres: wheres}
+		if mod.PrimaryKey != "" {
+			query.Orders = []model.QueryOrder{{Column: mod.PrimaryKey, Option: "asc"}}
+			query.Limit = option.BatchSize
+			if last != nil {
+				query.Wheres = append(append([]model.QueryWhere{}, wheres...), model.QueryWhere{Column: mod.PrimaryKey, OP: "gt", Value: last})
+			}
+		}
+
+		rows, err := mod.Get(query)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			line, err := jsoniter.Marshal(encode(row))
+			if err != nil {
+				return nil, err
+			}
+			if _, err := f.Write(append(line, '\n')); err != nil {
+				return nil, err
+			}
+
+			if updated, ok := toTime(row[WatermarkColumn]); ok && (entry.Watermark == nil || updated.After(*entry.Watermark)) {
+				entry.Watermark = &updated
+			}
+			last = row[mod.PrimaryKey]
+			entry.Rows++
+		}
+
+		if option.Progress != nil {
+			option.Progress(id, entry.Rows)
+		}
+		if mod.PrimaryKey == "" || len(rows) < option.BatchSize || last == nil {
+			break
+		}
+	}
+	return entry, nil
+}
+
+// since returns the watermark of the model, nil if all the rows should be dumped
+func (option Option) since(id string) *time.Time {
+	if option.Since != nil {
+		for _, entry := range option.Since.Models {
+			if entry.Model == id && entry.Watermark != nil && !entry.Query {
+				return entry.Watermark
+			}
+		}
+	}
+	return option.SinceTime
+}
+
+// selectModels returns the ids of the models sorted, all the models if ids is empty
+func selectModels(ids []string) ([]string, error) {
+	selected := []string{}
+	if len(ids) == 0 {
+		for id := range model.Models {
+			selected = append(selected, id)
+		}
+		sort.Strings(selected)
+		return selected, nil
+	}
+
+	for _, id := range ids {
+		if _, has := model.Models[id]; !has {
+			return nil, fmt.Errorf("the model %s is not loaded", id)
+		}
+		selected = append(selected, id)
+	}
+	sort.Strings(selected)
+	return selected, nil
+}
+
+// dependencies returns the models referenced by each model.
+// hasOne: the model references the related model; hasMany: the related model references the model.
+func dependencies() map[string][]string {
+	deps := map[string][]string{}
+	add := func(id, dep string) {
+		if id == dep {
+			return
+		}
+		for _, d := range deps[id] {
+			if d == dep {
+				return
+			}
+		}
+		deps[id] = append(deps[id], dep)
+	}
+
+	for id, mod := range model.Models {
+		for _, rel := range mod.MetaData.Relations {
+			if _, has := model.Models[rel.Model]; !has {
+				continue
+			}
+			switch rel.Type {
+			case "hasOne":
+				add(id, rel.Model)
+			case "hasMany":
+				add(rel.Model, id)
+			}
+		}
+	}
+
+	for id := range deps {
+		sort.Strings(deps[id])
+	}
+	return deps
+}
+
+// hasWatermark returns true if the model has the updated_at column
+func hasWatermark(mod *model.Model) bool {
+	if mod.MetaData.Option.Timestamps {
+		return true
+	}
+	_, has := mod.Columns[WatermarkColumn]
+	return has
+}
+
+// encode converts the values not encoded well by JSON
+func encode(row map[string]interface{}) map[string]interface{} {
+	values := map[string]interface{}{}
+	for name, value := range row {
+		switch v := value.(type) {
+		case time.Time:
+			values[name] = v.Format(layout)
+		case []byte:
+			values[name] = string(v)
+		default:
+			values[name] = v
+		}
+	}
+	return values
+}
+
+func toTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		for _, l := range []string{layout, time.RFC3339Nano, "2006-01-02T15:04:05"} {
+			if t, err := time.Parse(l, v); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// addDir adds the files of the directory to the archive
+func addDir(zw *zip.Writer, root string, base string) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		f, err := zw.Create(filepath.ToSlash(filepath.Join(base, rel)))
+		if err != nil {
+			return err
+		}
+
+		src, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		_, err = io.Copy(f, src)
+		return err
+	})
+}
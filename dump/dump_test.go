@@ -0,0 +1,82 @@
+package dump
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncrypt(t *testing.T) {
+	plain := []byte(strings.Repeat("yao dump\n", 20000)) // more than two chunks
+
+	var buf bytes.Buffer
+	w, err := encrypter(&buf, "secret")
+	require.NoError(t, err)
+	_, err = w.Write(plain)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	sealed := buf.Bytes()
+	assert.True(t, encrypted(bufio.NewReader(bytes.NewReader(sealed))))
+	assert.False(t, bytes.Contains(sealed, []byte("yao dump")))
+
+	var out bytes.Buffer
+	require.NoError(t, decrypt(bytes.NewReader(sealed), &out, "secret"))
+	assert.Equal(t, plain, out.Bytes())
+
+	out.Reset()
+	assert.ErrorContains(t, decrypt(bytes.NewReader(sealed), &out, "wrong"), "key is wrong")
+
+	// The final chunk is dropped
+	out.Reset()
+	assert.ErrorContains(t, decrypt(bytes.NewReader(sealed[:len(sealed)-40]), &out, "secret"), "truncated")
+
+	// The chunk length is bounded
+	out.Reset()
+	corrupted := append([]byte{}, sealed...)
+	copy(corrupted[len(magic)+saltSize+8:], []byte{0xff, 0xff, 0xff, 0xff})
+	assert.ErrorContains(t, decrypt(bytes.NewReader(corrupted), &out, "secret"), "corrupted")
+
+	// The key is derived with a random salt
+	var again bytes.Buffer
+	w, err = encrypter(&again, "secret")
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	assert.NotEqual(t, sealed[len(magic):len(magic)+saltSize], again.Bytes()[len(magic):len(magic)+saltSize])
+
+	assert.False(t, encrypted(bufio.NewReader(strings.NewReader("PK\x03\x04"))))
+}
+
+func TestOrder(t *testing.T) {
+	entries := map[string]Entry{
+		"order":      {Model: "order", Depends: []string{"user", "product"}},
+		"order.item": {Model: "order.item", Depends: []string{"order", "product"}},
+		"product":    {Model: "product", Depends: []string{"category"}}, // category is not in the dump
+		"user":       {Model: "user"},
+		"a":          {Model: "a", Depends: []string{"b"}},
+		"b":          {Model: "b", Depends: []string{"a"}},
+	}
+
+	ids := []string{}
+	for _, entry := range order(entries) {
+		ids = append(ids, entry.Model)
+	}
+	assert.Equal(t, []string{"product", "user", "order", "order.item", "a", "b"}, ids)
+}
+
+func TestDecode(t *testing.T) {
+	row, err := decode([]byte(`{"id":9007199254740993,"price":9.5,"name":"pet","tags":["a"],"deleted_at":null}` + "\n"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(9007199254740993), row["id"])
+	assert.Equal(t, 9.5, row["price"])
+	assert.Equal(t, "pet", row["name"])
+	assert.Equal(t, []interface{}{"a"}, row["tags"])
+	assert.Nil(t, row["deleted_at"])
+
+	_, err = decode([]byte(`{"id":`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,481 @@
+package dump
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/kun/maps"
+)
+
+// maxErrors the max error messages reported per model
+const maxErrors = 20
+
+// Archive the dump opened for restoring
+type Archive struct {
+	Manifest *Manifest // nil if the dump is written by the earlier versions
+	reader   *zip.ReadCloser
+	temp     string
+}
+
+// Open opens the dump, the encrypted dump is decrypted to a temporary file with the key
+func Open(file string, key string) (*Archive, error) {
+	src, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	archive := &Archive{}
+	path := file
+	r := bufio.NewReader(src)
+	if encrypted(r) {
+		if key == "" {
+			return nil, fmt.Errorf("the dump is encrypted, the key is required")
+		}
+
+		tmp, err := os.CreateTemp("", "yao-restore-*.zip")
+		if err != nil {
+			return nil, err
+		}
+		archive.temp = tmp.Name()
+		err = decrypt(r, tmp, key)
+		tmp.Close()
+		if err != nil {
+			os.Remove(archive.temp)
+			return nil, err
+		}
+		path = archive.temp
+	}
+
+	archive.reader, err = zip.OpenReader(path)
+	if err != nil {
+		archive.Close()
+		return nil, err
+	}
+
+	f := archive.file("manifest.json")
+	if f == nil {
+		return archive, nil
+	}
+
+	data, err := readAll(f)
+	if err != nil {
+		archive.Close()
+		return nil, err
+	}
+
+	manifest := &Manifest{}
+	err = jsoniter.Unmarshal(data, manifest)
+	if err != nil {
+		archive.Close()
+		return nil, fmt.Errorf("the manifest is invalid: %s", err.Error())
+	}
+	if manifest.Version > Version {
+		archive.Close()
+		return nil, fmt.Errorf("the dump version %d is not supported, upgrade to restore it", manifest.Version)
+	}
+	archive.Manifest = manifest
+	return archive, nil
+}
+
+// Close closes the archive and removes the decrypted file
+func (archive *Archive) Close() error {
+	var err error
+	if archive.reader != nil {
+		err = archive.reader.Close()
+	}
+	if archive.temp != "" {
+		os.Remove(archive.temp)
+	}
+	return err
+}
+
+// Restore restores the rows of the models in the referential order.
+// In the replace mode the rows of the models are deleted first in the reverse order.
+func (archive *Archive) Restore(option RestoreOption) (*Report, error) {
+	if archive.Manifest == nil {
+		return nil, fmt.Errorf("the dump has no manifest")
+	}
+
+	switch option.Mode {
+	case "":
+		option.Mode = Upsert
+	case Upsert, Replace:
+	default:
+		return nil, fmt.Errorf("the mode %s is not supported, use upsert or replace", option.Mode)
+	}
+
+	if option.BatchSize <= 0 {
+		option.BatchSize = 500
+	}
+
+	entries, err := archive.entries(option.Models)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Mode: option.Mode, DryRun: option.DryRun, Models: make([]ModelReport, len(entries))}
+	for i, entry := range entries {
+		report.Models[i] = ModelReport{Model: entry.Model}
+		mod := model.Models[entry.Model]
+		if option.DryRun {
+			continue
+		}
+		has, err := mod.HasTable()
+		if err != nil {
+			return report, err
+		}
+		if !has {
+			if err := mod.Migrate(false); err != nil {
+				return report, fmt.Errorf("[Restore] %s %s", entry.Model, err.Error())
+			}
+		}
+	}
+
+	if option.Mode == Replace {
+		for i := len(entries) - 1; i >= 0; i-- {
+			mod := model.Models[entries[i].Model]
+			if option.DryRun {
+				res, err := mod.Paginate(model.QueryParam{}, 1, 1)
+				if err != nil {
+					return report, fmt.Errorf("[Restore] %s %s", entries[i].Model, err.Error())
+				}
+				report.Models[i].Delete = toInt(res["total"])
+				continue
+			}
+
+			deleted, err := mod.DestroyWhere(model.QueryParam{})
+			if err != nil {
+				return report, fmt.Errorf("[Restore] %s %s", entries[i].Model, err.Error())
+			}
+			report.Models[i].Delete = deleted
+		}
+	}
+
+	for i, entry := range entries {
+		err := archive.restoreModel(entry, option, &report.Models[i])
+		if err != nil {
+			return report, fmt.Errorf("[Restore] %s %s", entry.Model, err.Error())
+		}
+	}
+	return report, nil
+}
+
+// RestoreData replaces the directory with the data directory of the dump
+func (archive *Archive) RestoreData(dataPath string) error {
+	temp := dataPath + ".restore"
+	os.RemoveAll(temp)
+
+	for _, f := range archive.reader.File {
+		name, ok := strings.CutPrefix(f.Name, "data/")
+		if !ok || f.FileInfo().IsDir() {
+			continue
+		}
+
+		target := filepath.Join(temp, filepath.FromSlash(name))
+		if !strings.HasPrefix(target, filepath.Clean(temp)+string(os.PathSeparator)) {
+			return fmt.Errorf("invalid file path %s", f.Name)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+			return err
+		}
+		if err := extract(f, target); err != nil {
+			return err
+		}
+	}
+
+	if _, err := os.Stat(temp); err != nil {
+		return nil
+	}
+	if err := os.RemoveAll(dataPath); err != nil {
+		return err
+	}
+	return os.Rename(temp, dataPath)
+}
+
+// restoreModel reads the rows of the model line by line
+func (archive *Archive) restoreModel(entry Entry, option RestoreOption, report *ModelReport) error {
+	mod := model.Models[entry.Model]
+	f := archive.file(entry.File)
+	if f == nil {
+		return fmt.Errorf("%s is not found in the dump", entry.File)
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	batch := []map[string]interface{}{}
+	flush := func() {
+		if len(batch) > 0 {
+			insertBatch(mod, batch, option.DryRun, report)
+			batch = []map[string]interface{}{}
+		}
+	}
+
+	r := bufio.NewReader(rc)
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			row, derr := decode(line)
+			if derr != nil {
+				return fmt.Errorf("line %d %s", report.Rows+1, derr.Error())
+			}
+			report.Rows++
+
+			if option.Mode == Replace {
+				batch = append(batch, row)
+				if len(batch) >= option.BatchSize {
+					flush()
+				}
+			} else {
+				upsert(mod, row, option.DryRun, report)
+			}
+
+			if option.Progress != nil && report.Rows%1000 == 0 {
+				option.Progress(entry.Model, report.Rows)
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	flush()
+	if option.Progress != nil {
+		option.Progress(entry.Model, report.Rows)
+	}
+	return nil
+}
+
+// upsert updates the row if the primary key exists, inserts it otherwise
+func upsert(mod *model.Model, row map[string]interface{}, dryRun bool, report *ModelReport) {
+	exists := false
+	if id, has := row[mod.PrimaryKey]; has && mod.PrimaryKey != "" {
+		_, err := mod.Find(id, model.QueryParam{Select: []interface{}{mod.PrimaryKey}})
+		exists = err == nil
+	}
+
+	if dryRun {
+		if exists {
+			report.Update++
+		} else {
+			report.Insert++
+		}
+		return
+	}
+
+	var err error
+	if exists {
+		_, err = mod.Save(maps.MapStrAny(row))
+	} else {
+		_, err = mod.Create(maps.MapStrAny(row))
+	}
+	if err != nil {
+		report.fail(err)
+		return
+	}
+
+	if exists {
+		report.Update++
+	} else {
+		report.Insert++
+	}
+}
+
+// insertBatch inserts the rows, the columns are the union of the keys of the rows
+func insertBatch(mod *model.Model, rows []map[string]interface{}, dryRun bool, report *ModelReport) {
+	if dryRun {
+		report.Insert += len(rows)
+		return
+	}
+
+	names := map[string]bool{}
+	for _, row := range rows {
+		for name := range row {
+			names[name] = true
+		}
+	}
+	columns := []string{}
+	for name := range names {
+		columns = append(columns, name)
+	}
+	sort.Strings(columns)
+
+	values := make([][]interface{}, len(rows))
+	for i, row := range rows {
+		values[i] = make([]interface{}, len(columns))
+		for j, name := range columns {
+			values[i][j] = row[name]
+		}
+	}
+
+	if err := mod.Insert(columns, values); err != nil {
+		report.fail(err)
+		report.Failure += len(rows) - 1 // the rows of the batch are all failed
+		return
+	}
+	report.Insert += len(rows)
+}
+
+func (report *ModelReport) fail(err error) {
+	report.Failure++
+	if len(report.Errors) < maxErrors {
+		report.Errors = append(report.Errors, err.Error())
+	}
+}
+
+// entries returns the entries of the models in the referential order, the referenced models first
+func (archive *Archive) entries(ids []string) ([]Entry, error) {
+	selected := map[string]Entry{}
+	for _, entry := range archive.Manifest.Models {
+		selected[entry.Model] = entry
+	}
+
+	if len(ids) > 0 {
+		filtered := map[string]Entry{}
+		for _, id := range ids {
+			entry, has := selected[id]
+			if !has {
+				return nil, fmt.Errorf("the model %s is not in the dump", id)
+			}
+			filtered[id] = entry
+		}
+		selected = filtered
+	}
+
+	for id := range selected {
+		if _, has := model.Models[id]; !has {
+			return nil, fmt.Errorf("the model %s is not loaded", id)
+		}
+	}
+	return order(selected), nil
+}
+
+// order sorts the entries topologically by the dependencies, the models in a cycle are sorted by name
+func order(entries map[string]Entry) []Entry {
+	ids := []string{}
+	for id := range entries {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	sorted := []Entry{}
+	done := map[string]bool{}
+	for len(sorted) < len(ids) {
+		progress := false
+		for _, id := range ids {
+			if done[id] {
+				continue
+			}
+			ready := true
+			for _, dep := range entries[id].Depends {
+				if _, has := entries[dep]; has && !done[dep] && dep != id {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				sorted = append(sorted, entries[id])
+				done[id] = true
+				progress = true
+			}
+		}
+
+		// A cycle, take the first model left
+		if !progress {
+			for _, id := range ids {
+				if !done[id] {
+					sorted = append(sorted, entries[id])
+					done[id] = true
+					break
+				}
+			}
+		}
+	}
+	return sorted
+}
+
+func (archive *Archive) file(name string) *zip.File {
+	for _, f := range archive.reader.File {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// decode reads the row, the numbers are kept as integers if possible
+func decode(line []byte) (map[string]interface{}, error) {
+	row := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.UseNumber()
+	if err := decoder.Decode(&row); err != nil {
+		return nil, err
+	}
+
+	for name, value := range row {
+		if number, ok := value.(json.Number); ok {
+			if n, err := number.Int64(); err == nil {
+				row[name] = n
+			} else if f, err := number.Float64(); err == nil {
+				row[name] = f
+			}
+		}
+	}
+	return row, nil
+}
+
+func readAll(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+func extract(f *zip.File, target string) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	dst, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, f.Mode())
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+	_, err = io.Copy(dst, rc)
+	return err
+}
+
+func toInt(value interface{}) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	return 0
+}
@@ -0,0 +1,80 @@
+package dump
+
+import (
+	"time"
+
+	"github.com/yaoapp/gou/model"
+)
+
+// Version the version of the dump format
+const Version = 1
+
+// WatermarkColumn the column of the incremental dumps
+const WatermarkColumn = "updated_at"
+
+// The restore modes
+const (
+	Upsert  = "upsert"  // update the rows by the primary key, insert the others
+	Replace = "replace" // delete the rows of the models, then insert
+)
+
+// Option the dump option
+type Option struct {
+	Models    []string                    // the models to dump, all the models if empty
+	Queries   map[string]model.QueryParam // the query of the model, the rows matched are dumped
+	Since     *Manifest                   // the previous dump, the rows updated after its watermarks are dumped
+	SinceTime *time.Time                  // the rows updated after the time are dumped
+	Key       string                      // encrypt the dump with the key if not empty
+	Data      bool                        // dump the data directory
+	BatchSize int                         // the rows read per query, default 1000
+	Progress  func(model string, rows int)
+}
+
+// RestoreOption the restore option
+type RestoreOption struct {
+	Models    []string // the models to restore, all the models in the dump if empty
+	Mode      string   // upsert (default) or replace
+	DryRun    bool     // report the changes without writing
+	BatchSize int      // the rows inserted per query in the replace mode, default 500
+	Progress  func(model string, rows int)
+}
+
+// Manifest the manifest of the dump, manifest.json in the archive
+type Manifest struct {
+	Version     int       `json:"version"`
+	Name        string    `json:"name,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	Incremental bool      `json:"incremental"`
+	Data        bool      `json:"data"`
+	Models      []Entry   `json:"models"`
+}
+
+// Entry the model in the dump
+type Entry struct {
+	Model       string     `json:"model"`
+	Table       string     `json:"table"`
+	File        string     `json:"file"`
+	Rows        int        `json:"rows"`
+	Incremental bool       `json:"incremental"`         // only the rows updated after the watermark of the previous dump
+	Query       bool       `json:"query,omitempty"`     // the rows are filtered by a query
+	Watermark   *time.Time `json:"watermark,omitempty"` // the max updated_at of the rows, or the previous one if nothing changed
+	Depends     []string   `json:"depends,omitempty"`   // the models referenced by the model
+}
+
+// Report the report of the restore
+type Report struct {
+	Mode   string        `json:"mode"`
+	DryRun bool          `json:"dry_run"`
+	Models []ModelReport `json:"models"`
+}
+
+// ModelReport the report of the model restored
+type ModelReport struct {
+	Model   string   `json:"model"`
+	Rows    int      `json:"rows"`
+	Insert  int      `json:"insert"`
+	Update  int      `json:"update"`
+	Delete  int      `json:"delete"`
+	Failure int      `json:"failure"`
+	Errors  []string `json:"errors,omitempty"`
+}
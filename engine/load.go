@@ -16,7 +16,9 @@ import (
 	"github.com/yaoapp/yao/aigc"
 	"github.com/yaoapp/yao/api"
 	"github.com/yaoapp/yao/attachment"
+	"github.com/yaoapp/yao/audit"
 	"github.com/yaoapp/yao/cert"
+	"github.com/yaoapp/yao/cluster"
 	"github.com/yaoapp/yao/config"
 	"github.com/yaoapp/yao/connector"
 	"github.com/yaoapp/yao/data"
@@ -33,6 +35,7 @@ import (
 	"github.com/yaoapp/yao/pipe"
 	"github.com/yaoapp/yao/plugin"
 	"github.com/yaoapp/yao/query"
+	"github.com/yaoapp/yao/ratelimit"
 	"github.com/yaoapp/yao/runtime"
 	"github.com/yaoapp/yao/schedule"
 	"github.com/yaoapp/yao/script"
@@ -41,6 +44,7 @@ import (
 	"github.com/yaoapp/yao/store"
 	sui "github.com/yaoapp/yao/sui/api"
 	"github.com/yaoapp/yao/task"
+	"github.com/yaoapp/yao/webhook"
 	"github.com/yaoapp/yao/websocket"
 	"github.com/yaoapp/yao/widget"
 	"github.com/yaoapp/yao/widgets"
@@ -186,6 +190,22 @@ func Load(cfg config.Config, options LoadOption, progressCallback ...func(string
 		warnings = append(warnings, Warning{Widget: "Model", Error: err})
 	}
 
+	// Load Audit Log
+	err = loadStep("Audit", func() error {
+		return audit.Load(cfg)
+	}, callback)
+	if err != nil {
+		warnings = append(warnings, Warning{Widget: "Audit", Error: err})
+	}
+
+	// Load the cluster event bus
+	err = loadStep("Cluster", func() error {
+		return cluster.Load(cfg)
+	}, callback)
+	if err != nil {
+		warnings = append(warnings, Warning{Widget: "Cluster", Error: err})
+	}
+
 	// Load Data flows
 	err = loadStep("Flow", func() error {
 		return flow.Load(cfg)
@@ -259,7 +279,7 @@ func Load(cfg config.Config, options LoadOption, progressCallback ...func(string
 		warnings = append(warnings, Warning{Widget: "Socket", Error: err})
 	}
 
-	// Load websockets (client mode)
+	// Load WebSocket servers
 	err = loadStep("WebSocket", func() error {
 		return websocket.Load(cfg)
 	}, callback)
@@ -267,6 +287,22 @@ func Load(cfg config.Config, options LoadOption, progressCallback ...func(string
 		warnings = append(warnings, Warning{Widget: "WebSocket", Error: err})
 	}
 
+	// Load rate limit policies
+	err = loadStep("RateLimit", func() error {
+		return ratelimit.Load(cfg)
+	}, callback)
+	if err != nil {
+		warnings = append(warnings, Warning{Widget: "RateLimit", Error: err})
+	}
+
+	// Load webhook signature policies
+	err = loadStep("Webhook", func() error {
+		return webhook.Load(cfg)
+	}, callback)
+	if err != nil {
+		warnings = append(warnings, Warning{Widget: "Webhook", Error: err})
+	}
+
 	// Load tasks
 	err = loadStep("Task", func() error {
 		return task.Load(cfg)
@@ -406,6 +442,9 @@ func Unload() (err error) {
 	// Stop Runtime
 	err = runtime.Stop()
 
+	// Write the queued audit records
+	audit.Stop()
+
 	// Close DB
 	err = share.DBClose()
 
@@ -544,12 +583,24 @@ func Reload(cfg config.Config, options LoadOption) (err error) {
 		printErr(cfg.Mode, "Socket", err)
 	}
 
-	// Load websockets (client mode)
+	// Load WebSocket servers
 	err = websocket.Load(cfg)
 	if err != nil {
 		printErr(cfg.Mode, "WebSocket", err)
 	}
 
+	// Load rate limit policies
+	err = ratelimit.Load(cfg)
+	if err != nil {
+		printErr(cfg.Mode, "RateLimit", err)
+	}
+
+	// Load webhook signature policies
+	err = webhook.Load(cfg)
+	if err != nil {
+		printErr(cfg.Mode, "Webhook", err)
+	}
+
 	// Load tasks
 	err = task.Load(cfg)
 	if err != nil {
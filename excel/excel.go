@@ -2,6 +2,7 @@ package excel
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sync"
@@ -89,6 +90,16 @@ func Open(path string, writable bool) (string, error) {
 	return id, nil
 }
 
+// OpenReader opens an excel workbook from a reader for reading
+// The workbook is not registered as a handler, the caller must close it
+func OpenReader(r io.Reader) (*Excel, error) {
+	file, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return &Excel{File: file, create: time.Now().Unix()}, nil
+}
+
 // Close close the excel file
 func Close(handler string) error {
 	excel, ok := openFiles.Load(handler)
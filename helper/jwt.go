@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
@@ -32,6 +33,23 @@ type JwtClaims struct {
 	jwt.RegisteredClaims
 }
 
+// jwtScopedSubjects the subjects of the scoped tokens, e.g. the download links, they are not sessions
+var jwtScopedSubjects sync.Map
+
+// JwtScoped registers the subject of a scoped token, the JWT guards reject the tokens of the subject
+func JwtScoped(subject string) {
+	jwtScopedSubjects.Store(subject, true)
+}
+
+// IsSession checks if the claims are of a session token, the scoped tokens and the tokens without a session are not
+func (claims *JwtClaims) IsSession() bool {
+	if claims == nil || claims.SID == "" {
+		return false
+	}
+	_, scoped := jwtScopedSubjects.Load(claims.Subject)
+	return !scoped
+}
+
 // JwtToken JWT令牌
 type JwtToken struct {
 	Token     string `json:"token"`
@@ -22,6 +22,21 @@ func TestJwt(t *testing.T) {
 	assert.Panics(t, func() { JwtValidate(tokenString) })
 }
 
+func TestJwtIsSession(t *testing.T) {
+	option := map[string]interface{}{"subject": "Unit Test Session", "timeout": 60}
+	claims := JwtValidate(JwtMake("1", nil, option).Token)
+	assert.True(t, claims.IsSession())
+
+	JwtScoped("Unit Test Scoped")
+	option["subject"] = "Unit Test Scoped"
+	claims = JwtValidate(JwtMake("1", nil, option).Token)
+	assert.False(t, claims.IsSession())
+
+	claims.Subject = "Unit Test Session"
+	claims.SID = ""
+	assert.False(t, claims.IsSession())
+}
+
 func TestProcessJwt(t *testing.T) {
 	data := map[string]interface{}{"hello": "world", "id": 1}
 	option := map[string]interface{}{"subject": "Unit Test", "audience": "Test", "issuer": "UnitTest", "timeout": 1, "sid": ""}
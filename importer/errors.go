@@ -0,0 +1,125 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/xuri/excelize/v2"
+)
+
+// appendRejects 追加未通过校验的记录 (JSONL), 恢复导入后仍可生成完整的错误数据工作簿
+func appendRejects(id string, rejects []*Reject) error {
+	if len(rejects) == 0 {
+		return nil
+	}
+
+	file := rejectsFile(id)
+	err := os.MkdirAll(filepath.Dir(file), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	for _, reject := range rejects {
+		data, err := jsoniter.Marshal(reject)
+		if err != nil {
+			return err
+		}
+		w.Write(data)
+		w.WriteByte('\n')
+	}
+	return w.Flush()
+}
+
+// writeErrors 生成错误数据工作簿, 返回 system 文件系统路径
+// 数据列与源文件标题一致, 修正后可直接重新上传; 最后三列为行号、校验规则和错误信息
+func (imp *Importer) writeErrors(state *State) (string, error) {
+	src, err := os.Open(rejectsFile(state.ID))
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	file := excelize.NewFile()
+	defer file.Close()
+
+	sheet := file.GetSheetName(0)
+	writer, err := file.NewStreamWriter(sheet)
+	if err != nil {
+		return "", err
+	}
+
+	bindings := state.Mapping.Columns
+	header := []interface{}{}
+	for _, binding := range bindings {
+		name := binding.Name
+		if name == "" {
+			name = binding.Label
+		}
+		header = append(header, name)
+	}
+	header = append(header, "行号", "校验规则", "错误信息")
+	err = writer.SetRow("A1", header)
+	if err != nil {
+		return "", err
+	}
+
+	line := 1
+	scanner := bufio.NewScanner(src)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var reject Reject
+		err := jsoniter.Unmarshal(scanner.Bytes(), &reject)
+		if err != nil {
+			return "", err
+		}
+
+		row := make([]interface{}, len(bindings), len(bindings)+3)
+		copy(row, reject.Row)
+		row = append(row, reject.Line, strings.Join(reject.Rules, "\n"), strings.Join(reject.Messages, "\n"))
+
+		line++
+		cell, err := excelize.CoordinatesToCellName(1, line)
+		if err != nil {
+			return "", err
+		}
+		err = writer.SetRow(cell, row)
+		if err != nil {
+			return "", err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		return "", err
+	}
+
+	name := filepath.Join("importer", "errors", fmt.Sprintf("%s.xlsx", state.ID))
+	err = os.MkdirAll(filepath.Join(DataRoot, "importer", "errors"), os.ModePerm)
+	if err != nil {
+		return "", err
+	}
+
+	err = file.SaveAs(filepath.Join(DataRoot, name))
+	if err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+func rejectsFile(id string) string {
+	return filepath.Join(DataRoot, "importer", "runs", fmt.Sprintf("%s.rejects.jsonl", id))
+}
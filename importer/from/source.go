@@ -24,6 +24,11 @@ type Source interface {
 	Close() error
 }
 
+// Counter 可选接口, 返回数据总行数 (用于计算导入进度)
+type Counter interface {
+	Count() int
+}
+
 // Column 源数据列
 type Column struct {
 	Name string
@@ -1,6 +1,7 @@
 package importer
 
 import (
+	"context"
 	"crypto/sha256"
 	"fmt"
 	"path/filepath"
@@ -16,6 +17,8 @@ import (
 	"github.com/yaoapp/kun/log"
 	"github.com/yaoapp/yao/config"
 	"github.com/yaoapp/yao/importer/from"
+	"github.com/yaoapp/yao/importer/json"
+	"github.com/yaoapp/yao/importer/parquet"
 	"github.com/yaoapp/yao/importer/xlsx"
 	"github.com/yaoapp/yao/share"
 )
@@ -59,6 +62,7 @@ func Load(cfg config.Config) error {
 			return fmt.Errorf("%s 导入配置错误. %s", id, err.Error())
 		}
 
+		importer.ID = id
 		Importers[id] = &importer
 		return nil
 	}, exts...)
@@ -80,6 +84,12 @@ func Open(name string) from.Source {
 	case "xlsx":
 		file := filepath.Join(DataRoot, name)
 		return xlsx.Open(file)
+	case "json", "jsonl", "ndjson":
+		file := filepath.Join(DataRoot, name)
+		return json.Open(file)
+	case "parquet":
+		file := filepath.Join(DataRoot, name)
+		return parquet.Open(file)
 	}
 	exception.New("暂不支持: %s 文件导入", 400, ext).Throw()
 	return nil
@@ -119,6 +129,8 @@ func (imp *Importer) AutoMapping(src from.Source) *Mapping {
 					Name:  srcCol.Name,
 					Axis:  srcCol.Axis,
 					Rules: imp.Columns[i].Rules,
+
+					Confidence: 1,
 				}
 				continue
 			}
@@ -155,20 +167,33 @@ func (imp *Importer) Chunk(src from.Source, mapping *Mapping, cb func(line int,
 
 // DataClean 清洗数据
 func (imp *Importer) DataClean(data [][]interface{}, bindings []*Binding) ([]string, [][]interface{}) {
+	columns, new, _ := imp.dataClean(data, bindings)
+	return columns, new
+}
+
+// dataClean 清洗数据, 同时返回未通过校验的记录 (Reject.Line 为记录在 data 中的位置)
+func (imp *Importer) dataClean(data [][]interface{}, bindings []*Binding) ([]string, [][]interface{}, []*Reject) {
 	columns := []string{}
 	new := [][]interface{}{}
+	rejects := []*Reject{}
 
 	for _, binding := range bindings {
 		columns = append(columns, binding.Field)
 	}
 	// 清洗数据
-	for _, row := range data {
+	for idx, row := range data {
 		success := true
+		reject := &Reject{Line: idx, Row: append([]interface{}{}, row...), Rules: []string{}, Messages: []string{}}
 		for i, binding := range bindings { // 调用字段清洗处理器
 			for _, rule := range binding.Rules {
-				update, ok := DataValidate(row, row[i], rule)
+				update, message, ok := dataValidate(row, row[i], rule)
 				if !ok {
 					success = false
+					if message == "" {
+						message = fmt.Sprintf("%s 未通过校验", binding.Label)
+					}
+					reject.Rules = append(reject.Rules, rule)
+					reject.Messages = append(reject.Messages, message)
 				} else {
 					row = update
 				}
@@ -176,30 +201,45 @@ func (imp *Importer) DataClean(data [][]interface{}, bindings []*Binding) ([]str
 		}
 		row = append(row, success)
 		new = append(new, row)
+		if !success {
+			rejects = append(rejects, reject)
+		}
 	}
 
 	columns = append(columns, "__effected")
-	return columns, new
+	return columns, new, rejects
 }
 
 // DataValidate 数值校验
 func DataValidate(row []interface{}, value interface{}, rule string) ([]interface{}, bool) {
+	row, _, ok := dataValidate(row, value, rule)
+	return row, ok
+}
+
+// dataValidate 数值校验, 校验失败时返回清洗规则给出的错误信息 (字符串或 {"message": "..."})
+func dataValidate(row []interface{}, value interface{}, rule string) ([]interface{}, string, bool) {
 	process, err := process.Of(rule, value, row)
 	if err != nil {
 		log.With(log.F{"rule": rule, "row": row}).Error("DataValidate: %s", err.Error())
-		return row, true
+		return row, "", true
 	}
 	res, err := process.Exec()
 	if err != nil {
 		log.With(log.F{"rule": rule, "row": row}).Error("DataValidate: %s", err.Error())
-		return row, true
+		return row, "", true
 	}
 
-	if update, ok := res.([]interface{}); ok {
-		row = update
-		return update, true
+	switch v := res.(type) {
+	case []interface{}:
+		return v, "", true
+	case string:
+		return row, v, false
+	case map[string]interface{}:
+		if message, ok := v["message"].(string); ok {
+			return row, message, false
+		}
 	}
-	return row, false
+	return row, "", false
 }
 
 // DataPreview 预览数据
@@ -243,11 +283,15 @@ func (imp *Importer) DataPreview(src from.Source, page int, size int, mapping *M
 // MappingPreview 预览字段映射关系
 func (imp *Importer) MappingPreview(src from.Source) *Mapping {
 
-	// 模板匹配(下一版实现)
-	// tpl := imp.Fingerprint(src)
-	// 查找已有模板
+	// 模板匹配
+	var mapping *Mapping
+	if imp.Option.UseTemplate {
+		mapping = imp.Template(src)
+	}
 
-	mapping := imp.AutoMapping(src) // 自动匹配
+	if mapping == nil {
+		mapping = imp.SmartMapping(src) // 自动匹配, 未匹配的字段由 AI 或相似度给出建议
+	}
 
 	// 预设值
 	columns, rows := imp.DataGet(src, 1, 1, mapping)
@@ -357,6 +401,20 @@ func (imp *Importer) MappingSetting(src from.Source) map[string]interface{} {
 				Props: map[string]interface{}{"options": imp.getRulesOption(), "value": ":rules", "mode": "multiple"},
 			},
 		},
+		"建议规则": {
+			Label: "建议规则",
+			View: share.Render{
+				Type:  "tag",
+				Props: map[string]interface{}{"value": ":suggest"},
+			},
+		},
+		"置信度": {
+			Label: "置信度",
+			View: share.Render{
+				Type:  "label",
+				Props: map[string]interface{}{"value": ":confidence"},
+			},
+		},
 		"数据示例": {
 			Label: "数据示例",
 			View: share.Render{
@@ -375,6 +433,8 @@ func (imp *Importer) MappingSetting(src from.Source) map[string]interface{} {
 					{"name": "字段名称"},
 					{"name": "数据源"},
 					{"name": "清洗规则", "width": 300},
+					{"name": "建议规则", "width": 200},
+					{"name": "置信度", "width": 80},
 					{"name": "数据示例"},
 				},
 			},
@@ -400,78 +460,97 @@ func (imp *Importer) Fingerprint(src from.Source) string {
 	return fmt.Sprintf("%x", hash.Sum(nil))
 }
 
-// SaveAsTemplate 保存为映射模板
-func (imp *Importer) SaveAsTemplate(src from.Source) {
-}
-
 // Run 运行导入
 func (imp *Importer) Run(src from.Source, mapping *Mapping) interface{} {
 	if mapping == nil {
 		mapping = imp.AutoMapping(src)
+	} else {
+		imp.rememberMapping(src, mapping)
 	}
 
-	id := uuid.NewString()
+	state := &State{ID: uuid.NewString(), Importer: imp.ID, Mapping: mapping, Sid: imp.Sid}
+	imp.execute(context.Background(), src, state, nil)
+
+	output := state.output()
+	if imp.Output != "" {
+		res, err := process.New(imp.Output, output).WithSID(imp.Sid).Exec()
+		if err != nil {
+			log.With(log.F{"output": imp.Output}).Error("%v", err)
+			return output
+		}
+		return res
+	}
+
+	return output
+}
+
+// execute 分块导入数据. 跳过 state.Chunk 之前已完成的分块, ctx 取消后不再处理后续分块
+// 每个分块完成后调用 done, 传入该分块未通过校验或导入失败的记录
+func (imp *Importer) execute(ctx context.Context, src from.Source, state *State, done func(state *State, rejects []*Reject)) {
 	page := 0
-	total := 0
-	failed := 0
-	ignore := 0
-	imp.Chunk(src, mapping, func(line int, data [][]interface{}) {
+	imp.Chunk(src, state.Mapping, func(line int, data [][]interface{}) {
 		page++
-		length := len(data)
-		total = total + length
-		columns, data := imp.DataClean(data, mapping.Columns)
-		process, err := process.Of(imp.Process, columns, data, id, page)
-		if err != nil {
-			failed = failed + length
-			log.With(log.F{"line": line}).Error("导入失败: %s", err.Error())
+		if page <= state.Chunk || ctx.Err() != nil {
 			return
 		}
 
-		response, err := process.WithSID(imp.Sid).Exec()
+		length := len(data)
+		first := line - length + 1
+		sources := make([][]interface{}, length)
+		for i, row := range data {
+			sources[i] = append([]interface{}{}, row...)
+		}
+
+		columns, data, rejects := imp.dataClean(data, state.Mapping.Columns)
+		for _, reject := range rejects {
+			reject.Line = first + reject.Line
+		}
+
+		failed, ignore, err := imp.importChunk(columns, data, state, page)
 		if err != nil {
-			failed = failed + length
 			log.With(log.F{"line": line}).Error("导入失败: %s", err.Error())
-			return
+			rejects = []*Reject{}
+			for i, row := range sources {
+				rejects = append(rejects, &Reject{Line: first + i, Row: row, Rules: []string{imp.Process}, Messages: []string{err.Error()}})
+			}
 		}
 
-		if res, ok := response.([]int); ok && len(res) > 1 {
-			failed = failed + res[0]
-			ignore = ignore + res[1]
-			return
-		} else if res, ok := response.([]int64); ok && len(res) > 1 {
-			failed = failed + int(res[0])
-			ignore = ignore + int(res[1])
-			return
-		} else if res, ok := response.([]interface{}); ok && len(res) > 1 {
-			failed = failed + any.Of(res[0]).CInt()
-			ignore = ignore + any.Of(res[1]).CInt()
-			return
+		state.Total = state.Total + length
+		state.Failure = state.Failure + failed
+		state.Ignore = state.Ignore + ignore
+		state.Rejected = state.Rejected + len(rejects)
+		state.Chunk = page
+		state.Line = line
+		if done != nil {
+			done(state, rejects)
 		}
-
-		log.With(log.F{"line": line, "response": response, "length": length}).Error("导入处理器未返回失败结果")
 	})
+}
 
-	output := map[string]int{
-		"total":   total,
-		"success": total - failed - ignore,
-		"failure": failed,
-		"ignore":  ignore,
+// importChunk 调用导入处理器, 返回失败和忽略的记录数
+func (imp *Importer) importChunk(columns []string, data [][]interface{}, state *State, page int) (int, int, error) {
+	length := len(data)
+	process, err := process.Of(imp.Process, columns, data, state.ID, page)
+	if err != nil {
+		return length, 0, err
 	}
 
-	if imp.Output != "" {
-		res, err := process.New(imp.Output, output).WithSID(imp.Sid).Exec()
-		if err != nil {
-			log.With(log.F{"output": imp.Output}).Error("%v", err)
-			return output
-		}
-		return res
+	response, err := process.WithSID(state.Sid).Exec()
+	if err != nil {
+		return length, 0, err
 	}
 
-	return output
-}
+	if res, ok := response.([]int); ok && len(res) > 1 {
+		return res[0], res[1], nil
+	} else if res, ok := response.([]int64); ok && len(res) > 1 {
+		return int(res[0]), int(res[1]), nil
+	} else if res, ok := response.([]interface{}); ok && len(res) > 1 {
+		return any.Of(res[0]).CInt(), any.Of(res[1]).CInt(), nil
+	}
 
-// Start 运行导入(异步)
-func (imp *Importer) Start() {}
+	log.With(log.F{"page": page, "response": response, "length": length}).Error("导入处理器未返回失败结果")
+	return 0, 0, nil
+}
 
 // getSourceColumns 读取源数据字段映射表
 func getSourceColumns(src from.Source) map[string]from.Column {
@@ -89,6 +89,32 @@ func TestDataGetSimple(t *testing.T) {
 
 }
 
+func TestDataCleanRejects(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+
+	root := prepare(t, config.Conf)
+	simple := filepath.Join(root, "assets", "simple.xlsx")
+	file := xlsx.Open(simple)
+	defer file.Close()
+
+	imp := Select("order")
+	mapping := imp.AutoMapping(file)
+	axises := []string{}
+	for _, binding := range mapping.Columns {
+		axises = append(axises, binding.Axis)
+	}
+	data := file.Data(mapping.RowStart, 2, axises)
+
+	_, rows, rejects := imp.dataClean(data, mapping.Columns)
+	assert.Equal(t, 2, len(rows))
+	assert.Equal(t, 1, len(rejects))
+	assert.Equal(t, 1, rejects[0].Line)
+	assert.Equal(t, "", rejects[0].Row[0])
+	assert.NotEmpty(t, rejects[0].Rules)
+	assert.Equal(t, len(rejects[0].Rules), len(rejects[0].Messages))
+}
+
 func TestDataChunkSimple(t *testing.T) {
 	test.Prepare(t, config.Conf)
 	defer test.Clean()
@@ -176,6 +202,34 @@ func TestMappingPreviewSimple(t *testing.T) {
 	}
 }
 
+func TestMappingPreviewTemplate(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+
+	root := prepare(t, config.Conf)
+	simple := filepath.Join(root, "assets", "simple.xlsx")
+	file := xlsx.Open(simple)
+	defer file.Close()
+
+	imp := Select("order")
+	mapping := imp.AutoMapping(file)
+	mapping.Columns[9].Axis = mapping.Columns[0].Axis
+	mapping.Columns[9].Name = mapping.Columns[0].Name
+
+	err := imp.SaveAsTemplate(file, mapping)
+	assert.Nil(t, err)
+	defer imp.RemoveTemplate(file)
+
+	preview := imp.MappingPreview(file)
+	assert.Equal(t, false, preview.AutoMatching)
+	assert.Equal(t, true, preview.TemplateMatching)
+	assert.Equal(t, mapping.Columns[0].Axis, preview.Columns[9].Axis)
+	assert.NotEmpty(t, preview.Columns[9].Value)
+
+	imp.RemoveTemplate(file)
+	assert.Nil(t, imp.Template(file))
+}
+
 func TestMappingSetting(t *testing.T) {
 	test.Prepare(t, config.Conf)
 	defer test.Clean()
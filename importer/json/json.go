@@ -0,0 +1,329 @@
+package json
+
+import (
+	"bufio"
+	"bytes"
+	ejson "encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/yaoapp/kun/exception"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/importer/from"
+)
+
+// SampleSize 推断列时扫描的记录数
+const SampleSize = 100
+
+// JSON JSON 数组或 JSONL (每行一条记录) 文件
+// 嵌套对象展开为点号路径 (如 customer.name), 路径即列坐标
+type JSON struct {
+	File     *os.File
+	Name     string
+	RowStart int
+	ColStart int
+	columns  []from.Column
+}
+
+// Open 打开 JSON / JSONL 文件
+func Open(filename string) *JSON {
+	file, err := os.Open(filename)
+	if err != nil {
+		exception.New("打开文件错误 %s", 400, err.Error()).Throw()
+	}
+	return &JSON{File: file, Name: filepath.Base(filename), RowStart: 1, ColStart: 1}
+}
+
+// Close 关闭文件句柄
+func (src *JSON) Close() error {
+	if err := src.File.Close(); err != nil {
+		log.Error("Close file error: %s", err.Error())
+		return err
+	}
+	return nil
+}
+
+// Inspect 基本信息
+func (src *JSON) Inspect() from.Inspect {
+	return from.Inspect{
+		SheetName: src.Name,
+		RowStart:  src.RowStart,
+		ColStart:  src.ColStart,
+	}
+}
+
+// Count 记录总数 (扫描整个文件, 不展开记录内容)
+func (src *JSON) Count() int {
+	count := 0
+	src.each(func(line int, raw ejson.RawMessage) bool {
+		count = line
+		return true
+	})
+	return count
+}
+
+// Columns 读取列, 按前 SampleSize 条记录中路径首次出现的顺序排列
+func (src *JSON) Columns() []from.Column {
+	if src.columns != nil {
+		return src.columns
+	}
+
+	columns := []from.Column{}
+	index := map[string]int{}
+	src.each(func(line int, raw ejson.RawMessage) bool {
+		record := src.record(line, raw)
+		for _, path := range keys(raw) {
+			i, has := index[path]
+			if !has {
+				i = len(columns)
+				index[path] = i
+				columns = append(columns, from.Column{Name: path, Axis: path, Type: from.TUnknown})
+			}
+			if columns[i].Type == from.TUnknown {
+				columns[i].Type = typeOf(record[path])
+			}
+		}
+		return line < SampleSize
+	})
+
+	src.columns = columns
+	return columns
+}
+
+// Data 读取数据, row 为记录序号 (从 1 开始)
+func (src *JSON) Data(row int, size int, axises []string) [][]interface{} {
+	data := [][]interface{}{}
+	if size <= 0 {
+		return data
+	}
+	src.each(func(line int, raw ejson.RawMessage) bool {
+		if line < row {
+			return true
+		}
+		data = append(data, readLine(src.record(line, raw), axises))
+		return len(data) < size
+	})
+	return data
+}
+
+// Chunk 遍历数据, line 为当前批次最后一条记录的序号
+func (src *JSON) Chunk(size int, axises []string, cb func(line int, data [][]interface{})) {
+	last := 0
+	data := [][]interface{}{}
+	src.each(func(line int, raw ejson.RawMessage) bool {
+		last = line
+		if line < src.RowStart {
+			return true
+		}
+		data = append(data, readLine(src.record(line, raw), axises))
+		if len(data) >= size {
+			cb(line, data)
+			data = [][]interface{}{}
+		}
+		return true
+	})
+
+	// 最后一批数据
+	if len(data) > 0 {
+		cb(last, data)
+	}
+}
+
+// each 从文件开头逐条读取记录 (流式读取, 不会一次载入整个文件), cb 返回 false 时停止
+// 文件以 [ 开头按 JSON 数组读取, 否则按连续的 JSON 值 (JSONL) 读取
+func (src *JSON) each(cb func(line int, raw ejson.RawMessage) bool) {
+	_, err := src.File.Seek(0, io.SeekStart)
+	if err != nil {
+		exception.New("读取文件失败 %s", 400, err.Error()).Throw()
+	}
+
+	reader := bufio.NewReader(src.File)
+	array, err := isArray(reader)
+	if err != nil {
+		exception.New("读取文件失败 %s", 400, err.Error()).Throw()
+	}
+
+	decoder := ejson.NewDecoder(reader)
+	if array {
+		if _, err := decoder.Token(); err != nil {
+			exception.New("数据格式错误 %s", 400, err.Error()).Throw()
+		}
+	}
+
+	line := 0
+	for {
+		if array && !decoder.More() {
+			return
+		}
+
+		var raw ejson.RawMessage
+		err := decoder.Decode(&raw)
+		if err == io.EOF && !array {
+			return
+		}
+
+		line++
+		if err != nil {
+			exception.New("数据格式错误 第 %d 条记录 %s", 400, line, err.Error()).Throw()
+		}
+		if !cb(line, raw) {
+			return
+		}
+	}
+}
+
+// record 解析记录并展开为 路径 => 值
+func (src *JSON) record(line int, raw ejson.RawMessage) map[string]interface{} {
+	decoder := ejson.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		exception.New("数据格式错误 第 %d 条记录 %s", 400, line, err.Error()).Throw()
+	}
+	return flatten(value)
+}
+
+// isArray 跳过 BOM 和空白字符, 判断文件是否为 JSON 数组
+func isArray(reader *bufio.Reader) (bool, error) {
+	bom, err := reader.Peek(3)
+	if err == nil && bytes.Equal(bom, []byte{0xEF, 0xBB, 0xBF}) {
+		reader.Discard(3)
+	}
+
+	for {
+		c, err := reader.ReadByte()
+		if err == io.EOF {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		switch c {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		return c == '[', reader.UnreadByte()
+	}
+}
+
+// flatten 将嵌套对象展开为点号路径, 数组保持原值. 非对象记录以 value 为列名
+func flatten(value interface{}) map[string]interface{} {
+	record := map[string]interface{}{}
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		record["value"] = normalize(value)
+		return record
+	}
+	flattenTo(record, object, "")
+	return record
+}
+
+func flattenTo(record map[string]interface{}, object map[string]interface{}, prefix string) {
+	for key, value := range object {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if child, ok := value.(map[string]interface{}); ok && len(child) > 0 {
+			flattenTo(record, child, path)
+			continue
+		}
+		record[path] = normalize(value)
+	}
+}
+
+// normalize 数字转换为 int64 或 float64, 嵌套数组中的数字同样转换
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case ejson.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case []interface{}:
+		for i := range v {
+			v[i] = normalize(v[i])
+		}
+		return v
+	case map[string]interface{}:
+		for key := range v {
+			v[key] = normalize(v[key])
+		}
+		return v
+	}
+	return value
+}
+
+// readLine 按坐标 (路径) 读取记录
+func readLine(record map[string]interface{}, axises []string) []interface{} {
+	row := make([]interface{}, len(axises))
+	for i, axis := range axises {
+		row[i] = record[axis]
+	}
+	return row
+}
+
+// typeOf 值类型
+func typeOf(value interface{}) byte {
+	switch value.(type) {
+	case string:
+		return from.TString
+	case int64, float64:
+		return from.TNumber
+	case bool:
+		return from.TBool
+	}
+	return from.TUnknown
+}
+
+// keys 按记录中出现的顺序返回展开后的路径 (与 flatten 规则一致)
+func keys(raw ejson.RawMessage) []string {
+	decoder := ejson.NewDecoder(bytes.NewReader(raw))
+	token, err := decoder.Token()
+	if err != nil {
+		return []string{}
+	}
+	if delim, ok := token.(ejson.Delim); !ok || delim != '{' {
+		return []string{"value"}
+	}
+
+	res := []string{}
+	keysTo(decoder, "", &res)
+	return res
+}
+
+// keysTo 读取对象的键直到对象结束 (起始的 { 已读取)
+func keysTo(decoder *ejson.Decoder, prefix string, res *[]string) {
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return
+		}
+
+		path, _ := token.(string)
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+
+		var value ejson.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return
+		}
+
+		trimmed := bytes.TrimSpace(value)
+		if len(trimmed) > 0 && trimmed[0] == '{' {
+			child := ejson.NewDecoder(bytes.NewReader(trimmed))
+			child.Token()
+			if child.More() {
+				keysTo(child, path, res)
+				continue
+			}
+		}
+		*res = append(*res, path)
+	}
+}
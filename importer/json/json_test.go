@@ -0,0 +1,88 @@
+package json
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/yao/importer/from"
+)
+
+const orders = `[
+	{"sn": "SN-001", "amount": 12.5, "paid": true, "customer": {"name": "Alice", "address": {"city": "Beijing"}}, "tags": ["a", "b"]},
+	{"sn": "SN-002", "amount": 8, "paid": false, "customer": {"name": "Bob"}, "remark": "urgent"},
+	{"sn": "SN-003", "amount": null, "customer": {"name": "Carol", "address": {"city": "Shanghai"}}}
+]`
+
+func TestColumns(t *testing.T) {
+	src := Open(write(t, "orders.json", orders))
+	defer src.Close()
+
+	columns := src.Columns()
+	names := []string{}
+	for _, col := range columns {
+		names = append(names, col.Name)
+		assert.Equal(t, col.Name, col.Axis)
+	}
+	assert.Equal(t, []string{"sn", "amount", "paid", "customer.name", "customer.address.city", "tags", "remark"}, names)
+	assert.Equal(t, from.TString, columns[0].Type)
+	assert.Equal(t, from.TNumber, columns[1].Type)
+	assert.Equal(t, from.TBool, columns[2].Type)
+	assert.Equal(t, from.TUnknown, columns[5].Type)
+
+	inspect := src.Inspect()
+	assert.Equal(t, "orders.json", inspect.SheetName)
+	assert.Equal(t, 1, inspect.RowStart)
+	assert.Equal(t, 3, src.Count())
+}
+
+func TestDataAndChunk(t *testing.T) {
+	src := Open(write(t, "orders.json", orders))
+	defer src.Close()
+
+	axises := []string{"sn", "customer.address.city", "amount"}
+	data := src.Data(2, 5, axises)
+	assert.Equal(t, [][]interface{}{{"SN-002", nil, int64(8)}, {"SN-003", "Shanghai", nil}}, data)
+
+	lines := []int{}
+	rows := [][]interface{}{}
+	src.Chunk(2, axises, func(line int, data [][]interface{}) {
+		lines = append(lines, line)
+		rows = append(rows, data...)
+	})
+	assert.Equal(t, []int{2, 3}, lines)
+	assert.Equal(t, []interface{}{"SN-001", "Beijing", 12.5}, rows[0])
+	assert.Equal(t, 3, len(rows))
+}
+
+func TestJSONL(t *testing.T) {
+	content := "{\"id\": 1, \"user\": {\"name\": \"Alice\"}}\n\n{\"id\": 2, \"user\": {\"name\": \"Bob\"}}\n{\"id\": 3}\n"
+	src := Open(write(t, "users.jsonl", content))
+	defer src.Close()
+
+	assert.Equal(t, 2, len(src.Columns()))
+	assert.Equal(t, 3, src.Count())
+
+	rows := [][]interface{}{}
+	src.Chunk(10, []string{"id", "user.name"}, func(line int, data [][]interface{}) {
+		assert.Equal(t, 3, line)
+		rows = append(rows, data...)
+	})
+	assert.Equal(t, [][]interface{}{{int64(1), "Alice"}, {int64(2), "Bob"}, {int64(3), nil}}, rows)
+}
+
+func TestInvalidRecord(t *testing.T) {
+	src := Open(write(t, "broken.jsonl", "{\"id\": 1}\n{\"id\": \n"))
+	defer src.Close()
+	assert.Panics(t, func() { src.Count() })
+}
+
+func write(t *testing.T, name string, content string) string {
+	file := filepath.Join(t.TempDir(), name)
+	err := os.WriteFile(file, []byte(content), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
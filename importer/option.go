@@ -48,6 +48,10 @@ func OptionOf(data map[string]interface{}) (*Option, error) {
 		option.DataPreview = getPreviewOption(dataPreview)
 	}
 
+	if connector, ok := data["connector"].(string); ok {
+		option.Connector = connector
+	}
+
 	return option, nil
 }
 
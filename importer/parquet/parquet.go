@@ -0,0 +1,170 @@
+package parquet
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/yaoapp/kun/exception"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/importer/from"
+)
+
+// Parquet Parquet 文件
+// 嵌套分组展开为点号路径 (如 customer.name), 路径即列坐标; 只包含基本类型元素的 LIST 读取为数组
+// 按行组 (Row Group) 流式读取, 每次只载入当前行组中映射的列
+// 文件结构、编码和压缩由 github.com/parquet-go/parquet-go 解析
+type Parquet struct {
+	File     *os.File
+	Name     string
+	RowStart int
+	ColStart int
+	file     *parquet.File
+	leaves   []*leaf
+}
+
+// Open 打开 Parquet 文件
+func Open(filename string) *Parquet {
+	file, err := os.Open(filename)
+	if err != nil {
+		exception.New("打开文件错误 %s", 400, err.Error()).Throw()
+	}
+
+	pf, leaves, err := openFile(file)
+	if err != nil {
+		file.Close()
+		exception.New("读取 Parquet 文件元数据失败 %s", 400, err.Error()).Throw()
+	}
+
+	return &Parquet{
+		File:     file,
+		Name:     filepath.Base(filename),
+		RowStart: 1,
+		ColStart: 1,
+		file:     pf,
+		leaves:   leaves,
+	}
+}
+
+// Close 关闭文件句柄
+func (src *Parquet) Close() error {
+	if err := src.File.Close(); err != nil {
+		log.Error("Close file error: %s", err.Error())
+		return err
+	}
+	return nil
+}
+
+// Inspect 基本信息
+func (src *Parquet) Inspect() from.Inspect {
+	return from.Inspect{
+		SheetName: src.Name,
+		RowStart:  src.RowStart,
+		ColStart:  src.ColStart,
+	}
+}
+
+// Count 数据行数 (读取自文件元数据)
+func (src *Parquet) Count() int {
+	return int(src.file.NumRows())
+}
+
+// Columns 读取列 (多级嵌套的重复字段不支持导入, 不会出现在列中)
+func (src *Parquet) Columns() []from.Column {
+	columns := []from.Column{}
+	names := map[string]bool{}
+	for _, lf := range src.leaves {
+		if lf.maxRep > 1 || names[lf.name] {
+			continue
+		}
+		names[lf.name] = true
+
+		typ := columnType(lf.column)
+		if lf.maxRep > 0 {
+			typ = from.TUnknown
+		}
+		columns = append(columns, from.Column{Name: lf.name, Axis: lf.name, Type: typ})
+	}
+	return columns
+}
+
+// Data 读取数据, row 为行号 (从 1 开始)
+func (src *Parquet) Data(row int, size int, axises []string) [][]interface{} {
+	data := [][]interface{}{}
+	if size <= 0 {
+		return data
+	}
+	src.each(row, axises, func(line int, values []interface{}) bool {
+		data = append(data, values)
+		return len(data) < size
+	})
+	return data
+}
+
+// Chunk 遍历数据, line 为当前批次最后一行的行号
+func (src *Parquet) Chunk(size int, axises []string, cb func(line int, data [][]interface{})) {
+	last := 0
+	data := [][]interface{}{}
+	src.each(src.RowStart, axises, func(line int, values []interface{}) bool {
+		last = line
+		data = append(data, values)
+		if len(data) >= size {
+			cb(line, data)
+			data = [][]interface{}{}
+		}
+		return true
+	})
+
+	// 最后一批数据
+	if len(data) > 0 {
+		cb(last, data)
+	}
+}
+
+// each 从第 row 行开始逐行读取, 跳过之前的行组, cb 返回 false 时停止
+func (src *Parquet) each(row int, axises []string, cb func(line int, values []interface{}) bool) {
+	index := map[string]*leaf{}
+	for _, lf := range src.leaves {
+		if _, has := index[lf.name]; !has {
+			index[lf.name] = lf
+		}
+	}
+
+	line := 0
+	for g, group := range src.file.RowGroups() {
+		rows := int(group.NumRows())
+		if line+rows < row {
+			line += rows
+			continue
+		}
+
+		columns := make([][]interface{}, len(axises))
+		for i, axis := range axises {
+			lf, has := index[axis]
+			if !has {
+				continue
+			}
+			values, err := readColumn(group, src.file.Metadata().RowGroups[g], lf)
+			if err != nil {
+				exception.New("读取数据失败 %s %s", 400, axis, err.Error()).Throw()
+			}
+			columns[i] = values
+		}
+
+		for r := 0; r < rows; r++ {
+			line++
+			if line < row {
+				continue
+			}
+			values := make([]interface{}, len(axises))
+			for i := range axises {
+				if columns[i] != nil {
+					values[i] = columns[i][r]
+				}
+			}
+			if !cb(line, values) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package parquet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/yao/importer/from"
+)
+
+// testdata/orders.parquet: 2 个行组共 6 行, 覆盖字典、DELTA 编码, SNAPPY、GZIP、ZSTD 压缩,
+// DECIMAL、TIMESTAMP 逻辑类型, 嵌套分组和 LIST
+func TestColumns(t *testing.T) {
+	src := Open(filepath.Join("testdata", "orders.parquet"))
+	defer src.Close()
+
+	columns := src.Columns()
+	assert.Equal(t, []from.Column{
+		{Name: "id", Axis: "id", Type: from.TNumber},
+		{Name: "name", Axis: "name", Type: from.TString},
+		{Name: "customer.city", Axis: "customer.city", Type: from.TString},
+		{Name: "amount", Axis: "amount", Type: from.TNumber},
+		{Name: "paid", Axis: "paid", Type: from.TBool},
+		{Name: "created", Axis: "created", Type: from.TDatetime},
+		{Name: "tags", Axis: "tags", Type: from.TUnknown},
+	}, columns)
+
+	assert.Equal(t, 6, src.Count())
+	assert.Equal(t, "orders.parquet", src.Inspect().SheetName)
+	assert.Equal(t, 1, src.Inspect().RowStart)
+}
+
+func TestChunk(t *testing.T) {
+	src := Open(filepath.Join("testdata", "orders.parquet"))
+	defer src.Close()
+
+	axises := []string{"id", "name", "customer.city", "amount", "paid", "created", "tags", "missing"}
+	lines := []int{}
+	rows := [][]interface{}{}
+	src.Chunk(4, axises, func(line int, data [][]interface{}) {
+		lines = append(lines, line)
+		rows = append(rows, data...)
+	})
+
+	assert.Equal(t, []int{4, 6}, lines)
+	assert.Equal(t, []interface{}{int64(1), "Alice", "Beijing", 12.5, true, time.UnixMilli(1700000000000).UTC(), []interface{}{"a", "b"}, nil}, rows[0])
+	assert.Equal(t, []interface{}{int64(2), "Bob", nil, 8.0, false, time.UnixMilli(1700000001000).UTC(), []interface{}{}, nil}, rows[1])
+	assert.Equal(t, []interface{}{int64(3), nil, nil, -0.05, true, time.UnixMilli(1700000002000).UTC(), []interface{}{}, nil}, rows[2])
+	assert.Equal(t, []interface{}{int64(100), "Carol", "Shanghai", 1.0, false, time.Unix(0, 0).UTC(), []interface{}{"c", nil}, nil}, rows[3])
+	assert.Equal(t, []interface{}{int64(103), "Caroline", "Shenzhen", 0.0, false, time.Unix(86400, 0).UTC(), []interface{}{"d"}, nil}, rows[4])
+	assert.Equal(t, []interface{}{int64(104), "Dave", nil, 999.99, true, time.UnixMilli(1700000000000).UTC(), []interface{}{}, nil}, rows[5])
+}
+
+func TestData(t *testing.T) {
+	src := Open(filepath.Join("testdata", "orders.parquet"))
+	defer src.Close()
+
+	data := src.Data(3, 2, []string{"id", "name"})
+	assert.Equal(t, [][]interface{}{{int64(3), nil}, {int64(100), "Carol"}}, data)
+
+	data = src.Data(6, 10, []string{"id"})
+	assert.Equal(t, [][]interface{}{{int64(104)}}, data)
+}
+
+// testdata/mixed.parquet: 同一列在两个行组中的压缩算法不同
+func TestMixedCodecs(t *testing.T) {
+	src := Open(filepath.Join("testdata", "mixed.parquet"))
+	defer src.Close()
+
+	assert.Equal(t, [][]interface{}{{int64(1)}}, src.Data(1, 1, []string{"id"}))
+	assert.Panics(t, func() { src.Data(4, 1, []string{"customer.city"}) })
+}
+
+func TestOpenInvalid(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "invalid.parquet")
+	err := os.WriteFile(file, []byte("PAR1 not a parquet file"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Panics(t, func() { Open(file) })
+}
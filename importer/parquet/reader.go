@@ -0,0 +1,294 @@
+package parquet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/format"
+	"github.com/yaoapp/yao/importer/from"
+)
+
+// julianUnixEpoch 1970-01-01 的儒略日 (INT96 时间戳)
+const julianUnixEpoch = 2440588
+
+// maxChunkSize 单个列块解压后的上限 (读取自文件元数据)
+const maxChunkSize = 1 << 30
+
+// errChunkSize 列块解压后的大小超出上限
+var errChunkSize = errors.New("column chunk is too large")
+
+// leaf 数据列 (表结构叶子节点)
+type leaf struct {
+	index  int    // 行组中的列序号
+	name   string // 点号路径
+	column *parquet.Column
+	maxDef int
+	maxRep int
+	repDef int // 重复节点的定义级别
+}
+
+// openFile 读取文件元数据, 元数据错误时解析库可能 panic, 统一返回错误
+func openFile(file *os.File) (pf *parquet.File, leaves []*leaf, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pf, err = parquet.OpenFile(file, stat.Size(), parquet.SkipPageIndex(true), parquet.SkipBloomFilters(true))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	leaves = []*leaf{}
+	for _, column := range pf.Root().Columns() {
+		walk(column, []string{}, 0, 0, 0, "", &leaves)
+	}
+	return pf, leaves, nil
+}
+
+// walk 计算叶子节点的路径和定义/重复级别
+// 只包含一个基本类型元素的 LIST 以列表名称作为列名
+func walk(column *parquet.Column, path []string, def int, rep int, repDef int, list string, leaves *[]*leaf) {
+	path = append(path, column.Name())
+	switch {
+	case column.Optional():
+		def++
+	case column.Repeated():
+		def++
+		rep++
+		repDef = def
+	}
+
+	if list == "" && isList(column) {
+		list = strings.Join(path, ".")
+	}
+
+	if column.Leaf() {
+		name := strings.Join(path, ".")
+		if rep > 0 && list != "" {
+			name = list
+		}
+		*leaves = append(*leaves, &leaf{index: column.Index(), name: name, column: column, maxDef: def, maxRep: rep, repDef: repDef})
+		return
+	}
+
+	for _, child := range column.Columns() {
+		walk(child, path, def, rep, repDef, list, leaves)
+	}
+}
+
+// isList 是否为只包含一个基本类型元素的 LIST 分组
+func isList(column *parquet.Column) bool {
+	if _, ok := logicalType(column).(*format.ListType); !ok {
+		return false
+	}
+	children := column.Columns()
+	if len(children) != 1 || !children[0].Repeated() {
+		return false
+	}
+	item := children[0]
+	return item.Leaf() || (len(item.Columns()) == 1 && item.Columns()[0].Leaf())
+}
+
+// logicalType 列的逻辑类型, 旧版本写入的 ConvertedType 已由解析库转换为逻辑类型
+func logicalType(column *parquet.Column) format.LogicalTypeValue {
+	if lt := column.Type().LogicalType(); lt != nil {
+		return lt.Value
+	}
+	return nil
+}
+
+// readColumn 读取行组中的一列, 返回每行的值
+// 解析库按第一个行组的压缩算法解压整列, 各行组压缩算法不同的列不支持导入
+func readColumn(group parquet.RowGroup, meta format.RowGroup, lf *leaf) (res []interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("column %s: %v", lf.name, r)
+		}
+	}()
+
+	if lf.maxRep > 1 {
+		return nil, fmt.Errorf("column %s: nested repeated fields are not supported", lf.name)
+	}
+	if lf.index >= len(meta.Columns) {
+		return nil, fmt.Errorf("column %s: missing column chunk", lf.name)
+	}
+
+	chunk := meta.Columns[lf.index].MetaData
+	if chunk.TotalUncompressedSize > maxChunkSize {
+		return nil, errChunkSize
+	}
+	if codec := lf.column.Compression(); codec == nil || chunk.Codec != codec.CompressionCodec() {
+		return nil, fmt.Errorf("column %s: the compression codec changes between the row groups", lf.name)
+	}
+
+	pages := group.ColumnChunks()[lf.index].Pages()
+	defer pages.Close()
+
+	res = make([]interface{}, 0, group.NumRows())
+	values := make([]parquet.Value, 256)
+	for {
+		page, err := pages.ReadPage()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		res, err = lf.assemble(res, page.Values(), values)
+		parquet.Release(page)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if int64(len(res)) != group.NumRows() {
+		return nil, fmt.Errorf("column %s: expected %d rows, got %d", lf.name, group.NumRows(), len(res))
+	}
+	return res, nil
+}
+
+// assemble 按定义/重复级别将页中的值还原为行
+func (lf *leaf) assemble(res []interface{}, reader parquet.ValueReader, values []parquet.Value) ([]interface{}, error) {
+	for {
+		n, err := reader.ReadValues(values)
+		for _, v := range values[:n] {
+			var value interface{}
+			if v.DefinitionLevel() == lf.maxDef {
+				value = lf.convert(v)
+			}
+
+			if lf.maxRep == 0 {
+				res = append(res, value)
+				continue
+			}
+
+			// 重复级别为 0 时开始新的一行, 空列表和 NULL 列表均读取为空数组
+			if v.RepetitionLevel() == 0 {
+				res = append(res, []interface{}{})
+			}
+			if len(res) == 0 {
+				return nil, fmt.Errorf("column %s: invalid repetition levels", lf.name)
+			}
+			if v.DefinitionLevel() >= lf.repDef {
+				res[len(res)-1] = append(res[len(res)-1].([]interface{}), value)
+			}
+		}
+
+		if err == io.EOF {
+			return res, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// convert 按逻辑类型转换物理值, INT32 统一转换为 int64
+func (lf *leaf) convert(v parquet.Value) interface{} {
+	switch v.Kind() {
+	case parquet.Boolean:
+		return v.Boolean()
+	case parquet.Int32:
+		return lf.convertInt(int64(v.Int32()))
+	case parquet.Int64:
+		return lf.convertInt(v.Int64())
+	case parquet.Float:
+		return float64(v.Float())
+	case parquet.Double:
+		return v.Double()
+	case parquet.Int96:
+		i96 := v.Int96()
+		nanos := int64(uint64(i96[0]) | uint64(i96[1])<<32)
+		days := int64(i96[2]) - julianUnixEpoch
+		return time.Unix(days*86400, nanos).UTC()
+	case parquet.ByteArray, parquet.FixedLenByteArray:
+		return lf.convertBytes(v.ByteArray())
+	}
+	return nil
+}
+
+func (lf *leaf) convertInt(v int64) interface{} {
+	switch lt := logicalType(lf.column).(type) {
+	case *format.DecimalType:
+		return float64(v) / math.Pow10(int(lt.Scale))
+	case *format.DateType:
+		return time.Unix(v*86400, 0).UTC()
+	case *format.TimestampType:
+		switch lt.Unit.Value.(type) {
+		case *format.MilliSeconds:
+			return time.UnixMilli(v).UTC()
+		case *format.NanoSeconds:
+			return time.Unix(0, v).UTC()
+		}
+		return time.UnixMicro(v).UTC()
+	case *format.IntType:
+		if !lt.IsSigned && lt.BitWidth == 32 {
+			return int64(uint32(v))
+		}
+		if !lt.IsSigned && lt.BitWidth == 64 {
+			return uint64(v)
+		}
+	}
+	return v
+}
+
+func (lf *leaf) convertBytes(v []byte) interface{} {
+	switch lt := logicalType(lf.column).(type) {
+	case *format.DecimalType:
+		f, _ := new(big.Float).SetInt(signed(v)).Float64()
+		return f / math.Pow10(int(lt.Scale))
+	case *format.UUIDType:
+		if len(v) == 16 {
+			return fmt.Sprintf("%x-%x-%x-%x-%x", v[0:4], v[4:6], v[6:8], v[8:10], v[10:16])
+		}
+	}
+	return string(v)
+}
+
+// columnType 列类型
+func columnType(column *parquet.Column) byte {
+	switch logicalType(column).(type) {
+	case *format.DateType, *format.TimestampType:
+		return from.TDatetime
+	case *format.DecimalType:
+		return from.TNumber
+	case *format.UUIDType:
+		return from.TString
+	}
+
+	switch column.Type().Kind() {
+	case parquet.Int96:
+		return from.TDatetime
+	case parquet.Boolean:
+		return from.TBool
+	case parquet.Int32, parquet.Int64, parquet.Float, parquet.Double:
+		return from.TNumber
+	case parquet.ByteArray, parquet.FixedLenByteArray:
+		return from.TString
+	}
+	return from.TUnknown
+}
+
+// signed 大端序补码转换为整数
+func signed(data []byte) *big.Int {
+	n := new(big.Int).SetBytes(data)
+	if len(data) > 0 && data[0]&0x80 != 0 {
+		n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(len(data)*8)))
+	}
+	return n
+}
@@ -24,6 +24,12 @@ func init() {
 	process.Alias("xiang.import.DataSetting", "yao.import.DataSetting")
 	process.Alias("xiang.import.Mapping", "yao.import.Mapping")
 	process.Alias("xiang.import.MappingSetting", "yao.import.MappingSetting")
+
+	process.Register("yao.import.Start", ProcessStart)
+	process.Register("yao.import.Status", ProcessStatus)
+	process.Register("yao.import.Cancel", ProcessCancel)
+	process.Register("yao.import.Resume", ProcessResume)
+	process.Register("yao.import.SaveTemplate", ProcessSaveTemplate)
 }
 
 // ProcessRun xiang.import.Run
@@ -39,6 +45,78 @@ func ProcessRun(process *process.Process) interface{} {
 	return imp.Run(src, mapping)
 }
 
+// ProcessStart yao.import.Start
+// 异步导入数据, 返回导入状态 (含 id 和 job_id)
+func ProcessStart(process *process.Process) interface{} {
+	process.ValidateArgNums(2)
+	name := process.ArgsString(0)
+	imp := Select(name).WithSid(process.Sid)
+	filename := process.ArgsString(1)
+
+	var mapping *Mapping
+	if process.NumOfArgs() > 2 {
+		mapping = anyToMapping(process.Args[2])
+	}
+
+	state, err := imp.Start(filename, mapping)
+	if err != nil {
+		exception.New("启动导入失败 %s", 500, err.Error()).Throw()
+	}
+	return state
+}
+
+// ProcessStatus yao.import.Status
+// 查询异步导入状态. 存在错误数据时 errors 为错误数据工作簿路径 (可通过 fs.system.Download 下载)
+func ProcessStatus(process *process.Process) interface{} {
+	process.ValidateArgNums(1)
+	state, err := Status(process.ArgsString(0))
+	if err != nil {
+		exception.New(err.Error(), 404).Throw()
+	}
+	return state
+}
+
+// ProcessCancel yao.import.Cancel
+// 取消异步导入
+func ProcessCancel(process *process.Process) interface{} {
+	process.ValidateArgNums(1)
+	err := Cancel(process.ArgsString(0))
+	if err != nil {
+		exception.New(err.Error(), 400).Throw()
+	}
+	return nil
+}
+
+// ProcessResume yao.import.Resume
+// 从最后完成的分块继续异步导入
+func ProcessResume(process *process.Process) interface{} {
+	process.ValidateArgNums(1)
+	state, err := Resume(process.ArgsString(0), process.Sid)
+	if err != nil {
+		exception.New(err.Error(), 400).Throw()
+	}
+	return state
+}
+
+// ProcessSaveTemplate yao.import.SaveTemplate
+// 按文件结构指纹保存映射模板
+func ProcessSaveTemplate(process *process.Process) interface{} {
+	process.ValidateArgNums(3)
+	name := process.ArgsString(0)
+	imp := Select(name).WithSid(process.Sid)
+
+	filename := process.ArgsString(1)
+	src := Open(filename)
+	defer src.Close()
+
+	mapping := anyToMapping(process.Args[2])
+	err := imp.SaveAsTemplate(src, mapping)
+	if err != nil {
+		exception.New("保存映射模板失败 %s", 500, err.Error()).Throw()
+	}
+	return nil
+}
+
 // ProcessSetting xiang.import.Setting
 // 导入配置选项
 func ProcessSetting(process *process.Process) interface{} {
@@ -0,0 +1,289 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/importer/from"
+	"github.com/yaoapp/yao/job"
+)
+
+// running 正在运行的异步导入 (导入批次 ID => 取消函数)
+var running = map[string]context.CancelFunc{}
+var runningMutex sync.Mutex
+
+// Start 运行导入(异步). 以 Job 执行, 返回导入状态, 可通过 Status 查询进度
+func (imp *Importer) Start(filename string, mapping *Mapping) (*State, error) {
+	now := time.Now()
+	state := &State{
+		ID:        uuid.NewString(),
+		Importer:  imp.ID,
+		File:      filename,
+		Mapping:   mapping,
+		Status:    StatusRunning,
+		Sid:       imp.Sid,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	err := imp.startJob(state)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := *state
+	return &snapshot, nil
+}
+
+// Resume 从最后完成的分块继续执行已取消、失败或中断的导入
+func Resume(id string, sid string) (*State, error) {
+	state, err := Status(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if isRunning(id) {
+		return nil, fmt.Errorf("导入 %s 正在运行", id)
+	}
+
+	if state.Status == StatusCompleted {
+		return nil, fmt.Errorf("导入 %s 已完成", id)
+	}
+
+	imp, has := Importers[state.Importer]
+	if !has {
+		return nil, fmt.Errorf("导入配置: %s 尚未加载", state.Importer)
+	}
+
+	state.Status = StatusRunning
+	state.Message = ""
+	state.Sid = sid
+	err = imp.startJob(state)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := *state
+	return &snapshot, nil
+}
+
+// Cancel 取消正在运行的导入, 已完成的分块会保留, 可通过 Resume 继续
+func Cancel(id string) error {
+	runningMutex.Lock()
+	cancel, has := running[id]
+	runningMutex.Unlock()
+	if !has {
+		return fmt.Errorf("导入 %s 未在运行", id)
+	}
+	cancel()
+	return nil
+}
+
+// Status 读取导入状态
+func Status(id string) (*State, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("导入 %s 不存在", id)
+	}
+
+	data, err := os.ReadFile(stateFile(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("导入 %s 不存在", id)
+		}
+		return nil, err
+	}
+
+	var state State
+	err = jsoniter.Unmarshal(data, &state)
+	if err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// startJob 创建并推送导入 Job
+func (imp *Importer) startJob(state *State) error {
+	name := imp.Title
+	if name == "" {
+		name = imp.ID
+	}
+
+	j, err := job.OnceAndSave(job.GOROUTINE, map[string]interface{}{
+		"name":          fmt.Sprintf("Import %s", name),
+		"description":   fmt.Sprintf("Importing %s", state.File),
+		"category_name": "Importer",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create and save job: %w", err)
+	}
+
+	state.JobID = j.JobID
+	err = state.save()
+	if err != nil {
+		return err
+	}
+
+	err = j.AddFunc(&job.ExecutionOptions{Priority: 1}, "importer.run", func(execCtx *job.ExecutionContext) error {
+		return imp.runJob(execCtx, state)
+	}, map[string]interface{}{
+		"id":       state.ID,
+		"importer": imp.ID,
+		"file":     state.File,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add job execution: %w", err)
+	}
+
+	err = j.Push()
+	if err != nil {
+		return fmt.Errorf("failed to push job: %w", err)
+	}
+	return nil
+}
+
+// runJob 执行导入 Job
+func (imp *Importer) runJob(execCtx *job.ExecutionContext, state *State) (err error) {
+	ctx, cancel := context.WithCancel(execCtx.Ctx)
+	runningMutex.Lock()
+	running[state.ID] = cancel
+	runningMutex.Unlock()
+
+	defer func() {
+		runningMutex.Lock()
+		delete(running, state.ID)
+		runningMutex.Unlock()
+		cancel()
+
+		// Open 和导入处理器通过异常返回错误
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+
+		switch {
+		case err != nil:
+			state.Status = StatusFailed
+			state.Message = err.Error()
+		case ctx.Err() != nil:
+			state.Status = StatusCancelled
+			err = ctx.Err()
+		default:
+			state.Status = StatusCompleted
+			state.Progress = 100
+		}
+
+		if saveErr := state.save(); saveErr != nil {
+			log.With(log.F{"id": state.ID}).Error("保存导入状态失败: %s", saveErr.Error())
+		}
+	}()
+
+	src := Open(state.File)
+	defer src.Close()
+
+	if state.Mapping == nil {
+		if imp.Option.UseTemplate {
+			state.Mapping = imp.Template(src)
+		}
+		if state.Mapping == nil {
+			state.Mapping = imp.AutoMapping(src)
+		}
+	} else if state.Chunk == 0 {
+		imp.rememberMapping(src, state.Mapping)
+	} else {
+		getSourceColumns(src) // 定位标题行
+	}
+
+	if counter, ok := src.(from.Counter); ok {
+		state.Rows = counter.Count()
+	}
+
+	if state.Chunk == 0 {
+		os.Remove(rejectsFile(state.ID))
+	}
+
+	imp.execute(ctx, src, state, func(state *State, rejects []*Reject) {
+		if err := appendRejects(state.ID, rejects); err != nil {
+			log.With(log.F{"id": state.ID}).Error("保存错误记录失败: %s", err.Error())
+		}
+
+		state.Progress = state.progress()
+		if err := state.save(); err != nil {
+			log.With(log.F{"id": state.ID}).Error("保存导入状态失败: %s", err.Error())
+		}
+		execCtx.Execution.SetProgress(state.Progress, fmt.Sprintf("%d rows imported", state.Total))
+	})
+
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	if state.Rejected > 0 {
+		state.Errors, err = imp.writeErrors(state)
+		if err != nil {
+			return err
+		}
+	}
+
+	state.Output = state.output()
+	if imp.Output != "" {
+		state.Output, err = process.New(imp.Output, state.output()).WithSID(state.Sid).Exec()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// output 导入结果
+func (state *State) output() map[string]int {
+	return map[string]int{
+		"total":   state.Total,
+		"success": state.Total - state.Failure - state.Ignore,
+		"failure": state.Failure,
+		"ignore":  state.Ignore,
+	}
+}
+
+// progress 导入进度, 数据源无法提供总行数时为 0
+func (state *State) progress() int {
+	if state.Rows <= 0 {
+		return 0
+	}
+	progress := state.Total * 100 / state.Rows
+	if progress > 99 {
+		return 99
+	}
+	return progress
+}
+
+// save 保存导入状态
+func (state *State) save() error {
+	state.UpdatedAt = time.Now()
+	data, err := jsoniter.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	file := stateFile(state.ID)
+	err = os.MkdirAll(filepath.Dir(file), os.ModePerm)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, data, 0644)
+}
+
+func isRunning(id string) bool {
+	runningMutex.Lock()
+	defer runningMutex.Unlock()
+	_, has := running[id]
+	return has
+}
+
+func stateFile(id string) string {
+	return filepath.Join(DataRoot, "importer", "runs", fmt.Sprintf("%s.json", id))
+}
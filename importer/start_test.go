@@ -0,0 +1,68 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/test"
+)
+
+func TestStartSimple(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+	prepare(t, config.Conf)
+
+	imp := Select("order")
+	state, err := imp.Start(filepath.Join("assets", "simple.xlsx"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, StatusRunning, state.Status)
+	assert.NotEmpty(t, state.JobID)
+
+	state = waitImport(t, state.ID)
+	assert.Equal(t, StatusCompleted, state.Status)
+	assert.Equal(t, 100, state.Progress)
+	assert.Equal(t, 4, state.Total)
+	assert.Equal(t, 1, state.Failure)
+	assert.Equal(t, 1, state.Ignore)
+
+	// The rejected rows are written to the error workbook
+	assert.Greater(t, state.Rejected, 0)
+	assert.NotEmpty(t, state.Errors)
+	_, err = os.Stat(filepath.Join(DataRoot, state.Errors))
+	assert.Nil(t, err)
+
+	// Completed imports cannot be resumed
+	_, err = Resume(state.ID, "")
+	assert.NotNil(t, err)
+}
+
+func TestStatusNotFound(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+	prepare(t, config.Conf)
+
+	_, err := Status("../../etc/passwd")
+	assert.NotNil(t, err)
+	assert.NotNil(t, Cancel("00000000-0000-0000-0000-000000000000"))
+}
+
+func waitImport(t *testing.T, id string) *State {
+	for i := 0; i < 100; i++ {
+		state, err := Status(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if state.Status != StatusRunning {
+			return state
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatal("import timeout")
+	return nil
+}
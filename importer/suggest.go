@@ -0,0 +1,403 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/importer/from"
+	"github.com/yaoapp/yao/openai"
+)
+
+// SuggestThreshold 相似度匹配的最低置信度, 低于此值不绑定
+const SuggestThreshold = 0.5
+
+// suggestSamples 发送给 AI 连接器的示例数据行数
+const suggestSamples = 5
+
+// suggestTimeout AI 连接器请求超时时间
+const suggestTimeout = 60 * time.Second
+
+// suggestPrompt AI 字段映射提示词
+const suggestPrompt = `You map the columns of an uploaded spreadsheet to the fields of a data model.
+The user message is a JSON object with:
+- "columns": the source column headers and types
+- "samples": the first rows of the source data, in the same order as "columns"
+- "fields": the target fields that are not mapped yet (field, label, match hints)
+- "rules": the allowed cleaning rules (name, label)
+
+Headers may be abbreviated or written in another language. Use the samples to decide.
+Map each source column to at most one field. Leave a field out if no column fits.
+Only suggest cleaning rules from "rules" that the sample values need.
+
+Reply with JSON only, no markdown:
+{"bindings": [{"field": "<field>", "column": "<column header>", "confidence": 0.0-1.0, "rules": ["<rule name>"], "reason": "<short reason>"}]}`
+
+// chat 调用 AI 连接器并返回回复内容 (测试时可替换)
+var chat = openai.Chat
+
+// suggestion AI 连接器返回的字段绑定
+type suggestion struct {
+	Field      string   `json:"field"`
+	Column     string   `json:"column"`
+	Confidence float64  `json:"confidence"`
+	Rules      []string `json:"rules"`
+	Reason     string   `json:"reason"`
+}
+
+// SmartMapping 在 AutoMapping 的基础上为未匹配的字段给出建议绑定
+// 配置了 Option.Connector 时由 AI 根据标题和示例数据匹配, 否则 (或请求失败时) 按名称相似度匹配
+// 建议的绑定带有置信度和建议的清洗规则, 需在映射预览中确认
+func (imp *Importer) SmartMapping(src from.Source) *Mapping {
+	mapping := imp.AutoMapping(src)
+
+	pending := map[string]*Binding{}
+	used := map[string]bool{}
+	for _, binding := range mapping.Columns {
+		if binding.Axis == "" {
+			pending[binding.Field] = binding
+			continue
+		}
+		used[binding.Axis] = true
+	}
+
+	columns := []from.Column{}
+	for _, col := range src.Columns() {
+		if col.Name != "" && !used[col.Axis] {
+			columns = append(columns, col)
+		}
+	}
+
+	if len(pending) == 0 || len(columns) == 0 {
+		return mapping
+	}
+
+	if imp.Option.Connector != "" {
+		err := imp.suggestByAI(src, mapping, pending, columns)
+		if err == nil {
+			mapping.AIMatching = true
+			return mapping
+		}
+		log.With(log.F{"importer": imp.ID, "connector": imp.Option.Connector}).Error("AI 字段映射失败, 使用相似度匹配: %s", err.Error())
+	}
+
+	imp.suggestBySimilarity(pending, columns)
+	return mapping
+}
+
+// suggestByAI 将标题、示例数据和目标字段发送给 AI 连接器, 按返回结果绑定
+// 相同结构 (文件指纹) 的文件复用上次的建议, 预览时不重复请求 AI 连接器
+func (imp *Importer) suggestByAI(src from.Source, mapping *Mapping, pending map[string]*Binding, columns []from.Column) error {
+	fingerprint := imp.Fingerprint(src)
+	bindings, cached := imp.cachedSuggestions(fingerprint)
+	if !cached {
+		var err error
+		bindings, err = imp.requestSuggestions(src, mapping, pending, columns)
+		if err != nil {
+			return err
+		}
+		imp.saveSuggestions(fingerprint, bindings)
+	}
+
+	byName := map[string]from.Column{}
+	for _, col := range columns {
+		byName[col.Name] = col
+	}
+
+	for _, item := range bindings {
+		binding, has := pending[item.Field]
+		col, ok := byName[item.Column]
+		if !has || !ok {
+			continue
+		}
+
+		binding.Name = col.Name
+		binding.Axis = col.Axis
+		binding.Confidence = clamp(item.Confidence)
+		binding.Reason = item.Reason
+		binding.Suggest = imp.suggestRules(binding, item.Rules)
+		binding.Rules = imp.columnRules(binding.Field)
+		delete(pending, item.Field)
+		delete(byName, item.Column)
+	}
+	return nil
+}
+
+// requestSuggestions 请求 AI 连接器给出未匹配字段的绑定建议
+func (imp *Importer) requestSuggestions(src from.Source, mapping *Mapping, pending map[string]*Binding, columns []from.Column) ([]suggestion, error) {
+	input := map[string]interface{}{}
+
+	headers := []map[string]interface{}{}
+	axises := []string{}
+	for _, col := range columns {
+		headers = append(headers, map[string]interface{}{"name": col.Name, "type": columnType(col.Type)})
+		axises = append(axises, col.Axis)
+	}
+	input["columns"] = headers
+	input["samples"] = src.Data(mapping.RowStart, suggestSamples, axises)
+
+	fields := []map[string]interface{}{}
+	for _, col := range imp.Columns {
+		if _, has := pending[col.Field]; has {
+			fields = append(fields, map[string]interface{}{"field": col.Field, "label": col.Label, "match": col.Match})
+		}
+	}
+	input["fields"] = fields
+
+	rules := []map[string]interface{}{}
+	for _, option := range imp.getRulesOption() {
+		rules = append(rules, map[string]interface{}{"name": option["value"], "label": option["label"]})
+	}
+	input["rules"] = rules
+
+	content, err := jsoniter.MarshalToString(input)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), suggestTimeout)
+	defer cancel()
+	reply, err := chat(ctx, imp.Option.Connector, []map[string]interface{}{
+		{"role": "system", "content": suggestPrompt},
+		{"role": "user", "content": content},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var res struct {
+		Bindings []suggestion `json:"bindings"`
+	}
+	err = jsoniter.UnmarshalFromString(openai.TrimFence(reply), &res)
+	if err != nil {
+		return nil, fmt.Errorf("AI 返回格式错误 %s", err.Error())
+	}
+	return res.Bindings, nil
+}
+
+// cachedSuggestions 按文件结构指纹读取 AI 连接器上次返回的绑定建议
+func (imp *Importer) cachedSuggestions(fingerprint string) ([]suggestion, bool) {
+	data, err := os.ReadFile(imp.suggestionFile(fingerprint))
+	if err != nil {
+		return nil, false
+	}
+
+	bindings := []suggestion{}
+	if err := jsoniter.Unmarshal(data, &bindings); err != nil {
+		return nil, false
+	}
+	return bindings, true
+}
+
+// saveSuggestions 按文件结构指纹保存 AI 连接器返回的绑定建议
+func (imp *Importer) saveSuggestions(fingerprint string, bindings []suggestion) {
+	file := imp.suggestionFile(fingerprint)
+	data, err := jsoniter.Marshal(bindings)
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(file), os.ModePerm)
+	}
+	if err == nil {
+		err = os.WriteFile(file, data, 0644)
+	}
+	if err != nil {
+		log.With(log.F{"file": file}).Error("保存映射建议失败: %s", err.Error())
+	}
+}
+
+func (imp *Importer) suggestionFile(fingerprint string) string {
+	return filepath.Join(DataRoot, "importer", "suggestions", imp.ID, fmt.Sprintf("%s.json", fingerprint))
+}
+
+// suggestBySimilarity 按名称相似度匹配 (确定性), 置信度高的绑定优先
+func (imp *Importer) suggestBySimilarity(pending map[string]*Binding, columns []from.Column) {
+	type candidate struct {
+		binding *Binding
+		column  from.Column
+		score   float64
+	}
+
+	candidates := []candidate{}
+	for _, col := range imp.Columns {
+		binding, has := pending[col.Field]
+		if !has {
+			continue
+		}
+
+		names := append([]string{col.Field, col.Label}, col.Match...)
+		for _, source := range columns {
+			score := 0.0
+			for _, name := range names {
+				if s := similarity(source.Name, name); s > score {
+					score = s
+				}
+			}
+			if score >= SuggestThreshold {
+				candidates = append(candidates, candidate{binding: binding, column: source, score: score})
+			}
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].score > candidates[j].score })
+	used := map[string]bool{}
+	for _, c := range candidates {
+		if c.binding.Axis != "" || used[c.column.Axis] {
+			continue
+		}
+		used[c.column.Axis] = true
+		c.binding.Name = c.column.Name
+		c.binding.Axis = c.column.Axis
+		c.binding.Rules = imp.columnRules(c.binding.Field)
+		c.binding.Confidence = c.score
+		c.binding.Reason = "名称相似"
+	}
+}
+
+// suggestRules 过滤建议的清洗规则, 只保留许可的且尚未配置的规则
+func (imp *Importer) suggestRules(binding *Binding, rules []string) []string {
+	configured := map[string]bool{}
+	for _, rule := range imp.columnRules(binding.Field) {
+		configured[rule] = true
+	}
+
+	res := []string{}
+	for _, rule := range rules {
+		if _, allowed := imp.Rules[rule]; allowed && !configured[rule] {
+			configured[rule] = true
+			res = append(res, rule)
+		}
+	}
+	return res
+}
+
+// columnRules 字段定义的清洗规则
+func (imp *Importer) columnRules(field string) []string {
+	for _, col := range imp.Columns {
+		if col.Field == field {
+			return col.Rules
+		}
+	}
+	return []string{}
+}
+
+// similarity 名称相似度 0-1
+// 忽略大小写、空格和标点; 完全相同 0.9, 包含关系 0.6-0.9, 否则按词的 Dice 系数 (前缀视为相同, 如 cust/customer) 计算
+func similarity(a, b string) float64 {
+	na, nb := normalize(a), normalize(b)
+	if na == "" || nb == "" {
+		return 0
+	}
+	if na == nb {
+		return 0.9
+	}
+
+	ra, rb := []rune(na), []rune(nb)
+	short, long := len(ra), len(rb)
+	if short > long {
+		short, long = long, short
+	}
+	if short >= 2 && (strings.Contains(na, nb) || strings.Contains(nb, na)) {
+		return 0.6 + 0.3*float64(short)/float64(long)
+	}
+
+	ta, tb := words(a), words(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	matched := 0
+	used := make([]bool, len(tb))
+	for _, x := range ta {
+		for j, y := range tb {
+			if !used[j] && sameWord(x, y) {
+				used[j] = true
+				matched++
+				break
+			}
+		}
+	}
+	return 0.8 * 2 * float64(matched) / float64(len(ta)+len(tb))
+}
+
+// normalize 转为小写并去除非字母数字字符
+func normalize(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// words 拆分单词 (按非字母数字字符和驼峰拆分, 汉字逐字拆分)
+func words(s string) []string {
+	res := []string{}
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			res = append(res, strings.ToLower(string(word)))
+			word = nil
+		}
+	}
+
+	for _, r := range s {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			flush()
+			res = append(res, string(r))
+		case unicode.IsUpper(r):
+			if len(word) > 0 && unicode.IsLower(word[len(word)-1]) {
+				flush()
+			}
+			word = append(word, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word = append(word, r)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return res
+}
+
+// sameWord 单词相同, 或较短的单词 (至少 3 个字符) 是较长单词的前缀
+func sameWord(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	return len(a) >= 3 && strings.HasPrefix(b, a)
+}
+
+// columnType 源数据列类型名称
+func columnType(typ byte) string {
+	switch typ {
+	case from.TBool:
+		return "bool"
+	case from.TDatetime:
+		return "datetime"
+	case from.TNumber:
+		return "number"
+	case from.TString:
+		return "string"
+	}
+	return "unknown"
+}
+
+func clamp(confidence float64) float64 {
+	if confidence < 0 {
+		return 0
+	}
+	if confidence > 1 {
+		return 1
+	}
+	return confidence
+}
@@ -0,0 +1,149 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/yao/importer/from"
+)
+
+func TestSimilarity(t *testing.T) {
+	assert.Equal(t, 0.9, similarity("Customer Name", "customer_name"))
+	assert.InDelta(t, 0.64, similarity("Cust. Tel (mobile)", "customer_mobile"), 0.001)
+	assert.InDelta(t, 0.686, similarity("订单编号", "订单号"), 0.001)
+	assert.InDelta(t, 0.8, similarity("金额(元)", "金额"), 0.001)
+	assert.Equal(t, 0.0, similarity("Amount", "remark"))
+	assert.Equal(t, []string{"order", "id", "客", "户"}, words("orderID 客户"))
+}
+
+func TestSmartMappingSimilarity(t *testing.T) {
+	imp := suggestImporter("")
+	mapping := imp.SmartMapping(&suggestSource{})
+
+	assert.False(t, mapping.AIMatching)
+	assert.Equal(t, "SN", mapping.Columns[0].Name)
+	assert.Equal(t, 1.0, mapping.Columns[0].Confidence)
+	assert.Equal(t, "Cust. Tel (mobile)", mapping.Columns[1].Name)
+	assert.Equal(t, "B", mapping.Columns[1].Axis)
+	assert.InDelta(t, 0.738, mapping.Columns[1].Confidence, 0.001)
+	assert.Equal(t, []string{"scripts.rules.trim"}, mapping.Columns[1].Rules)
+	assert.Equal(t, "C", mapping.Columns[2].Axis)
+	assert.InDelta(t, 0.8, mapping.Columns[2].Confidence, 0.001)
+}
+
+func TestSmartMappingAI(t *testing.T) {
+	defer func(fn func(ctx context.Context, connector string, messages []map[string]interface{}) (string, error)) {
+		chat = fn
+	}(chat)
+	useDataRoot(t)
+
+	var request string
+	calls := 0
+	chat = func(ctx context.Context, connector string, messages []map[string]interface{}) (string, error) {
+		assert.Equal(t, "gpt-4o", connector)
+		request = messages[1]["content"].(string)
+		calls++
+		return "```json\n" + `{"bindings": [
+			{"field": "amount", "column": "金额(元)", "confidence": 0.92, "rules": ["scripts.rules.number", "scripts.rules.unknown"], "reason": "金额 means amount"},
+			{"field": "mobile", "column": "Cust. Tel (mobile)", "confidence": 1.5, "rules": ["scripts.rules.trim", "scripts.rules.phone"]},
+			{"field": "missing", "column": "SN"}
+		]}` + "\n```", nil
+	}
+
+	imp := suggestImporter("gpt-4o")
+	mapping := imp.SmartMapping(&suggestSource{})
+	assert.True(t, mapping.AIMatching)
+	assert.Contains(t, request, "Cust. Tel (mobile)")
+	assert.Contains(t, request, "138 0000 0000")
+	assert.NotContains(t, request, `"field":"sn"`)
+
+	mobile := mapping.Columns[1]
+	assert.Equal(t, "B", mobile.Axis)
+	assert.Equal(t, 1.0, mobile.Confidence)
+	assert.Equal(t, []string{"scripts.rules.trim"}, mobile.Rules)
+	assert.Equal(t, []string{"scripts.rules.phone"}, mobile.Suggest)
+
+	amount := mapping.Columns[2]
+	assert.Equal(t, "C", amount.Axis)
+	assert.Equal(t, 0.92, amount.Confidence)
+	assert.Equal(t, []string{"scripts.rules.number"}, amount.Suggest)
+	assert.Equal(t, "金额 means amount", amount.Reason)
+
+	// 相同结构的文件复用建议, 不再请求 AI 连接器
+	cached := imp.SmartMapping(&suggestSource{})
+	assert.Equal(t, 1, calls)
+	assert.True(t, cached.AIMatching)
+	assert.Equal(t, mapping.Columns, cached.Columns)
+}
+
+func TestSmartMappingAIFallback(t *testing.T) {
+	defer func(fn func(ctx context.Context, connector string, messages []map[string]interface{}) (string, error)) {
+		chat = fn
+	}(chat)
+	useDataRoot(t)
+	chat = func(ctx context.Context, connector string, messages []map[string]interface{}) (string, error) {
+		return "", fmt.Errorf("connector gpt-4o not found")
+	}
+
+	mapping := suggestImporter("gpt-4o").SmartMapping(&suggestSource{})
+	assert.False(t, mapping.AIMatching)
+	assert.Equal(t, "B", mapping.Columns[1].Axis)
+}
+
+// useDataRoot 测试时将数据目录指向临时目录
+func useDataRoot(t *testing.T) {
+	root := DataRoot
+	DataRoot = t.TempDir()
+	t.Cleanup(func() { DataRoot = root })
+}
+
+func suggestImporter(connector string) *Importer {
+	return &Importer{
+		ID: "suggest",
+		Columns: []Column{
+			{Label: "订单号", Field: "sn", Match: []string{"SN"}, Rules: []string{}},
+			{Label: "手机号", Field: "mobile", Match: []string{"customer_mobile"}, Rules: []string{"scripts.rules.trim"}},
+			{Label: "金额", Field: "amount", Rules: []string{}},
+		},
+		Rules: map[string]string{
+			"scripts.rules.trim":   "去除空格",
+			"scripts.rules.phone":  "手机号格式",
+			"scripts.rules.number": "数字",
+		},
+		Option: Option{Connector: connector},
+	}
+}
+
+// suggestSource 测试数据源
+type suggestSource struct{}
+
+func (src *suggestSource) Data(row int, size int, axises []string) [][]interface{} {
+	values := map[string]interface{}{"A": "SO-001", "B": "138 0000 0000", "C": "12.50"}
+	data := []interface{}{}
+	for _, axis := range axises {
+		data = append(data, values[axis])
+	}
+	return [][]interface{}{data}
+}
+
+func (src *suggestSource) Columns() []from.Column {
+	return []from.Column{
+		{Name: "SN", Axis: "A", Type: from.TString},
+		{Name: "Cust. Tel (mobile)", Axis: "B", Type: from.TString},
+		{Name: "金额(元)", Axis: "C", Type: from.TNumber},
+	}
+}
+
+func (src *suggestSource) Chunk(size int, axises []string, cb func(line int, data [][]interface{})) {
+	cb(1, src.Data(1, size, axises))
+}
+
+func (src *suggestSource) Inspect() from.Inspect {
+	return from.Inspect{RowStart: 2, ColStart: 1}
+}
+
+func (src *suggestSource) Close() error {
+	return nil
+}
@@ -0,0 +1,111 @@
+package importer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/importer/from"
+)
+
+// Template 按文件结构指纹读取已保存的映射模板, 没有模板返回 nil
+func (imp *Importer) Template(src from.Source) *Mapping {
+	file := imp.templateFile(imp.Fingerprint(src))
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.With(log.F{"file": file}).Error("读取映射模板失败: %s", err.Error())
+		}
+		return nil
+	}
+
+	var mapping Mapping
+	err = jsoniter.Unmarshal(data, &mapping)
+	if err != nil {
+		log.With(log.F{"file": file}).Error("映射模板格式错误: %s", err.Error())
+		return nil
+	}
+
+	// 指纹不区分列顺序, 按列名重新定位坐标
+	sourceColumns := getSourceColumns(src)
+	for _, binding := range mapping.Columns {
+		if col, has := sourceColumns[binding.Name]; has && binding.Name != "" {
+			binding.Axis = col.Axis
+		}
+	}
+
+	mapping.AutoMatching = false
+	mapping.TemplateMatching = true
+	return &mapping
+}
+
+// SaveAsTemplate 保存为映射模板
+func (imp *Importer) SaveAsTemplate(src from.Source, mapping *Mapping) error {
+	if mapping == nil {
+		return fmt.Errorf("映射表不能为空")
+	}
+
+	template := *mapping
+	template.Columns = []*Binding{}
+	for _, binding := range mapping.Columns {
+		b := *binding
+		b.Value = ""
+		template.Columns = append(template.Columns, &b)
+	}
+
+	data, err := jsoniter.Marshal(template)
+	if err != nil {
+		return err
+	}
+
+	file := imp.templateFile(imp.Fingerprint(src))
+	err = os.MkdirAll(filepath.Dir(file), os.ModePerm)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, data, 0644)
+}
+
+// RemoveTemplate 删除映射模板
+func (imp *Importer) RemoveTemplate(src from.Source) error {
+	err := os.Remove(imp.templateFile(imp.Fingerprint(src)))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// rememberMapping 使用模板时, 保存与自动匹配结果不同的映射表
+func (imp *Importer) rememberMapping(src from.Source, mapping *Mapping) {
+	if !imp.Option.UseTemplate || mapping == nil || sameBindings(mapping, imp.AutoMapping(src)) {
+		return
+	}
+	if err := imp.SaveAsTemplate(src, mapping); err != nil {
+		log.With(log.F{"importer": imp.ID}).Error("保存映射模板失败: %s", err.Error())
+	}
+}
+
+func (imp *Importer) templateFile(fingerprint string) string {
+	return filepath.Join(DataRoot, "importer", "templates", imp.ID, fmt.Sprintf("%s.json", fingerprint))
+}
+
+// sameBindings 比较两个映射表的字段绑定
+func sameBindings(a, b *Mapping) bool {
+	if len(a.Columns) != len(b.Columns) {
+		return false
+	}
+	for i := range a.Columns {
+		x, y := a.Columns[i], b.Columns[i]
+		if x.Field != y.Field || x.Axis != y.Axis || len(x.Rules) != len(y.Rules) {
+			return false
+		}
+		for j := range x.Rules {
+			if x.Rules[j] != y.Rules[j] {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -1,5 +1,7 @@
 package importer
 
+import "time"
+
 // PreviewAuto 一直显示
 const PreviewAuto = "auto"
 
@@ -17,6 +19,7 @@ type Importer struct {
 	Columns []Column          `json:"columns"`          // 字段列表
 	Option  Option            `json:"option,omitempty"` // 导入配置项
 	Rules   map[string]string `json:"rules,omitempty"`  // 许可导入规则
+	ID      string            `json:"-"`                // 导入器 ID
 	Sid     string            `json:"-"`                // sid
 }
 
@@ -42,6 +45,7 @@ type Option struct {
 	ChunkSize      int    `json:"chunkSize,omitempty"`      // 每次处理记录数量
 	MappingPreview string `json:"mappingPreview,omitempty"` // 显示字段映射界面方式 auto 匹配模板失败显示, always 一直显示, never 不显示
 	DataPreview    string `json:"dataPreview,omitempty"`    // 数据预览界面方式 auto 有异常数据时显示, always 一直显示, never 不显示
+	Connector      string `json:"connector,omitempty"`      // 智能字段映射使用的 AI 连接器, 为空时使用确定性的相似度匹配
 }
 
 // Mapping 字段映射表
@@ -52,6 +56,7 @@ type Mapping struct {
 	Columns          []*Binding `json:"data"`             // 字段数据列表
 	AutoMatching     bool       `json:"autoMatching"`     // 是否自动匹配
 	TemplateMatching bool       `json:"templateMatching"` // 是否通过已传模板匹配
+	AIMatching       bool       `json:"aiMatching"`       // 是否通过 AI 连接器匹配
 }
 
 // Binding 数据绑定
@@ -62,4 +67,52 @@ type Binding struct {
 	Axis  string   `json:"axis"`  // 源关联字段坐标
 	Value string   `json:"value"` // 示例数据
 	Rules []string `json:"rules"` // 清洗规则
+
+	Confidence float64  `json:"confidence,omitempty"` // 匹配置信度 0-1 (名称完全匹配为 1)
+	Suggest    []string `json:"suggest,omitempty"`    // 建议的清洗规则, 确认后加入 Rules 才会生效
+	Reason     string   `json:"reason,omitempty"`     // 匹配说明
+}
+
+// StatusRunning 导入中
+const StatusRunning = "running"
+
+// StatusCompleted 导入完成
+const StatusCompleted = "completed"
+
+// StatusFailed 导入失败
+const StatusFailed = "failed"
+
+// StatusCancelled 导入已取消
+const StatusCancelled = "cancelled"
+
+// State 异步导入状态 (保存在 system 文件系统 importer/runs/<id>.json)
+type State struct {
+	ID        string      `json:"id"`                // 导入批次 ID
+	Importer  string      `json:"importer"`          // 导入器 ID
+	File      string      `json:"file"`              // 导入文件 (system 文件系统路径)
+	Mapping   *Mapping    `json:"mapping"`           // 字段映射表
+	JobID     string      `json:"job_id,omitempty"`  // 当前执行的 Job ID
+	Status    string      `json:"status"`            // running, completed, failed, cancelled
+	Progress  int         `json:"progress"`          // 进度 0-100
+	Chunk     int         `json:"chunk"`             // 已完成的分块数 (从此处恢复)
+	Line      int         `json:"line"`              // 已完成的最后一行
+	Rows      int         `json:"rows"`              // 数据总行数 (数据源支持时)
+	Total     int         `json:"total"`             // 已处理记录数
+	Failure   int         `json:"failure"`           // 失败记录数
+	Ignore    int         `json:"ignore"`            // 忽略记录数
+	Rejected  int         `json:"rejected"`          // 未通过校验的记录数
+	Errors    string      `json:"errors,omitempty"`  // 错误数据工作簿 (system 文件系统路径)
+	Message   string      `json:"message,omitempty"` // 失败原因
+	Output    interface{} `json:"output,omitempty"`  // 导入输出处理器返回值
+	Sid       string      `json:"-"`                 // sid
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// Reject 未通过校验的记录
+type Reject struct {
+	Line     int           `json:"line"`     // 源文件行号
+	Row      []interface{} `json:"row"`      // 源数据
+	Rules    []string      `json:"rules"`    // 未通过的清洗规则
+	Messages []string      `json:"messages"` // 错误信息
 }
@@ -3,6 +3,7 @@ package xlsx
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/xuri/excelize/v2"
 	"github.com/yaoapp/kun/exception"
@@ -19,6 +20,7 @@ type Xlsx struct {
 	RowStart   int
 	Cols       *excelize.Cols
 	Rows       *excelize.Rows
+	columns    []from.Column // 已扫描的列 (扫描会移动行游标, 只扫描一次)
 }
 
 // Open 打开 Xlsx 文件
@@ -71,6 +73,27 @@ func (xlsx *Xlsx) Inspect() from.Inspect {
 	}
 }
 
+// Count 数据行数 (根据表格区域估算, 不含标题行)
+func (xlsx *Xlsx) Count() int {
+	dimension, err := xlsx.File.GetSheetDimension(xlsx.SheetName)
+	if err != nil {
+		log.With(log.F{"SheetName": xlsx.SheetName}).Error("读取表格区域失败 %s", err.Error())
+		return 0
+	}
+
+	cells := strings.Split(dimension, ":")
+	row, _, err := axisToPosition(cells[len(cells)-1])
+	if err != nil {
+		return 0
+	}
+
+	count := row + 1 - xlsx.RowStart
+	if count < 0 {
+		return 0
+	}
+	return count
+}
+
 // Data 读取数据
 func (xlsx *Xlsx) Data(row int, size int, axises []string) [][]interface{} {
 	data := [][]interface{}{}
@@ -137,6 +160,9 @@ func (xlsx *Xlsx) readLine(line int, axises []string) ([]interface{}, bool) {
 
 // Columns 读取列
 func (xlsx *Xlsx) Columns() []from.Column {
+	if xlsx.columns != nil {
+		return xlsx.columns
+	}
 	columns := []from.Column{}
 
 	// 扫描标题位置坐标 扫描行
@@ -176,6 +202,7 @@ func (xlsx *Xlsx) Columns() []from.Column {
 		}
 		line++
 	}
+	xlsx.columns = columns
 	return columns
 }
 
@@ -299,6 +299,33 @@ func SaveJob(job *Job) error {
 	return nil
 }
 
+// UpdateJob update the given fields of the job, the other fields are kept
+// (SaveJob writes all the fields, the changes made by the other instances may be overwritten)
+func UpdateJob(jobID string, fields map[string]interface{}) error {
+	mod := model.Select("__yao.job")
+	if mod == nil {
+		return fmt.Errorf("job model not found")
+	}
+
+	data := map[string]interface{}{"updated_at": time.Now()}
+	for key, value := range fields {
+		data[key] = value
+	}
+
+	param := model.QueryParam{
+		Wheres: []model.QueryWhere{
+			{Column: "job_id", Value: jobID},
+		},
+		Limit: 1,
+	}
+
+	_, err := mod.UpdateWhere(param, data)
+	if err != nil {
+		return fmt.Errorf("failed to update job: %w", err)
+	}
+	return nil
+}
+
 // RemoveJobs remove jobs by IDs
 func RemoveJobs(ids []string) error {
 	mod := model.Select("__yao.job")
@@ -1008,6 +1035,214 @@ func SaveExecution(execution *Execution) error {
 	return nil
 }
 
+// resetExecution resets the execution to be queued again, the results of the previous attempts are cleared
+func resetExecution(execution *Execution) error {
+	mod := model.Select("__yao.job.execution")
+	if mod == nil {
+		return fmt.Errorf("job execution model not found")
+	}
+
+	param := model.QueryParam{
+		Wheres: []model.QueryWhere{
+			{Column: "execution_id", Value: execution.ExecutionID},
+		},
+		Limit: 1,
+	}
+
+	now := time.Now()
+	_, err := mod.UpdateWhere(param, map[string]interface{}{
+		"status":        "queued",
+		"retry_attempt": 0,
+		"progress":      0,
+		"scheduled_at":  nil,
+		"started_at":    nil,
+		"ended_at":      nil,
+		"duration":      nil,
+		"worker_id":     nil,
+		"error_info":    nil,
+		"updated_at":    now,
+	})
+	if err != nil {
+		return err
+	}
+
+	execution.Status = "queued"
+	execution.RetryAttempt = 0
+	execution.Progress = 0
+	execution.ScheduledAt = nil
+	execution.StartedAt = nil
+	execution.EndedAt = nil
+	execution.Duration = nil
+	execution.WorkerID = nil
+	execution.ErrorInfo = nil
+	execution.UpdatedAt = now
+	return updateJobProgress(execution.JobID)
+}
+
+// dueRetries returns the queued executions whose retry is due at the time
+func dueRetries(now time.Time) ([]*Execution, error) {
+	mod := model.Select("__yao.job.execution")
+	if mod == nil {
+		return nil, fmt.Errorf("job execution model not found")
+	}
+
+	results, err := mod.Get(model.QueryParam{
+		Select: ExecutionFields,
+		Wheres: []model.QueryWhere{
+			{Column: "status", Value: "queued"},
+			{Column: "scheduled_at", OP: "le", Value: now},
+		},
+		Orders: []model.QueryOrder{
+			{Column: "scheduled_at", Option: "asc"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	executions := make([]*Execution, 0, len(results))
+	for _, result := range results {
+		execution := &Execution{}
+		if err := mapToStruct(result, execution); err != nil {
+			continue
+		}
+
+		// Restore ExecutionConfig from ConfigSnapshot if available
+		if execution.ConfigSnapshot != nil && len(*execution.ConfigSnapshot) > 0 {
+			var config ExecutionConfig
+			if err := jsoniter.Unmarshal(*execution.ConfigSnapshot, &config); err == nil {
+				execution.ExecutionConfig = &config
+			}
+		}
+
+		executions = append(executions, execution)
+	}
+
+	return executions, nil
+}
+
+// claimRetry clears the schedule of the due retry, false if another instance claimed it first
+func claimRetry(executionID string) (bool, error) {
+	mod := model.Select("__yao.job.execution")
+	if mod == nil {
+		return false, fmt.Errorf("job execution model not found")
+	}
+
+	param := model.QueryParam{
+		Wheres: []model.QueryWhere{
+			{Column: "execution_id", Value: executionID},
+			{Column: "status", Value: "queued"},
+			{Column: "scheduled_at", OP: "notnull"},
+		},
+		Limit: 1,
+	}
+
+	affected, err := mod.UpdateWhere(param, map[string]interface{}{
+		"scheduled_at": nil,
+		"updated_at":   time.Now(),
+	})
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// waitingExecutions returns the executions waiting for their dependencies
+func waitingExecutions() ([]*Execution, error) {
+	mod := model.Select("__yao.job.execution")
+	if mod == nil {
+		return nil, fmt.Errorf("job execution model not found")
+	}
+
+	results, err := mod.Get(model.QueryParam{
+		Select: ExecutionFields,
+		Wheres: []model.QueryWhere{
+			{Column: "status", Value: "waiting"},
+		},
+		Orders: []model.QueryOrder{
+			{Column: "created_at", Option: "asc"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	executions := make([]*Execution, 0, len(results))
+	for _, result := range results {
+		execution := &Execution{}
+		if err := mapToStruct(result, execution); err != nil {
+			continue
+		}
+
+		// Restore ExecutionConfig from ConfigSnapshot if available
+		if execution.ConfigSnapshot != nil && len(*execution.ConfigSnapshot) > 0 {
+			var config ExecutionConfig
+			if err := jsoniter.Unmarshal(*execution.ConfigSnapshot, &config); err == nil {
+				execution.ExecutionConfig = &config
+			}
+		}
+
+		executions = append(executions, execution)
+	}
+
+	return executions, nil
+}
+
+// executionStatuses returns the statuses of the executions, the executions not found are not included
+func executionStatuses(executionIDs []string) (map[string]string, error) {
+	statuses := map[string]string{}
+	if len(executionIDs) == 0 {
+		return statuses, nil
+	}
+
+	mod := model.Select("__yao.job.execution")
+	if mod == nil {
+		return nil, fmt.Errorf("job execution model not found")
+	}
+
+	results, err := mod.Get(model.QueryParam{
+		Select: []interface{}{"execution_id", "status"},
+		Wheres: []model.QueryWhere{
+			{Column: "execution_id", OP: "in", Value: executionIDs},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range results {
+		id, _ := result["execution_id"].(string)
+		status, _ := result["status"].(string)
+		statuses[id] = status
+	}
+	return statuses, nil
+}
+
+// claimWaiting marks the waiting execution queued, returns false if it is claimed by another instance
+func claimWaiting(executionID string) (bool, error) {
+	mod := model.Select("__yao.job.execution")
+	if mod == nil {
+		return false, fmt.Errorf("job execution model not found")
+	}
+
+	param := model.QueryParam{
+		Wheres: []model.QueryWhere{
+			{Column: "execution_id", Value: executionID},
+			{Column: "status", Value: "waiting"},
+		},
+		Limit: 1,
+	}
+
+	affected, err := mod.UpdateWhere(param, map[string]interface{}{
+		"status":     "queued",
+		"updated_at": time.Now(),
+	})
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
 // ========================
 // Live progress methods
 // ========================
@@ -1234,6 +1469,16 @@ func mapToStruct(m maps.MapStr, v interface{}) error {
 			} else {
 				cleanMap[key] = value
 			}
+		case "execution_options":
+			// The JSON columns are strings on SQLite
+			if str, ok := value.(string); ok && str != "" {
+				var options interface{}
+				if err := jsoniter.UnmarshalFromString(str, &options); err == nil {
+					cleanMap[key] = options
+					continue
+				}
+			}
+			cleanMap[key] = value
 		default:
 			cleanMap[key] = value
 		}
@@ -1278,7 +1523,7 @@ func updateJobProgress(jobID string) error {
 		switch execution.Status {
 		case "completed":
 			completedCount++
-		case "failed":
+		case "failed", "dead":
 			failedCount++
 		case "running":
 			runningCount++
@@ -12,21 +12,23 @@ import (
 
 // Add adds a new execution with Yao process (default execution type)
 func (j *Job) Add(options *ExecutionOptions, processName string, args ...interface{}) error {
-	return j.addExecution(options, &ExecutionConfig{
+	_, err := j.addExecution(options, &ExecutionConfig{
 		Type:        ExecutionTypeProcess,
 		ProcessName: processName,
 		ProcessArgs: args,
 	})
+	return err
 }
 
 // AddCommand adds a new execution with system command
 func (j *Job) AddCommand(options *ExecutionOptions, command string, args []string, env map[string]string) error {
-	return j.addExecution(options, &ExecutionConfig{
+	_, err := j.addExecution(options, &ExecutionConfig{
 		Type:        ExecutionTypeCommand,
 		Command:     command,
 		CommandArgs: args,
 		Environment: env,
 	})
+	return err
 }
 
 // AddFunc adds a new execution with a Go function
@@ -34,16 +36,26 @@ func (j *Job) AddCommand(options *ExecutionOptions, command string, args []strin
 // Note: The function is stored in memory registry and will be lost if the process restarts
 func (j *Job) AddFunc(options *ExecutionOptions, name string, fn ExecutionFunc, args map[string]interface{}) error {
 	// fn will be registered in addExecution after ExecutionID is generated
-	return j.addExecution(options, &ExecutionConfig{
+	_, err := j.addExecution(options, &ExecutionConfig{
 		Type:     ExecutionTypeFunc,
 		Func:     fn, // Temporarily store here, will be moved to registry
 		FuncName: name,
 		FuncArgs: args,
 	})
+	return err
+}
+
+// AddExecution adds a new execution with the execution config and returns it,
+// the ID of the returned execution is used in the DependsOn option of the executions depending on it
+func (j *Job) AddExecution(options *ExecutionOptions, config *ExecutionConfig) (*Execution, error) {
+	if config == nil {
+		return nil, fmt.Errorf("execution config is required")
+	}
+	return j.addExecution(options, config)
 }
 
 // addExecution is the internal method to create execution records
-func (j *Job) addExecution(options *ExecutionOptions, config *ExecutionConfig) error {
+func (j *Job) addExecution(options *ExecutionOptions, config *ExecutionConfig) (*Execution, error) {
 	// Set default options if nil
 	if options == nil {
 		options = &ExecutionOptions{
@@ -52,6 +64,13 @@ func (j *Job) addExecution(options *ExecutionOptions, config *ExecutionConfig) e
 		}
 	}
 
+	// The dependencies must exist, so the dependency graph can not have cycles
+	for _, id := range options.DependsOn {
+		if _, err := GetExecution(id, model.QueryParam{Select: []interface{}{"execution_id"}}); err != nil {
+			return nil, fmt.Errorf("dependency %s: %w", id, err)
+		}
+	}
+
 	// For ExecutionTypeFunc, we need to register the function after getting ExecutionID
 	// Store the function temporarily and clear it before serialization
 	var funcToRegister ExecutionFunc
@@ -63,7 +82,7 @@ func (j *Job) addExecution(options *ExecutionOptions, config *ExecutionConfig) e
 	// Serialize ExecutionConfig to JSON for ConfigSnapshot
 	configBytes, err := jsoniter.Marshal(config)
 	if err != nil {
-		return fmt.Errorf("failed to serialize execution config: %w", err)
+		return nil, fmt.Errorf("failed to serialize execution config: %w", err)
 	}
 	configSnapshot := json.RawMessage(configBytes)
 
@@ -82,9 +101,14 @@ func (j *Job) addExecution(options *ExecutionOptions, config *ExecutionConfig) e
 		UpdatedAt:        time.Now(),
 	}
 
+	// The first dependency is recorded as the parent, the execution is waiting for the dependencies when it is submitted
+	if len(options.DependsOn) > 0 {
+		execution.ParentExecutionID = &options.DependsOn[0]
+	}
+
 	// Save execution to database (this generates ExecutionID)
 	if err := SaveExecution(execution); err != nil {
-		return fmt.Errorf("failed to create execution record: %w", err)
+		return nil, fmt.Errorf("failed to create execution record: %w", err)
 	}
 
 	// For ExecutionTypeFunc, register the function in global registry using ExecutionID
@@ -93,7 +117,7 @@ func (j *Job) addExecution(options *ExecutionOptions, config *ExecutionConfig) e
 		RegisterFunc(execution.ExecutionID, funcToRegister)
 	}
 
-	return nil
+	return execution, nil
 }
 
 // GetExecutions get executions for this job
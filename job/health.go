@@ -125,6 +125,11 @@ func (hc *HealthChecker) getRunningJobs() ([]*Job, error) {
 
 // checkJobHealth checks the health status of a single job
 func (hc *HealthChecker) checkJobHealth(job *Job, wm *WorkerManager) error {
+	// The executions of the schedules are run by the schedule package instead of the workers
+	if _, ok := job.Config["schedule"]; ok {
+		return nil
+	}
+
 	// Get current execution record for the job
 	if job.CurrentExecutionID == nil || *job.CurrentExecutionID == "" {
 		// No current execution ID but status is running, this is abnormal
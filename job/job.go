@@ -210,16 +210,6 @@ func (j *Job) Push() error {
 		return priorityI > priorityJ
 	})
 
-	// Initialize job context for cancellation
-	if j.ctx == nil {
-		j.ctx, j.cancel = context.WithCancel(context.Background())
-	}
-
-	// Initialize execution contexts map
-	if j.executionContexts == nil {
-		j.executionContexts = make(map[string]context.CancelFunc)
-	}
-
 	// Update job status to ready
 	j.Status = "ready"
 	if err := SaveJob(j); err != nil {
@@ -230,23 +220,16 @@ func (j *Job) Push() error {
 	wm := GetWorkerManager()
 
 	// Submit executions and ensure all are added successfully
+	// The executions depending on others are held until their dependencies are completed
 	var submitErrors []string
 	for _, execution := range executions {
 		// Create execution-specific context derived from job context
-		execCtx, execCancel := context.WithCancel(j.ctx)
-
-		// Store execution cancel function
-		j.executionMutex.Lock()
-		j.executionContexts[execution.ExecutionID] = execCancel
-		j.executionMutex.Unlock()
+		execCtx := j.executionContext(execution.ExecutionID)
 
 		// Submit execution (non-blocking)
-		if err := wm.SubmitJob(execCtx, j, execution); err != nil {
+		if err := wm.submit(execCtx, j, execution); err != nil {
 			// Clean up on error
-			execCancel()
-			j.executionMutex.Lock()
-			delete(j.executionContexts, execution.ExecutionID)
-			j.executionMutex.Unlock()
+			j.releaseContext(execution.ExecutionID)
 
 			submitErrors = append(submitErrors, fmt.Sprintf("execution %s: %v", execution.ExecutionID, err))
 			log.Error("Failed to submit execution %s: %v", execution.ExecutionID, err)
@@ -261,6 +244,36 @@ func (j *Job) Push() error {
 	return nil
 }
 
+// executionContext creates the context of the execution derived from the job context, the job stop cancels it
+func (j *Job) executionContext(executionID string) context.Context {
+	j.executionMutex.Lock()
+	defer j.executionMutex.Unlock()
+
+	// Initialize job context for cancellation
+	if j.ctx == nil {
+		j.ctx, j.cancel = context.WithCancel(context.Background())
+	}
+
+	// Initialize execution contexts map
+	if j.executionContexts == nil {
+		j.executionContexts = make(map[string]context.CancelFunc)
+	}
+
+	ctx, cancel := context.WithCancel(j.ctx)
+	j.executionContexts[executionID] = cancel
+	return ctx
+}
+
+// releaseContext releases the context of the finished execution
+func (j *Job) releaseContext(executionID string) {
+	j.executionMutex.Lock()
+	defer j.executionMutex.Unlock()
+	if cancel, has := j.executionContexts[executionID]; has {
+		cancel()
+		delete(j.executionContexts, executionID)
+	}
+}
+
 // Stop stops the job and cancels all running executions
 func (j *Job) Stop() error {
 	// Update job status
@@ -282,7 +295,7 @@ func (j *Job) Stop() error {
 
 		// Update execution status in database
 		execution, err := GetExecution(executionID, model.QueryParam{})
-		if err == nil && (execution.Status == "queued" || execution.Status == "running") {
+		if err == nil && (execution.Status == "queued" || execution.Status == "waiting" || execution.Status == "running") {
 			execution.Status = "cancelled"
 			execution.EndedAt = &time.Time{}
 			*execution.EndedAt = time.Now()
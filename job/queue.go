@@ -0,0 +1,572 @@
+package job
+
+import (
+	"container/heap"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/kun/log"
+)
+
+// RetryBaseDelay the delay before the first retry, doubled for every next retry
+var RetryBaseDelay = time.Second
+
+// RetryMaxDelay the max delay before a retry
+var RetryMaxDelay = 5 * time.Minute
+
+// RetryPollInterval the interval to check the retries due
+var RetryPollInterval = time.Second
+
+// requeueStatus the execution statuses can be requeued
+var requeueStatus = map[string]bool{
+	"dead":      true,
+	"failed":    true,
+	"cancelled": true,
+	"timeout":   true,
+	"killed":    true,
+}
+
+// workQueue the pending requests ordered by the job priority, the execution priority and the submission order
+type workQueue struct {
+	items    workItems
+	capacity int
+	sequence uint64
+	notify   chan struct{}
+	mu       sync.Mutex
+}
+
+// workItem a pending request of the work queue
+type workItem struct {
+	work      *WorkRequest
+	priority  int // Job priority
+	execution int // Execution priority
+	sequence  uint64
+}
+
+// workItems the heap of the pending requests
+type workItems []*workItem
+
+// waitingRequest a request waiting for its dependencies
+type waitingRequest struct {
+	work    *WorkRequest
+	pending map[string]bool // The dependencies not completed yet
+}
+
+func (items workItems) Len() int { return len(items) }
+
+func (items workItems) Less(i, j int) bool {
+	if items[i].priority != items[j].priority {
+		return items[i].priority > items[j].priority
+	}
+	if items[i].execution != items[j].execution {
+		return items[i].execution > items[j].execution
+	}
+	return items[i].sequence < items[j].sequence
+}
+
+func (items workItems) Swap(i, j int) { items[i], items[j] = items[j], items[i] }
+
+func (items *workItems) Push(x interface{}) { *items = append(*items, x.(*workItem)) }
+
+func (items *workItems) Pop() interface{} {
+	old := *items
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*items = old[:n-1]
+	return item
+}
+
+// newWorkQueue creates a work queue holding up to capacity requests
+func newWorkQueue(capacity int) *workQueue {
+	return &workQueue{
+		items:    workItems{},
+		capacity: capacity,
+		notify:   make(chan struct{}, 1),
+	}
+}
+
+// push adds the request to the queue and wakes up the dispatcher
+func (q *workQueue) push(work *WorkRequest) error {
+	q.mu.Lock()
+	if len(q.items) >= q.capacity {
+		length := len(q.items)
+		q.mu.Unlock()
+		return fmt.Errorf("work queue is full (%d/%d), please retry later", length, q.capacity)
+	}
+
+	q.sequence++
+	item := &workItem{work: work, priority: work.Job.Priority, sequence: q.sequence}
+	if work.Execution.ExecutionOptions != nil {
+		item.execution = work.Execution.ExecutionOptions.Priority
+	}
+	heap.Push(&q.items, item)
+	q.mu.Unlock()
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// pop returns the request of the highest priority, the cancelled requests are dropped
+func (q *workQueue) pop() *WorkRequest {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for len(q.items) > 0 {
+		item := heap.Pop(&q.items).(*workItem)
+		if item.work.Context != nil && item.work.Context.Err() != nil {
+			log.Warn("Job %s execution %s submission cancelled", item.work.Job.JobID, item.work.Execution.ExecutionID)
+			continue
+		}
+		return item.work
+	}
+	return nil
+}
+
+// len returns the number of the pending requests
+func (q *workQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.items)
+}
+
+// RetryDelay returns the delay before the retry attempt (1 for the first retry).
+// The delay is doubled for every attempt up to RetryMaxDelay, a random jitter of up to the half of the delay
+// is removed so the executions failed at the same time are not retried at once.
+func RetryDelay(attempt int) time.Duration {
+	delay := RetryBaseDelay
+	for i := 1; i < attempt && delay < RetryMaxDelay; i++ {
+		delay = delay * 2
+	}
+	if delay > RetryMaxDelay {
+		delay = RetryMaxDelay
+	}
+
+	half := int64(delay / 2)
+	if half <= 0 {
+		return delay
+	}
+	return delay - time.Duration(rand.Int63n(half+1))
+}
+
+// Requeue puts a dead-lettered or failed execution back to the queue with its retries reset,
+// the execution waits for its dependencies again if they are not completed.
+// The function of a function execution is removed from the registry when it is dead-lettered or fails,
+// and the registry is not persisted, register it again under its FuncID (the execution ID by default) before requeuing.
+func Requeue(executionID string) (*Execution, error) {
+	execution, err := GetExecution(executionID, model.QueryParam{})
+	if err != nil {
+		return nil, err
+	}
+
+	if !requeueStatus[execution.Status] {
+		return nil, fmt.Errorf("execution %s is %s, only the dead, failed, cancelled, timeout or killed executions can be requeued", executionID, execution.Status)
+	}
+
+	if execution.ExecutionConfig != nil && execution.ExecutionConfig.Type == ExecutionTypeFunc && retainFunc(execution) == nil {
+		return nil, fmt.Errorf("the function of execution %s is not registered anymore", executionID)
+	}
+
+	job, err := GetJob(execution.JobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := resetExecution(execution); err != nil {
+		return nil, fmt.Errorf("failed to reset execution %s: %w", executionID, err)
+	}
+
+	ctx := job.executionContext(execution.ExecutionID)
+	if err := GetWorkerManager().submit(ctx, job, execution); err != nil {
+		job.releaseContext(execution.ExecutionID)
+		return nil, err
+	}
+
+	log.Info("Job %s execution %s requeued", job.JobID, executionID)
+	return execution, nil
+}
+
+// submit submits the execution, or holds it until its dependencies are completed.
+// The execution is dead-lettered if one of its dependencies can not complete.
+func (wm *WorkerManager) submit(ctx context.Context, job *Job, execution *Execution) error {
+	work := &WorkRequest{Job: job, Execution: execution, Context: ctx}
+	dependencies := execution.Dependencies()
+	if len(dependencies) == 0 {
+		return wm.SubmitJob(ctx, job, execution)
+	}
+
+	// The dependencies are checked under the lock, a dependency completed after the check releases the execution
+	wm.waitingMu.Lock()
+	pending := map[string]bool{}
+	for _, id := range dependencies {
+		parent, err := GetExecution(id, model.QueryParam{Select: []interface{}{"execution_id", "status"}})
+		if err != nil {
+			wm.waitingMu.Unlock()
+			wm.bury(work, fmt.Sprintf("dependency %s: %v", id, err))
+			return nil
+		}
+
+		switch parent.Status {
+		case "completed":
+		case "dead", "failed", "cancelled", "timeout", "killed":
+			wm.waitingMu.Unlock()
+			wm.bury(work, fmt.Sprintf("dependency %s is %s", id, parent.Status))
+			return nil
+		default:
+			pending[id] = true
+		}
+	}
+
+	if len(pending) == 0 {
+		wm.waitingMu.Unlock()
+		return wm.SubmitJob(ctx, job, execution)
+	}
+
+	execution.Status = "waiting"
+	if err := SaveExecution(execution); err != nil {
+		log.Warn("Failed to save execution status (database may be closed): %v", err)
+	}
+	wm.waiting[execution.ExecutionID] = &waitingRequest{work: work, pending: pending}
+	wm.waitingMu.Unlock()
+
+	// The dependencies finished on the other instances are checked by the scheduler
+	wm.startScheduler()
+
+	log.Debug("Job %s execution %s is waiting for %d dependencies", job.JobID, execution.ExecutionID, len(pending))
+	return nil
+}
+
+// release submits the executions waiting for the finished execution when their dependencies are all completed,
+// the waiting executions are dead-lettered if the finished execution is not completed
+func (wm *WorkerManager) release(execution *Execution) {
+	wm.waitingMu.Lock()
+	ready := []*WorkRequest{}
+	blocked := []*WorkRequest{}
+	for id, waiting := range wm.waiting {
+		// The cancelled executions are not waiting anymore
+		if waiting.work.Context.Err() != nil {
+			delete(wm.waiting, id)
+			continue
+		}
+
+		if !waiting.pending[execution.ExecutionID] {
+			continue
+		}
+
+		if execution.Status != "completed" {
+			delete(wm.waiting, id)
+			blocked = append(blocked, waiting.work)
+			continue
+		}
+
+		delete(waiting.pending, execution.ExecutionID)
+		if len(waiting.pending) == 0 {
+			delete(wm.waiting, id)
+			ready = append(ready, waiting.work)
+		}
+	}
+	wm.waitingMu.Unlock()
+
+	for _, work := range ready {
+		if wm.claimWaiting(work) {
+			wm.submitWaiting(work, "")
+		}
+	}
+
+	for _, work := range blocked {
+		if wm.claimWaiting(work) {
+			wm.submitWaiting(work, fmt.Sprintf("dependency %s is %s", execution.ExecutionID, execution.Status))
+		}
+	}
+}
+
+// claimWaiting claims the waiting execution so it is released once across the instances
+func (wm *WorkerManager) claimWaiting(work *WorkRequest) bool {
+	claimed, err := claimWaiting(work.Execution.ExecutionID)
+	if err != nil {
+		log.Warn("Failed to claim the waiting execution %s (database may be closed): %v", work.Execution.ExecutionID, err)
+		return true
+	}
+	return claimed
+}
+
+// submitWaiting submits the claimed waiting execution, it is dead-lettered with the reason if one of its dependencies can not complete
+func (wm *WorkerManager) submitWaiting(work *WorkRequest, reason string) {
+	if reason != "" {
+		wm.bury(work, reason)
+		return
+	}
+
+	work.Execution.Status = "queued"
+	if work.Context.Err() != nil {
+		work.Job.releaseContext(work.Execution.ExecutionID)
+		return
+	}
+
+	if err := wm.SubmitJob(work.Context, work.Job, work.Execution); err != nil {
+		wm.bury(work, err.Error())
+	}
+}
+
+// releaseWaiting re-evaluates the waiting executions in the database, it releases the executions waiting before a restart
+// and the executions depending on the executions finished on the other instances.
+// The waiting executions of the other processes are left to the servers.
+func (wm *WorkerManager) releaseWaiting() {
+	executions, err := waitingExecutions()
+	if err != nil {
+		log.Warn("Failed to get the waiting executions (database may be closed): %v", err)
+		return
+	}
+	if len(executions) == 0 {
+		return
+	}
+
+	dependencies := []string{}
+	for _, execution := range executions {
+		dependencies = append(dependencies, execution.Dependencies()...)
+	}
+
+	statuses, err := executionStatuses(dependencies)
+	if err != nil {
+		log.Warn("Failed to get the status of the dependencies (database may be closed): %v", err)
+		return
+	}
+
+	resumed := wm.resumed.Load()
+	for _, execution := range executions {
+		wm.waitingMu.Lock()
+		held, has := wm.waiting[execution.ExecutionID]
+		if has && held.work.Context.Err() != nil {
+			// The cancelled executions are not waiting anymore
+			delete(wm.waiting, execution.ExecutionID)
+			has = false
+			wm.waitingMu.Unlock()
+			continue
+		}
+		wm.waitingMu.Unlock()
+		if !has && !resumed {
+			continue
+		}
+
+		ready, reason := true, ""
+		for _, id := range execution.Dependencies() {
+			status, found := statuses[id]
+			switch {
+			case !found:
+				reason = fmt.Sprintf("dependency %s: execution not found", id)
+			case status == "completed":
+			case requeueStatus[status]:
+				reason = fmt.Sprintf("dependency %s is %s", id, status)
+			default:
+				ready = false
+			}
+			if reason != "" || !ready {
+				break
+			}
+		}
+		if reason == "" && !ready {
+			continue
+		}
+
+		claimed, err := claimWaiting(execution.ExecutionID)
+		if err != nil {
+			log.Warn("Failed to claim the waiting execution %s: %v", execution.ExecutionID, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		var work *WorkRequest
+		if has {
+			wm.waitingMu.Lock()
+			delete(wm.waiting, execution.ExecutionID)
+			wm.waitingMu.Unlock()
+			work = held.work
+		} else if work = wm.resumeRequest(execution); work == nil {
+			continue
+		}
+		wm.submitWaiting(work, reason)
+	}
+}
+
+// retry holds the failed request until its retry is due, the function of the execution is registered again.
+// The retry is scheduled at the persisted scheduled_at, the scheduler submits it when it is due.
+func (wm *WorkerManager) retry(work *WorkRequest, fn ExecutionFunc) {
+	if fn != nil {
+		RegisterFunc(funcID(work.Execution), fn)
+	}
+
+	wm.retriesMu.Lock()
+	wm.retries[work.Execution.ExecutionID] = work
+	wm.retriesMu.Unlock()
+	wm.startScheduler()
+}
+
+// Resume starts the scheduler, the retries scheduled and the executions waiting before a restart are submitted
+// when they are due or their dependencies are completed.
+// It is called by the server (yao start) only, the one-shot commands submit their own executions and leave the others.
+func Resume() {
+	wm := GetWorkerManager()
+	wm.resumed.Store(true)
+	wm.startScheduler()
+}
+
+// startScheduler starts the scheduler of the retries and the waiting executions once
+func (wm *WorkerManager) startScheduler() {
+	wm.scheduler.Do(func() { go wm.schedule() })
+}
+
+// schedule submits the due retries and releases the waiting executions every RetryPollInterval until the worker manager is stopped
+func (wm *WorkerManager) schedule() {
+	for {
+		select {
+		case <-time.After(RetryPollInterval):
+			wm.submitDueRetries(time.Now())
+			wm.releaseWaiting()
+		case <-wm.quit:
+			return
+		}
+	}
+}
+
+// submitDueRetries claims and submits the retries due at the time
+func (wm *WorkerManager) submitDueRetries(now time.Time) {
+	executions, err := dueRetries(now)
+	if err != nil {
+		log.Warn("Failed to get the due retries (database may be closed): %v", err)
+		return
+	}
+
+	resumed := wm.resumed.Load()
+	for _, execution := range executions {
+		// The retries of the other processes are left to the servers
+		if !resumed && !wm.holdsRetry(execution.ExecutionID) {
+			continue
+		}
+
+		// The retry is claimed by another instance
+		claimed, err := claimRetry(execution.ExecutionID)
+		if err != nil {
+			log.Warn("Failed to claim the retry of execution %s: %v", execution.ExecutionID, err)
+			continue
+		}
+		if claimed {
+			wm.submitRetry(execution)
+		}
+	}
+}
+
+// holdsRetry checks if the retry of the execution is held by this process
+func (wm *WorkerManager) holdsRetry(executionID string) bool {
+	wm.retriesMu.Lock()
+	defer wm.retriesMu.Unlock()
+	_, held := wm.retries[executionID]
+	return held
+}
+
+// submitRetry submits the claimed retry, the request is rebuilt from the database if it was scheduled before a restart.
+func (wm *WorkerManager) submitRetry(execution *Execution) {
+	wm.retriesMu.Lock()
+	work, held := wm.retries[execution.ExecutionID]
+	delete(wm.retries, execution.ExecutionID)
+	wm.retriesMu.Unlock()
+
+	isFunc := execution.ExecutionConfig != nil && execution.ExecutionConfig.Type == ExecutionTypeFunc
+	if !held {
+		if work = wm.resumeRequest(execution); work == nil {
+			return
+		}
+	}
+	work.Execution.ScheduledAt = nil
+
+	if work.Context.Err() != nil {
+		if isFunc {
+			UnregisterFunc(funcID(work.Execution))
+		}
+		work.Job.releaseContext(work.Execution.ExecutionID)
+		return
+	}
+
+	if err := wm.SubmitJob(work.Context, work.Job, work.Execution); err != nil {
+		log.Error("Failed to submit the retry of execution %s: %v", work.Execution.ExecutionID, err)
+		wm.bury(work, err.Error())
+	}
+}
+
+// resumeRequest rebuilds the request of the execution from the database, nil if it can not be resumed.
+// The function executions can not be resumed after a restart, they are dead-lettered.
+func (wm *WorkerManager) resumeRequest(execution *Execution) *WorkRequest {
+	job, err := GetJob(execution.JobID)
+	if err != nil {
+		log.Error("Failed to resume execution %s: %v", execution.ExecutionID, err)
+		return nil
+	}
+
+	work := &WorkRequest{Job: job, Execution: execution, Context: job.executionContext(execution.ExecutionID)}
+	if execution.ExecutionConfig != nil && execution.ExecutionConfig.Type == ExecutionTypeFunc && retainFunc(execution) == nil {
+		wm.bury(work, "the function is not registered anymore")
+		return nil
+	}
+	return work
+}
+
+// bury dead-letters the request that can not run, the executions depending on it are dead-lettered too
+func (wm *WorkerManager) bury(work *WorkRequest, reason string) {
+	now := time.Now()
+	errorData, _ := jsoniter.Marshal(map[string]interface{}{
+		"error":   reason,
+		"time":    now,
+		"attempt": work.Execution.RetryAttempt,
+	})
+	work.Execution.Status = "dead"
+	work.Execution.EndedAt = &now
+	work.Execution.ErrorInfo = (*json.RawMessage)(&errorData)
+	if err := SaveExecution(work.Execution); err != nil {
+		log.Warn("Failed to save execution status (database may be closed): %v", err)
+	}
+
+	log.Error("Job %s execution %s is dead: %s", work.Job.JobID, work.Execution.ExecutionID, reason)
+	logEntry := &Log{
+		JobID:       work.Job.JobID,
+		Level:       "error",
+		Message:     fmt.Sprintf("Execution dead: %s", reason),
+		ExecutionID: &work.Execution.ExecutionID,
+		Timestamp:   now,
+		Sequence:    0,
+	}
+	if err := SaveLog(logEntry); err != nil {
+		log.Warn("Failed to save error log (database may be closed): %v", err)
+	}
+
+	if work.Execution.ExecutionConfig != nil && work.Execution.ExecutionConfig.Type == ExecutionTypeFunc {
+		UnregisterFunc(funcID(work.Execution))
+	}
+	work.Job.releaseContext(work.Execution.ExecutionID)
+	wm.release(work.Execution)
+}
+
+// funcID returns the registry key of the function of the execution
+func funcID(execution *Execution) string {
+	if execution.ExecutionConfig != nil && execution.ExecutionConfig.FuncID != "" {
+		return execution.ExecutionConfig.FuncID
+	}
+	return execution.ExecutionID
+}
+
+// retainFunc returns the registered function of the execution, nil if the execution is not a function
+func retainFunc(execution *Execution) ExecutionFunc {
+	if execution.ExecutionConfig == nil || execution.ExecutionConfig.Type != ExecutionTypeFunc {
+		return nil
+	}
+	fn, _ := GetFunc(funcID(execution))
+	return fn
+}
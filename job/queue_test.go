@@ -0,0 +1,376 @@
+package job_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/kun/exception"
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/job"
+	"github.com/yaoapp/yao/test"
+)
+
+func TestRetryDelay(t *testing.T) {
+	base, maxDelay := job.RetryBaseDelay, job.RetryMaxDelay
+	defer func() { job.RetryBaseDelay, job.RetryMaxDelay = base, maxDelay }()
+
+	job.RetryBaseDelay = time.Second
+	job.RetryMaxDelay = 10 * time.Second
+
+	cases := map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 8 * time.Second, 5: 10 * time.Second, 100: 10 * time.Second}
+	for attempt, delay := range cases {
+		for i := 0; i < 20; i++ {
+			got := job.RetryDelay(attempt)
+			if got > delay || got < delay/2 {
+				t.Errorf("Expected the delay of attempt %d between %s and %s, got %s", attempt, delay/2, delay, got)
+			}
+		}
+	}
+}
+
+func TestRetryAndDeadLetter(t *testing.T) {
+	test.Prepare(&testing.T{}, config.Conf)
+	defer test.Clean()
+	useShortRetries(t)
+
+	testJob, err := job.OnceAndSave(job.GOROUTINE, map[string]interface{}{
+		"name":            "Test Retry Job",
+		"max_retry_count": 2,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	var mu sync.Mutex
+	calls := 0
+	execution, err := testJob.AddExecution(nil, &job.ExecutionConfig{
+		Type:     job.ExecutionTypeFunc,
+		FuncName: "test.retry",
+		Func: func(ctx *job.ExecutionContext) error {
+			mu.Lock()
+			defer mu.Unlock()
+			calls++
+			return fmt.Errorf("attempt %d failed", calls)
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to add execution: %v", err)
+	}
+
+	if err := testJob.Push(); err != nil {
+		t.Fatalf("Failed to push job: %v", err)
+	}
+
+	dead := waitStatus(t, execution.ExecutionID, "dead")
+	if dead.RetryAttempt != 2 {
+		t.Errorf("Expected retry attempt 2, got %d", dead.RetryAttempt)
+	}
+
+	mu.Lock()
+	if calls != 3 {
+		t.Errorf("Expected 3 calls (1 attempt + 2 retries), got %d", calls)
+	}
+	mu.Unlock()
+
+	// The function is removed from the registry, the execution can not be requeued
+	if _, ok := job.GetFunc(execution.ExecutionID); ok {
+		t.Error("Expected function to be removed from global registry after the retries")
+	}
+	if _, err := job.Requeue(execution.ExecutionID); err == nil {
+		t.Error("Expected error when requeuing a function execution removed from the registry")
+	}
+
+	// The function registered again can be requeued
+	job.RegisterFunc(execution.ExecutionID, func(ctx *job.ExecutionContext) error { return nil })
+	if _, err := job.Requeue(execution.ExecutionID); err != nil {
+		t.Fatalf("Failed to requeue execution: %v", err)
+	}
+	waitStatus(t, execution.ExecutionID, "completed")
+}
+
+func TestDependencies(t *testing.T) {
+	test.Prepare(&testing.T{}, config.Conf)
+	defer test.Clean()
+
+	testJob, err := job.OnceAndSave(job.GOROUTINE, map[string]interface{}{
+		"name": "Test Dependencies Job",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	var mu sync.Mutex
+	order := []string{}
+	step := func(name string, fail bool) *job.ExecutionConfig {
+		return &job.ExecutionConfig{
+			Type:     job.ExecutionTypeFunc,
+			FuncName: name,
+			Func: func(ctx *job.ExecutionContext) error {
+				time.Sleep(20 * time.Millisecond)
+				mu.Lock()
+				defer mu.Unlock()
+				order = append(order, name)
+				if fail {
+					return fmt.Errorf("%s failed", name)
+				}
+				return nil
+			},
+		}
+	}
+
+	// extract -> transform -> load, and extract -> broken -> skipped
+	extract, err := testJob.AddExecution(nil, step("extract", false))
+	if err != nil {
+		t.Fatalf("Failed to add execution: %v", err)
+	}
+	transform, err := testJob.AddExecution(job.NewExecutionOptions().WithDependsOn(extract.ExecutionID).WithPriority(10), step("transform", false))
+	if err != nil {
+		t.Fatalf("Failed to add execution: %v", err)
+	}
+	load, err := testJob.AddExecution(job.NewExecutionOptions().WithDependsOn(extract.ExecutionID, transform.ExecutionID), step("load", false))
+	if err != nil {
+		t.Fatalf("Failed to add execution: %v", err)
+	}
+	broken, err := testJob.AddExecution(job.NewExecutionOptions().WithDependsOn(extract.ExecutionID), step("broken", true))
+	if err != nil {
+		t.Fatalf("Failed to add execution: %v", err)
+	}
+	skipped, err := testJob.AddExecution(job.NewExecutionOptions().WithDependsOn(broken.ExecutionID), step("skipped", false))
+	if err != nil {
+		t.Fatalf("Failed to add execution: %v", err)
+	}
+
+	if load.ParentExecutionID == nil || *load.ParentExecutionID != extract.ExecutionID {
+		t.Errorf("Expected the extract execution to be the parent of the load execution")
+	}
+
+	// The dependencies are persisted
+	saved, err := job.GetExecution(load.ExecutionID, model.QueryParam{})
+	if err != nil {
+		t.Fatalf("Failed to get execution: %v", err)
+	}
+	if deps := saved.Dependencies(); len(deps) != 2 || deps[0] != extract.ExecutionID || deps[1] != transform.ExecutionID {
+		t.Errorf("Expected the dependencies of the load execution to be persisted, got %v", deps)
+	}
+
+	// The dependencies must exist
+	if _, err := testJob.AddExecution(job.NewExecutionOptions().WithDependsOn("not-exists"), step("orphan", false)); err == nil {
+		t.Error("Expected error when the dependency does not exist")
+	}
+
+	if err := testJob.Push(); err != nil {
+		t.Fatalf("Failed to push job: %v", err)
+	}
+
+	waitStatus(t, load.ExecutionID, "completed")
+	waitStatus(t, broken.ExecutionID, "failed")
+	waitStatus(t, skipped.ExecutionID, "dead")
+
+	mu.Lock()
+	defer mu.Unlock()
+	position := map[string]int{}
+	for i, name := range order {
+		position[name] = i
+	}
+	if position["extract"] > position["transform"] || position["transform"] > position["load"] || position["extract"] > position["broken"] {
+		t.Errorf("Expected the executions to run after their dependencies, got %v", order)
+	}
+	if _, has := position["skipped"]; has {
+		t.Errorf("Expected the execution depending on a failed execution not to run, got %v", order)
+	}
+}
+
+func TestRequeue(t *testing.T) {
+	test.Prepare(&testing.T{}, config.Conf)
+	defer test.Clean()
+
+	var mu sync.Mutex
+	ready := false
+	process.Register("test.queue.flaky", func(process *process.Process) interface{} {
+		mu.Lock()
+		defer mu.Unlock()
+		if !ready {
+			exception.New("the source is not ready", 500).Throw()
+		}
+		return map[string]interface{}{"status": "success"}
+	})
+
+	testJob, err := job.OnceAndSave(job.GOROUTINE, map[string]interface{}{
+		"name": "Test Requeue Job",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	execution, err := testJob.AddExecution(nil, &job.ExecutionConfig{
+		Type:        job.ExecutionTypeProcess,
+		ProcessName: "test.queue.flaky",
+	})
+	if err != nil {
+		t.Fatalf("Failed to add execution: %v", err)
+	}
+
+	// The queued executions can not be requeued
+	if _, err := job.Requeue(execution.ExecutionID); err == nil {
+		t.Error("Expected error when requeuing a queued execution")
+	}
+
+	if err := testJob.Push(); err != nil {
+		t.Fatalf("Failed to push job: %v", err)
+	}
+	waitStatus(t, execution.ExecutionID, "failed")
+
+	mu.Lock()
+	ready = true
+	mu.Unlock()
+
+	requeued, err := job.Requeue(execution.ExecutionID)
+	if err != nil {
+		t.Fatalf("Failed to requeue execution: %v", err)
+	}
+	if requeued.RetryAttempt != 0 || requeued.ErrorInfo != nil {
+		t.Errorf("Expected the retries and the error of the requeued execution to be reset")
+	}
+
+	completed := waitStatus(t, execution.ExecutionID, "completed")
+	if completed.ErrorInfo != nil && string(*completed.ErrorInfo) != "null" {
+		t.Errorf("Expected the error info to be cleared, got %s", string(*completed.ErrorInfo))
+	}
+}
+
+func TestResumeRetries(t *testing.T) {
+	test.Prepare(&testing.T{}, config.Conf)
+	defer test.Clean()
+	useShortRetries(t)
+
+	process.Register("test.queue.resume", func(process *process.Process) interface{} {
+		return map[string]interface{}{"status": "success"}
+	})
+
+	testJob, err := job.OnceAndSave(job.GOROUTINE, map[string]interface{}{
+		"name":            "Test Resume Job",
+		"max_retry_count": 3,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	// The retries scheduled before a restart are only in the database
+	scheduled := func(config *job.ExecutionConfig) *job.Execution {
+		execution, err := testJob.AddExecution(nil, config)
+		if err != nil {
+			t.Fatalf("Failed to add execution: %v", err)
+		}
+		scheduledAt := time.Now().Add(-time.Second)
+		execution.Status = "queued"
+		execution.RetryAttempt = 1
+		execution.ScheduledAt = &scheduledAt
+		if err := job.SaveExecution(execution); err != nil {
+			t.Fatalf("Failed to save execution: %v", err)
+		}
+		return execution
+	}
+
+	resumed := scheduled(&job.ExecutionConfig{Type: job.ExecutionTypeProcess, ProcessName: "test.queue.resume"})
+	lost := scheduled(&job.ExecutionConfig{
+		Type:     job.ExecutionTypeFunc,
+		FuncName: "test.resume",
+		Func:     func(ctx *job.ExecutionContext) error { return nil },
+	})
+	job.UnregisterFunc(lost.ExecutionID)
+
+	job.Resume()
+	completed := waitStatus(t, resumed.ExecutionID, "completed")
+	if completed.ScheduledAt != nil {
+		t.Errorf("Expected the schedule of the resumed retry to be cleared")
+	}
+
+	// The function is not registered after the restart, the execution is dead-lettered
+	waitStatus(t, lost.ExecutionID, "dead")
+}
+
+func TestResumeWaiting(t *testing.T) {
+	test.Prepare(&testing.T{}, config.Conf)
+	defer test.Clean()
+	useShortRetries(t)
+
+	process.Register("test.queue.waiting", func(process *process.Process) interface{} {
+		return map[string]interface{}{"status": "success"}
+	})
+
+	testJob, err := job.OnceAndSave(job.GOROUTINE, map[string]interface{}{"name": "Test Resume Waiting Job"})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	// The parents finished on another instance, or before a restart
+	finished := func(status string) *job.Execution {
+		execution, err := testJob.AddExecution(nil, &job.ExecutionConfig{Type: job.ExecutionTypeProcess, ProcessName: "test.queue.waiting"})
+		if err != nil {
+			t.Fatalf("Failed to add execution: %v", err)
+		}
+		execution.Status = status
+		if err := job.SaveExecution(execution); err != nil {
+			t.Fatalf("Failed to save execution: %v", err)
+		}
+		return execution
+	}
+
+	// The executions waiting for them are only in the database
+	waiting := func(parent *job.Execution) *job.Execution {
+		execution, err := testJob.AddExecution(job.NewExecutionOptions().WithDependsOn(parent.ExecutionID), &job.ExecutionConfig{Type: job.ExecutionTypeProcess, ProcessName: "test.queue.waiting"})
+		if err != nil {
+			t.Fatalf("Failed to add execution: %v", err)
+		}
+		execution.Status = "waiting"
+		if err := job.SaveExecution(execution); err != nil {
+			t.Fatalf("Failed to save execution: %v", err)
+		}
+		return execution
+	}
+
+	ready := waiting(finished("completed"))
+	blocked := waiting(finished("failed"))
+	pending := waiting(finished("running"))
+
+	job.Resume()
+	waitStatus(t, ready.ExecutionID, "completed")
+	waitStatus(t, blocked.ExecutionID, "dead")
+
+	// The execution is waiting until its dependency is completed
+	time.Sleep(100 * time.Millisecond)
+	waitStatus(t, pending.ExecutionID, "waiting")
+}
+
+// useShortRetries shortens the retry delays during the test
+func useShortRetries(t *testing.T) {
+	base, maxDelay, interval := job.RetryBaseDelay, job.RetryMaxDelay, job.RetryPollInterval
+	job.RetryBaseDelay = 10 * time.Millisecond
+	job.RetryMaxDelay = 50 * time.Millisecond
+	job.RetryPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { job.RetryBaseDelay, job.RetryMaxDelay, job.RetryPollInterval = base, maxDelay, interval })
+}
+
+// waitStatus waits until the execution has the status
+func waitStatus(t *testing.T, executionID string, status string) *job.Execution {
+	t.Helper()
+	var execution *job.Execution
+	var err error
+	for i := 0; i < 100; i++ {
+		execution, err = job.GetExecution(executionID, model.QueryParam{})
+		if err == nil && execution.Status == status {
+			return execution
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	if err != nil {
+		t.Fatalf("Failed to get execution %s: %v", executionID, err)
+	}
+	t.Fatalf("Expected execution %s to be %s, got %s", executionID, status, execution.Status)
+	return nil
+}
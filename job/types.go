@@ -64,8 +64,9 @@ const (
 
 // ExecutionOptions holds common execution options
 type ExecutionOptions struct {
-	Priority   int                    `json:"priority"`    // Execution priority (higher = more important)
-	SharedData map[string]interface{} `json:"shared_data"` // Shared data (session, context, etc.)
+	Priority   int                    `json:"priority"`             // Execution priority (higher = more important)
+	SharedData map[string]interface{} `json:"shared_data"`          // Shared data (session, context, etc.)
+	DependsOn  []string               `json:"depends_on,omitempty"` // The executions must be completed before this one runs
 }
 
 // NewExecutionOptions creates a new ExecutionOptions with default values
@@ -88,6 +89,12 @@ func (o *ExecutionOptions) WithSharedData(data map[string]interface{}) *Executio
 	return o
 }
 
+// WithDependsOn sets the executions must be completed before this one runs and returns the options for chaining
+func (o *ExecutionOptions) WithDependsOn(executionIDs ...string) *ExecutionOptions {
+	o.DependsOn = executionIDs
+	return o
+}
+
 // AddSharedData adds a key-value pair to shared data and returns the options for chaining
 func (o *ExecutionOptions) AddSharedData(key string, value interface{}) *ExecutionOptions {
 	if o.SharedData == nil {
@@ -220,7 +227,7 @@ type Execution struct {
 	ID                uint              `json:"id"`
 	ExecutionID       string            `json:"execution_id"`
 	JobID             string            `json:"job_id"`
-	Status            string            `json:"status"` // default: "queued"
+	Status            string            `json:"status"` // default: "queued", "waiting" for the dependencies, "dead" after the retries
 	TriggerCategory   string            `json:"trigger_category"`
 	TriggerSource     *string           `json:"trigger_source,omitempty"`      // nullable: true
 	TriggerContext    *json.RawMessage  `json:"trigger_context,omitempty"`     // nullable: true
@@ -252,6 +259,14 @@ type Execution struct {
 	Logs            []Log       `json:"logs,omitempty"`
 }
 
+// Dependencies returns the IDs of the executions this execution depends on
+func (e *Execution) Dependencies() []string {
+	if e.ExecutionOptions == nil {
+		return nil
+	}
+	return e.ExecutionOptions.DependsOn
+}
+
 // Log represents job execution logs and events
 type Log struct {
 	ID          uint             `json:"id"`
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,10 +18,16 @@ import (
 type WorkerManager struct {
 	maxWorkers    int
 	activeWorkers map[string]*Worker
-	workQueue     chan *WorkRequest
+	workQueue     *workQueue                 // Pending requests ordered by priority
+	waiting       map[string]*waitingRequest // executionID -> request waiting for its dependencies
+	retries       map[string]*WorkRequest    // executionID -> failed request waiting for its retry
 	workerPool    chan chan *WorkRequest
 	quit          chan bool
 	mu            sync.RWMutex
+	waitingMu     sync.Mutex
+	retriesMu     sync.Mutex
+	scheduler     sync.Once   // Starts the retry scheduler
+	resumed       atomic.Bool // The retries of the other processes are resumed, set by the server only
 }
 
 // Worker represents a single worker instance
@@ -78,7 +85,9 @@ func NewWorkerManager(maxWorkers int) *WorkerManager {
 	return &WorkerManager{
 		maxWorkers:    maxWorkers,
 		activeWorkers: make(map[string]*Worker),
-		workQueue:     make(chan *WorkRequest, maxWorkers*4), // Allow 200% overload (4x buffer)
+		workQueue:     newWorkQueue(maxWorkers * 4), // Allow 200% overload (4x buffer)
+		waiting:       make(map[string]*waitingRequest),
+		retries:       make(map[string]*WorkRequest),
 		workerPool:    make(chan chan *WorkRequest, maxWorkers),
 		quit:          make(chan bool),
 	}
@@ -135,17 +144,8 @@ func (wm *WorkerManager) Stop() {
 }
 
 // SubmitJob submits a job execution for processing with context (non-blocking)
+// The requests are dispatched by the job priority, then the execution priority, then the submission order
 func (wm *WorkerManager) SubmitJob(ctx context.Context, job *Job, execution *Execution) error {
-	// Check queue capacity before submitting
-	queueLen := len(wm.workQueue)
-	queueCap := cap(wm.workQueue)
-
-	// Allow reasonable backlog but prevent unlimited accumulation
-	// Reject only when queue is completely full to maximize throughput
-	if queueLen >= queueCap {
-		return fmt.Errorf("work queue is full (%d/%d), please retry later", queueLen, queueCap)
-	}
-
 	// Create work request
 	workRequest := &WorkRequest{
 		Job:       job,
@@ -153,34 +153,40 @@ func (wm *WorkerManager) SubmitJob(ctx context.Context, job *Job, execution *Exe
 		Context:   ctx,
 	}
 
-	// Submit asynchronously to avoid blocking
-	go func() {
-		select {
-		case wm.workQueue <- workRequest:
-			log.Debug("Job %s execution %s submitted to work queue", job.JobID, execution.ExecutionID)
-		case <-ctx.Done():
-			log.Warn("Job %s execution %s submission cancelled", job.JobID, execution.ExecutionID)
-		}
-	}()
+	// Allow reasonable backlog but prevent unlimited accumulation
+	// Reject only when queue is completely full to maximize throughput
+	if err := wm.workQueue.push(workRequest); err != nil {
+		return err
+	}
 
+	log.Debug("Job %s execution %s submitted to work queue", job.JobID, execution.ExecutionID)
 	return nil
 }
 
-// dispatch dispatches work requests to available workers
+// dispatch dispatches work requests to available workers, the highest priority request is taken when a worker is available
 func (wm *WorkerManager) dispatch() {
 	for {
 		select {
-		case work := <-wm.workQueue:
+		case <-wm.workQueue.notify:
+		case <-wm.quit:
+			return
+		}
+
+		for wm.workQueue.len() > 0 {
 			// Get an available worker
 			select {
 			case jobChannel := <-wm.workerPool:
+				work := wm.workQueue.pop()
+				if work == nil {
+					// The pending requests were cancelled, give the worker back
+					wm.workerPool <- jobChannel
+					continue
+				}
 				// Send work to worker
 				jobChannel <- work
 			case <-wm.quit:
 				return
 			}
-		case <-wm.quit:
-			return
 		}
 	}
 }
@@ -194,7 +200,7 @@ func (wm *WorkerManager) GetActiveWorkers() int {
 
 // GetQueueStatus returns queue length and capacity for monitoring
 func (wm *WorkerManager) GetQueueStatus() (length int, capacity int) {
-	return len(wm.workQueue), cap(wm.workQueue)
+	return wm.workQueue.len(), wm.workQueue.capacity
 }
 
 // NewWorker creates a new worker
@@ -285,7 +291,12 @@ func (w *Worker) processWork(work *WorkRequest) {
 	// Update execution with progress manager
 	work.Execution.Job = work.Job // Set job reference for progress updates
 
+	// Keep the function of the execution, it is removed from the registry by the execution and registered again for the retries
+	retained := retainFunc(work.Execution)
+
 	var err error
+	var retryDelay time.Duration
+	retrying := false
 	startTime := time.Now()
 
 	// Execute based on mode
@@ -307,22 +318,39 @@ func (w *Worker) processWork(work *WorkRequest) {
 	work.Execution.Duration = &duration
 
 	if err != nil {
-		work.Execution.Status = "failed"
 		errorInfo := map[string]interface{}{
-			"error":  err.Error(),
-			"time":   endTime,
-			"worker": w.ID,
+			"error":   err.Error(),
+			"time":    endTime,
+			"worker":  w.ID,
+			"attempt": work.Execution.RetryAttempt,
 		}
+
+		// The cancelled executions are not retried, the executions used up their retries are dead-lettered
+		message := fmt.Sprintf("Execution failed: %v", err)
+		work.Execution.Status = "failed"
+		if work.Context.Err() == nil && work.Execution.RetryAttempt < work.Job.MaxRetryCount {
+			retrying = true
+			retryDelay = RetryDelay(work.Execution.RetryAttempt + 1)
+			scheduledAt := endTime.Add(retryDelay)
+			work.Execution.Status = "queued"
+			work.Execution.RetryAttempt++
+			work.Execution.ScheduledAt = &scheduledAt
+			message = fmt.Sprintf("Execution failed: %v, retry %d/%d in %s", err, work.Execution.RetryAttempt, work.Job.MaxRetryCount, retryDelay)
+		} else if work.Context.Err() == nil && work.Job.MaxRetryCount > 0 {
+			work.Execution.Status = "dead"
+			message = fmt.Sprintf("Execution failed: %v, dead after %d retries", err, work.Execution.RetryAttempt)
+		}
+
 		errorData, _ := jsoniter.Marshal(errorInfo)
 		work.Execution.ErrorInfo = (*json.RawMessage)(&errorData)
 
-		log.Error("Job %s execution failed: %v", work.Job.JobID, err)
+		log.Error("Job %s execution %s", work.Job.JobID, message)
 
 		// Log error
 		logEntry := &Log{
 			JobID:       work.Job.JobID,
 			Level:       "error",
-			Message:     fmt.Sprintf("Execution failed: %v", err),
+			Message:     message,
 			ExecutionID: &work.Execution.ExecutionID,
 			WorkerID:    &w.ID,
 			Timestamp:   time.Now(),
@@ -354,13 +382,21 @@ func (w *Worker) processWork(work *WorkRequest) {
 		}
 	}
 
+	// Hold the retry before its schedule is saved, the scheduler submits it when it is due
+	wm := GetWorkerManager()
+	if retrying {
+		wm.retry(work, retained)
+	}
+
 	// Update execution in database
 	if err := SaveExecution(work.Execution); err != nil {
 		log.Warn("Failed to save final execution status (database may be closed): %v", err)
 	}
 
 	// Update job status
-	if work.Job.ScheduleType == string(ScheduleTypeOnce) {
+	if retrying {
+		work.Job.Status = "ready" // Waiting for the retry
+	} else if work.Job.ScheduleType == string(ScheduleTypeOnce) {
 		work.Job.Status = "completed"
 	} else {
 		work.Job.Status = "ready" // Ready for next execution
@@ -370,12 +406,11 @@ func (w *Worker) processWork(work *WorkRequest) {
 		log.Warn("Failed to save final job status (database may be closed): %v", err)
 	}
 
-	// Clean up execution context from job
-	work.Job.executionMutex.Lock()
-	if work.Job.executionContexts != nil {
-		delete(work.Job.executionContexts, work.Execution.ExecutionID)
+	// Release the executions depending on this one
+	if !retrying {
+		work.Job.releaseContext(work.Execution.ExecutionID)
+		wm.release(work.Execution)
 	}
-	work.Job.executionMutex.Unlock()
 
 	log.Debug("Worker %s finished processing job %s", w.ID, work.Job.JobID)
 }
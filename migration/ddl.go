@@ -0,0 +1,335 @@
+package migration
+
+import (
+	"fmt"
+	"strings"
+)
+
+// dialect writes the DDL statements of a database driver (mysql, postgres, sqlite3)
+type dialect struct {
+	driver string
+}
+
+func newDialect(driver string) dialect {
+	driver = strings.ToLower(driver)
+	switch {
+	case strings.HasPrefix(driver, "sqlite"):
+		driver = "sqlite3"
+	case strings.HasPrefix(driver, "postgres"), driver == "pgsql":
+		driver = "postgres"
+	default:
+		driver = "mysql"
+	}
+	return dialect{driver: driver}
+}
+
+func (d dialect) quote(name string) string {
+	if d.driver == "mysql" {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// sqlType returns the SQL type of the column, the arguments (length, precision, options) are returned separately
+func (d dialect) sqlType(col column) (string, []string, error) {
+	mysql := d.driver == "mysql"
+	typ := strings.ToLower(col.Type)
+	unsigned := mysql && strings.HasPrefix(typ, "unsigned")
+	typ = strings.TrimPrefix(typ, "unsigned")
+
+	name := ""
+	args := []string{}
+	switch typ {
+	case "id", "bigincrements":
+		name = pick(d.driver, "BIGINT UNSIGNED", "BIGINT", "INTEGER")
+	case "increments", "mediumincrements", "smallincrements", "tinyincrements":
+		name = pick(d.driver, "INT UNSIGNED", "INTEGER", "INTEGER")
+	case "string", "ipaddress", "macaddress":
+		length := col.Length
+		switch {
+		case length > 0:
+		case typ == "ipaddress":
+			length = 45
+		case typ == "macaddress":
+			length = 17
+		default:
+			length = 200
+		}
+		name, args = pick(d.driver, "VARCHAR", "VARCHAR", "TEXT"), []string{fmt.Sprint(length)}
+	case "char":
+		length := col.Length
+		if length <= 0 {
+			length = 200
+		}
+		name, args = pick(d.driver, "CHAR", "CHAR", "TEXT"), []string{fmt.Sprint(length)}
+	case "uuid":
+		name = pick(d.driver, "CHAR", "UUID", "TEXT")
+		if mysql {
+			args = []string{"36"}
+		}
+	case "text":
+		name = "TEXT"
+	case "mediumtext":
+		name = pick(d.driver, "MEDIUMTEXT", "TEXT", "TEXT")
+	case "longtext":
+		name = pick(d.driver, "LONGTEXT", "TEXT", "TEXT")
+	case "binary":
+		name = pick(d.driver, "BLOB", "BYTEA", "BLOB")
+	case "json":
+		name = pick(d.driver, "JSON", "JSON", "TEXT")
+	case "jsonb":
+		name = pick(d.driver, "JSON", "JSONB", "TEXT")
+	case "tinyinteger":
+		name = pick(d.driver, "TINYINT", "SMALLINT", "INTEGER")
+	case "smallinteger":
+		name = pick(d.driver, "SMALLINT", "SMALLINT", "INTEGER")
+	case "mediuminteger":
+		name = pick(d.driver, "MEDIUMINT", "INTEGER", "INTEGER")
+	case "integer":
+		name = pick(d.driver, "INT", "INTEGER", "INTEGER")
+	case "biginteger":
+		name = pick(d.driver, "BIGINT", "BIGINT", "INTEGER")
+	case "float":
+		name = pick(d.driver, "FLOAT", "REAL", "REAL")
+	case "double":
+		name = pick(d.driver, "DOUBLE", "DOUBLE PRECISION", "REAL")
+	case "decimal":
+		precision, scale := col.Precision, col.Scale
+		if precision <= 0 {
+			precision, scale = 10, 2
+		}
+		name, args = pick(d.driver, "DECIMAL", "DECIMAL", "NUMERIC"), []string{fmt.Sprint(precision), fmt.Sprint(scale)}
+	case "boolean":
+		name = pick(d.driver, "TINYINT", "BOOLEAN", "INTEGER")
+		if mysql {
+			args = []string{"1"}
+		}
+	case "enum":
+		if !mysql {
+			name, args = pick(d.driver, "", "VARCHAR", "TEXT"), []string{"200"}
+			break
+		}
+		name = "ENUM"
+		for _, option := range col.Option {
+			args = append(args, d.literal(option))
+		}
+	case "date":
+		name = "DATE"
+	case "datetime":
+		name = pick(d.driver, "DATETIME", "TIMESTAMP", "DATETIME")
+	case "datetimetz":
+		name = pick(d.driver, "DATETIME", "TIMESTAMPTZ", "DATETIME")
+	case "timestamptz":
+		name = pick(d.driver, "TIMESTAMP", "TIMESTAMPTZ", "DATETIME")
+	case "timestamp":
+		name = pick(d.driver, "TIMESTAMP", "TIMESTAMP", "DATETIME")
+	case "time":
+		name = "TIME"
+	case "timetz":
+		name = pick(d.driver, "TIME", "TIMETZ", "TIME")
+	case "year":
+		name = pick(d.driver, "YEAR", "SMALLINT", "INTEGER")
+	default:
+		return "", nil, fmt.Errorf("the type %s of the column %s is not supported", col.Type, col.Name)
+	}
+
+	if unsigned && !strings.HasSuffix(name, "UNSIGNED") {
+		name = name + " UNSIGNED"
+	}
+	// sqlite ignores the lengths
+	if d.driver == "sqlite3" {
+		args = []string{}
+	}
+	return name, args, nil
+}
+
+// typeString returns the SQL type with the arguments
+func (d dialect) typeString(col column) (string, error) {
+	name, args, err := d.sqlType(col)
+	if err != nil {
+		return "", err
+	}
+	if len(args) == 0 {
+		return name, nil
+	}
+	if strings.HasSuffix(name, " UNSIGNED") {
+		return fmt.Sprintf("%s(%s) UNSIGNED", strings.TrimSuffix(name, " UNSIGNED"), strings.Join(args, ",")), nil
+	}
+	return fmt.Sprintf("%s(%s)", name, strings.Join(args, ",")), nil
+}
+
+// definition returns the column definition of CREATE TABLE and ADD COLUMN
+func (d dialect) definition(col column) (string, error) {
+	if strings.EqualFold(col.Type, "ID") {
+		switch d.driver {
+		case "postgres":
+			return d.quote(col.Name) + " BIGSERIAL PRIMARY KEY", nil
+		case "sqlite3":
+			return d.quote(col.Name) + " INTEGER PRIMARY KEY AUTOINCREMENT", nil
+		}
+		return d.quote(col.Name) + " BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY", nil
+	}
+
+	typ, err := d.typeString(col)
+	if err != nil {
+		return "", err
+	}
+
+	def := d.quote(col.Name) + " " + typ
+	if col.Nullable {
+		def += " NULL"
+	} else {
+		def += " NOT NULL"
+	}
+
+	if col.DefaultRaw != "" {
+		def += " DEFAULT " + col.DefaultRaw
+	} else if col.Default != nil {
+		def += " DEFAULT " + d.literal(col.Default)
+	}
+
+	if col.Primary {
+		def += " PRIMARY KEY"
+	}
+
+	if d.driver == "mysql" && col.Comment != "" {
+		def += " COMMENT " + d.literal(col.Comment)
+	}
+	return def, nil
+}
+
+func (d dialect) literal(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	case bool:
+		if d.driver == "postgres" {
+			return strings.ToUpper(fmt.Sprint(v))
+		}
+		if v {
+			return "1"
+		}
+		return "0"
+	case nil:
+		return "NULL"
+	}
+	return fmt.Sprint(value)
+}
+
+func (d dialect) createTable(t table) ([]string, error) {
+	defs := []string{}
+	for _, col := range t.Columns {
+		def, err := d.definition(col)
+		if err != nil {
+			return nil, err
+		}
+		defs = append(defs, "  "+def)
+	}
+
+	stmts := []string{fmt.Sprintf("CREATE TABLE %s (\n%s\n)", d.quote(t.Name), strings.Join(defs, ",\n"))}
+	for _, idx := range t.Indexes {
+		if idx.Type == "primary" {
+			continue
+		}
+		stmts = append(stmts, d.createIndex(t.Name, idx))
+	}
+	return stmts, nil
+}
+
+func (d dialect) dropTable(name string) string {
+	return fmt.Sprintf("DROP TABLE %s", d.quote(name))
+}
+
+func (d dialect) addColumn(tableName string, col column) (string, error) {
+	def, err := d.definition(col)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", d.quote(tableName), def), nil
+}
+
+func (d dialect) dropColumn(tableName string, name string) string {
+	return fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", d.quote(tableName), d.quote(name))
+}
+
+// alterColumn changes the column from the live definition to the given one
+func (d dialect) alterColumn(tableName string, col column, live column) ([]string, error) {
+	switch d.driver {
+	case "sqlite3":
+		return nil, fmt.Errorf("sqlite can not alter the column %s, rebuild the table %s instead", col.Name, tableName)
+
+	case "postgres":
+		stmts := []string{}
+		typ, err := d.typeString(col)
+		if err != nil {
+			return nil, err
+		}
+		liveType, err := d.typeString(live)
+		if err != nil {
+			return nil, err
+		}
+		if typ != liveType {
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s USING %s::%s", d.quote(tableName), d.quote(col.Name), typ, d.quote(col.Name), typ))
+		}
+		if col.Nullable != live.Nullable {
+			action := "SET NOT NULL"
+			if col.Nullable {
+				action = "DROP NOT NULL"
+			}
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s", d.quote(tableName), d.quote(col.Name), action))
+		}
+		return stmts, nil
+	}
+
+	def, err := d.definition(col)
+	if err != nil {
+		return nil, err
+	}
+	return []string{fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s", d.quote(tableName), def)}, nil
+}
+
+func (d dialect) createIndex(tableName string, idx index) string {
+	columns := []string{}
+	for _, name := range idx.Columns {
+		columns = append(columns, d.quote(name))
+	}
+
+	kind := "INDEX"
+	switch {
+	case idx.Type == "unique":
+		kind = "UNIQUE INDEX"
+	case idx.Type == "match" && d.driver == "mysql":
+		kind = "FULLTEXT INDEX"
+	}
+	return fmt.Sprintf("CREATE %s %s ON %s (%s)", kind, d.quote(idx.Name), d.quote(tableName), strings.Join(columns, ", "))
+}
+
+func (d dialect) dropIndex(tableName string, name string) string {
+	if d.driver == "mysql" {
+		return fmt.Sprintf("DROP INDEX %s ON %s", d.quote(name), d.quote(tableName))
+	}
+	return fmt.Sprintf("DROP INDEX %s", d.quote(name))
+}
+
+// indexName the name of a new index, the index names of postgres and sqlite are unique in the database
+func (d dialect) indexName(tableName string, idx index) string {
+	name := idx.Name
+	if name == "" {
+		name = strings.Join(idx.Columns, "_") + "_" + idx.Type
+	}
+	if d.driver != "mysql" && !strings.HasPrefix(name, tableName+"_") {
+		name = tableName + "_" + name
+	}
+	return name
+}
+
+// pick the value of the driver
+func pick(driver, mysql, postgres, sqlite string) string {
+	switch driver {
+	case "postgres":
+		return postgres
+	case "sqlite3":
+		return sqlite
+	}
+	return mysql
+}
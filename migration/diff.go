@@ -0,0 +1,399 @@
+package migration
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/gou/schema"
+	"github.com/yaoapp/gou/schema/types"
+	"github.com/yaoapp/yao/config"
+)
+
+// The widening orders of the SQL types, a type can be changed to the latter ones of its order without data loss
+var widening = [][]string{
+	{"TINYINT", "SMALLINT", "MEDIUMINT", "INT", "INTEGER", "BIGINT"},
+	{"CHAR", "VARCHAR", "TEXT", "MEDIUMTEXT", "LONGTEXT"},
+	{"FLOAT", "REAL", "DOUBLE", "DOUBLE PRECISION"},
+	{"DATE", "DATETIME", "TIMESTAMP"},
+	{"JSON", "JSONB"},
+}
+
+// Diff compares the models with the tables of the database and returns the changes, all the models are compared if no id is given.
+// The models of the other connectors are ignored, the tables without models are kept.
+func Diff(ids ...string) (*Plan, error) {
+	if len(ids) == 0 {
+		for id := range model.Models {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	d := newDialect(config.Conf.DB.Driver)
+	sch := schema.Use("default")
+	tables, err := sch.Tables()
+	if err != nil {
+		return nil, err
+	}
+	exists := map[string]bool{}
+	for _, name := range tables {
+		exists[name] = true
+	}
+
+	plan := &Plan{Driver: d.driver, Changes: []*Change{}}
+	for _, id := range ids {
+		mod, has := model.Models[id]
+		if !has {
+			return nil, fmt.Errorf("model %s does not exist", id)
+		}
+
+		if mod.MetaData.Connector != "" && mod.MetaData.Connector != "default" {
+			continue
+		}
+
+		if mod.MetaData.Table.Name == "" {
+			continue
+		}
+
+		want := fromModel(mod)
+		if !exists[want.Name] {
+			plan.Changes = append(plan.Changes, d.diff(id, want, nil)...)
+			continue
+		}
+
+		blueprint, err := sch.TableGet(want.Name)
+		if err != nil {
+			return nil, fmt.Errorf("read table %s: %s", want.Name, err.Error())
+		}
+		live := fromBlueprint(want.Name, blueprint)
+		plan.Changes = append(plan.Changes, d.diff(id, want, &live)...)
+	}
+	return plan, nil
+}
+
+// Destructive returns the destructive changes of the plan
+func (plan *Plan) Destructive() []*Change {
+	changes := []*Change{}
+	for _, change := range plan.Changes {
+		if change.Destructive {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// Unsupported returns the changes the driver can not apply
+func (plan *Plan) Unsupported() []*Change {
+	changes := []*Change{}
+	for _, change := range plan.Changes {
+		if len(change.Up) == 0 {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// diff returns the changes from the live table to the wanted one, the table is created if live is nil.
+// The changes are ordered: create table, add columns, alter columns, add indexes, drop indexes, drop columns.
+func (d dialect) diff(id string, want table, live *table) []*Change {
+	for i := range want.Indexes {
+		want.Indexes[i].Name = d.indexName(want.Name, want.Indexes[i])
+	}
+
+	if live == nil {
+		change := &Change{Model: id, Table: want.Name, Kind: CreateTable, Name: want.Name}
+		stmts, err := d.createTable(want)
+		if err != nil {
+			change.Reason = err.Error()
+			return []*Change{change}
+		}
+		change.Up = stmts
+		change.Down = []string{d.dropTable(want.Name)}
+		return []*Change{change}
+	}
+
+	liveColumns := map[string]column{}
+	for _, col := range live.Columns {
+		liveColumns[col.Name] = col
+	}
+	wantColumns := map[string]bool{}
+	for _, col := range want.Columns {
+		wantColumns[col.Name] = true
+	}
+
+	adds, alters, drops := []*Change{}, []*Change{}, []*Change{}
+	for _, col := range want.Columns {
+		liveCol, has := liveColumns[col.Name]
+		if !has {
+			change := &Change{Model: id, Table: want.Name, Kind: AddColumn, Name: col.Name}
+			stmt, err := d.addColumn(want.Name, col)
+			if err != nil {
+				change.Reason = err.Error()
+			} else {
+				change.Up = []string{stmt}
+				change.Down = []string{d.dropColumn(want.Name, col.Name)}
+			}
+			adds = append(adds, change)
+			continue
+		}
+
+		if change := d.diffColumn(id, want.Name, col, liveCol); change != nil {
+			alters = append(alters, change)
+		}
+	}
+
+	for _, col := range live.Columns {
+		if wantColumns[col.Name] {
+			continue
+		}
+		change := &Change{
+			Model: id, Table: want.Name, Kind: DropColumn, Name: col.Name,
+			Up:          []string{d.dropColumn(want.Name, col.Name)},
+			Destructive: true,
+			Reason:      fmt.Sprintf("the data of the column %s is lost", col.Name),
+		}
+		if stmt, err := d.addColumn(want.Name, col); err == nil {
+			change.Down = []string{stmt}
+		}
+		drops = append(drops, change)
+	}
+
+	// The indexes are compared by the columns and the uniqueness, the names are kept
+	indexAdds, indexDrops := []*Change{}, []*Change{}
+	liveIndexes := map[string]index{}
+	for _, idx := range live.Indexes {
+		liveIndexes[indexKey(idx)] = idx
+	}
+	wantIndexes := map[string]bool{}
+	for _, idx := range want.Indexes {
+		key := indexKey(idx)
+		wantIndexes[key] = true
+		if _, has := liveIndexes[key]; has || idx.Type == "primary" {
+			continue
+		}
+		indexAdds = append(indexAdds, &Change{
+			Model: id, Table: want.Name, Kind: AddIndex, Name: idx.Name,
+			Up:   []string{d.createIndex(want.Name, idx)},
+			Down: []string{d.dropIndex(want.Name, idx.Name)},
+		})
+	}
+
+	for _, idx := range live.Indexes {
+		if wantIndexes[indexKey(idx)] || idx.Type == "primary" {
+			continue
+		}
+
+		// The indexes of the dropped columns are dropped with the columns
+		dropped := false
+		for _, name := range idx.Columns {
+			if !wantColumns[name] {
+				dropped = true
+			}
+		}
+		if dropped {
+			continue
+		}
+
+		indexDrops = append(indexDrops, &Change{
+			Model: id, Table: want.Name, Kind: DropIndex, Name: idx.Name,
+			Up:   []string{d.dropIndex(want.Name, idx.Name)},
+			Down: []string{d.createIndex(want.Name, idx)},
+		})
+	}
+
+	changes := append(adds, alters...)
+	changes = append(changes, indexAdds...)
+	changes = append(changes, indexDrops...)
+	return append(changes, drops...)
+}
+
+// diffColumn returns the change of the column, nil if the column is not changed.
+// The primary keys and the defaults are not compared.
+func (d dialect) diffColumn(id string, tableName string, col column, live column) *Change {
+	if col.Primary || live.Primary || strings.EqualFold(col.Type, "ID") {
+		return nil
+	}
+
+	name, args, err := d.sqlType(col)
+	if err != nil {
+		return &Change{Model: id, Table: tableName, Kind: AlterColumn, Name: col.Name, Reason: err.Error()}
+	}
+
+	liveName, liveArgs, err := d.sqlType(live)
+	if err != nil {
+		// The type of the database is unknown, keep the column
+		return nil
+	}
+
+	// The length and the options are not always reported by the database
+	sameArgs := len(liveArgs) == 0 || strings.Join(args, ",") == strings.Join(liveArgs, ",")
+	if name == liveName && sameArgs && col.Nullable == live.Nullable {
+		return nil
+	}
+
+	change := &Change{Model: id, Table: tableName, Kind: AlterColumn, Name: col.Name}
+	reasons := []string{}
+	if reason := narrowing(name, args, liveName, liveArgs); reason != "" {
+		reasons = append(reasons, reason)
+	}
+	if live.Nullable && !col.Nullable {
+		reasons = append(reasons, "the column becomes not null, the null values fail the change")
+	}
+	change.Destructive = len(reasons) > 0
+	change.Reason = strings.Join(reasons, "; ")
+
+	if len(liveArgs) == 0 {
+		live.Length, live.Precision, live.Scale, live.Option = col.Length, col.Precision, col.Scale, col.Option
+	}
+
+	up, err := d.alterColumn(tableName, col, live)
+	if err != nil {
+		change.Reason = err.Error()
+		return change
+	}
+	change.Up = up
+	if down, err := d.alterColumn(tableName, live, col); err == nil {
+		change.Down = down
+	}
+	return change
+}
+
+// narrowing returns the reason if the type change may lose data, empty if the type is widened
+func narrowing(name string, args []string, liveName string, liveArgs []string) string {
+	if name != liveName {
+		from, to := rank(liveName), rank(name)
+		if from.order < 0 || from.order != to.order || to.pos < from.pos {
+			return fmt.Sprintf("the type is changed from %s to %s", liveName, name)
+		}
+		// The lengths are compared only between CHAR and VARCHAR
+		if from.order != 1 || to.pos > 1 {
+			return ""
+		}
+	}
+
+	if len(liveArgs) == 0 {
+		return ""
+	}
+
+	// The removed enum options
+	if name == "ENUM" {
+		options := map[string]bool{}
+		for _, option := range args {
+			options[option] = true
+		}
+		for _, option := range liveArgs {
+			if !options[option] {
+				return fmt.Sprintf("the option %s is removed", option)
+			}
+		}
+		return ""
+	}
+
+	// The length, precision and scale are reduced
+	for i := range liveArgs {
+		if i >= len(args) {
+			break
+		}
+		from, err1 := strconv.Atoi(liveArgs[i])
+		to, err2 := strconv.Atoi(args[i])
+		if err1 == nil && err2 == nil && to < from {
+			return fmt.Sprintf("the type is narrowed from %s(%s) to %s(%s)", liveName, strings.Join(liveArgs, ","), name, strings.Join(args, ","))
+		}
+	}
+	return ""
+}
+
+type position struct {
+	order int
+	pos   int
+}
+
+func rank(name string) position {
+	name = strings.TrimSuffix(name, " UNSIGNED")
+	for order, names := range widening {
+		for pos, n := range names {
+			if n == name {
+				return position{order: order, pos: pos}
+			}
+		}
+	}
+	return position{order: -1, pos: -1}
+}
+
+func indexKey(idx index) string {
+	unique := idx.Type == "unique" || idx.Type == "primary"
+	return fmt.Sprintf("%v:%s", unique, strings.Join(idx.Columns, ","))
+}
+
+// fromModel reads the table definition of the model, the columns of the timestamps and the soft deletes are added
+func fromModel(mod *model.Model) table {
+	t := table{Name: mod.MetaData.Table.Name}
+	has := map[string]bool{}
+	for _, col := range mod.MetaData.Columns {
+		has[col.Name] = true
+		t.Columns = append(t.Columns, column{
+			Name:       col.Name,
+			Type:       col.Type,
+			Length:     col.Length,
+			Precision:  col.Precision,
+			Scale:      col.Scale,
+			Nullable:   col.Nullable,
+			Default:    col.Default,
+			DefaultRaw: col.DefaultRaw,
+			Comment:    col.Comment,
+			Option:     col.Option,
+			Primary:    col.Primary,
+		})
+
+		switch {
+		case col.Unique:
+			t.Indexes = append(t.Indexes, index{Name: col.Name + "_unique", Type: "unique", Columns: []string{col.Name}})
+		case col.Index:
+			t.Indexes = append(t.Indexes, index{Name: col.Name + "_index", Type: "index", Columns: []string{col.Name}})
+		}
+	}
+
+	extra := []string{}
+	if mod.MetaData.Option.Timestamps {
+		extra = append(extra, "created_at", "updated_at")
+	}
+	if mod.MetaData.Option.SoftDeletes {
+		extra = append(extra, "deleted_at")
+	}
+	for _, name := range extra {
+		if !has[name] {
+			t.Columns = append(t.Columns, column{Name: name, Type: "timestamp", Nullable: true})
+		}
+	}
+
+	for _, idx := range mod.MetaData.Indexes {
+		t.Indexes = append(t.Indexes, index{Name: idx.Name, Type: idx.Type, Columns: idx.Columns})
+	}
+	return t
+}
+
+// fromBlueprint reads the table definition of the database
+func fromBlueprint(name string, blueprint types.Blueprint) table {
+	t := table{Name: name}
+	for _, col := range blueprint.Columns {
+		t.Columns = append(t.Columns, column{
+			Name:       col.Name,
+			Type:       col.Type,
+			Length:     col.Length,
+			Precision:  col.Precision,
+			Scale:      col.Scale,
+			Nullable:   col.Nullable,
+			Default:    col.Default,
+			DefaultRaw: col.DefaultRaw,
+			Comment:    col.Comment,
+			Option:     col.Option,
+			Primary:    col.Primary,
+		})
+	}
+	for _, idx := range blueprint.Indexes {
+		t.Indexes = append(t.Indexes, index{Name: idx.Name, Type: idx.Type, Columns: idx.Columns})
+	}
+	return t
+}
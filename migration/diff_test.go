@@ -0,0 +1,127 @@
+package migration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffCreateTable(t *testing.T) {
+	want := table{
+		Name: "pet",
+		Columns: []column{
+			{Name: "id", Type: "ID"},
+			{Name: "name", Type: "string", Length: 80, Comment: "Pet's name"},
+			{Name: "status", Type: "enum", Option: []string{"on", "off"}, Default: "on"},
+		},
+		Indexes: []index{{Type: "index", Columns: []string{"name"}}},
+	}
+
+	changes := newDialect("mysql").diff("pet", want, nil)
+	require.Len(t, changes, 1)
+	assert.Equal(t, CreateTable, changes[0].Kind)
+	assert.Equal(t, []string{
+		"CREATE TABLE `pet` (\n" +
+			"  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,\n" +
+			"  `name` VARCHAR(80) NOT NULL COMMENT 'Pet''s name',\n" +
+			"  `status` ENUM('on','off') NOT NULL DEFAULT 'on'\n" +
+			")",
+		"CREATE INDEX `name_index` ON `pet` (`name`)",
+	}, changes[0].Up)
+	assert.Equal(t, []string{"DROP TABLE `pet`"}, changes[0].Down)
+
+	changes = newDialect("postgres").diff("pet", want, nil)
+	require.Len(t, changes, 1)
+	assert.Contains(t, changes[0].Up[0], `"id" BIGSERIAL PRIMARY KEY`)
+	assert.Equal(t, `CREATE INDEX "pet_name_index" ON "pet" ("name")`, changes[0].Up[1])
+}
+
+func TestDiffColumns(t *testing.T) {
+	live := table{
+		Name: "pet",
+		Columns: []column{
+			{Name: "id", Type: "ID", Primary: true},
+			{Name: "name", Type: "string", Length: 200},
+			{Name: "age", Type: "integer", Nullable: true},
+			{Name: "legacy", Type: "text", Nullable: true},
+		},
+		Indexes: []index{
+			{Name: "legacy_index", Type: "index", Columns: []string{"legacy"}},
+			{Name: "age_index", Type: "index", Columns: []string{"age"}},
+		},
+	}
+
+	want := table{
+		Name: "pet",
+		Columns: []column{
+			{Name: "id", Type: "ID"},
+			{Name: "name", Type: "string", Length: 100},
+			{Name: "age", Type: "bigInteger", Nullable: true},
+			{Name: "birthday", Type: "date", Nullable: true},
+		},
+		Indexes: []index{{Type: "unique", Columns: []string{"name"}}},
+	}
+
+	changes := newDialect("mysql").diff("pet", want, &live)
+	kinds := []string{}
+	for _, change := range changes {
+		kinds = append(kinds, change.Kind+":"+change.Name)
+	}
+	assert.Equal(t, []string{
+		"add_column:birthday",
+		"alter_column:name",
+		"alter_column:age",
+		"add_index:name_unique",
+		"drop_index:age_index",
+		"drop_column:legacy",
+	}, kinds)
+
+	// The length is narrowed
+	assert.True(t, changes[1].Destructive)
+	assert.Contains(t, changes[1].Reason, "VARCHAR(200) to VARCHAR(100)")
+	assert.Equal(t, []string{"ALTER TABLE `pet` MODIFY COLUMN `name` VARCHAR(100) NOT NULL"}, changes[1].Up)
+	assert.Equal(t, []string{"ALTER TABLE `pet` MODIFY COLUMN `name` VARCHAR(200) NOT NULL"}, changes[1].Down)
+
+	// The type is widened
+	assert.False(t, changes[2].Destructive)
+
+	assert.True(t, changes[5].Destructive)
+	assert.Equal(t, []string{"ALTER TABLE `pet` DROP COLUMN `legacy`"}, changes[5].Up)
+	assert.Equal(t, []string{"ALTER TABLE `pet` ADD COLUMN `legacy` TEXT NULL"}, changes[5].Down)
+
+	// sqlite can not alter the columns
+	changes = newDialect("sqlite3").diff("pet", want, &live)
+	for _, change := range changes {
+		if change.Kind == AlterColumn {
+			assert.Empty(t, change.Up)
+			assert.Contains(t, change.Reason, "rebuild the table")
+		}
+	}
+}
+
+func TestDiffNullable(t *testing.T) {
+	live := table{Name: "pet", Columns: []column{{Name: "name", Type: "string", Length: 80, Nullable: true}}}
+	want := table{Name: "pet", Columns: []column{{Name: "name", Type: "string", Length: 80}}}
+
+	changes := newDialect("postgres").diff("pet", want, &live)
+	require.Len(t, changes, 1)
+	assert.True(t, changes[0].Destructive)
+	assert.Equal(t, []string{`ALTER TABLE "pet" ALTER COLUMN "name" SET NOT NULL`}, changes[0].Up)
+	assert.Equal(t, []string{`ALTER TABLE "pet" ALTER COLUMN "name" DROP NOT NULL`}, changes[0].Down)
+
+	// Nothing is changed
+	assert.Empty(t, newDialect("postgres").diff("pet", want, &want))
+}
+
+func TestNarrowing(t *testing.T) {
+	assert.Empty(t, narrowing("BIGINT", nil, "INT", nil))
+	assert.Empty(t, narrowing("TEXT", nil, "VARCHAR", []string{"200"}))
+	assert.Empty(t, narrowing("VARCHAR", []string{"200"}, "CHAR", []string{"36"}))
+	assert.Empty(t, narrowing("ENUM", []string{"'a'", "'b'", "'c'"}, "ENUM", []string{"'a'", "'b'"}))
+	assert.NotEmpty(t, narrowing("INT", nil, "BIGINT", nil))
+	assert.NotEmpty(t, narrowing("INT", nil, "VARCHAR", []string{"200"}))
+	assert.NotEmpty(t, narrowing("VARCHAR", []string{"20"}, "CHAR", []string{"36"}))
+	assert.NotEmpty(t, narrowing("DECIMAL", []string{"10", "2"}, "DECIMAL", []string{"12", "2"}))
+	assert.NotEmpty(t, narrowing("ENUM", []string{"'a'"}, "ENUM", []string{"'a'", "'b'"}))
+}
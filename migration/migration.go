@@ -0,0 +1,387 @@
+package migration
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/yaoapp/gou/application"
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/xun/capsule"
+	"github.com/yaoapp/yao/config"
+)
+
+// HistoryModel the model of the applied migrations
+const HistoryModel = "__yao.migration"
+
+// Dir the directory of the migration files
+const Dir = "migrations"
+
+var fileRe = regexp.MustCompile(`^(\d{14})_([a-z0-9_]+)\.mig\.(yao|json|jsonc)$`)
+var slugRe = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Load reads the migration files ordered by version
+func Load() ([]*Migration, error) {
+	exists, err := application.App.Exists(Dir)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return []*Migration{}, nil
+	}
+
+	migrations := []*Migration{}
+	versions := map[string]string{}
+	exts := []string{"*.mig.yao", "*.mig.json", "*.mig.jsonc"}
+	err = application.App.Walk(Dir, func(root, file string, isdir bool) error {
+		if isdir {
+			return nil
+		}
+		migration, err := LoadFile(file)
+		if err != nil {
+			return err
+		}
+		if other, has := versions[migration.Version]; has {
+			return fmt.Errorf("[Migration] %s and %s have the same version %s", other, file, migration.Version)
+		}
+		versions[migration.Version] = file
+		migrations = append(migrations, migration)
+		return nil
+	}, exts...)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// LoadFile reads a migration file, the name of the file is <version>_<name>.mig.yao
+func LoadFile(file string) (*Migration, error) {
+	matches := fileRe.FindStringSubmatch(filepath.Base(file))
+	if matches == nil {
+		return nil, fmt.Errorf("[Migration] %s the file name should be <yyyymmddhhmmss>_<name>.mig.yao", file)
+	}
+
+	data, err := application.App.Read(file)
+	if err != nil {
+		return nil, err
+	}
+
+	migration := &Migration{}
+	err = application.Parse(file, data, migration)
+	if err != nil {
+		return nil, fmt.Errorf("[Migration] %s %s", file, err.Error())
+	}
+
+	if len(migration.Up) == 0 {
+		return nil, fmt.Errorf("[Migration] %s up is required", file)
+	}
+
+	migration.Version = matches[1]
+	migration.File = file
+	migration.Checksum = fmt.Sprintf("%x", sha256.Sum256(data))
+	if migration.Name == "" {
+		migration.Name = matches[2]
+	}
+	return migration, nil
+}
+
+// Create writes the supported changes of the plan to a new migration file.
+// The down statements are written only if all the changes can be rolled back.
+func Create(name string, plan *Plan) (*Migration, error) {
+	slug := strings.Trim(slugRe.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if slug == "" {
+		return nil, fmt.Errorf("the migration name is required")
+	}
+
+	migration := &Migration{
+		Version:     time.Now().UTC().Format("20060102150405"),
+		Name:        name,
+		Description: fmt.Sprintf("Generated from the models for %s", plan.Driver),
+		Up:          []string{},
+		Down:        []string{},
+	}
+
+	reversible := true
+	for _, change := range plan.Changes {
+		if len(change.Up) == 0 {
+			continue
+		}
+		migration.Up = append(migration.Up, change.Up...)
+		if change.Destructive {
+			migration.Destructive = true
+			migration.Notes = append(migration.Notes, fmt.Sprintf("%s %s.%s: %s", change.Kind, change.Table, change.Name, change.Reason))
+		}
+		if len(change.Down) == 0 {
+			reversible = false
+			migration.Notes = append(migration.Notes, fmt.Sprintf("%s %s.%s can not be rolled back", change.Kind, change.Table, change.Name))
+		}
+	}
+
+	if len(migration.Up) == 0 {
+		return nil, fmt.Errorf("there are no changes to migrate")
+	}
+
+	if reversible {
+		for i := len(plan.Changes) - 1; i >= 0; i-- {
+			if len(plan.Changes[i].Up) > 0 {
+				migration.Down = append(migration.Down, plan.Changes[i].Down...)
+			}
+		}
+	} else {
+		migration.Down = nil
+	}
+
+	data, err := jsoniter.MarshalIndent(migration, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	migration.File = filepath.Join(Dir, fmt.Sprintf("%s_%s.mig.yao", migration.Version, slug))
+	migration.Checksum = fmt.Sprintf("%x", sha256.Sum256(data))
+	err = application.App.Write(migration.File, data)
+	if err != nil {
+		return nil, err
+	}
+	return migration, nil
+}
+
+// Applied returns the applied migrations ordered by version
+func Applied() ([]Record, error) {
+	mod, err := historyModel()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := mod.Get(model.QueryParam{Orders: []model.QueryOrder{{Column: "version", Option: "asc"}}})
+	if err != nil {
+		return nil, err
+	}
+
+	records := []Record{}
+	for _, row := range rows {
+		records = append(records, toRecord(row))
+	}
+	return records, nil
+}
+
+// Statuses returns the status of the migration files and the applied migrations ordered by version
+func Statuses() ([]Status, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := Applied()
+	if err != nil {
+		return nil, err
+	}
+
+	applied := map[string]Record{}
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+
+	statuses := []Status{}
+	for _, migration := range migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if record, has := applied[migration.Version]; has {
+			appliedAt := record.AppliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+			status.Changed = record.Checksum != migration.Checksum
+			delete(applied, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+
+	for _, record := range applied {
+		appliedAt := record.AppliedAt
+		statuses = append(statuses, Status{Version: record.Version, Name: record.Name, Applied: true, AppliedAt: &appliedAt, Missing: true})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+// Pending returns the migrations not applied yet ordered by version.
+// The applied migrations must not be changed, the changes should be made by a new migration.
+func Pending() ([]*Migration, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := Applied()
+	if err != nil {
+		return nil, err
+	}
+
+	applied := map[string]Record{}
+	last := ""
+	for _, record := range records {
+		applied[record.Version] = record
+		if record.Version > last {
+			last = record.Version
+		}
+	}
+
+	pending := []*Migration{}
+	for _, migration := range migrations {
+		record, has := applied[migration.Version]
+		if !has {
+			if migration.Version < last {
+				log.Warn("[Migration] %s is older than the last applied migration %s", migration.File, last)
+			}
+			pending = append(pending, migration)
+			continue
+		}
+
+		if record.Checksum != migration.Checksum {
+			return nil, fmt.Errorf("[Migration] %s is changed after it was applied (checksum %s, applied %s)", migration.File, migration.Checksum, record.Checksum)
+		}
+	}
+	return pending, nil
+}
+
+// Apply runs the up statements of the migration and records it in the history
+func Apply(migration *Migration) error {
+	mod, err := historyModel()
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	err = execute(migration.Up)
+	if err != nil {
+		return fmt.Errorf("[Migration] %s %s", migration.File, err.Error())
+	}
+
+	_, err = mod.Create(map[string]interface{}{
+		"version":     migration.Version,
+		"name":        migration.Name,
+		"checksum":    migration.Checksum,
+		"down":        migration.Down,
+		"destructive": migration.Destructive,
+		"duration":    int(time.Since(start).Milliseconds()),
+		"applied_at":  time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("[Migration] %s is applied but not recorded: %s", migration.File, err.Error())
+	}
+	return nil
+}
+
+// Rollback runs the down statements of the applied migration and removes it from the history.
+// The down statements saved when the migration was applied are used, the file may be changed or removed.
+func Rollback(record Record) error {
+	if len(record.Down) == 0 {
+		return fmt.Errorf("[Migration] %s_%s can not be rolled back", record.Version, record.Name)
+	}
+
+	mod, err := historyModel()
+	if err != nil {
+		return err
+	}
+
+	err = execute(record.Down)
+	if err != nil {
+		return fmt.Errorf("[Migration] %s_%s %s", record.Version, record.Name, err.Error())
+	}
+
+	_, err = mod.DeleteWhere(model.QueryParam{Wheres: []model.QueryWhere{{Column: "version", Value: record.Version}}})
+	return err
+}
+
+// execute runs the statements, in a transaction if the driver supports the transactional DDL (mysql does not)
+func execute(stmts []string) error {
+	if capsule.Global == nil {
+		return fmt.Errorf("the database is not connected")
+	}
+
+	db := capsule.Global.Query().DB(true)
+	if newDialect(config.Conf.DB.Driver).driver == "mysql" {
+		for i, stmt := range stmts {
+			if _, err := db.Exec(stmt); err != nil {
+				return fmt.Errorf("statement %d failed, the statements before it are applied: %s", i+1, err.Error())
+			}
+		}
+		return nil
+	}
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return err
+	}
+	for i, stmt := range stmts {
+		if _, err := tx.Exec(stmt); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("statement %d failed: %s", i+1, err.Error())
+		}
+	}
+	return tx.Commit()
+}
+
+// toRecord reads the record from the row of the history table
+func toRecord(row map[string]interface{}) Record {
+	record := Record{
+		Version:  fmt.Sprint(row["version"]),
+		Name:     fmt.Sprint(row["name"]),
+		Checksum: fmt.Sprint(row["checksum"]),
+	}
+
+	switch v := row["destructive"].(type) {
+	case bool:
+		record.Destructive = v
+	case int, int64, float64:
+		record.Destructive = fmt.Sprint(v) != "0"
+	}
+
+	switch v := row["duration"].(type) {
+	case int:
+		record.Duration = v
+	case int64:
+		record.Duration = int(v)
+	case float64:
+		record.Duration = int(v)
+	}
+
+	switch v := row["down"].(type) {
+	case []interface{}:
+		for _, stmt := range v {
+			record.Down = append(record.Down, fmt.Sprint(stmt))
+		}
+	case []string:
+		record.Down = v
+	case string:
+		jsoniter.UnmarshalFromString(v, &record.Down)
+	}
+
+	switch v := row["applied_at"].(type) {
+	case time.Time:
+		record.AppliedAt = v
+	case string:
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05"} {
+			if t, err := time.Parse(layout, v); err == nil {
+				record.AppliedAt = t
+				break
+			}
+		}
+	}
+	return record
+}
+
+func historyModel() (*model.Model, error) {
+	mod, has := model.Models[HistoryModel]
+	if !has {
+		return nil, fmt.Errorf("the model %s is not loaded", HistoryModel)
+	}
+	return mod, nil
+}
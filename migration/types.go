@@ -0,0 +1,92 @@
+package migration
+
+import "time"
+
+// The kinds of the changes
+const (
+	CreateTable = "create_table"
+	AddColumn   = "add_column"
+	AlterColumn = "alter_column"
+	DropColumn  = "drop_column"
+	AddIndex    = "add_index"
+	DropIndex   = "drop_index"
+)
+
+// Plan the changes to make the database match the models
+type Plan struct {
+	Driver  string    `json:"driver"`
+	Changes []*Change `json:"changes"`
+}
+
+// Change a change of a table, with the DDL statements to apply and to roll it back
+type Change struct {
+	Model       string   `json:"model"`
+	Table       string   `json:"table"`
+	Kind        string   `json:"kind"`
+	Name        string   `json:"name,omitempty"` // the column or the index name
+	Up          []string `json:"up,omitempty"`   // empty if the change is not supported by the driver
+	Down        []string `json:"down,omitempty"` // empty if the change can not be rolled back
+	Destructive bool     `json:"destructive,omitempty"`
+	Reason      string   `json:"reason,omitempty"` // why the change is destructive or not supported
+}
+
+// Migration a migration file migrations/<version>_<name>.mig.yao
+type Migration struct {
+	Version     string   `json:"-"`
+	File        string   `json:"-"`
+	Checksum    string   `json:"-"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Up          []string `json:"up"`
+	Down        []string `json:"down,omitempty"`
+	Destructive bool     `json:"destructive,omitempty"`
+	Notes       []string `json:"notes,omitempty"`
+}
+
+// Record an applied migration, saved in the history table
+type Record struct {
+	Version     string    `json:"version"`
+	Name        string    `json:"name"`
+	Checksum    string    `json:"checksum"`
+	Down        []string  `json:"down,omitempty"`
+	Destructive bool      `json:"destructive"`
+	Duration    int       `json:"duration"` // ms
+	AppliedAt   time.Time `json:"applied_at"`
+}
+
+// Status the status of a migration
+type Status struct {
+	Version   string     `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+	Changed   bool       `json:"changed,omitempty"` // the file is changed after it was applied
+	Missing   bool       `json:"missing,omitempty"` // the file of the applied migration is removed
+}
+
+// table the definition of a table, read from a model or from the database
+type table struct {
+	Name    string
+	Columns []column
+	Indexes []index
+}
+
+type column struct {
+	Name       string
+	Type       string
+	Length     int
+	Precision  int
+	Scale      int
+	Nullable   bool
+	Default    interface{}
+	DefaultRaw string
+	Comment    string
+	Option     []string
+	Primary    bool
+}
+
+type index struct {
+	Name    string
+	Type    string // index, unique, primary, match
+	Columns []string
+}
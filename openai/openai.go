@@ -396,6 +396,36 @@ func (openai OpenAI) GetContent(response interface{}) (string, *exception.Except
 	return "", exception.New("response format error, %#v", 500, response)
 }
 
+// Chat sends the messages to the connector with temperature 0 and returns the reply content
+func Chat(ctx context.Context, connector string, messages []map[string]interface{}) (string, error) {
+	ai, err := New(connector)
+	if err != nil {
+		return "", err
+	}
+
+	res, ex := ai.ChatCompletionsWith(ctx, messages, map[string]interface{}{"temperature": 0}, nil)
+	if ex != nil {
+		return "", fmt.Errorf("%s", ex.Message)
+	}
+
+	content, ex := ai.GetContent(res)
+	if ex != nil {
+		return "", fmt.Errorf("%s", ex.Message)
+	}
+	return content, nil
+}
+
+// TrimFence removes the markdown code fence of the reply content
+func TrimFence(content string) string {
+	content = strings.TrimSpace(content)
+	if strings.HasPrefix(content, "```") {
+		content = strings.TrimPrefix(content, "```json")
+		content = strings.TrimPrefix(content, "```")
+		content = strings.TrimSuffix(content, "```")
+	}
+	return strings.TrimSpace(content)
+}
+
 // Post post request
 func (openai OpenAI) Post(path string, payload map[string]interface{}) (interface{}, *exception.Exception) {
 	return openai.post(path, payload)
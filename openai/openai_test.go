@@ -302,3 +302,9 @@ func audio(t *testing.T) string {
 	}
 	return base64.StdEncoding.EncodeToString(data)
 }
+
+func TestTrimFence(t *testing.T) {
+	assert.Equal(t, `{"a": 1}`, TrimFence("```json\n{\"a\": 1}\n```"))
+	assert.Equal(t, `{"a": 1}`, TrimFence("```\n{\"a\": 1}\n```"))
+	assert.Equal(t, `{"a": 1}`, TrimFence(` {"a": 1} `))
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/yaoapp/yao/agent"
 	"github.com/yaoapp/yao/agent/assistant"
 	agenttypes "github.com/yaoapp/yao/agent/store/types"
+	"github.com/yaoapp/yao/cluster"
 	"github.com/yaoapp/yao/openapi/oauth/authorized"
 	"github.com/yaoapp/yao/openapi/oauth/types"
 	"github.com/yaoapp/yao/openapi/response"
@@ -393,6 +394,7 @@ func CreateAssistant(c *gin.Context) {
 	if cache != nil {
 		cache.Remove(id)
 	}
+	cluster.Invalidate(assistant.CacheName, id)
 
 	// Reload the assistant to ensure it's available in cache with updated data
 	_, err = assistant.Get(id)
@@ -498,6 +500,7 @@ func UpdateAssistant(c *gin.Context) {
 	if cache != nil {
 		cache.Remove(assistantID)
 	}
+	cluster.Invalidate(assistant.CacheName, assistantID)
 
 	// Reload the assistant to ensure it's available in cache with updated data
 	_, err = assistant.Get(assistantID)
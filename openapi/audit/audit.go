@@ -1,3 +1,32 @@
 package audit
 
-// Audit Log
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/yao/openapi/oauth/types"
+)
+
+func init() {
+	// Register audit process handlers
+	process.RegisterGroup("audit", map[string]process.Handler{
+		"search": ProcessSearch,
+		"get":    ProcessGet,
+		"verify": ProcessVerify,
+	})
+}
+
+// Attach attaches the Audit Log API to the router, the records are read-only
+func Attach(group *gin.RouterGroup, oauth types.OAuth) {
+	// Protect all endpoints with OAuth
+	group.Use(oauth.Guard)
+
+	// Search and export
+	group.GET("", Search)
+	group.GET("/export", Export)
+
+	// Hash chain verification
+	group.GET("/verify", Verify)
+
+	// Record details
+	group.GET("/:id", Get)
+}
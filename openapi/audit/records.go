@@ -0,0 +1,327 @@
+package audit
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/kun/exception"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/audit"
+	"github.com/yaoapp/yao/openapi/oauth/authorized"
+	"github.com/yaoapp/yao/openapi/oauth/types"
+)
+
+// ExportColumns the columns of the CSV export
+var ExportColumns = []string{
+	"id", "time", "operation", "category", "severity", "user_id", "team_id", "client_id", "client_ip",
+	"target_resource", "resource_type", "source", "success", "error_message",
+	"data_before", "data_after", "details", "prev_hash", "hash",
+}
+
+// Search searches the audit records with pagination
+func Search(c *gin.Context) {
+	filter, err := ParseFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page := 1
+	pagesize := 20
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+
+	if ps := c.Query("pagesize"); ps != "" {
+		if parsed, err := strconv.Atoi(ps); err == nil && parsed > 0 && parsed <= 1000 {
+			pagesize = parsed
+		}
+	}
+
+	result, err := audit.Search(filter, page, pagesize)
+	if err != nil {
+		log.Error("Failed to search audit records: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Get gets an audit record by ID
+func Get(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid audit record id"})
+		return
+	}
+
+	entry, err := audit.Get(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !HasAccess(authorized.GetInfo(c), entry) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// Export exports the audit records matching the filter, format: jsonl (default) or csv
+func Export(c *gin.Context) {
+	filter, err := ParseFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	format := c.DefaultQuery("format", "jsonl")
+	if format != "jsonl" && format != "csv" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unsupported format: %s", format)})
+		return
+	}
+
+	filename := fmt.Sprintf("audit-%s.%s", time.Now().Format("20060102150405"), format)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	if format == "csv" {
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+	} else {
+		c.Header("Content-Type", "application/x-ndjson")
+	}
+	c.Status(http.StatusOK)
+
+	err = WriteExport(c.Writer, format, filter)
+	if err != nil {
+		// The response is streaming, the error can only be logged
+		log.Error("Failed to export audit records: %v", err)
+	}
+}
+
+// Verify verifies the hash chain of the audit records, only for the users without data constraints
+func Verify(c *gin.Context) {
+	authInfo := authorized.GetInfo(c)
+	if authInfo != nil && (authInfo.Constraints.TeamOnly || authInfo.Constraints.OwnerOnly) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		return
+	}
+
+	result, err := audit.Verify()
+	if err != nil {
+		log.Error("Failed to verify audit records: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// WriteExport writes the audit records to the writer, one record per line
+func WriteExport(w io.Writer, format string, filter audit.Filter) error {
+	if format == "csv" {
+		writer := csv.NewWriter(w)
+		if err := writer.Write(ExportColumns); err != nil {
+			return err
+		}
+
+		err := audit.Each(filter, func(entry *audit.Entry) error {
+			return writer.Write(csvRow(entry))
+		})
+		writer.Flush()
+		if err != nil {
+			return err
+		}
+		return writer.Error()
+	}
+
+	encoder := jsoniter.NewEncoder(w)
+	return audit.Each(filter, func(entry *audit.Entry) error {
+		return encoder.Encode(entry)
+	})
+}
+
+func csvRow(entry *audit.Entry) []string {
+	jsonString := func(value map[string]interface{}) string {
+		if value == nil {
+			return ""
+		}
+		raw, _ := jsoniter.MarshalToString(value)
+		return raw
+	}
+
+	return []string{
+		strconv.FormatUint(uint64(entry.ID), 10),
+		time.UnixMilli(entry.Timestamp).UTC().Format(time.RFC3339Nano),
+		entry.Operation,
+		entry.Category,
+		entry.Severity,
+		entry.UserID,
+		entry.TeamID,
+		entry.ClientID,
+		entry.ClientIP,
+		entry.Resource,
+		entry.ResourceType,
+		entry.Source,
+		strconv.FormatBool(entry.Success),
+		entry.Error,
+		jsonString(entry.Before),
+		jsonString(entry.After),
+		jsonString(entry.Details),
+		entry.PrevHash,
+		entry.Hash,
+	}
+}
+
+// ParseFilter parses the filter from the query, the data constraints of the user are applied
+func ParseFilter(c *gin.Context) (audit.Filter, error) {
+	filter := audit.Filter{
+		UserID:       c.Query("user_id"),
+		TeamID:       c.Query("team_id"),
+		ClientID:     c.Query("client_id"),
+		ClientIP:     c.Query("client_ip"),
+		Operation:    c.Query("operation"),
+		Category:     c.Query("category"),
+		Severity:     c.Query("severity"),
+		Resource:     c.Query("target_resource"),
+		ResourceType: c.Query("resource_type"),
+		Source:       c.Query("source"),
+	}
+
+	switch c.Query("success") {
+	case "true", "1", "yes", "on":
+		success := true
+		filter.Success = &success
+	case "false", "0", "no", "off":
+		success := false
+		filter.Success = &success
+	}
+
+	var err error
+	if from := c.Query("from"); from != "" {
+		if filter.From, err = parseTime(from); err != nil {
+			return filter, fmt.Errorf("invalid from: %s", from)
+		}
+	}
+
+	if to := c.Query("to"); to != "" {
+		if filter.To, err = parseTime(to); err != nil {
+			return filter, fmt.Errorf("invalid to: %s", to)
+		}
+	}
+
+	AuthFilter(authorized.GetInfo(c), &filter)
+	return filter, nil
+}
+
+// AuthFilter restricts the filter to the records the user can access
+func AuthFilter(authInfo *types.AuthorizedInfo, filter *audit.Filter) {
+	if authInfo == nil {
+		return
+	}
+
+	// Team only - the records of the team
+	if authInfo.Constraints.TeamOnly && authInfo.TeamID != "" {
+		filter.TeamID = authInfo.TeamID
+		return
+	}
+
+	// Owner only - the records of the user
+	if authInfo.Constraints.OwnerOnly && authInfo.UserID != "" {
+		filter.UserID = authInfo.UserID
+	}
+}
+
+// HasAccess checks if the user can access the audit record
+func HasAccess(authInfo *types.AuthorizedInfo, entry *audit.Entry) bool {
+	if authInfo == nil {
+		return true
+	}
+
+	if authInfo.Constraints.TeamOnly && authInfo.TeamID != "" {
+		return entry.TeamID == authInfo.TeamID
+	}
+
+	if authInfo.Constraints.OwnerOnly && authInfo.UserID != "" {
+		return entry.UserID == authInfo.UserID
+	}
+	return true
+}
+
+// parseTime parses RFC3339, date or unix milliseconds
+func parseTime(value string) (*time.Time, error) {
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		t := time.UnixMilli(ms)
+		return &t, nil
+	}
+
+	for _, layout := range []string{time.RFC3339Nano, time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid time %s", value)
+}
+
+// ProcessSearch audit.Search (:filter, :page, :pagesize)
+func ProcessSearch(process *process.Process) interface{} {
+	filter := audit.Filter{}
+	if process.NumOfArgs() > 0 {
+		raw, err := jsoniter.Marshal(process.Args[0])
+		if err == nil {
+			err = jsoniter.Unmarshal(raw, &filter)
+		}
+		if err != nil {
+			exception.New("invalid filter: %s", 400, err.Error()).Throw()
+		}
+	}
+
+	page := 1
+	pagesize := 20
+	if process.NumOfArgs() > 1 {
+		page = process.ArgsInt(1, 1)
+	}
+	if process.NumOfArgs() > 2 {
+		pagesize = process.ArgsInt(2, 20)
+	}
+
+	AuthFilter(authorized.ProcessAuthInfo(process), &filter)
+	result, err := audit.Search(filter, page, pagesize)
+	if err != nil {
+		exception.New(err.Error(), 500).Throw()
+	}
+	return result
+}
+
+// ProcessGet audit.Get (:id)
+func ProcessGet(process *process.Process) interface{} {
+	process.ValidateArgNums(1)
+	entry, err := audit.Get(uint(process.ArgsInt(0)))
+	if err != nil {
+		exception.New(err.Error(), 404).Throw()
+	}
+
+	if !HasAccess(authorized.ProcessAuthInfo(process), entry) {
+		exception.New("access denied", 403).Throw()
+	}
+	return entry
+}
+
+// ProcessVerify audit.Verify
+func ProcessVerify(process *process.Process) interface{} {
+	result, err := audit.Verify()
+	if err != nil {
+		exception.New(err.Error(), 500).Throw()
+	}
+	return result
+}
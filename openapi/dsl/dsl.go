@@ -5,6 +5,8 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/yao/audit"
+	"github.com/yaoapp/yao/cluster"
 	"github.com/yaoapp/yao/dsl"
 	"github.com/yaoapp/yao/dsl/types"
 	oauthTypes "github.com/yaoapp/yao/openapi/oauth/types"
@@ -208,10 +210,12 @@ func create(c *gin.Context) {
 	}
 
 	err = dslManager.Create(c.Request.Context(), &options)
+	auditDSL(c, "create", dslType, options.ID, nil, map[string]interface{}{"source": options.Source, "store": options.Store}, err)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	cluster.ReloadDSL(cluster.ActionLoad, string(dslType), string(options.Store), options.ID)
 
 	c.JSON(http.StatusCreated, gin.H{"message": "DSL created successfully"})
 }
@@ -242,11 +246,14 @@ func update(c *gin.Context) {
 		return
 	}
 
+	before := sourceOf(c, dslManager, options.ID)
 	err = dslManager.Update(c.Request.Context(), &options)
+	auditDSL(c, "update", dslType, options.ID, before, sourceOf(c, dslManager, options.ID), err)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	cluster.ReloadDSL(cluster.ActionReload, string(dslType), storeOf(c, dslManager, options.ID), options.ID)
 
 	c.JSON(http.StatusOK, gin.H{"message": "DSL updated successfully"})
 }
@@ -274,11 +281,15 @@ func delete(c *gin.Context) {
 	// Try to bind JSON body if provided
 	c.ShouldBindJSON(&options)
 
+	before := sourceOf(c, dslManager, id)
+	store := storeOf(c, dslManager, id)
 	err = dslManager.Delete(c.Request.Context(), &options)
+	auditDSL(c, "delete", dslType, id, before, nil, err)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	cluster.ReloadDSL(cluster.ActionUnload, string(dslType), store, id)
 
 	c.JSON(http.StatusOK, gin.H{"message": "DSL deleted successfully"})
 }
@@ -305,10 +316,12 @@ func load(c *gin.Context) {
 	}
 
 	err = dslManager.Load(c.Request.Context(), &options)
+	auditDSL(c, "load", dslType, options.ID, nil, nil, err)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	cluster.ReloadDSL(cluster.ActionLoad, string(dslType), string(options.Store), options.ID)
 
 	c.JSON(http.StatusOK, gin.H{"message": "DSL loaded successfully"})
 }
@@ -335,10 +348,12 @@ func unload(c *gin.Context) {
 	}
 
 	err = dslManager.Unload(c.Request.Context(), &options)
+	auditDSL(c, "unload", dslType, options.ID, nil, nil, err)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	cluster.ReloadDSL(cluster.ActionUnload, string(dslType), string(options.Store), options.ID)
 
 	c.JSON(http.StatusOK, gin.H{"message": "DSL unloaded successfully"})
 }
@@ -365,10 +380,12 @@ func reload(c *gin.Context) {
 	}
 
 	err = dslManager.Reload(c.Request.Context(), &options)
+	auditDSL(c, "reload", dslType, options.ID, nil, nil, err)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	cluster.ReloadDSL(cluster.ActionReload, string(dslType), string(options.Store), options.ID)
 
 	c.JSON(http.StatusOK, gin.H{"message": "DSL reloaded successfully"})
 }
@@ -440,3 +457,37 @@ func validate(c *gin.Context) {
 		"messages": messages,
 	})
 }
+
+// auditDSL records the changes of the DSL
+func auditDSL(c *gin.Context, action string, dslType types.Type, id string, before interface{}, after interface{}, err error) {
+	entry := audit.New("dsl."+action, audit.CategorySystem).
+		WithGin(c).
+		WithResource(string(dslType), id).
+		WithChange(before, after).
+		WithError(err)
+	if action == "delete" || action == "unload" {
+		entry.Severity = audit.SeverityHigh
+	}
+	audit.Log(entry)
+}
+
+// sourceOf returns the source of the DSL for the audit log, nil if not found
+func sourceOf(c *gin.Context, dslManager types.DSL, id string) map[string]interface{} {
+	if !audit.Enabled() {
+		return nil
+	}
+	source, err := dslManager.Source(c.Request.Context(), id)
+	if err != nil {
+		return nil
+	}
+	return map[string]interface{}{"source": source}
+}
+
+// storeOf returns the store of the DSL for the cluster events, empty if not found
+func storeOf(c *gin.Context, dslManager types.DSL, id string) string {
+	info, err := dslManager.Inspect(c.Request.Context(), id)
+	if err != nil || info == nil {
+		return ""
+	}
+	return string(info.Store)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/yaoapp/gou/model"
 	"github.com/yaoapp/gou/process"
 	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/audit"
 	"github.com/yaoapp/yao/job"
 	"github.com/yaoapp/yao/openapi/oauth/authorized"
 )
@@ -178,6 +179,11 @@ func StopExecution(c *gin.Context) {
 	// For now, we stop the entire job since individual execution stopping
 	// would require more complex implementation in the job package
 	err = jobInstance.Stop()
+	audit.Log(audit.New("job.execution.stop", audit.CategorySystem).
+		WithGin(c).
+		WithResource("execution", executionID).
+		WithDetails(map[string]interface{}{"job_id": execution.JobID}).
+		WithError(err))
 	if err != nil {
 		log.Error("Failed to stop job %s (execution %s): %v", execution.JobID, executionID, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -192,6 +198,62 @@ func StopExecution(c *gin.Context) {
 	})
 }
 
+// RequeueExecution puts a dead-lettered or failed execution back to the queue
+func RequeueExecution(c *gin.Context) {
+	// Get authorized information
+	authInfo := authorized.GetInfo(c)
+
+	executionID := c.Param("executionID")
+	if executionID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "execution_id is required"})
+		return
+	}
+
+	// Get the execution first to check access to the job
+	execution, err := job.GetExecution(executionID, model.QueryParam{})
+	if err != nil {
+		log.Error("Failed to get execution %s: %v", executionID, err)
+		if err.Error() == "execution not found: "+executionID {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Execution not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	jobInstance, err := job.GetJob(execution.JobID)
+	if err != nil {
+		log.Error("Failed to get job %s for execution %s: %v", execution.JobID, executionID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Check if user has access to the job
+	if !HasJobAccess(c, authInfo, jobInstance) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Execution not found"})
+		return
+	}
+
+	requeued, err := job.Requeue(executionID)
+	audit.Log(audit.New("job.execution.requeue", audit.CategorySystem).
+		WithGin(c).
+		WithResource("execution", executionID).
+		WithDetails(map[string]interface{}{"job_id": execution.JobID, "status": execution.Status}).
+		WithError(err))
+	if err != nil {
+		log.Error("Failed to requeue execution %s: %v", executionID, err)
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":      "Execution requeued successfully",
+		"execution_id": executionID,
+		"job_id":       execution.JobID,
+		"status":       requeued.Status,
+	})
+}
+
 // GetExecutionProgress gets execution progress information
 func GetExecutionProgress(c *gin.Context) {
 	// Get authorized information
@@ -385,6 +447,11 @@ func ProcessStopExecution(process *process.Process) interface{} {
 	// For now, we stop the entire job since individual execution stopping
 	// would require more complex implementation in the job package
 	err = jobInstance.Stop()
+	audit.Log(audit.New("job.execution.stop", audit.CategorySystem).
+		WithProcess(process).
+		WithResource("execution", executionID).
+		WithDetails(map[string]interface{}{"job_id": execution.JobID}).
+		WithError(err))
 	if err != nil {
 		log.Error("Failed to stop job %s (execution %s): %v", execution.JobID, executionID, err)
 		return map[string]interface{}{"error": err.Error()}
@@ -396,3 +463,33 @@ func ProcessStopExecution(process *process.Process) interface{} {
 		"job_id":       execution.JobID,
 	}
 }
+
+// ProcessRequeueExecution process handler for requeuing a dead-lettered or failed execution
+func ProcessRequeueExecution(process *process.Process) interface{} {
+	args := process.Args
+	if len(args) == 0 {
+		return map[string]interface{}{"error": "execution_id is required"}
+	}
+
+	executionID, ok := args[0].(string)
+	if !ok {
+		return map[string]interface{}{"error": "execution_id must be a string"}
+	}
+
+	execution, err := job.Requeue(executionID)
+	audit.Log(audit.New("job.execution.requeue", audit.CategorySystem).
+		WithProcess(process).
+		WithResource("execution", executionID).
+		WithError(err))
+	if err != nil {
+		log.Error("Failed to requeue execution %s: %v", executionID, err)
+		return map[string]interface{}{"error": err.Error()}
+	}
+
+	return map[string]interface{}{
+		"message":      "Execution requeued successfully",
+		"execution_id": executionID,
+		"job_id":       execution.JobID,
+		"status":       execution.Status,
+	}
+}
@@ -9,18 +9,19 @@ import (
 func init() {
 	// Register job process handlers
 	process.RegisterGroup("job", map[string]process.Handler{
-		"jobs.list":        ProcessListJobs,
-		"jobs.get":         ProcessGetJob,
-		"jobs.count":       ProcessCountJobs,
-		"jobs.stop":        ProcessStopJob,
-		"executions.list":  ProcessListExecutions,
-		"executions.get":   ProcessGetExecution,
-		"executions.count": ProcessCountExecutions,
-		"executions.stop":  ProcessStopExecution,
-		"logs.list":        ProcessListLogs,
-		"categories.list":  ProcessListCategories,
-		"categories.get":   ProcessGetCategory,
-		"categories.count": ProcessCountCategories,
+		"jobs.list":          ProcessListJobs,
+		"jobs.get":           ProcessGetJob,
+		"jobs.count":         ProcessCountJobs,
+		"jobs.stop":          ProcessStopJob,
+		"executions.list":    ProcessListExecutions,
+		"executions.get":     ProcessGetExecution,
+		"executions.count":   ProcessCountExecutions,
+		"executions.stop":    ProcessStopExecution,
+		"executions.requeue": ProcessRequeueExecution,
+		"logs.list":          ProcessListLogs,
+		"categories.list":    ProcessListCategories,
+		"categories.get":     ProcessGetCategory,
+		"categories.count":   ProcessCountCategories,
 	})
 }
 
@@ -38,11 +39,19 @@ func Attach(group *gin.RouterGroup, oauth types.OAuth) {
 	group.GET("/jobs/:jobID/executions", ListExecutions)
 	group.GET("/executions/:executionID", GetExecution)
 	group.POST("/executions/:executionID/stop", StopExecution)
+	group.POST("/executions/:executionID/requeue", RequeueExecution)
 
 	// Log Management
 	group.GET("/jobs/:jobID/logs", ListLogs)
 	group.GET("/executions/:executionID/logs", ListExecutionLogs)
 
+	// Schedule Management (the runs are the executions of the job schedule.<id>)
+	group.GET("/schedules", ListSchedules)
+	group.GET("/schedules/:scheduleID", GetSchedule)
+	group.POST("/schedules/:scheduleID/trigger", TriggerSchedule)
+	group.POST("/schedules/:scheduleID/pause", PauseSchedule)
+	group.POST("/schedules/:scheduleID/resume", ResumeSchedule)
+
 	// Category Management (Read-only)
 	group.GET("/categories", ListCategories)
 	group.GET("/categories/:categoryID", GetCategory)
@@ -8,6 +8,7 @@ import (
 	"github.com/yaoapp/gou/model"
 	"github.com/yaoapp/gou/process"
 	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/audit"
 	"github.com/yaoapp/yao/job"
 	"github.com/yaoapp/yao/openapi/oauth/authorized"
 )
@@ -174,6 +175,7 @@ func StopJob(c *gin.Context) {
 
 	// Stop the job
 	err = jobInstance.Stop()
+	audit.Log(audit.New("job.stop", audit.CategorySystem).WithGin(c).WithResource("job", jobID).WithError(err))
 	if err != nil {
 		log.Error("Failed to stop job %s: %v", jobID, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -463,6 +465,7 @@ func ProcessStopJob(process *process.Process) interface{} {
 
 	// Stop the job
 	err = jobInstance.Stop()
+	audit.Log(audit.New("job.stop", audit.CategorySystem).WithProcess(process).WithResource("job", jobID).WithError(err))
 	if err != nil {
 		log.Error("Failed to stop job %s: %v", jobID, err)
 		return map[string]interface{}{"error": err.Error()}
@@ -0,0 +1,118 @@
+package job
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/audit"
+	"github.com/yaoapp/yao/job"
+	"github.com/yaoapp/yao/openapi/oauth/authorized"
+	"github.com/yaoapp/yao/schedule"
+)
+
+// ListSchedules lists the schedules, the runs are listed by the executions of their jobs
+func ListSchedules(c *gin.Context) {
+	authInfo := authorized.GetInfo(c)
+
+	// The schedules are system jobs, the users limited to their team or their own jobs can not access them
+	list := []map[string]interface{}{}
+	if HasJobAccess(c, authInfo, &job.Job{}) {
+		list = schedule.List()
+	}
+	c.JSON(http.StatusOK, gin.H{"data": list, "total": len(list)})
+}
+
+// GetSchedule gets a specific schedule by ID
+func GetSchedule(c *gin.Context) {
+	sch, ok := selectSchedule(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, sch.Info())
+}
+
+// TriggerSchedule runs a schedule manually, the overlap policy of the schedule is applied
+func TriggerSchedule(c *gin.Context) {
+	sch, ok := selectSchedule(c)
+	if !ok {
+		return
+	}
+
+	source := "openapi"
+	if authInfo := authorized.GetInfo(c); authInfo != nil && authInfo.UserID != "" {
+		source = authInfo.UserID
+	}
+
+	execution, err := sch.Trigger(source)
+	audit.Log(audit.New("schedule.trigger", audit.CategorySystem).WithGin(c).WithResource("schedule", sch.ID).WithError(err))
+	if err == schedule.ErrSkipped {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "execution_id": execution.ExecutionID})
+		return
+	}
+	if err != nil {
+		log.Error("Failed to trigger schedule %s: %v", sch.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":      "Schedule triggered successfully",
+		"schedule_id":  sch.ID,
+		"job_id":       sch.JobID(),
+		"execution_id": execution.ExecutionID,
+		"status":       execution.Status,
+	})
+}
+
+// PauseSchedule pauses a schedule on all the instances
+func PauseSchedule(c *gin.Context) {
+	sch, ok := selectSchedule(c)
+	if !ok {
+		return
+	}
+
+	err := sch.Pause()
+	audit.Log(audit.New("schedule.pause", audit.CategorySystem).WithGin(c).WithResource("schedule", sch.ID).WithError(err))
+	if err != nil {
+		log.Error("Failed to pause schedule %s: %v", sch.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Schedule paused successfully", "schedule_id": sch.ID, "status": "paused"})
+}
+
+// ResumeSchedule resumes a paused schedule
+func ResumeSchedule(c *gin.Context) {
+	sch, ok := selectSchedule(c)
+	if !ok {
+		return
+	}
+
+	err := sch.Resume()
+	audit.Log(audit.New("schedule.resume", audit.CategorySystem).WithGin(c).WithResource("schedule", sch.ID).WithError(err))
+	if err != nil {
+		log.Error("Failed to resume schedule %s: %v", sch.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Schedule resumed successfully", "schedule_id": sch.ID, "status": "ready"})
+}
+
+// selectSchedule selects the schedule of the request and checks the access, the error is responded if not ok
+func selectSchedule(c *gin.Context) (*schedule.Schedule, bool) {
+	id := c.Param("scheduleID")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "schedule_id is required"})
+		return nil, false
+	}
+
+	sch, err := schedule.Select(id)
+	if err != nil || !HasJobAccess(c, authorized.GetInfo(c), &job.Job{}) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Schedule not found"})
+		return nil, false
+	}
+	return sch, true
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/yao/audit"
 	"github.com/yaoapp/yao/openapi/oauth"
 	"github.com/yaoapp/yao/openapi/oauth/types"
 	"github.com/yaoapp/yao/openapi/response"
@@ -218,6 +219,7 @@ func (openapi *OpenAPI) handleStandardTokenGrant(c *gin.Context, grantType strin
 
 	// Call OAuth service to handle the token request
 	token, err := openapi.OAuth.Token(c, grantType, code, clientID, codeVerifier)
+	auditToken(c, "oauth.token", clientID, grantType, err)
 	if err != nil {
 		// Convert OAuth service error to token error response with security headers
 		if oauthErr, ok := err.(*response.ErrorResponse); ok {
@@ -276,6 +278,7 @@ func (openapi *OpenAPI) handleRefreshTokenGrant(c *gin.Context) {
 	} else {
 		refreshResponse, err = openapi.OAuth.RefreshToken(c, refreshToken)
 	}
+	auditToken(c, "oauth.token.refresh", clientID, types.GrantTypeRefreshToken, err)
 	if err != nil {
 		// Convert OAuth service error to token error response with security headers
 		if oauthErr, ok := err.(*response.ErrorResponse); ok {
@@ -305,6 +308,7 @@ func (openapi *OpenAPI) handleTokenExchangeGrant(c *gin.Context) {
 
 	// Call OAuth service to handle token exchange
 	exchangeResponse, err := openapi.OAuth.TokenExchange(c, subjectToken, subjectTokenType, audience, scope)
+	auditToken(c, "oauth.token.exchange", "", types.GrantTypeTokenExchange, err)
 	if err != nil {
 		// Convert OAuth service error to token error response with security headers
 		if oauthErr, ok := err.(*response.ErrorResponse); ok {
@@ -455,6 +459,22 @@ func (openapi *OpenAPI) extractClientCredentials(c *gin.Context) (clientID, clie
 	return clientID, clientSecret
 }
 
+// auditToken records the token issued by the token endpoint, the failed requests are high severity
+func auditToken(c *gin.Context, operation string, clientID string, grantType string, err error) {
+	entry := audit.New(operation, audit.CategoryAuthentication).
+		WithGin(c).
+		WithResource("client", clientID).
+		WithDetails(map[string]interface{}{"grant_type": grantType}).
+		WithError(err)
+	if clientID != "" {
+		entry.ClientID = clientID
+	}
+	if err != nil {
+		entry.Severity = audit.SeverityHigh
+	}
+	audit.Log(entry)
+}
+
 // clientSupportsGrantType checks if a client supports a specific grant type
 func (openapi *OpenAPI) clientSupportsGrantType(clientInfo *types.ClientInfo, grantType string) bool {
 	if clientInfo == nil || len(clientInfo.GrantTypes) == 0 {
@@ -14,6 +14,7 @@ import (
 	"github.com/yaoapp/yao/openapi/oauth/types"
 	"github.com/yaoapp/yao/openapi/response"
 	"github.com/yaoapp/yao/openapi/utils"
+	"github.com/yaoapp/yao/ratelimit"
 )
 
 // Guard is the OAuth guard middleware
@@ -23,6 +24,13 @@ func (s *Service) Guard(c *gin.Context) {
 		return // Authentication failed, response already sent
 	}
 
+	// Apply the rate limit policies keyed by the authorized user, team or client
+	if res := ratelimit.Enforce(c); res != nil {
+		response.RespondWithError(c, http.StatusTooManyRequests, types.ErrRateLimitExceeded)
+		c.Abort()
+		return
+	}
+
 	// Check if ACL is enabled
 	if acl.Global == nil || !acl.Global.Enabled() {
 		return
@@ -8,6 +8,7 @@ import (
 	"github.com/yaoapp/yao/config"
 	"github.com/yaoapp/yao/openapi/agent"
 	"github.com/yaoapp/yao/openapi/app"
+	"github.com/yaoapp/yao/openapi/audit"
 	"github.com/yaoapp/yao/openapi/captcha"
 	"github.com/yaoapp/yao/openapi/chat"
 	"github.com/yaoapp/yao/openapi/dsl"
@@ -160,6 +161,9 @@ func (openapi *OpenAPI) Attach(router *gin.Engine) {
 	// App handlers (menu, etc.)
 	app.Attach(group.Group("/app"), openapi.OAuth)
 
+	// Audit log handlers
+	audit.Attach(group.Group("/audit"), openapi.OAuth)
+
 	// Custom handlers (Defined by developer)
 
 }
@@ -0,0 +1,53 @@
+package user
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/yao/audit"
+	"github.com/yaoapp/yao/openapi/utils"
+)
+
+// auditLogin records the tokens issued to the user by a login or team selection
+func auditLogin(params *IssueTokensParams, clientID string, scope string) {
+	entry := audit.New("user.login", audit.CategoryAuthentication).WithResource("user", params.UserID)
+	entry.UserID = params.UserID
+	entry.TeamID = params.TeamID
+	entry.ClientID = clientID
+	entry.Source = audit.SourceOpenAPI
+	entry.UserName = utils.ToString(params.User["preferred_username"])
+
+	details := map[string]interface{}{"scope": scope}
+	if params.LoginCtx != nil {
+		entry.ClientIP = params.LoginCtx.IP
+		entry.UserAgent = params.LoginCtx.UserAgent
+		details["device"] = params.LoginCtx.Device
+		details["platform"] = params.LoginCtx.Platform
+		details["remember_me"] = params.LoginCtx.RememberMe
+	}
+	audit.Log(entry.WithDetails(details))
+}
+
+// auditLoginFailed records a failed login attempt
+func auditLoginFailed(c *gin.Context, userID string, reason string) {
+	entry := audit.New("user.login", audit.CategoryAuthentication).
+		WithGin(c).
+		WithResource("user", userID).
+		WithSeverity(audit.SeverityHigh).
+		WithError(fmt.Errorf("%s", reason))
+	entry.UserID = userID
+	audit.Log(entry)
+}
+
+// auditTeam records the changes of the team, members and invitations
+func auditTeam(c *gin.Context, operation string, resourceType string, resourceID string, teamID string, before interface{}, after interface{}, err error) {
+	entry := audit.New(operation, audit.CategoryAuthorization).
+		WithGin(c).
+		WithResource(resourceType, resourceID).
+		WithChange(before, after).
+		WithError(err)
+	if teamID != "" {
+		entry.TeamID = teamID
+	}
+	audit.Log(entry)
+}
@@ -1007,6 +1007,7 @@ func GinEntryLogin(c *gin.Context) {
 	valid, err := userProvider.VerifyPassword(ctx, req.Password, passwordHash)
 	if err != nil || !valid {
 		log.Warn("Password verification failed for user %s", userID)
+		auditLoginFailed(c, userID, "invalid password")
 		errorResp := &response.ErrorResponse{
 			Code:             response.ErrInvalidRequest.Code,
 			ErrorDescription: "Invalid username or password",
@@ -12,6 +12,7 @@ import (
 	"github.com/yaoapp/gou/session"
 	"github.com/yaoapp/kun/log"
 	"github.com/yaoapp/yao/agent/assistant"
+	"github.com/yaoapp/yao/audit"
 	"github.com/yaoapp/yao/kb"
 	kbapi "github.com/yaoapp/yao/kb/api"
 	"github.com/yaoapp/yao/openapi/oauth"
@@ -630,6 +631,8 @@ func issueTokens(ctx context.Context, params *IssueTokensParams) (*LoginResponse
 		return nil, fmt.Errorf("failed to sign refresh token: %w", err)
 	}
 
+	auditLogin(params, yaoClientConfig.ClientID, strings.Join(params.Scopes, " "))
+
 	return &LoginResponse{
 		UserID:                params.UserID,
 		Subject:               params.Subject,
@@ -767,6 +770,8 @@ func GinLogout(c *gin.Context) {
 		}
 	}
 
+	audit.Log(audit.New("user.logout", audit.CategoryAuthentication).WithGin(c))
+
 	// Clear all authentication cookies
 	response.DeleteAllAuthCookies(c)
 
@@ -330,6 +330,7 @@ func GinMemberCreateRobot(c *gin.Context) {
 
 	// Call business logic
 	memberID, err := memberCreateRobot(c.Request.Context(), authInfo.UserID, teamID, robotData)
+	auditTeam(c, "member.robot.create", "member", memberID, teamID, nil, robotData, err)
 	if err != nil {
 		log.Error("Failed to create robot member: %v", err)
 		// Check error type for appropriate response
@@ -463,6 +464,7 @@ func GinMemberUpdateRobot(c *gin.Context) {
 
 	// Call business logic
 	err := memberUpdateRobot(c.Request.Context(), authInfo.UserID, teamID, memberID, robotData)
+	auditTeam(c, "member.robot.update", "member", memberID, teamID, nil, robotData, err)
 	if err != nil {
 		log.Error("Failed to update robot member: %v", err)
 		// Check error type for appropriate response
@@ -557,6 +559,7 @@ func GinMemberUpdate(c *gin.Context) {
 
 	// Call business logic
 	err := memberUpdate(c.Request.Context(), authInfo.UserID, teamID, memberID, updateData)
+	auditTeam(c, "member.update", "member", memberID, teamID, nil, updateData, err)
 	if err != nil {
 		log.Error("Failed to update member: %v", err)
 		// Check error type for appropriate response
@@ -680,6 +683,7 @@ func GinMemberUpdateProfile(c *gin.Context) {
 
 	// Call business logic
 	err := memberUpdateProfile(c.Request.Context(), authInfo.UserID, teamID, memberUserID, req)
+	auditTeam(c, "member.profile.update", "member", memberUserID, teamID, nil, req, err)
 	if err != nil {
 		log.Error("Failed to update member profile: %v", err)
 		// Check error type for appropriate response
@@ -743,6 +747,7 @@ func GinMemberDelete(c *gin.Context) {
 
 	// Call business logic
 	err := memberDelete(c.Request.Context(), authInfo.UserID, teamID, memberID)
+	auditTeam(c, "member.delete", "member", memberID, teamID, nil, nil, err)
 	if err != nil {
 		log.Error("Failed to delete member: %v", err)
 		// Check error type for appropriate response
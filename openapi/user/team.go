@@ -192,6 +192,7 @@ func GinTeamCreate(c *gin.Context) {
 
 	// Call business logic
 	teamID, err := teamCreate(c.Request.Context(), authInfo.UserID, teamData)
+	auditTeam(c, "team.create", "team", teamID, teamID, nil, teamData, err)
 	if err != nil {
 		log.Error("Failed to create team: %v", err)
 		errorResp := &response.ErrorResponse{
@@ -276,6 +277,7 @@ func GinTeamUpdate(c *gin.Context) {
 
 	// Call business logic
 	err := teamUpdate(c.Request.Context(), authInfo.UserID, teamID, updateData)
+	auditTeam(c, "team.update", "team", teamID, teamID, nil, updateData, err)
 	if err != nil {
 		log.Error("Failed to update team: %v", err)
 		// Check error type for appropriate response
@@ -468,6 +470,7 @@ func GinTeamDelete(c *gin.Context) {
 
 	// Call business logic
 	err := teamDelete(c.Request.Context(), authInfo.UserID, teamID)
+	auditTeam(c, "team.delete", "team", teamID, teamID, nil, nil, err)
 	if err != nil {
 		log.Error("Failed to delete team: %v", err)
 		// Check error type for appropriate response
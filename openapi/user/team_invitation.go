@@ -289,6 +289,7 @@ func GinTeamInvitationCreate(c *gin.Context) {
 
 	// Call business logic
 	invitationID, err := teamInvitationCreate(c.Request.Context(), authInfo.UserID, teamID, invitationData)
+	auditTeam(c, "invitation.create", "invitation", invitationID, teamID, nil, invitationData, err)
 	if err != nil {
 		log.Error("Failed to create invitation: %v", err)
 		// Check error type for appropriate response
@@ -389,6 +390,7 @@ func GinTeamInvitationResend(c *gin.Context) {
 
 	// Call business logic
 	err := teamInvitationResend(c.Request.Context(), authInfo.UserID, teamID, invitationID, requestBaseURL, locale)
+	auditTeam(c, "invitation.resend", "invitation", invitationID, teamID, nil, nil, err)
 	if err != nil {
 		log.Error("Failed to resend invitation: %v", err)
 		// Check error type for appropriate response
@@ -449,6 +451,7 @@ func GinTeamInvitationDelete(c *gin.Context) {
 
 	// Call business logic
 	err := teamInvitationDelete(c.Request.Context(), authInfo.UserID, teamID, invitationID)
+	auditTeam(c, "invitation.delete", "invitation", invitationID, teamID, nil, nil, err)
 	if err != nil {
 		log.Error("Failed to cancel invitation: %v", err)
 		// Check error type for appropriate response
@@ -556,6 +559,7 @@ func GinTeamInvitationAccept(c *gin.Context) {
 
 	// Accept the invitation (will update user_id if invitation doesn't have one)
 	err = provider.AcceptInvitation(ctx, invitationID, req.Token, userID)
+	auditTeam(c, "invitation.accept", "invitation", invitationID, teamID, nil, nil, err)
 	if err != nil {
 		log.Error("Failed to accept invitation: %v", err)
 		// Check error type for appropriate response
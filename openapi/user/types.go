@@ -704,3 +704,16 @@ type InviteConfig struct {
 	BaseURL   string            `json:"base_url,omitempty"` // Base URL for invitation links
 	Templates map[string]string `json:"templates,omitempty"`
 }
+
+// UsageRequest represents the query of the usage statistics and history
+type UsageRequest struct {
+	// Pagination (history only)
+	Page     int `json:"page" form:"page"`         // Page number (default: 1)
+	PageSize int `json:"pagesize" form:"pagesize"` // Page size (default: 20, max: 100)
+
+	// Filters
+	AssistantID string `json:"assistant_id" form:"assistant_id"` // Filter by assistant ID
+	Connector   string `json:"connector" form:"connector"`       // Filter by connector ID
+	From        string `json:"from" form:"from"`                 // Start date (YYYY-MM-DD or RFC3339), inclusive
+	To          string `json:"to" form:"to"`                     // End date (YYYY-MM-DD or RFC3339), exclusive
+}
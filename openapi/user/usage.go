@@ -0,0 +1,132 @@
+package user
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/agent/usage"
+	"github.com/yaoapp/yao/openapi/oauth/authorized"
+	"github.com/yaoapp/yao/openapi/response"
+)
+
+// GinUsageStatistics handles GET /user/usage/statistics - Get the LLM usage totals of the current user
+// Query: ?assistant_id=&connector=&from=2025-03-01&to=2025-04-01 (defaults to the current month)
+func GinUsageStatistics(c *gin.Context) {
+	filter, _, ok := usageFilter(c)
+	if !ok {
+		return
+	}
+
+	if filter.From == nil {
+		from := usage.PeriodStart(usage.PeriodMonthly, time.Now())
+		filter.From = &from
+	}
+
+	stats, err := usage.GetStatistics(filter)
+	if err != nil {
+		log.Error("Failed to get usage statistics: %v", err)
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrServerError.Code,
+			ErrorDescription: "Failed to retrieve usage statistics",
+		}
+		response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+		return
+	}
+
+	response.RespondWithSuccess(c, http.StatusOK, stats)
+}
+
+// GinUsageHistory handles GET /user/usage/history - Get the LLM usage records of the current user
+// Query: ?page=1&pagesize=20&assistant_id=&connector=&from=&to=
+func GinUsageHistory(c *gin.Context) {
+	filter, req, ok := usageFilter(c)
+	if !ok {
+		return
+	}
+
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 20
+	}
+	if req.PageSize > 100 {
+		req.PageSize = 100
+	}
+
+	result, err := usage.History(filter, req.Page, req.PageSize)
+	if err != nil {
+		log.Error("Failed to get usage history: %v", err)
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrServerError.Code,
+			ErrorDescription: "Failed to retrieve usage history",
+		}
+		response.RespondWithError(c, response.StatusInternalServerError, errorResp)
+		return
+	}
+
+	response.RespondWithSuccess(c, http.StatusOK, result)
+}
+
+// usageFilter builds the usage filter of the current user (and team), responds with an error if invalid
+func usageFilter(c *gin.Context) (usage.Filter, *UsageRequest, bool) {
+	authInfo := authorized.GetInfo(c)
+	if authInfo == nil || authInfo.UserID == "" {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidClient.Code,
+			ErrorDescription: "User not authenticated",
+		}
+		response.RespondWithError(c, response.StatusUnauthorized, errorResp)
+		return usage.Filter{}, nil, false
+	}
+
+	var req UsageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		errorResp := &response.ErrorResponse{
+			Code:             response.ErrInvalidRequest.Code,
+			ErrorDescription: "Invalid query parameters",
+		}
+		response.RespondWithError(c, response.StatusBadRequest, errorResp)
+		return usage.Filter{}, nil, false
+	}
+
+	filter := usage.Filter{
+		UserID:      authInfo.UserID,
+		TeamID:      authInfo.TeamID,
+		AssistantID: req.AssistantID,
+		Connector:   req.Connector,
+	}
+
+	for name, value := range map[string]string{"from": req.From, "to": req.To} {
+		if value == "" {
+			continue
+		}
+		t, err := parseUsageTime(value)
+		if err != nil {
+			errorResp := &response.ErrorResponse{
+				Code:             response.ErrInvalidRequest.Code,
+				ErrorDescription: fmt.Sprintf("Invalid %s, use YYYY-MM-DD or RFC3339", name),
+			}
+			response.RespondWithError(c, response.StatusBadRequest, errorResp)
+			return usage.Filter{}, nil, false
+		}
+		if name == "from" {
+			filter.From = &t
+		} else {
+			filter.To = &t
+		}
+	}
+
+	return filter, &req, true
+}
+
+// parseUsageTime parses a YYYY-MM-DD date in local time or an RFC3339 time
+func parseUsageTime(value string) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
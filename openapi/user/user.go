@@ -228,8 +228,8 @@ func attachCredits(group *gin.RouterGroup, oauth types.OAuth) {
 func attachUsage(group *gin.RouterGroup, oauth types.OAuth) {
 	usage := group.Group("/usage")
 	usage.Use(oauth.Guard)
-	usage.GET("/statistics", placeholder) // Get user usage statistics
-	usage.GET("/history", placeholder)    // Get user usage history
+	usage.GET("/statistics", GinUsageStatistics) // Get user LLM usage statistics
+	usage.GET("/history", GinUsageHistory)       // Get user LLM usage history
 }
 
 // User API Keys Management
@@ -0,0 +1,138 @@
+package ratelimit
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/yao/openapi/oauth/authorized"
+)
+
+// contextKey the result with the lowest remaining of the request, used to write the headers
+const contextKey = "__ratelimit"
+
+// Guards returns the rate-limit guards of the HTTP APIs.
+// "rate-limit" uses the default policy, "rate-limit:<id>" uses the policy <id>.
+func Guards() map[string]gin.HandlerFunc {
+	guards := map[string]gin.HandlerFunc{"rate-limit": Guard("default")}
+	lock.RLock()
+	defer lock.RUnlock()
+	for id := range Policies {
+		guards["rate-limit:"+id] = Guard(id)
+	}
+	return guards
+}
+
+// Guard returns the guard of the policy, the policy is selected when the request comes
+// so the guard follows the reloaded policies.
+func Guard(id string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		policy, err := Select(id)
+		if err != nil {
+			c.JSON(500, gin.H{"code": 500, "message": err.Error()})
+			c.Abort()
+			return
+		}
+
+		res := policy.Take(Identity(c, policy.Key))
+		if !Write(c, res) {
+			c.JSON(http.StatusTooManyRequests, gin.H{"code": http.StatusTooManyRequests, "message": "Too many requests. Please try again later"})
+			c.Abort()
+		}
+	}
+}
+
+// Middleware applies the policies of the routes keyed by ip or api_key before the routing.
+// The policies keyed by user, team or client_id are applied by Enforce after the authentication.
+func Middleware(c *gin.Context) {
+	for _, policy := range routed() {
+		if policy.authenticated() || !policy.match(c.Request.Method, c.Request.URL.Path) {
+			continue
+		}
+		if !Write(c, policy.Take(Identity(c, policy.Key))) {
+			c.JSON(http.StatusTooManyRequests, gin.H{"code": http.StatusTooManyRequests, "message": "Too many requests. Please try again later"})
+			c.Abort()
+			return
+		}
+	}
+}
+
+// Enforce applies the policies of the routes keyed by user, team or client_id,
+// it should be called after the authentication. Returns the result if the request is denied.
+func Enforce(c *gin.Context) *Result {
+	for _, policy := range routed() {
+		if !policy.authenticated() || !policy.match(c.Request.Method, c.Request.URL.Path) {
+			continue
+		}
+		res := policy.Take(Identity(c, policy.Key))
+		if !Write(c, res) {
+			return res
+		}
+	}
+	return nil
+}
+
+// Write writes the RateLimit headers of the result, returns true if the request is allowed.
+// If several policies are applied, the headers of the policy with the lowest remaining are kept.
+func Write(c *gin.Context, res *Result) bool {
+	if last, has := c.Get(contextKey); has {
+		if prev, ok := last.(*Result); ok && prev.Remaining < res.Remaining && res.Allowed {
+			return true
+		}
+	}
+	c.Set(contextKey, res)
+
+	header := c.Writer.Header()
+	header.Set("RateLimit-Limit", fmt.Sprintf("%d", res.Limit))
+	header.Set("RateLimit-Remaining", fmt.Sprintf("%d", res.Remaining))
+	header.Set("RateLimit-Reset", fmt.Sprintf("%d", seconds(res.Reset)))
+	if policy, err := Select(res.Policy); err == nil {
+		header.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", res.Limit, seconds(policy.window)))
+	}
+
+	if !res.Allowed {
+		header.Set("Retry-After", fmt.Sprintf("%d", seconds(res.RetryAfter)))
+		return false
+	}
+	return true
+}
+
+// Identity returns the identity of the request by the key.
+// The anonymous requests are keyed by ip, the user falls back to the session id.
+func Identity(c *gin.Context, key string) string {
+	switch key {
+	case KeyUser, KeyTeam, KeyClient:
+		info := authorized.GetInfo(c)
+		switch {
+		case key == KeyUser && info.UserID != "":
+			return info.UserID
+		case key == KeyUser && info.SessionID != "":
+			return "sid:" + info.SessionID
+		case key == KeyTeam && info.TeamID != "":
+			return info.TeamID
+		case key == KeyClient && info.ClientID != "":
+			return info.ClientID
+		}
+
+	case KeyAPIKey:
+		apiKey := c.GetHeader("X-API-Key")
+		if apiKey == "" {
+			if token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok && strings.HasPrefix(token, "ak-") {
+				apiKey = token
+			}
+		}
+		if apiKey != "" {
+			// The key is hashed, the counters should not expose the secrets
+			return fmt.Sprintf("%x", sha256.Sum256([]byte(apiKey)))[:32]
+		}
+	}
+	return "ip:" + c.ClientIP()
+}
+
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
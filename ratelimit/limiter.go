@@ -0,0 +1,244 @@
+package ratelimit
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yaoapp/gou/store"
+	"github.com/yaoapp/kun/log"
+)
+
+// counters the methods of the gou store used by the limiters
+type counters interface {
+	Get(key string) (interface{}, bool)
+	Set(key string, value interface{}, ttl time.Duration) error
+	Has(key string) bool
+	Del(key string) error
+	Incr(key string, delta int64) (int64, error)
+	Decr(key string, delta int64) (int64, error)
+}
+
+// locks the striped locks of the counters in the instance, the requests of the keys in the other stripes do not wait
+var locks [256]sync.Mutex
+
+// The bucket lock in the store, the instances sharing the store update a bucket in turn
+var (
+	bucketLockTTL     = time.Second
+	bucketLockWait    = 5 * time.Millisecond
+	bucketLockRetries = 20
+)
+
+// now returns the current time, replaced in the tests
+var now = time.Now
+
+// Take takes a request of the identity from the policy.
+// The request is allowed if the counters can not be read or written (fail open).
+func (policy *Policy) Take(identity string) *Result {
+	st, err := store.Get(policy.Store)
+	if err != nil {
+		log.Warn("[RateLimit] %s %s, the request is allowed", policy.ID, err.Error())
+		return policy.allow()
+	}
+	return policy.take(st, identity)
+}
+
+func (policy *Policy) take(st counters, identity string) *Result {
+	key := fmt.Sprintf("ratelimit:%s:%s:%s", policy.ID, policy.Key, identity)
+	mutex := keyLock(key)
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if policy.Algorithm == TokenBucket {
+		return policy.bucket(st, key)
+	}
+	return policy.sliding(st, key)
+}
+
+// keyLock returns the lock of the key
+func keyLock(key string) *sync.Mutex {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return &locks[h.Sum32()%uint32(len(locks))]
+}
+
+// sliding counts the requests of the current and the previous fixed windows,
+// the previous count is weighted by the part of it still covered by the sliding window.
+func (policy *Policy) sliding(st counters, key string) *Result {
+	at := now()
+	size := policy.window.Nanoseconds()
+	index := at.UnixNano() / size
+	elapsed := float64(at.UnixNano()%size) / float64(size)
+	reset := time.Duration(size - at.UnixNano()%size)
+
+	current := fmt.Sprintf("%s:%d", key, index)
+	previous := fmt.Sprintf("%s:%d", key, index-1)
+
+	prev := 0
+	if value, ok := st.Get(previous); ok {
+		prev = toInt(value)
+	}
+
+	// The counter expires with the next window, it is created before the increment to set the TTL
+	if !st.Has(current) {
+		if err := st.Set(current, 0, 2*policy.window); err != nil {
+			log.Warn("[RateLimit] %s %s, the request is allowed", policy.ID, err.Error())
+			return policy.allow()
+		}
+	}
+
+	// The increment is atomic in the store, the instances sharing the store count the same requests
+	n, err := st.Incr(current, 1)
+	if err != nil {
+		log.Warn("[RateLimit] %s %s, the request is allowed", policy.ID, err.Error())
+		return policy.allow()
+	}
+
+	cur := int(n) - 1
+	count := int(math.Floor(float64(prev)*(1-elapsed))) + cur
+	res := &Result{Policy: policy.ID, Limit: policy.Limit, Reset: reset}
+	if count >= policy.Limit {
+		// The denied requests are not counted
+		if _, err := st.Decr(current, 1); err != nil {
+			log.Warn("[RateLimit] %s %s", policy.ID, err.Error())
+		}
+		res.RetryAfter = policy.retryAfter(prev, cur, elapsed, reset)
+		return res
+	}
+
+	res.Allowed = true
+	res.Remaining = policy.Limit - count - 1
+	return res
+}
+
+// retryAfter estimates when the weighted count drops below the limit
+func (policy *Policy) retryAfter(prev, cur int, elapsed float64, reset time.Duration) time.Duration {
+	if cur >= policy.Limit || prev == 0 {
+		return reset
+	}
+	// prev * (1 - e) + cur < limit => e > 1 - (limit - cur) / prev
+	target := 1 - float64(policy.Limit-cur)/float64(prev)
+	wait := time.Duration((target - elapsed) * float64(policy.window))
+	if wait < time.Second {
+		wait = time.Second
+	}
+	if wait > reset {
+		return reset
+	}
+	return wait
+}
+
+// bucket refills the tokens at the rate of limit per window up to the burst, a request takes a token.
+// The state is saved as "<tokens>:<unix nano>" and expires when the bucket is full.
+func (policy *Policy) bucket(st counters, key string) *Result {
+	unlock, ok := lockBucket(st, key)
+	if !ok {
+		log.Warn("[RateLimit] %s the bucket %s is locked, the request is allowed", policy.ID, key)
+		return policy.allow()
+	}
+	defer unlock()
+
+	at := now()
+	rate := float64(policy.Limit) / float64(policy.window) // tokens per nanosecond
+	tokens := float64(policy.Burst)
+
+	if value, ok := st.Get(key); ok {
+		if saved, last, ok := parseBucket(value); ok {
+			tokens = math.Min(float64(policy.Burst), saved+float64(at.Sub(last))*rate)
+		}
+	}
+
+	res := &Result{Policy: policy.ID, Limit: policy.Burst}
+	if tokens < 1 {
+		res.RetryAfter = time.Duration(math.Ceil((1 - tokens) / rate))
+		res.Reset = time.Duration(math.Ceil((float64(policy.Burst) - tokens) / rate))
+		return res
+	}
+
+	tokens--
+	full := time.Duration(math.Ceil((float64(policy.Burst) - tokens) / rate))
+	err := st.Set(key, fmt.Sprintf("%g:%d", tokens, at.UnixNano()), full+time.Second)
+	if err != nil {
+		log.Warn("[RateLimit] %s %s, the request is allowed", policy.ID, err.Error())
+	}
+
+	res.Allowed = true
+	res.Remaining = int(math.Floor(tokens))
+	res.Reset = full
+	return res
+}
+
+// lockBucket acquires the lock of the bucket in the store, the first increment of the lock key owns it.
+// The lock expires after bucketLockTTL if its owner exits without releasing it.
+func lockBucket(st counters, key string) (func(), bool) {
+	lockKey := key + ":lock"
+	for i := 0; i < bucketLockRetries; i++ {
+		n, err := st.Incr(lockKey, 1)
+		if err != nil {
+			return nil, false
+		}
+
+		if n == 1 {
+			// The waiting instances increment the lock, they never read 1 until it is released
+			if err := st.Set(lockKey, 1, bucketLockTTL); err != nil {
+				st.Del(lockKey)
+				return nil, false
+			}
+			return func() { st.Del(lockKey) }, true
+		}
+		time.Sleep(bucketLockWait)
+	}
+	return nil, false
+}
+
+// allow returns the result of the request allowed without counting
+func (policy *Policy) allow() *Result {
+	limit := policy.Limit
+	if policy.Algorithm == TokenBucket {
+		limit = policy.Burst
+	}
+	return &Result{Policy: policy.ID, Allowed: true, Limit: limit, Remaining: limit, Reset: policy.window}
+}
+
+func parseBucket(value interface{}) (float64, time.Time, bool) {
+	text, ok := value.(string)
+	if !ok {
+		return 0, time.Time{}, false
+	}
+	tokens, at, ok := strings.Cut(text, ":")
+	if !ok {
+		return 0, time.Time{}, false
+	}
+	t, err := strconv.ParseFloat(tokens, 64)
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+	nano, err := strconv.ParseInt(at, 10, 64)
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+	return t, time.Unix(0, nano), true
+}
+
+// toInt reads the counter, the stores may return the numbers as float64 or string
+func toInt(value interface{}) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	case string:
+		n, _ := strconv.Atoi(v)
+		return n
+	case fmt.Stringer:
+		n, _ := strconv.Atoi(v.String())
+		return n
+	}
+	return 0
+}
@@ -0,0 +1,204 @@
+package ratelimit
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yaoapp/gou/application"
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/share"
+)
+
+// Policies the loaded policies
+var Policies = map[string]*Policy{}
+
+var lock sync.RWMutex
+
+// fallback the policy of the rate-limit guard if the default policy is not defined
+var fallback = &Policy{ID: "default", Name: "Default", Limit: 60, Window: "1m", Key: KeyIP}
+
+func init() {
+	fallback.validate()
+}
+
+// Load the policies from the ratelimits directory
+func Load(cfg config.Config) error {
+	exists, err := application.App.Exists("ratelimits")
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+
+	messages := []string{}
+	exts := []string{"*.limit.yao", "*.limit.json", "*.limit.jsonc"}
+	err = application.App.Walk("ratelimits", func(root, file string, isdir bool) error {
+		if isdir {
+			return nil
+		}
+		_, err := LoadFile(file, share.ID(root, file))
+		if err != nil {
+			messages = append(messages, err.Error())
+		}
+		return nil
+	}, exts...)
+
+	if len(messages) > 0 {
+		return fmt.Errorf("%s", strings.Join(messages, ";\n"))
+	}
+	return err
+}
+
+// LoadFile load the policy from the file
+func LoadFile(file string, id string) (*Policy, error) {
+	data, err := application.App.Read(file)
+	if err != nil {
+		return nil, err
+	}
+	return LoadSource(file, data, id)
+}
+
+// LoadSource load the policy from the source
+func LoadSource(file string, data []byte, id string) (*Policy, error) {
+	policy := &Policy{}
+	err := application.Parse(file, data, policy)
+	if err != nil {
+		return nil, fmt.Errorf("[RateLimit] %s %s", id, err.Error())
+	}
+
+	policy.ID = id
+	err = policy.validate()
+	if err != nil {
+		return nil, fmt.Errorf("[RateLimit] %s %s", id, err.Error())
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	Policies[id] = policy
+	return policy, nil
+}
+
+// Select the policy by id, the default policy is always available
+func Select(id string) (*Policy, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+	policy, has := Policies[id]
+	if !has {
+		if id == "default" {
+			return fallback, nil
+		}
+		return nil, fmt.Errorf("rate limit policy %s not found", id)
+	}
+	return policy, nil
+}
+
+// Unload the policy
+func Unload(id string) {
+	lock.Lock()
+	defer lock.Unlock()
+	delete(Policies, id)
+}
+
+// routed returns the policies with routes ordered by id
+func routed() []*Policy {
+	lock.RLock()
+	defer lock.RUnlock()
+	policies := []*Policy{}
+	for _, policy := range Policies {
+		if len(policy.routes) > 0 {
+			policies = append(policies, policy)
+		}
+	}
+	sort.Slice(policies, func(i, j int) bool { return policies[i].ID < policies[j].ID })
+	return policies
+}
+
+// validate the policy and set the defaults
+func (policy *Policy) validate() error {
+	if policy.Limit <= 0 {
+		return fmt.Errorf("limit should be greater than 0")
+	}
+
+	switch policy.Algorithm {
+	case "":
+		policy.Algorithm = SlidingWindow
+	case SlidingWindow, TokenBucket:
+	default:
+		return fmt.Errorf("algorithm %s is not supported, use sliding-window or token-bucket", policy.Algorithm)
+	}
+
+	if policy.Window == "" {
+		policy.Window = "1m"
+	}
+	window, err := time.ParseDuration(policy.Window)
+	if err != nil {
+		return fmt.Errorf("window %s is invalid: %s", policy.Window, err.Error())
+	}
+	if window < time.Second {
+		return fmt.Errorf("window should be at least 1s")
+	}
+	policy.window = window
+
+	if policy.Burst <= 0 {
+		policy.Burst = policy.Limit
+	}
+
+	switch policy.Key {
+	case "":
+		policy.Key = KeyIP
+	case KeyIP, KeyUser, KeyTeam, KeyClient, KeyAPIKey:
+	default:
+		return fmt.Errorf("key %s is not supported, use ip, user, team, client_id or api_key", policy.Key)
+	}
+
+	if policy.Store == "" {
+		policy.Store = DefaultStore
+	}
+
+	policy.routes = []route{}
+	for _, pattern := range policy.Routes {
+		r := route{pattern: strings.TrimSpace(pattern)}
+		if method, p, ok := strings.Cut(r.pattern, " "); ok {
+			r.method, r.pattern = strings.ToUpper(method), strings.TrimSpace(p)
+		}
+		if !strings.HasPrefix(r.pattern, "/") {
+			return fmt.Errorf("route %s should start with /", pattern)
+		}
+		if strings.HasSuffix(r.pattern, "/*") {
+			r.prefix, r.pattern = true, strings.TrimSuffix(r.pattern, "*")
+		} else if _, err := path.Match(r.pattern, "/"); err != nil {
+			return fmt.Errorf("route %s is invalid: %s", pattern, err.Error())
+		}
+		policy.routes = append(policy.routes, r)
+	}
+	return nil
+}
+
+// authenticated returns true if the policy is keyed by the identity of the authorized user
+func (policy *Policy) authenticated() bool {
+	return policy.Key == KeyUser || policy.Key == KeyTeam || policy.Key == KeyClient
+}
+
+// match returns true if the request matches one of the routes of the policy
+func (policy *Policy) match(method string, p string) bool {
+	for _, r := range policy.routes {
+		if r.method != "" && r.method != method {
+			continue
+		}
+		if r.prefix {
+			if strings.HasPrefix(p, r.pattern) || p == strings.TrimSuffix(r.pattern, "/") {
+				return true
+			}
+			continue
+		}
+		if ok, _ := path.Match(r.pattern, p); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,152 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memory map[string]interface{}
+
+func (m memory) Get(key string) (interface{}, bool) {
+	value, has := m[key]
+	return value, has
+}
+
+func (m memory) Set(key string, value interface{}, ttl time.Duration) error {
+	m[key] = value
+	return nil
+}
+
+func (m memory) Has(key string) bool {
+	_, has := m[key]
+	return has
+}
+
+func (m memory) Del(key string) error {
+	delete(m, key)
+	return nil
+}
+
+func (m memory) Incr(key string, delta int64) (int64, error) {
+	value := int64(toInt(m[key])) + delta
+	m[key] = value
+	return value, nil
+}
+
+func (m memory) Decr(key string, delta int64) (int64, error) {
+	return m.Incr(key, -delta)
+}
+
+func TestSlidingWindow(t *testing.T) {
+	policy := &Policy{ID: "api", Limit: 3, Window: "1m"}
+	require.NoError(t, policy.validate())
+
+	start := time.Unix(0, 0).Add(10 * time.Minute)
+	now = func() time.Time { return start }
+	defer func() { now = time.Now }()
+
+	st := memory{}
+	for i := 2; i >= 0; i-- {
+		res := policy.take(st, "ip:127.0.0.1")
+		assert.True(t, res.Allowed)
+		assert.Equal(t, i, res.Remaining)
+	}
+
+	res := policy.take(st, "ip:127.0.0.1")
+	assert.False(t, res.Allowed)
+	assert.Equal(t, time.Minute, res.RetryAfter)
+
+	// The other identities are counted separately
+	assert.True(t, policy.take(st, "ip:127.0.0.2").Allowed)
+
+	// Half of the previous window is still covered: 3 * 0.5 = 1
+	now = func() time.Time { return start.Add(90 * time.Second) }
+	res = policy.take(st, "ip:127.0.0.1")
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 1, res.Remaining)
+	assert.Equal(t, 30*time.Second, res.Reset)
+
+	assert.True(t, policy.take(st, "ip:127.0.0.1").Allowed)
+	res = policy.take(st, "ip:127.0.0.1")
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 10*time.Second, res.RetryAfter) // 3 * (1 - 2/3) + 2 < 3
+}
+
+func TestTokenBucket(t *testing.T) {
+	policy := &Policy{ID: "chat", Algorithm: TokenBucket, Limit: 6, Window: "1m", Burst: 2, Key: KeyUser}
+	require.NoError(t, policy.validate())
+
+	start := time.Unix(0, 0)
+	now = func() time.Time { return start }
+	defer func() { now = time.Now }()
+
+	st := memory{}
+	assert.True(t, policy.take(st, "1").Allowed)
+	res := policy.take(st, "1")
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+	assert.Equal(t, 20*time.Second, res.Reset)
+
+	res = policy.take(st, "1")
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 10*time.Second, res.RetryAfter)
+
+	// A token is refilled every 10 seconds
+	now = func() time.Time { return start.Add(10 * time.Second) }
+	assert.True(t, policy.take(st, "1").Allowed)
+	assert.False(t, policy.take(st, "1").Allowed)
+}
+
+func TestBucketLock(t *testing.T) {
+	policy := &Policy{ID: "chat", Algorithm: TokenBucket, Limit: 6, Window: "1m", Burst: 1, Key: KeyUser}
+	require.NoError(t, policy.validate())
+
+	retries := bucketLockRetries
+	bucketLockRetries = 2
+	defer func() { bucketLockRetries = retries }()
+
+	// The bucket is locked by another instance, the request is allowed without taking a token
+	st := memory{}
+	key := "ratelimit:chat:user:1"
+	st.Set(key+":lock", int64(1), bucketLockTTL)
+	assert.True(t, policy.take(st, "1").Allowed)
+	assert.False(t, st.Has(key))
+
+	// The lock is released after the update
+	st.Del(key + ":lock")
+	assert.True(t, policy.take(st, "1").Allowed)
+	assert.False(t, st.Has(key+":lock"))
+	assert.False(t, policy.take(st, "1").Allowed)
+}
+
+func TestValidate(t *testing.T) {
+	policy := &Policy{Limit: 10}
+	require.NoError(t, policy.validate())
+	assert.Equal(t, SlidingWindow, policy.Algorithm)
+	assert.Equal(t, time.Minute, policy.window)
+	assert.Equal(t, KeyIP, policy.Key)
+	assert.Equal(t, DefaultStore, policy.Store)
+	assert.Equal(t, 10, policy.Burst)
+
+	assert.Error(t, (&Policy{}).validate())
+	assert.Error(t, (&Policy{Limit: 1, Algorithm: "leaky-bucket"}).validate())
+	assert.Error(t, (&Policy{Limit: 1, Window: "100ms"}).validate())
+	assert.Error(t, (&Policy{Limit: 1, Key: "email"}).validate())
+	assert.Error(t, (&Policy{Limit: 1, Routes: []string{"api/*"}}).validate())
+}
+
+func TestMatch(t *testing.T) {
+	policy := &Policy{Limit: 10, Routes: []string{"/v1/chat/*", "post /api/pets/*/photos", "/api/users"}}
+	require.NoError(t, policy.validate())
+
+	assert.True(t, policy.match("GET", "/v1/chat/completions"))
+	assert.True(t, policy.match("GET", "/v1/chat"))
+	assert.False(t, policy.match("GET", "/v1/chatbot"))
+	assert.True(t, policy.match("POST", "/api/pets/1/photos"))
+	assert.False(t, policy.match("GET", "/api/pets/1/photos"))
+	assert.True(t, policy.match("DELETE", "/api/users"))
+	assert.False(t, policy.match("GET", "/api/users/1"))
+}
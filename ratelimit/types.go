@@ -0,0 +1,52 @@
+package ratelimit
+
+import "time"
+
+// The algorithms
+const (
+	SlidingWindow = "sliding-window"
+	TokenBucket   = "token-bucket"
+)
+
+// The keys of the counters, the requests without the identity are keyed by ip
+const (
+	KeyIP     = "ip"
+	KeyUser   = "user"
+	KeyTeam   = "team"
+	KeyClient = "client_id"
+	KeyAPIKey = "api_key"
+)
+
+// DefaultStore the store of the counters, shared by the instances
+const DefaultStore = "__yao.store"
+
+// Policy the rate limit policy, ratelimits/<id>.limit.yao
+type Policy struct {
+	ID        string   `json:"-"`
+	Name      string   `json:"name,omitempty"`
+	Algorithm string   `json:"algorithm,omitempty"` // sliding-window (default) or token-bucket
+	Limit     int      `json:"limit"`               // the requests allowed in the window
+	Window    string   `json:"window,omitempty"`    // the duration of the window, default 1m
+	Burst     int      `json:"burst,omitempty"`     // the capacity of the token bucket, default limit
+	Key       string   `json:"key,omitempty"`       // ip (default), user, team, client_id or api_key
+	Store     string   `json:"store,omitempty"`     // the gou store of the counters, default __yao.store
+	Routes    []string `json:"routes,omitempty"`    // the routes applied to, "/v1/chat/*" or "POST /api/pets/*"
+	window    time.Duration
+	routes    []route
+}
+
+// Result the result of taking a request from a policy
+type Result struct {
+	Policy     string        `json:"policy"`
+	Allowed    bool          `json:"allowed"`
+	Limit      int           `json:"limit"`
+	Remaining  int           `json:"remaining"`
+	Reset      time.Duration `json:"reset"`       // until the window is reset or the bucket is full
+	RetryAfter time.Duration `json:"retry_after"` // until the next request is allowed, zero if allowed
+}
+
+type route struct {
+	method  string
+	pattern string
+	prefix  bool
+}
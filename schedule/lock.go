@@ -0,0 +1,128 @@
+package schedule
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yaoapp/gou/model"
+)
+
+// LockModel the model of the leases shared by the instances
+const LockModel = "__yao.job.lock"
+
+// instance the identifier of the running instance
+var instance = instanceID()
+
+func instanceID() string {
+	hostname, _ := os.Hostname()
+	return fmt.Sprintf("%s:%d:%s", hostname, os.Getpid(), uuid.NewString()[:8])
+}
+
+func lockModel() (*model.Model, error) {
+	mod, has := model.Models[LockModel]
+	if !has {
+		return nil, fmt.Errorf("model %s not found", LockModel)
+	}
+	return mod, nil
+}
+
+// ensureLock create the lock row if it does not exist, the row may be created by another instance at the same time
+func ensureLock(name string) error {
+	mod, err := lockModel()
+	if err != nil {
+		return err
+	}
+
+	param := model.QueryParam{Select: []interface{}{"id"}, Wheres: []model.QueryWhere{{Column: "name", Value: name}}, Limit: 1}
+	rows, err := mod.Get(param)
+	if err != nil {
+		return err
+	}
+	if len(rows) > 0 {
+		return nil
+	}
+
+	_, err = mod.Create(map[string]interface{}{"name": name})
+	if err != nil {
+		rows, getErr := mod.Get(param)
+		if getErr == nil && len(rows) > 0 {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// claim the fire time, returns true if the fire time is claimed by the instance.
+// The update is conditional so only one of the instances updates the row.
+func claim(name string, tick time.Time) (bool, error) {
+	mod, err := lockModel()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := mod.UpdateWhere(model.QueryParam{
+		Wheres: []model.QueryWhere{
+			{Column: "name", Value: name},
+			{Wheres: []model.QueryWhere{
+				{Column: "tick", OP: "null"},
+				{Column: "tick", OP: "<", Value: tick, Method: "orwhere"},
+			}},
+		},
+	}, map[string]interface{}{"tick": tick})
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// acquire the lease of the run, returns false if the lease is held by another run
+func acquire(name string, ttl time.Duration) (bool, error) {
+	mod, err := lockModel()
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	affected, err := mod.UpdateWhere(model.QueryParam{
+		Wheres: []model.QueryWhere{
+			{Column: "name", Value: name},
+			{Wheres: []model.QueryWhere{
+				{Column: "expires_at", OP: "null"},
+				{Column: "expires_at", OP: "<", Value: now, Method: "orwhere"},
+			}},
+		},
+	}, map[string]interface{}{"expires_at": now.Add(ttl), "owner": instance})
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// renew the lease held by the instance
+func renew(name string, ttl time.Duration) error {
+	mod, err := lockModel()
+	if err != nil {
+		return err
+	}
+
+	_, err = mod.UpdateWhere(model.QueryParam{
+		Wheres: []model.QueryWhere{{Column: "name", Value: name}, {Column: "owner", Value: instance}},
+	}, map[string]interface{}{"expires_at": time.Now().Add(ttl)})
+	return err
+}
+
+// release the lease held by the instance
+func release(name string) error {
+	mod, err := lockModel()
+	if err != nil {
+		return err
+	}
+
+	_, err = mod.UpdateWhere(model.QueryParam{
+		Wheres: []model.QueryWhere{{Column: "name", Value: name}, {Column: "owner", Value: instance}},
+	}, map[string]interface{}{"expires_at": time.Now()})
+	return err
+}
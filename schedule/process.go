@@ -0,0 +1,103 @@
+package schedule
+
+import (
+	"sort"
+
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/kun/exception"
+	"github.com/yaoapp/yao/audit"
+	"github.com/yaoapp/yao/openapi/oauth/authorized"
+)
+
+func init() {
+	process.RegisterGroup("schedule", map[string]process.Handler{
+		"list":    processList,
+		"get":     processGet,
+		"trigger": processTrigger,
+		"pause":   processPause,
+		"resume":  processResume,
+	})
+}
+
+// processList schedule.List returns the schedules and the states of their jobs
+func processList(process *process.Process) interface{} {
+	return List()
+}
+
+// processGet schedule.Get (:id)
+func processGet(process *process.Process) interface{} {
+	process.ValidateArgNums(1)
+	return mustSelect(process.ArgsString(0)).Info()
+}
+
+// processTrigger schedule.Trigger (:id) run the schedule manually, returns the execution
+func processTrigger(process *process.Process) interface{} {
+	process.ValidateArgNums(1)
+	sch := mustSelect(process.ArgsString(0))
+
+	source := "process"
+	if info := authorized.ProcessAuthInfo(process); info != nil && info.UserID != "" {
+		source = info.UserID
+	}
+
+	execution, err := sch.Trigger(source)
+	audit.Log(audit.New("schedule.trigger", audit.CategorySystem).WithProcess(process).WithResource("schedule", sch.ID).WithError(err))
+	if err == ErrSkipped {
+		exception.New("schedule %s is skipped, %s", 409, sch.ID, err.Error()).Throw()
+	}
+	if err != nil {
+		exception.New(err.Error(), 500).Throw()
+	}
+	return map[string]interface{}{"execution_id": execution.ExecutionID, "job_id": sch.JobID(), "status": execution.Status}
+}
+
+// processPause schedule.Pause (:id)
+func processPause(process *process.Process) interface{} {
+	process.ValidateArgNums(1)
+	sch := mustSelect(process.ArgsString(0))
+	err := sch.Pause()
+	audit.Log(audit.New("schedule.pause", audit.CategorySystem).WithProcess(process).WithResource("schedule", sch.ID).WithError(err))
+	if err != nil {
+		exception.New(err.Error(), 500).Throw()
+	}
+	return nil
+}
+
+// processResume schedule.Resume (:id)
+func processResume(process *process.Process) interface{} {
+	process.ValidateArgNums(1)
+	sch := mustSelect(process.ArgsString(0))
+	err := sch.Resume()
+	audit.Log(audit.New("schedule.resume", audit.CategorySystem).WithProcess(process).WithResource("schedule", sch.ID).WithError(err))
+	if err != nil {
+		exception.New(err.Error(), 500).Throw()
+	}
+	return nil
+}
+
+// List returns the information of the schedules ordered by id
+func List() []map[string]interface{} {
+	lock.RLock()
+	ids := make([]string, 0, len(Schedules))
+	for id := range Schedules {
+		ids = append(ids, id)
+	}
+	lock.RUnlock()
+	sort.Strings(ids)
+
+	list := []map[string]interface{}{}
+	for _, id := range ids {
+		if sch, err := Select(id); err == nil {
+			list = append(list, sch.Info())
+		}
+	}
+	return list
+}
+
+func mustSelect(id string) *Schedule {
+	sch, err := Select(id)
+	if err != nil {
+		exception.New(err.Error(), 404).Throw()
+	}
+	return sch
+}
@@ -0,0 +1,405 @@
+package schedule
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/job"
+)
+
+// ErrSkipped the run is skipped because the previous run is still running (overlap: skip)
+var ErrSkipped = errors.New("the previous run is still running")
+
+// queueInterval the interval of trying to acquire the lease when the run is queued
+var queueInterval = time.Second
+
+// JobID the job recording the runs of the schedule
+func (sch *Schedule) JobID() string {
+	return "schedule." + sch.ID
+}
+
+// Trigger run the schedule manually, the overlap policy is applied.
+// Returns the execution once the run is started or queued.
+func (sch *Schedule) Trigger(source string) (*job.Execution, error) {
+	lock.RLock()
+	ctx := sch.ctx
+	lock.RUnlock()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return sch.run(ctx, "manual", source, nil)
+}
+
+// Pause the schedule on all the instances, the manual triggers are still allowed
+func (sch *Schedule) Pause() error {
+	return job.UpdateJob(sch.JobID(), map[string]interface{}{"enabled": false, "status": "paused"})
+}
+
+// Resume the paused schedule
+func (sch *Schedule) Resume() error {
+	return job.UpdateJob(sch.JobID(), map[string]interface{}{"enabled": true, "status": "ready"})
+}
+
+// Paused returns true if the schedule is paused
+func (sch *Schedule) Paused() (bool, error) {
+	j, err := job.GetJob(sch.JobID())
+	if err != nil {
+		return false, err
+	}
+	return !j.Enabled, nil
+}
+
+// start the schedule, the job and the lock of the schedule are created if they do not exist
+func (sch *Schedule) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	sch.ctx = ctx
+	sch.cancel = cancel
+	go func() {
+		if err := sch.prepare(); err != nil {
+			log.Error("[Schedule] %s %s", sch.ID, err.Error())
+		}
+		sch.loop(ctx)
+	}()
+}
+
+// stop the schedule, the queued runs are cancelled and the running ones are finished
+func (sch *Schedule) stop() {
+	if sch.cancel != nil {
+		sch.cancel()
+		sch.cancel = nil
+	}
+}
+
+func (sch *Schedule) prepare() error {
+	err := sch.ensureJob()
+	if err != nil {
+		return err
+	}
+	if sch.Lock.Disable {
+		return nil
+	}
+	return ensureLock(sch.JobID())
+}
+
+// ensureJob create the job of the schedule, the pause state of the existing job is kept
+func (sch *Schedule) ensureJob() error {
+	config := map[string]interface{}{
+		"schedule": sch.ID,
+		"process":  sch.Process,
+		"task":     sch.TaskName,
+		"overlap":  sch.Overlap,
+		"lock":     !sch.Lock.Disable,
+	}
+
+	if _, err := job.GetJob(sch.JobID()); err == nil {
+		return job.UpdateJob(sch.JobID(), map[string]interface{}{
+			"name":                sch.Name,
+			"description":         sch.Description,
+			"schedule_expression": sch.Schedule,
+			"config":              config,
+		})
+	}
+
+	j, err := job.Cron(job.GOROUTINE, map[string]interface{}{
+		"job_id":        sch.JobID(),
+		"name":          sch.Name,
+		"description":   sch.Description,
+		"category_name": "Schedules",
+		"status":        "ready",
+		"enabled":       true,
+		"system":        true,
+		"readonly":      true,
+		"config":        config,
+	}, sch.Schedule)
+	if err != nil {
+		return err
+	}
+	return job.SaveJob(j)
+}
+
+// loop fire the schedule at the times of the cron expression until the schedule is stopped
+func (sch *Schedule) loop(ctx context.Context) {
+	for {
+		next := sch.cron.Next(time.Now())
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			go sch.fire(ctx, next)
+		}
+	}
+}
+
+// fire the schedule, only the instance claims the fire time runs it
+func (sch *Schedule) fire(ctx context.Context, tick time.Time) {
+	paused, err := sch.Paused()
+	if err != nil {
+		log.Warn("[Schedule] %s %s", sch.ID, err.Error())
+	}
+	if paused {
+		log.Trace("[Schedule] %s is paused", sch.ID)
+		return
+	}
+
+	if !sch.Lock.Disable {
+		claimed, err := claim(sch.JobID(), tick)
+		if err != nil {
+			log.Error("[Schedule] %s claim: %s", sch.ID, err.Error())
+			return
+		}
+		if !claimed {
+			log.Trace("[Schedule] %s %s is claimed by another instance", sch.ID, tick.Format(time.RFC3339))
+			return
+		}
+	}
+
+	_, err = sch.run(ctx, "scheduled", instance, &tick)
+	if err != nil && err != ErrSkipped {
+		log.Error("[Schedule] %s %s", sch.ID, err.Error())
+	}
+}
+
+// run record the execution and run the schedule with the overlap policy
+func (sch *Schedule) run(ctx context.Context, trigger string, source string, tick *time.Time) (*job.Execution, error) {
+	execution := sch.newExecution(trigger, source, tick)
+	sch.save(execution)
+
+	acquired, err := sch.acquire()
+	if err != nil {
+		sch.finish(execution, nil, err)
+		return execution, err
+	}
+
+	if acquired {
+		go sch.exec(execution)
+		return execution, nil
+	}
+
+	if sch.Overlap == OverlapSkip {
+		log.Warn("[Schedule] %s skipped, %s", sch.ID, ErrSkipped.Error())
+		sch.abort(execution, "Execution skipped: "+ErrSkipped.Error())
+		return execution, ErrSkipped
+	}
+
+	// Queue the run until the previous run is finished
+	go func() {
+		if !sch.wait(ctx) {
+			sch.abort(execution, "Execution cancelled: the schedule is stopped")
+			return
+		}
+		sch.exec(execution)
+	}()
+	return execution, nil
+}
+
+// exec run the process of the schedule, the lease is renewed until the run is finished
+func (sch *Schedule) exec(execution *job.Execution) {
+	done := make(chan struct{})
+	defer func() {
+		close(done)
+		sch.release()
+	}()
+	go sch.heartbeat(done)
+
+	now := time.Now()
+	execution.Status = "running"
+	execution.StartedAt = &now
+	execution.WorkerID = &instance
+	sch.save(execution)
+
+	err := job.UpdateJob(sch.JobID(), map[string]interface{}{"last_run_at": now, "next_run_at": sch.cron.Next(now)})
+	if err != nil {
+		log.Warn("[Schedule] %s %s", sch.ID, err.Error())
+	}
+
+	res, err := sch.call()
+	sch.finish(execution, res, err)
+}
+
+// call the process or add the job to the task
+func (sch *Schedule) call() (interface{}, error) {
+	name := sch.Process
+	if sch.TaskName != "" {
+		name = fmt.Sprintf("tasks.%s.Add", sch.TaskName)
+	}
+
+	p, err := process.Of(name, sch.Args...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.Execute()
+	if err != nil {
+		return nil, err
+	}
+	defer p.Release()
+	return p.Value(), nil
+}
+
+// acquire the lease of the run, the lease is not required if the runs are allowed to overlap
+func (sch *Schedule) acquire() (bool, error) {
+	switch {
+	case sch.Overlap == OverlapAllow:
+		return true, nil
+	case sch.Lock.Disable:
+		return sch.local.TryLock(), nil
+	}
+	return acquire(sch.JobID(), sch.ttl())
+}
+
+func (sch *Schedule) release() {
+	switch {
+	case sch.Overlap == OverlapAllow:
+		return
+	case sch.Lock.Disable:
+		sch.local.Unlock()
+		return
+	}
+
+	if err := release(sch.JobID()); err != nil {
+		log.Warn("[Schedule] %s release: %s", sch.ID, err.Error())
+	}
+}
+
+// wait until the lease is acquired, returns false if the schedule is stopped
+func (sch *Schedule) wait(ctx context.Context) bool {
+	ticker := time.NewTicker(queueInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+			acquired, err := sch.acquire()
+			if err != nil {
+				log.Warn("[Schedule] %s %s", sch.ID, err.Error())
+				continue
+			}
+			if acquired {
+				return true
+			}
+		}
+	}
+}
+
+// heartbeat renew the lease until the run is finished
+func (sch *Schedule) heartbeat(done chan struct{}) {
+	if sch.Lock.Disable || sch.Overlap == OverlapAllow {
+		return
+	}
+
+	ticker := time.NewTicker(sch.ttl() / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := renew(sch.JobID(), sch.ttl()); err != nil {
+				log.Warn("[Schedule] %s renew: %s", sch.ID, err.Error())
+			}
+		}
+	}
+}
+
+func (sch *Schedule) ttl() time.Duration {
+	return time.Duration(sch.Lock.TTL) * time.Second
+}
+
+func (sch *Schedule) newExecution(trigger string, source string, tick *time.Time) *job.Execution {
+	config := &job.ExecutionConfig{
+		Type:        job.ExecutionTypeProcess,
+		ProcessName: sch.Process,
+		ProcessArgs: sch.Args,
+	}
+	if sch.TaskName != "" {
+		config.ProcessName = fmt.Sprintf("tasks.%s.Add", sch.TaskName)
+	}
+
+	data, _ := jsoniter.Marshal(config)
+	snapshot := json.RawMessage(data)
+	return &job.Execution{
+		JobID:           sch.JobID(),
+		Status:          "queued",
+		TriggerCategory: trigger,
+		TriggerSource:   &source,
+		ScheduledAt:     tick,
+		ExecutionConfig: config,
+		ConfigSnapshot:  &snapshot,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+}
+
+// finish record the result of the run
+func (sch *Schedule) finish(execution *job.Execution, res interface{}, err error) {
+	end := time.Now()
+	execution.EndedAt = &end
+
+	var duration *int
+	if execution.StartedAt != nil {
+		ms := int(end.Sub(*execution.StartedAt).Milliseconds())
+		duration = &ms
+		execution.Duration = duration
+	}
+
+	level, message := "info", "Execution completed successfully"
+	if err != nil {
+		execution.Status = "failed"
+		errorData, _ := jsoniter.Marshal(map[string]interface{}{"error": err.Error(), "time": end, "worker": instance})
+		execution.ErrorInfo = (*json.RawMessage)(&errorData)
+		level, message = "error", fmt.Sprintf("Execution failed: %v", err)
+		log.Error("[Schedule] %s %s", sch.ID, err.Error())
+	} else {
+		execution.Status = "completed"
+		execution.Progress = 100
+		if res != nil {
+			if resultData, err := jsoniter.Marshal(res); err == nil {
+				execution.Result = (*json.RawMessage)(&resultData)
+			}
+		}
+	}
+
+	sch.save(execution)
+	sch.log(execution, level, message, duration)
+}
+
+// abort record the run is skipped or cancelled
+func (sch *Schedule) abort(execution *job.Execution, message string) {
+	end := time.Now()
+	execution.Status = "cancelled"
+	execution.EndedAt = &end
+	errorData, _ := jsoniter.Marshal(map[string]interface{}{"error": message, "time": end, "worker": instance})
+	execution.ErrorInfo = (*json.RawMessage)(&errorData)
+	sch.save(execution)
+	sch.log(execution, "warning", message, nil)
+}
+
+func (sch *Schedule) save(execution *job.Execution) {
+	if err := job.SaveExecution(execution); err != nil {
+		log.Warn("[Schedule] %s failed to save execution: %s", sch.ID, err.Error())
+	}
+}
+
+func (sch *Schedule) log(execution *job.Execution, level string, message string, duration *int) {
+	entry := &job.Log{
+		JobID:       sch.JobID(),
+		Level:       level,
+		Message:     message,
+		ExecutionID: &execution.ExecutionID,
+		WorkerID:    &instance,
+		Duration:    duration,
+		Timestamp:   time.Now(),
+	}
+	if err := job.SaveLog(entry); err != nil {
+		log.Warn("[Schedule] %s failed to save log: %s", sch.ID, err.Error())
+	}
+}
@@ -3,14 +3,27 @@ package schedule
 import (
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/robfig/cron/v3"
 	"github.com/yaoapp/gou/application"
-	"github.com/yaoapp/gou/schedule"
 	"github.com/yaoapp/kun/log"
 	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/job"
 	"github.com/yaoapp/yao/share"
 )
 
+// Schedules the loaded schedules
+var Schedules = map[string]*Schedule{}
+
+var lock sync.RWMutex
+var started bool
+
+// the standard cron expressions, the seconds field and the descriptors (@every 1h, @daily) are optional.
+// The @every intervals are counted from the start of each instance, use the cron fields for the schedules of a cluster.
+var parser = cron.NewParser(cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
 // Load load schedule
 func Load(cfg config.Config) error {
 
@@ -29,7 +42,7 @@ func Load(cfg config.Config) error {
 		if isdir {
 			return nil
 		}
-		_, err := schedule.Load(file, share.ID(root, file))
+		_, err := LoadFile(file, share.ID(root, file))
 		if err != nil {
 			messages = append(messages, err.Error())
 		}
@@ -42,18 +55,134 @@ func Load(cfg config.Config) error {
 	return err
 }
 
+// LoadFile load the schedule from the file
+func LoadFile(file string, id string) (*Schedule, error) {
+	data, err := application.App.Read(file)
+	if err != nil {
+		return nil, err
+	}
+	return LoadSource(file, data, id)
+}
+
+// LoadSource load the schedule from the source, the running schedule with the same id is restarted
+func LoadSource(file string, data []byte, id string) (*Schedule, error) {
+	sch := &Schedule{}
+	err := application.Parse(file, data, sch)
+	if err != nil {
+		return nil, fmt.Errorf("[Schedule] %s %s", id, err.Error())
+	}
+
+	sch.ID = id
+	err = sch.validate()
+	if err != nil {
+		return nil, fmt.Errorf("[Schedule] %s %s", id, err.Error())
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	if loaded, has := Schedules[id]; has {
+		loaded.stop()
+	}
+	Schedules[id] = sch
+	if started {
+		sch.start()
+	}
+	return sch, nil
+}
+
+// Select the schedule by id
+func Select(id string) (*Schedule, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+	sch, has := Schedules[id]
+	if !has {
+		return nil, fmt.Errorf("schedule %s not found", id)
+	}
+	return sch, nil
+}
+
+// Unload the schedule, the running schedule is stopped
+func Unload(id string) {
+	lock.Lock()
+	defer lock.Unlock()
+	if sch, has := Schedules[id]; has {
+		sch.stop()
+		delete(Schedules, id)
+	}
+}
+
 // Start schedules
 func Start() {
-	for name, sch := range schedule.Schedules {
-		sch.Start()
+	lock.Lock()
+	defer lock.Unlock()
+	started = true
+	for name, sch := range Schedules {
+		sch.start()
 		log.Info("[Schedule] %s start", name)
 	}
 }
 
 // Stop schedules
 func Stop() {
-	for name, sch := range schedule.Schedules {
-		sch.Stop()
+	lock.Lock()
+	defer lock.Unlock()
+	started = false
+	for name, sch := range Schedules {
+		sch.stop()
 		log.Info("[Schedule] %s stop", name)
 	}
 }
+
+// validate the schedule and set the defaults
+func (sch *Schedule) validate() error {
+	if sch.Process == "" && sch.TaskName == "" {
+		return fmt.Errorf("process or task is required")
+	}
+
+	expr, err := parser.Parse(sch.Schedule)
+	if err != nil {
+		return fmt.Errorf("schedule %q is invalid: %s", sch.Schedule, err.Error())
+	}
+	sch.cron = expr
+
+	switch sch.Overlap {
+	case "":
+		sch.Overlap = OverlapSkip
+	case OverlapSkip, OverlapQueue, OverlapAllow:
+	default:
+		return fmt.Errorf("overlap %s is not supported, use skip, queue or allow", sch.Overlap)
+	}
+
+	if sch.Lock.TTL <= 0 {
+		sch.Lock.TTL = 60
+	}
+
+	if sch.Name == "" {
+		sch.Name = sch.ID
+	}
+	return nil
+}
+
+// Info returns the information of the schedule and the state of its job
+func (sch *Schedule) Info() map[string]interface{} {
+	info := map[string]interface{}{
+		"id":          sch.ID,
+		"name":        sch.Name,
+		"description": sch.Description,
+		"schedule":    sch.Schedule,
+		"process":     sch.Process,
+		"task":        sch.TaskName,
+		"args":        sch.Args,
+		"overlap":     sch.Overlap,
+		"lock":        sch.Lock,
+		"job_id":      sch.JobID(),
+		"next_run_at": sch.cron.Next(time.Now()),
+	}
+
+	if j, err := job.GetJob(sch.JobID()); err == nil {
+		info["paused"] = !j.Enabled
+		info["status"] = j.Status
+		info["last_run_at"] = j.LastRunAt
+	}
+	return info
+}
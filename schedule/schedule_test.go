@@ -2,14 +2,27 @@ package schedule
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
-	"github.com/yaoapp/gou/schedule"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/gou/process"
 	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/job"
 	"github.com/yaoapp/yao/task"
 	"github.com/yaoapp/yao/test"
 )
 
+var waiting = make(chan struct{})
+
+func init() {
+	process.Register("tests.schedule.wait", func(p *process.Process) interface{} {
+		<-waiting
+		return map[string]interface{}{"done": true}
+	})
+}
+
 func TestLoad(t *testing.T) {
 	test.Prepare(t, config.Conf)
 	defer test.Clean()
@@ -30,9 +43,129 @@ func TestStartStop(t *testing.T) {
 	defer Stop()
 }
 
+func TestLoadSource(t *testing.T) {
+	sch, err := LoadSource("test.sch.yao", []byte(`{"name": "Test", "schedule": "*/5 * * * *", "process": "utils.now.Timestamp"}`), "tests.source")
+	require.NoError(t, err)
+	defer Unload("tests.source")
+
+	assert.Equal(t, OverlapSkip, sch.Overlap)
+	assert.Equal(t, 60, sch.Lock.TTL)
+	assert.Equal(t, "schedule.tests.source", sch.JobID())
+
+	next := sch.cron.Next(time.Date(2026, 1, 1, 0, 1, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 5, 0, 0, time.UTC), next)
+
+	_, err = LoadSource("test.sch.yao", []byte(`{"schedule": "@every 10s", "process": "utils.now.Timestamp", "overlap": "never"}`), "tests.invalid")
+	assert.Error(t, err)
+
+	_, err = LoadSource("test.sch.yao", []byte(`{"schedule": "not a cron", "process": "utils.now.Timestamp"}`), "tests.invalid")
+	assert.Error(t, err)
+
+	_, err = LoadSource("test.sch.yao", []byte(`{"schedule": "@daily"}`), "tests.invalid")
+	assert.Error(t, err)
+}
+
+func TestClaim(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+	defer clean(t)
+
+	name := "schedule.tests.claim"
+	require.NoError(t, ensureLock(name))
+	require.NoError(t, ensureLock(name))
+
+	tick := time.Now().Truncate(time.Minute)
+	claimed, err := claim(name, tick)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	// The fire time is claimed once
+	claimed, err = claim(name, tick)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	claimed, err = claim(name, tick.Add(time.Minute))
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	// The lease is held until it's released or expired
+	acquired, err := acquire(name, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	acquired, err = acquire(name, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+
+	require.NoError(t, release(name))
+	acquired, err = acquire(name, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+	require.NoError(t, release(name))
+}
+
+func TestTrigger(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+	defer clean(t)
+
+	sch, err := LoadSource("test.sch.yao", []byte(`{"name": "Wait", "schedule": "@yearly", "process": "tests.schedule.wait"}`), "tests.trigger")
+	require.NoError(t, err)
+	defer Unload("tests.trigger")
+	require.NoError(t, sch.prepare())
+
+	first, err := sch.Trigger("tests")
+	require.NoError(t, err)
+	assert.NotEmpty(t, first.ExecutionID)
+	assert.Eventually(t, func() bool { return status(first.ExecutionID) == "running" }, 5*time.Second, 20*time.Millisecond)
+
+	// The previous run is still running
+	second, err := sch.Trigger("tests")
+	assert.Equal(t, ErrSkipped, err)
+	assert.Equal(t, "cancelled", status(second.ExecutionID))
+
+	waiting <- struct{}{}
+	assert.Eventually(t, func() bool { return status(first.ExecutionID) == "completed" }, 5*time.Second, 20*time.Millisecond)
+
+	executions, err := job.GetExecutions(sch.JobID())
+	require.NoError(t, err)
+	assert.Len(t, executions, 2)
+
+	// Pause and resume
+	require.NoError(t, sch.Pause())
+	paused, err := sch.Paused()
+	require.NoError(t, err)
+	assert.True(t, paused)
+
+	require.NoError(t, sch.Resume())
+	paused, err = sch.Paused()
+	require.NoError(t, err)
+	assert.False(t, paused)
+}
+
+func status(executionID string) string {
+	execution, err := job.GetExecution(executionID, model.QueryParam{})
+	if err != nil {
+		return ""
+	}
+	return execution.Status
+}
+
+func clean(t *testing.T) {
+	columns := map[string]string{LockModel: "name", "__yao.job.execution": "job_id", "__yao.job.log": "job_id", "__yao.job": "job_id"}
+	for id, column := range columns {
+		mod, has := model.Models[id]
+		if !has {
+			continue
+		}
+		_, err := mod.DeleteWhere(model.QueryParam{Wheres: []model.QueryWhere{{Column: column, OP: "like", Value: "schedule.tests.%"}}})
+		assert.NoError(t, err)
+	}
+}
+
 func check(t *testing.T) {
 	ids := map[string]bool{}
-	for id := range schedule.Schedules {
+	for id := range Schedules {
 		ids[id] = true
 	}
 	assert.True(t, ids["mail"])
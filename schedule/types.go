@@ -0,0 +1,40 @@
+package schedule
+
+import (
+	"context"
+	"sync"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Overlap policies, what to do when the schedule fires while the previous run is still running
+const (
+	OverlapSkip  = "skip"  // skip the run (default)
+	OverlapQueue = "queue" // wait for the previous run to finish
+	OverlapAllow = "allow" // run concurrently
+)
+
+// Schedule the schedule DSL (schedules/*.sch.yao)
+type Schedule struct {
+	ID          string        `json:"-"`
+	Name        string        `json:"name"`
+	Description string        `json:"description,omitempty"`
+	Schedule    string        `json:"schedule"`
+	Process     string        `json:"process,omitempty"`
+	TaskName    string        `json:"task,omitempty"`
+	Args        []interface{} `json:"args,omitempty"`
+	Overlap     string        `json:"overlap,omitempty"`
+	Lock        Lock          `json:"lock,omitempty"`
+
+	cron   cron.Schedule
+	ctx    context.Context
+	cancel context.CancelFunc
+	local  sync.Mutex // the lease of the run when the cluster lock is disabled
+}
+
+// Lock the cluster lock of the schedule. Each fire time is claimed by only one of the instances,
+// and the running instance holds a lease renewed until the run is finished.
+type Lock struct {
+	Disable bool `json:"disable,omitempty"` // every instance fires the schedule
+	TTL     int  `json:"ttl,omitempty"`     // the lease in seconds, the lease of a crashed instance is expired after ttl, default 60
+}
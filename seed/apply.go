@@ -0,0 +1,397 @@
+package seed
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/kun/maps"
+)
+
+// HistoryModel the model of the applied seed items
+const HistoryModel = "__yao.seed"
+
+// Apply applies the items of the seed set in the referential order.
+// The items unchanged since they were applied are skipped unless all is set.
+// The rows are upserted by the natural keys of the item, so applying an item again does not duplicate the rows.
+func Apply(name string, option ApplyOption) ([]ItemResult, error) {
+	set, err := LoadSet(name)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := set.Ordered()
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := applied()
+	if err != nil {
+		return nil, err
+	}
+
+	results := []ItemResult{}
+	res := &resolver{cache: map[string]interface{}{}}
+	for _, item := range items {
+		if _, has := model.Models[item.Model]; !has {
+			return results, fmt.Errorf("[Seed] %s %s the model %s is not loaded", item.set, item.Name, item.Model)
+		}
+
+		checksum, err := item.checksum()
+		if err != nil {
+			return results, fmt.Errorf("[Seed] %s %s %s", item.set, item.Name, err.Error())
+		}
+
+		result := ItemResult{Set: item.set, Item: item.Name, Model: item.Model}
+		record, has := records[item.set+"/"+item.Name]
+		if has && record.Checksum == checksum && !option.All {
+			result.Skipped = true
+			results = append(results, result)
+			continue
+		}
+
+		result.Result, err = item.apply(res, option.Progress)
+		if err != nil {
+			return results, fmt.Errorf("[Seed] %s %s %s", item.set, item.Name, err.Error())
+		}
+		results = append(results, result)
+
+		// The failed items are applied again next time
+		if result.Result.Failure > 0 {
+			continue
+		}
+		err = save(item, checksum, result.Result.Success, has)
+		if err != nil {
+			return results, fmt.Errorf("[Seed] %s %s is applied but not recorded: %s", item.set, item.Name, err.Error())
+		}
+	}
+	return results, nil
+}
+
+// Reset deletes the rows of the models of the seed set in the reverse order and applies the set again
+func Reset(name string, option ApplyOption) ([]ItemResult, error) {
+	set, err := LoadSet(name)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := set.Ordered()
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := map[string]bool{}
+	sets := map[string]bool{}
+	for i := len(items) - 1; i >= 0; i-- {
+		item := items[i]
+		sets[item.set] = true
+		if deleted[item.Model] {
+			continue
+		}
+
+		mod, has := model.Models[item.Model]
+		if !has {
+			return nil, fmt.Errorf("[Seed] %s %s the model %s is not loaded", item.set, item.Name, item.Model)
+		}
+		if _, err := mod.DestroyWhere(model.QueryParam{}); err != nil {
+			return nil, fmt.Errorf("[Seed] %s %s", item.Model, err.Error())
+		}
+		deleted[item.Model] = true
+	}
+
+	history, err := historyModel()
+	if err != nil {
+		return nil, err
+	}
+	for name := range sets {
+		_, err := history.DestroyWhere(model.QueryParam{Wheres: []model.QueryWhere{{Column: "seed", Value: name}}})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	option.All = true
+	return Apply(name, option)
+}
+
+// Statuses returns the status of the items of the seed set in the order to apply them
+func Statuses(name string) ([]Status, error) {
+	set, err := LoadSet(name)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := set.Ordered()
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := applied()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []Status{}
+	for _, item := range items {
+		status := Status{Set: item.set, Item: item.Name, Model: item.Model}
+		if record, has := records[item.set+"/"+item.Name]; has {
+			checksum, err := item.checksum()
+			if err != nil {
+				return nil, fmt.Errorf("[Seed] %s %s %s", item.set, item.Name, err.Error())
+			}
+			appliedAt := record.AppliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+			status.Rows = record.Rows
+			status.Changed = record.Checksum != checksum
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// apply upserts the rows of the item chunk by chunk
+func (item *Item) apply(res *resolver, progress func(item string, rows int)) (*ImportResult, error) {
+	mod := model.Models[item.Model]
+	result := &ImportResult{Errors: []ImportError{}}
+
+	if item.Generate != nil {
+		for start := 0; start < item.Generate.Count; start += item.ChunkSize {
+			count := min(item.ChunkSize, item.Generate.Count-start)
+			rows, err := generate(item.Generate, start, count)
+			if err != nil {
+				return nil, err
+			}
+			item.applyRows(mod, rows, start+1, res, result)
+			if progress != nil {
+				progress(item.Name, result.Total)
+			}
+		}
+		return result, nil
+	}
+
+	rows, err := records(item.File, mod)
+	if err != nil {
+		return nil, err
+	}
+	for start := 0; start < len(rows); start += item.ChunkSize {
+		end := min(start+item.ChunkSize, len(rows))
+		item.applyRows(mod, rows[start:end], start+1, res, result)
+		if progress != nil {
+			progress(item.Name, result.Total)
+		}
+	}
+	return result, nil
+}
+
+// applyRows upserts the rows by the natural keys or the primary key, the rows without both are inserted in a batch
+func (item *Item) applyRows(mod *model.Model, rows []map[string]interface{}, line int, res *resolver, result *ImportResult) {
+	inserts := [][]interface{}{}
+	insertLines := []int{}
+	columns := []string{}
+
+	for i, row := range rows {
+		result.Total++
+		err := item.resolve(res, row)
+		if err != nil {
+			result.Errors = append(result.Errors, ImportError{Row: line + i, Message: err.Error(), Code: 400})
+			result.Failure++
+			continue
+		}
+
+		if len(item.Keys) > 0 {
+			err := upsert(mod, item.Keys, row)
+			if err != nil {
+				result.Errors = append(result.Errors, ImportError{Row: line + i, Message: err.Error(), Code: 500})
+				result.Failure++
+				continue
+			}
+			result.Success++
+			continue
+		}
+
+		if _, has := row[mod.PrimaryKey]; has {
+			handleDuplicate(mod, maps.MapStrAny(row), line+i, DuplicateUpdate, result)
+			continue
+		}
+
+		if len(columns) == 0 {
+			for name := range row {
+				columns = append(columns, name)
+			}
+			sort.Strings(columns)
+		}
+		values := make([]interface{}, len(columns))
+		for j, name := range columns {
+			values[j] = row[name]
+		}
+		inserts = append(inserts, values)
+		insertLines = append(insertLines, line+i)
+	}
+
+	if len(inserts) > 0 {
+		err := importBatch(mod, columns, inserts, insertLines[0], ImportOption{Duplicate: DuplicateError}, result)
+		if err != nil {
+			log.Error("[Seed] %s %s %v", item.set, item.Name, err)
+		}
+	}
+}
+
+// resolve replaces the natural keys of the ref columns with the primary keys of the referenced rows
+func (item *Item) resolve(res *resolver, row map[string]interface{}) error {
+	for column, ref := range item.Refs {
+		value, has := row[column]
+		if !has || value == nil || value == "" {
+			continue
+		}
+
+		id, err := res.resolve(ref, value)
+		if err != nil {
+			return fmt.Errorf("%s %s", column, err.Error())
+		}
+		row[column] = id
+	}
+	return nil
+}
+
+// upsert updates the row found by the natural keys, creates it if not found
+func upsert(mod *model.Model, keys []string, row map[string]interface{}) error {
+	wheres := []model.QueryWhere{}
+	for _, key := range keys {
+		value, has := row[key]
+		if !has || value == nil {
+			return fmt.Errorf("the natural key %s is required", key)
+		}
+		wheres = append(wheres, model.QueryWhere{Column: key, Value: value})
+	}
+
+	rows, err := mod.Get(model.QueryParam{Select: []interface{}{mod.PrimaryKey}, Wheres: wheres, Limit: 1})
+	if err != nil {
+		return err
+	}
+
+	if len(rows) > 0 {
+		row[mod.PrimaryKey] = rows[0][mod.PrimaryKey]
+		_, err = mod.Save(maps.MapStrAny(row))
+		return err
+	}
+
+	delete(row, mod.PrimaryKey)
+	_, err = mod.Create(maps.MapStrAny(row))
+	return err
+}
+
+// resolver finds the primary keys of the rows by the natural keys
+type resolver struct {
+	cache map[string]interface{} // model/key/value -> primary key
+}
+
+func (res *resolver) resolve(ref Ref, value interface{}) (interface{}, error) {
+	cacheKey := fmt.Sprintf("%s/%s/%v", ref.Model, ref.Key, value)
+	if id, has := res.cache[cacheKey]; has {
+		return id, nil
+	}
+
+	mod, has := model.Models[ref.Model]
+	if !has {
+		return nil, fmt.Errorf("the model %s is not loaded", ref.Model)
+	}
+
+	rows, err := mod.Get(model.QueryParam{
+		Select: []interface{}{mod.PrimaryKey},
+		Wheres: []model.QueryWhere{{Column: ref.Key, Value: value}},
+		Limit:  1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%s %s=%v is not found", ref.Model, ref.Key, value)
+	}
+
+	id := rows[0][mod.PrimaryKey]
+	res.cache[cacheKey] = id
+	return id, nil
+}
+
+// applied returns the applied items, the key is <set>/<item>
+func applied() (map[string]Record, error) {
+	mod, err := historyModel()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := mod.Get(model.QueryParam{})
+	if err != nil {
+		return nil, err
+	}
+
+	records := map[string]Record{}
+	for _, row := range rows {
+		record := toRecord(row)
+		records[record.Set+"/"+record.Item] = record
+	}
+	return records, nil
+}
+
+// save records the applied item
+func save(item *Item, checksum string, rows int, exists bool) error {
+	mod, err := historyModel()
+	if err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"seed":       item.set,
+		"item":       item.Name,
+		"model":      item.Model,
+		"checksum":   checksum,
+		"rows":       rows,
+		"applied_at": time.Now(),
+	}
+
+	if exists {
+		_, err = mod.UpdateWhere(model.QueryParam{Wheres: []model.QueryWhere{
+			{Column: "seed", Value: item.set},
+			{Column: "item", Value: item.Name},
+		}}, data)
+		return err
+	}
+
+	_, err = mod.Create(data)
+	return err
+}
+
+// toRecord reads the record from the row of the history table
+func toRecord(row map[string]interface{}) Record {
+	record := Record{
+		Set:      fmt.Sprint(row["seed"]),
+		Item:     fmt.Sprint(row["item"]),
+		Model:    fmt.Sprint(row["model"]),
+		Checksum: fmt.Sprint(row["checksum"]),
+		Rows:     toInt(row["rows"]),
+	}
+
+	switch v := row["applied_at"].(type) {
+	case time.Time:
+		record.AppliedAt = v
+	case string:
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05"} {
+			if t, err := time.Parse(layout, v); err == nil {
+				record.AppliedAt = t
+				break
+			}
+		}
+	}
+	return record
+}
+
+func historyModel() (*model.Model, error) {
+	mod, has := model.Models[HistoryModel]
+	if !has {
+		return nil, fmt.Errorf("the model %s is not loaded", HistoryModel)
+	}
+	return mod, nil
+}
@@ -0,0 +1,274 @@
+package seed
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fakerRe matches the generator of a field: $faker.<name> or $faker.<name>(<args>)
+var fakerRe = regexp.MustCompile(`^\$faker\.([a-z_]+)(?:\((.*)\))?$`)
+
+var firstNames = []string{"James", "Mary", "John", "Patricia", "Robert", "Jennifer", "Michael", "Linda", "William", "Elizabeth", "David", "Barbara", "Richard", "Susan", "Joseph", "Jessica", "Thomas", "Sarah", "Charles", "Karen", "Wei", "Fang", "Min", "Jing", "Lei", "Yan", "Hiroshi", "Yuki", "Carlos", "Sofia"}
+var lastNames = []string{"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia", "Miller", "Davis", "Rodriguez", "Martinez", "Wilson", "Anderson", "Taylor", "Thomas", "Moore", "Jackson", "Martin", "Lee", "Wang", "Li", "Zhang", "Liu", "Chen", "Yang", "Tanaka", "Sato", "Lopez", "Gonzalez", "Clark", "Lewis"}
+var companies = []string{"Acme", "Globex", "Initech", "Umbrella", "Hooli", "Stark", "Wayne", "Wonka", "Soylent", "Cyberdyne", "Tyrell", "Aperture"}
+var companySuffixes = []string{"Inc", "LLC", "Ltd", "Group", "Corp", "Co"}
+var cities = []string{"New York", "London", "Paris", "Tokyo", "Beijing", "Shanghai", "Berlin", "Madrid", "Rome", "Sydney", "Toronto", "Singapore", "Seoul", "Mumbai", "Cairo", "Mexico City"}
+var words = []string{"alpha", "beta", "gamma", "delta", "apple", "orange", "river", "mountain", "cloud", "stone", "light", "shadow", "green", "blue", "quick", "silent", "bright", "ocean", "forest", "garden", "market", "signal", "paper", "window", "engine", "planet", "coffee", "winter", "summer", "harbor"}
+
+// generator generates the value of a field, seq starts from 1
+type generator func(r *rand.Rand, seq int) interface{}
+
+// compile compiles the generators of the fields, the values not starting with $faker. are copied
+func compile(fields map[string]interface{}) (map[string]generator, error) {
+	generators := map[string]generator{}
+	for name, value := range fields {
+		expr, ok := value.(string)
+		if !ok || !strings.HasPrefix(expr, "$faker.") {
+			v := value
+			generators[name] = func(r *rand.Rand, seq int) interface{} { return v }
+			continue
+		}
+
+		gen, err := parseFaker(expr)
+		if err != nil {
+			return nil, fmt.Errorf("the field %s %s", name, err.Error())
+		}
+		generators[name] = gen
+	}
+	return generators, nil
+}
+
+// generate returns the rows of the generate, the fields are generated in the order of their names
+func generate(g *Generate, start int, count int) ([]map[string]interface{}, error) {
+	generators, err := compile(g.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for name := range generators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	seed := g.Seed
+	if seed == 0 {
+		seed = 1
+	}
+
+	// Each chunk has its own source derived from the first seq, the same seed and chunk size generate the same rows
+	r := rand.New(rand.NewSource(seed + int64(start)*7919))
+	rows := make([]map[string]interface{}, 0, count)
+	for seq := start + 1; seq <= start+count; seq++ {
+		row := map[string]interface{}{}
+		for _, name := range names {
+			row[name] = generators[name](r, seq)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func parseFaker(expr string) (generator, error) {
+	matches := fakerRe.FindStringSubmatch(strings.TrimSpace(expr))
+	if matches == nil {
+		return nil, fmt.Errorf("%s is invalid, it should be $faker.<name>(<args>)", expr)
+	}
+
+	name := matches[1]
+	args := []string{}
+	if strings.TrimSpace(matches[2]) != "" {
+		for _, arg := range strings.Split(matches[2], ",") {
+			args = append(args, strings.TrimSpace(arg))
+		}
+	}
+
+	switch name {
+	case "seq":
+		format := "%d"
+		if len(args) > 0 {
+			format = strings.Join(args, ",")
+		}
+		if !strings.Contains(format, "%") {
+			return nil, fmt.Errorf("%s the format should contain %%d", expr)
+		}
+		return func(r *rand.Rand, seq int) interface{} {
+			if format == "%d" {
+				return seq
+			}
+			return fmt.Sprintf(format, seq)
+		}, nil
+
+	case "int":
+		min, max, err := intRange(args, 0, 100)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s", expr, err.Error())
+		}
+		return func(r *rand.Rand, seq int) interface{} { return min + r.Intn(max-min+1) }, nil
+
+	case "float":
+		min, max, err := floatRange(args, 0, 100)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s", expr, err.Error())
+		}
+		return func(r *rand.Rand, seq int) interface{} {
+			return math.Round((min+r.Float64()*(max-min))*100) / 100
+		}, nil
+
+	case "bool":
+		return func(r *rand.Rand, seq int) interface{} { return r.Intn(2) == 1 }, nil
+
+	case "pick":
+		if len(args) == 0 {
+			return nil, fmt.Errorf("%s the values to pick are required", expr)
+		}
+		return func(r *rand.Rand, seq int) interface{} { return args[r.Intn(len(args))] }, nil
+
+	case "first_name":
+		return func(r *rand.Rand, seq int) interface{} { return pick(r, firstNames) }, nil
+
+	case "last_name":
+		return func(r *rand.Rand, seq int) interface{} { return pick(r, lastNames) }, nil
+
+	case "name":
+		return func(r *rand.Rand, seq int) interface{} { return pick(r, firstNames) + " " + pick(r, lastNames) }, nil
+
+	case "username":
+		return func(r *rand.Rand, seq int) interface{} {
+			return fmt.Sprintf("%s%d", strings.ToLower(pick(r, firstNames)), seq)
+		}, nil
+
+	case "email":
+		domain := "example.com"
+		if len(args) > 0 {
+			domain = args[0]
+		}
+		// The seq keeps the emails unique
+		return func(r *rand.Rand, seq int) interface{} {
+			return fmt.Sprintf("%s.%s%d@%s", strings.ToLower(pick(r, firstNames)), strings.ToLower(pick(r, lastNames)), seq, domain)
+		}, nil
+
+	case "phone":
+		return func(r *rand.Rand, seq int) interface{} {
+			return fmt.Sprintf("+1-%03d-%03d-%04d", 200+r.Intn(800), r.Intn(1000), r.Intn(10000))
+		}, nil
+
+	case "company":
+		return func(r *rand.Rand, seq int) interface{} { return pick(r, companies) + " " + pick(r, companySuffixes) }, nil
+
+	case "city":
+		return func(r *rand.Rand, seq int) interface{} { return pick(r, cities) }, nil
+
+	case "word":
+		return func(r *rand.Rand, seq int) interface{} { return pick(r, words) }, nil
+
+	case "sentence":
+		n := 8
+		if len(args) > 0 {
+			var err error
+			if n, err = strconv.Atoi(args[0]); err != nil || n <= 0 {
+				return nil, fmt.Errorf("%s the number of the words is invalid", expr)
+			}
+		}
+		return func(r *rand.Rand, seq int) interface{} {
+			parts := make([]string, n)
+			for i := range parts {
+				parts[i] = pick(r, words)
+			}
+			sentence := strings.Join(parts, " ")
+			return strings.ToUpper(sentence[:1]) + sentence[1:] + "."
+		}, nil
+
+	case "uuid":
+		return func(r *rand.Rand, seq int) interface{} {
+			b := make([]byte, 16)
+			r.Read(b)
+			b[6] = (b[6] & 0x0f) | 0x40
+			b[8] = (b[8] & 0x3f) | 0x80
+			return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+		}, nil
+
+	case "date", "datetime":
+		from, to, err := timeRange(args)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s", expr, err.Error())
+		}
+		layout := "2006-01-02"
+		if name == "datetime" {
+			layout = "2006-01-02 15:04:05"
+		}
+		span := to.Unix() - from.Unix()
+		return func(r *rand.Rand, seq int) interface{} {
+			return time.Unix(from.Unix()+r.Int63n(span+1), 0).UTC().Format(layout)
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%s the faker %s is not supported", expr, name)
+}
+
+func pick(r *rand.Rand, values []string) string {
+	return values[r.Intn(len(values))]
+}
+
+func intRange(args []string, min, max int) (int, int, error) {
+	var err error
+	if len(args) > 0 {
+		if min, err = strconv.Atoi(args[0]); err != nil {
+			return 0, 0, err
+		}
+	}
+	if len(args) > 1 {
+		if max, err = strconv.Atoi(args[1]); err != nil {
+			return 0, 0, err
+		}
+	}
+	if max < min {
+		return 0, 0, fmt.Errorf("the max %d is less than the min %d", max, min)
+	}
+	return min, max, nil
+}
+
+func floatRange(args []string, min, max float64) (float64, float64, error) {
+	var err error
+	if len(args) > 0 {
+		if min, err = strconv.ParseFloat(args[0], 64); err != nil {
+			return 0, 0, err
+		}
+	}
+	if len(args) > 1 {
+		if max, err = strconv.ParseFloat(args[1], 64); err != nil {
+			return 0, 0, err
+		}
+	}
+	if max < min {
+		return 0, 0, fmt.Errorf("the max %v is less than the min %v", max, min)
+	}
+	return min, max, nil
+}
+
+// timeRange returns the range of the dates, 2020-01-01 to 2025-12-31 by default
+func timeRange(args []string) (time.Time, time.Time, error) {
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC)
+	var err error
+	if len(args) > 0 {
+		if from, err = time.Parse("2006-01-02", args[0]); err != nil {
+			return from, to, err
+		}
+	}
+	if len(args) > 1 {
+		if to, err = time.Parse("2006-01-02", args[1]); err != nil {
+			return from, to, err
+		}
+	}
+	if to.Before(from) {
+		return from, to, fmt.Errorf("the end %s is before the start %s", args[1], args[0])
+	}
+	return from, to, nil
+}
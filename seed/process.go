@@ -11,6 +11,9 @@ import (
 func init() {
 	process.RegisterGroup("seeds", map[string]process.Handler{
 		"import": processSeedImport,
+		"apply":  processSeedApply,
+		"reset":  processSeedReset,
+		"status": processSeedStatus,
 	})
 }
 
@@ -52,6 +55,55 @@ func processSeedImport(process *process.Process) interface{} {
 	return result
 }
 
+// processSeedApply applies the seed set, seeds.apply("dev", {"all": true})
+func processSeedApply(process *process.Process) interface{} {
+	name, option := setArgs(process)
+	results, err := Apply(name, option)
+	if err != nil {
+		exception.New(err.Error(), 500).Throw()
+	}
+	return results
+}
+
+// processSeedReset deletes the rows of the models of the seed set and applies it again, seeds.reset("test")
+func processSeedReset(process *process.Process) interface{} {
+	name, option := setArgs(process)
+	results, err := Reset(name, option)
+	if err != nil {
+		exception.New(err.Error(), 500).Throw()
+	}
+	return results
+}
+
+// processSeedStatus returns the status of the items of the seed set, seeds.status("dev")
+func processSeedStatus(process *process.Process) interface{} {
+	name, _ := setArgs(process)
+	statuses, err := Statuses(name)
+	if err != nil {
+		exception.New(err.Error(), 500).Throw()
+	}
+	return statuses
+}
+
+// setArgs returns the seed set name and the apply option of the process, the default set is dev
+func setArgs(process *process.Process) (string, ApplyOption) {
+	name := DefaultSet
+	if process.NumOfArgs() > 0 && process.ArgsString(0) != "" {
+		name = process.ArgsString(0)
+	}
+
+	option := ApplyOption{}
+	if process.NumOfArgs() > 1 {
+		switch val := process.Args[1].(type) {
+		case map[string]interface{}:
+			option.All, _ = val["all"].(bool)
+		case maps.MapStr:
+			option.All, _ = val.Get("all").(bool)
+		}
+	}
+	return name, option
+}
+
 // getOptions parses import options from interface
 func getOptions(v interface{}) (ImportOption, error) {
 	opts := ImportOption{
@@ -0,0 +1,93 @@
+package seed
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+	"github.com/yaoapp/gou/application"
+	"github.com/yaoapp/gou/fs"
+	"github.com/yaoapp/gou/model"
+)
+
+// records reads the rows of the seed file, the columns not in the model and the auto-generated columns are removed
+func records(filename string, mod *model.Model) ([]map[string]interface{}, error) {
+	data, err := fs.MustGet("seed").ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", filename, err)
+	}
+
+	rows := []map[string]interface{}{}
+	switch ext := strings.ToLower(filepath.Ext(filename)); ext {
+	case ".csv":
+		reader := csv.NewReader(bytes.NewReader(data))
+		reader.FieldsPerRecord = -1
+		lines, err := reader.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CSV: %v", err)
+		}
+		rows = table(lines, mod)
+
+	case ".xlsx", ".xls":
+		file, err := excelize.OpenReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open XLSX file: %v", err)
+		}
+		defer file.Close()
+
+		lines, err := file.GetRows(file.GetSheetName(file.GetActiveSheetIndex()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get rows: %v", err)
+		}
+		rows = table(lines, mod)
+
+	case ".json":
+		if err := json.Unmarshal(data, &rows); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON: %v", err)
+		}
+
+	case ".yao", ".jsonc":
+		if err := application.Parse(filename, data, &rows); err != nil {
+			return nil, fmt.Errorf("failed to parse Yao file: %v", err)
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported file format: %s", ext)
+	}
+
+	for _, row := range rows {
+		for name := range row {
+			if _, exists := mod.Columns[name]; !exists || isAutoGeneratedField(name, mod) {
+				delete(row, name)
+			}
+		}
+	}
+	return rows, nil
+}
+
+// table converts the lines of CSV/XLSX to rows, the first line is the header and the empty lines are skipped
+func table(lines [][]string, mod *model.Model) []map[string]interface{} {
+	rows := []map[string]interface{}{}
+	if len(lines) == 0 {
+		return rows
+	}
+
+	header := lines[0]
+	columnTypes := buildColumnTypeMap(mod, header)
+	for _, line := range lines[1:] {
+		if strings.TrimSpace(strings.Join(line, "")) == "" {
+			continue
+		}
+
+		row := map[string]interface{}{}
+		for i := 0; i < len(header) && i < len(line); i++ {
+			row[header[i]] = parseJSONField(line[i], columnTypes[i])
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
@@ -0,0 +1,236 @@
+package seed
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/yaoapp/gou/application"
+	"github.com/yaoapp/gou/fs"
+	"github.com/yaoapp/gou/model"
+)
+
+// Dir the directory of the seed sets and the seed files
+const Dir = "seeds"
+
+// DefaultSet the seed set applied if the name is not given
+const DefaultSet = "dev"
+
+var setExts = []string{".seed.yao", ".seed.jsonc", ".seed.json"}
+var setRe = regexp.MustCompile(`^[a-zA-Z0-9_\-]+$`)
+
+// LoadSet reads the seed set seeds/<name>.seed.yao
+func LoadSet(name string) (*Set, error) {
+	if !setRe.MatchString(name) {
+		return nil, fmt.Errorf("[Seed] the seed set name %s is invalid", name)
+	}
+
+	for _, ext := range setExts {
+		file := path.Join(Dir, name+ext)
+		exists, err := application.App.Exists(file)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+
+		data, err := application.App.Read(file)
+		if err != nil {
+			return nil, err
+		}
+
+		set := &Set{ID: name, File: file}
+		err = application.Parse(file, data, set)
+		if err != nil {
+			return nil, fmt.Errorf("[Seed] %s %s", file, err.Error())
+		}
+
+		err = set.validate()
+		if err != nil {
+			return nil, fmt.Errorf("[Seed] %s %s", file, err.Error())
+		}
+		return set, nil
+	}
+	return nil, fmt.Errorf("[Seed] %s/%s.seed.yao does not exist", Dir, name)
+}
+
+// Ordered returns the items of the set and the sets it extends, in the order to apply them
+func (set *Set) Ordered() ([]*Item, error) {
+	items := []*Item{}
+	err := set.collect(&items, map[string]bool{}, []string{})
+	if err != nil {
+		return nil, err
+	}
+	return order(items, dependencies())
+}
+
+// collect appends the items of the extended sets first, each set is collected once
+func (set *Set) collect(items *[]*Item, visited map[string]bool, stack []string) error {
+	for _, name := range stack {
+		if name == set.ID {
+			return fmt.Errorf("[Seed] the sets extend each other: %s -> %s", strings.Join(stack, " -> "), set.ID)
+		}
+	}
+	if visited[set.ID] {
+		return nil
+	}
+
+	stack = append(stack, set.ID)
+	for _, name := range set.Extends {
+		parent, err := LoadSet(name)
+		if err != nil {
+			return err
+		}
+		err = parent.collect(items, visited, stack)
+		if err != nil {
+			return err
+		}
+	}
+
+	visited[set.ID] = true
+	*items = append(*items, set.Items...)
+	return nil
+}
+
+func (set *Set) validate() error {
+	names := map[string]bool{}
+	for i, item := range set.Items {
+		if item.Model == "" {
+			return fmt.Errorf("items[%d] the model is required", i)
+		}
+		if (item.File == "") == (item.Generate == nil) {
+			return fmt.Errorf("items[%d] one of the file and the generate is required", i)
+		}
+
+		if item.Generate != nil {
+			if item.Generate.Count <= 0 {
+				return fmt.Errorf("items[%d] the count of the generate should be greater than 0", i)
+			}
+			if _, err := compile(item.Generate.Fields); err != nil {
+				return fmt.Errorf("items[%d] %s", i, err.Error())
+			}
+		}
+
+		for column, ref := range item.Refs {
+			if ref.Model == "" || ref.Key == "" {
+				return fmt.Errorf("items[%d] the model and the key of the ref %s are required", i, column)
+			}
+		}
+
+		if item.Name == "" {
+			item.Name = item.File
+			if item.Generate != nil {
+				item.Name = "generate:" + item.Model
+			}
+		}
+		if names[item.Name] {
+			return fmt.Errorf("items[%d] %s is declared twice, set the name of the item", i, item.Name)
+		}
+		names[item.Name] = true
+
+		if item.ChunkSize <= 0 {
+			item.ChunkSize = ChunkSizeDefault
+		}
+		item.set = set.ID
+	}
+	return nil
+}
+
+// checksum returns the checksum of the item and its file
+func (item *Item) checksum() (string, error) {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	h.Write(data)
+	if item.File != "" {
+		content, err := fs.MustGet("seed").ReadFile(item.File)
+		if err != nil {
+			return "", err
+		}
+		h.Write(content)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// order sorts the items, the items of the referenced models first.
+// The refs must not be circular; the relations of the models are ignored if they are circular.
+// The items keep the declared order if they do not depend on each other.
+func order(items []*Item, relations map[string][]string) ([]*Item, error) {
+	sorted := []*Item{}
+	done := make([]bool, len(items))
+
+	ready := func(i int, withRelations bool) bool {
+		deps := map[string]bool{}
+		for _, ref := range items[i].Refs {
+			deps[ref.Model] = true
+		}
+		if withRelations {
+			for _, dep := range relations[items[i].Model] {
+				deps[dep] = true
+			}
+		}
+
+		for j, other := range items {
+			if j != i && !done[j] && other.Model != items[i].Model && deps[other.Model] {
+				return false
+			}
+		}
+		return true
+	}
+
+	for len(sorted) < len(items) {
+		next := -1
+		for _, withRelations := range []bool{true, false} {
+			for i := range items {
+				if !done[i] && ready(i, withRelations) {
+					next = i
+					break
+				}
+			}
+			if next >= 0 {
+				break
+			}
+		}
+
+		if next < 0 {
+			circular := []string{}
+			for i, item := range items {
+				if !done[i] {
+					circular = append(circular, fmt.Sprintf("%s(%s)", item.Name, item.Model))
+				}
+			}
+			return nil, fmt.Errorf("[Seed] the refs of the items are circular: %s", strings.Join(circular, ", "))
+		}
+
+		sorted = append(sorted, items[next])
+		done[next] = true
+	}
+	return sorted, nil
+}
+
+// dependencies returns the models referenced by each model.
+// hasOne: the model references the related model; hasMany: the related model references the model.
+func dependencies() map[string][]string {
+	deps := map[string][]string{}
+	for id, mod := range model.Models {
+		for _, rel := range mod.MetaData.Relations {
+			if rel.Model == id {
+				continue
+			}
+			switch rel.Type {
+			case "hasOne":
+				deps[id] = append(deps[id], rel.Model)
+			case "hasMany":
+				deps[rel.Model] = append(deps[rel.Model], id)
+			}
+		}
+	}
+	return deps
+}
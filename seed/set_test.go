@@ -0,0 +1,113 @@
+package seed
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetValidate(t *testing.T) {
+	set := &Set{ID: "dev", Items: []*Item{
+		{Model: "category", File: "categories.csv", Keys: []string{"slug"}},
+		{Model: "user", Generate: &Generate{Count: 10, Fields: map[string]interface{}{"name": "$faker.name"}}},
+	}}
+	require.NoError(t, set.validate())
+	assert.Equal(t, "categories.csv", set.Items[0].Name)
+	assert.Equal(t, "generate:user", set.Items[1].Name)
+	assert.Equal(t, ChunkSizeDefault, set.Items[1].ChunkSize)
+	assert.Equal(t, "dev", set.Items[1].set)
+
+	invalid := map[string]*Item{
+		"one of the file and the generate": {Model: "user"},
+		"should be greater than 0":         {Model: "user", Generate: &Generate{}},
+		"is not supported":                 {Model: "user", Generate: &Generate{Count: 1, Fields: map[string]interface{}{"name": "$faker.unknown"}}},
+		"the model and the key of the ref": {Model: "pet", File: "pets.csv", Refs: map[string]Ref{"owner_id": {Model: "user"}}},
+	}
+	for message, item := range invalid {
+		assert.ErrorContains(t, (&Set{ID: "dev", Items: []*Item{item}}).validate(), message)
+	}
+
+	duplicate := &Set{ID: "dev", Items: []*Item{{Model: "user", File: "users.csv"}, {Model: "user", File: "users.csv"}}}
+	assert.ErrorContains(t, duplicate.validate(), "declared twice")
+}
+
+func TestOrder(t *testing.T) {
+	items := []*Item{
+		{Name: "order.item", Model: "order.item", Refs: map[string]Ref{"order_id": {Model: "order", Key: "sn"}}},
+		{Name: "order", Model: "order", Refs: map[string]Ref{"user_id": {Model: "user", Key: "email"}}},
+		{Name: "pet", Model: "pet"},
+		{Name: "user", Model: "user"},
+		{Name: "user.profile", Model: "user.profile"},
+	}
+
+	// user.profile hasOne user, pet and user reference each other
+	relations := map[string][]string{"user.profile": {"user"}, "pet": {"user"}, "user": {"pet"}}
+	sorted, err := order(items, relations)
+	require.NoError(t, err)
+
+	names := []string{}
+	for _, item := range sorted {
+		names = append(names, item.Name)
+	}
+	assert.Equal(t, []string{"pet", "user", "order", "order.item", "user.profile"}, names)
+
+	// The refs must not be circular
+	items[3].Refs = map[string]Ref{"last_order_id": {Model: "order.item", Key: "sn"}}
+	_, err = order(items, relations)
+	assert.ErrorContains(t, err, "circular")
+}
+
+func TestGenerate(t *testing.T) {
+	g := &Generate{Count: 5, Seed: 42, Fields: map[string]interface{}{
+		"id":         "$faker.seq",
+		"sn":         "$faker.seq(SN-%05d)",
+		"name":       "$faker.name",
+		"email":      "$faker.email(yaoapps.com)",
+		"age":        "$faker.int(18, 60)",
+		"price":      "$faker.float(1, 10)",
+		"status":     "$faker.pick(enabled, disabled)",
+		"uuid":       "$faker.uuid",
+		"born":       "$faker.date(1990-01-01, 2000-12-31)",
+		"bio":        "$faker.sentence(4)",
+		"country":    "CN",
+		"categories": []interface{}{1, 2},
+	}}
+
+	rows, err := generate(g, 0, 5)
+	require.NoError(t, err)
+	require.Len(t, rows, 5)
+
+	again, err := generate(g, 0, 5)
+	require.NoError(t, err)
+	assert.Equal(t, rows, again)
+
+	for i, row := range rows {
+		assert.Equal(t, i+1, row["id"])
+		assert.Regexp(t, regexp.MustCompile(`^SN-0000\d$`), row["sn"])
+		assert.Regexp(t, regexp.MustCompile(`^[a-z]+\.[a-z]+\d+@yaoapps\.com$`), row["email"])
+		assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), row["uuid"])
+		assert.Contains(t, []interface{}{"enabled", "disabled"}, row["status"])
+		assert.GreaterOrEqual(t, row["age"], 18)
+		assert.LessOrEqual(t, row["age"], 60)
+		assert.GreaterOrEqual(t, row["price"], 1.0)
+		assert.LessOrEqual(t, row["price"], 10.0)
+		assert.GreaterOrEqual(t, row["born"], "1990-01-01")
+		assert.LessOrEqual(t, row["born"], "2000-12-31")
+		assert.Regexp(t, regexp.MustCompile(`^[A-Z][a-z]+( [a-z]+){3}\.$`), row["bio"])
+		assert.Equal(t, "CN", row["country"])
+		assert.Equal(t, []interface{}{1, 2}, row["categories"])
+	}
+
+	// The next chunk continues the seq
+	next, err := generate(g, 5, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 6, next[0]["id"])
+	assert.Equal(t, "SN-00007", next[1]["sn"])
+
+	for _, expr := range []string{"$faker.int(9, 1)", "$faker.pick()", "$faker.seq(SN)", "$faker.date(2020-13-01)", "$faker.Name"} {
+		_, err := parseFaker(expr)
+		assert.Error(t, err, expr)
+	}
+}
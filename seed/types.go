@@ -1,5 +1,7 @@
 package seed
 
+import "time"
+
 // DuplicateMode the duplicate mode
 type DuplicateMode string
 
@@ -54,3 +56,74 @@ type ImportError struct {
 	Code    int           `json:"code,omitempty"`
 	Data    []interface{} `json:"data,omitempty"`
 }
+
+// Set the seed set seeds/<name>.seed.yao, the seed data of an environment (dev, test, demo ...)
+type Set struct {
+	ID          string   `json:"-"`
+	File        string   `json:"-"`
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Extends     []string `json:"extends,omitempty"` // the sets applied before the items of the set
+	Items       []*Item  `json:"items"`
+}
+
+// Item the rows of a model, read from a file of the seeds directory or generated by the faker
+type Item struct {
+	Name      string         `json:"name,omitempty"` // the file name or generate:<model> by default
+	Model     string         `json:"model"`
+	File      string         `json:"file,omitempty"`
+	Generate  *Generate      `json:"generate,omitempty"`
+	Keys      []string       `json:"keys,omitempty"` // the natural keys to find the existing rows
+	Refs      map[string]Ref `json:"refs,omitempty"` // the columns referencing the rows of the other models
+	ChunkSize int            `json:"chunk_size,omitempty"`
+	set       string
+}
+
+// Ref the column holds the natural key of a row of the model, it is replaced by the primary key
+type Ref struct {
+	Model string `json:"model"`
+	Key   string `json:"key"`
+}
+
+// Generate generates the rows with the faker, the same seed generates the same rows
+type Generate struct {
+	Count  int                    `json:"count"`
+	Seed   int64                  `json:"seed,omitempty"`
+	Fields map[string]interface{} `json:"fields"`
+}
+
+// ApplyOption the seed apply option
+type ApplyOption struct {
+	All      bool                        // apply the items unchanged since they were applied too
+	Progress func(item string, rows int) // called after each chunk
+}
+
+// ItemResult the result of applying an item
+type ItemResult struct {
+	Set     string        `json:"set"`
+	Item    string        `json:"item"`
+	Model   string        `json:"model"`
+	Skipped bool          `json:"skipped,omitempty"` // unchanged since it was applied
+	Result  *ImportResult `json:"result,omitempty"`
+}
+
+// Record an applied item, saved in the history table
+type Record struct {
+	Set       string    `json:"seed"`
+	Item      string    `json:"item"`
+	Model     string    `json:"model"`
+	Checksum  string    `json:"checksum"`
+	Rows      int       `json:"rows"`
+	AppliedAt time.Time `json:"applied_at"`
+}
+
+// Status the status of an item
+type Status struct {
+	Set       string     `json:"set"`
+	Item      string     `json:"item"`
+	Model     string     `json:"model"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+	Rows      int        `json:"rows,omitempty"`
+	Changed   bool       `json:"changed,omitempty"` // the item or its file is changed after it was applied
+}
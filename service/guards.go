@@ -9,7 +9,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/yaoapp/yao/helper"
 	"github.com/yaoapp/yao/openapi/oauth"
+	"github.com/yaoapp/yao/ratelimit"
 	"github.com/yaoapp/yao/utils"
+	"github.com/yaoapp/yao/webhook"
 
 	"github.com/yaoapp/yao/widgets/chart"
 	"github.com/yaoapp/yao/widgets/dashboard"
@@ -50,6 +52,21 @@ func OpenAPIGuards() map[string]gin.HandlerFunc {
 	}
 }
 
+// httpGuards returns the guards of the HTTP APIs with the rate-limit and webhook-signature guards of the loaded policies
+func httpGuards(guards map[string]gin.HandlerFunc) map[string]gin.HandlerFunc {
+	merged := map[string]gin.HandlerFunc{}
+	for name, guard := range guards {
+		merged[name] = guard
+	}
+	for name, guard := range ratelimit.Guards() {
+		merged[name] = guard
+	}
+	for name, guard := range webhook.Guards() {
+		merged[name] = guard
+	}
+	return merged
+}
+
 // guardCookieTrace set sid cookie
 func guardCookieTrace(c *gin.Context) {
 	sid, err := c.Cookie("sid")
@@ -79,6 +96,11 @@ func guardCookieJWT(c *gin.Context) {
 	}
 
 	claims := helper.JwtValidate(tokenString)
+	if !claims.IsSession() {
+		c.JSON(403, gin.H{"code": 403, "message": "Not Authorized"})
+		c.Abort()
+		return
+	}
 	c.Set("__sid", claims.SID)
 }
 
@@ -93,6 +115,11 @@ func guardBearerJWT(c *gin.Context) {
 	}
 
 	claims := helper.JwtValidate(tokenString)
+	if !claims.IsSession() {
+		c.JSON(403, gin.H{"code": 403, "message": "Not Authorized"})
+		c.Abort()
+		return
+	}
 	c.Set("__sid", claims.SID)
 }
 
@@ -106,6 +133,11 @@ func guardQueryJWT(c *gin.Context) {
 	}
 
 	claims := helper.JwtValidate(tokenString)
+	if !claims.IsSession() {
+		c.JSON(403, gin.H{"code": 403, "message": "Not Authorized"})
+		c.Abort()
+		return
+	}
 	c.Set("__sid", claims.SID)
 }
 
@@ -11,6 +11,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/yaoapp/kun/log"
 	"github.com/yaoapp/yao/openapi"
+	"github.com/yaoapp/yao/ratelimit"
 	"github.com/yaoapp/yao/service/fs"
 	"github.com/yaoapp/yao/share"
 	"github.com/yaoapp/yao/sui/api"
@@ -19,6 +20,7 @@ import (
 // Middlewares the middlewares
 var Middlewares = []gin.HandlerFunc{
 	gin.Logger(),
+	ratelimit.Middleware,
 	withStaticFileServer,
 }
 
@@ -12,6 +12,8 @@ import (
 	"github.com/yaoapp/yao/openapi"
 	"github.com/yaoapp/yao/service/fs"
 	"github.com/yaoapp/yao/share"
+	"github.com/yaoapp/yao/websocket"
+	"github.com/yaoapp/yao/widgets/table"
 )
 
 // Start the yao service
@@ -34,7 +36,7 @@ func Start(cfg config.Config) (*http.Server, error) {
 	if openapi.Server != nil {
 		// OpenAPI mode: use OAuth guards and dynamic routing
 		apiRoot = openapi.Server.Config.BaseURL
-		api.SetGuards(OpenAPIGuards())
+		api.SetGuards(httpGuards(OpenAPIGuards()))
 
 		// Developer APIs: use dynamic proxy (supports hot-reload)
 		router.Any(apiRoot+"/api/*path", DynamicAPIHandler)
@@ -50,9 +52,16 @@ func Start(cfg config.Config) (*http.Server, error) {
 	} else {
 		// Traditional mode: unchanged
 		apiRoot = "/api"
-		api.SetGuards(Guards)
+		api.SetGuards(httpGuards(Guards))
 		api.SetRoutes(router, "/api", cfg.AllowFrom...)
 	}
+
+	// WebSocket servers
+	websocket.SetRoutes(router)
+
+	// Table export downloads, streamed from the attachment managers
+	table.SetRoutes(router, apiRoot)
+
 	router.NoRoute(func(c *gin.Context) {
 		staticDir := fs.Dir("public") // 获取 Yao 文件系统实例
 		files := []string{"/404.html", "/notFound.html"}
@@ -94,20 +103,28 @@ func Restart(srv *http.Server, cfg config.Config) error {
 	router := gin.New()
 	router.Use(Middlewares...)
 
+	apiRoot := "/api"
 	if openapi.Server != nil {
 		// OpenAPI mode
 		baseURL := openapi.Server.Config.BaseURL
-		api.SetGuards(OpenAPIGuards())
+		apiRoot = baseURL
+		api.SetGuards(httpGuards(OpenAPIGuards()))
 		router.Any(baseURL+"/api/*path", DynamicAPIHandler)
 		api.SetRoutes(router, baseURL, cfg.AllowFrom...)
 		api.BuildRouteTable()
 		openapi.Server.Attach(router)
 	} else {
 		// Traditional mode: unchanged
-		api.SetGuards(Guards)
+		api.SetGuards(httpGuards(Guards))
 		api.SetRoutes(router, "/api", cfg.AllowFrom...)
 	}
 
+	// WebSocket servers
+	websocket.SetRoutes(router)
+
+	// Table export downloads, streamed from the attachment managers
+	table.SetRoutes(router, apiRoot)
+
 	srv.Reset(router)
 	return srv.Restart()
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/yaoapp/gou/application"
 	"github.com/yaoapp/gou/server/http"
+	"github.com/yaoapp/yao/cluster"
 	"github.com/yaoapp/yao/config"
 	"github.com/yaoapp/yao/engine"
 	"github.com/yaoapp/yao/openapi"
@@ -24,40 +25,70 @@ func Watch(srv *http.Server, interrupt chan uint8) (err error) {
 			return
 		}
 
-		// Reload
-		err = engine.Reload(config.Conf, engine.LoadOption{Action: "watch"})
+		err = reload(srv, "Watch", name)
 		if err != nil {
-			fmt.Println(color.RedString("[Watch] Reload: %s", err.Error()))
 			return
 		}
-		fmt.Println(color.GreenString("[Watch] Reload Completed"))
 
+		// Let the other instances of the cluster reload the same files
+		cluster.ReloadApp(name)
+
+	}, interrupt)
+}
+
+// Coordinate reload the application when the files are changed on another instance of the cluster
+func Coordinate(srv *http.Server) {
+	cluster.Handle(cluster.KindApp, func(event *cluster.Event) error {
+		return reload(srv, "Cluster", event.IDs...)
+	})
+}
+
+// reload the application engine, then reload the APIs or restart the server if the APIs are changed
+func reload(srv *http.Server, prefix string, names ...string) error {
+
+	// Reload
+	err := engine.Reload(config.Conf, engine.LoadOption{Action: "watch"})
+	if err != nil {
+		fmt.Println(color.RedString("[%s] Reload: %s", prefix, err.Error()))
+		return err
+	}
+	fmt.Println(color.GreenString("[%s] Reload Completed", prefix))
+
+	apis := false
+	for _, name := range names {
 		uname := strings.ReplaceAll(name, "\\", "/")
 		// Model
 		if strings.HasPrefix(uname, "/models") {
-			fmt.Println(color.GreenString("[Watch] Model: %s changed (Please run yao migrate manually)", name))
+			fmt.Println(color.GreenString("[%s] Model: %s changed (Please run yao migrate manually)", prefix, name))
 		}
 
-		// API changes: hot reload or restart
 		if strings.HasPrefix(name, "/apis") {
-			if openapi.Server != nil {
-				// OpenAPI mode: hot reload (no server restart needed)
-				err = ReloadAPIs()
-				if err != nil {
-					fmt.Println(color.RedString("[Watch] Reload APIs: %s", err.Error()))
-					return
-				}
-				fmt.Println(color.GreenString("[Watch] APIs Reloaded"))
-			} else {
-				// Traditional mode: restart server
-				err = Restart(srv, config.Conf)
-				if err != nil {
-					fmt.Println(color.RedString("[Watch] Restart: %s", err.Error()))
-					return
-				}
-				fmt.Println(color.GreenString("[Watch] Restart Completed"))
-			}
+			apis = true
 		}
+	}
 
-	}, interrupt)
+	// API changes: hot reload or restart
+	if !apis {
+		return nil
+	}
+
+	if openapi.Server != nil {
+		// OpenAPI mode: hot reload (no server restart needed)
+		err = ReloadAPIs()
+		if err != nil {
+			fmt.Println(color.RedString("[%s] Reload APIs: %s", prefix, err.Error()))
+			return err
+		}
+		fmt.Println(color.GreenString("[%s] APIs Reloaded", prefix))
+		return nil
+	}
+
+	// Traditional mode: restart server
+	err = Restart(srv, config.Conf)
+	if err != nil {
+		fmt.Println(color.RedString("[%s] Restart: %s", prefix, err.Error()))
+		return err
+	}
+	fmt.Println(color.GreenString("[%s] Restart Completed", prefix))
+	return nil
 }
@@ -44,6 +44,11 @@ func guardBearerJWT(r *Request) error {
 	}
 
 	claims := helper.JwtValidate(tokenString)
+	if !claims.IsSession() {
+		c.JSON(401, gin.H{"code": 401, "message": "Not authenticated"})
+		c.Abort()
+		return fmt.Errorf("Not authenticated")
+	}
 	c.Set("__sid", claims.SID)
 	r.Sid = claims.SID
 	return nil
@@ -70,6 +75,9 @@ func guardCookieJWT(r *Request) error {
 	}
 
 	claims := helper.JwtValidate(tokenString)
+	if !claims.IsSession() {
+		return fmt.Errorf("Not authenticated")
+	}
 	c.Set("__sid", claims.SID)
 	r.Sid = claims.SID
 	return nil
@@ -138,6 +146,11 @@ func guardQueryJWT(r *Request) error {
 	}
 
 	claims := helper.JwtValidate(tokenString)
+	if !claims.IsSession() {
+		c.JSON(401, gin.H{"code": 401, "message": "Not authenticated"})
+		c.Abort()
+		return fmt.Errorf("Not authenticated")
+	}
 	c.Set("__sid", claims.SID)
 	r.Sid = claims.SID
 	return nil
@@ -13,6 +13,7 @@ import (
 	"github.com/yaoapp/gou/process"
 	"github.com/yaoapp/gou/types"
 	"github.com/yaoapp/kun/exception"
+	"github.com/yaoapp/yao/cluster"
 	"github.com/yaoapp/yao/sui/core"
 )
 
@@ -975,6 +976,7 @@ func BuildAll(process *process.Process) interface{} {
 	if err != nil {
 		exception.New(err.Error(), 500).Throw()
 	}
+	cluster.Invalidate(core.CacheName)
 
 	if warnings != nil && len(warnings) > 0 {
 		return warnings
@@ -1019,6 +1021,7 @@ func BuildPage(process *process.Process) interface{} {
 	if err != nil {
 		exception.New(err.Error(), 500).Throw()
 	}
+	cluster.Invalidate(core.CacheName)
 	if warnings != nil && len(warnings) > 0 {
 		return warnings
 	}
@@ -0,0 +1,659 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/yaoapp/gou/application"
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/kun/any"
+	"github.com/yaoapp/yao/sui/core"
+)
+
+// staticManifest the manifest file of the static output, used for the incremental rebuilds
+const staticManifest = ".sui-static.json"
+
+// StaticOption the static pre-rendering option
+type StaticOption struct {
+	Output  string   // the output directory
+	BaseURL string   // the base url of the sitemap and hreflang links, e.g. https://example.com
+	Locales []string // the locales to render, default is all locales of the template
+	Full    bool     // render all pages, ignore the manifest of the last build
+}
+
+// StaticResult the static pre-rendering result
+type StaticResult struct {
+	Rendered int      `json:"rendered"`
+	Skipped  int      `json:"skipped"`
+	Removed  int      `json:"removed"`
+	Assets   int      `json:"assets"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// StaticPage a rendered page of the static output
+type StaticPage struct {
+	Route   string            `json:"route"`
+	Locale  string            `json:"locale,omitempty"`
+	Path    string            `json:"path"`
+	File    string            `json:"file"`
+	Params  map[string]string `json:"params,omitempty"`
+	Lastmod string            `json:"lastmod,omitempty"`
+	Hash    string            `json:"hash"`
+	group   string            // the pages of the same route and params in different locales
+}
+
+type staticBuilder struct {
+	sui     core.SUI
+	tmpl    core.ITemplate
+	option  *StaticOption
+	root    string // the public root
+	sid     string
+	locales []core.SelectOption
+	assets  map[string]string // the asset url => the hashed asset url
+	pages   map[string]*StaticPage
+	last    map[string]*StaticPage
+	stale   map[string]string // the assets of the last build
+	result  *StaticResult
+}
+
+// BuildStatic render the pages of the template to plain HTML files, should be called after the template is built.
+// Dynamic [param] routes are enumerated by the static.process of the page config, each locale is rendered to /<root>/<locale>/...
+// Only the pages whose compiled file, locale messages or route data changed since the last build are rendered again.
+func BuildStatic(sui core.SUI, tmpl core.ITemplate, option *StaticOption) (*StaticResult, error) {
+
+	if option == nil || option.Output == "" {
+		return nil, fmt.Errorf("the output directory is required")
+	}
+
+	root, err := sui.PublicRootWithSid(sui.GetSid())
+	if err != nil {
+		return nil, err
+	}
+
+	builder := &staticBuilder{
+		sui:     sui,
+		tmpl:    tmpl,
+		option:  option,
+		root:    "/" + strings.Trim(root, "/"),
+		sid:     sui.GetSid(),
+		locales: staticLocales(tmpl.Locales(), option.Locales),
+		assets:  map[string]string{},
+		pages:   map[string]*StaticPage{},
+		last:    map[string]*StaticPage{},
+		stale:   map[string]string{},
+		result:  &StaticResult{Warnings: []string{}},
+	}
+
+	if builder.root == "/" {
+		builder.root = ""
+	}
+
+	err = os.MkdirAll(option.Output, os.ModePerm)
+	if err != nil {
+		return nil, err
+	}
+
+	if !option.Full {
+		builder.readManifest()
+	}
+
+	err = builder.copyAssets()
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := tmpl.Pages()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, page := range pages {
+		err = builder.renderPage(page.Get().Route)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	builder.removeStale()
+
+	err = builder.writeSitemap()
+	if err != nil {
+		return nil, err
+	}
+
+	return builder.result, builder.writeManifest()
+}
+
+// renderPage render the page in all locales and all params of the dynamic route
+func (builder *staticBuilder) renderPage(route string) error {
+	source, err := application.App.Read(builder.publicFile(route, ".sui"))
+	if err != nil {
+		builder.warn("%s is not built, skipped. %s", route, err.Error())
+		return nil
+	}
+
+	// The config of the built page
+	cfg, _ := getPageConfig(builder.publicFile(route, ""), true)
+	if cfg != nil && cfg.Static != nil && cfg.Static.Exclude {
+		return nil
+	}
+
+	if cfg != nil && cfg.Guard != "" {
+		builder.warn("%s is guarded by %s, skipped", route, cfg.Guard)
+		return nil
+	}
+
+	items, err := builder.routeItems(route, cfg)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		path, err := staticPath(route, item.params)
+		if err != nil {
+			builder.warn("%s %s", route, err.Error())
+			continue
+		}
+
+		for _, locale := range builder.locales {
+			urlPath := builder.root + localePrefix(locale) + path
+			messages, _ := application.App.Read(builder.localeFile(locale, route))
+			hash := staticHash(source, messages, item.raw, []byte(builder.assetsHash()))
+
+			static := &StaticPage{
+				Route:   route,
+				Locale:  locale.Value,
+				Path:    urlPath,
+				File:    staticFile(urlPath),
+				Params:  item.params,
+				Lastmod: item.lastmod,
+				Hash:    hash,
+				group:   builder.root + path,
+			}
+			builder.pages[urlPath] = static
+
+			if last, has := builder.last[urlPath]; has && last.Hash == hash {
+				if _, err := os.Stat(filepath.Join(builder.option.Output, static.File)); err == nil {
+					builder.result.Skipped++
+					continue
+				}
+			}
+
+			html, err := builder.render(route, static, locale)
+			if err != nil {
+				builder.warn("%s %s", urlPath, err.Error())
+				delete(builder.pages, urlPath)
+				continue
+			}
+
+			err = builder.write(static.File, []byte(html))
+			if err != nil {
+				return err
+			}
+			builder.result.Rendered++
+		}
+	}
+
+	return nil
+}
+
+// render the page with a mock request, the guard is not applied
+func (builder *staticBuilder) render(route string, page *StaticPage, locale core.SelectOption) (string, error) {
+	headers := url.Values{}
+	if locale.Value != "" {
+		headers.Set("Cookie", fmt.Sprintf("locale=%s", locale.Value))
+	}
+
+	link := builder.link(page.Path)
+	u, err := url.Parse(link)
+	if err != nil {
+		return "", err
+	}
+
+	scheme := u.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+
+	r := &Request{
+		File: builder.publicFile(route, ".sui"),
+		Request: &core.Request{
+			Sid:     builder.sid,
+			Method:  "GET",
+			Query:   url.Values{},
+			Headers: headers,
+			Params:  page.Params,
+			URL: core.ReqeustURL{
+				URL:    link,
+				Host:   u.Host,
+				Path:   page.Path,
+				Domain: u.Hostname(),
+				Scheme: scheme,
+			},
+		},
+	}
+
+	html, status, err := r.Render()
+	if err != nil {
+		return "", err
+	}
+
+	if status != 200 {
+		return "", fmt.Errorf("render status %d", status)
+	}
+
+	html = builder.rewriteAssets(html)
+	return builder.hreflang(html, page), nil
+}
+
+// staticRouteItem the params of a dynamic route
+type staticRouteItem struct {
+	params  map[string]string
+	lastmod string
+	raw     []byte
+}
+
+// routeItems the params of the route, dynamic routes are enumerated by the static.process of the page config
+func (builder *staticBuilder) routeItems(route string, cfg *core.PageConfig) ([]staticRouteItem, error) {
+	if !strings.Contains(route, "[") {
+		return []staticRouteItem{{}}, nil
+	}
+
+	if cfg == nil || cfg.Static == nil || cfg.Static.Process == "" {
+		builder.warn("%s is a dynamic route without static.process, skipped", route)
+		return []staticRouteItem{}, nil
+	}
+
+	p, err := process.Of(cfg.Static.Process, cfg.Static.Args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s static.process %s", route, err.Error())
+	}
+
+	res, err := p.WithSID(builder.sid).Exec()
+	if err != nil {
+		return nil, fmt.Errorf("%s static.process %s", route, err.Error())
+	}
+
+	values, ok := res.([]interface{})
+	if !ok {
+		raw, err := jsoniter.Marshal(res)
+		if err == nil {
+			err = jsoniter.Unmarshal(raw, &values)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s static.process should return an array", route)
+		}
+	}
+
+	items := []staticRouteItem{}
+	for _, value := range values {
+		data, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		item := staticRouteItem{params: map[string]string{}}
+		item.raw, _ = jsoniter.Marshal(data)
+
+		params := data
+		if v, has := data["params"].(map[string]interface{}); has {
+			params = v
+			if lastmod, has := data["lastmod"]; has && lastmod != nil {
+				item.lastmod = fmt.Sprintf("%v", lastmod)
+			}
+		}
+
+		for key, v := range params {
+			item.params[key] = any.Of(v).CString()
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// copyAssets copy the built assets to the output with the content hash in the file names
+// The css files are copied last, the assets referenced in them are rewritten
+func (builder *staticBuilder) copyAssets() error {
+	src := filepath.Join(application.App.Root(), "public", builder.root, "assets")
+	if _, err := os.Stat(src); err != nil {
+		return nil
+	}
+
+	files := []string{}
+	err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		files = append(files, path)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		return filepath.Ext(files[i]) != ".css" && filepath.Ext(files[j]) == ".css"
+	})
+
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		if filepath.Ext(file) == ".css" {
+			content = []byte(builder.rewriteAssets(string(content)))
+		}
+
+		rel, err := filepath.Rel(src, file)
+		if err != nil {
+			return err
+		}
+
+		asset := builder.root + "/assets/" + filepath.ToSlash(rel)
+		hashed := hashedName(asset, content)
+		builder.assets[asset] = hashed
+
+		target := filepath.Join(builder.option.Output, filepath.FromSlash(hashed))
+		if _, err := os.Stat(target); err == nil {
+			continue
+		}
+
+		err = builder.write(filepath.FromSlash(hashed), content)
+		if err != nil {
+			return err
+		}
+		builder.result.Assets++
+	}
+	return nil
+}
+
+// rewriteAssets replace the asset urls with the hashed asset urls
+func (builder *staticBuilder) rewriteAssets(content string) string {
+	if len(builder.assets) == 0 {
+		return content
+	}
+
+	re := regexp.MustCompile(regexp.QuoteMeta(builder.root+"/assets/") + `[^"'()\s?#]+`)
+	return re.ReplaceAllStringFunc(content, func(asset string) string {
+		if hashed, has := builder.assets[asset]; has {
+			return hashed
+		}
+		return asset
+	})
+}
+
+// hreflang add the alternate links of the other locales to the head
+func (builder *staticBuilder) hreflang(html string, page *StaticPage) string {
+	if len(builder.locales) < 2 {
+		return html
+	}
+
+	links := []string{}
+	for _, locale := range builder.locales {
+		path := builder.localePath(page.group, locale)
+		links = append(links, fmt.Sprintf(`<link rel="alternate" hreflang="%s" href="%s" />`, locale.Value, builder.link(path)))
+		if locale.Default {
+			links = append(links, fmt.Sprintf(`<link rel="alternate" hreflang="x-default" href="%s" />`, builder.link(path)))
+		}
+	}
+
+	head := strings.Join(links, "\n") + "\n"
+	if pos := strings.Index(html, "</head>"); pos >= 0 {
+		return html[:pos] + head + html[pos:]
+	}
+	return head + html
+}
+
+// writeSitemap write the sitemap.xml of all rendered pages, with the hreflang alternates
+func (builder *staticBuilder) writeSitemap() error {
+	type link struct {
+		Rel      string `xml:"rel,attr"`
+		Hreflang string `xml:"hreflang,attr"`
+		Href     string `xml:"href,attr"`
+	}
+
+	type entry struct {
+		Loc     string `xml:"loc"`
+		Lastmod string `xml:"lastmod,omitempty"`
+		Links   []link `xml:"xhtml:link"`
+	}
+
+	type urlset struct {
+		XMLName xml.Name `xml:"urlset"`
+		Xmlns   string   `xml:"xmlns,attr"`
+		Xhtml   string   `xml:"xmlns:xhtml,attr"`
+		URLs    []entry  `xml:"url"`
+	}
+
+	paths := []string{}
+	for path := range builder.pages {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	sitemap := urlset{
+		Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9",
+		Xhtml: "http://www.w3.org/1999/xhtml",
+		URLs:  []entry{},
+	}
+
+	for _, path := range paths {
+		page := builder.pages[path]
+		item := entry{Loc: builder.link(path), Lastmod: page.Lastmod}
+		if len(builder.locales) > 1 {
+			for _, locale := range builder.locales {
+				alternate := builder.localePath(page.group, locale)
+				if _, has := builder.pages[alternate]; has {
+					item.Links = append(item.Links, link{Rel: "alternate", Hreflang: locale.Value, Href: builder.link(alternate)})
+				}
+			}
+		}
+		sitemap.URLs = append(sitemap.URLs, item)
+	}
+
+	content, err := xml.MarshalIndent(sitemap, "", "  ")
+	if err != nil {
+		return err
+	}
+	return builder.write(filepath.Join(builder.root, "sitemap.xml"), append([]byte(xml.Header), content...))
+}
+
+// removeStale remove the pages of the last build that no longer exist (e.g. the data is deleted),
+// and the hashed assets of the last build that are replaced
+func (builder *staticBuilder) removeStale() {
+	for path, page := range builder.last {
+		if _, has := builder.pages[path]; has {
+			continue
+		}
+		err := os.Remove(filepath.Join(builder.option.Output, page.File))
+		if err == nil {
+			builder.result.Removed++
+		}
+	}
+
+	current := map[string]bool{}
+	for _, hashed := range builder.assets {
+		current[hashed] = true
+	}
+
+	for _, hashed := range builder.stale {
+		if current[hashed] || !strings.HasPrefix(hashed, builder.root+"/assets/") || strings.Contains(hashed, "..") {
+			continue
+		}
+		err := os.Remove(filepath.Join(builder.option.Output, filepath.FromSlash(hashed)))
+		if err == nil {
+			builder.result.Removed++
+		}
+	}
+}
+
+func (builder *staticBuilder) readManifest() {
+	content, err := os.ReadFile(filepath.Join(builder.option.Output, staticManifest))
+	if err != nil {
+		return
+	}
+
+	var manifest struct {
+		Pages  map[string]*StaticPage `json:"pages"`
+		Assets map[string]string      `json:"assets"`
+	}
+	if err := jsoniter.Unmarshal(content, &manifest); err != nil {
+		return
+	}
+	if manifest.Pages != nil {
+		builder.last = manifest.Pages
+	}
+	if manifest.Assets != nil {
+		builder.stale = manifest.Assets
+	}
+}
+
+func (builder *staticBuilder) writeManifest() error {
+	content, err := jsoniter.MarshalIndent(map[string]interface{}{
+		"updated_at": time.Now().Format(time.RFC3339),
+		"pages":      builder.pages,
+		"assets":     builder.assets,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return builder.write(staticManifest, content)
+}
+
+func (builder *staticBuilder) write(file string, content []byte) error {
+	target := filepath.Join(builder.option.Output, file)
+	err := os.MkdirAll(filepath.Dir(target), os.ModePerm)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(target, content, 0644)
+}
+
+func (builder *staticBuilder) warn(format string, args ...interface{}) {
+	builder.result.Warnings = append(builder.result.Warnings, fmt.Sprintf(format, args...))
+}
+
+// assetsHash the pages should be rendered again when the assets changed
+func (builder *staticBuilder) assetsHash() string {
+	keys := []string{}
+	for _, hashed := range builder.assets {
+		keys = append(keys, hashed)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+func (builder *staticBuilder) publicFile(route string, ext string) string {
+	return filepath.Join("/", "public", builder.root, route) + ext
+}
+
+func (builder *staticBuilder) localeFile(locale core.SelectOption, route string) string {
+	return filepath.Join("/", "public", builder.root, ".locales", locale.Value, fmt.Sprintf("%s.yml", route))
+}
+
+// localePath the path of the page in the locale, group is the path without the locale prefix
+func (builder *staticBuilder) localePath(group string, locale core.SelectOption) string {
+	return builder.root + localePrefix(locale) + strings.TrimPrefix(group, builder.root)
+}
+
+func (builder *staticBuilder) link(path string) string {
+	return strings.TrimSuffix(builder.option.BaseURL, "/") + staticURL(path)
+}
+
+// staticLocales the locales to render, the default locale is rendered without the prefix
+func staticLocales(defined []core.SelectOption, only []string) []core.SelectOption {
+	selected := map[string]bool{}
+	for _, locale := range only {
+		selected[strings.ToLower(locale)] = true
+	}
+
+	locales := []core.SelectOption{}
+	hasDefault := false
+	for _, locale := range defined {
+		if len(selected) > 0 && !selected[strings.ToLower(locale.Value)] && !locale.Default {
+			continue
+		}
+		hasDefault = hasDefault || locale.Default
+		locales = append(locales, locale)
+	}
+
+	if !hasDefault {
+		locales = append([]core.SelectOption{{Default: true}}, locales...)
+	}
+	return locales
+}
+
+func localePrefix(locale core.SelectOption) string {
+	if locale.Default || locale.Value == "" {
+		return ""
+	}
+	return "/" + strings.ToLower(locale.Value)
+}
+
+// staticPath replace the [param] of the route with the params
+func staticPath(route string, params map[string]string) (string, error) {
+	var missing error
+	path := reRouteVar.ReplaceAllStringFunc(route, func(match string) string {
+		name := strings.Trim(match, "[]")
+		value, has := params[name]
+		if !has || value == "" {
+			missing = fmt.Errorf("the param %s is missing", name)
+			return match
+		}
+
+		return url.PathEscape(value)
+	})
+	if missing != nil {
+		return path, missing
+	}
+
+	// The empty and dot segments would write the page out of its directory
+	for _, segment := range strings.Split(strings.TrimPrefix(path, "/"), "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return path, fmt.Errorf("the path %s of the route %s is invalid", path, route)
+		}
+	}
+	return path, nil
+}
+
+// staticURL the url of the page, the index page is served as the directory
+func staticURL(path string) string {
+	if path == "/index" || strings.HasSuffix(path, "/index") {
+		return strings.TrimSuffix(path, "index")
+	}
+	return path + "/"
+}
+
+// staticFile the html file of the page, the pages are written as <path>/index.html
+func staticFile(path string) string {
+	return filepath.FromSlash(staticURL(path) + "index.html")
+}
+
+// hashedName add the content hash to the file name, e.g. /assets/js/app.js => /assets/js/app.1a2b3c4d.js
+func hashedName(name string, content []byte) string {
+	sum := sha256.Sum256(content)
+	ext := filepath.Ext(name)
+	return fmt.Sprintf("%s.%s%s", strings.TrimSuffix(name, ext), hex.EncodeToString(sum[:4]), ext)
+}
+
+func staticHash(parts ...[]byte) string {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write(part)
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/yao/sui/core"
+)
+
+func TestStaticPath(t *testing.T) {
+	path, err := staticPath("/blog/[id]/[slug]", map[string]string{"id": "1", "slug": "hello world"})
+	assert.Nil(t, err)
+	assert.Equal(t, "/blog/1/hello%20world", path)
+
+	_, err = staticPath("/blog/[id]", map[string]string{})
+	assert.NotNil(t, err)
+
+	for _, value := range []string{".", ".."} {
+		_, err = staticPath("/blog/[id]", map[string]string{"id": value})
+		assert.NotNil(t, err)
+	}
+	_, err = staticPath("/blog//[id]", map[string]string{"id": "1"})
+	assert.NotNil(t, err)
+
+	assert.Equal(t, "/demo/", staticURL("/demo/index"))
+	assert.Equal(t, "/demo/about/", staticURL("/demo/about"))
+	assert.Equal(t, filepath.FromSlash("/demo/index.html"), staticFile("/demo/index"))
+	assert.Equal(t, filepath.FromSlash("/demo/about/index.html"), staticFile("/demo/about"))
+}
+
+func TestStaticLocales(t *testing.T) {
+	defined := []core.SelectOption{
+		{Label: "English", Value: "en-us", Default: true},
+		{Label: "简体中文", Value: "zh-CN"},
+		{Label: "日本語", Value: "ja-jp"},
+	}
+
+	locales := staticLocales(defined, []string{"zh-cn"})
+	assert.Len(t, locales, 2)
+	assert.Equal(t, "", localePrefix(locales[0]))
+	assert.Equal(t, "/zh-cn", localePrefix(locales[1]))
+
+	// The default locale is added when the template has no default locale
+	locales = staticLocales(defined[1:], nil)
+	assert.Len(t, locales, 3)
+	assert.True(t, locales[0].Default)
+}
+
+func TestStaticAssetsAndSitemap(t *testing.T) {
+	output := t.TempDir()
+	builder := &staticBuilder{
+		option:  &StaticOption{Output: output, BaseURL: "https://example.com/"},
+		root:    "/demo",
+		locales: staticLocales([]core.SelectOption{{Value: "en-us", Default: true}, {Value: "zh-cn"}}, nil),
+		assets:  map[string]string{},
+		pages:   map[string]*StaticPage{},
+		result:  &StaticResult{},
+	}
+
+	hashed := hashedName("/demo/assets/js/app.js", []byte("console.log(1)"))
+	assert.Regexp(t, `^/demo/assets/js/app\.[0-9a-f]{8}\.js$`, hashed)
+	builder.assets["/demo/assets/js/app.js"] = hashed
+
+	html := `<html><head><script src="/demo/assets/js/app.js?v=1"></script></head><body><img src="/demo/assets/logo.png"></body></html>`
+	html = builder.rewriteAssets(html)
+	assert.Contains(t, html, `src="`+hashed+`?v=1"`)
+	assert.Contains(t, html, `src="/demo/assets/logo.png"`)
+
+	page := &StaticPage{Path: "/demo/zh-cn/blog/1", group: "/demo/blog/1"}
+	html = builder.hreflang(html, page)
+	assert.Contains(t, html, `<link rel="alternate" hreflang="zh-cn" href="https://example.com/demo/zh-cn/blog/1/" />`)
+	assert.Contains(t, html, `<link rel="alternate" hreflang="x-default" href="https://example.com/demo/blog/1/" />`)
+
+	builder.pages["/demo/blog/1"] = &StaticPage{Path: "/demo/blog/1", Lastmod: "2024-05-01", group: "/demo/blog/1"}
+	builder.pages["/demo/zh-cn/blog/1"] = page
+	err := builder.writeSitemap()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sitemap, err := os.ReadFile(filepath.Join(output, "demo", "sitemap.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Contains(t, string(sitemap), `<loc>https://example.com/demo/blog/1/</loc>`)
+	assert.Contains(t, string(sitemap), `<lastmod>2024-05-01</lastmod>`)
+	assert.Contains(t, string(sitemap), `<xhtml:link rel="alternate" hreflang="zh-cn" href="https://example.com/demo/zh-cn/blog/1/"></xhtml:link>`)
+	assert.Contains(t, string(sitemap), `xmlns:xhtml="http://www.w3.org/1999/xhtml"`)
+}
+
+func TestStaticRemoveStale(t *testing.T) {
+	output := t.TempDir()
+	builder := &staticBuilder{
+		option: &StaticOption{Output: output},
+		pages:  map[string]*StaticPage{"/blog/1": {File: staticFile("/blog/1")}},
+		last: map[string]*StaticPage{
+			"/blog/1": {File: staticFile("/blog/1")},
+			"/blog/2": {File: staticFile("/blog/2")},
+		},
+		result: &StaticResult{},
+	}
+
+	assert.Nil(t, builder.write(staticFile("/blog/1"), []byte("1")))
+	assert.Nil(t, builder.write(staticFile("/blog/2"), []byte("2")))
+	builder.removeStale()
+
+	assert.Equal(t, 1, builder.result.Removed)
+	_, err := os.Stat(filepath.Join(output, staticFile("/blog/2")))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(output, staticFile("/blog/1")))
+	assert.Nil(t, err)
+}
+
+func TestStaticRemoveStaleAssets(t *testing.T) {
+	output := t.TempDir()
+	builder := &staticBuilder{
+		option: &StaticOption{Output: output},
+		root:   "/demo",
+		assets: map[string]string{"/demo/assets/app.js": "/demo/assets/app.22222222.js"},
+		stale: map[string]string{
+			"/demo/assets/app.js":   "/demo/assets/app.11111111.js",
+			"/demo/assets/logo.png": "/demo/assets/../../outside.png",
+		},
+		result: &StaticResult{},
+	}
+
+	assert.Nil(t, builder.write(filepath.FromSlash("/demo/assets/app.11111111.js"), []byte("1")))
+	assert.Nil(t, builder.write(filepath.FromSlash("/demo/assets/app.22222222.js"), []byte("2")))
+	builder.removeStale()
+
+	assert.Equal(t, 1, builder.result.Removed)
+	_, err := os.Stat(filepath.Join(output, "demo", "assets", "app.11111111.js"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(output, "demo", "assets", "app.22222222.js"))
+	assert.Nil(t, err)
+}
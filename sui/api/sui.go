@@ -7,12 +7,14 @@ import (
 
 	"github.com/yaoapp/gou/application"
 	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/cluster"
 	"github.com/yaoapp/yao/config"
 	"github.com/yaoapp/yao/share"
 	"github.com/yaoapp/yao/sui/core"
 	"github.com/yaoapp/yao/sui/storages/agent"
 	"github.com/yaoapp/yao/sui/storages/azure"
 	"github.com/yaoapp/yao/sui/storages/local"
+	"github.com/yaoapp/yao/sui/storages/object"
 )
 
 // New create a new sui
@@ -30,6 +32,9 @@ func New(dsl *core.DSL) (core.SUI, error) {
 	case "azure":
 		return azure.New(dsl)
 
+	case "s3", "database":
+		return object.New(dsl)
+
 	case "agent":
 		return agent.New(dsl)
 
@@ -72,6 +77,9 @@ func Load(cfg config.Config) error {
 		log.Warn("[sui] Failed to load agent SUI: %s", err.Error())
 	}
 
+	// Clean the page caches when the pages are built on the other instances of the cluster
+	cluster.RegisterCache(core.CacheName, core.Invalidate)
+
 	buildRouteMatchers()
 	return registerAPI()
 }
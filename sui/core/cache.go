@@ -35,6 +35,9 @@ type cacheData struct {
 	cmd   uint8
 }
 
+// CacheName the name of the page caches in the cluster events
+const CacheName = "sui"
+
 // Caches the caches
 var Caches = map[string]*Cache{}
 var ch = make(chan *cacheData, 1)
@@ -81,6 +84,18 @@ func CleanCache() {
 	Caches = map[string]*Cache{}
 }
 
+// Invalidate removes the caches of the built files, empty files clean all the caches
+func Invalidate(files []string) error {
+	if len(files) == 0 {
+		CleanCache()
+		return nil
+	}
+	for _, file := range files {
+		RemoveCache(file)
+	}
+	return nil
+}
+
 // GetHTML get the html
 func (c *Cache) GetHTML(hash string) (string, bool) {
 
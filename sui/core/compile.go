@@ -27,6 +27,9 @@ func (page *Page) Compile(ctx *BuildContext, option *BuildOption) (string, strin
 		return "", "", warnings, fmt.Errorf("Page build error: %s", err.Error())
 	}
 
+	// Responsive images
+	warnings = append(warnings, page.buildImages(doc, option)...)
+
 	if warnings != nil && len(warnings) > 0 {
 		for _, warning := range warnings {
 			log.Warn("Compile page %s/%s/%s: %s", page.SuiID, page.TemplateID, page.Route, warning)
@@ -135,6 +138,9 @@ func (page *Page) CompileAsComponent(ctx *BuildContext, option *BuildOption) (st
 		return "", warnings, err
 	}
 
+	// Responsive images
+	warnings = append(warnings, page.buildImages(doc, &opt)...)
+
 	if warnings != nil && len(warnings) > 0 {
 		for _, warning := range warnings {
 			log.Warn("Compile page %s/%s/%s: %s", page.SuiID, page.TemplateID, page.Route, warning)
@@ -0,0 +1,451 @@
+package core
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/image/draw"
+
+	// Register the decoders of the source images
+	_ "golang.org/x/image/bmp"
+	_ "golang.org/x/image/webp"
+)
+
+// DefaultImageWidths the default srcset widths of the responsive images
+var DefaultImageWidths = []uint{320, 640, 960, 1280, 1920}
+
+// DefaultImageQuality the default quality of the image derivatives
+var DefaultImageQuality = 80
+
+// DefaultImageSizes the default sizes attribute of the responsive images
+var DefaultImageSizes = "100vw"
+
+// ImageEncoder encode the image, the quality is 1-100
+type ImageEncoder func(w io.Writer, img image.Image, quality int) error
+
+type imageFormat struct {
+	mimeType string
+	ext      string
+	encoder  ImageEncoder
+}
+
+var imageFormats = map[string]*imageFormat{
+	"jpeg": {mimeType: "image/jpeg", ext: "jpg", encoder: func(w io.Writer, img image.Image, quality int) error {
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
+	}},
+	"png": {mimeType: "image/png", ext: "png", encoder: func(w io.Writer, img image.Image, quality int) error {
+		return png.Encode(w, img)
+	}},
+	"gif": {mimeType: "image/gif", ext: "gif", encoder: func(w io.Writer, img image.Image, quality int) error {
+		return gif.Encode(w, img, nil)
+	}},
+}
+var imageFormatsMutex sync.RWMutex
+
+// ImageOption the option of the image derivative
+type ImageOption struct {
+	Width   uint   // 0 keeps the aspect ratio
+	Height  uint   // 0 keeps the aspect ratio
+	Format  string // jpeg, png, gif, webp, avif... empty for the format of the source
+	Quality int    // 1-100, DefaultImageQuality by default
+}
+
+// RegisterImageEncoder register the encoder of an output format, e.g. webp or avif.
+// The formats without an encoder are skipped when building the responsive images.
+func RegisterImageEncoder(format string, mimeType string, ext string, encoder ImageEncoder) {
+	imageFormatsMutex.Lock()
+	defer imageFormatsMutex.Unlock()
+	imageFormats[strings.ToLower(format)] = &imageFormat{mimeType: mimeType, ext: ext, encoder: encoder}
+}
+
+// ImageFormatAvailable check if the output format has an encoder
+func ImageFormatAvailable(format string) bool {
+	return getImageFormat(format) != nil
+}
+
+// ImageMimeType returns the mime type of the output format
+func ImageMimeType(format string) string {
+	if f := getImageFormat(format); f != nil {
+		return f.mimeType
+	}
+	return "application/octet-stream"
+}
+
+// ImageExt returns the file extension of the output format
+func ImageExt(format string) string {
+	if f := getImageFormat(format); f != nil {
+		return f.ext
+	}
+	return strings.ToLower(format)
+}
+
+// ImageFallbackFormat returns the output format of the source format when no format is given
+func ImageFallbackFormat(format string) string {
+	switch format {
+	case "jpeg", "png", "gif":
+		return format
+	}
+	if ImageFormatAvailable(format) {
+		return format
+	}
+	return "png"
+}
+
+// ImageHash returns the hash of the source image, the derivatives are cached by it
+func ImageHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// DecodeImage decode the source image and apply the EXIF orientation
+func DecodeImage(data []byte) (image.Image, string, error) {
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", err
+	}
+
+	if format == "jpeg" {
+		img = orientImage(img, exifOrientation(data))
+	}
+	return img, format, nil
+}
+
+// EncodeImage resize and encode the image, the metadata of the source (EXIF, ICC ...) is not kept
+func EncodeImage(img image.Image, option ImageOption) (*Asset, error) {
+
+	format := getImageFormat(option.Format)
+	if format == nil {
+		return nil, fmt.Errorf("image format %s is not supported", option.Format)
+	}
+
+	quality := option.Quality
+	if quality <= 0 || quality > 100 {
+		quality = DefaultImageQuality
+	}
+
+	img = resizeImage(img, option.Width, option.Height)
+
+	var buf bytes.Buffer
+	err := format.encoder(&buf, img, quality)
+	if err != nil {
+		return nil, err
+	}
+	return &Asset{Type: format.mimeType, Content: buf.Bytes()}, nil
+}
+
+// ProcessImage decode, resize and encode the source image
+func ProcessImage(data []byte, option ImageOption) (*Asset, error) {
+	img, format, err := DecodeImage(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if option.Format == "" {
+		option.Format = ImageFallbackFormat(format)
+	}
+	return EncodeImage(img, option)
+}
+
+// ImageSize returns the size of the source image after applying the EXIF orientation
+func ImageSize(data []byte) (uint, uint, string, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, "", err
+	}
+
+	width, height := uint(config.Width), uint(config.Height)
+	if format == "jpeg" && exifOrientation(data) >= 5 {
+		width, height = height, width
+	}
+	return width, height, format, nil
+}
+
+// ImageWidths returns the srcset widths of the source, the widths larger than the source are replaced by the source width
+func (setting *TemplateImages) ImageWidths(width uint) []uint {
+	widths := append([]uint{}, setting.Widths...)
+	if len(widths) == 0 {
+		widths = append(widths, DefaultImageWidths...)
+	}
+	sort.Slice(widths, func(i, j int) bool { return widths[i] < widths[j] })
+
+	res := []uint{}
+	for _, w := range widths {
+		if w >= width {
+			res = append(res, width)
+			break
+		}
+		if len(res) == 0 || res[len(res)-1] != w {
+			res = append(res, w)
+		}
+	}
+	return res
+}
+
+// ImageQuality returns the quality of the derivatives
+func (setting *TemplateImages) ImageQuality() int {
+	if setting.Quality <= 0 || setting.Quality > 100 {
+		return DefaultImageQuality
+	}
+	return setting.Quality
+}
+
+// ImageSizes returns the default sizes attribute
+func (setting *TemplateImages) ImageSizes() string {
+	if setting.Sizes == "" {
+		return DefaultImageSizes
+	}
+	return setting.Sizes
+}
+
+// ImageFormats returns the available modern formats, the fallback format is excluded
+func (setting *TemplateImages) ImageFormats(fallback string) []string {
+	formats := []string{}
+	for _, format := range setting.Formats {
+		format = strings.ToLower(format)
+		if format == "jpg" {
+			format = "jpeg"
+		}
+		if format == fallback || !ImageFormatAvailable(format) {
+			continue
+		}
+		formats = append(formats, format)
+	}
+	return formats
+}
+
+// buildImages add the srcset and the sizes to the <img> of the assets
+func (page *Page) buildImages(doc *goquery.Document, option *BuildOption) []string {
+	warnings := []string{}
+	if option.ImageBuilder == nil {
+		return warnings
+	}
+
+	prefix := option.AssetRoot + "/"
+	doc.Find("img[src]").Each(func(i int, sel *goquery.Selection) {
+		responsive, has := sel.Attr("s:responsive")
+		if has {
+			sel.RemoveAttr("s:responsive")
+			if responsive == "false" {
+				return
+			}
+		}
+
+		src, _ := sel.Attr("src")
+		if !strings.HasPrefix(src, prefix) || strings.Contains(src, "{{") {
+			return
+		}
+
+		if _, has := sel.Attr("srcset"); has || goquery.NodeName(sel.Parent()) == "picture" {
+			return
+		}
+
+		file := strings.TrimPrefix(src, option.AssetRoot)
+		switch strings.ToLower(file[strings.LastIndex(file, ".")+1:]) {
+		case "jpg", "jpeg", "png", "webp", "bmp":
+		default:
+			return
+		}
+
+		set, err := option.ImageBuilder.ImageSet(file)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("Image %s: %s", file, err.Error()))
+			return
+		}
+
+		sizes, has := sel.Attr("sizes")
+		if !has {
+			sizes = set.Sizes
+			sel.SetAttr("sizes", sizes)
+		}
+		sel.SetAttr("src", set.Src)
+		sel.SetAttr("srcset", set.Srcset)
+
+		if len(set.Sources) == 0 {
+			return
+		}
+
+		sources := ""
+		for _, source := range set.Sources {
+			sources += fmt.Sprintf(`<source type="%s" srcset="%s" sizes="%s">`, source.Type, source.Srcset, sizes)
+		}
+		sel.WrapHtml("<picture></picture>")
+		sel.BeforeHtml(sources)
+	})
+
+	return warnings
+}
+
+func getImageFormat(format string) *imageFormat {
+	format = strings.ToLower(format)
+	if format == "jpg" {
+		format = "jpeg"
+	}
+	imageFormatsMutex.RLock()
+	defer imageFormatsMutex.RUnlock()
+	return imageFormats[format]
+}
+
+// resizeImage resize the image, keeps the aspect ratio if the width or the height is 0
+func resizeImage(img image.Image, width, height uint) image.Image {
+	bounds := img.Bounds()
+	if width == 0 && height == 0 {
+		return toNRGBA(img)
+	}
+
+	if width == 0 {
+		width = uint(float64(bounds.Dx())*float64(height)/float64(bounds.Dy()) + 0.5)
+	} else if height == 0 {
+		height = uint(float64(bounds.Dy())*float64(width)/float64(bounds.Dx()) + 0.5)
+	}
+
+	if width == 0 {
+		width = 1
+	}
+	if height == 0 {
+		height = 1
+	}
+
+	if int(width) == bounds.Dx() && int(height) == bounds.Dy() {
+		return toNRGBA(img)
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, int(width), int(height)))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+	return dst
+}
+
+// toNRGBA copy the pixels to a new image, drops the metadata of the source
+func toNRGBA(img image.Image) *image.NRGBA {
+	bounds := img.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, bounds.Min, draw.Src)
+	return dst
+}
+
+// orientImage rotate or flip the image by the EXIF orientation (1-8)
+func orientImage(img image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // flip horizontal
+				dx, dy = w-1-x, y
+			case 3: // rotate 180
+				dx, dy = w-1-x, h-1-y
+			case 4: // flip vertical
+				dx, dy = x, h-1-y
+			case 5: // transpose
+				dx, dy = y, x
+			case 6: // rotate 90 clockwise
+				dx, dy = h-1-y, x
+			case 7: // transverse
+				dx, dy = h-1-y, w-1-x
+			case 8: // rotate 270 clockwise
+				dx, dy = y, w-1-x
+			}
+			dst.Set(dx, dy, color.NRGBAModel.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)))
+		}
+	}
+	return dst
+}
+
+// exifOrientation returns the orientation tag of the JPEG EXIF, 1 if not found
+func exifOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	offset := 2
+	for offset+4 <= len(data) {
+		if data[offset] != 0xFF {
+			return 1
+		}
+
+		marker := data[offset+1]
+		if marker == 0xD8 || (marker >= 0xD0 && marker <= 0xD7) || marker == 0x01 {
+			offset += 2
+			continue
+		}
+
+		// Start of scan, no more metadata
+		if marker == 0xDA || marker == 0xD9 {
+			return 1
+		}
+
+		size := int(binary.BigEndian.Uint16(data[offset+2:]))
+		if size < 2 || offset+2+size > len(data) {
+			return 1
+		}
+
+		segment := data[offset+4 : offset+2+size]
+		if marker == 0xE1 && len(segment) > 6 && string(segment[:6]) == "Exif\x00\x00" {
+			return tiffOrientation(segment[6:])
+		}
+		offset += 2 + size
+	}
+	return 1
+}
+
+// tiffOrientation read the orientation (0x0112) from the IFD0 of the TIFF header
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+
+	count := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < count; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			orientation := int(order.Uint16(tiff[entry+8:]))
+			if orientation < 1 || orientation > 8 {
+				return 1
+			}
+			return orientation
+		}
+	}
+	return 1
+}
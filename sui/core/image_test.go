@@ -0,0 +1,150 @@
+package core
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testImageBuilder struct{ files []string }
+
+func (builder *testImageBuilder) ImageSet(file string) (*ImageSet, error) {
+	builder.files = append(builder.files, file)
+	return &ImageSet{
+		Src:     "/assets/__images/abc/640w-q80.jpg",
+		Srcset:  "/assets/__images/abc/320w-q80.jpg 320w, /assets/__images/abc/640w-q80.jpg 640w",
+		Sizes:   "100vw",
+		Sources: []ImageSource{{Type: "image/webp", Srcset: "/assets/__images/abc/320w-q80.webp 320w"}},
+	}, nil
+}
+
+func TestImageOrientation(t *testing.T) {
+	// 40x20, the left half is red
+	img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 40; x++ {
+			c := color.RGBA{0, 0, 255, 255}
+			if x < 20 {
+				c = color.RGBA{255, 0, 0, 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+
+	var buf bytes.Buffer
+	jpeg.Encode(&buf, img, &jpeg.Options{Quality: 100})
+	data := withOrientation(buf.Bytes(), 6)
+	assert.Equal(t, 6, exifOrientation(data))
+	assert.Equal(t, 1, exifOrientation(buf.Bytes()))
+
+	width, height, format, err := ImageSize(data)
+	assert.Nil(t, err)
+	assert.Equal(t, "jpeg", format)
+	assert.Equal(t, uint(20), width)
+	assert.Equal(t, uint(40), height)
+
+	// Rotated 90 clockwise, the red half is on the top
+	asset, err := ProcessImage(data, ImageOption{Width: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, "image/jpeg", asset.Type)
+	assert.NotContains(t, string(asset.Content), "Exif")
+
+	thumb, _, err := image.Decode(bytes.NewReader(asset.Content))
+	assert.Nil(t, err)
+	assert.Equal(t, 10, thumb.Bounds().Dx())
+	assert.Equal(t, 20, thumb.Bounds().Dy())
+	r, _, b, _ := thumb.At(5, 2).RGBA()
+	assert.Greater(t, r, b)
+	r, _, b, _ = thumb.At(5, 17).RGBA()
+	assert.Greater(t, b, r)
+}
+
+func TestImageFormats(t *testing.T) {
+	assert.True(t, ImageFormatAvailable("jpg"))
+	assert.False(t, ImageFormatAvailable("avif"))
+	assert.Equal(t, "png", ImageFallbackFormat("bmp"))
+	assert.Equal(t, "jpeg", ImageFallbackFormat("jpeg"))
+
+	setting := &TemplateImages{Formats: []string{"avif", "webp", "jpg"}}
+	assert.Equal(t, []string{}, setting.ImageFormats("jpeg"))
+	assert.Equal(t, []string{"jpeg"}, setting.ImageFormats("png"))
+
+	RegisterImageEncoder("webp", "image/webp", "webp", imageFormats["png"].encoder)
+	defer func() {
+		imageFormatsMutex.Lock()
+		delete(imageFormats, "webp")
+		imageFormatsMutex.Unlock()
+	}()
+	assert.Equal(t, []string{"webp"}, setting.ImageFormats("jpeg"))
+	assert.Equal(t, "image/webp", ImageMimeType("webp"))
+}
+
+func TestImageWidths(t *testing.T) {
+	setting := &TemplateImages{}
+	assert.Equal(t, []uint{320, 640, 800}, setting.ImageWidths(800))
+	assert.Equal(t, []uint{200}, setting.ImageWidths(200))
+	assert.Equal(t, DefaultImageWidths, setting.ImageWidths(4000))
+	assert.Equal(t, 80, setting.ImageQuality())
+	assert.Equal(t, "100vw", setting.ImageSizes())
+
+	setting = &TemplateImages{Widths: []uint{800, 400}, Quality: 60, Sizes: "50vw"}
+	assert.Equal(t, []uint{400, 640}, setting.ImageWidths(640))
+	assert.Equal(t, 60, setting.ImageQuality())
+	assert.Equal(t, "50vw", setting.ImageSizes())
+}
+
+func TestBuildImages(t *testing.T) {
+	doc, err := NewDocumentString(`<html><body>
+		<img src="/assets/images/a.jpg" alt="a">
+		<img src="/assets/images/b.png" sizes="50vw">
+		<img src="/assets/images/c.jpg" s:responsive="false">
+		<img src="/assets/images/logo.svg">
+		<img src="/assets/images/{{ name }}.jpg">
+		<img src="https://example.com/d.jpg">
+		<picture><img src="/assets/images/e.jpg"></picture>
+	</body></html>`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	page := &Page{}
+	builder := &testImageBuilder{}
+	warnings := page.buildImages(doc, &BuildOption{AssetRoot: "/assets", ImageBuilder: builder})
+	assert.Empty(t, warnings)
+	assert.Equal(t, []string{"/images/a.jpg", "/images/b.png"}, builder.files)
+
+	html, err := doc.Html()
+	assert.Nil(t, err)
+	assert.Contains(t, html, `<picture><source type="image/webp" srcset="/assets/__images/abc/320w-q80.webp 320w" sizes="100vw"/><img src="/assets/__images/abc/640w-q80.jpg" alt="a" sizes="100vw" srcset=`)
+	assert.Contains(t, html, `sizes="50vw"/><img src="/assets/__images/abc/640w-q80.jpg" sizes="50vw"`)
+	assert.Contains(t, html, `<img src="/assets/images/c.jpg"/>`)
+	assert.Contains(t, html, `<img src="/assets/images/logo.svg"/>`)
+	assert.Contains(t, html, `<picture><img src="/assets/images/e.jpg"/></picture>`)
+	assert.Equal(t, 3, strings.Count(html, "<picture>"))
+}
+
+// withOrientation insert an EXIF APP1 segment with the orientation after the SOI marker
+func withOrientation(data []byte, orientation int) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3)
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, uint16(orientation))
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1}
+	app1 = binary.BigEndian.AppendUint16(app1, uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	res := append([]byte{}, data[:2]...)
+	res = append(res, app1...)
+	return append(res, data[2:]...)
+}
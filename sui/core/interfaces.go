@@ -73,6 +73,11 @@ type ITemplate interface {
 	GlobRoutes(patterns []string, unique ...bool) ([]string, error)
 }
 
+// IImageBuilder is the interface for generating the responsive image derivatives at build time
+type IImageBuilder interface {
+	ImageSet(file string) (*ImageSet, error) // file is the path under the __assets
+}
+
 // IPage is the interface for the page
 type IPage interface {
 	Load() error
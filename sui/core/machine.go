@@ -0,0 +1,311 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/yaoapp/yao/openai"
+)
+
+// machineBatchSize the default number of messages per request
+const machineBatchSize = 50
+
+// machineTimeout the timeout of a translation request
+const machineTimeout = 120 * time.Second
+
+// machinePrompt the system prompt of the translation request
+const machinePrompt = `You translate the user interface messages of a web application.
+The user message is a JSON object with:
+- "source", "target": the source and the target locale
+- "style": the style guidance for the target locale (optional)
+- "glossary": terms and their required translations (optional)
+- "messages": the messages to translate, id -> text
+
+Placeholders like ⟦0⟧ stand for markup, expressions or variables. Keep every placeholder exactly once, you may move it to fit the grammar.
+Always use the glossary translation of a term. Keep the tone and the punctuation style of a user interface.
+
+Reply with JSON only, no markdown: {"<id>": "<translation>"}`
+
+// rePlaceholder matches the parts of a message that must not be translated:
+// expressions, html tags, entities, format verbs and named variables
+var rePlaceholder = regexp.MustCompile(`\{\{.*?\}\}|</?[a-zA-Z][^<>]*>|&#?[0-9a-zA-Z]+;|%[-+#0-9.]*[sdvfqxXtTbeEgGcop%]|\{[0-9a-zA-Z_.]+\}|\$[a-zA-Z_][0-9a-zA-Z_.]*`)
+var reMark = regexp.MustCompile(`⟦[0-9]+⟧`)
+
+// MachineChat sends the messages to the connector and returns the reply content
+var MachineChat = openai.Chat
+
+// MachineOption is the option of the machine translation
+type MachineOption struct {
+	Connector string
+	Source    string
+	Target    string
+	Glossary  map[string]string
+	Style     string
+	BatchSize int
+}
+
+// MachineOption returns the machine translation option of the target locale
+func (setting *TemplateTranslation) MachineOption(source, target string) *MachineOption {
+	option := &MachineOption{
+		Connector: setting.Connector,
+		Source:    source,
+		Target:    target,
+		Glossary:  map[string]string{},
+		BatchSize: setting.BatchSize,
+	}
+
+	// The locale glossary overrides the common one
+	for _, name := range []string{"*", target} {
+		for lc, terms := range setting.Glossary {
+			if !strings.EqualFold(lc, name) {
+				continue
+			}
+			for term, trans := range terms {
+				option.Glossary[term] = trans
+			}
+		}
+	}
+
+	for lc, style := range setting.Styles {
+		if strings.EqualFold(lc, target) {
+			option.Style = style
+		}
+	}
+	return option
+}
+
+// MachineTranslate fills the missing translations of the locale with the connector.
+// Only the keys that are new or whose source text changed since the last run are translated,
+// the messages translated by hand are kept. The machine translations are listed in Review
+// until they are edited or removed from the list.
+func (locale *Locale) MachineTranslate(translations []Translation, prefix string, option *MachineOption) []string {
+	warnings := []string{}
+	if option == nil || option.Connector == "" {
+		return warnings
+	}
+
+	if locale.Keys == nil {
+		locale.Keys = map[string]string{}
+	}
+	if locale.Messages == nil {
+		locale.Messages = map[string]string{}
+	}
+	if locale.Sources == nil {
+		locale.Sources = map[string]string{}
+	}
+	if locale.Review == nil {
+		locale.Review = map[string]string{}
+	}
+
+	var reg *regexp.Regexp = nil
+	if prefix != "" {
+		reg = regexp.MustCompile(fmt.Sprintf(`^%s_([0-9]+)$`, prefix))
+	}
+
+	// The reviewed messages were edited or removed from the list
+	for message, machine := range locale.Review {
+		if trans, has := locale.Messages[message]; !has || trans != machine {
+			delete(locale.Review, message)
+		}
+	}
+
+	pending := []string{}
+	keys := map[string][]string{}
+	current := map[string]bool{}
+	for _, t := range translations {
+		if reg != nil && !reg.MatchString(t.Key) {
+			continue
+		}
+
+		current[t.Key] = true
+		if source, has := locale.Sources[t.Key]; has && source == t.Message {
+			continue
+		}
+
+		// Translated already (by hand or for another key)
+		if trans, has := locale.Messages[t.Message]; has && trans != t.Message {
+			locale.Sources[t.Key] = t.Message
+			continue
+		}
+
+		if _, has := keys[t.Message]; !has {
+			pending = append(pending, t.Message)
+		}
+		keys[t.Message] = append(keys[t.Message], t.Key)
+	}
+
+	// Remove the keys of the deleted messages
+	for key := range locale.Sources {
+		if (reg == nil || reg.MatchString(key)) && !current[key] {
+			delete(locale.Sources, key)
+		}
+	}
+
+	size := option.BatchSize
+	if size <= 0 {
+		size = machineBatchSize
+	}
+
+	for start := 0; start < len(pending); start += size {
+		end := start + size
+		if end > len(pending) {
+			end = len(pending)
+		}
+
+		results, messages, err := option.translate(pending[start:end])
+		warnings = append(warnings, messages...)
+		if err != nil {
+			// Keep the finished batches, the rest is translated in the next run
+			warnings = append(warnings, fmt.Sprintf("Machine translation %s (%s): %s", option.Target, option.Connector, err.Error()))
+			break
+		}
+
+		for _, message := range pending[start:end] {
+			trans, has := results[message]
+			if !has {
+				continue
+			}
+
+			locale.Messages[message] = trans
+			locale.Review[message] = trans
+			if _, has := locale.ScriptMessages[message]; has {
+				locale.ScriptMessages[message] = trans
+			}
+			for _, key := range keys[message] {
+				locale.Keys[key] = trans
+				locale.Sources[key] = message
+			}
+		}
+	}
+
+	return warnings
+}
+
+// translate translates a batch of messages, returns the translations by the source text
+func (option *MachineOption) translate(batch []string) (map[string]string, []string, error) {
+	results := map[string]string{}
+	warnings := []string{}
+	tokens := map[string][]string{}
+	sources := map[string]string{}
+	messages := map[string]string{}
+	glossary := map[string]string{}
+
+	for i, message := range batch {
+		masked, placeholders := maskPlaceholders(strings.TrimSpace(message))
+
+		// Nothing to translate
+		if strings.TrimSpace(reMark.ReplaceAllString(masked, "")) == "" {
+			results[message] = message
+			continue
+		}
+
+		id := strconv.Itoa(i)
+		messages[id] = masked
+		tokens[id] = placeholders
+		sources[id] = message
+
+		for term, trans := range option.Glossary {
+			if strings.Contains(strings.ToLower(message), strings.ToLower(term)) {
+				glossary[term] = trans
+			}
+		}
+	}
+
+	if len(messages) == 0 {
+		return results, warnings, nil
+	}
+
+	input := map[string]interface{}{"source": option.Source, "target": option.Target, "messages": messages}
+	if option.Style != "" {
+		input["style"] = option.Style
+	}
+	if len(glossary) > 0 {
+		input["glossary"] = glossary
+	}
+
+	content, err := jsoniter.MarshalToString(input)
+	if err != nil {
+		return nil, warnings, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), machineTimeout)
+	defer cancel()
+
+	reply, err := MachineChat(ctx, option.Connector, []map[string]interface{}{
+		{"role": "system", "content": machinePrompt},
+		{"role": "user", "content": content},
+	})
+	if err != nil {
+		return nil, warnings, err
+	}
+
+	replies := map[string]string{}
+	err = jsoniter.UnmarshalFromString(openai.TrimFence(reply), &replies)
+	if err != nil {
+		return nil, warnings, fmt.Errorf("invalid reply %s", err.Error())
+	}
+
+	for id, source := range sources {
+		trans, has := replies[id]
+		if !has || strings.TrimSpace(trans) == "" {
+			warnings = append(warnings, fmt.Sprintf("Machine translation %s: %q is not translated", option.Target, source))
+			continue
+		}
+
+		trans, err := unmaskPlaceholders(strings.TrimSpace(trans), tokens[id])
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("Machine translation %s: %q %s", option.Target, source, err.Error()))
+			continue
+		}
+
+		// Keep the leading and trailing spaces of the source, the messages are often concatenated
+		lead := source[:len(source)-len(strings.TrimLeftFunc(source, unicode.IsSpace))]
+		trail := source[len(strings.TrimRightFunc(source, unicode.IsSpace)):]
+		results[source] = lead + trans + trail
+	}
+
+	return results, warnings, nil
+}
+
+// maskPlaceholders replaces the placeholders with ⟦n⟧ marks
+func maskPlaceholders(text string) (string, []string) {
+	tokens := []string{}
+	masked := rePlaceholder.ReplaceAllStringFunc(text, func(token string) string {
+		tokens = append(tokens, token)
+		return fmt.Sprintf("⟦%d⟧", len(tokens)-1)
+	})
+	return masked, tokens
+}
+
+// unmaskPlaceholders restores the placeholders, every mark must be kept exactly once
+func unmaskPlaceholders(text string, tokens []string) (string, error) {
+	marks := reMark.FindAllString(text, -1)
+	if len(marks) != len(tokens) {
+		return "", fmt.Errorf("has %d placeholders, expected %d", len(marks), len(tokens))
+	}
+
+	seen := map[string]bool{}
+	for _, mark := range marks {
+		if seen[mark] {
+			return "", fmt.Errorf("placeholder %s is repeated", mark)
+		}
+		seen[mark] = true
+	}
+
+	var err error
+	text = reMark.ReplaceAllStringFunc(text, func(mark string) string {
+		index, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(mark, "⟦"), "⟧"))
+		if index >= len(tokens) {
+			err = fmt.Errorf("unknown placeholder %s", mark)
+			return mark
+		}
+		return tokens[index]
+	})
+	return text, err
+}
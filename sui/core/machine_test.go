@@ -0,0 +1,125 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMachinePlaceholders(t *testing.T) {
+	masked, tokens := maskPlaceholders(`Hello <b>{{ name }}</b>, you have %d messages&nbsp;`)
+	assert.Equal(t, `Hello ⟦0⟧⟦1⟧⟦2⟧, you have ⟦3⟧ messages⟦4⟧`, masked)
+	assert.Equal(t, []string{"<b>", "{{ name }}", "</b>", "%d", "&nbsp;"}, tokens)
+
+	text, err := unmaskPlaceholders(`你好 ⟦0⟧⟦1⟧⟦2⟧，你有 ⟦3⟧ 条消息⟦4⟧`, tokens)
+	assert.Nil(t, err)
+	assert.Equal(t, `你好 <b>{{ name }}</b>，你有 %d 条消息&nbsp;`, text)
+
+	_, err = unmaskPlaceholders(`你好 ⟦0⟧⟦1⟧，你有 ⟦3⟧ 条消息⟦4⟧`, tokens)
+	assert.NotNil(t, err)
+
+	_, err = unmaskPlaceholders(`你好 ⟦0⟧⟦0⟧⟦2⟧，你有 ⟦3⟧ 条消息⟦4⟧`, tokens)
+	assert.NotNil(t, err)
+}
+
+func TestMachineOption(t *testing.T) {
+	setting := &TemplateTranslation{
+		Connector: "gpt-4o",
+		Glossary: map[string]map[string]string{
+			"*":     {"Yao": "Yao", "Workspace": "Workspace"},
+			"zh-CN": {"Workspace": "工作区"},
+		},
+		Styles: map[string]string{"zh-cn": "Use simplified chinese"},
+	}
+
+	option := setting.MachineOption("en-us", "zh-cn")
+	assert.Equal(t, "gpt-4o", option.Connector)
+	assert.Equal(t, map[string]string{"Yao": "Yao", "Workspace": "工作区"}, option.Glossary)
+	assert.Equal(t, "Use simplified chinese", option.Style)
+}
+
+func TestMachineTranslate(t *testing.T) {
+	chat := MachineChat
+	defer func() { MachineChat = chat }()
+
+	requests := 0
+	MachineChat = func(ctx context.Context, connector string, messages []map[string]interface{}) (string, error) {
+		requests++
+		input := struct {
+			Glossary map[string]string `json:"glossary"`
+			Messages map[string]string `json:"messages"`
+		}{}
+		err := jsoniter.UnmarshalFromString(messages[1]["content"].(string), &input)
+		if err != nil {
+			return "", err
+		}
+
+		replies := map[string]string{}
+		for id, message := range input.Messages {
+			if message == "Broken ⟦0⟧" {
+				replies[id] = "Kaputt"
+				continue
+			}
+			replies[id] = "[zh] " + message
+		}
+		raw, _ := jsoniter.MarshalToString(replies)
+		return fmt.Sprintf("```json\n%s\n```", raw), nil
+	}
+
+	locale := Locale{
+		Keys:     map[string]string{},
+		Messages: map[string]string{"Home": "首页"},
+	}
+	translations := []Translation{
+		{Key: "trans_index_0", Message: "Home", Type: "text"},
+		{Key: "trans_index_1", Message: "Hello, ", Type: "text"},
+		{Key: "trans_index_2", Message: "You have <b>{{ count }}</b> messages", Type: "text"},
+		{Key: "trans_index_3", Message: "Broken {{ x }}", Type: "text"},
+		{Key: "trans_other_0", Message: "Other page", Type: "text"},
+	}
+	locale.MergeTranslations(translations, "trans_index")
+	option := &MachineOption{Connector: "test", Source: "en-us", Target: "zh-cn", BatchSize: 2}
+
+	warnings := locale.MachineTranslate(translations, "trans_index", option)
+	assert.Equal(t, 2, requests)
+	assert.Len(t, warnings, 1)
+	assert.True(t, strings.Contains(warnings[0], "Broken"))
+
+	assert.Equal(t, "首页", locale.Keys["trans_index_0"])
+	assert.Equal(t, "[zh] Hello, ", locale.Messages["Hello, "])
+	assert.Equal(t, "[zh] You have <b>{{ count }}</b> messages", locale.Keys["trans_index_2"])
+	assert.Equal(t, "Broken {{ x }}", locale.Messages["Broken {{ x }}"])
+	assert.NotContains(t, locale.Keys, "trans_other_0")
+
+	assert.Equal(t, map[string]string{
+		"trans_index_0": "Home",
+		"trans_index_1": "Hello, ",
+		"trans_index_2": "You have <b>{{ count }}</b> messages",
+	}, locale.Sources)
+	assert.Equal(t, "[zh] Hello, ", locale.Review["Hello, "])
+	assert.NotContains(t, locale.Review, "Home")
+
+	// The reviewer edits a translation, the unchanged keys are not sent again
+	locale.Messages["Hello, "] = "你好，"
+	requests = 0
+	warnings = locale.MachineTranslate(translations, "trans_index", option)
+	assert.Equal(t, 1, requests) // The broken message only
+	assert.Len(t, warnings, 1)
+	assert.NotContains(t, locale.Review, "Hello, ")
+	assert.Equal(t, "你好，", locale.Messages["Hello, "])
+
+	// The source text of a key changed
+	translations[1].Message = "Hi, "
+	translations = translations[:3]
+	requests = 0
+	warnings = locale.MachineTranslate(translations, "trans_index", option)
+	assert.Equal(t, 1, requests)
+	assert.Empty(t, warnings)
+	assert.Equal(t, "[zh] Hi, ", locale.Keys["trans_index_1"])
+	assert.Equal(t, "Hi, ", locale.Sources["trans_index_1"])
+	assert.NotContains(t, locale.Sources, "trans_index_3")
+}
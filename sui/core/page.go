@@ -71,6 +71,7 @@ func (page *Page) ExportConfig() string {
 		"cache":      page.Config.Cache,
 		"dataCache":  page.Config.DataCache,
 		"api":        page.Config.API,
+		"static":     page.Config.Static,
 		"root":       page.Root,
 	})
 
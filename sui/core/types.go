@@ -128,6 +128,8 @@ type Locale struct {
 	ScriptMessages map[string]string `json:"script_messages,omitempty" yaml:"script_messages,omitempty"`
 	Direction      string            `json:"direction,omitempty" yaml:"direction,omitempty"`
 	Timezone       string            `json:"timezone,omitempty" yaml:"timezone,omitempty"`
+	Sources        map[string]string `json:"sources,omitempty" yaml:"sources,omitempty"` // key -> the source text of the last machine translation run
+	Review         map[string]string `json:"review,omitempty" yaml:"review,omitempty"`   // source text -> machine translation, waiting for review
 }
 
 // PageTreeNode is the struct for the page tree node
@@ -174,19 +176,51 @@ type LayoutItem struct {
 
 // Template is the struct for the template
 type Template struct {
-	Version      int              `json:"version"` // Yao Builder version
-	ID           string           `json:"id"`
-	Name         string           `json:"name"`
-	Descrption   string           `json:"description"`
-	Screenshots  []string         `json:"screenshots"`
-	Themes       []SelectOption   `json:"themes"`
-	Locales      []SelectOption   `json:"locales"`
-	Document     []byte           `json:"-"`
-	GlobalData   []byte           `json:"-"`
-	Scripts      *TemplateScirpts `json:"scripts,omitempty"`
-	Translator   string           `json:"translator,omitempty"`
-	BuildScript  *Script          `json:"-"` // __build.backend.ts / __build.backend.js
-	GlobalScript *Script          `json:"-"` // __global.backend.ts / __global.backend.js
+	Version      int                  `json:"version"` // Yao Builder version
+	ID           string               `json:"id"`
+	Name         string               `json:"name"`
+	Descrption   string               `json:"description"`
+	Screenshots  []string             `json:"screenshots"`
+	Themes       []SelectOption       `json:"themes"`
+	Locales      []SelectOption       `json:"locales"`
+	Document     []byte               `json:"-"`
+	GlobalData   []byte               `json:"-"`
+	Scripts      *TemplateScirpts     `json:"scripts,omitempty"`
+	Translator   string               `json:"translator,omitempty"`
+	Translation  *TemplateTranslation `json:"translation,omitempty"`
+	Images       *TemplateImages      `json:"images,omitempty"`
+	BuildScript  *Script              `json:"-"` // __build.backend.ts / __build.backend.js
+	GlobalScript *Script              `json:"-"` // __global.backend.ts / __global.backend.js
+}
+
+// TemplateTranslation is the machine translation setting of the template
+type TemplateTranslation struct {
+	Connector string                       `json:"connector"`
+	Glossary  map[string]map[string]string `json:"glossary,omitempty"` // locale -> term -> translation, "*" for all locales
+	Styles    map[string]string            `json:"styles,omitempty"`   // locale -> style guidance
+	BatchSize int                          `json:"batch_size,omitempty"`
+}
+
+// TemplateImages is the responsive image setting of the template
+type TemplateImages struct {
+	Widths  []uint   `json:"widths,omitempty"`  // The srcset widths, DefaultImageWidths by default
+	Formats []string `json:"formats,omitempty"` // The modern formats in order of preference, e.g. ["avif", "webp"]
+	Quality int      `json:"quality,omitempty"` // 1-100, DefaultImageQuality by default
+	Sizes   string   `json:"sizes,omitempty"`   // The default sizes attribute, DefaultImageSizes by default
+}
+
+// ImageSet is the responsive image derivatives of an asset
+type ImageSet struct {
+	Src     string        `json:"src"`
+	Srcset  string        `json:"srcset"`
+	Sizes   string        `json:"sizes"`
+	Sources []ImageSource `json:"sources,omitempty"` // The modern formats, rendered as <picture> sources
+}
+
+// ImageSource is the source of the <picture>
+type ImageSource struct {
+	Type   string `json:"type"`
+	Srcset string `json:"srcset"`
 }
 
 // TemplateScirpts is the struct for the template scripts
@@ -274,6 +308,9 @@ type BuildOption struct {
 	StyleMinify     bool                   `json:"styleminify,omitempty"`
 	ExecScripts     bool                   `json:"exec_scripts,omitempty"`
 	Locales         []string               `json:"locales,omitempty"`
+	Machine         bool                   `json:"machine,omitempty"`   // Fill the missing translations with the translation connector
+	Connector       string                 `json:"connector,omitempty"` // Override the connector of the template translation setting
+	ImageBuilder    IImageBuilder          `json:"-"`                   // Generate the srcset of the <img>, nil to keep the images as is
 }
 
 // Request is the struct for the request
@@ -374,15 +411,24 @@ type PageConfig struct {
 
 // PageSetting is the struct for the page setting
 type PageSetting struct {
-	Title       string   `json:"title,omitempty"`
-	Guard       string   `json:"guard,omitempty"`
-	CacheStore  string   `json:"cacheStore,omitempty"`
-	Cache       int      `json:"cache,omitempty"`
-	Root        string   `json:"root,omitempty"`
-	DataCache   int      `json:"dataCache,omitempty"`
-	Description string   `json:"description,omitempty"`
-	SEO         *PageSEO `json:"seo,omitempty"`
-	API         *PageAPI `json:"api,omitempty"`
+	Title       string      `json:"title,omitempty"`
+	Guard       string      `json:"guard,omitempty"`
+	CacheStore  string      `json:"cacheStore,omitempty"`
+	Cache       int         `json:"cache,omitempty"`
+	Root        string      `json:"root,omitempty"`
+	DataCache   int         `json:"dataCache,omitempty"`
+	Description string      `json:"description,omitempty"`
+	SEO         *PageSEO    `json:"seo,omitempty"`
+	API         *PageAPI    `json:"api,omitempty"`
+	Static      *PageStatic `json:"static,omitempty"`
+}
+
+// PageStatic is the struct for the static pre-rendering setting of the page
+// The process returns the params of the dynamic [param] routes, e.g. [{"id": 1}, {"params": {"id": 2}, "lastmod": "2024-01-01"}]
+type PageStatic struct {
+	Process string        `json:"process,omitempty"`
+	Args    []interface{} `json:"args,omitempty"`
+	Exclude bool          `json:"exclude,omitempty"`
 }
 
 // PageConfigRendered is the struct for the page config rendered
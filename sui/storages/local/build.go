@@ -179,6 +179,11 @@ func (tmpl *Template) Trans(option *core.BuildOption) ([]string, error) {
 // SyncAssetFile sync the assets
 func (tmpl *Template) SyncAssetFile(file string, option *core.BuildOption) error {
 
+	// The files are not on the local disk
+	if tmpl.local.fs.Root() == "" {
+		return tmpl.syncAssetsFromFS(file, option)
+	}
+
 	// get source abs path
 	sourceRoot := filepath.Join(tmpl.local.fs.Root(), tmpl.Root, "__assets")
 	if exist, _ := os.Stat(sourceRoot); exist == nil {
@@ -270,6 +275,11 @@ func (tmpl *Template) writeGlobalScript(data map[string]interface{}) error {
 // SyncAssets sync the assets
 func (tmpl *Template) SyncAssets(option *core.BuildOption) error {
 
+	// The files are not on the local disk
+	if tmpl.local.fs.Root() == "" {
+		return tmpl.syncAssetsFromFS("", option)
+	}
+
 	// get source abs path
 	sourceRoot := filepath.Join(tmpl.local.fs.Root(), tmpl.Root, "__assets")
 	if exist, _ := os.Stat(sourceRoot); exist == nil {
@@ -292,6 +302,49 @@ func (tmpl *Template) SyncAssets(option *core.BuildOption) error {
 	return copyDirectory(sourceRoot, targetRoot)
 }
 
+// syncAssetsFromFS copy the assets through the file system, all the assets if the file is empty
+func (tmpl *Template) syncAssetsFromFS(file string, option *core.BuildOption) error {
+	sourceRoot := filepath.Join(tmpl.Root, "__assets")
+	if !tmpl.local.fs.IsDir(sourceRoot) {
+		return nil
+	}
+
+	root, err := tmpl.local.DSL.PublicRoot(option.Data)
+	if err != nil {
+		log.Error("SyncAssets: Get the public root error: %s. use %s", err.Error(), tmpl.local.DSL.Public.Root)
+		root = tmpl.local.DSL.Public.Root
+	}
+	targetRoot := filepath.Join(application.App.Root(), "public", root, "assets")
+
+	files := []string{filepath.Join(sourceRoot, file)}
+	if file == "" {
+		os.RemoveAll(targetRoot)
+		files, err = tmpl.local.fs.ReadDir(sourceRoot, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, source := range files {
+		if strings.HasPrefix(filepath.Base(source), ".") || !tmpl.local.fs.IsFile(source) {
+			continue
+		}
+
+		content, err := tmpl.local.fs.ReadFile(source)
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(targetRoot, strings.TrimPrefix(source, sourceRoot))
+		os.MkdirAll(filepath.Dir(target), os.ModePerm)
+		err = os.WriteFile(target, content, 0644)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (tmpl *Template) getLocaleGlobal(name string) core.Locale {
 	global := core.Locale{
 		Keys:     map[string]string{},
@@ -397,6 +450,10 @@ func (page *Page) Build(globalCtx *core.GlobalBuildContext, option *core.BuildOp
 	}
 	page.Root = root
 
+	if option.ImageBuilder == nil {
+		option.ImageBuilder = page.tmpl.imageBuilder(option)
+	}
+
 	html, config, warnings, err := page.Page.Compile(ctx, option)
 	if err != nil {
 		return warnings, fmt.Errorf("Compile the page %s error: %s", page.Route, err.Error())
@@ -475,6 +532,10 @@ func (page *Page) BuildAsComponent(globalCtx *core.GlobalBuildContext, option *c
 		option.AssetRoot = utils.RepalcePath(filepath.Join(root, "assets"))
 	}
 
+	if option.ImageBuilder == nil {
+		option.ImageBuilder = page.tmpl.imageBuilder(option)
+	}
+
 	html, messages, err := page.Page.CompileAsComponent(ctx, option)
 	if err != nil {
 		return warnings, err
@@ -543,7 +604,10 @@ func (page *Page) Trans(globalCtx *core.GlobalBuildContext, option *core.BuildOp
 	}
 
 	// Tranlate the locale files
-	err = page.writeLocaleSource(ctx, option)
+	messages, err = page.writeLocaleSource(ctx, option)
+	if len(messages) > 0 {
+		warnings = append(warnings, messages...)
+	}
 	return warnings, err
 }
 
@@ -575,10 +639,12 @@ func (page *Page) localeFiles(data map[string]interface{}) map[string]string {
 	return roots
 }
 
-func (page *Page) writeLocaleSource(ctx *core.BuildContext, option *core.BuildOption) error {
+func (page *Page) writeLocaleSource(ctx *core.BuildContext, option *core.BuildOption) ([]string, error) {
 
+	warnings := []string{}
 	locales := page.tmpl.Locales()
 	translations := ctx.GetTranslations()
+	source := page.tmpl.defaultLocale()
 
 	if option.Locales != nil && len(option.Locales) > 0 {
 		locales = []core.SelectOption{}
@@ -597,23 +663,32 @@ func (page *Page) writeLocaleSource(ctx *core.BuildContext, option *core.BuildOp
 		locale := page.tmpl.getLocale(lc.Value, page.Route, true)
 		locale.MergeTranslations(translations, prefix)
 
+		// Fill the missing translations with the connector
+		if option.Machine {
+			machine, err := page.tmpl.machineOption(source, lc.Value, option)
+			if err != nil {
+				return warnings, err
+			}
+			warnings = append(warnings, locale.MachineTranslate(translations, prefix, machine)...)
+		}
+
 		// Call the hook
 		var keys any = locale.Keys
 		var messages any = locale.Messages
 		if page.tmpl.Translator != "" {
 			p, err := process.Of(page.tmpl.Translator, lc.Value, locale, page.Route, page.TemplateID)
 			if err != nil {
-				return err
+				return warnings, err
 			}
 
 			res, err := p.Exec()
 			if err != nil {
-				return err
+				return warnings, err
 			}
 
 			pres, ok := res.(map[string]interface{})
 			if !ok {
-				return fmt.Errorf("The translator %s should return a locale", page.tmpl.Translator)
+				return warnings, fmt.Errorf("The translator %s should return a locale", page.tmpl.Translator)
 			}
 
 			keys = pres["keys"]
@@ -621,26 +696,62 @@ func (page *Page) writeLocaleSource(ctx *core.BuildContext, option *core.BuildOp
 		}
 
 		if keys == nil && messages == nil {
-			return nil
+			return warnings, nil
 		}
 
 		// Save to file
-		file := filepath.Join(page.tmpl.Root, "__locales", lc.Value, fmt.Sprintf("%s.yml", page.Route))
-		content, err := yaml.Marshal(map[string]interface{}{
+		data := map[string]interface{}{
 			"keys":     keys,
 			"messages": messages,
-		})
+		}
+		if len(locale.Sources) > 0 {
+			data["sources"] = locale.Sources
+		}
+		if len(locale.Review) > 0 {
+			data["review"] = locale.Review
+		}
+
+		file := filepath.Join(page.tmpl.Root, "__locales", lc.Value, fmt.Sprintf("%s.yml", page.Route))
+		content, err := yaml.Marshal(data)
 		if err != nil {
-			return err
+			return warnings, err
 		}
 
 		_, err = page.tmpl.local.fs.WriteFile(file, content, 0644)
 		if err != nil {
-			return err
+			return warnings, err
 		}
 	}
 
-	return nil
+	return warnings, nil
+}
+
+// machineOption get the machine translation option of the locale
+func (tmpl *Template) machineOption(source string, target string, option *core.BuildOption) (*core.MachineOption, error) {
+	setting := tmpl.Translation
+	if setting == nil {
+		setting = &core.TemplateTranslation{}
+	}
+
+	machine := setting.MachineOption(source, target)
+	if option.Connector != "" {
+		machine.Connector = option.Connector
+	}
+
+	if machine.Connector == "" {
+		return nil, fmt.Errorf("The translation connector of the template %s is not set", tmpl.ID)
+	}
+	return machine, nil
+}
+
+// defaultLocale get the default locale of the template, the source language of the messages
+func (tmpl *Template) defaultLocale() string {
+	for _, locale := range tmpl.Locales() {
+		if locale.Default {
+			return locale.Value
+		}
+	}
+	return "en-us"
 }
 
 func (page *Page) writeLocaleFiles(ctx *core.BuildContext, data map[string]interface{}) error {
@@ -667,8 +778,10 @@ func (page *Page) writeLocaleFiles(ctx *core.BuildContext, data map[string]inter
 			locale.Merge(compLocale)
 		}
 
-		// Remove messages
+		// Remove messages and the machine translation records
 		locale.Messages = map[string]string{}
+		locale.Sources = nil
+		locale.Review = nil
 		raw, err := yaml.Marshal(locale)
 		if err != nil {
 			log.Error(`[SUI] Marshal the locale file error: %s`, err.Error())
@@ -0,0 +1,191 @@
+package local
+
+import (
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yaoapp/gou/application"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/sui/core"
+)
+
+// imageBuilder generates the responsive image derivatives of the template assets
+type imageBuilder struct {
+	tmpl      *Template
+	setting   *core.TemplateImages
+	assetRoot string // The url of the assets
+	target    string // The absolute path of the public assets
+	sets      map[string]*core.ImageSet
+}
+
+// imageBuilder returns the image builder of the build, nil if the template has no images setting
+func (tmpl *Template) imageBuilder(option *core.BuildOption) core.IImageBuilder {
+	if tmpl.Images == nil {
+		return nil
+	}
+
+	root, err := tmpl.local.DSL.PublicRoot(option.Data)
+	if err != nil {
+		log.Error("ImageBuilder: Get the public root error: %s. use %s", err.Error(), tmpl.local.DSL.Public.Root)
+		root = tmpl.local.DSL.Public.Root
+	}
+
+	return &imageBuilder{
+		tmpl:      tmpl,
+		setting:   tmpl.Images,
+		assetRoot: option.AssetRoot,
+		target:    filepath.Join(application.App.Root(), "public", root, "assets"),
+		sets:      map[string]*core.ImageSet{},
+	}
+}
+
+// ImageSet generate the derivatives of the asset, the files are written to <public>/assets/__images/<hash>/
+func (builder *imageBuilder) ImageSet(file string) (*core.ImageSet, error) {
+	if set, has := builder.sets[file]; has {
+		return set, nil
+	}
+
+	tmpl := builder.tmpl
+	data, err := tmpl.local.fs.ReadFile(filepath.Join(tmpl.Root, "__assets", file))
+	if err != nil {
+		return nil, err
+	}
+
+	width, _, format, err := core.ImageSize(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var img image.Image
+	hash := core.ImageHash(data)
+	quality := builder.setting.ImageQuality()
+	widths := builder.setting.ImageWidths(width)
+	fallback := core.ImageFallbackFormat(format)
+
+	set := &core.ImageSet{Sizes: builder.setting.ImageSizes(), Sources: []core.ImageSource{}}
+	for _, output := range append(builder.setting.ImageFormats(fallback), fallback) {
+		srcset := []string{}
+		for _, w := range widths {
+			option := core.ImageOption{Width: w, Format: output, Quality: quality}
+			name := fmt.Sprintf("%dw-q%d.%s", w, quality, core.ImageExt(output))
+			content, err := tmpl.imageDerivative(func() ([]byte, error) { return data, nil }, &img, hash, name, option)
+			if err != nil {
+				return nil, err
+			}
+
+			target := filepath.Join(builder.target, "__images", hash, name)
+			if _, err := os.Stat(target); err != nil {
+				os.MkdirAll(filepath.Dir(target), os.ModePerm)
+				err = os.WriteFile(target, content, 0644)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			url := fmt.Sprintf("%s/__images/%s/%s", builder.assetRoot, hash, name)
+			srcset = append(srcset, fmt.Sprintf("%s %dw", url, w))
+			if output == fallback {
+				set.Src = url
+			}
+		}
+
+		if output == fallback {
+			set.Srcset = strings.Join(srcset, ", ")
+			continue
+		}
+		set.Sources = append(set.Sources, core.ImageSource{Type: core.ImageMimeType(output), Srcset: strings.Join(srcset, ", ")})
+	}
+
+	builder.sets[file] = set
+	return set, nil
+}
+
+// imageDerivative returns the content of the derivative, the derivatives are cached in <template>/.cache/images/<hash>/
+// the source is read and decoded only when the derivative is not cached
+func (tmpl *Template) imageDerivative(read func() ([]byte, error), img *image.Image, hash string, name string, option core.ImageOption) ([]byte, error) {
+	cacheFile := filepath.Join(tmpl.Root, ".cache", "images", hash, name)
+	if tmpl.local.fs.IsFile(cacheFile) {
+		return tmpl.local.fs.ReadFile(cacheFile)
+	}
+
+	if *img == nil {
+		data, err := read()
+		if err != nil {
+			return nil, err
+		}
+		decoded, _, err := core.DecodeImage(data)
+		if err != nil {
+			return nil, err
+		}
+		*img = decoded
+	}
+
+	asset, err := core.EncodeImage(*img, option)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tmpl.local.fs.WriteFile(cacheFile, asset.Content, 0644)
+	if err != nil {
+		log.Warn("Write the image cache %s error: %s", cacheFile, err.Error())
+	}
+	return asset.Content, nil
+}
+
+// sourceImage the hash and the format of a source image
+type sourceImage struct {
+	size    int
+	modTime time.Time
+	hash    string
+	format  string
+}
+
+// sourceImages the source images by path, the hash is computed again when the size or the modification time changed
+var sourceImages sync.Map
+
+// sourceImage returns the hash and the format of the source image, the data is nil if the source is not changed
+func (tmpl *Template) sourceImage(file string) (*sourceImage, []byte, error) {
+	size, err := tmpl.local.fs.Size(file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	modTime, err := tmpl.local.fs.ModTime(file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if v, has := sourceImages.Load(file); has {
+		source := v.(*sourceImage)
+		if source.size == size && source.modTime.Equal(modTime) {
+			return source, nil, nil
+		}
+	}
+
+	data, err := tmpl.local.fs.ReadFile(file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, _, format, err := core.ImageSize(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	source := &sourceImage{size: size, modTime: modTime, hash: core.ImageHash(data), format: format}
+	sourceImages.Store(file, source)
+	return source, data, nil
+}
+
+// imageQuality returns the quality of the image derivatives
+func (tmpl *Template) imageQuality() int {
+	if tmpl.Images == nil {
+		return core.DefaultImageQuality
+	}
+	return tmpl.Images.ImageQuality()
+}
@@ -16,6 +16,15 @@ import (
 
 // New create a new local sui
 func New(dsl *sui.DSL) (*Local, error) {
+	dataFS, err := fs.Get("system")
+	if err != nil {
+		return nil, err
+	}
+	return NewWith(dsl, dataFS), nil
+}
+
+// NewWith create a new sui with the given file system, the templates, pages and media are stored in it
+func NewWith(dsl *sui.DSL, filesystem FileSystem) *Local {
 
 	templateRoot := "/data/sui/templates"
 	if dsl.Storage.Option != nil && dsl.Storage.Option["root"] != nil {
@@ -44,11 +53,6 @@ func New(dsl *sui.DSL) (*Local, error) {
 		}
 	}
 
-	dataFS, err := fs.Get("system")
-	if err != nil {
-		return nil, err
-	}
-
 	dsl.Public = &sui.Public{
 		Host:    host,
 		Root:    root,
@@ -58,9 +62,9 @@ func New(dsl *sui.DSL) (*Local, error) {
 
 	return &Local{
 		root: templateRoot,
-		fs:   dataFS,
+		fs:   filesystem,
 		DSL:  dsl,
-	}, nil
+	}
 }
 
 // GetTemplates get the templates
@@ -2,6 +2,7 @@ package local
 
 import (
 	"fmt"
+	"image"
 	"io"
 	"net/url"
 	"os"
@@ -419,23 +420,26 @@ func (tmpl *Template) Asset(file string, width, height uint) (*core.Asset, error
 
 func (tmpl *Template) assetThumb(file string, width, height uint) (*core.Asset, error) {
 
-	cacheFile := filepath.Join(tmpl.Root, "__assets", ".cache", fmt.Sprintf("%dx%d", width, height), file)
-	exist, _ := tmpl.local.fs.Exists(cacheFile)
-	if !exist {
-		err := tmpl.local.fs.Resize(file, cacheFile, width, height)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	typ, err := tmpl.local.fs.MimeType(file)
+	// The source is read only when it changed or the thumbnail is not cached
+	source, data, err := tmpl.sourceImage(file)
 	if err != nil {
 		return nil, err
 	}
 
-	content, err := tmpl.local.fs.ReadFile(cacheFile)
+	read := func() ([]byte, error) {
+		if data != nil {
+			return data, nil
+		}
+		return tmpl.local.fs.ReadFile(file)
+	}
+
+	// Re-encode the image, the EXIF orientation is applied and the metadata is stripped
+	var img image.Image
+	option := core.ImageOption{Width: width, Height: height, Format: core.ImageFallbackFormat(source.format), Quality: tmpl.imageQuality()}
+	name := fmt.Sprintf("%dx%d-q%d.%s", width, height, option.Quality, core.ImageExt(option.Format))
+	content, err := tmpl.imageDerivative(read, &img, source.hash, name, option)
 	if err != nil {
 		return nil, err
 	}
-	return &core.Asset{Type: typ, Content: content}, nil
+	return &core.Asset{Type: core.ImageMimeType(option.Format), Content: content}, nil
 }
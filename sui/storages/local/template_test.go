@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/yaoapp/gou/application"
+	"github.com/yaoapp/yao/sui/core"
 )
 
 func TestTemplateThemes(t *testing.T) {
@@ -87,7 +88,11 @@ func TestTemplateAsset(t *testing.T) {
 	}
 	assert.Equal(t, "image/png", asset.Type)
 	assert.NotEmpty(t, asset.Content)
-	exists, err := application.App.Exists("/data/test-cases/advanced/__assets/.cache/100x100/test-cases/advanced/__assets/images/icons/app.png")
+	source, err := application.App.Read("/data/test-cases/advanced/__assets/images/icons/app.png")
+	if err != nil {
+		t.Fatalf("Asset error: %v", err)
+	}
+	exists, err := application.App.Exists("/data/test-cases/advanced/.cache/images/" + core.ImageHash(source) + "/100x100-q80.png")
 	if err != nil {
 		t.Fatalf("Asset error: %v", err)
 	}
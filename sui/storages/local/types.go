@@ -1,17 +1,42 @@
 package local
 
 import (
-	"github.com/yaoapp/gou/fs"
+	"io"
+	"time"
+
 	"github.com/yaoapp/yao/sui/core"
 )
 
 // Local is the struct for the local sui
 type Local struct {
 	root string
-	fs   fs.FileSystem
+	fs   FileSystem
 	*core.DSL
 }
 
+// FileSystem is the file system of the templates, the "system" file system by default.
+// Root returns the absolute path on the local disk, empty if the files are not stored on the local disk.
+type FileSystem interface {
+	ReadFile(file string) ([]byte, error)
+	WriteFile(file string, data []byte, perm uint32) (int, error)
+	Write(file string, reader io.Reader, perm uint32) (int, error)
+	ReadDir(dir string, recursive bool) ([]string, error)
+	Glob(pattern string) ([]string, error)
+	Walk(root string, handler func(root, filename string, isdir bool) error, patterns ...string) error
+	List(path string, types []string, page, pageSize int, filter func(string) bool) ([]string, int, int, error)
+	Exists(name string) (bool, error)
+	IsDir(name string) bool
+	IsFile(name string) bool
+	Size(name string) (int, error)
+	ModTime(name string) (time.Time, error)
+	Remove(name string) error
+	RemoveAll(name string) error
+	Copy(src string, dest string) error
+	MimeType(name string) (string, error)
+	Resize(inputPath, outputPath string, width, height uint) error
+	Root() string
+}
+
 // Template is the struct for the local sui template
 type Template struct {
 	Root    string `json:"-"`
@@ -0,0 +1,240 @@
+package object
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yaoapp/gou/connector"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/xun/capsule"
+	"github.com/yaoapp/xun/dbal/query"
+	"github.com/yaoapp/xun/dbal/schema"
+)
+
+// Database is the object store on a database table, the content is base64 encoded
+type Database struct {
+	Connector string
+	Table     string
+	query     query.Query
+	schema    schema.Schema
+}
+
+// NewDatabase create a new database store
+// options: connector (default connector by default), table (__yao_sui_<id> by default)
+func NewDatabase(id string, options map[string]interface{}) (*Database, error) {
+	store := &Database{Connector: "default", Table: fmt.Sprintf("__yao_sui_%s", strings.ReplaceAll(id, ".", "_"))}
+	if name, ok := options["connector"].(string); ok && name != "" {
+		store.Connector = name
+	}
+
+	if table, ok := options["table"].(string); ok && table != "" {
+		store.Table = table
+	}
+
+	if store.Connector == "default" {
+		store.query = capsule.Global.Query()
+		store.schema = capsule.Global.Schema()
+
+	} else {
+		conn, err := connector.Select(store.Connector)
+		if err != nil {
+			return nil, err
+		}
+
+		if !conn.Is(connector.DATABASE) {
+			return nil, fmt.Errorf("The connector %s is not a database connector", store.Connector)
+		}
+
+		store.query, err = conn.Query()
+		if err != nil {
+			return nil, err
+		}
+
+		store.schema, err = conn.Schema()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := store.init()
+	if err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// Get get the object content
+func (store *Database) Get(ctx context.Context, key string) ([]byte, error) {
+	row, err := store.query.New().Table(store.Table).Select("content").Where("path", key).First()
+	if err != nil {
+		return nil, err
+	}
+
+	if row == nil || len(row.ToMap()) == 0 {
+		return nil, ErrNotFound
+	}
+
+	content := ""
+	switch value := row.ToMap()["content"].(type) {
+	case string:
+		content = value
+	case []byte:
+		content = string(value)
+	}
+	return base64.StdEncoding.DecodeString(content)
+}
+
+// Stat get the object information
+func (store *Database) Stat(ctx context.Context, key string) (*Object, error) {
+	row, err := store.query.New().Table(store.Table).Select("path", "size", "updated_at").Where("path", key).First()
+	if err != nil {
+		return nil, err
+	}
+
+	if row == nil || len(row.ToMap()) == 0 {
+		return nil, ErrNotFound
+	}
+	return store.object(row.ToMap()), nil
+}
+
+// Put put the object
+func (store *Database) Put(ctx context.Context, key string, data []byte) error {
+	values := map[string]interface{}{
+		"content":    base64.StdEncoding.EncodeToString(data),
+		"size":       len(data),
+		"updated_at": time.Now(),
+	}
+
+	has, err := store.query.New().Table(store.Table).Where("path", key).Exists()
+	if err != nil {
+		return err
+	}
+
+	if has {
+		_, err = store.query.New().Table(store.Table).Where("path", key).Update(values)
+		return err
+	}
+
+	values["path"] = key
+	return store.query.New().Table(store.Table).Insert(values)
+}
+
+// Delete delete the objects
+func (store *Database) Delete(ctx context.Context, keys []string) error {
+	for start := 0; start < len(keys); start += 500 {
+		end := start + 500
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		paths := []interface{}{}
+		for _, key := range keys[start:end] {
+			paths = append(paths, key)
+		}
+
+		_, err := store.query.New().Table(store.Table).WhereIn("path", paths).Delete()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// List list the objects under the prefix
+func (store *Database) List(ctx context.Context, prefix string, limit int) ([]Object, error) {
+	qb := store.query.New().Table(store.Table).Select("path", "size", "updated_at").OrderBy("path", "asc")
+	if prefix != "" {
+		qb.Where("path", "like", prefix+"%")
+	}
+
+	rows, err := qb.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []Object{}
+	for _, row := range rows {
+		object := store.object(row.ToMap())
+
+		// "_" is a wildcard of like
+		if !strings.HasPrefix(object.Key, prefix) {
+			continue
+		}
+
+		objects = append(objects, *object)
+		if limit > 0 && len(objects) >= limit {
+			break
+		}
+	}
+	return objects, nil
+}
+
+func (store *Database) object(row map[string]interface{}) *Object {
+	object := &Object{}
+	object.Key, _ = row["path"].(string)
+
+	switch size := row["size"].(type) {
+	case int64:
+		object.Size = size
+	case int:
+		object.Size = int64(size)
+	case float64:
+		object.Size = int64(size)
+	}
+
+	switch updated := row["updated_at"].(type) {
+	case time.Time:
+		object.ModTime = updated
+	case string:
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999-07:00", "2006-01-02 15:04:05"} {
+			if t, err := time.Parse(layout, updated); err == nil {
+				object.ModTime = t
+				break
+			}
+		}
+	}
+	return object
+}
+
+// init create the table if it does not exist
+func (store *Database) init() error {
+
+	has, err := store.schema.HasTable(store.Table)
+	if err != nil {
+		return err
+	}
+
+	if !has {
+		err = store.schema.CreateTable(store.Table, func(table schema.Blueprint) {
+			table.ID("id")
+			table.String("path", 255).Unique()
+			table.LongText("content").Null()
+			table.Integer("size").Null()
+			table.TimestampTz("created_at").SetDefaultRaw("NOW()").Index()
+			table.TimestampTz("updated_at").Null().Index()
+		})
+
+		if err != nil {
+			return err
+		}
+		log.Trace("Create the sui storage table: %s", store.Table)
+	}
+
+	tab, err := store.schema.GetTable(store.Table)
+	if err != nil {
+		return err
+	}
+
+	fields := []string{"id", "path", "content", "size", "updated_at"}
+	for _, field := range fields {
+		if !tab.HasColumn(field) {
+			return fmt.Errorf("sui storage table %s field %s is required", store.Table, field)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,507 @@
+package object
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	iofs "io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/image/draw"
+)
+
+// FS is the file system on an object store, the directories are the key prefixes.
+// It implements the local.FileSystem interface.
+type FS struct {
+	store Store
+}
+
+// entry is a file or a directory of the object store
+type entry struct {
+	name    string
+	isdir   bool
+	modTime int64
+}
+
+// NewFS create a new file system on the object store
+func NewFS(store Store) *FS {
+	return &FS{store: store}
+}
+
+// ReadFile read the file
+func (fsys *FS) ReadFile(file string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	data, err := fsys.store.Get(ctx, key(file))
+	if err == ErrNotFound {
+		return nil, &iofs.PathError{Op: "open", Path: file, Err: os.ErrNotExist}
+	}
+	return data, err
+}
+
+// WriteFile write the file
+func (fsys *FS) WriteFile(file string, data []byte, perm uint32) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	err := fsys.store.Put(ctx, key(file), data)
+	if err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// Write write the content of the reader to the file
+func (fsys *FS) Write(file string, reader io.Reader, perm uint32) (int, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return 0, err
+	}
+	return fsys.WriteFile(file, data, perm)
+}
+
+// ReadDir read the directory, returns the paths of the files and the sub directories
+func (fsys *FS) ReadDir(dir string, recursive bool) ([]string, error) {
+	entries, err := fsys.entries(dir, recursive)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, entry := range entries {
+		names = append(names, entry.name)
+	}
+	return names, nil
+}
+
+// Glob returns the paths matching the pattern
+func (fsys *FS) Glob(pattern string) ([]string, error) {
+	pattern = "/" + key(pattern)
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+
+	// List the directory before the first wildcard
+	index := strings.IndexAny(pattern, `*?[\`)
+	if index < 0 {
+		exists, err := fsys.Exists(pattern)
+		if err != nil || !exists {
+			return []string{}, err
+		}
+		return []string{pattern}, nil
+	}
+
+	entries, err := fsys.entries(path.Dir(pattern[:index+1]), true)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []string{}
+	for _, entry := range entries {
+		if matched, _ := path.Match(pattern, entry.name); matched {
+			matches = append(matches, entry.name)
+		}
+	}
+	return matches, nil
+}
+
+// Walk walks the directory like filepath.Walk, the patterns filter the file names
+func (fsys *FS) Walk(root string, handler func(root, filename string, isdir bool) error, patterns ...string) error {
+	entries, err := fsys.entries(root, true)
+	if err != nil {
+		return err
+	}
+
+	skipped := []string{}
+	for _, entry := range entries {
+		skip := false
+		for _, dir := range skipped {
+			if strings.HasPrefix(entry.name, dir+"/") {
+				skip = true
+				break
+			}
+		}
+		if skip {
+			continue
+		}
+
+		if !entry.isdir && !matchAny(path.Base(entry.name), patterns) {
+			continue
+		}
+
+		err := handler(root, entry.name, entry.isdir)
+		if err == filepath.SkipAll {
+			return nil
+		}
+
+		if err == filepath.SkipDir {
+			if entry.isdir {
+				skipped = append(skipped, entry.name)
+				continue
+			}
+			skipped = append(skipped, path.Dir(entry.name))
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// List returns the files under the directory with the given extensions, the newest first.
+// returns the files of the page, the total and the page count
+func (fsys *FS) List(dir string, types []string, page, pageSize int, filter func(string) bool) ([]string, int, int, error) {
+	entries, err := fsys.entries(dir, true)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	exts := map[string]bool{}
+	for _, typ := range types {
+		exts[strings.ToLower("."+strings.TrimPrefix(typ, "."))] = true
+	}
+
+	files := []entry{}
+	for _, entry := range entries {
+		if entry.isdir {
+			continue
+		}
+		if len(exts) > 0 && !exts[strings.ToLower(path.Ext(entry.name))] {
+			continue
+		}
+		if filter != nil && !filter(entry.name) {
+			continue
+		}
+		files = append(files, entry)
+	}
+
+	sort.SliceStable(files, func(i, j int) bool { return files[i].modTime > files[j].modTime })
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+
+	total := len(files)
+	pagecnt := (total + pageSize - 1) / pageSize
+	names := []string{}
+	for i := (page - 1) * pageSize; i < total && i < page*pageSize; i++ {
+		names = append(names, files[i].name)
+	}
+	return names, total, pagecnt, nil
+}
+
+// Exists check if the file or the directory exists
+func (fsys *FS) Exists(name string) (bool, error) {
+	isfile, err := fsys.isFile(name)
+	if err != nil || isfile {
+		return isfile, err
+	}
+	return fsys.isDir(name)
+}
+
+// IsDir check if the path is a directory
+func (fsys *FS) IsDir(name string) bool {
+	isdir, _ := fsys.isDir(name)
+	return isdir
+}
+
+// IsFile check if the path is a file
+func (fsys *FS) IsFile(name string) bool {
+	isfile, _ := fsys.isFile(name)
+	return isfile
+}
+
+// Size returns the size of the file
+func (fsys *FS) Size(name string) (int, error) {
+	object, err := fsys.stat(name)
+	if err != nil {
+		return 0, err
+	}
+	return int(object.Size), nil
+}
+
+// ModTime returns the modification time of the file
+func (fsys *FS) ModTime(name string) (time.Time, error) {
+	object, err := fsys.stat(name)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return object.ModTime, nil
+}
+
+// Remove remove the file or the empty directory
+func (fsys *FS) Remove(name string) error {
+	isfile, err := fsys.isFile(name)
+	if err != nil {
+		return err
+	}
+
+	if isfile {
+		ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+		defer cancel()
+		return fsys.store.Delete(ctx, []string{key(name)})
+	}
+
+	isdir, err := fsys.isDir(name)
+	if err != nil {
+		return err
+	}
+
+	if isdir {
+		return &iofs.PathError{Op: "remove", Path: name, Err: fmt.Errorf("directory not empty")}
+	}
+	return &iofs.PathError{Op: "remove", Path: name, Err: os.ErrNotExist}
+}
+
+// RemoveAll remove the file or the directory and its contents
+func (fsys *FS) RemoveAll(name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	keys := []string{}
+	k := key(name)
+	if k != "" {
+		if _, err := fsys.store.Stat(ctx, k); err == nil {
+			keys = append(keys, k)
+		}
+	}
+
+	objects, err := fsys.store.List(ctx, prefix(name), 0)
+	if err != nil {
+		return err
+	}
+	for _, object := range objects {
+		keys = append(keys, object.Key)
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+	return fsys.store.Delete(ctx, keys)
+}
+
+// Copy copy the file or the directory
+func (fsys *FS) Copy(src string, dest string) error {
+	if fsys.IsFile(src) {
+		data, err := fsys.ReadFile(src)
+		if err != nil {
+			return err
+		}
+		_, err = fsys.WriteFile(dest, data, 0644)
+		return err
+	}
+
+	entries, err := fsys.entries(src, true)
+	if err != nil {
+		return err
+	}
+
+	from := "/" + key(src)
+	for _, entry := range entries {
+		if entry.isdir {
+			continue
+		}
+
+		data, err := fsys.ReadFile(entry.name)
+		if err != nil {
+			return err
+		}
+
+		_, err = fsys.WriteFile(path.Join(dest, strings.TrimPrefix(entry.name, from)), data, 0644)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MimeType returns the mime type of the file
+func (fsys *FS) MimeType(name string) (string, error) {
+	if typ := mime.TypeByExtension(path.Ext(name)); typ != "" {
+		return typ, nil
+	}
+
+	data, err := fsys.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+	return http.DetectContentType(data), nil
+}
+
+// Resize resize the image, keeps the aspect ratio if the width or the height is 0
+func (fsys *FS) Resize(inputPath, outputPath string, width, height uint) error {
+	data, err := fsys.ReadFile(inputPath)
+	if err != nil {
+		return err
+	}
+
+	src, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	bounds := src.Bounds()
+	if width == 0 && height == 0 {
+		width, height = uint(bounds.Dx()), uint(bounds.Dy())
+	} else if width == 0 {
+		width = uint(float64(bounds.Dx()) * float64(height) / float64(bounds.Dy()))
+	} else if height == 0 {
+		height = uint(float64(bounds.Dy()) * float64(width) / float64(bounds.Dx()))
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, int(width), int(height)))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+
+	var buf bytes.Buffer
+	switch format {
+	case "png":
+		err = png.Encode(&buf, dst)
+	case "gif":
+		err = gif.Encode(&buf, dst, nil)
+	default:
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 90})
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = fsys.WriteFile(outputPath, buf.Bytes(), 0644)
+	return err
+}
+
+// Root the files are not on the local disk
+func (fsys *FS) Root() string {
+	return ""
+}
+
+func (fsys *FS) isFile(name string) (bool, error) {
+	k := key(name)
+	if k == "" {
+		return false, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	_, err := fsys.store.Stat(ctx, k)
+	if err == ErrNotFound {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (fsys *FS) stat(name string) (*Object, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	object, err := fsys.store.Stat(ctx, key(name))
+	if err == ErrNotFound {
+		return nil, &iofs.PathError{Op: "stat", Path: name, Err: os.ErrNotExist}
+	}
+	return object, err
+}
+
+func (fsys *FS) isDir(name string) (bool, error) {
+	if key(name) == "" {
+		return true, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	objects, err := fsys.store.List(ctx, prefix(name), 1)
+	if err != nil {
+		return false, err
+	}
+	return len(objects) > 0, nil
+}
+
+// entries returns the files and the directories under the directory, sorted by path
+func (fsys *FS) entries(dir string, recursive bool) ([]entry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	p := prefix(dir)
+	objects, err := fsys.store.List(ctx, p, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	entries := []entry{}
+	for _, object := range objects {
+		rel := strings.TrimPrefix(object.Key, p)
+		parts := strings.Split(rel, "/")
+		if !recursive && len(parts) > 1 {
+			parts = parts[:1]
+			name := "/" + p + parts[0]
+			if !seen[name] {
+				seen[name] = true
+				entries = append(entries, entry{name: name, isdir: true})
+			}
+			continue
+		}
+
+		// The parent directories
+		for i := 1; i < len(parts); i++ {
+			name := "/" + p + strings.Join(parts[:i], "/")
+			if !seen[name] {
+				seen[name] = true
+				entries = append(entries, entry{name: name, isdir: true})
+			}
+		}
+
+		name := "/" + object.Key
+		if !seen[name] {
+			seen[name] = true
+			entries = append(entries, entry{name: name, modTime: object.ModTime.UnixNano()})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+	return entries, nil
+}
+
+// key returns the object key of the path
+func key(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(name)), "/")
+}
+
+// prefix returns the key prefix of the directory
+func prefix(dir string) string {
+	k := key(dir)
+	if k == "" {
+		return ""
+	}
+	return k + "/"
+}
+
+func matchAny(name string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
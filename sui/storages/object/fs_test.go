@@ -0,0 +1,228 @@
+package object
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// memory is the in-memory object store for testing
+type memory struct {
+	objects map[string][]byte
+	times   map[string]time.Time
+	mu      sync.Mutex
+}
+
+func newMemory() *memory {
+	return &memory{objects: map[string][]byte{}, times: map[string]time.Time{}}
+}
+
+func (m *memory) Get(ctx context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, has := m.objects[key]
+	if !has {
+		return nil, ErrNotFound
+	}
+	return data, nil
+}
+
+func (m *memory) Stat(ctx context.Context, key string) (*Object, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, has := m.objects[key]
+	if !has {
+		return nil, ErrNotFound
+	}
+	return &Object{Key: key, Size: int64(len(data)), ModTime: m.times[key]}, nil
+}
+
+func (m *memory) Put(ctx context.Context, key string, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.objects[key] = data
+	m.times[key] = time.Now()
+	return nil
+}
+
+func (m *memory) Delete(ctx context.Context, keys []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, key := range keys {
+		delete(m.objects, key)
+	}
+	return nil
+}
+
+func (m *memory) List(ctx context.Context, prefix string, limit int) ([]Object, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	objects := []Object{}
+	for key, data := range m.objects {
+		if strings.HasPrefix(key, prefix) {
+			objects = append(objects, Object{Key: key, Size: int64(len(data)), ModTime: m.times[key]})
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	if limit > 0 && len(objects) > limit {
+		objects = objects[:limit]
+	}
+	return objects, nil
+}
+
+func TestFS(t *testing.T) {
+	testFS(t, NewFS(newMemory()), "/templates")
+}
+
+func TestFSOnS3(t *testing.T) {
+	if os.Getenv("S3_ACCESS_KEY") == "" || os.Getenv("S3_SECRET_KEY") == "" || os.Getenv("S3_BUCKET") == "" {
+		t.Skip("S3 configuration not available (set S3_API, S3_ACCESS_KEY, S3_SECRET_KEY, S3_BUCKET environment variables)")
+	}
+
+	prefix := "sui-test-" + uuid.NewString()
+	store, err := NewS3(map[string]interface{}{
+		"endpoint": os.Getenv("S3_API"),
+		"key":      os.Getenv("S3_ACCESS_KEY"),
+		"secret":   os.Getenv("S3_SECRET_KEY"),
+		"bucket":   os.Getenv("S3_BUCKET"),
+		"prefix":   prefix,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fsys := NewFS(store)
+	defer fsys.RemoveAll("/")
+	testFS(t, fsys, "/templates")
+
+	_, err = store.Get(context.Background(), "not-found")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func testFS(t *testing.T, fsys *FS, root string) {
+	files := map[string]string{
+		"/default/template.json":             `{"name": "Default"}`,
+		"/default/index/index.html":          `<div>Index</div>`,
+		"/default/index/index.css":           `div {}`,
+		"/default/blog/[id]/[id].html":       `<div>{{ id }}</div>`,
+		"/default/__assets/css/app.css":      `body {}`,
+		"/default/__document.html":           `<html></html>`,
+		"/default/__locales/zh-cn/index.yml": `messages: {}`,
+	}
+	for name, content := range files {
+		_, err := fsys.WriteFile(filepath.Join(root, name), []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Files and directories
+	data, err := fsys.ReadFile(root + "/default/index/index.html")
+	assert.Nil(t, err)
+	assert.Equal(t, `<div>Index</div>`, string(data))
+
+	_, err = fsys.ReadFile(root + "/default/none.html")
+	assert.True(t, os.IsNotExist(err))
+
+	assert.True(t, fsys.IsDir(root+"/default"))
+	assert.False(t, fsys.IsDir(root+"/default/index/index.html"))
+	assert.True(t, fsys.IsFile(root+"/default/index/index.html"))
+
+	size, err := fsys.Size(root + "/default/index/index.html")
+	assert.Nil(t, err)
+	assert.Equal(t, len(`<div>Index</div>`), size)
+
+	modTime, err := fsys.ModTime(root + "/default/index/index.html")
+	assert.Nil(t, err)
+	assert.False(t, modTime.IsZero())
+
+	_, err = fsys.Size(root + "/default/none.html")
+	assert.True(t, os.IsNotExist(err))
+	assert.False(t, fsys.IsFile(root+"/default/index"))
+	exists, err := fsys.Exists(root + "/default/blog")
+	assert.Nil(t, err)
+	assert.True(t, exists)
+
+	dirs, err := fsys.ReadDir(root, false)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{root + "/default"}, dirs)
+
+	children, err := fsys.ReadDir(root+"/default", false)
+	assert.Nil(t, err)
+	assert.Contains(t, children, root+"/default/template.json")
+	assert.Contains(t, children, root+"/default/__assets")
+	assert.NotContains(t, children, root+"/default/__assets/css")
+
+	matches, err := fsys.Glob(root + "/default/*/*.html")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{root + "/default/index/index.html"}, matches)
+
+	// Walk the pages, skip the __ directories
+	pages := []string{}
+	err = fsys.Walk(root+"/default", func(_, file string, isdir bool) error {
+		if strings.HasPrefix(filepath.Base(file), "__") {
+			if isdir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !isdir {
+			pages = append(pages, file)
+		}
+		return nil
+	}, "*.html")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{root + "/default/blog/[id]/[id].html", root + "/default/index/index.html"}, pages)
+
+	// Media
+	img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+	_, err = fsys.Write(root+"/default/__assets/upload/20240501/A.png", &buf, 0644)
+	assert.Nil(t, err)
+	_, err = fsys.WriteFile(root+"/default/__assets/upload/20240501/B.mp4", []byte("mp4"), 0644)
+	assert.Nil(t, err)
+
+	media, total, pagecnt, err := fsys.List(root+"/default/__assets/upload", []string{".png", ".jpg"}, 1, 10, func(s string) bool { return true })
+	assert.Nil(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, 1, pagecnt)
+	assert.Equal(t, []string{root + "/default/__assets/upload/20240501/A.png"}, media)
+
+	typ, err := fsys.MimeType(root + "/default/__assets/upload/20240501/A.png")
+	assert.Nil(t, err)
+	assert.Equal(t, "image/png", typ)
+
+	err = fsys.Resize(root+"/default/__assets/upload/20240501/A.png", root+"/default/__assets/.cache/20x0/A.png", 20, 0)
+	assert.Nil(t, err)
+	data, err = fsys.ReadFile(root + "/default/__assets/.cache/20x0/A.png")
+	assert.Nil(t, err)
+	thumb, err := png.Decode(bytes.NewReader(data))
+	assert.Nil(t, err)
+	assert.Equal(t, 20, thumb.Bounds().Dx())
+	assert.Equal(t, 10, thumb.Bounds().Dy())
+
+	// Copy and remove
+	err = fsys.Copy(root+"/default/index", root+"/default/home")
+	assert.Nil(t, err)
+	assert.True(t, fsys.IsFile(root+"/default/home/index.css"))
+
+	assert.NotNil(t, fsys.Remove(root+"/default/home"))
+	assert.Nil(t, fsys.RemoveAll(root+"/default/home"))
+	assert.False(t, fsys.IsDir(root+"/default/home"))
+
+	assert.Nil(t, fsys.Remove(root+"/default/index/index.css"))
+	assert.False(t, fsys.IsFile(root+"/default/index/index.css"))
+	assert.Equal(t, "", fsys.Root())
+}
@@ -0,0 +1,73 @@
+package object
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/yaoapp/yao/sui/core"
+	"github.com/yaoapp/yao/sui/storages/local"
+)
+
+// ErrNotFound the object does not exist
+var ErrNotFound = errors.New("object not found")
+
+// Timeout the default timeout of a store request
+var Timeout = 30 * time.Second
+
+// Store is the interface of an object store, the keys are slash separated paths without the leading slash
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Stat(ctx context.Context, key string) (*Object, error)
+	Put(ctx context.Context, key string, data []byte) error
+	Delete(ctx context.Context, keys []string) error
+	List(ctx context.Context, prefix string, limit int) ([]Object, error) // all the objects under the prefix, limit 0 for no limit
+}
+
+// Object is the stored object
+type Object struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+}
+
+// New create a new sui storing the templates, pages and media in an object store.
+// The driver is "s3" for the S3 compatible object storage or "database" for a database table.
+func New(dsl *core.DSL) (*local.Local, error) {
+
+	option := map[string]interface{}{}
+	if dsl.Storage.Option != nil {
+		for name, value := range dsl.Storage.Option {
+			if v, ok := value.(string); ok && strings.HasPrefix(v, "$ENV.") {
+				value = os.Getenv(strings.TrimPrefix(v, "$ENV."))
+			}
+			option[name] = value
+		}
+	}
+
+	var store Store
+	var err error
+	switch strings.ToLower(dsl.Storage.Driver) {
+	case "s3":
+		store, err = NewS3(option)
+
+	case "database":
+		store, err = NewDatabase(dsl.ID, option)
+
+	default:
+		err = fmt.Errorf("%s is not a valid object storage driver", dsl.Storage.Driver)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, has := option["root"]; !has {
+		option["root"] = "/templates"
+	}
+	dsl.Storage.Option = option
+	return local.NewWith(dsl, NewFS(store)), nil
+}
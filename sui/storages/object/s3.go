@@ -0,0 +1,209 @@
+package object
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// S3 is the S3 compatible object store (AWS S3, MinIO, Cloudflare R2 ...)
+type S3 struct {
+	Endpoint string
+	Region   string
+	Bucket   string
+	prefix   string
+	client   *s3.Client
+}
+
+// NewS3 create a new S3 store
+// options: endpoint, region, key, secret, bucket, prefix
+func NewS3(options map[string]interface{}) (*S3, error) {
+	store := &S3{Region: "auto"}
+
+	key, _ := options["key"].(string)
+	secret, _ := options["secret"].(string)
+	if endpoint, ok := options["endpoint"].(string); ok {
+		store.Endpoint = endpoint
+	}
+
+	if region, ok := options["region"].(string); ok && region != "" {
+		store.Region = region
+	}
+
+	if bucket, ok := options["bucket"].(string); ok {
+		store.Bucket = bucket
+	}
+
+	if prefix, ok := options["prefix"].(string); ok {
+		store.prefix = strings.Trim(prefix, "/")
+	}
+
+	if key == "" || secret == "" {
+		return nil, fmt.Errorf("key and secret are required")
+	}
+
+	if store.Bucket == "" {
+		return nil, fmt.Errorf("bucket is required")
+	}
+
+	opts := s3.Options{
+		Region:       store.Region,
+		Credentials:  credentials.NewStaticCredentialsProvider(key, secret, ""),
+		UsePathStyle: true,
+	}
+
+	if store.Endpoint != "" {
+		opts.BaseEndpoint = aws.String(strings.TrimSuffix(store.Endpoint, "/"+store.Bucket))
+	}
+
+	store.client = s3.New(opts)
+	return store, nil
+}
+
+// Get get the object content
+func (store *S3) Get(ctx context.Context, key string) ([]byte, error) {
+	res, err := store.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(store.Bucket),
+		Key:    aws.String(store.key(key)),
+	})
+	if err != nil {
+		return nil, store.error(err)
+	}
+	defer res.Body.Close()
+	return io.ReadAll(res.Body)
+}
+
+// Stat get the object information
+func (store *S3) Stat(ctx context.Context, key string) (*Object, error) {
+	res, err := store.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(store.Bucket),
+		Key:    aws.String(store.key(key)),
+	})
+	if err != nil {
+		return nil, store.error(err)
+	}
+
+	object := &Object{Key: key, Size: aws.ToInt64(res.ContentLength)}
+	if res.LastModified != nil {
+		object.ModTime = *res.LastModified
+	}
+	return object, nil
+}
+
+// Put put the object
+func (store *S3) Put(ctx context.Context, key string, data []byte) error {
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	_, err := store.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(store.Bucket),
+		Key:         aws.String(store.key(key)),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(contentType),
+	})
+	return err
+}
+
+// Delete delete the objects
+func (store *S3) Delete(ctx context.Context, keys []string) error {
+	for start := 0; start < len(keys); start += 1000 {
+		end := start + 1000
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		objects := []types.ObjectIdentifier{}
+		for _, key := range keys[start:end] {
+			objects = append(objects, types.ObjectIdentifier{Key: aws.String(store.key(key))})
+		}
+
+		res, err := store.client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(store.Bucket),
+			Delete: &types.Delete{Objects: objects, Quiet: aws.Bool(true)},
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(res.Errors) > 0 {
+			return fmt.Errorf("delete %s: %s", aws.ToString(res.Errors[0].Key), aws.ToString(res.Errors[0].Message))
+		}
+	}
+	return nil
+}
+
+// List list the objects under the prefix
+func (store *S3) List(ctx context.Context, prefix string, limit int) ([]Object, error) {
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(store.Bucket),
+		Prefix: aws.String(store.key(prefix)),
+	}
+	if limit > 0 && limit < 1000 {
+		input.MaxKeys = aws.Int32(int32(limit))
+	}
+
+	objects := []Object{}
+	paginator := s3.NewListObjectsV2Paginator(store.client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, content := range page.Contents {
+			key := aws.ToString(content.Key)
+			if store.prefix != "" {
+				key = strings.TrimPrefix(key, store.prefix+"/")
+			}
+
+			object := Object{Key: key, Size: aws.ToInt64(content.Size)}
+			if content.LastModified != nil {
+				object.ModTime = *content.LastModified
+			}
+			objects = append(objects, object)
+
+			if limit > 0 && len(objects) >= limit {
+				return objects, nil
+			}
+		}
+	}
+	return objects, nil
+}
+
+// key returns the key with the prefix
+func (store *S3) key(key string) string {
+	if store.prefix == "" {
+		return key
+	}
+	if key == "" {
+		return store.prefix + "/"
+	}
+	return store.prefix + "/" + key
+}
+
+// error converts the not found errors to ErrNotFound
+func (store *S3) error(err error) error {
+	var noSuchKey *types.NoSuchKey
+	var notFound *types.NotFound
+	if errors.As(err, &noSuchKey) || errors.As(err, &notFound) {
+		return ErrNotFound
+	}
+
+	var res interface{ HTTPStatusCode() int }
+	if errors.As(err, &res) && res.HTTPStatusCode() == 404 {
+		return ErrNotFound
+	}
+	return err
+}
@@ -201,8 +201,10 @@ var testSystemModels = map[string]string{
 	"__yao.agent.message":      "yao/models/agent/message.mod.yao",
 	"__yao.agent.resume":       "yao/models/agent/resume.mod.yao",
 	"__yao.agent.search":       "yao/models/agent/search.mod.yao",
+	"__yao.agent.usage":        "yao/models/agent/usage.mod.yao",
 	"__yao.attachment":         "yao/models/attachment.mod.yao",
 	"__yao.audit":              "yao/models/audit.mod.yao",
+	"__yao.cluster":            "yao/models/cluster.mod.yao",
 	"__yao.config":             "yao/models/config.mod.yao",
 	"__yao.dsl":                "yao/models/dsl.mod.yao",
 	"__yao.invitation":         "yao/models/invitation.mod.yao",
@@ -210,10 +212,13 @@ var testSystemModels = map[string]string{
 	"__yao.job":                "yao/models/job/job.mod.yao",
 	"__yao.job.execution":      "yao/models/job/execution.mod.yao",
 	"__yao.job.log":            "yao/models/job/log.mod.yao",
+	"__yao.job.lock":           "yao/models/job/lock.mod.yao",
 	"__yao.kb.collection":      "yao/models/kb/collection.mod.yao",
 	"__yao.kb.document":        "yao/models/kb/document.mod.yao",
 	"__yao.team":               "yao/models/team.mod.yao",
 	"__yao.member":             "yao/models/member.mod.yao",
+	"__yao.migration":          "yao/models/migration.mod.yao",
+	"__yao.seed":               "yao/models/seed.mod.yao",
 	"__yao.user":               "yao/models/user.mod.yao",
 	"__yao.role":               "yao/models/role.mod.yao",
 	"__yao.user.type":          "yao/models/user/type.mod.yao",
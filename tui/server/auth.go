@@ -0,0 +1,326 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/openapi/oauth"
+	userapi "github.com/yaoapp/yao/openapi/user"
+	"github.com/yaoapp/yao/openapi/utils"
+	"golang.org/x/crypto/ssh"
+)
+
+// User the authenticated Yao user of a SSH connection
+type User struct {
+	ID      string `json:"id"`
+	TeamID  string `json:"team_id,omitempty"`
+	Subject string `json:"subject,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Email   string `json:"email,omitempty"`
+	Scope   string `json:"scope,omitempty"`
+	MFA     bool   `json:"-"` // The user must enter a one-time code after the password or key
+}
+
+// Authenticator authenticates the SSH users
+type Authenticator interface {
+	// Password verifies the password of the user
+	Password(username string, password string) (*User, error)
+
+	// PublicKey verifies the public key is authorized for the user
+	PublicKey(username string, key ssh.PublicKey) (*User, error)
+
+	// OTP verifies the one-time code of a user with MFA enabled
+	OTP(user *User, code string) error
+}
+
+// YaoAuthenticator authenticates the SSH users with the Yao users.
+// The username is the email, phone number, preferred username or user id.
+// The public keys are listed in the authorized keys file with a user option:
+//
+//	user="alice@example.com" ssh-ed25519 AAAAC3Nza... alice@laptop
+type YaoAuthenticator struct {
+	AuthorizedKeys string
+}
+
+var mobilePattern = regexp.MustCompile(`^\+?[0-9]{10,15}$`)
+
+// Password verifies the password of the Yao user
+func (auth *YaoAuthenticator) Password(username string, password string) (*User, error) {
+	ctx := context.Background()
+	provider, err := oauth.OAuth.GetUserProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := auth.lookup(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, _ := user["password_hash"].(string)
+	if hash == "" {
+		return nil, fmt.Errorf("user %s has no password", username)
+	}
+
+	valid, err := provider.VerifyPassword(ctx, password, hash)
+	if err != nil || !valid {
+		return nil, fmt.Errorf("invalid username or password")
+	}
+
+	return auth.user(ctx, user)
+}
+
+// PublicKey verifies the key is authorized for the Yao user
+func (auth *YaoAuthenticator) PublicKey(username string, key ssh.PublicKey) (*User, error) {
+	identifier, err := auth.keyOwner(key)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	owner, err := auth.lookup(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := auth.lookup(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if utils.ToString(owner["user_id"]) != utils.ToString(user["user_id"]) {
+		return nil, fmt.Errorf("the key is not authorized for %s", username)
+	}
+
+	return auth.user(ctx, user)
+}
+
+// OTP verifies the MFA code of the Yao user
+func (auth *YaoAuthenticator) OTP(user *User, code string) error {
+	provider, err := oauth.OAuth.GetUserProvider()
+	if err != nil {
+		return err
+	}
+
+	valid, err := provider.VerifyMFACode(context.Background(), user.ID, strings.TrimSpace(code))
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return fmt.Errorf("invalid one-time code")
+	}
+	return nil
+}
+
+// lookup the user for authentication by the username
+func (auth *YaoAuthenticator) lookup(ctx context.Context, username string) (map[string]interface{}, error) {
+	provider, err := oauth.OAuth.GetUserProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	types := []string{"preferred_username", "user_id"}
+	if strings.Contains(username, "@") {
+		types = []string{"email"}
+	} else if mobilePattern.MatchString(username) {
+		types = []string{"phone_number", "preferred_username"}
+	}
+
+	for _, typ := range types {
+		user, err := provider.GetUserForAuth(ctx, username, typ)
+		if err == nil && user != nil {
+			return user, nil
+		}
+	}
+	return nil, fmt.Errorf("user %s not found", username)
+}
+
+// user converts the user record, only the active users can login
+func (auth *YaoAuthenticator) user(ctx context.Context, data map[string]interface{}) (*User, error) {
+	status := utils.ToString(data["status"])
+	if status != "active" {
+		return nil, fmt.Errorf("account status is %s", status)
+	}
+
+	user := &User{
+		ID:    utils.ToString(data["user_id"]),
+		Email: utils.ToString(data["email"]),
+		Name:  utils.ToString(data["preferred_username"]),
+		MFA:   utils.ToBool(data["mfa_enabled"]),
+	}
+
+	provider, err := oauth.OAuth.GetUserProvider()
+	if err == nil {
+		if detail, err := provider.GetUserWithScopes(ctx, user.ID); err == nil {
+			if name := utils.ToString(detail["name"]); name != "" {
+				user.Name = name
+			}
+			if scopes, ok := detail["scopes"].([]string); ok {
+				user.Scope = strings.Join(scopes, " ")
+			}
+		}
+	}
+
+	client := userapi.GetYaoClientConfig()
+	if client != nil {
+		if user.Scope == "" {
+			user.Scope = strings.Join(client.Scopes, " ")
+		}
+		user.Subject, err = oauth.OAuth.Subject(client.ClientID, user.ID)
+		if err != nil {
+			log.Warn("[TUI] SSH get the subject of %s error: %s", user.ID, err.Error())
+		}
+	}
+	return user, nil
+}
+
+// keyOwner returns the user of the public key in the authorized keys file
+func (auth *YaoAuthenticator) keyOwner(key ssh.PublicKey) (string, error) {
+	data, err := os.ReadFile(auth.AuthorizedKeys)
+	if err != nil {
+		return "", fmt.Errorf("public key authentication is not configured")
+	}
+
+	wire := key.Marshal()
+	for len(bytes.TrimSpace(data)) > 0 {
+		authorized, _, options, rest, err := ssh.ParseAuthorizedKey(data)
+		if err != nil {
+			break
+		}
+		data = rest
+
+		if !bytes.Equal(authorized.Marshal(), wire) {
+			continue
+		}
+
+		for _, option := range options {
+			if strings.HasPrefix(option, "user=") {
+				return strings.Trim(strings.TrimPrefix(option, "user="), `"`), nil
+			}
+		}
+		return "", fmt.Errorf("the key has no user option")
+	}
+	return "", fmt.Errorf("the key is not authorized")
+}
+
+// serverConfig the SSH server config, users with MFA enabled must enter a one-time code after the password or key
+func (server *Server) serverConfig() *ssh.ServerConfig {
+	cfg := &ssh.ServerConfig{
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			user, err := server.Auth.PublicKey(conn.User(), key)
+			if err != nil {
+				return nil, err
+			}
+			return server.permissions(user)
+		},
+	}
+
+	if server.Option.Password {
+		cfg.PasswordCallback = func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			user, err := server.Auth.Password(conn.User(), string(password))
+			if err != nil {
+				log.Warn("[TUI] SSH password authentication of %s from %s failed: %s", conn.User(), conn.RemoteAddr().String(), err.Error())
+				return nil, err
+			}
+			return server.permissions(user)
+		}
+
+		// For the clients prefer keyboard-interactive
+		cfg.KeyboardInteractiveCallback = func(conn ssh.ConnMetadata, client ssh.KeyboardInteractiveChallenge) (*ssh.Permissions, error) {
+			answers, err := client("", "", []string{"Password: "}, []bool{false})
+			if err != nil {
+				return nil, err
+			}
+			if len(answers) != 1 {
+				return nil, fmt.Errorf("password is required")
+			}
+
+			user, err := server.Auth.Password(conn.User(), answers[0])
+			if err != nil {
+				log.Warn("[TUI] SSH password authentication of %s from %s failed: %s", conn.User(), conn.RemoteAddr().String(), err.Error())
+				return nil, err
+			}
+			if user.MFA {
+				if err := server.otp(client, user); err != nil {
+					return nil, err
+				}
+			}
+			return server.grant(user), nil
+		}
+	}
+
+	if server.Option.Banner != "" {
+		cfg.BannerCallback = func(conn ssh.ConnMetadata) string { return server.Option.Banner }
+	}
+	return cfg
+}
+
+// permissions grants the user, or asks for the one-time code if the user has MFA enabled
+func (server *Server) permissions(user *User) (*ssh.Permissions, error) {
+	if !user.MFA {
+		return server.grant(user), nil
+	}
+
+	return nil, &ssh.PartialSuccessError{
+		Next: ssh.ServerAuthCallbacks{
+			KeyboardInteractiveCallback: func(conn ssh.ConnMetadata, client ssh.KeyboardInteractiveChallenge) (*ssh.Permissions, error) {
+				if err := server.otp(client, user); err != nil {
+					return nil, err
+				}
+				return server.grant(user), nil
+			},
+		},
+	}
+}
+
+// otp asks for the one-time code
+func (server *Server) otp(client ssh.KeyboardInteractiveChallenge, user *User) error {
+	answers, err := client("", "", []string{"One-time code: "}, []bool{true})
+	if err != nil {
+		return err
+	}
+	if len(answers) != 1 {
+		return fmt.Errorf("one-time code is required")
+	}
+
+	err = server.Auth.OTP(user, answers[0])
+	if err != nil {
+		log.Warn("[TUI] SSH one-time code of %s failed: %s", user.ID, err.Error())
+	}
+	return err
+}
+
+// grant the permissions of the user, the user is passed to the session by the extensions
+func (server *Server) grant(user *User) *ssh.Permissions {
+	return &ssh.Permissions{
+		Extensions: map[string]string{
+			"user_id": user.ID,
+			"team_id": user.TeamID,
+			"subject": user.Subject,
+			"name":    user.Name,
+			"email":   user.Email,
+			"scope":   user.Scope,
+		},
+	}
+}
+
+// userFromPermissions returns the user granted by the authentication
+func userFromPermissions(permissions *ssh.Permissions) *User {
+	user := &User{}
+	if permissions == nil {
+		return user
+	}
+	ext := permissions.Extensions
+	user.ID = ext["user_id"]
+	user.TeamID = ext["team_id"]
+	user.Subject = ext["subject"]
+	user.Name = ext["name"]
+	user.Email = ext["email"]
+	user.Scope = ext["scope"]
+	return user
+}
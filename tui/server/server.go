@@ -0,0 +1,274 @@
+package server
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/config"
+	"golang.org/x/crypto/ssh"
+)
+
+// DefaultAddr is the default listen address of the SSH server
+const DefaultAddr = ":2222"
+
+// Option the SSH server option
+type Option struct {
+	Addr           string        // Listen address, default ":2222"
+	HostKey        string        // Host key file, generated if not exists, default <data>/tui/ssh/host_ed25519_key
+	AuthorizedKeys string        // Authorized keys file, default <data>/tui/ssh/authorized_keys
+	TUI            string        // The TUI served when the client does not request one
+	TUIs           []string      // The TUIs allowed to be served, empty means all loaded TUIs
+	Password       bool          // Allow password authentication
+	MaxSessions    int           // Maximum number of concurrent sessions, 0 means unlimited
+	SessionTimeout time.Duration // The lifetime of the session data, default 12h
+	Banner         string        // Shown before authentication
+}
+
+// Server serves the TUIs over SSH, each session runs its own bubbletea program
+type Server struct {
+	Option   Option
+	Auth     Authenticator
+	Handler  Handler // Creates the model of the session, default DefaultHandler
+	config   *ssh.ServerConfig
+	listener net.Listener
+	sessions map[string]*Session
+	active   int // Accepted session channels
+	mu       sync.Mutex
+	closed   bool
+}
+
+// New create a new SSH server
+func New(option Option, auth Authenticator) (*Server, error) {
+	if option.Addr == "" {
+		option.Addr = DefaultAddr
+	}
+
+	if option.HostKey == "" {
+		option.HostKey = filepath.Join(config.Conf.DataRoot, "tui", "ssh", "host_ed25519_key")
+	}
+
+	if option.AuthorizedKeys == "" {
+		option.AuthorizedKeys = filepath.Join(config.Conf.DataRoot, "tui", "ssh", "authorized_keys")
+	}
+
+	if option.SessionTimeout <= 0 {
+		option.SessionTimeout = 12 * time.Hour
+	}
+
+	if auth == nil {
+		auth = &YaoAuthenticator{AuthorizedKeys: option.AuthorizedKeys}
+	}
+
+	signer, err := hostKey(option.HostKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// The output of the sessions is not the terminal of the server, render the colors for the SSH clients
+	lipgloss.SetColorProfile(termenv.ANSI256)
+
+	server := &Server{Option: option, Auth: auth, sessions: map[string]*Session{}}
+	server.config = server.serverConfig()
+	server.config.AddHostKey(signer)
+	return server, nil
+}
+
+// Start listen and serve the SSH connections, blocks until the server is closed
+func (server *Server) Start() error {
+	listener, err := net.Listen("tcp", server.Option.Addr)
+	if err != nil {
+		return err
+	}
+	return server.Serve(listener)
+}
+
+// Serve the SSH connections of the listener, blocks until the server is closed
+func (server *Server) Serve(listener net.Listener) error {
+	server.mu.Lock()
+	server.listener = listener
+	server.mu.Unlock()
+
+	log.Info("[TUI] SSH server listening on %s", listener.Addr().String())
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if server.isClosed() {
+				return nil
+			}
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				continue
+			}
+			return err
+		}
+		go server.handleConn(conn)
+	}
+}
+
+// Close stop the server and close all the sessions
+func (server *Server) Close() error {
+	server.mu.Lock()
+	server.closed = true
+	listener := server.listener
+	sessions := []*Session{}
+	for _, sess := range server.sessions {
+		sessions = append(sessions, sess)
+	}
+	server.mu.Unlock()
+
+	for _, sess := range sessions {
+		sess.Close()
+	}
+
+	if listener != nil {
+		return listener.Close()
+	}
+	return nil
+}
+
+// Sessions returns the running sessions
+func (server *Server) Sessions() []*Session {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	sessions := []*Session{}
+	for _, sess := range server.sessions {
+		sessions = append(sessions, sess)
+	}
+	return sessions
+}
+
+func (server *Server) isClosed() bool {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	return server.closed
+}
+
+// handleConn handshake and serve the session channels of the connection
+func (server *Server) handleConn(conn net.Conn) {
+	sshConn, channels, requests, err := ssh.NewServerConn(conn, server.config)
+	if err != nil {
+		log.Warn("[TUI] SSH handshake with %s failed: %s", conn.RemoteAddr().String(), err.Error())
+		conn.Close()
+		return
+	}
+	defer sshConn.Close()
+
+	user := userFromPermissions(sshConn.Permissions)
+	log.Info("[TUI] SSH %s connected from %s", user.ID, sshConn.RemoteAddr().String())
+	go ssh.DiscardRequests(requests)
+
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+
+		if !server.acquire() {
+			newChannel.Reject(ssh.ResourceShortage, "too many sessions")
+			continue
+		}
+
+		channel, reqs, err := newChannel.Accept()
+		if err != nil {
+			log.Error("[TUI] SSH accept channel error: %s", err.Error())
+			server.release(nil)
+			continue
+		}
+
+		sess := newSession(server, user, channel, sshConn.RemoteAddr().String())
+		go func() {
+			sess.serve(reqs)
+			server.release(sess)
+		}()
+	}
+	log.Info("[TUI] SSH %s disconnected", user.ID)
+}
+
+// acquire reserves a session slot
+func (server *Server) acquire() bool {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if server.closed {
+		return false
+	}
+	if server.Option.MaxSessions > 0 && server.active >= server.Option.MaxSessions {
+		return false
+	}
+	server.active++
+	return true
+}
+
+// release frees the session slot
+func (server *Server) release(sess *Session) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	server.active--
+	if sess != nil {
+		delete(server.sessions, sess.ID)
+	}
+}
+
+// register the running session
+func (server *Server) register(sess *Session) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	server.sessions[sess.ID] = sess
+}
+
+// allowed checks if the TUI can be served
+func (server *Server) allowed(id string) bool {
+	if len(server.Option.TUIs) == 0 {
+		return true
+	}
+	for _, allowed := range server.Option.TUIs {
+		if allowed == id {
+			return true
+		}
+	}
+	return false
+}
+
+// hostKey load the host key, a new ed25519 key is generated if the file does not exist
+func hostKey(file string) (ssh.Signer, error) {
+	data, err := os.ReadFile(file)
+	if err == nil {
+		return ssh.ParsePrivateKey(data)
+	}
+
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := ssh.MarshalPrivateKey(key, "yao tui")
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(filepath.Dir(file), 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.WriteFile(file, pem.EncodeToMemory(block), 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("[TUI] SSH host key generated: %s", file)
+	return ssh.NewSignerFromKey(key)
+}
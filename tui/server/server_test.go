@@ -0,0 +1,279 @@
+package server
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+type testAuth struct {
+	mfa bool
+}
+
+func (auth *testAuth) Password(username string, password string) (*User, error) {
+	if password != "secret" {
+		return nil, fmt.Errorf("invalid username or password")
+	}
+	return &User{ID: "u-" + username, TeamID: "t-1", Name: username, Scope: "system", MFA: auth.mfa}, nil
+}
+
+func (auth *testAuth) PublicKey(username string, key ssh.PublicKey) (*User, error) {
+	return nil, fmt.Errorf("the key is not authorized")
+}
+
+func (auth *testAuth) OTP(user *User, code string) error {
+	if code != "123456" {
+		return fmt.Errorf("invalid one-time code")
+	}
+	return nil
+}
+
+// sizeModel renders the session user and window size, quits on q
+type sizeModel struct {
+	user   string
+	args   []string
+	width  int
+	height int
+}
+
+func (m sizeModel) Init() tea.Cmd { return nil }
+
+func (m sizeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	case tea.KeyMsg:
+		if msg.String() == "q" {
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m sizeModel) View() string {
+	return fmt.Sprintf("user=%s args=%s size=%dx%d", m.user, strings.Join(m.args, ","), m.width, m.height)
+}
+
+// syncBuffer collects the output of the session
+type syncBuffer struct {
+	buf bytes.Buffer
+	mu  sync.Mutex
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func (b *syncBuffer) wait(t *testing.T, expected string) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if strings.Contains(b.String(), expected) {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Expected %q in the output, got %q", expected, b.String())
+}
+
+func startServer(t *testing.T, option Option, auth Authenticator) (*Server, string) {
+	option.HostKey = filepath.Join(t.TempDir(), "host_ed25519_key")
+	option.Password = true
+	srv, err := New(option, auth)
+	require.NoError(t, err)
+
+	srv.Handler = func(sess *Session, name string, args []string) (tea.Model, error) {
+		if name != "console" {
+			return nil, fmt.Errorf("TUI not found: %s", name)
+		}
+		return sizeModel{user: sess.User.ID, args: args}, nil
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go srv.Serve(listener)
+	t.Cleanup(func() { srv.Close() })
+	return srv, listener.Addr().String()
+}
+
+func dial(t *testing.T, addr string, auth ...ssh.AuthMethod) (*ssh.Client, error) {
+	return ssh.Dial("tcp", addr, &ssh.ClientConfig{
+		User:            "alice",
+		Auth:            auth,
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         5 * time.Second,
+	})
+}
+
+func TestServerSession(t *testing.T) {
+	srv, addr := startServer(t, Option{TUI: "console"}, &testAuth{})
+
+	_, err := dial(t, addr, ssh.Password("wrong"))
+	assert.Error(t, err)
+
+	client, err := dial(t, addr, ssh.Password("secret"))
+	require.NoError(t, err)
+	defer client.Close()
+
+	sess, err := client.NewSession()
+	require.NoError(t, err)
+	output := &syncBuffer{}
+	sess.Stdout = output
+	stdin, err := sess.StdinPipe()
+	require.NoError(t, err)
+
+	require.NoError(t, sess.RequestPty("xterm-256color", 30, 100, ssh.TerminalModes{}))
+	require.NoError(t, sess.Start(`console '::{"id":1}'`))
+	output.wait(t, `user=u-alice args=::{"id":1} size=100x30`)
+	assert.Len(t, srv.Sessions(), 1)
+
+	// Each session has its own window size
+	require.NoError(t, sess.WindowChange(40, 120))
+	output.wait(t, "size=120x40")
+
+	_, err = stdin.Write([]byte("q"))
+	require.NoError(t, err)
+	require.NoError(t, sess.Wait())
+	assert.Eventually(t, func() bool { return len(srv.Sessions()) == 0 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestServerRequiresTerminal(t *testing.T) {
+	_, addr := startServer(t, Option{TUI: "console"}, &testAuth{})
+	client, err := dial(t, addr, ssh.Password("secret"))
+	require.NoError(t, err)
+	defer client.Close()
+
+	sess, err := client.NewSession()
+	require.NoError(t, err)
+	output, err := sess.CombinedOutput("")
+	assert.Error(t, err)
+	assert.Contains(t, string(output), "A terminal is required")
+}
+
+func TestServerNotAllowed(t *testing.T) {
+	_, addr := startServer(t, Option{TUI: "console", TUIs: []string{"console"}}, &testAuth{})
+	client, err := dial(t, addr, ssh.Password("secret"))
+	require.NoError(t, err)
+	defer client.Close()
+
+	sess, err := client.NewSession()
+	require.NoError(t, err)
+	output := &syncBuffer{}
+	sess.Stdout = output
+	require.NoError(t, sess.RequestPty("xterm", 24, 80, ssh.TerminalModes{}))
+	require.NoError(t, sess.Start("admin"))
+
+	err = sess.Wait()
+	assert.Error(t, err)
+	assert.Contains(t, output.String(), "TUI admin is not served")
+}
+
+func TestServerMaxSessions(t *testing.T) {
+	_, addr := startServer(t, Option{TUI: "console", MaxSessions: 1}, &testAuth{})
+	client, err := dial(t, addr, ssh.Password("secret"))
+	require.NoError(t, err)
+	defer client.Close()
+
+	first, err := client.NewSession()
+	require.NoError(t, err)
+	defer first.Close()
+
+	_, err = client.NewSession()
+	assert.Error(t, err)
+}
+
+func TestServerMFA(t *testing.T) {
+	_, addr := startServer(t, Option{TUI: "console"}, &testAuth{mfa: true})
+
+	answer := func(code string) ssh.AuthMethod {
+		return ssh.KeyboardInteractive(func(user, instruction string, questions []string, echos []bool) ([]string, error) {
+			answers := []string{}
+			for _, question := range questions {
+				if strings.HasPrefix(question, "Password") {
+					answers = append(answers, "secret")
+					continue
+				}
+				answers = append(answers, code)
+			}
+			return answers, nil
+		})
+	}
+
+	_, err := dial(t, addr, answer("000000"))
+	assert.Error(t, err)
+
+	client, err := dial(t, addr, answer("123456"))
+	require.NoError(t, err)
+	client.Close()
+
+	// The password method continues with the one-time code
+	client, err = dial(t, addr, ssh.Password("secret"), answer("123456"))
+	require.NoError(t, err)
+	client.Close()
+}
+
+func TestKeyOwner(t *testing.T) {
+	alice, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	bob, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	eve, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	aliceKey, _ := ssh.NewPublicKey(alice)
+	bobKey, _ := ssh.NewPublicKey(bob)
+	eveKey, _ := ssh.NewPublicKey(eve)
+
+	file := filepath.Join(t.TempDir(), "authorized_keys")
+	content := "# consoles\n" +
+		`user="alice@example.com" ` + string(ssh.MarshalAuthorizedKey(aliceKey)) +
+		string(ssh.MarshalAuthorizedKey(bobKey))
+	require.NoError(t, os.WriteFile(file, []byte(content), 0600))
+
+	auth := &YaoAuthenticator{AuthorizedKeys: file}
+	owner, err := auth.keyOwner(aliceKey)
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", owner)
+
+	_, err = auth.keyOwner(bobKey)
+	assert.ErrorContains(t, err, "no user option")
+
+	_, err = auth.keyOwner(eveKey)
+	assert.ErrorContains(t, err, "not authorized")
+}
+
+func TestSplitArgs(t *testing.T) {
+	assert.Equal(t, []string{"console", `::{"name":"Big Box"}`, "x"}, splitArgs(`console '::{"name":"Big Box"}'  x`))
+	assert.Equal(t, []string{}, splitArgs(""))
+}
+
+func TestCRLFWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := &crlfWriter{w: &buf}
+	w.Write([]byte("a\nb\r"))
+	w.Write([]byte("\nc\n"))
+	assert.Equal(t, "a\r\nb\r\nc\r\n", buf.String())
+	_, err := io.WriteString(w, "")
+	assert.NoError(t, err)
+}
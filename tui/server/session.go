@@ -0,0 +1,405 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/yaoapp/gou/session"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/tui/tui"
+	"golang.org/x/crypto/ssh"
+)
+
+// Handler creates the bubbletea model of the session
+// name is the requested TUI, args are the arguments after the name (e.g. '::{"key":"value"}')
+type Handler func(sess *Session, name string, args []string) (tea.Model, error)
+
+// DefaultHandler creates the models of the loaded TUIs, replace it to serve other models
+var DefaultHandler Handler = TUIHandler
+
+// Session a SSH session running a TUI
+type Session struct {
+	ID     string // The session id, the processes called from the TUI run with it
+	User   *User
+	TUI    string
+	Remote string
+	Term   string
+	Width  int
+	Height int
+
+	server  *Server
+	channel ssh.Channel
+	program *tea.Program
+	pty     bool
+	started bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+	mu      sync.Mutex
+}
+
+type ptyRequest struct {
+	Term    string
+	Columns uint32
+	Rows    uint32
+	Width   uint32
+	Height  uint32
+	Modes   string
+}
+
+type windowChangeRequest struct {
+	Columns uint32
+	Rows    uint32
+	Width   uint32
+	Height  uint32
+}
+
+type execRequest struct {
+	Command string
+}
+
+type exitStatus struct {
+	Status uint32
+}
+
+func newSession(server *Server, user *User, channel ssh.Channel, remote string) *Session {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Session{
+		ID:      session.ID(),
+		User:    user,
+		Remote:  remote,
+		Term:    "xterm-256color",
+		Width:   80,
+		Height:  24,
+		server:  server,
+		channel: channel,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Authorized returns the authorized information passed to the processes and scripts
+func (sess *Session) Authorized() map[string]interface{} {
+	return map[string]interface{}{
+		"subject":    sess.User.Subject,
+		"user_id":    sess.User.ID,
+		"team_id":    sess.User.TeamID,
+		"scope":      sess.User.Scope,
+		"session_id": sess.ID,
+	}
+}
+
+// Close stop the TUI and close the channel
+func (sess *Session) Close() {
+	sess.cancel()
+	sess.channel.Close()
+}
+
+// serve handles the requests of the session channel
+func (sess *Session) serve(requests <-chan *ssh.Request) {
+	done := make(chan struct{})
+	for req := range requests {
+		switch req.Type {
+		case "pty-req":
+			var pty ptyRequest
+			if err := ssh.Unmarshal(req.Payload, &pty); err != nil {
+				req.Reply(false, nil)
+				continue
+			}
+			sess.mu.Lock()
+			sess.pty = true
+			if pty.Term != "" {
+				sess.Term = pty.Term
+			}
+			sess.Width, sess.Height = int(pty.Columns), int(pty.Rows)
+			sess.mu.Unlock()
+			req.Reply(true, nil)
+
+		case "window-change":
+			var size windowChangeRequest
+			if err := ssh.Unmarshal(req.Payload, &size); err == nil {
+				sess.resize(int(size.Columns), int(size.Rows))
+			}
+			if req.WantReply {
+				req.Reply(true, nil)
+			}
+
+		case "env":
+			req.Reply(true, nil)
+
+		case "shell", "exec":
+			args := []string{}
+			if req.Type == "exec" {
+				var cmd execRequest
+				if err := ssh.Unmarshal(req.Payload, &cmd); err != nil {
+					req.Reply(false, nil)
+					continue
+				}
+				args = splitArgs(cmd.Command)
+			}
+
+			if sess.started {
+				req.Reply(false, nil)
+				continue
+			}
+			sess.started = true
+			req.Reply(true, nil)
+
+			go func() {
+				defer close(done)
+				code := sess.run(args)
+				sess.channel.SendRequest("exit-status", false, ssh.Marshal(exitStatus{Status: uint32(code)}))
+				sess.channel.Close()
+			}()
+
+		default:
+			if req.WantReply {
+				req.Reply(false, nil)
+			}
+		}
+	}
+
+	// The client closed the channel
+	sess.cancel()
+	if sess.started {
+		<-done
+	}
+}
+
+// run the TUI, returns the exit status
+func (sess *Session) run(args []string) int {
+	name := sess.server.Option.TUI
+	if len(args) > 0 && !strings.HasPrefix(args[0], "::") {
+		name, args = args[0], args[1:]
+	}
+
+	sess.mu.Lock()
+	pty := sess.pty
+	sess.TUI = name
+	sess.mu.Unlock()
+
+	if !pty {
+		fmt.Fprint(sess.channel, "A terminal is required, connect with: ssh -t <host> [tui]\r\n")
+		return 1
+	}
+
+	if name == "" {
+		fmt.Fprintf(sess.channel, "No TUI specified, connect with: ssh -t <host> <tui>\r\n")
+		return 1
+	}
+
+	if !sess.server.allowed(name) {
+		fmt.Fprintf(sess.channel, "TUI %s is not served\r\n", name)
+		return 1
+	}
+
+	handler := sess.server.Handler
+	if handler == nil {
+		handler = DefaultHandler
+	}
+
+	model, err := handler(sess, name, args)
+	if err != nil {
+		fmt.Fprintf(sess.channel, "%s\r\n", err.Error())
+		return 1
+	}
+
+	err = sess.store()
+	if err != nil {
+		log.Error("[TUI] SSH store the session of %s error: %s", sess.User.ID, err.Error())
+		fmt.Fprint(sess.channel, "Failed to create the session\r\n")
+		return 1
+	}
+	defer sess.clear()
+
+	sess.mu.Lock()
+	program := tea.NewProgram(
+		model,
+		tea.WithInput(sess.channel),
+		tea.WithOutput(&crlfWriter{w: sess.channel}),
+		tea.WithEnvironment([]string{"TERM=" + sess.Term}),
+		tea.WithContext(sess.ctx),
+		tea.WithAltScreen(),
+		tea.WithMouseCellMotion(),
+		tea.WithoutSignalHandler(), // The signals belong to the server
+	)
+	sess.program = program
+	width, height := sess.Width, sess.Height
+	sess.mu.Unlock()
+
+	if m, ok := model.(*tui.Model); ok {
+		m.Program = program
+	}
+
+	sess.server.register(sess)
+	log.Info("[TUI] SSH %s started %s (session %s)", sess.User.ID, name, sess.ID)
+
+	// The output is not a terminal, bubbletea can not query the size
+	go program.Send(tea.WindowSizeMsg{Width: width, Height: height})
+
+	_, err = program.Run()
+	log.Info("[TUI] SSH %s stopped %s (session %s)", sess.User.ID, name, sess.ID)
+	if err != nil && err != tea.ErrProgramKilled {
+		log.Error("[TUI] SSH %s program error: %s", name, err.Error())
+		return 1
+	}
+	return 0
+}
+
+// resize sends the new window size to the program
+func (sess *Session) resize(width, height int) {
+	sess.mu.Lock()
+	sess.Width, sess.Height = width, height
+	program := sess.program
+	sess.mu.Unlock()
+
+	if program != nil {
+		go program.Send(tea.WindowSizeMsg{Width: width, Height: height})
+	}
+}
+
+// sessionKeys the session data set for the user of the TUI
+var sessionKeys = []string{"__user_id", "__team_id", "__subject", "__scope", "user_id", "user"}
+
+// store the user to the session, the processes read the user from it
+func (sess *Session) store() error {
+	return session.Global().Expire(sess.server.Option.SessionTimeout).ID(sess.ID).SetMany(map[string]interface{}{
+		"__user_id": sess.User.ID,
+		"__team_id": sess.User.TeamID,
+		"__subject": sess.User.Subject,
+		"__scope":   sess.User.Scope,
+		"user_id":   sess.User.ID,
+		"user":      map[string]interface{}{"id": sess.User.ID, "name": sess.User.Name, "email": sess.User.Email},
+	})
+}
+
+// clear the session data
+func (sess *Session) clear() {
+	ss := session.Global().ID(sess.ID)
+	for _, key := range sessionKeys {
+		ss.Del(key)
+	}
+}
+
+// TUIHandler creates the model of a loaded TUI. Each session has its own copy of the configuration,
+// the session id and user are set to the state as __sid and __user
+func TUIHandler(sess *Session, name string, args []string) (tea.Model, error) {
+	cfg := tui.Get(name)
+	if cfg == nil {
+		return nil, fmt.Errorf("TUI not found: %s", name)
+	}
+
+	external, err := parseArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	external["__sid"] = sess.ID
+	external["__user"] = map[string]interface{}{
+		"id":      sess.User.ID,
+		"team_id": sess.User.TeamID,
+		"name":    sess.User.Name,
+		"email":   sess.User.Email,
+	}
+
+	external, err = tui.ValidateAndFlattenExternal(external)
+	if err != nil {
+		return nil, err
+	}
+
+	copied := *cfg
+	copied.Data = map[string]interface{}{}
+	for key, value := range cfg.Data {
+		copied.Data[key] = value
+	}
+
+	defaults := tui.LoadTUIDefaults(name)
+	if len(defaults) > 0 {
+		copied.Data = tui.MergeData(copied.Data, defaults, false)
+	}
+	tui.PrepareInitialState(&copied, external)
+
+	model := tui.NewModel(&copied, nil)
+	model.Sid = sess.ID
+	model.Authorized = sess.Authorized()
+	return model, nil
+}
+
+// parseArgs parses the external data of the arguments, the same as `yao tui <name> '::{"key":"value"}'`
+func parseArgs(args []string) (map[string]interface{}, error) {
+	data := map[string]interface{}{}
+	rest := []interface{}{}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "::") {
+			var v map[string]interface{}
+			err := jsoniter.UnmarshalFromString(strings.TrimPrefix(arg, "::"), &v)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse external data: %s", err.Error())
+			}
+			for key, value := range v {
+				data[key] = value
+			}
+			continue
+		}
+		rest = append(rest, strings.Replace(arg, `\::`, "::", 1))
+	}
+
+	if len(rest) > 0 {
+		data["_args"] = rest
+	}
+	return data, nil
+}
+
+// splitArgs splits the command of the exec request, single quoted arguments may contain spaces
+func splitArgs(command string) []string {
+	args := []string{}
+	var current strings.Builder
+	quoted := false
+	started := false
+	for _, r := range command {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+			started = true
+		case (r == ' ' || r == '\t') && !quoted:
+			if started {
+				args = append(args, current.String())
+				current.Reset()
+				started = false
+			}
+		default:
+			current.WriteRune(r)
+			started = true
+		}
+	}
+	if started {
+		args = append(args, current.String())
+	}
+	return args
+}
+
+// crlfWriter translates "\n" to "\r\n", the session has no pty to do it
+type crlfWriter struct {
+	w    io.Writer
+	last byte
+}
+
+func (w *crlfWriter) Write(data []byte) (int, error) {
+	buf := make([]byte, 0, len(data)+16)
+	for _, b := range data {
+		if b == '\n' && w.last != '\r' {
+			buf = append(buf, '\r')
+		}
+		buf = append(buf, b)
+		w.last = b
+	}
+
+	_, err := w.w.Write(buf)
+	if err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
@@ -100,6 +100,12 @@ func executeProcessAction(model *Model, action *core.Action) (interface{}, error
 		// Create and execute the process, passing the model ID as the first argument
 		allArgs := append([]interface{}{model.Config.ID}, preparedArgs...)
 		p := process.New(action.Process, allArgs...)
+		if model.Sid != "" {
+			p.WithSID(model.Sid)
+		}
+		if model.Authorized != nil {
+			p.WithAuthorized(model.Authorized)
+		}
 		result, err := p.Exec()
 		if err != nil {
 			return nil, fmt.Errorf("process execution failed: %w", err)
@@ -1,6 +1,7 @@
 package component
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -20,6 +21,12 @@ type Message struct {
 	Role      string    `json:"role"` // "user" or "assistant"
 	Content   string    `json:"content"`
 	Timestamp time.Time `json:"timestamp"`
+
+	// Type is the agent message type: text, thinking, tool_call, loading, error... (empty for text)
+	Type string `json:"type,omitempty"`
+
+	// Props are the properties of the agent message
+	Props map[string]interface{} `json:"props,omitempty"`
 }
 
 // ChatProps defines the properties for the Chat component
@@ -65,6 +72,31 @@ type ChatProps struct {
 
 	// Bindings define custom key bindings for the component (optional)
 	Bindings []core.ComponentBinding `json:"bindings,omitempty"`
+
+	// Assistant binds the chat to an assistant, the completions are streamed through the agent
+	Assistant string `json:"assistant,omitempty"`
+
+	// ChatID continues a stored conversation, a new chat is created if empty
+	ChatID string `json:"chatId,omitempty"`
+
+	// Connector overrides the connector of the assistant
+	Connector string `json:"connector,omitempty"`
+
+	// Locale is the locale of the completions
+	Locale string `json:"locale,omitempty"`
+
+	// UserID and TeamID identify the owner of the conversation
+	UserID string `json:"userId,omitempty"`
+	TeamID string `json:"teamId,omitempty"`
+
+	// HistoryLimit is the number of stored messages loaded when the chat starts
+	HistoryLimit int `json:"historyLimit,omitempty"`
+
+	// InterruptKey interrupts the running completion (default "esc")
+	InterruptKey string `json:"interruptKey,omitempty"`
+
+	// ShowReasoning shows the reasoning of the model
+	ShowReasoning bool `json:"showReasoning,omitempty"`
 }
 
 // ChatModel represents a chat model for interactive chats
@@ -447,6 +479,13 @@ type ChatComponentWrapper struct {
 	id          string
 	bindings    []core.ComponentBinding
 	stateHelper *core.ChatStateHelper
+
+	// Agent conversation
+	chatID string
+	stream int                // Sequence of the completions, the messages of a stale completion are dropped
+	cancel context.CancelFunc // Cancels the running completion, nil if idle
+	events chan tea.Msg       // The messages of the running completion
+	index  map[string]int     // Message id -> position in messages, for merging the deltas
 }
 
 // NewChatComponentWrapper creates a wrapper that implements ComponentInterface
@@ -456,6 +495,12 @@ func NewChatComponentWrapper(props ChatProps, id string) *ChatComponentWrapper {
 		messages: props.Messages,
 		id:       id,
 		bindings: props.Bindings,
+		chatID:   props.ChatID,
+		index:    map[string]int{},
+	}
+
+	if cm.bound() && cm.chatID == "" {
+		cm.chatID = newChatID()
 	}
 
 	// Initialize viewport
@@ -506,6 +551,15 @@ func NewChatComponentWrapper(props ChatProps, id string) *ChatComponentWrapper {
 func (cm *ChatComponentWrapper) updateHistoryText() {
 	var historyText strings.Builder
 	for _, msg := range cm.messages {
+		// Reasoning, tool calls and status lines of the agent
+		if line, ok := cm.renderAgentMessage(msg); ok {
+			if line != "" {
+				historyText.WriteString(line)
+				historyText.WriteString("\n\n")
+			}
+			continue
+		}
+
 		// Format message based on role
 		var msgStyle lipgloss.Style
 		var prefix string
@@ -524,6 +578,9 @@ func (cm *ChatComponentWrapper) updateHistoryText() {
 
 		// Apply Markdown rendering if enabled
 		content := msg.Content
+		if msg.Role == "assistant" {
+			content = renderCitations(content)
+		}
 		if cm.props.EnableMarkdown {
 			renderer, err := glamour.NewTermRenderer(
 				glamour.WithStandardStyle(cm.props.GlamourStyle),
@@ -558,7 +615,9 @@ func (w *ChatComponentWrapper) Init() tea.Cmd {
 	// 不要在初始化时自动获取焦点
 	// 焦点应该通过框架的焦点管理机制来控制
 	// 只有当组件被明确设置焦点时才获取焦点
-	return nil
+
+	// 绑定助手时加载存储的聊天记录
+	return w.loadHistory()
 }
 
 func (w *ChatComponentWrapper) UpdateMsg(msg tea.Msg) (core.ComponentInterface, tea.Cmd, core.Response) {
@@ -591,6 +650,11 @@ func (w *ChatComponentWrapper) delegateToBubbles(msg tea.Msg) tea.Cmd {
 	var cmds []tea.Cmd
 	oldValue := ""
 
+	// 处理助手流式输出
+	if cmd, ok := w.handleAgentMsg(msg); ok {
+		return cmd
+	}
+
 	if w.TextInput.Focused() {
 		oldValue = w.TextInput.Value()
 	}
@@ -613,12 +677,22 @@ func (w *ChatComponentWrapper) delegateToBubbles(msg tea.Msg) tea.Cmd {
 				return nil
 			}
 
+			// 助手正在回复，保留输入
+			if w.Streaming() {
+				return nil
+			}
+
 			// 清空输入
 			w.TextInput.Reset()
 
 			// 添加用户消息
 			w.AddMessage("user", inputText)
 
+			// 绑定助手时发起流式请求
+			if w.bound() {
+				cmds = append(cmds, w.send(inputText))
+			}
+
 			// 发布消息发送事件
 			cmds = append(cmds, core.PublishEvent(w.id, core.EventChatMessageSent, map[string]interface{}{
 				"role":    "user",
@@ -661,6 +735,10 @@ func (w *ChatComponentWrapper) delegateToBubbles(msg tea.Msg) tea.Cmd {
 	if actionMsg, ok := msg.(core.ActionMsg); ok {
 		switch actionMsg.Action {
 		case core.EventChatMessageReceived:
+			// 助手回复已在流式输出中显示，忽略自身发布的事件
+			if w.bound() && actionMsg.ID == w.id {
+				return nil
+			}
 			// 添加收到的消息到聊天
 			if data, ok := actionMsg.Data.(map[string]interface{}); ok {
 				if role, ok := data["role"].(string); ok {
@@ -708,6 +786,11 @@ func (w *ChatComponentWrapper) DetectStateChanges(old, new map[string]interface{
 
 // 实现 HandleSpecialKey 方法
 func (w *ChatComponentWrapper) HandleSpecialKey(keyMsg tea.KeyMsg) (tea.Cmd, core.Response, bool) {
+	// 中断正在进行的回复（优先于 ESC 的焦点释放）
+	if w.Streaming() && keyMsg.String() == w.interruptKey() {
+		return w.Interrupt(), core.Handled, true
+	}
+
 	// ESC 和 Tab 现在由框架层统一处理，这里不处理
 	// 如果有其他特殊的键处理需求，可以在这里添加
 	return nil, core.Ignored, false
@@ -782,7 +865,9 @@ func (w *ChatComponentWrapper) Render(config core.RenderConfig) (string, error)
 
 	// Update component properties
 	w.props = props
-	w.messages = props.Messages
+	if !w.bound() {
+		w.messages = props.Messages
+	}
 	w.updateHistoryText()
 
 	return w.View(), nil
@@ -829,8 +914,11 @@ func (w *ChatComponentWrapper) UpdateRenderConfig(config core.RenderConfig) erro
 	props := ParseChatProps(propsMap)
 
 	// Update component properties
+	// 绑定助手时消息由流式输出和聊天记录维护
 	w.props = props
-	w.messages = props.Messages
+	if !w.bound() {
+		w.messages = props.Messages
+	}
 	w.updateHistoryText()
 
 	return nil
@@ -838,7 +926,12 @@ func (w *ChatComponentWrapper) UpdateRenderConfig(config core.RenderConfig) erro
 
 // Cleanup 清理资源
 func (w *ChatComponentWrapper) Cleanup() {
-	// Chat 组件通常不需要特殊清理操作
+	// 取消正在进行的回复
+	if w.cancel != nil {
+		w.cancel()
+		w.cancel = nil
+		w.events = nil
+	}
 }
 
 // GetStateChanges returns the state changes from this component
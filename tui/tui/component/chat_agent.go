@@ -0,0 +1,608 @@
+package component
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/uuid"
+	"github.com/yaoapp/yao/agent/assistant"
+	agentContext "github.com/yaoapp/yao/agent/context"
+	"github.com/yaoapp/yao/agent/output/message"
+	storetypes "github.com/yaoapp/yao/agent/store/types"
+	oauthtypes "github.com/yaoapp/yao/openapi/oauth/types"
+	"github.com/yaoapp/yao/tui/tui/core"
+)
+
+// DefaultChatInterruptKey is the key to interrupt a running completion
+const DefaultChatInterruptKey = "esc"
+
+// DefaultChatHistoryLimit is the number of stored messages loaded when the chat starts
+const DefaultChatHistoryLimit = 100
+
+// ChatAgentRequest is a completion request sent to an assistant
+type ChatAgentRequest struct {
+	Assistant string
+	ChatID    string
+	Connector string
+	Locale    string
+	UserID    string
+	TeamID    string
+	Content   string
+}
+
+// ChatAgentResult is the result of a finished completion
+type ChatAgentResult struct {
+	RequestID string
+	Sources   []ChatSource
+}
+
+// ChatSource is a search reference cited by the assistant
+type ChatSource struct {
+	Index int    `json:"index"`
+	Title string `json:"title"`
+	URL   string `json:"url,omitempty"`
+}
+
+// ChatAgentStream streams a completion of the assistant, the handler is called with every output message.
+// Replace it to use another backend (tests, remote agents)
+var ChatAgentStream = streamAgent
+
+// ChatAgentHistory loads the stored messages of a chat
+var ChatAgentHistory = agentHistory
+
+// chatAgentChunkMsg carries an output message of the running completion
+type chatAgentChunkMsg struct {
+	stream  int
+	message *message.Message
+}
+
+// chatAgentDoneMsg is sent when the completion is finished
+type chatAgentDoneMsg struct {
+	stream int
+	result *ChatAgentResult
+	err    error
+}
+
+// chatAgentHistoryMsg carries the stored messages of the chat
+type chatAgentHistoryMsg struct {
+	messages []Message
+	err      error
+}
+
+// streamAgent streams the completion through the agent package, the output is written as CUI messages
+func streamAgent(ctx context.Context, req ChatAgentRequest, handler func(*message.Message)) (*ChatAgentResult, error) {
+	ast, err := assistant.Get(req.Assistant)
+	if err != nil {
+		return nil, fmt.Errorf("assistant %s not found: %w", req.Assistant, err)
+	}
+
+	subject := req.UserID
+	if subject == "" {
+		subject = "tui"
+	}
+	authorized := &oauthtypes.AuthorizedInfo{Subject: subject, UserID: req.UserID, TeamID: req.TeamID}
+
+	agentCtx := agentContext.New(ctx, authorized, req.ChatID)
+	defer agentCtx.Release()
+
+	// The console belongs to the TUI, suppress the request logs
+	if agentCtx.Logger != nil {
+		agentCtx.Logger.Close()
+	}
+	agentCtx.Logger = agentContext.Noop()
+	agentCtx.AssistantID = req.Assistant
+	agentCtx.Locale = req.Locale
+	agentCtx.Referer = agentContext.RefererInternal
+	agentCtx.Accept = agentContext.AccepNativeCUI
+	agentCtx.Writer = newChatWriter(handler)
+
+	messages := []agentContext.Message{{Role: agentContext.RoleUser, Content: req.Content}}
+	response, err := ast.Stream(agentCtx, messages, &agentContext.Options{Context: ctx, Connector: req.Connector})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ChatAgentResult{RequestID: response.RequestID, Sources: []ChatSource{}}
+	store := assistant.GetChatStore()
+	if store == nil || response.RequestID == "" {
+		return result, nil
+	}
+
+	searches, err := store.GetSearches(response.RequestID)
+	if err != nil {
+		return result, nil
+	}
+	for _, search := range searches {
+		for _, ref := range search.References {
+			result.Sources = append(result.Sources, ChatSource{Index: ref.Index, Title: ref.Title, URL: ref.URL})
+		}
+	}
+	return result, nil
+}
+
+// agentHistory loads the stored messages of the chat, the transient messages are skipped
+func agentHistory(chatID string, limit int) ([]Message, error) {
+	store := assistant.GetChatStore()
+	if store == nil {
+		return nil, fmt.Errorf("the chat store is not configured")
+	}
+
+	stored, err := store.GetMessages(chatID, storetypes.MessageFilter{Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+
+	messages := []Message{}
+	for _, msg := range stored {
+		typ := msg.Type
+		if typ == message.TypeUserInput {
+			typ = message.TypeText
+		}
+
+		item := Message{ID: msg.MessageID, Role: msg.Role, Type: typ, Props: msg.Props, Timestamp: msg.CreatedAt}
+		item.Content = chatMessageContent(item.Type, item.Props)
+		if item.Content == "" || item.Type == message.TypeLoading {
+			continue
+		}
+		messages = append(messages, item)
+	}
+	return messages, nil
+}
+
+// chatWriter receives the CUI output of the agent, the SSE chunks are decoded into messages
+type chatWriter struct {
+	header  http.Header
+	buffer  []byte
+	handler func(*message.Message)
+	mu      sync.Mutex
+}
+
+func newChatWriter(handler func(*message.Message)) *chatWriter {
+	return &chatWriter{header: http.Header{}, handler: handler}
+}
+
+// Header returns the header map
+func (w *chatWriter) Header() http.Header {
+	return w.header
+}
+
+// WriteHeader the status code is not used
+func (w *chatWriter) WriteHeader(statusCode int) {}
+
+// Flush the chunks are decoded on write
+func (w *chatWriter) Flush() {}
+
+// Write decodes the complete "data: {json}\n\n" chunks
+func (w *chatWriter) Write(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buffer = append(w.buffer, data...)
+	for {
+		end := bytes.Index(w.buffer, []byte("\n\n"))
+		if end < 0 {
+			break
+		}
+
+		chunk := bytes.TrimSpace(w.buffer[:end])
+		w.buffer = w.buffer[end+2:]
+		if !bytes.HasPrefix(chunk, []byte("data: ")) {
+			continue
+		}
+
+		msg := &message.Message{}
+		if err := json.Unmarshal(chunk[6:], msg); err != nil || msg.Type == "" {
+			continue // Groups and unknown chunks
+		}
+		w.handler(msg)
+	}
+	return len(data), nil
+}
+
+// bound returns true if the chat is connected to an assistant
+func (w *ChatComponentWrapper) bound() bool {
+	return w.props.Assistant != ""
+}
+
+// interruptKey returns the key to interrupt the running completion
+func (w *ChatComponentWrapper) interruptKey() string {
+	if w.props.InterruptKey != "" {
+		return w.props.InterruptKey
+	}
+	return DefaultChatInterruptKey
+}
+
+// Streaming returns true if a completion is running
+func (w *ChatComponentWrapper) Streaming() bool {
+	return w.cancel != nil
+}
+
+// ChatID returns the chat id of the agent conversation
+func (w *ChatComponentWrapper) ChatID() string {
+	return w.chatID
+}
+
+// loadHistory loads the stored messages of the chat
+func (w *ChatComponentWrapper) loadHistory() tea.Cmd {
+	if !w.bound() || w.props.ChatID == "" {
+		return nil
+	}
+
+	id := w.id
+	chatID := w.chatID
+	limit := w.props.HistoryLimit
+	if limit <= 0 {
+		limit = DefaultChatHistoryLimit
+	}
+
+	return func() tea.Msg {
+		messages, err := ChatAgentHistory(chatID, limit)
+		return core.TargetedMsg{TargetID: id, InnerMsg: chatAgentHistoryMsg{messages: messages, err: err}}
+	}
+}
+
+// send starts a completion of the assistant, the output messages are delivered to the component one by one
+func (w *ChatComponentWrapper) send(content string) tea.Cmd {
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan tea.Msg, 64)
+
+	w.stream++
+	w.cancel = cancel
+	w.events = events
+	w.index = map[string]int{}
+
+	stream := w.stream
+	req := ChatAgentRequest{
+		Assistant: w.props.Assistant,
+		ChatID:    w.chatID,
+		Connector: w.props.Connector,
+		Locale:    w.props.Locale,
+		UserID:    w.props.UserID,
+		TeamID:    w.props.TeamID,
+		Content:   content,
+	}
+
+	go func() {
+		defer close(events)
+		result, err := ChatAgentStream(ctx, req, func(msg *message.Message) {
+			select {
+			case events <- chatAgentChunkMsg{stream: stream, message: msg}:
+			case <-ctx.Done():
+			}
+		})
+		select {
+		case events <- chatAgentDoneMsg{stream: stream, result: result, err: err}:
+		case <-ctx.Done():
+		}
+	}()
+
+	return w.waitAgent()
+}
+
+// waitAgent waits for the next message of the running completion
+func (w *ChatComponentWrapper) waitAgent() tea.Cmd {
+	if w.events == nil {
+		return nil
+	}
+
+	id := w.id
+	events := w.events
+	return func() tea.Msg {
+		msg, ok := <-events
+		if !ok {
+			return nil
+		}
+		return core.TargetedMsg{TargetID: id, InnerMsg: msg}
+	}
+}
+
+// Interrupt stops the running completion
+func (w *ChatComponentWrapper) Interrupt() tea.Cmd {
+	if w.cancel == nil {
+		return nil
+	}
+
+	w.cancel()
+	w.cancel = nil
+	w.events = nil
+	w.finishStream()
+	w.messages = append(w.messages, Message{
+		ID:        fmt.Sprintf("%d", time.Now().UnixNano()),
+		Role:      "assistant",
+		Type:      chatTypeStatus,
+		Content:   "Interrupted",
+		Timestamp: time.Now(),
+	})
+	w.updateHistoryText()
+	w.Viewport.GotoBottom()
+
+	return core.PublishEvent(w.id, core.EventChatInterrupted, map[string]interface{}{
+		"chatId": w.chatID,
+	})
+}
+
+// handleAgentMsg handles the messages of the agent, returns false if the message is not an agent message
+func (w *ChatComponentWrapper) handleAgentMsg(msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case chatAgentHistoryMsg:
+		if msg.err != nil {
+			w.addAgentMessage(message.TypeError, fmt.Sprintf("Load the chat history error: %s", msg.err.Error()))
+			return nil, true
+		}
+		w.messages = append(msg.messages, w.messages...)
+		w.updateHistoryText()
+		w.Viewport.GotoBottom()
+		return nil, true
+
+	case chatAgentChunkMsg:
+		if msg.stream != w.stream || w.cancel == nil {
+			return nil, true // The completion was interrupted
+		}
+		w.mergeAgentMessage(msg.message)
+		w.updateHistoryText()
+		w.Viewport.GotoBottom()
+		return w.waitAgent(), true
+
+	case chatAgentDoneMsg:
+		if msg.stream != w.stream || w.cancel == nil {
+			return nil, true
+		}
+
+		w.cancel()
+		w.cancel = nil
+		w.events = nil
+		w.finishStream()
+
+		if msg.err != nil {
+			w.addAgentMessage(message.TypeError, msg.err.Error())
+			return core.PublishEvent(w.id, core.EventChatError, map[string]interface{}{
+				"chatId": w.chatID,
+				"error":  msg.err.Error(),
+			}), true
+		}
+
+		requestID := ""
+		if msg.result != nil {
+			requestID = msg.result.RequestID
+			if sources := chatSourcesContent(msg.result.Sources); sources != "" {
+				w.addAgentMessage(chatTypeSources, sources)
+			}
+		}
+
+		return core.PublishEvent(w.id, core.EventChatMessageReceived, map[string]interface{}{
+			"role":      "assistant",
+			"content":   w.lastAgentText(),
+			"chatId":    w.chatID,
+			"requestId": requestID,
+		}), true
+	}
+
+	return nil, false
+}
+
+// mergeAgentMessage merges an output message into the chat, the chunks of a message are merged by the message id
+func (w *ChatComponentWrapper) mergeAgentMessage(msg *message.Message) {
+	switch msg.Type {
+	case message.TypeEvent, message.TypeAction, message.TypeUserInput:
+		return
+	}
+
+	id := msg.MessageID
+	if id == "" {
+		id = msg.ChunkID
+	}
+
+	idx, has := w.index[id]
+	if !has || id == "" {
+		item := Message{
+			ID:        id,
+			Role:      "assistant",
+			Type:      msg.Type,
+			Props:     map[string]interface{}{},
+			Timestamp: time.Now(),
+		}
+		for key, value := range msg.Props {
+			item.Props[key] = value
+		}
+		item.Content = chatMessageContent(item.Type, item.Props)
+		w.messages = append(w.messages, item)
+		if id != "" {
+			w.index[id] = len(w.messages) - 1
+		}
+		return
+	}
+
+	item := &w.messages[idx]
+	if msg.TypeChange {
+		item.Type = msg.Type
+	}
+
+	if !msg.Delta {
+		item.Type = msg.Type
+		item.Props = map[string]interface{}{}
+	}
+
+	for key, value := range msg.Props {
+		if msg.Delta && msg.DeltaAction == message.DeltaAppend {
+			if text, ok := value.(string); ok {
+				prev, _ := item.Props[key].(string)
+				item.Props[key] = prev + text
+				continue
+			}
+		}
+		item.Props[key] = value
+	}
+	item.Content = chatMessageContent(item.Type, item.Props)
+}
+
+// finishStream removes the loading indicators of the finished completion
+func (w *ChatComponentWrapper) finishStream() {
+	messages := make([]Message, 0, len(w.messages))
+	for _, msg := range w.messages {
+		if msg.Type == message.TypeLoading {
+			continue
+		}
+		messages = append(messages, msg)
+	}
+	w.messages = messages
+	w.index = map[string]int{}
+}
+
+// addAgentMessage appends a message generated by the component
+func (w *ChatComponentWrapper) addAgentMessage(typ string, content string) {
+	w.messages = append(w.messages, Message{
+		ID:        fmt.Sprintf("%d", time.Now().UnixNano()),
+		Role:      "assistant",
+		Type:      typ,
+		Content:   content,
+		Timestamp: time.Now(),
+	})
+	w.updateHistoryText()
+	w.Viewport.GotoBottom()
+}
+
+// lastAgentText returns the text of the last answer
+func (w *ChatComponentWrapper) lastAgentText() string {
+	parts := []string{}
+	for i := len(w.messages) - 1; i >= 0; i-- {
+		msg := w.messages[i]
+		if msg.Role != "assistant" {
+			break
+		}
+		if msg.Type == "" || msg.Type == message.TypeText {
+			parts = append([]string{msg.Content}, parts...)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// renderAgentMessage renders the reasoning, tool calls and status lines, returns false for text messages
+func (w *ChatComponentWrapper) renderAgentMessage(msg Message) (string, bool) {
+	dim := lipgloss.NewStyle().Faint(true)
+	switch msg.Type {
+	case "", message.TypeText:
+		return "", false
+
+	case message.TypeThinking:
+		if !w.props.ShowReasoning || msg.Content == "" {
+			return "", true
+		}
+		return dim.Render("💭 " + strings.TrimSpace(msg.Content)), true
+
+	case message.TypeToolCall:
+		return dim.Render("🔧 " + msg.Content), true
+
+	case message.TypeLoading:
+		if done, _ := msg.Props["done"].(bool); done || msg.Content == "" {
+			return "", true
+		}
+		return dim.Render("⏳ " + msg.Content), true
+
+	case message.TypeError:
+		return "❌ " + msg.Content, true
+
+	case chatTypeSources:
+		return dim.Render("📚 Sources\n" + msg.Content), true
+
+	case chatTypeStatus:
+		return dim.Render("⏹ " + msg.Content), true
+	}
+
+	if msg.Content == "" {
+		return "", true
+	}
+	return dim.Render(fmt.Sprintf("[%s] %s", msg.Type, msg.Content)), true
+}
+
+const (
+	chatTypeSources = "sources" // The references cited by the answer
+	chatTypeStatus  = "status"  // Status lines, e.g. interrupted
+)
+
+// chatMessageContent returns the displayed content of an agent message
+func chatMessageContent(typ string, props map[string]interface{}) string {
+	if props == nil {
+		return ""
+	}
+
+	switch typ {
+	case message.TypeLoading:
+		text, _ := props["message"].(string)
+		return text
+
+	case message.TypeToolCall:
+		name, _ := props["name"].(string)
+		args := ""
+		switch v := props["arguments"].(type) {
+		case string:
+			args = v
+		case nil:
+		default:
+			if data, err := json.Marshal(v); err == nil {
+				args = string(data)
+			}
+		}
+		return fmt.Sprintf("%s(%s)", name, args)
+
+	case message.TypeError:
+		if text, ok := props["message"].(string); ok {
+			return text
+		}
+	}
+
+	switch v := props["content"].(type) {
+	case string:
+		return v
+	case nil:
+	default:
+		if data, err := json.Marshal(v); err == nil {
+			return string(data)
+		}
+	}
+
+	text, _ := props["text"].(string)
+	return text
+}
+
+// chatSourcesContent formats the cited references
+func chatSourcesContent(sources []ChatSource) string {
+	lines := []string{}
+	for _, source := range sources {
+		line := fmt.Sprintf("[%d] %s", source.Index, source.Title)
+		if source.URL != "" {
+			line += " " + source.URL
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+var (
+	chatCitationAnchor = regexp.MustCompile(`<a[^>]*data-ref-id="([^"]+)"[^>]*>.*?</a>`)
+	chatCitationLink   = regexp.MustCompile(`\[[^\]]*\]\(#ref:([^)\s]+)\)`)
+	chatCitationRef    = regexp.MustCompile(`#ref:([\w-]+)`)
+)
+
+// renderCitations replaces the citation markup of the answer with [id]
+func renderCitations(content string) string {
+	if !strings.Contains(content, "#ref:") && !strings.Contains(content, "data-ref-id") {
+		return content
+	}
+	content = chatCitationAnchor.ReplaceAllString(content, "[$1]")
+	content = chatCitationLink.ReplaceAllString(content, "[$1]")
+	return chatCitationRef.ReplaceAllString(content, "[$1]")
+}
+
+// newChatID generates the chat id of a new agent conversation
+func newChatID() string {
+	return "tui_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+}
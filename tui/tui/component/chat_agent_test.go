@@ -0,0 +1,215 @@
+package component
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/yaoapp/yao/agent/output/message"
+	"github.com/yaoapp/yao/tui/tui/core"
+)
+
+// drainAgent runs the wait commands of the chat until the completion is finished
+func drainAgent(t *testing.T, wrapper *ChatComponentWrapper, cmd tea.Cmd) []core.ActionMsg {
+	events := []core.ActionMsg{}
+	for cmd != nil {
+		msg := cmd()
+		targeted, ok := msg.(core.TargetedMsg)
+		if !ok {
+			t.Fatalf("Expected TargetedMsg, got %T", msg)
+		}
+		if targeted.TargetID != wrapper.GetID() {
+			t.Fatalf("Expected target %s, got %s", wrapper.GetID(), targeted.TargetID)
+		}
+
+		cmd = wrapper.delegateToBubbles(targeted.InnerMsg)
+		if _, done := targeted.InnerMsg.(chatAgentDoneMsg); done {
+			if cmd != nil {
+				if action, ok := cmd().(core.ActionMsg); ok {
+					events = append(events, action)
+				}
+			}
+			break
+		}
+	}
+	return events
+}
+
+func TestChatAgentStream(t *testing.T) {
+	var request ChatAgentRequest
+	stream := ChatAgentStream
+	defer func() { ChatAgentStream = stream }()
+	ChatAgentStream = func(ctx context.Context, req ChatAgentRequest, handler func(*message.Message)) (*ChatAgentResult, error) {
+		request = req
+		handler(&message.Message{Type: message.TypeEvent, Props: map[string]interface{}{"event": "stream_start"}})
+		handler(&message.Message{Type: message.TypeLoading, MessageID: "L1", Props: map[string]interface{}{"message": "Searching..."}})
+		handler(&message.Message{Type: message.TypeThinking, MessageID: "M1", Delta: true, DeltaAction: message.DeltaAppend, Props: map[string]interface{}{"content": "Let me "}})
+		handler(&message.Message{Type: message.TypeThinking, MessageID: "M1", Delta: true, DeltaAction: message.DeltaAppend, Props: map[string]interface{}{"content": "check"}})
+		handler(&message.Message{Type: message.TypeToolCall, MessageID: "M2", Props: map[string]interface{}{"name": "weather", "arguments": `{"city":`}})
+		handler(&message.Message{Type: message.TypeToolCall, MessageID: "M2", Delta: true, DeltaAction: message.DeltaAppend, Props: map[string]interface{}{"arguments": `"Paris"}`}})
+		handler(&message.Message{Type: message.TypeText, MessageID: "M3", Delta: true, DeltaAction: message.DeltaAppend, Props: map[string]interface{}{"content": "It is sunny"}})
+		handler(&message.Message{Type: message.TypeText, MessageID: "M3", Delta: true, DeltaAction: message.DeltaAppend, Props: map[string]interface{}{"content": ` <a class="ref" data-ref-id="1" href="#ref:1">[1]</a>`}})
+		return &ChatAgentResult{RequestID: "R1", Sources: []ChatSource{{Index: 1, Title: "Weather", URL: "https://weather.example.com"}}}, nil
+	}
+
+	props := ParseChatProps(map[string]interface{}{"assistant": "tests.weather", "locale": "en-us", "showReasoning": true})
+	wrapper := NewChatComponentWrapper(props, "copilot")
+	wrapper.SetFocus(true)
+	if !strings.HasPrefix(wrapper.ChatID(), "tui_") {
+		t.Fatalf("Expected a generated chat id, got %s", wrapper.ChatID())
+	}
+	if wrapper.Init() != nil {
+		t.Error("Expected no history to load for a new chat")
+	}
+
+	wrapper.SetValue("Weather in Paris?")
+	cmd := wrapper.delegateToBubbles(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || !wrapper.Streaming() {
+		t.Fatal("Expected the completion to start")
+	}
+
+	events := drainAgent(t, wrapper, wrapper.waitAgent())
+	if wrapper.Streaming() {
+		t.Error("Expected the completion to be finished")
+	}
+	if request.Assistant != "tests.weather" || request.Content != "Weather in Paris?" || request.ChatID != wrapper.ChatID() || request.Locale != "en-us" {
+		t.Errorf("Unexpected request %+v", request)
+	}
+
+	messages := wrapper.GetMessages()
+	types := []string{}
+	for _, msg := range messages {
+		types = append(types, msg.Type)
+	}
+	if strings.Join(types, ",") != ",thinking,tool_call,text,sources" {
+		t.Fatalf("Unexpected message types %v", types)
+	}
+	if messages[1].Content != "Let me check" {
+		t.Errorf("Expected merged reasoning, got %q", messages[1].Content)
+	}
+	if messages[2].Content != `weather({"city":"Paris"})` {
+		t.Errorf("Expected merged tool call, got %q", messages[2].Content)
+	}
+
+	history := wrapper.historyText
+	for _, expected := range []string{"💭 Let me check", `🔧 weather({"city":"Paris"})`, "[1]", "https://weather.example.com"} {
+		if !strings.Contains(history, expected) {
+			t.Errorf("Expected %q in the history", expected)
+		}
+	}
+	if strings.Contains(history, "data-ref-id") || strings.Contains(history, "Searching...") {
+		t.Error("Expected the citation markup and loading lines to be removed")
+	}
+
+	if len(events) != 1 || events[0].Action != core.EventChatMessageReceived {
+		t.Fatalf("Expected CHAT_MESSAGE_RECEIVED, got %v", events)
+	}
+	data := events[0].Data.(map[string]interface{})
+	if data["requestId"] != "R1" || !strings.HasPrefix(data["content"].(string), "It is sunny") {
+		t.Errorf("Unexpected event data %v", data)
+	}
+
+	// The messages are kept on render
+	wrapper.Render(core.RenderConfig{Data: map[string]interface{}{"assistant": "tests.weather"}})
+	if len(wrapper.GetMessages()) != len(messages) {
+		t.Error("Expected the messages to be kept on render")
+	}
+}
+
+func TestChatAgentInterrupt(t *testing.T) {
+	stream := ChatAgentStream
+	defer func() { ChatAgentStream = stream }()
+	ChatAgentStream = func(ctx context.Context, req ChatAgentRequest, handler func(*message.Message)) (*ChatAgentResult, error) {
+		handler(&message.Message{Type: message.TypeText, MessageID: "M1", Props: map[string]interface{}{"content": "Once upon"}})
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	wrapper := NewChatComponentWrapper(ParseChatProps(map[string]interface{}{"assistant": "tests.story"}), "copilot")
+	wrapper.SetFocus(true)
+	cmd := wrapper.send("Tell me a story")
+
+	msg := cmd().(core.TargetedMsg)
+	cmd = wrapper.delegateToBubbles(msg.InnerMsg)
+	if wrapper.GetMessages()[0].Content != "Once upon" {
+		t.Fatalf("Expected the first chunk, got %v", wrapper.GetMessages())
+	}
+
+	interrupt, response, handled := wrapper.HandleSpecialKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if !handled || response != core.Handled || interrupt == nil {
+		t.Fatal("Expected the interrupt key to be handled")
+	}
+	if action := interrupt().(core.ActionMsg); action.Action != core.EventChatInterrupted {
+		t.Errorf("Expected CHAT_INTERRUPTED, got %s", action.Action)
+	}
+	if wrapper.Streaming() {
+		t.Error("Expected the completion to be stopped")
+	}
+
+	// The pending wait command ends without delivering the stale completion
+	if msg := cmd(); msg != nil {
+		if _, ok := wrapper.handleAgentMsg(msg.(core.TargetedMsg).InnerMsg); !ok {
+			t.Error("Expected a stale agent message")
+		}
+	}
+
+	messages := wrapper.GetMessages()
+	last := messages[len(messages)-1]
+	if last.Type != chatTypeStatus || last.Content != "Interrupted" {
+		t.Errorf("Expected the interrupted status, got %+v", last)
+	}
+
+	// Esc is not handled when idle
+	if _, _, handled := wrapper.HandleSpecialKey(tea.KeyMsg{Type: tea.KeyEsc}); handled {
+		t.Error("Expected esc to be ignored when idle")
+	}
+}
+
+func TestChatAgentHistory(t *testing.T) {
+	history := ChatAgentHistory
+	defer func() { ChatAgentHistory = history }()
+	ChatAgentHistory = func(chatID string, limit int) ([]Message, error) {
+		if chatID != "chat-1" || limit != DefaultChatHistoryLimit {
+			t.Errorf("Unexpected history request %s %d", chatID, limit)
+		}
+		return []Message{{ID: "1", Role: "user", Content: "Hi"}, {ID: "2", Role: "assistant", Content: "Hello"}}, nil
+	}
+
+	wrapper := NewChatComponentWrapper(ParseChatProps(map[string]interface{}{"assistant": "tests.chat", "chatId": "chat-1"}), "copilot")
+	cmd := wrapper.Init()
+	if cmd == nil {
+		t.Fatal("Expected the history to be loaded")
+	}
+
+	wrapper.delegateToBubbles(cmd().(core.TargetedMsg).InnerMsg)
+	if len(wrapper.GetMessages()) != 2 || wrapper.ChatID() != "chat-1" {
+		t.Errorf("Expected 2 stored messages, got %v", wrapper.GetMessages())
+	}
+}
+
+func TestChatWriter(t *testing.T) {
+	messages := []*message.Message{}
+	writer := newChatWriter(func(msg *message.Message) { messages = append(messages, msg) })
+
+	writer.Write([]byte(`data: {"type":"text","message_id":"M1","props":{"content":"Hel`))
+	writer.Write([]byte("lo\"}}\n\ndata: {\"id\":\"G1\",\"messages\":[]}\n\n"))
+	writer.Write([]byte(`data: {"type":"loading","props":{"message":"Searching"}}` + "\n\n"))
+
+	if len(messages) != 2 {
+		t.Fatalf("Expected 2 messages, got %d", len(messages))
+	}
+	if messages[0].MessageID != "M1" || messages[0].Props["content"] != "Hello" {
+		t.Errorf("Unexpected message %+v", messages[0])
+	}
+	if messages[1].Type != message.TypeLoading {
+		t.Errorf("Expected loading, got %s", messages[1].Type)
+	}
+}
+
+func TestRenderCitations(t *testing.T) {
+	content := `Price is $999 <a class="ref" data-ref-id="2" data-ref-type="db" href="#ref:2">[2]</a>, see [docs](#ref:3) and #ref:4`
+	if res := renderCitations(content); res != "Price is $999 [2], see [3] and [4]" {
+		t.Errorf("Unexpected citations %q", res)
+	}
+}
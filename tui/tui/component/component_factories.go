@@ -224,7 +224,7 @@ func NewChatComponent(config core.RenderConfig, id string) *ChatComponentWrapper
 	}
 
 	// Use defaults if no data provided
-	if len(props.Messages) == 0 {
+	if len(props.Messages) == 0 && props.Assistant == "" {
 		props = ChatProps{
 			Messages:         []Message{},
 			InputPlaceholder: "Type your message...",
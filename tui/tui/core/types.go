@@ -504,6 +504,8 @@ const (
 	EventChatMessageReceived = "CHAT_MESSAGE_RECEIVED"
 	EventChatTypingStarted   = "CHAT_TYPING_STARTED"
 	EventChatTypingStopped   = "CHAT_TYPING_STOPPED"
+	EventChatInterrupted     = "CHAT_INTERRUPTED"
+	EventChatError           = "CHAT_ERROR"
 
 	// Data events
 	EventDataLoaded    = "DATA_LOADED"
@@ -169,12 +169,15 @@ func (s *Script) ExecuteWithModel(model *Model, method string, args ...interface
 		return nil, fmt.Errorf("model is nil")
 	}
 
-	// Create new context
-	ctx, err := s.Script.NewContext("", nil)
+	// Create new context, the processes called from the script run with the session user
+	ctx, err := s.Script.NewContext(model.Sid, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create V8 context: %w", err)
 	}
 	defer ctx.Close()
+	if model.Authorized != nil {
+		ctx.WithAuthorized(model.Authorized)
+	}
 	// err = injectModelToContext(ctx.Context, model)
 	// if err != nil {
 	// 	return nil, fmt.Errorf("failed to injectModelToContext object: %w", err)
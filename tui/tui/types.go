@@ -253,6 +253,12 @@ type Model struct {
 	// Used for sending messages from external goroutines
 	Program *tea.Program
 
+	// Sid is the session id of the processes and scripts called from the TUI (SSH sessions)
+	Sid string
+
+	// Authorized is the authorized information of the session user (SSH sessions)
+	Authorized map[string]interface{}
+
 	// MessageHandlers maps message types to their handlers
 	MessageHandlers map[string]core.MessageHandler
 
@@ -0,0 +1,42 @@
+package webhook
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yaoapp/kun/log"
+)
+
+// Guards returns the webhook-signature guards of the HTTP APIs.
+// "webhook-signature" uses the policy "default", "webhook-signature:<id>" uses the policy <id>.
+func Guards() map[string]gin.HandlerFunc {
+	guards := map[string]gin.HandlerFunc{"webhook-signature": Guard("default")}
+	lock.RLock()
+	defer lock.RUnlock()
+	for id := range Policies {
+		guards["webhook-signature:"+id] = Guard(id)
+	}
+	return guards
+}
+
+// Guard returns the guard verifying the signature of the request by the policy,
+// the policy is selected when the request comes so the guard follows the reloaded policies.
+func Guard(id string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		policy, err := Select(id)
+		if err != nil {
+			c.JSON(500, gin.H{"code": 500, "message": err.Error()})
+			c.Abort()
+			return
+		}
+
+		err = policy.Verify(c.Request)
+		if err != nil {
+			log.Warn("[Webhook] %s %s %s", id, c.Request.URL.Path, err.Error())
+			c.JSON(http.StatusUnauthorized, gin.H{"code": http.StatusUnauthorized, "message": "Invalid webhook signature"})
+			c.Abort()
+			return
+		}
+		c.Set("__webhook", id)
+	}
+}
@@ -0,0 +1,50 @@
+package webhook
+
+import "time"
+
+// The providers of the signatures
+const (
+	HMAC    = "hmac"    // HMAC-SHA256 of the body, "<timestamp>.<body>" if the timestamp header is set
+	GitHub  = "github"  // X-Hub-Signature-256: sha256=<hex>
+	Stripe  = "stripe"  // Stripe-Signature: t=<timestamp>,v1=<hex>
+	Twilio  = "twilio"  // X-Twilio-Signature: base64 HMAC-SHA1 of the url and the sorted form
+	Mailgun = "mailgun" // the hex HMAC-SHA256 of <timestamp><token> in the form or the JSON body
+)
+
+// DefaultStore the store of the nonces, shared by the instances
+const DefaultStore = "__yao.store"
+
+// Policy the verification policy of the inbound webhooks, webhooks/<id>.webhook.yao
+type Policy struct {
+	ID              string     `json:"-"`
+	Name            string     `json:"name,omitempty"`
+	Provider        string     `json:"provider,omitempty"`         // hmac (default), github, stripe, twilio or mailgun
+	Secret          string     `json:"secret,omitempty"`           // the secret, "$ENV.NAME" reads the environment variable
+	Encrypted       *Encrypted `json:"encrypted,omitempty"`        // the secret encrypted by AES-256-GCM
+	Header          string     `json:"header,omitempty"`           // the header of the signature, the provider default if empty
+	Prefix          string     `json:"prefix,omitempty"`           // the prefix of the signature, "sha256=" for example
+	Encoding        string     `json:"encoding,omitempty"`         // hex (default) or base64, hmac only
+	TimestampHeader string     `json:"timestamp_header,omitempty"` // the header of the timestamp, hmac only
+	Tolerance       string     `json:"tolerance,omitempty"`        // the max age of the timestamp, default 5m
+	Replay          bool       `json:"replay,omitempty"`           // reject the requests with the nonce seen before
+	NonceHeader     string     `json:"nonce_header,omitempty"`     // the header of the nonce, the signature is used if empty
+	Store           string     `json:"store,omitempty"`            // the gou store of the nonces, default __yao.store
+	URL             string     `json:"url,omitempty"`              // the public url of the webhook, twilio only
+	secret          []byte
+	tolerance       time.Duration
+}
+
+// Encrypted the secret encrypted by AES-256-GCM, the ciphertext is base64 encoded
+type Encrypted struct {
+	Key   string `json:"key"` // the 32 bytes key, "$ENV.NAME" reads the environment variable
+	Nonce string `json:"nonce"`
+	Text  string `json:"text"`
+}
+
+// signed the signature and the payload read from the request
+type signed struct {
+	signatures []string  // the candidate signatures, one of them should match
+	payload    []byte    // the signed payload
+	timestamp  time.Time // zero if the provider does not sign the timestamp
+	nonce      string
+}
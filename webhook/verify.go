@@ -0,0 +1,331 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/yaoapp/gou/store"
+)
+
+// maxBody the max size of the body read for the verification
+const maxBody = 10 << 20
+
+// now returns the current time, replaced in the tests
+var now = time.Now
+
+// mutex serializes the check and the save of the nonces in the instance
+var mutex sync.Mutex
+
+// nonces the methods of the gou store used by the replay protection
+type nonces interface {
+	Has(key string) bool
+	Set(key string, value interface{}, ttl time.Duration) error
+}
+
+// Verify verifies the signature of the request, the body is restored for the handlers.
+func (policy *Policy) Verify(req *http.Request) error {
+	var st nonces
+	if policy.Replay {
+		s, err := store.Get(policy.Store)
+		if err != nil {
+			return fmt.Errorf("the nonce store %s is not available: %s", policy.Store, err.Error())
+		}
+		st = s
+	}
+	return policy.verify(req, st)
+}
+
+func (policy *Policy) verify(req *http.Request, st nonces) error {
+	body, err := readBody(req)
+	if err != nil {
+		return err
+	}
+
+	var sig *signed
+	switch policy.Provider {
+	case Stripe:
+		sig, err = policy.stripe(req, body)
+	case Twilio:
+		sig, err = policy.twilio(req, body)
+	case Mailgun:
+		sig, err = policy.mailgun(req, body)
+	default:
+		sig, err = policy.hmac(req, body)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !sig.timestamp.IsZero() && policy.tolerance > 0 {
+		age := now().Sub(sig.timestamp)
+		if age > policy.tolerance || age < -policy.tolerance {
+			return fmt.Errorf("the timestamp is out of the tolerance %s", policy.Tolerance)
+		}
+	}
+
+	expected := policy.sign(sig.payload)
+	matched := false
+	for _, signature := range sig.signatures {
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return fmt.Errorf("the signature is invalid")
+	}
+
+	if st != nil {
+		return policy.checkNonce(st, sig.nonce)
+	}
+	return nil
+}
+
+// hmac the signature of the body, or "<timestamp>.<body>" if the timestamp header is set (github too)
+func (policy *Policy) hmac(req *http.Request, body []byte) (*signed, error) {
+	signature := req.Header.Get(policy.Header)
+	if signature == "" {
+		return nil, fmt.Errorf("the signature header %s is missing", policy.Header)
+	}
+
+	sig := &signed{
+		signatures: []string{strings.TrimPrefix(signature, policy.Prefix)},
+		payload:    body,
+		nonce:      signature,
+	}
+
+	if policy.TimestampHeader != "" {
+		ts := req.Header.Get(policy.TimestampHeader)
+		timestamp, err := parseUnix(ts)
+		if err != nil {
+			return nil, fmt.Errorf("the timestamp header %s is invalid", policy.TimestampHeader)
+		}
+		sig.timestamp = timestamp
+		sig.payload = append([]byte(ts+"."), body...)
+	}
+
+	if policy.NonceHeader != "" {
+		sig.nonce = req.Header.Get(policy.NonceHeader)
+	}
+	return sig, nil
+}
+
+// stripe Stripe-Signature: t=<timestamp>,v1=<signature>[,v1=<signature>], the payload is "<timestamp>.<body>"
+func (policy *Policy) stripe(req *http.Request, body []byte) (*signed, error) {
+	header := req.Header.Get(policy.Header)
+	if header == "" {
+		return nil, fmt.Errorf("the signature header %s is missing", policy.Header)
+	}
+
+	ts := ""
+	sig := &signed{signatures: []string{}}
+	for _, part := range strings.Split(header, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch name {
+		case "t":
+			ts = value
+		case "v1":
+			sig.signatures = append(sig.signatures, value)
+		}
+	}
+
+	timestamp, err := parseUnix(ts)
+	if err != nil || len(sig.signatures) == 0 {
+		return nil, fmt.Errorf("the signature header %s is invalid", policy.Header)
+	}
+
+	sig.timestamp = timestamp
+	sig.payload = append([]byte(ts+"."), body...)
+	sig.nonce = ts + ":" + sig.signatures[0]
+	if policy.NonceHeader != "" {
+		sig.nonce = req.Header.Get(policy.NonceHeader)
+	}
+	return sig, nil
+}
+
+// twilio the base64 HMAC-SHA1 of the url followed by the sorted form parameters.
+// The JSON requests are signed with the url only, the body is checked by the bodySHA256 parameter.
+func (policy *Policy) twilio(req *http.Request, body []byte) (*signed, error) {
+	signature := req.Header.Get(policy.Header)
+	if signature == "" {
+		return nil, fmt.Errorf("the signature header %s is missing", policy.Header)
+	}
+
+	link := policy.URL
+	if link == "" {
+		link = requestURL(req)
+	} else if req.URL.RawQuery != "" && !strings.Contains(link, "?") {
+		link = link + "?" + req.URL.RawQuery
+	}
+
+	payload := link
+	if hash := req.URL.Query().Get("bodySHA256"); hash != "" {
+		sum := sha256.Sum256(body)
+		if !hmac.Equal([]byte(hex.EncodeToString(sum[:])), []byte(strings.ToLower(hash))) {
+			return nil, fmt.Errorf("the body does not match the bodySHA256")
+		}
+	} else if strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, fmt.Errorf("the form is invalid: %s", err.Error())
+		}
+		keys := make([]string, 0, len(form))
+		for key := range form {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			values := form[key]
+			sort.Strings(values)
+			for _, value := range values {
+				payload += key + value
+			}
+		}
+	}
+
+	return &signed{signatures: []string{signature}, payload: []byte(payload), nonce: signature}, nil
+}
+
+// mailgun the hex HMAC-SHA256 of <timestamp><token>, read from the form or the "signature" of the JSON body
+func (policy *Policy) mailgun(req *http.Request, body []byte) (*signed, error) {
+	var ts, token, signature string
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") {
+		var data struct {
+			Signature struct {
+				Timestamp string `json:"timestamp"`
+				Token     string `json:"token"`
+				Signature string `json:"signature"`
+			} `json:"signature"`
+		}
+		if err := jsoniter.Unmarshal(body, &data); err != nil {
+			return nil, fmt.Errorf("the body is invalid: %s", err.Error())
+		}
+		ts, token, signature = data.Signature.Timestamp, data.Signature.Token, data.Signature.Signature
+	} else {
+		form, err := formValues(req, body)
+		if err != nil {
+			return nil, err
+		}
+		ts, token, signature = form.Get("timestamp"), form.Get("token"), form.Get("signature")
+	}
+
+	if signature == "" || token == "" {
+		return nil, fmt.Errorf("the signature is missing")
+	}
+
+	timestamp, err := parseUnix(ts)
+	if err != nil {
+		return nil, fmt.Errorf("the timestamp is invalid")
+	}
+	return &signed{signatures: []string{signature}, payload: []byte(ts + token), timestamp: timestamp, nonce: token}, nil
+}
+
+// sign returns the signature of the payload in the encoding of the provider
+func (policy *Policy) sign(payload []byte) string {
+	if policy.Provider == Twilio {
+		mac := hmac.New(sha1.New, policy.secret)
+		mac.Write(payload)
+		return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	}
+
+	mac := hmac.New(sha256.New, policy.secret)
+	mac.Write(payload)
+	if policy.Provider == HMAC && policy.Encoding == "base64" {
+		return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	}
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// checkNonce rejects the nonce seen before, the nonces are kept as long as the timestamps are accepted
+func (policy *Policy) checkNonce(st nonces, nonce string) error {
+	if nonce == "" {
+		return fmt.Errorf("the nonce is missing")
+	}
+
+	ttl := policy.tolerance * 2
+	if ttl == 0 {
+		ttl = 24 * time.Hour
+	}
+
+	key := fmt.Sprintf("webhook:%s:%x", policy.ID, sha256.Sum256([]byte(nonce)))
+	mutex.Lock()
+	defer mutex.Unlock()
+	if st.Has(key) {
+		return fmt.Errorf("the request is replayed")
+	}
+	return st.Set(key, now().Unix(), ttl)
+}
+
+// readBody reads the body and restores it for the handlers
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return []byte{}, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxBody+1))
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxBody {
+		return nil, fmt.Errorf("the body is too large")
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// formValues parses the urlencoded or the multipart form of the body
+func formValues(req *http.Request, body []byte) (url.Values, error) {
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/form-data") {
+		clone := req.Clone(req.Context())
+		clone.Body = io.NopCloser(bytes.NewReader(body))
+		if err := clone.ParseMultipartForm(maxBody); err != nil {
+			return nil, fmt.Errorf("the form is invalid: %s", err.Error())
+		}
+		return url.Values(clone.MultipartForm.Value), nil
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, fmt.Errorf("the form is invalid: %s", err.Error())
+	}
+	return form, nil
+}
+
+// requestURL returns the public url of the request behind the proxies
+func requestURL(req *http.Request) string {
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+	if proto := req.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = strings.TrimSpace(strings.Split(proto, ",")[0])
+	}
+
+	host := req.Host
+	if forwarded := req.Header.Get("X-Forwarded-Host"); forwarded != "" {
+		host = strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	}
+	return scheme + "://" + host + req.URL.RequestURI()
+}
+
+func parseUnix(value string) (time.Time, error) {
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(seconds, 0), nil
+}
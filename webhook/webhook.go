@@ -0,0 +1,184 @@
+package webhook
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yaoapp/gou/application"
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/crypto"
+	"github.com/yaoapp/yao/share"
+)
+
+// Policies the loaded policies
+var Policies = map[string]*Policy{}
+
+var lock sync.RWMutex
+
+// Load the policies from the webhooks directory
+func Load(cfg config.Config) error {
+	exists, err := application.App.Exists("webhooks")
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+
+	messages := []string{}
+	exts := []string{"*.webhook.yao", "*.webhook.json", "*.webhook.jsonc"}
+	err = application.App.Walk("webhooks", func(root, file string, isdir bool) error {
+		if isdir {
+			return nil
+		}
+		_, err := LoadFile(file, share.ID(root, file))
+		if err != nil {
+			messages = append(messages, err.Error())
+		}
+		return nil
+	}, exts...)
+
+	if len(messages) > 0 {
+		return fmt.Errorf("%s", strings.Join(messages, ";\n"))
+	}
+	return err
+}
+
+// LoadFile load the policy from the file
+func LoadFile(file string, id string) (*Policy, error) {
+	data, err := application.App.Read(file)
+	if err != nil {
+		return nil, err
+	}
+	return LoadSource(file, data, id)
+}
+
+// LoadSource load the policy from the source
+func LoadSource(file string, data []byte, id string) (*Policy, error) {
+	policy := &Policy{}
+	err := application.Parse(file, data, policy)
+	if err != nil {
+		return nil, fmt.Errorf("[Webhook] %s %s", id, err.Error())
+	}
+
+	policy.ID = id
+	err = policy.validate()
+	if err != nil {
+		return nil, fmt.Errorf("[Webhook] %s %s", id, err.Error())
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	Policies[id] = policy
+	return policy, nil
+}
+
+// Select the policy by id
+func Select(id string) (*Policy, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+	policy, has := Policies[id]
+	if !has {
+		return nil, fmt.Errorf("webhook policy %s not found", id)
+	}
+	return policy, nil
+}
+
+// Unload the policy
+func Unload(id string) {
+	lock.Lock()
+	defer lock.Unlock()
+	delete(Policies, id)
+}
+
+// validate the policy, set the defaults and read the secret
+func (policy *Policy) validate() error {
+	switch policy.Provider {
+	case "":
+		policy.Provider = HMAC
+	case HMAC, GitHub, Stripe, Twilio, Mailgun:
+	default:
+		return fmt.Errorf("provider %s is not supported, use hmac, github, stripe, twilio or mailgun", policy.Provider)
+	}
+
+	if policy.Header == "" {
+		switch policy.Provider {
+		case HMAC:
+			policy.Header = "X-Signature"
+		case GitHub:
+			policy.Header = "X-Hub-Signature-256"
+		case Stripe:
+			policy.Header = "Stripe-Signature"
+		case Twilio:
+			policy.Header = "X-Twilio-Signature"
+		}
+	}
+
+	if policy.Provider == GitHub && policy.Prefix == "" {
+		policy.Prefix = "sha256="
+	}
+
+	if policy.Provider == GitHub && policy.NonceHeader == "" {
+		policy.NonceHeader = "X-GitHub-Delivery"
+	}
+
+	switch policy.Encoding {
+	case "":
+		policy.Encoding = "hex"
+	case "hex", "base64":
+	default:
+		return fmt.Errorf("encoding %s is not supported, use hex or base64", policy.Encoding)
+	}
+
+	if policy.Tolerance == "" {
+		policy.Tolerance = "5m"
+	}
+	tolerance, err := time.ParseDuration(policy.Tolerance)
+	if err != nil {
+		return fmt.Errorf("tolerance %s is invalid: %s", policy.Tolerance, err.Error())
+	}
+	policy.tolerance = tolerance
+
+	if policy.Store == "" {
+		policy.Store = DefaultStore
+	}
+
+	secret, err := policy.readSecret()
+	if err != nil {
+		return err
+	}
+	if secret == "" {
+		return fmt.Errorf("secret is required")
+	}
+	policy.secret = []byte(secret)
+	return nil
+}
+
+// readSecret reads the secret from the environment variable or decrypts it
+func (policy *Policy) readSecret() (string, error) {
+	if policy.Encrypted == nil {
+		return env(policy.Secret), nil
+	}
+
+	key := env(policy.Encrypted.Key)
+	if key == "" {
+		return "", fmt.Errorf("the key of the encrypted secret is required")
+	}
+
+	secret, err := crypto.AES256Decrypt(key, "GCM", policy.Encrypted.Nonce, policy.Encrypted.Text, "", "base64")
+	if err != nil {
+		return "", fmt.Errorf("the secret can not be decrypted: %s", err.Error())
+	}
+	return secret, nil
+}
+
+// env returns the value of the environment variable if the value is $ENV.NAME
+func env(value string) string {
+	if name, ok := strings.CutPrefix(value, "$ENV."); ok {
+		return os.Getenv(name)
+	}
+	return value
+}
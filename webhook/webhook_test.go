@@ -0,0 +1,149 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/yao/crypto"
+)
+
+type memory map[string]interface{}
+
+func (m memory) Has(key string) bool {
+	_, has := m[key]
+	return has
+}
+
+func (m memory) Set(key string, value interface{}, ttl time.Duration) error {
+	m[key] = value
+	return nil
+}
+
+func TestGitHub(t *testing.T) {
+	policy := prepare(t, &Policy{ID: "github", Provider: GitHub, Secret: "$ENV.TEST_WEBHOOK_SECRET", Replay: true})
+	body := `{"action":"opened"}`
+
+	req := httptest.NewRequest("POST", "/api/hooks/github", strings.NewReader(body))
+	req.Header.Set("X-Hub-Signature-256", "sha256="+hmacHex("secret", body))
+	req.Header.Set("X-GitHub-Delivery", "d-1")
+
+	st := memory{}
+	require.NoError(t, policy.verify(req, st))
+
+	// The body is restored for the handlers
+	restored, _ := io.ReadAll(req.Body)
+	assert.Equal(t, body, string(restored))
+
+	// The delivery is replayed
+	req = httptest.NewRequest("POST", "/api/hooks/github", strings.NewReader(body))
+	req.Header.Set("X-Hub-Signature-256", "sha256="+hmacHex("secret", body))
+	req.Header.Set("X-GitHub-Delivery", "d-1")
+	assert.ErrorContains(t, policy.verify(req, st), "replayed")
+
+	req = httptest.NewRequest("POST", "/api/hooks/github", strings.NewReader(`{"action":"closed"}`))
+	req.Header.Set("X-Hub-Signature-256", "sha256="+hmacHex("secret", body))
+	req.Header.Set("X-GitHub-Delivery", "d-2")
+	assert.ErrorContains(t, policy.verify(req, st), "signature is invalid")
+}
+
+func TestStripe(t *testing.T) {
+	policy := prepare(t, &Policy{ID: "stripe", Provider: Stripe, Secret: "secret"})
+	body := `{"type":"charge.succeeded"}`
+	ts := fmt.Sprintf("%d", time.Now().Unix())
+
+	req := httptest.NewRequest("POST", "/api/hooks/stripe", strings.NewReader(body))
+	req.Header.Set("Stripe-Signature", fmt.Sprintf("t=%s,v1=%s,v1=%s", ts, "rotated", hmacHex("secret", ts+"."+body)))
+	require.NoError(t, policy.verify(req, nil))
+
+	// The timestamp is out of the tolerance
+	now = func() time.Time { return time.Now().Add(10 * time.Minute) }
+	defer func() { now = time.Now }()
+	req = httptest.NewRequest("POST", "/api/hooks/stripe", strings.NewReader(body))
+	req.Header.Set("Stripe-Signature", fmt.Sprintf("t=%s,v1=%s", ts, hmacHex("secret", ts+"."+body)))
+	assert.ErrorContains(t, policy.verify(req, nil), "tolerance")
+}
+
+func TestTwilio(t *testing.T) {
+	policy := prepare(t, &Policy{ID: "twilio", Provider: Twilio, Secret: "token"})
+	form := url.Values{"From": {"+15550001"}, "Body": {"hello"}}
+
+	req := httptest.NewRequest("POST", "/api/hooks/twilio?id=1", strings.NewReader(form.Encode()))
+	req.Host = "internal:5099"
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Forwarded-Proto", "https")
+	req.Header.Set("X-Forwarded-Host", "example.com")
+
+	mac := hmac.New(sha1.New, []byte("token"))
+	mac.Write([]byte("https://example.com/api/hooks/twilio?id=1BodyhelloFrom+15550001"))
+	req.Header.Set("X-Twilio-Signature", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	require.NoError(t, policy.verify(req, nil))
+
+	// The form values are still available
+	assert.Equal(t, "hello", req.FormValue("Body"))
+}
+
+func TestMailgun(t *testing.T) {
+	policy := prepare(t, &Policy{ID: "mailgun", Provider: Mailgun, Secret: "key", Replay: true})
+	ts := fmt.Sprintf("%d", time.Now().Unix())
+	st := memory{}
+
+	form := url.Values{"event": {"delivered"}, "timestamp": {ts}, "token": {"t-1"}, "signature": {hmacHex("key", ts+"t-1")}}
+	req := httptest.NewRequest("POST", "/api/hooks/mailgun", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	require.NoError(t, policy.verify(req, st))
+
+	body := fmt.Sprintf(`{"signature":{"timestamp":"%s","token":"t-2","signature":"%s"},"event-data":{}}`, ts, hmacHex("key", ts+"t-2"))
+	req = httptest.NewRequest("POST", "/api/hooks/mailgun", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	require.NoError(t, policy.verify(req, st))
+
+	req = httptest.NewRequest("POST", "/api/hooks/mailgun", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	assert.ErrorContains(t, policy.verify(req, st), "replayed")
+}
+
+func TestEncryptedSecret(t *testing.T) {
+	key := "0123456789abcdef0123456789abcdef"
+	nonce := "0123456789ab"
+	text, err := crypto.AES256Encrypt(key, "GCM", nonce, "secret", "", "base64")
+	require.NoError(t, err)
+
+	t.Setenv("TEST_WEBHOOK_KEY", key)
+	policy := prepare(t, &Policy{ID: "hmac", Encrypted: &Encrypted{Key: "$ENV.TEST_WEBHOOK_KEY", Nonce: nonce, Text: text}, TimestampHeader: "X-Timestamp"})
+	assert.Equal(t, "secret", string(policy.secret))
+
+	body := `{"id":1}`
+	ts := fmt.Sprintf("%d", time.Now().Unix())
+	req := httptest.NewRequest(http.MethodPost, "/api/hooks/hmac", strings.NewReader(body))
+	req.Header.Set("X-Timestamp", ts)
+	req.Header.Set("X-Signature", hmacHex("secret", ts+"."+body))
+	require.NoError(t, policy.verify(req, nil))
+
+	assert.ErrorContains(t, (&Policy{Provider: "paypal", Secret: "secret"}).validate(), "not supported")
+	assert.ErrorContains(t, (&Policy{Secret: "$ENV.TEST_WEBHOOK_MISSING"}).validate(), "secret is required")
+}
+
+func prepare(t *testing.T, policy *Policy) *Policy {
+	t.Setenv("TEST_WEBHOOK_SECRET", "secret")
+	require.NoError(t, policy.validate())
+	return policy
+}
+
+func hmacHex(key, value string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,197 @@
+package websocket
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gorilla/websocket"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/kun/log"
+)
+
+// sendBuffer the number of the messages queued for a connection, the slow connections are closed
+const sendBuffer = 256
+
+// Send the message to the connection
+func (conn *Conn) Send(message []byte) error {
+	select {
+	case <-conn.done:
+		return fmt.Errorf("connection %s is closed", conn.ID)
+	default:
+	}
+
+	select {
+	case conn.send <- message:
+		return nil
+	default:
+		log.Warn("[WebSocket] %s %s the send buffer is full, close the connection", conn.ServerID, conn.ID)
+		conn.Close()
+		return fmt.Errorf("connection %s is too slow", conn.ID)
+	}
+}
+
+// Close the connection
+func (conn *Conn) Close() {
+	conn.once.Do(func() {
+		close(conn.done)
+	})
+}
+
+// Info returns the information of the connection passed to the handlers
+func (conn *Conn) Info() map[string]interface{} {
+	conn.hub.mutex.RLock()
+	defer conn.hub.mutex.RUnlock()
+	return conn.infoLocked()
+}
+
+func (conn *Conn) infoLocked() map[string]interface{} {
+	rooms := make([]string, 0, len(conn.rooms))
+	for room := range conn.rooms {
+		rooms = append(rooms, room)
+	}
+	sort.Strings(rooms)
+
+	info := map[string]interface{}{
+		"id":        conn.ID,
+		"server":    conn.ServerID,
+		"sid":       conn.Sid,
+		"rooms":     rooms,
+		"query":     conn.Query,
+		"connected": conn.Connected.Unix(),
+	}
+
+	if conn.Authorized != nil {
+		info["user_id"] = conn.Authorized.UserID
+		info["team_id"] = conn.Authorized.TeamID
+		info["client_id"] = conn.Authorized.ClientID
+	}
+	return info
+}
+
+// read the messages until the connection is closed, the messages are handled in order
+func (conn *Conn) read(server *Server) {
+	defer conn.Close()
+
+	pongWait := time.Duration(server.Limit.PongWait) * time.Second
+	conn.ws.SetReadLimit(server.Limit.MaxMessage)
+	conn.ws.SetReadDeadline(time.Now().Add(pongWait))
+	conn.ws.SetPongHandler(func(string) error {
+		return conn.ws.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		typ, data, err := conn.ws.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure, websocket.CloseNoStatusReceived) {
+				log.Warn("[WebSocket] %s %s %s", conn.ServerID, conn.ID, err.Error())
+			}
+			return
+		}
+
+		// The server may be reloaded, the handlers of the latest version are used
+		if current, err := Select(conn.ServerID); err == nil {
+			server = current
+		}
+
+		if server.Event.Message == "" {
+			continue
+		}
+
+		res, err := conn.exec(server.Event.Message, conn.Info(), parseMessage(typ, data))
+		if err != nil {
+			log.Error("[WebSocket] %s %s %s", conn.ServerID, server.Event.Message, err.Error())
+			conn.Send(errorMessage(err))
+			continue
+		}
+
+		if res != nil {
+			conn.Send(encode(res))
+		}
+	}
+}
+
+// write the queued messages and ping the client until the connection is closed
+func (conn *Conn) write(server *Server) {
+	writeWait := time.Duration(server.Limit.WriteWait) * time.Second
+	ticker := time.NewTicker(time.Duration(server.Limit.PongWait) * time.Second * 9 / 10)
+	defer func() {
+		ticker.Stop()
+		conn.ws.Close()
+	}()
+
+	for {
+		select {
+		case message := <-conn.send:
+			conn.ws.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := conn.ws.WriteMessage(websocket.TextMessage, message); err != nil {
+				conn.Close()
+				return
+			}
+
+		case <-ticker.C:
+			conn.ws.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := conn.ws.WriteMessage(websocket.PingMessage, nil); err != nil {
+				conn.Close()
+				return
+			}
+
+		case <-conn.done:
+			conn.ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(writeWait))
+			return
+		}
+	}
+}
+
+// exec the handler process with the session and the authorized information of the connection
+func (conn *Conn) exec(name string, args ...interface{}) (interface{}, error) {
+	p, err := process.Of(name, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	p.WithSID(conn.Sid)
+	if conn.Authorized != nil {
+		p.WithAuthorized(conn.Authorized.AuthorizedToMap())
+	}
+
+	err = p.Execute()
+	if err != nil {
+		return nil, err
+	}
+	defer p.Release()
+	return p.Value(), nil
+}
+
+// parseMessage the JSON messages are decoded, the others are passed as string
+func parseMessage(typ int, data []byte) interface{} {
+	if typ == websocket.TextMessage {
+		var message interface{}
+		if err := jsoniter.Unmarshal(data, &message); err == nil {
+			return message
+		}
+	}
+	return string(data)
+}
+
+// encode the message sent to the clients, the strings and bytes are sent as is
+func encode(message interface{}) []byte {
+	switch value := message.(type) {
+	case []byte:
+		return value
+	case string:
+		return []byte(value)
+	}
+
+	data, err := jsoniter.Marshal(message)
+	if err != nil {
+		return []byte(fmt.Sprintf("%v", message))
+	}
+	return data
+}
+
+func errorMessage(err error) []byte {
+	data, _ := jsoniter.Marshal(map[string]interface{}{"error": err.Error()})
+	return data
+}
@@ -0,0 +1,214 @@
+package websocket
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/yaoapp/gou/session"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/openapi/oauth"
+	"github.com/yaoapp/yao/openapi/oauth/authorized"
+	"github.com/yaoapp/yao/openapi/oauth/types"
+)
+
+// sessionKeys the session data of the authorized connections
+var sessionKeys = []string{"__user_id", "__team_id", "__subject", "__scope", "__client_id", "user_id"}
+
+// SetRoutes register the WebSocket endpoint GET /websocket/:name, the servers are selected on each request
+// so the reloaded servers are served without restarting
+func SetRoutes(router *gin.Engine) {
+	router.GET("/websocket/:name", Handler)
+}
+
+// Handler upgrade the request to the WebSocket connection of the server
+func Handler(c *gin.Context) {
+	server, err := Select(c.Param("name"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	var auth *types.AuthorizedInfo
+	if server.Guard == "oauth" {
+		if oauth.OAuth == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "oauth is not enabled"})
+			return
+		}
+
+		// The browsers can not set the headers of the WebSocket requests, the token can be passed in the query
+		if token := c.Query("token"); token != "" && c.GetHeader("Authorization") == "" {
+			c.Request.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		oauth.OAuth.Guard(c)
+		if c.IsAborted() {
+			return
+		}
+		auth = authorized.GetInfo(c)
+	}
+
+	server.Serve(c, auth)
+}
+
+// Serve upgrade the request and serve the connection until it is closed
+func (server *Server) Serve(c *gin.Context, auth *types.AuthorizedInfo) {
+	if server.Limit.MaxConnections > 0 && server.hub.Count() >= server.Limit.MaxConnections {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "too many connections"})
+		return
+	}
+
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  server.Buffer.Read,
+		WriteBufferSize: server.Buffer.Write,
+		Subprotocols:    server.Protocols,
+		CheckOrigin:     server.checkOrigin,
+	}
+
+	// The upgrader responds the errors
+	ws, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Error("[WebSocket] %s upgrade: %s", server.ID, err.Error())
+		return
+	}
+
+	conn := &Conn{
+		ID:         uuid.NewString(),
+		Sid:        uuid.NewString(),
+		ServerID:   server.ID,
+		Authorized: auth,
+		Query:      map[string]string{},
+		Connected:  time.Now(),
+		hub:        server.hub,
+		ws:         ws,
+		send:       make(chan []byte, sendBuffer),
+		rooms:      map[string]bool{},
+		done:       make(chan struct{}),
+	}
+	for key := range c.Request.URL.Query() {
+		if key != "token" {
+			conn.Query[key] = c.Query(key)
+		}
+	}
+
+	go conn.write(server)
+	err = server.hub.add(conn, server.Limit.MaxConnections)
+	if err != nil {
+		conn.Close()
+		return
+	}
+
+	defer func() {
+		server.hub.remove(conn)
+		conn.clearSession()
+	}()
+
+	if !conn.connect(server) {
+		conn.Close()
+		return
+	}
+
+	conn.read(server)
+
+	// The close handler of the latest version
+	if current, err := Select(server.ID); err == nil {
+		server = current
+	}
+	if server.Event.Close != "" {
+		if _, err := conn.exec(server.Event.Close, conn.Info()); err != nil {
+			log.Error("[WebSocket] %s %s %s", server.ID, server.Event.Close, err.Error())
+		}
+	}
+}
+
+// connect store the session, join the default rooms and call the connect handler.
+// Returns false if the connection is rejected.
+func (conn *Conn) connect(server *Server) bool {
+	if conn.Authorized != nil {
+		err := session.Global().ID(conn.Sid).SetMany(map[string]interface{}{
+			"__user_id":   conn.Authorized.UserID,
+			"__team_id":   conn.Authorized.TeamID,
+			"__subject":   conn.Authorized.Subject,
+			"__scope":     conn.Authorized.Scope,
+			"__client_id": conn.Authorized.ClientID,
+			"user_id":     conn.Authorized.UserID,
+		})
+		if err != nil {
+			log.Error("[WebSocket] %s session: %s", server.ID, err.Error())
+		}
+	}
+
+	for _, room := range server.Rooms {
+		conn.hub.Join(conn.ID, room)
+	}
+
+	if server.Event.Connect == "" {
+		return true
+	}
+
+	res, err := conn.exec(server.Event.Connect, conn.Info())
+	if err != nil {
+		log.Error("[WebSocket] %s %s %s", server.ID, server.Event.Connect, err.Error())
+		conn.Send(errorMessage(err))
+		return false
+	}
+
+	switch value := res.(type) {
+	case bool:
+		return value
+
+	case map[string]interface{}:
+		if rooms, ok := value["rooms"].([]interface{}); ok {
+			for _, room := range rooms {
+				if name, ok := room.(string); ok && name != "" {
+					conn.hub.Join(conn.ID, name)
+				}
+			}
+		}
+	}
+	return true
+}
+
+// clearSession remove the session data of the connection
+func (conn *Conn) clearSession() {
+	if conn.Authorized == nil {
+		return
+	}
+	ss := session.Global().ID(conn.Sid)
+	for _, key := range sessionKeys {
+		ss.Del(key)
+	}
+}
+
+// checkOrigin the origin must be in the allowed origins, "*" allows all origins.
+// Without allowed origins only the same origin is allowed (the default check of gorilla),
+// the socket is authorized by the cookies so the other websites must not open it.
+func (server *Server) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if len(server.Origins) == 0 {
+		return sameOrigin(r, origin)
+	}
+
+	for _, allowed := range server.Origins {
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// sameOrigin checks the host of the origin is the host of the request, the requests without origin are not from browsers
+func sameOrigin(r *http.Request, origin string) bool {
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, r.Host)
+}
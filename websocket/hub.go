@@ -0,0 +1,177 @@
+package websocket
+
+import (
+	"fmt"
+	"sort"
+)
+
+func newHub() *Hub {
+	return &Hub{
+		conns: map[string]*Conn{},
+		rooms: map[string]map[string]*Conn{},
+	}
+}
+
+// add the connection, fails if the server reaches the max connections
+func (hub *Hub) add(conn *Conn, max int) error {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+	if max > 0 && len(hub.conns) >= max {
+		return fmt.Errorf("too many connections")
+	}
+	hub.conns[conn.ID] = conn
+	return nil
+}
+
+// remove the connection and leave all the rooms
+func (hub *Hub) remove(conn *Conn) {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+	delete(hub.conns, conn.ID)
+	for room := range conn.rooms {
+		hub.leaveLocked(conn, room)
+	}
+}
+
+func (hub *Hub) get(id string) (*Conn, bool) {
+	hub.mutex.RLock()
+	defer hub.mutex.RUnlock()
+	conn, has := hub.conns[id]
+	return conn, has
+}
+
+// Join the connection to the room
+func (hub *Hub) Join(id string, room string) error {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+	conn, has := hub.conns[id]
+	if !has {
+		return fmt.Errorf("connection %s not found", id)
+	}
+
+	if hub.rooms[room] == nil {
+		hub.rooms[room] = map[string]*Conn{}
+	}
+	hub.rooms[room][id] = conn
+	conn.rooms[room] = true
+	return nil
+}
+
+// Leave the room
+func (hub *Hub) Leave(id string, room string) error {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+	conn, has := hub.conns[id]
+	if !has {
+		return fmt.Errorf("connection %s not found", id)
+	}
+	hub.leaveLocked(conn, room)
+	return nil
+}
+
+func (hub *Hub) leaveLocked(conn *Conn, room string) {
+	delete(conn.rooms, room)
+	members, has := hub.rooms[room]
+	if !has {
+		return
+	}
+	delete(members, conn.ID)
+	if len(members) == 0 {
+		delete(hub.rooms, room)
+	}
+}
+
+// Send the message to the connection
+func (hub *Hub) Send(id string, message []byte) error {
+	conn, has := hub.get(id)
+	if !has {
+		return fmt.Errorf("connection %s not found", id)
+	}
+	return conn.Send(message)
+}
+
+// Broadcast the message to the connections in the room, all the connections if the room is empty.
+// Returns the number of the connections the message is sent to.
+func (hub *Hub) Broadcast(room string, message []byte, except ...string) int {
+	hub.mutex.RLock()
+	targets := hub.conns
+	if room != "" {
+		targets = hub.rooms[room]
+	}
+
+	conns := make([]*Conn, 0, len(targets))
+	for id, conn := range targets {
+		if len(except) > 0 && contains(except, id) {
+			continue
+		}
+		conns = append(conns, conn)
+	}
+	hub.mutex.RUnlock()
+
+	sent := 0
+	for _, conn := range conns {
+		if err := conn.Send(message); err == nil {
+			sent++
+		}
+	}
+	return sent
+}
+
+// Rooms returns the rooms and the number of the connections in each room
+func (hub *Hub) Rooms() map[string]int {
+	hub.mutex.RLock()
+	defer hub.mutex.RUnlock()
+	rooms := make(map[string]int, len(hub.rooms))
+	for room, members := range hub.rooms {
+		rooms[room] = len(members)
+	}
+	return rooms
+}
+
+// Connections returns the connections in the room, all the connections if the room is empty
+func (hub *Hub) Connections(room string) []map[string]interface{} {
+	hub.mutex.RLock()
+	targets := hub.conns
+	if room != "" {
+		targets = hub.rooms[room]
+	}
+
+	res := make([]map[string]interface{}, 0, len(targets))
+	for _, conn := range targets {
+		res = append(res, conn.infoLocked())
+	}
+	hub.mutex.RUnlock()
+
+	sort.Slice(res, func(i, j int) bool { return res[i]["id"].(string) < res[j]["id"].(string) })
+	return res
+}
+
+// Count returns the number of the connections
+func (hub *Hub) Count() int {
+	hub.mutex.RLock()
+	defer hub.mutex.RUnlock()
+	return len(hub.conns)
+}
+
+// closeAll closes all the connections
+func (hub *Hub) closeAll() {
+	hub.mutex.RLock()
+	conns := make([]*Conn, 0, len(hub.conns))
+	for _, conn := range hub.conns {
+		conns = append(conns, conn)
+	}
+	hub.mutex.RUnlock()
+
+	for _, conn := range conns {
+		conn.Close()
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
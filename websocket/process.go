@@ -0,0 +1,113 @@
+package websocket
+
+import (
+	"fmt"
+
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/kun/exception"
+)
+
+func init() {
+	process.RegisterGroup("websocket", map[string]process.Handler{
+		"broadcast":   processBroadcast,
+		"send":        processSend,
+		"join":        processJoin,
+		"leave":       processLeave,
+		"rooms":       processRooms,
+		"connections": processConnections,
+		"close":       processClose,
+	})
+}
+
+// processBroadcast websocket.Broadcast (:server, :room, :message, [:except])
+// Sends the message to the connections in the room, all the connections if the room is empty.
+// Returns the number of the connections the message is sent to.
+func processBroadcast(process *process.Process) interface{} {
+	process.ValidateArgNums(3)
+	server := mustSelect(process.ArgsString(0))
+
+	except := []string{}
+	if process.NumOfArgs() > 3 {
+		switch value := process.Args[3].(type) {
+		case string:
+			except = append(except, value)
+		case []interface{}:
+			for _, id := range value {
+				except = append(except, fmt.Sprintf("%v", id))
+			}
+		case []string:
+			except = value
+		}
+	}
+	return server.hub.Broadcast(process.ArgsString(1), encode(process.Args[2]), except...)
+}
+
+// processSend websocket.Send (:server, :connection, :message)
+func processSend(process *process.Process) interface{} {
+	process.ValidateArgNums(3)
+	server := mustSelect(process.ArgsString(0))
+	err := server.hub.Send(process.ArgsString(1), encode(process.Args[2]))
+	if err != nil {
+		exception.New(err.Error(), 404).Throw()
+	}
+	return nil
+}
+
+// processJoin websocket.Join (:server, :connection, :room)
+func processJoin(process *process.Process) interface{} {
+	process.ValidateArgNums(3)
+	server := mustSelect(process.ArgsString(0))
+	err := server.hub.Join(process.ArgsString(1), process.ArgsString(2))
+	if err != nil {
+		exception.New(err.Error(), 404).Throw()
+	}
+	return nil
+}
+
+// processLeave websocket.Leave (:server, :connection, :room)
+func processLeave(process *process.Process) interface{} {
+	process.ValidateArgNums(3)
+	server := mustSelect(process.ArgsString(0))
+	err := server.hub.Leave(process.ArgsString(1), process.ArgsString(2))
+	if err != nil {
+		exception.New(err.Error(), 404).Throw()
+	}
+	return nil
+}
+
+// processRooms websocket.Rooms (:server) returns the rooms and the number of the connections
+func processRooms(process *process.Process) interface{} {
+	process.ValidateArgNums(1)
+	return mustSelect(process.ArgsString(0)).hub.Rooms()
+}
+
+// processConnections websocket.Connections (:server, [:room])
+func processConnections(process *process.Process) interface{} {
+	process.ValidateArgNums(1)
+	server := mustSelect(process.ArgsString(0))
+	room := ""
+	if process.NumOfArgs() > 1 {
+		room = process.ArgsString(1)
+	}
+	return server.hub.Connections(room)
+}
+
+// processClose websocket.Close (:server, :connection)
+func processClose(process *process.Process) interface{} {
+	process.ValidateArgNums(2)
+	server := mustSelect(process.ArgsString(0))
+	conn, has := server.hub.get(process.ArgsString(1))
+	if !has {
+		exception.New("connection %s not found", 404, process.ArgsString(1)).Throw()
+	}
+	conn.Close()
+	return nil
+}
+
+func mustSelect(id string) *Server {
+	server, err := Select(id)
+	if err != nil {
+		exception.New(err.Error(), 404).Throw()
+	}
+	return server
+}
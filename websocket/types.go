@@ -0,0 +1,74 @@
+package websocket
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/yaoapp/yao/openapi/oauth/types"
+)
+
+// Server the WebSocket server defined in websockets/*.ws.yao
+type Server struct {
+	ID          string   `json:"-"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Version     string   `json:"version,omitempty"`
+	Protocols   []string `json:"protocols,omitempty"`
+	Guard       string   `json:"guard,omitempty"`   // oauth: authenticate the connections with the OAuth guard
+	Origins     []string `json:"origins,omitempty"` // The allowed origins, "*" allows all origins, only the same origin is allowed if empty
+	Rooms       []string `json:"rooms,omitempty"`   // The rooms joined on connect
+	Buffer      Buffer   `json:"buffer,omitempty"`
+	Limit       Limit    `json:"limit,omitempty"`
+	Event       Event    `json:"event,omitempty"`
+	Process     string   `json:"process,omitempty"` // The message handler, the same as event.message
+	hub         *Hub
+}
+
+// Buffer the read and write buffer size of the connections
+type Buffer struct {
+	Read  int `json:"read,omitempty"`
+	Write int `json:"write,omitempty"`
+}
+
+// Limit the limits of the connections, the waits are in seconds
+type Limit struct {
+	MaxMessage     int64 `json:"max-message,omitempty"`
+	MaxConnections int   `json:"max-connections,omitempty"`
+	WriteWait      int   `json:"write-wait,omitempty"`
+	PongWait       int   `json:"pong-wait,omitempty"`
+}
+
+// Event the processes bound to the events of the connections
+//
+//	connect(conn)          returns false to reject the connection, or {"rooms": [...]} to join the rooms
+//	message(conn, message) the return value is sent to the connection
+//	close(conn)
+type Event struct {
+	Connect string `json:"connect,omitempty"`
+	Message string `json:"message,omitempty"`
+	Close   string `json:"close,omitempty"`
+}
+
+// Hub the connections and the rooms of a server
+type Hub struct {
+	conns map[string]*Conn
+	rooms map[string]map[string]*Conn
+	mutex sync.RWMutex
+}
+
+// Conn a client connection
+type Conn struct {
+	ID         string
+	Sid        string // The session of the connection, the handlers are executed with it
+	ServerID   string
+	Authorized *types.AuthorizedInfo
+	Query      map[string]string
+	Connected  time.Time
+	hub        *Hub
+	ws         *websocket.Conn
+	send       chan []byte
+	rooms      map[string]bool
+	done       chan struct{}
+	once       sync.Once
+}
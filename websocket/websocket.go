@@ -1,38 +1,136 @@
 package websocket
 
 import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/yaoapp/gou/application"
+	"github.com/yaoapp/kun/log"
 	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/share"
 )
 
-// Load 加载API
+// Servers the loaded WebSocket servers
+var Servers = map[string]*Server{}
+
+var lock sync.RWMutex
+
+// Load the WebSocket servers from the websockets directory
 func Load(cfg config.Config) error {
-	// exts := []string{"*.http.yao", "*.http.json", "*.http.jsonc"}
-	// return application.App.Walk("websockets", func(root, file string, isdir bool) error {
-	// 	_, err := websocket.Load(file, share.ID(root, file))
-	// 	return err
-	// }, exts...)
+	messages := []string{}
 
-	// var root = filepath.Join(cfg.Root, "websockets")
-	// return LoadFrom(root, "")
+	// Ignore if the websockets directory does not exist
+	exists, err := application.App.Exists("websockets")
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
 
-	return nil
+	exts := []string{"*.ws.yao", "*.ws.json", "*.ws.jsonc"}
+	err = application.App.Walk("websockets", func(root, file string, isdir bool) error {
+		if isdir {
+			return nil
+		}
+		_, err := LoadFile(file, share.ID(root, file))
+		if err != nil {
+			messages = append(messages, err.Error())
+		}
+		return nil
+	}, exts...)
+
+	if len(messages) > 0 {
+		return fmt.Errorf("%s", strings.Join(messages, ";\n"))
+	}
+
+	return err
+}
+
+// LoadFile load the WebSocket server from the file
+func LoadFile(file string, id string) (*Server, error) {
+	data, err := application.App.Read(file)
+	if err != nil {
+		return nil, err
+	}
+	return LoadSource(file, data, id)
+}
+
+// LoadSource load the WebSocket server from the source, the connections of the server with the same id are kept
+func LoadSource(file string, data []byte, id string) (*Server, error) {
+	server := &Server{}
+	err := application.Parse(file, data, server)
+	if err != nil {
+		return nil, fmt.Errorf("[WebSocket] %s %s", id, err.Error())
+	}
+
+	server.ID = id
+	err = server.validate()
+	if err != nil {
+		return nil, fmt.Errorf("[WebSocket] %s %s", id, err.Error())
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	if loaded, has := Servers[id]; has {
+		server.hub = loaded.hub
+	} else {
+		server.hub = newHub()
+	}
+	Servers[id] = server
+	log.Trace("[WebSocket] %s loaded", id)
+	return server, nil
 }
 
-// // LoadFrom 从特定目录加载
-// func LoadFrom(dir string, prefix string) error {
+// Select the WebSocket server by id
+func Select(id string) (*Server, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+	server, has := Servers[id]
+	if !has {
+		return nil, fmt.Errorf("websocket server %s not found", id)
+	}
+	return server, nil
+}
 
-// 	if share.DirNotExists(dir) {
-// 		return fmt.Errorf("%s does not exists", dir)
-// 	}
+// Unload the WebSocket server, the connections are closed
+func Unload(id string) {
+	lock.Lock()
+	server, has := Servers[id]
+	delete(Servers, id)
+	lock.Unlock()
 
-// 	err := share.Walk(dir, ".ws.json", func(root, filename string) {
-// 		name := prefix + share.SpecName(root, filename)
-// 		content := share.ReadFile(filename)
-// 		_, err := gou.LoadWebSocket(string(content), name)
-// 		if err != nil {
-// 			log.With(log.F{"root": root, "file": filename}).Error(err.Error())
-// 		}
-// 	})
+	if has {
+		server.hub.closeAll()
+	}
+}
 
-// 	return err
-// }
+// validate the server and set the defaults
+func (server *Server) validate() error {
+	if server.Event.Message == "" {
+		server.Event.Message = server.Process
+	}
+	server.Process = server.Event.Message
+
+	if server.Guard != "" && server.Guard != "oauth" {
+		return fmt.Errorf("guard %s is not supported, use oauth", server.Guard)
+	}
+
+	if server.Buffer.Read <= 0 {
+		server.Buffer.Read = 1024
+	}
+	if server.Buffer.Write <= 0 {
+		server.Buffer.Write = 1024
+	}
+	if server.Limit.MaxMessage <= 0 {
+		server.Limit.MaxMessage = 64 * 1024
+	}
+	if server.Limit.WriteWait <= 0 {
+		server.Limit.WriteWait = 10
+	}
+	if server.Limit.PongWait <= 0 {
+		server.Limit.PongWait = 60
+	}
+	return nil
+}
@@ -2,64 +2,221 @@ package websocket
 
 import (
 	"fmt"
-	"net"
-	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/yaoapp/gou/websocket"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaoapp/gou/process"
 	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/test"
 )
 
+const testSource = `{
+  "name": "Chat",
+  "protocols": ["yao-chat-01"],
+  "rooms": ["lobby"],
+  "limit": { "max-connections": 2 },
+  "event": {
+    "connect": "tests.websocket.connect",
+    "message": "tests.websocket.message",
+    "close": "tests.websocket.close"
+  }
+}`
+
+var closed = make(chan string, 10)
+
+func init() {
+	process.Register("tests.websocket.connect", func(p *process.Process) interface{} {
+		query := queryOf(p.ArgsMap(0))
+		if query["reject"] == "1" {
+			return false
+		}
+		return map[string]interface{}{"rooms": []interface{}{"room-" + query["room"]}}
+	})
+
+	process.Register("tests.websocket.message", func(p *process.Process) interface{} {
+		conn := p.ArgsMap(0)
+		message := p.ArgsMap(1)
+		switch message["type"] {
+		case "broadcast":
+			res, err := process.New("websocket.Broadcast", "chat", message["room"], message["text"]).Exec()
+			if err != nil {
+				return err.Error()
+			}
+			return res
+		case "rooms":
+			return conn["rooms"]
+		}
+		return message
+	})
+
+	process.Register("tests.websocket.close", func(p *process.Process) interface{} {
+		closed <- fmt.Sprintf("%v", p.ArgsMap(0)["id"])
+		return nil
+	})
+}
+
+func queryOf(conn map[string]interface{}) map[string]string {
+	query := map[string]string{}
+	switch value := conn["query"].(type) {
+	case map[string]string:
+		query = value
+	case map[string]interface{}:
+		for key, v := range value {
+			query[key] = fmt.Sprintf("%v", v)
+		}
+	}
+	return query
+}
+
 func TestLoad(t *testing.T) {
-	Load(config.Conf)
-	check(t)
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+	err := Load(config.Conf)
+	assert.NoError(t, err)
 }
 
-func TestWebSocketOpen(t *testing.T) {
-	// Load(config.Conf)
-	// script.Load(config.Conf)
-	// srv, url := serve(t)
-	// defer srv.Stop()
-
-	// ws := websocket.Se("message")
-	// err := ws.Open(url, "messageV2", "chatV3")
-	// if err != nil {
-	// 	t.Fatal(err)
-	// }
+func TestLoadSource(t *testing.T) {
+	server, err := LoadSource("chat.ws.yao", []byte(testSource), "chat")
+	require.NoError(t, err)
+	defer Unload("chat")
+
+	assert.Equal(t, "tests.websocket.message", server.Process)
+	assert.Equal(t, 1024, server.Buffer.Read)
+	assert.EqualValues(t, 64*1024, server.Limit.MaxMessage)
+
+	// The connections are kept when the server is reloaded
+	reloaded, err := LoadSource("chat.ws.yao", []byte(testSource), "chat")
+	require.NoError(t, err)
+	assert.Same(t, server.hub, reloaded.hub)
+
+	_, err = LoadSource("chat.ws.yao", []byte(`{"name": "Chat", "guard": "bearer-jwt"}`), "invalid")
+	assert.Error(t, err)
 }
 
-func serve(t *testing.T) (*websocket.Upgrader, string) {
+func TestRoomsAndBroadcast(t *testing.T) {
+	_, err := LoadSource("chat.ws.yao", []byte(testSource), "chat")
+	require.NoError(t, err)
+	defer Unload("chat")
+
+	url := serve(t)
+	alice := dial(t, url+"?room=a")
+	defer alice.Close()
+	bob := dial(t, url+"?room=b")
+	defer bob.Close()
+
+	// Echo the message
+	send(t, alice, `{"type":"echo","text":"hello"}`)
+	assert.JSONEq(t, `{"type":"echo","text":"hello"}`, read(t, alice))
+
+	// The default rooms and the rooms returned by the connect handler
+	send(t, alice, `{"type":"rooms"}`)
+	assert.JSONEq(t, `["lobby","room-a"]`, read(t, alice))
+
+	server, _ := Select("chat")
+	assert.Equal(t, map[string]int{"lobby": 2, "room-a": 1, "room-b": 1}, server.hub.Rooms())
+
+	// Broadcast to the room
+	send(t, alice, `{"type":"broadcast","room":"room-b","text":"hi bob"}`)
+	assert.Equal(t, "hi bob", read(t, bob))
+	assert.Equal(t, "1", read(t, alice))
+
+	// Broadcast to the lobby
+	send(t, bob, `{"type":"broadcast","room":"lobby","text":"hi all"}`)
+	assert.Equal(t, "hi all", read(t, alice))
+	assert.Equal(t, "hi all", read(t, bob))
+	assert.Equal(t, "2", read(t, bob))
 
-	ws, err := websocket.NewUpgrader("test")
-	if err != nil {
-		t.Fatalf("%s", err)
+	// The max connections
+	_, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	assert.Error(t, err)
+	if resp != nil {
+		assert.Equal(t, 503, resp.StatusCode)
 	}
 
-	gin.SetMode(gin.ReleaseMode)
-	router := gin.Default()
-	ws.SetHandler(func(message []byte, id int) ([]byte, error) { return message, nil })
-	ws.SetRouter(router)
+	// The close handler
+	bob.Close()
+	select {
+	case id := <-closed:
+		assert.NotEmpty(t, id)
+	case <-time.After(2 * time.Second):
+		t.Fatal("the close handler is not called")
+	}
+	assert.Eventually(t, func() bool { return server.hub.Count() == 1 }, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, map[string]int{"lobby": 1, "room-a": 1}, server.hub.Rooms())
+}
+
+func TestReject(t *testing.T) {
+	_, err := LoadSource("chat.ws.yao", []byte(testSource), "chat")
+	require.NoError(t, err)
+	defer Unload("chat")
+
+	conn := dial(t, serve(t)+"?reject=1")
+	defer conn.Close()
 
-	listener, err := net.Listen("tcp", "127.0.0.1:0")
-	if err != nil {
-		t.Fatal(err)
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, _, err = conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure), "%v", err)
+}
+
+func TestNotFound(t *testing.T) {
+	_, resp, err := websocket.DefaultDialer.Dial(serve(t)+"-missing", nil)
+	assert.Error(t, err)
+	if resp != nil {
+		assert.Equal(t, 404, resp.StatusCode)
 	}
+}
+
+func TestCheckOrigin(t *testing.T) {
+	request := httptest.NewRequest("GET", "http://chat.example.com/websocket/chat", nil)
+	server := &Server{}
 
-	go ws.Start()
-	go func() {
-		http.Serve(listener, router)
-	}()
-	time.Sleep(200 * time.Millisecond)
+	// Without the allowed origins only the same origin is allowed
+	assert.True(t, server.checkOrigin(request))
+	request.Header.Set("Origin", "http://chat.example.com")
+	assert.True(t, server.checkOrigin(request))
+	request.Header.Set("Origin", "https://evil.example.com")
+	assert.False(t, server.checkOrigin(request))
+
+	server.Origins = []string{"https://app.example.com"}
+	assert.False(t, server.checkOrigin(request))
+	request.Header.Set("Origin", "https://app.example.com")
+	assert.True(t, server.checkOrigin(request))
+
+	server.Origins = []string{"*"}
+	request.Header.Set("Origin", "https://evil.example.com")
+	assert.True(t, server.checkOrigin(request))
+}
+
+func serve(t *testing.T) string {
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	SetRoutes(router)
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+	return "ws" + strings.TrimPrefix(srv.URL, "http") + "/websocket/chat"
+}
+
+func dial(t *testing.T, url string) *websocket.Conn {
+	dialer := websocket.Dialer{Subprotocols: []string{"yao-chat-01"}}
+	conn, _, err := dialer.Dial(url, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "yao-chat-01", conn.Subprotocol())
+	return conn
+}
 
-	return ws, fmt.Sprintf("ws://127.0.0.1:%d/websocket/test", listener.Addr().(*net.TCPAddr).Port)
+func send(t *testing.T, conn *websocket.Conn, message string) {
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(message)))
 }
 
-func check(t *testing.T) {
-	// keys := []string{}
-	// for key := range gou.WebSockets {
-	// 	keys = append(keys, key)
-	// }
-	// assert.Equal(t, 1, len(keys))
+func read(t *testing.T, conn *websocket.Conn) string {
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, message, err := conn.ReadMessage()
+	require.NoError(t, err)
+	return string(message)
 }
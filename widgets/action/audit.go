@@ -0,0 +1,220 @@
+package action
+
+import (
+	"fmt"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/gou/process"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/audit"
+)
+
+// auditRows the max number of the records read before and after the bulk write actions
+const auditRows = 100
+
+// auditMethods the write actions of the widgets recorded in the audit log
+var auditMethods = map[string]bool{
+	"save":        true,
+	"create":      true,
+	"insert":      true,
+	"update":      true,
+	"updatewhere": true,
+	"updatein":    true,
+	"delete":      true,
+	"deletewhere": true,
+	"deletein":    true,
+}
+
+// auditable returns the widget type and the method of the write action, e.g. yao.table.Save -> table, save
+func (p *Process) auditable() (string, string, bool) {
+	parts := strings.Split(strings.ToLower(p.Name), ".")
+	if len(parts) != 3 || parts[0] != "yao" {
+		return "", "", false
+	}
+	return parts[1], parts[2], auditMethods[parts[2]]
+}
+
+// snapshot the records changed by the write action, keyed by the primary key
+type snapshot struct {
+	mod  *model.Model
+	rows map[string]interface{}
+}
+
+// snapshot reads the records the update and delete actions change from the bound model.
+// It returns nil when the action is bound to a process other than the model ones, only the submitted fields are recorded then.
+func (p *Process) snapshot(method string, proc *process.Process) *snapshot {
+	if len(proc.Args) < 2 {
+		return nil
+	}
+
+	mod := p.boundModel()
+	if mod == nil {
+		return nil
+	}
+
+	var param model.QueryParam
+	switch method {
+	case "update", "delete":
+		param = model.QueryParam{Wheres: []model.QueryWhere{{Column: mod.PrimaryKey, Value: proc.Args[1]}}}
+
+	case "updatewhere", "updatein", "deletewhere", "deletein":
+		var err error
+		param, err = queryParam(proc.Args[1])
+		if err != nil {
+			log.Warn("[audit] %s query %s", p.Name, err.Error())
+			return nil
+		}
+		param.Limit = auditRows
+
+	default:
+		return nil
+	}
+
+	snap := &snapshot{mod: mod}
+	rows, err := snap.read(param)
+	if err != nil {
+		log.Warn("[audit] %s read the records before the write %s", p.Name, err.Error())
+		return nil
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	snap.rows = rows
+	return snap
+}
+
+// after reads the records of the snapshot again, the records no longer match the query of the write
+func (snap *snapshot) after() map[string]interface{} {
+	ids := make([]interface{}, 0, len(snap.rows))
+	for id := range snap.rows {
+		ids = append(ids, id)
+	}
+	rows, err := snap.read(model.QueryParam{Wheres: []model.QueryWhere{{Column: snap.mod.PrimaryKey, OP: "in", Value: ids}}})
+	if err != nil {
+		log.Warn("[audit] %s read the records after the write %s", snap.mod.ID, err.Error())
+		return nil
+	}
+	return rows
+}
+
+// first returns the record of the single record actions
+func first(rows map[string]interface{}) interface{} {
+	for _, row := range rows {
+		return row
+	}
+	return nil
+}
+
+func (snap *snapshot) read(param model.QueryParam) (map[string]interface{}, error) {
+	rows, err := snap.mod.Get(param)
+	if err != nil {
+		return nil, err
+	}
+
+	res := map[string]interface{}{}
+	for _, row := range rows {
+		res[fmt.Sprintf("%v", row[snap.mod.PrimaryKey])] = map[string]interface{}(row)
+	}
+	return res, nil
+}
+
+// boundModel returns the model of the bound process, e.g. models.pet.Update -> pet
+func (p *Process) boundModel() *model.Model {
+	name := p.Process
+	if name == "" {
+		name = p.ProcessBind
+	}
+
+	if !strings.HasPrefix(strings.ToLower(name), "models.") {
+		return nil
+	}
+	name = name[len("models."):]
+	dot := strings.LastIndex(name, ".")
+	if dot < 1 {
+		return nil
+	}
+
+	mod, has := model.Models[name[:dot]]
+	if !has {
+		return nil
+	}
+	return mod
+}
+
+// queryParam the query of the where and in actions, the in actions have converted the IDs to a query
+func queryParam(value interface{}) (model.QueryParam, error) {
+	if param, ok := value.(model.QueryParam); ok {
+		return param, nil
+	}
+
+	param := model.QueryParam{}
+	raw, err := jsoniter.Marshal(value)
+	if err != nil {
+		return param, err
+	}
+	err = jsoniter.Unmarshal(raw, &param)
+	return param, err
+}
+
+// audit records the write action. The records changed by the update and delete actions bound to the models
+// are read before and after the write, the other actions record the submitted fields as the data after.
+func (p *Process) audit(widget string, method string, proc *process.Process, snap *snapshot, res interface{}, err error) {
+	entry := audit.New(fmt.Sprintf("%s.%s", widget, method), audit.CategoryData).WithProcess(proc).WithError(err)
+	args := proc.Args[1:]
+	switch method {
+	case "update":
+		if len(args) > 1 {
+			entry.WithResource(widget, fmt.Sprintf("%s:%v", proc.Args[0], args[0])).WithChange(nil, args[1])
+		}
+		if snap != nil && err == nil {
+			entry.WithChange(first(snap.rows), first(snap.after()))
+		}
+
+	case "delete":
+		if len(args) > 0 {
+			entry.WithResource(widget, fmt.Sprintf("%s:%v", proc.Args[0], args[0]))
+		}
+		if snap != nil && err == nil {
+			entry.WithChange(first(snap.rows), nil)
+		}
+		entry.Severity = audit.SeverityHigh
+
+	case "save", "create":
+		id := res
+		if err != nil {
+			id = nil
+		}
+		entry.WithResource(widget, fmt.Sprintf("%s:%v", proc.Args[0], id))
+		if len(args) > 0 {
+			entry.WithChange(nil, args[0])
+		}
+
+	case "updatewhere", "updatein":
+		entry.WithResource(widget, proc.Args[0])
+		if len(args) > 1 {
+			entry.WithChange(nil, args[1]).WithDetails(map[string]interface{}{"query": args[0]})
+		}
+		if snap != nil && err == nil {
+			entry.WithChange(snap.rows, snap.after())
+		}
+
+	case "deletewhere", "deletein":
+		entry.WithResource(widget, proc.Args[0]).WithSeverity(audit.SeverityHigh)
+		if len(args) > 0 {
+			entry.WithDetails(map[string]interface{}{"query": args[0]})
+		}
+		if snap != nil && err == nil {
+			entry.WithChange(snap.rows, nil)
+		}
+
+	default:
+		entry.WithResource(widget, proc.Args[0])
+	}
+
+	if err == nil && (method == "insert" || strings.HasSuffix(method, "where") || strings.HasSuffix(method, "in")) {
+		entry.WithDetails(map[string]interface{}{"affected": res})
+	}
+	audit.Log(entry)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/yaoapp/kun/any"
 	"github.com/yaoapp/kun/exception"
 	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/audit"
 	"github.com/yaoapp/yao/widgets/hook"
 )
 
@@ -126,7 +127,17 @@ func (p *Process) Exec(process *process.Process) (interface{}, error) {
 	if p.Handler == nil {
 		return nil, fmt.Errorf("%s handler does not set", p.Name)
 	}
-	return p.Handler(p, process)
+
+	// Record the write actions in the audit log
+	widget, method, auditable := p.auditable()
+	if !auditable || !audit.Enabled() || len(process.Args) == 0 {
+		return p.Handler(p, process)
+	}
+
+	snap := p.snapshot(method, process)
+	res, err := p.Handler(p, process)
+	p.audit(widget, method, process, snap, res, err)
+	return res, err
 }
 
 // MustExec exec the process
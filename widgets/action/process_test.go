@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/gou/model"
+	"github.com/yaoapp/gou/process"
 )
 
 func TestBind(t *testing.T) {
@@ -187,3 +189,22 @@ func testProcessDefaults() map[string]interface{} {
 		},
 	}
 }
+
+func TestAuditSnapshot(t *testing.T) {
+	param, err := queryParam(map[string]interface{}{"wheres": []interface{}{map[string]interface{}{"column": "status", "value": "checked"}}})
+	assert.Nil(t, err)
+	assert.Equal(t, "status", param.Wheres[0].Column)
+
+	in := model.QueryParam{Wheres: []model.QueryWhere{{Column: "id", OP: "in", Value: []string{"1", "2"}}}}
+	param, err = queryParam(in)
+	assert.Nil(t, err)
+	assert.Equal(t, in, param)
+
+	// The actions bound to the scripts are not read before the write
+	script := &Process{Name: "yao.table.Update", ProcessBind: "scripts.pet.Update"}
+	assert.Nil(t, script.boundModel())
+	assert.Nil(t, script.snapshot("update", &process.Process{Args: []interface{}{"pet", 1, map[string]interface{}{}}}))
+
+	missing := &Process{Name: "yao.table.Update", ProcessBind: "models.not.exists.Update"}
+	assert.Nil(t, missing.boundModel())
+}
@@ -79,6 +79,8 @@ func (table *DSL) getAction(path string) (*action.Process, error) {
 		return table.Action.DeleteIn, nil
 	case "/api/__yao/table/:id/delete/where":
 		return table.Action.DeleteWhere, nil
+	case "/api/__yao/table/:id/export", "/api/__yao/table/:id/export/:export":
+		return table.Action.Search, nil
 	}
 
 	return nil, fmt.Errorf("the table widget %s %s action does not exist", table.ID, path)
@@ -304,6 +306,30 @@ func exportAPI() error {
 	}
 	http.Paths = append(http.Paths, path)
 
+	//  POST  /api/__yao/table/:id/export  					-> Default process: yao.table.ExportStart $param.id :query-param :payload
+	path = api.Path{
+		Label:       "Export",
+		Description: "Export",
+		Path:        "/:id/export",
+		Method:      "POST",
+		Process:     "yao.table.ExportStart",
+		In:          []interface{}{"$param.id", ":query-param", ":payload"},
+		Out:         api.Out{Status: 200, Type: "application/json"},
+	}
+	http.Paths = append(http.Paths, path)
+
+	//  GET  /api/__yao/table/:id/export/:export  				-> Default process: yao.table.ExportStatus $param.id $param.export
+	path = api.Path{
+		Label:       "Export Status",
+		Description: "Export Status",
+		Path:        "/:id/export/:export",
+		Method:      "GET",
+		Process:     "yao.table.ExportStatus",
+		In:          []interface{}{"$param.id", "$param.export"},
+		Out:         api.Out{Status: 200, Type: "application/json"},
+	}
+	http.Paths = append(http.Paths, path)
+
 	// api source
 	source, err := jsoniter.Marshal(http)
 	if err != nil {
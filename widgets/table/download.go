@@ -0,0 +1,110 @@
+package table
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/yao/attachment"
+	"github.com/yaoapp/yao/helper"
+)
+
+// downloadRoot the root of the export download endpoint, the download links are built from it
+var downloadRoot = "/api"
+
+func init() {
+	// The download tokens are not sessions, the JWT guards reject them
+	helper.JwtScoped(exportSubject)
+}
+
+// SetRoutes register the export download endpoint GET <root>/__yao/table/:id/export/:export/download?token=
+// The file is streamed from the attachment manager, the signed token of the download link is required.
+func SetRoutes(router *gin.Engine, root string) {
+	downloadRoot = root
+	router.GET(root+"/__yao/table/:id/export/:export/download", exportDownload)
+}
+
+// exportDownload stream the export file to the response
+func exportDownload(c *gin.Context) {
+	table := c.Param("id")
+	id := c.Param("export")
+
+	state, code, err := exportOfToken(table, id, c.Query("token"))
+	if err != nil {
+		c.JSON(code, gin.H{"code": code, "message": err.Error()})
+		return
+	}
+
+	manager, has := attachment.Managers[state.Option.Uploader]
+	if !has {
+		message := fmt.Sprintf("[table] %s the uploader %s does not exist", table, state.Option.Uploader)
+		c.JSON(http.StatusInternalServerError, gin.H{"code": http.StatusInternalServerError, "message": message})
+		return
+	}
+
+	file, err := manager.Download(c.Request.Context(), state.FileID)
+	if err != nil {
+		message := fmt.Sprintf("[table] %s export %s %s", table, id, err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"code": http.StatusInternalServerError, "message": message})
+		return
+	}
+	defer file.Reader.Close()
+
+	c.Header("Content-Type", exportContentType(state.Format))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", state.Filename))
+	c.Status(http.StatusOK)
+	if _, err := io.Copy(c.Writer, file.Reader); err != nil {
+		log.Error("[table] %s export %s download error %s", table, id, err.Error())
+	}
+}
+
+// exportOfToken read the completed export of the signed download token, returns the status code on error
+func exportOfToken(table string, id string, token string) (*ExportState, int, error) {
+	token = strings.TrimSpace(strings.TrimPrefix(token, "Bearer "))
+	if token == "" {
+		return nil, http.StatusForbidden, fmt.Errorf("[table] %s export %s No permission", table, id)
+	}
+
+	claims, err := exportTokenClaims(token)
+	if err != nil || claims.ID != id || claims.Subject != exportSubject || claims.Table != table {
+		return nil, http.StatusForbidden, fmt.Errorf("[table] %s export %s Invalid token", table, id)
+	}
+
+	state, err := ExportStatusOf(id)
+	if err != nil || state.Table != table || state.Status != ExportCompleted {
+		return nil, http.StatusNotFound, fmt.Errorf("[table] %s the export %s does not exist", table, id)
+	}
+
+	// The token is scoped to the export file
+	if claims.FileID != state.FileID {
+		return nil, http.StatusForbidden, fmt.Errorf("[table] %s export %s Invalid token", table, id)
+	}
+	return state, http.StatusOK, nil
+}
+
+// exportTokenClaims validate the signed download token with the export key
+func exportTokenClaims(token string) (*exportClaims, error) {
+	if len(token) > helper.MaxTokenLength {
+		return nil, fmt.Errorf("token too long")
+	}
+
+	claims := &exportClaims{}
+	parsed, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", t.Header["alg"])
+		}
+		return exportSecret(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !parsed.Valid || !claims.VerifyAudience(exportAudience, true) {
+		return nil, fmt.Errorf("invalid token")
+	}
+	return claims, nil
+}
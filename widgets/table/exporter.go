@@ -0,0 +1,503 @@
+package table
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
+	gouProcess "github.com/yaoapp/gou/process"
+	"github.com/yaoapp/gou/types"
+	"github.com/yaoapp/kun/any"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/kun/maps"
+	"github.com/yaoapp/yao/attachment"
+	"github.com/yaoapp/yao/config"
+	"github.com/yaoapp/yao/job"
+	"github.com/yaoapp/yao/messenger"
+	messengerTypes "github.com/yaoapp/yao/messenger/types"
+)
+
+// Export status
+const (
+	ExportRunning   = "running"
+	ExportCompleted = "completed"
+	ExportFailed    = "failed"
+)
+
+// exportUploader the default attachment manager of the export files
+const exportUploader = "__yao.attachment"
+
+// exportChunkSize the export file is uploaded to the attachment manager in chunks
+const exportChunkSize = 2 * 1024 * 1024
+
+// exportSubject the subject of the signed download token
+const exportSubject = "Table Export"
+
+// exportAudience the audience of the signed download token, the signing key is derived from it
+const exportAudience = "yao.table.export.download"
+
+// exportClaims the claims of the signed download token, scoped to one export file
+type exportClaims struct {
+	Table  string `json:"table"`
+	FileID string `json:"file_id"`
+	jwt.RegisteredClaims
+}
+
+// ExportOption the background export option
+type ExportOption struct {
+	Format   string   `json:"format,omitempty"`   // csv, jsonl, xlsx. default is xlsx
+	Columns  []string `json:"columns,omitempty"`  // the layout column names or the field ids, default is all columns of the layout
+	PageSize int      `json:"pagesize,omitempty"` // the rows of each search page, default is 500
+	Uploader string   `json:"uploader,omitempty"` // the attachment manager, default is __yao.attachment
+	Expires  int      `json:"expires,omitempty"`  // the download link expiration (seconds), default is 86400
+	Host     string   `json:"host,omitempty"`     // the host prefix of the download link, e.g. https://yao.run
+	Notify   string   `json:"notify,omitempty"`   // the process called with the export state when done
+	Email    []string `json:"email,omitempty"`    // send the download link to these addresses via the messenger when done
+}
+
+// ExportState the background export state
+type ExportState struct {
+	ID        string       `json:"id"`
+	Table     string       `json:"table"`
+	JobID     string       `json:"job_id"`
+	Format    string       `json:"format"`
+	Status    string       `json:"status"`
+	Progress  int          `json:"progress"`
+	Rows      int          `json:"rows"`  // the total rows of the query
+	Total     int          `json:"total"` // the exported rows
+	FileID    string       `json:"file_id,omitempty"`
+	Filename  string       `json:"filename,omitempty"`
+	Bytes     int          `json:"bytes,omitempty"`
+	URL       string       `json:"url,omitempty"`
+	ExpiresAt int64        `json:"expires_at,omitempty"`
+	Message   string       `json:"message,omitempty"`
+	Option    ExportOption `json:"option"`
+	Sid       string       `json:"-"` // the owner session, not returned to the clients
+	UserID    string       `json:"-"` // the owner user, not returned to the clients
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+// exportRecord the saved export state, the owner of the export is saved with the state
+type exportRecord struct {
+	*ExportState
+	Sid    string `json:"sid,omitempty"`
+	UserID string `json:"user_id,omitempty"`
+}
+
+// StartExport export the query result in background, returns the export state, the progress could be queried by ExportStatusOf
+// The export belongs to the user if given, or to the session.
+func (dsl *DSL) StartExport(params types.QueryParam, option ExportOption, sid string, userID string, global map[string]interface{}) (*ExportState, error) {
+
+	if option.Format == "" {
+		option.Format = ExportXLSX
+	}
+
+	if option.PageSize <= 0 {
+		option.PageSize = 500
+	}
+
+	if option.Uploader == "" {
+		option.Uploader = exportUploader
+	}
+
+	if option.Expires <= 0 {
+		option.Expires = 86400
+	}
+
+	if _, has := attachment.Managers[option.Uploader]; !has {
+		return nil, fmt.Errorf("the uploader %s does not exist", option.Uploader)
+	}
+
+	// Validate the format and columns before starting the job
+	writer, err := newExportWriter(option.Format, io.Discard, "")
+	if err != nil {
+		return nil, err
+	}
+	writer.Close()
+
+	columns, err := dsl.exportColumns(option.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	state := &ExportState{
+		ID:        uuid.NewString(),
+		Table:     dsl.ID,
+		Format:    option.Format,
+		Status:    ExportRunning,
+		Option:    option,
+		Sid:       sid,
+		UserID:    userID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	name := dsl.Name
+	if name == "" {
+		name = dsl.ID
+	}
+
+	j, err := job.OnceAndSave(job.GOROUTINE, map[string]interface{}{
+		"name":          fmt.Sprintf("Export %s", name),
+		"description":   fmt.Sprintf("Exporting %s to %s", dsl.ID, option.Format),
+		"category_name": "Export",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create and save job: %w", err)
+	}
+
+	state.JobID = j.JobID
+	err = state.save()
+	if err != nil {
+		return nil, err
+	}
+
+	err = j.AddFunc(&job.ExecutionOptions{Priority: 1}, "table.export", func(execCtx *job.ExecutionContext) error {
+		return dsl.runExport(execCtx, state, params, columns, global)
+	}, map[string]interface{}{
+		"id":     state.ID,
+		"table":  dsl.ID,
+		"format": option.Format,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add job execution: %w", err)
+	}
+
+	err = j.Push()
+	if err != nil {
+		return nil, fmt.Errorf("failed to push job: %w", err)
+	}
+
+	snapshot := *state
+	return &snapshot, nil
+}
+
+// ExportStatusOf read the export state
+func ExportStatusOf(id string) (*ExportState, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("the export %s does not exist", id)
+	}
+
+	data, err := os.ReadFile(exportStateFile(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("the export %s does not exist", id)
+		}
+		return nil, err
+	}
+
+	state := ExportState{}
+	record := exportRecord{ExportState: &state}
+	err = jsoniter.Unmarshal(data, &record)
+	if err != nil {
+		return nil, err
+	}
+	state.Sid = record.Sid
+	state.UserID = record.UserID
+	return &state, nil
+}
+
+// OwnedBy check if the export belongs to the user, or to the session when the export has no user
+func (state *ExportState) OwnedBy(sid string, userID string) bool {
+	if state.UserID != "" {
+		return userID == state.UserID
+	}
+	return sid == state.Sid
+}
+
+// runExport the export job, pages through the search action and writes the rows to a temporary file
+func (dsl *DSL) runExport(execCtx *job.ExecutionContext, state *ExportState, params types.QueryParam, columns []exportColumn, global map[string]interface{}) (err error) {
+
+	defer func() {
+		// The search action throws exceptions
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+
+		if err != nil {
+			state.Status = ExportFailed
+			state.Message = err.Error()
+		} else {
+			state.Status = ExportCompleted
+			state.Progress = 100
+		}
+
+		if saveErr := state.save(); saveErr != nil {
+			log.Error("[table] export %s save state error %s", state.ID, saveErr.Error())
+		}
+		dsl.notifyExport(state)
+	}()
+
+	tmp, err := os.CreateTemp("", fmt.Sprintf("yao-export-*.%s", state.Format))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	writer, err := newExportWriter(state.Format, tmp, dsl.Name)
+	if err != nil {
+		return err
+	}
+
+	err = writer.Header(columns)
+	if err != nil {
+		writer.Close()
+		return err
+	}
+
+	page := 1
+	for page > 0 {
+		if execCtx.Ctx != nil && execCtx.Ctx.Err() != nil {
+			writer.Close()
+			return execCtx.Ctx.Err()
+		}
+
+		res, err := dsl.exportSearch(state, params, page, global)
+		if err != nil {
+			writer.Close()
+			return err
+		}
+
+		if state.Rows == 0 {
+			state.Rows = any.Of(res["total"]).CInt()
+		}
+
+		for _, row := range exportRows(res["data"]) {
+			err = writer.Write(exportValues(columns, row))
+			if err != nil {
+				writer.Close()
+				return err
+			}
+			state.Total++
+		}
+
+		state.Progress = state.progress()
+		if err := state.save(); err != nil {
+			log.Error("[table] export %s save state error %s", state.ID, err.Error())
+		}
+		execCtx.Execution.SetProgress(state.Progress, fmt.Sprintf("%d rows exported", state.Total))
+
+		page = -1
+		if next, has := res["next"]; has {
+			page = any.Of(next).CInt()
+		}
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return err
+	}
+
+	return dsl.uploadExport(execCtx.Ctx, state, tmp)
+}
+
+// exportSearch search a page through the search action, the view computes are applied by the action
+func (dsl *DSL) exportSearch(state *ExportState, params types.QueryParam, page int, global map[string]interface{}) (maps.MapStrAny, error) {
+	process := gouProcess.New("yao.table.search", dsl.ID, params, page, state.Option.PageSize).
+		WithSID(state.Sid).
+		WithGlobal(global)
+
+	data, err := dsl.Action.Search.Exec(process)
+	if err != nil {
+		return nil, err
+	}
+
+	switch res := data.(type) {
+	case maps.MapStrAny:
+		return res, nil
+	case map[string]interface{}:
+		return maps.MapStrAny(res), nil
+	}
+	return nil, fmt.Errorf("the search action response data error %#v", data)
+}
+
+// uploadExport upload the export file to the attachment manager in chunks, and sign the download link
+func (dsl *DSL) uploadExport(ctx context.Context, state *ExportState, file *os.File) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	manager, has := attachment.Managers[state.Option.Uploader]
+	if !has {
+		return fmt.Errorf("the uploader %s does not exist", state.Option.Uploader)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	filename := fmt.Sprintf("%s-%s.%s", dsl.ID, time.Now().Format("20060102150405"), state.Format)
+	option := attachment.UploadOption{
+		OriginalFilename: filename,
+		Groups:           []string{"exports", dsl.ID},
+	}
+
+	total := info.Size()
+	buffer := make([]byte, exportChunkSize)
+	var uploaded *attachment.File
+	for start := int64(0); uploaded == nil || start < total; {
+		n, err := file.ReadAt(buffer, start)
+		if err != nil && err != io.EOF {
+			return err
+		}
+
+		header := &attachment.FileHeader{FileHeader: &multipart.FileHeader{
+			Filename: filename,
+			Header:   textproto.MIMEHeader{},
+			Size:     int64(n),
+		}}
+		header.Header.Set("Content-Type", exportContentType(state.Format))
+
+		// Small files are uploaded at once
+		if total > exportChunkSize {
+			header.Header.Set("Content-Uid", state.ID)
+			header.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, start+int64(n)-1, total))
+		}
+
+		uploaded, err = manager.Upload(ctx, header, bytes.NewReader(buffer[:n]), option)
+		if err != nil {
+			return err
+		}
+		start += int64(n)
+	}
+
+	state.FileID = uploaded.ID
+	state.Filename = filename
+	state.Bytes = int(total)
+	state.URL, state.ExpiresAt, err = dsl.exportLink(state)
+	return err
+}
+
+// exportLink the signed download link of the export file
+// The token is scoped to the export file, it carries no session and is signed with the export key.
+func (dsl *DSL) exportLink(state *ExportState) (string, int64, error) {
+	now := time.Now()
+	expiresAt := now.Add(time.Duration(state.Option.Expires) * time.Second)
+	claims := &exportClaims{
+		Table:  dsl.ID,
+		FileID: state.FileID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        state.ID,
+			Subject:   exportSubject,
+			Audience:  []string{exportAudience},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			NotBefore: jwt.NewNumericDate(now),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(exportSecret())
+	if err != nil {
+		return "", 0, err
+	}
+
+	link := fmt.Sprintf("%s%s/__yao/table/%s/export/%s/download?token=%s",
+		state.Option.Host, downloadRoot, dsl.ID, state.ID, url.QueryEscape(token))
+	return link, expiresAt.Unix(), nil
+}
+
+// exportSecret the signing key of the download tokens, it is derived from the JWT secret,
+// so the download tokens and the session tokens can not be used in place of each other
+func exportSecret() []byte {
+	mac := hmac.New(sha256.New, []byte(config.Conf.JWTSecret))
+	mac.Write([]byte(exportAudience))
+	return mac.Sum(nil)
+}
+
+// notifyExport call the notify process and send the download link via the messenger
+func (dsl *DSL) notifyExport(state *ExportState) {
+	if state.Option.Notify != "" {
+		data := map[string]interface{}{}
+		raw, _ := jsoniter.Marshal(state)
+		jsoniter.Unmarshal(raw, &data)
+		_, err := gouProcess.New(state.Option.Notify, data).WithSID(state.Sid).Exec()
+		if err != nil {
+			log.Error("[table] export %s notify %s error %s", state.ID, state.Option.Notify, err.Error())
+		}
+	}
+
+	if len(state.Option.Email) == 0 {
+		return
+	}
+
+	if messenger.Instance == nil {
+		log.Warn("[table] export %s the messenger is not configured, the email notification is skipped", state.ID)
+		return
+	}
+
+	subject := fmt.Sprintf("Export %s completed", dsl.Name)
+	body := fmt.Sprintf("%d rows exported, download: %s\nThe link expires at %s.",
+		state.Total, state.URL, time.Unix(state.ExpiresAt, 0).Format("2006-01-02 15:04:05"))
+	if state.Status != ExportCompleted {
+		subject = fmt.Sprintf("Export %s failed", dsl.Name)
+		body = state.Message
+	}
+
+	err := messenger.Instance.Send(context.Background(), "default", &messengerTypes.Message{
+		Type:    messengerTypes.MessageTypeEmail,
+		To:      state.Option.Email,
+		Subject: subject,
+		Body:    body,
+	})
+	if err != nil {
+		log.Error("[table] export %s send email error %s", state.ID, err.Error())
+	}
+}
+
+// progress the export progress, 0 when the total rows is unknown
+func (state *ExportState) progress() int {
+	if state.Rows <= 0 {
+		return 0
+	}
+	progress := state.Total * 100 / state.Rows
+	if progress > 99 {
+		return 99
+	}
+	return progress
+}
+
+// save the export state
+func (state *ExportState) save() error {
+	state.UpdatedAt = time.Now()
+	data, err := jsoniter.Marshal(exportRecord{ExportState: state, Sid: state.Sid, UserID: state.UserID})
+	if err != nil {
+		return err
+	}
+
+	file := exportStateFile(state.ID)
+	err = os.MkdirAll(filepath.Dir(file), os.ModePerm)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, data, 0644)
+}
+
+func exportStateFile(id string) string {
+	return filepath.Join(config.Conf.DataRoot, "table", "exports", fmt.Sprintf("%s.json", id))
+}
+
+func exportContentType(format string) string {
+	switch format {
+	case ExportCSV:
+		return "text/csv"
+	case ExportJSONL:
+		return "application/json"
+	}
+	return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	jsoniter "github.com/json-iterator/go"
 	"github.com/yaoapp/gou/fs"
 	"github.com/yaoapp/gou/model"
 	gouProcess "github.com/yaoapp/gou/process"
@@ -44,6 +45,8 @@ func exportProcess() {
 	gouProcess.Register("yao.table.deletewhere", processDeleteWhere)
 	gouProcess.Register("yao.table.deletein", processDeleteIn)
 	gouProcess.Register("yao.table.export", processExport)
+	gouProcess.Register("yao.table.exportstart", processExportStart)
+	gouProcess.Register("yao.table.exportstatus", processExportStatus)
 
 	// DSL Operations
 	gouProcess.Register("yao.table.exists", processExists)
@@ -323,6 +326,51 @@ func processExport(process *gouProcess.Process) interface{} {
 	return filename
 }
 
+// processExportStart yao.table.ExportStart (:table, :queryParam, :option)
+// Export the query result in background, option: {"format": "csv|jsonl|xlsx", "columns": [], "pagesize": 500, "notify": "<process>", "email": []}
+func processExportStart(process *gouProcess.Process) interface{} {
+	process.ValidateArgNums(1)
+	tab := MustGet(process) // 0
+	params := process.ArgsQueryParams(1, types.QueryParam{})
+
+	option := ExportOption{}
+	if process.NumOfArgs() > 2 && process.Args[2] != nil {
+		raw, err := jsoniter.Marshal(process.Args[2])
+		if err == nil {
+			err = jsoniter.Unmarshal(raw, &option)
+		}
+		if err != nil {
+			exception.New("[table] %s export option error %s", 400, tab.ID, err.Error()).Throw()
+		}
+	}
+
+	state, err := tab.StartExport(params, option, process.Sid, exportUserID(process), process.Global)
+	if err != nil {
+		exception.New("[table] %s export error %s", 400, tab.ID, err.Error()).Throw()
+	}
+	return state
+}
+
+// processExportStatus yao.table.ExportStatus (:table, :id)
+// Only the user or the session started the export could read the state
+func processExportStatus(process *gouProcess.Process) interface{} {
+	process.ValidateArgNums(2)
+	tab := MustGet(process) // 0
+	state, err := ExportStatusOf(process.ArgsString(1))
+	if err != nil || state.Table != tab.ID || !state.OwnedBy(process.Sid, exportUserID(process)) {
+		exception.New("[table] %s the export %s does not exist", 404, tab.ID, process.ArgsString(1)).Throw()
+	}
+	return state
+}
+
+// exportUserID the user id of the authorized process, empty when the process is not authorized
+func exportUserID(process *gouProcess.Process) string {
+	if auth := process.GetAuthorized(); auth != nil {
+		return auth.UserID
+	}
+	return ""
+}
+
 // processLoad yao.table.Load table_name file <source>
 func processLoad(process *gouProcess.Process) interface{} {
 	process.ValidateArgNums(1)
@@ -3,10 +3,14 @@ package table
 import (
 	"fmt"
 	"io"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/yaoapp/gou/fs"
 	"github.com/yaoapp/gou/model"
@@ -14,6 +18,7 @@ import (
 	"github.com/yaoapp/gou/session"
 	"github.com/yaoapp/gou/types"
 	"github.com/yaoapp/kun/any"
+	"github.com/yaoapp/yao/attachment"
 	"github.com/yaoapp/yao/config"
 	"github.com/yaoapp/yao/helper"
 	"github.com/yaoapp/yao/test"
@@ -582,6 +587,73 @@ func TestProcessExport(t *testing.T) {
 	assert.Greater(t, size, 1000)
 }
 
+func TestProcessExportStart(t *testing.T) {
+	test.Prepare(t, config.Conf)
+	defer test.Clean()
+
+	prepare(t)
+	clear(t)
+	testData(t)
+
+	if _, has := attachment.Managers["__yao.attachment"]; !has {
+		_, err := attachment.RegisterDefault("__yao.attachment")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	args := []interface{}{"pet", nil, map[string]interface{}{"format": "csv", "pagesize": 2}}
+	state, ok := process.New("yao.table.ExportStart", args...).Run().(*ExportState)
+	if !ok {
+		t.Fatal("the export state is not returned")
+	}
+	assert.Equal(t, ExportRunning, state.Status)
+	assert.NotEmpty(t, state.JobID)
+
+	for i := 0; i < 100 && state.Status == ExportRunning; i++ {
+		time.Sleep(100 * time.Millisecond)
+		state = process.New("yao.table.ExportStatus", "pet", state.ID).Run().(*ExportState)
+	}
+	assert.Equal(t, ExportCompleted, state.Status, state.Message)
+	assert.Equal(t, 100, state.Progress)
+	assert.Greater(t, state.Total, 0)
+	assert.NotEmpty(t, state.URL)
+
+	// Only the owner could read the export state
+	assert.Panics(t, func() {
+		process.New("yao.table.ExportStatus", "pet", state.ID).WithSID(uuid.NewString()).Run()
+	})
+
+	// Download with the signed link
+	link, err := url.Parse(state.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	router := gin.New()
+	SetRoutes(router, "/api")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", link.RequestURI(), nil))
+	assert.Equal(t, 200, rec.Code)
+	assert.Equal(t, "text/csv", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Header().Get("Content-Disposition"), state.Filename)
+	assert.Equal(t, state.Bytes, rec.Body.Len())
+
+	// The download token is not a session token
+	assert.Panics(t, func() { helper.JwtValidate(link.Query().Get("token")) })
+
+	// The token is bound to the export
+	rec = httptest.NewRecorder()
+	path := fmt.Sprintf("/api/__yao/table/pet/export/%s/download?token=%s", uuid.NewString(), url.QueryEscape(link.Query().Get("token")))
+	router.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+	assert.Equal(t, 403, rec.Code)
+
+	// Unsupported format
+	assert.Panics(t, func() {
+		process.New("yao.table.ExportStart", "pet", nil, map[string]interface{}{"format": "pdf"}).Run()
+	})
+}
+
 func TestProcessLoad(t *testing.T) {
 	test.Prepare(t, config.Conf)
 	defer test.Clean()
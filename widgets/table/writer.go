@@ -0,0 +1,292 @@
+package table
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/xuri/excelize/v2"
+	"github.com/yaoapp/kun/any"
+	"github.com/yaoapp/kun/maps"
+)
+
+// Export formats
+const (
+	ExportCSV   = "csv"
+	ExportJSONL = "jsonl"
+	ExportXLSX  = "xlsx"
+)
+
+// exportColumn the exported column, follows the table layout
+type exportColumn struct {
+	Name    string            // the layout column name (header)
+	Field   string            // the bind of the field view
+	Options map[string]string // value => label of the view props options
+}
+
+// exportWriter write the rows to the export file in streaming
+type exportWriter interface {
+	Header(columns []exportColumn) error
+	Write(values []interface{}) error
+	Close() error
+}
+
+// newExportWriter create a streaming writer of the given format
+func newExportWriter(format string, w io.Writer, sheet string) (exportWriter, error) {
+	switch format {
+	case ExportCSV:
+		return &csvWriter{writer: csv.NewWriter(w)}, nil
+
+	case ExportJSONL:
+		return &jsonlWriter{writer: bufio.NewWriter(w)}, nil
+
+	case ExportXLSX:
+		file := excelize.NewFile()
+		name := file.GetSheetName(file.GetActiveSheetIndex())
+		if sheet != "" && sheet != name {
+			if err := file.SetSheetName(name, sheet); err == nil {
+				name = sheet
+			}
+		}
+		stream, err := file.NewStreamWriter(name)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		return &xlsxWriter{file: file, stream: stream, output: w, line: 1}, nil
+	}
+	return nil, fmt.Errorf("the export format %s does not support, should be one of csv, jsonl, xlsx", format)
+}
+
+// exportColumns the exported columns, in the order of the table layout
+// names could be the layout column names or the field ids, empty means all columns
+func (dsl *DSL) exportColumns(names []string) ([]exportColumn, error) {
+	setting, err := dsl.exportSetting()
+	if err != nil {
+		return nil, err
+	}
+
+	selected := map[string]bool{}
+	for _, name := range names {
+		if dsl.Mapping != nil && dsl.Mapping.Columns != nil {
+			if mapped, has := dsl.Mapping.Columns[name]; has {
+				if _, isField := dsl.Fields.Table[mapped]; isField {
+					name = mapped
+				}
+			}
+		}
+		selected[name] = true
+	}
+
+	columns := []exportColumn{}
+	for _, item := range setting {
+		if len(selected) > 0 && !selected[item["name"]] {
+			continue
+		}
+
+		column := exportColumn{Name: item["name"], Field: item["field"]}
+		if field, has := dsl.Fields.Table[item["name"]]; has && field.View != nil {
+			column.Options = viewOptions(field.View.Props)
+		}
+		columns = append(columns, column)
+	}
+
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("the table does not support export")
+	}
+	return columns, nil
+}
+
+// viewOptions the value => label mapping of the view props options (Tag, Select...)
+func viewOptions(props map[string]interface{}) map[string]string {
+	if props == nil {
+		return nil
+	}
+
+	items, ok := props["options"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	options := map[string]string{}
+	for _, item := range items {
+		option, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if label, has := option["label"]; has {
+			options[fmt.Sprintf("%v", option["value"])] = fmt.Sprintf("%v", label)
+		}
+	}
+
+	if len(options) == 0 {
+		return nil
+	}
+	return options
+}
+
+// exportRows flatten the search result rows
+func exportRows(data interface{}) []maps.MapStr {
+	rows := []maps.MapStr{}
+	switch values := data.(type) {
+	case []maps.MapStrAny:
+		for _, row := range values {
+			rows = append(rows, row.Dot())
+		}
+	case []map[string]interface{}:
+		for _, row := range values {
+			rows = append(rows, maps.Of(row).Dot())
+		}
+	case []interface{}:
+		for _, row := range values {
+			rows = append(rows, any.Of(row).MapStr().Dot())
+		}
+	}
+	return rows
+}
+
+// exportValues the formatted values of the row
+func exportValues(columns []exportColumn, row maps.MapStr) []interface{} {
+	values := make([]interface{}, len(columns))
+	for i, column := range columns {
+		values[i] = column.format(row.Get(column.Field))
+	}
+	return values
+}
+
+// format the value for display, the options value is replaced by the label
+func (column exportColumn) format(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	if column.Options != nil {
+		if label, has := column.Options[fmt.Sprintf("%v", value)]; has {
+			return label
+		}
+	}
+
+	if t, ok := value.(time.Time); ok {
+		return t.Format("2006-01-02 15:04:05")
+	}
+	return value
+}
+
+// csvWriter the CSV writer
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func (w *csvWriter) Header(columns []exportColumn) error {
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.Name
+	}
+	return w.writer.Write(header)
+}
+
+func (w *csvWriter) Write(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case nil:
+			record[i] = ""
+		case string:
+			record[i] = v
+		case map[string]interface{}, []interface{}:
+			record[i], _ = jsoniter.MarshalToString(v)
+		default:
+			record[i] = fmt.Sprintf("%v", v)
+		}
+	}
+	return w.writer.Write(record)
+}
+
+func (w *csvWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// jsonlWriter the JSON Lines writer, one object per row, keyed by the column name
+type jsonlWriter struct {
+	writer *bufio.Writer
+	names  []string
+}
+
+func (w *jsonlWriter) Header(columns []exportColumn) error {
+	w.names = make([]string, len(columns))
+	for i, column := range columns {
+		w.names[i] = column.Name
+	}
+	return nil
+}
+
+func (w *jsonlWriter) Write(values []interface{}) error {
+	stream := jsoniter.ConfigCompatibleWithStandardLibrary.BorrowStream(w.writer)
+	defer jsoniter.ConfigCompatibleWithStandardLibrary.ReturnStream(stream)
+
+	// Keep the column order of the layout
+	stream.WriteObjectStart()
+	for i, name := range w.names {
+		if i > 0 {
+			stream.WriteMore()
+		}
+		stream.WriteObjectField(name)
+		stream.WriteVal(values[i])
+	}
+	stream.WriteObjectEnd()
+	stream.WriteRaw("\n")
+	if stream.Error != nil {
+		return stream.Error
+	}
+	return stream.Flush()
+}
+
+func (w *jsonlWriter) Close() error {
+	return w.writer.Flush()
+}
+
+// xlsxWriter the Excel writer, rows are written with the excelize stream writer
+type xlsxWriter struct {
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	output io.Writer
+	line   int
+}
+
+func (w *xlsxWriter) Header(columns []exportColumn) error {
+	header := make([]interface{}, len(columns))
+	for i, column := range columns {
+		header[i] = column.Name
+	}
+	return w.Write(header)
+}
+
+func (w *xlsxWriter) Write(values []interface{}) error {
+	for i, value := range values {
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			values[i], _ = jsoniter.MarshalToString(value)
+		}
+	}
+
+	cell, err := excelize.CoordinatesToCellName(1, w.line)
+	if err != nil {
+		return err
+	}
+	w.line++
+	return w.stream.SetRow(cell, values)
+}
+
+func (w *xlsxWriter) Close() error {
+	defer w.file.Close()
+	err := w.stream.Flush()
+	if err != nil {
+		return err
+	}
+	_, err = w.file.WriteTo(w.output)
+	return err
+}
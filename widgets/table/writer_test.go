@@ -0,0 +1,81 @@
+package table
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestExportWriters(t *testing.T) {
+	columns := []exportColumn{
+		{Name: "名称", Field: "name"},
+		{Name: "状态", Field: "status", Options: map[string]string{"checked": "已检查"}},
+		{Name: "更新时间", Field: "updated_at"},
+		{Name: "标签", Field: "tags"},
+	}
+	rows := exportRows([]interface{}{
+		map[string]interface{}{"name": "Cookie", "status": "checked", "updated_at": time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC)},
+		map[string]interface{}{"name": "Max, Jr.", "status": "pending", "tags": []interface{}{"cat"}},
+	})
+
+	write := func(format string) []byte {
+		var buf bytes.Buffer
+		writer, err := newExportWriter(format, &buf, "pet")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Nil(t, writer.Header(columns))
+		for _, row := range rows {
+			assert.Nil(t, writer.Write(exportValues(columns, row)))
+		}
+		assert.Nil(t, writer.Close())
+		return buf.Bytes()
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(write(ExportCSV))), "\n")
+	assert.Equal(t, []string{
+		"名称,状态,更新时间,标签",
+		"Cookie,已检查,2024-05-01 08:30:00,",
+		`"Max, Jr.",pending,,"[""cat""]"`,
+	}, lines)
+
+	lines = strings.Split(strings.TrimSpace(string(write(ExportJSONL))), "\n")
+	assert.Equal(t, []string{
+		`{"名称":"Cookie","状态":"已检查","更新时间":"2024-05-01 08:30:00","标签":null}`,
+		`{"名称":"Max, Jr.","状态":"pending","更新时间":null,"标签":["cat"]}`,
+	}, lines)
+
+	file, err := excelize.OpenReader(bytes.NewReader(write(ExportXLSX)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	data, err := file.GetRows("pet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"名称", "状态", "更新时间"}, data[0][:3])
+	assert.Equal(t, "已检查", data[1][1])
+	assert.Equal(t, `["cat"]`, data[2][3])
+
+	_, err = newExportWriter("pdf", &bytes.Buffer{}, "")
+	assert.NotNil(t, err)
+}
+
+func TestViewOptions(t *testing.T) {
+	options := viewOptions(map[string]interface{}{"options": []interface{}{
+		map[string]interface{}{"label": "启用", "value": 1},
+		map[string]interface{}{"label": "停用", "value": 0},
+	}})
+	assert.Equal(t, map[string]string{"1": "启用", "0": "停用"}, options)
+	assert.Nil(t, viewOptions(map[string]interface{}{"options": "::status"}))
+
+	column := exportColumn{Options: options}
+	assert.Equal(t, "启用", column.format(int64(1)))
+	assert.Equal(t, 2, column.format(2))
+	assert.Nil(t, column.format(nil))
+}